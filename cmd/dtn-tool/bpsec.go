@@ -5,8 +5,10 @@
 package main
 
 import (
+	"fmt"
 	"io"
 	"os"
+	"strconv"
 
 	"github.com/dtn7/dtn7-go/pkg/bpv7"
 	log "github.com/sirupsen/logrus"
@@ -126,21 +128,30 @@ func verifyBundle(args []string) {
 
 }
 
-// encryptBundle for the "sign" CLI option.
+// encryptBundle for the "encrypt" CLI option.
 func encryptBundle(args []string) {
-	if len(args) != 3 {
+	if len(args) < 3 {
 		printUsage()
 	}
 
 	var (
-		input  = args[0]
-		psk    = args[1]
-		output = args[2]
-		err    error
-		f      io.ReadCloser
-		b      bpv7.Bundle
+		input   = args[0]
+		psk     = args[1]
+		output  = args[2]
+		targets []uint64
+		err     error
+		f       io.ReadCloser
+		b       bpv7.Bundle
 	)
 
+	for _, arg := range args[3:] {
+		if target, targetErr := strconv.ParseUint(arg, 10, 64); targetErr != nil {
+			printFatal(targetErr, "Parsing block number erred")
+		} else {
+			targets = append(targets, target)
+		}
+	}
+
 	if input == "-" {
 		f = os.Stdin
 	} else if f, err = os.Open(input); err != nil {
@@ -154,31 +165,16 @@ func encryptBundle(args []string) {
 		printFatal(err, "Closing file erred")
 	}
 
-	payloadSecurityTarget, _ := b.ExtensionBlock(bpv7.ExtBlockTypePayloadBlock)
-
-	aesVariant := bpv7.A256GCM
-
-	bcb := bpv7.NewBCBIOPAESGCM(&aesVariant, nil, nil, payloadSecurityTarget.BlockNumber, b.PrimaryBlock.SourceNode)
-
-	eb := bpv7.CanonicalBlock{
-		BlockNumber:       0,
-		BlockControlFlags: 0,
-		CRCType:           bpv7.CRCNo,
-		CRC:               nil,
-		Value:             bcb,
+	if len(targets) == 0 {
+		payloadSecurityTarget, payloadErr := b.PayloadBlock()
+		if payloadErr != nil {
+			printFatal(payloadErr, "Could not get Payload Block")
+		}
+		targets = append(targets, payloadSecurityTarget.BlockNumber)
 	}
 
-	err = b.AddExtensionBlock(eb)
-	if err != nil {
-		printFatal(err, "Add Extension Block failed")
-
-	}
-
-	bcbBlockAdded, _ := b.ExtensionBlock(bcb.BlockTypeCode())
-
-	err = bcbBlockAdded.Value.(*bpv7.BCBIOPAESGCM).EncryptTarget(b, bcbBlockAdded.BlockNumber, []byte(psk))
-	if err != nil {
-		printFatal(err, "Encrypting Target erred")
+	if err = b.EncryptBlocks(b.PrimaryBlock.SourceNode, []byte(psk), targets...); err != nil {
+		printFatal(err, "Encrypting Targets erred")
 	}
 
 	logger := log.WithFields(log.Fields{
@@ -196,7 +192,7 @@ func encryptBundle(args []string) {
 
 }
 
-// decryptBundle for the "verify" CLI option.
+// decryptBundle for the "decrypt" CLI option.
 func decryptBundle(args []string) {
 	if len(args) != 3 {
 		printUsage()
@@ -225,18 +221,14 @@ func decryptBundle(args []string) {
 		printFatal(err, "Closing file erred")
 	}
 
-	bcbBlock, err := b.ExtensionBlock(bpv7.ExtBlockTypeBlockConfidentialityBlock)
-	if err != nil {
-		printFatal(err, "Could not get BIB Extension Block")
+	if !b.HasExtensionBlock(bpv7.ExtBlockTypeBlockConfidentialityBlock) {
+		printFatal(fmt.Errorf("no BCB found"), "Could not get BCB Extension Block")
 	}
 
-	err = bcbBlock.Value.(*bpv7.BCBIOPAESGCM).DecryptTarget(b, bcbBlock.BlockNumber, []byte(psk))
-	if err != nil {
+	if err = b.DecryptBlocks([]byte(psk)); err != nil {
 		printFatal(err, "Decryption Error")
 	}
 
-	b.RemoveExtensionBlockByBlockNumber(bcbBlock.BlockNumber)
-
 	logger := log.WithFields(log.Fields{
 		"bundle": b.ID(),
 		"file":   output,
@@ -6,18 +6,85 @@ package main
 
 import (
 	"encoding/hex"
+	"flag"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/dtn7/dtn7-go/pkg/bpv7"
 )
 
+// metadataFlag collects repeated "key=value" flags for a MetadataBlock.
+type metadataFlag map[string]string
+
+func (mf metadataFlag) String() string {
+	return fmt.Sprint(map[string]string(mf))
+}
+
+func (mf metadataFlag) Set(value string) error {
+	key, val, ok := strings.Cut(value, "=")
+	if !ok || key == "" {
+		return fmt.Errorf("%q is no key=value pair", value)
+	}
+	mf[key] = val
+	return nil
+}
+
+// blockFlag collects repeated "type:hex" flags for generic extension blocks of a custom type code.
+type blockFlag []*bpv7.GenericExtensionBlock
+
+func (bf *blockFlag) String() string {
+	return fmt.Sprint(len(*bf), " blocks")
+}
+
+func (bf *blockFlag) Set(value string) error {
+	typeStr, dataStr, ok := strings.Cut(value, ":")
+	if !ok {
+		return fmt.Errorf("%q is no type:hex pair", value)
+	}
+
+	typeCode, err := strconv.ParseUint(typeStr, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid block type code %q: %v", typeStr, err)
+	}
+	if typeCode == bpv7.ExtBlockTypePayloadBlock {
+		return fmt.Errorf("the payload block cannot be set by a custom block")
+	}
+
+	data, err := hex.DecodeString(dataStr)
+	if err != nil {
+		return fmt.Errorf("invalid block data %q: %v", dataStr, err)
+	}
+
+	*bf = append(*bf, bpv7.NewGenericExtensionBlock(data, typeCode))
+	return nil
+}
+
 // createBundle for the "create" CLI option.
 func createBundle(args []string) {
+	metadata := make(metadataFlag)
+	var blocks blockFlag
+
+	flags := flag.NewFlagSet("create", flag.ExitOnError)
+	hopLimit := flags.Uint("hop-limit", 64, "limit of the hop count block, zero omits the block")
+	age := flags.Duration("age", -1, "initial age of a bundle age block, omitted if negative")
+	lifetime := flags.Duration("lifetime", 24*time.Hour, "lifetime of the bundle")
+	flags.Var(metadata, "metadata", "key=value pair of a metadata block, may be repeated")
+	flags.Var(&blocks, "block", "custom block as type:hex, e.g., 240:cafe, may be repeated")
+	flags.Usage = printUsage
+	_ = flags.Parse(args)
+	args = flags.Args()
+
 	if len(args) != 3 && len(args) != 4 {
 		printUsage()
 	}
+	if *hopLimit > 255 {
+		printFatal(fmt.Errorf("hop limit %d exceeds 255", *hopLimit), "Parsing flags erred")
+	}
 
 	var (
 		sender    = args[0]
@@ -40,15 +107,26 @@ func createBundle(args []string) {
 		printFatal(err, "Reading input erred")
 	}
 
-	b, err = bpv7.Builder().
+	bldr := bpv7.Builder().
 		CRC(bpv7.CRC32).
 		Source(sender).
 		Destination(receiver).
 		CreationTimestampNow().
-		Lifetime("24h").
-		HopCountBlock(64).
-		PayloadBlock(data).
-		Build()
+		Lifetime(*lifetime)
+	if *hopLimit > 0 {
+		bldr.HopCountBlock(int(*hopLimit))
+	}
+	if *age >= 0 {
+		bldr.BundleAgeBlock(*age)
+	}
+	if len(metadata) > 0 {
+		bldr.MetadataBlock(metadata)
+	}
+	for _, block := range blocks {
+		bldr.Canonical(block, bpv7.BlockControlFlags(0))
+	}
+
+	b, err = bldr.PayloadBlock(data).Build()
 	if err != nil {
 		printFatal(err, "Building Bundle erred")
 	}
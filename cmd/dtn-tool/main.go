@@ -11,14 +11,16 @@ import (
 
 // printUsage of dtn-tool and exit with an error code afterwards.
 func printUsage() {
-	_, _ = fmt.Fprintf(os.Stderr, "Usage of %s create|exchange|sign|verify|encrypt|decrypt|ping|show:\n\n", os.Args[0])
+	_, _ = fmt.Fprintf(os.Stderr, "Usage of %s create|exchange|sign|verify|encrypt|decrypt|ping|show|store:\n\n", os.Args[0])
 
-	_, _ = fmt.Fprintf(os.Stderr, "%s create sender receiver -|filename [-|filename]\n", os.Args[0])
+	_, _ = fmt.Fprintf(os.Stderr, "%s create [-hop-limit n] [-age duration] [-lifetime duration] [-metadata key=value]... [-block type:hex]... sender receiver -|filename [-|filename]\n", os.Args[0])
 	_, _ = fmt.Fprintf(os.Stderr, "  Creates a new Bundle, addressed from sender to receiver with the stdin (-)\n")
 	_, _ = fmt.Fprintf(os.Stderr, "  or the given file (filename) as payload. If no further specified, the\n")
 	_, _ = fmt.Fprintf(os.Stderr, "  Bundle is stored locally named after the hex representation of its ID.\n")
 	_, _ = fmt.Fprintf(os.Stderr, "  Otherwise, the Bundle can be written to the stdout (-) or saved\n")
-	_, _ = fmt.Fprintf(os.Stderr, "  according to a freely selectable filename.\n\n")
+	_, _ = fmt.Fprintf(os.Stderr, "  according to a freely selectable filename. The flags attach a hop count\n")
+	_, _ = fmt.Fprintf(os.Stderr, "  block, limited to 64 by default, a bundle age block, a metadata block, and\n")
+	_, _ = fmt.Fprintf(os.Stderr, "  custom blocks of a type code and hex-encoded data.\n\n")
 
 	_, _ = fmt.Fprintf(os.Stderr, "%s exchange websocket endpoint-id directory\n", os.Args[0])
 	_, _ = fmt.Fprintf(os.Stderr, "  %s registeres itself as an agent on the given websocket and writes\n", os.Args[0])
@@ -31,8 +33,9 @@ func printUsage() {
 	_, _ = fmt.Fprintf(os.Stderr, "%s verify bundle key\n", os.Args[0])
 	_, _ = fmt.Fprintf(os.Stderr, "  verifies the signature of a bundle against the given key.\n")
 
-	_, _ = fmt.Fprintf(os.Stderr, "%s encrypt bundle key filename\n", os.Args[0])
-	_, _ = fmt.Fprintf(os.Stderr, "  encrypts a bundle with the given key and writes the encrypted bundle to the given filename.\n")
+	_, _ = fmt.Fprintf(os.Stderr, "%s encrypt bundle key filename [block-number...]\n", os.Args[0])
+	_, _ = fmt.Fprintf(os.Stderr, "  encrypts a bundle's payload, or the blocks of the given block numbers, with the given\n")
+	_, _ = fmt.Fprintf(os.Stderr, "  16 or 32 byte key and writes the encrypted bundle to the given filename.\n")
 
 	_, _ = fmt.Fprintf(os.Stderr, "%s decrypt bundle key filename\n", os.Args[0])
 	_, _ = fmt.Fprintf(os.Stderr, "  decrypts a bundle with the given key and writes the decrypted bundle to the given filename.\n")
@@ -43,6 +46,19 @@ func printUsage() {
 	_, _ = fmt.Fprintf(os.Stderr, "%s show -|filename\n", os.Args[0])
 	_, _ = fmt.Fprintf(os.Stderr, "  Prints a JSON version of a Bundle, read from stdin (-) or filename.\n\n")
 
+	_, _ = fmt.Fprintf(os.Stderr, "%s store list directory [-source prefix] [-destination prefix] [-pending] [-expired]\n", os.Args[0])
+	_, _ = fmt.Fprintf(os.Stderr, "  Lists the bundles of a store directory, optionally filtered. The store must\n")
+	_, _ = fmt.Fprintf(os.Stderr, "  not be used by a running dtnd, as for all store commands.\n\n")
+
+	_, _ = fmt.Fprintf(os.Stderr, "%s store show directory bundle-id\n", os.Args[0])
+	_, _ = fmt.Fprintf(os.Stderr, "  Prints a JSON version of a stored Bundle with all its blocks and meta data.\n\n")
+
+	_, _ = fmt.Fprintf(os.Stderr, "%s store delete directory bundle-id...\n", os.Args[0])
+	_, _ = fmt.Fprintf(os.Stderr, "  Deletes stored Bundles by their IDs, as listed by store list.\n\n")
+
+	_, _ = fmt.Fprintf(os.Stderr, "%s store export directory bundle-id -|filename\n", os.Args[0])
+	_, _ = fmt.Fprintf(os.Stderr, "  Writes a stored Bundle to the stdout (-) or the given file.\n\n")
+
 	os.Exit(1)
 }
 
@@ -82,6 +98,9 @@ func main() {
 	case "show":
 		showBundle(os.Args[2:])
 
+	case "store":
+		inspectStore(os.Args[2:])
+
 	default:
 		printUsage()
 	}
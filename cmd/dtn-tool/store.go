@@ -0,0 +1,205 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	// The routing package registers the types of the stored bundles' properties.
+	_ "github.com/dtn7/dtn7-go/pkg/routing"
+	"github.com/dtn7/dtn7-go/pkg/storage"
+)
+
+// openStore opens an offline store directory, which must not be used by a running dtnd.
+func openStore(dir string) *storage.Store {
+	// Both the store and its database log verbosely.
+	log.SetLevel(log.WarnLevel)
+
+	if _, err := os.Stat(dir); err != nil {
+		printFatal(err, "Opening store erred")
+	}
+
+	s, err := storage.NewStore(dir)
+	if err != nil {
+		printFatal(err, "Opening store erred, is dtnd still running?")
+	}
+	return s
+}
+
+// queryStoreItems fetches the BundleItems of the given IDs, as listed by "store list".
+func queryStoreItems(s *storage.Store, ids []string) []storage.BundleItem {
+	bis, err := s.QueryAll()
+	if err != nil {
+		printFatal(err, "Querying store erred")
+	}
+
+	items := make([]storage.BundleItem, 0, len(ids))
+	for _, id := range ids {
+		found := false
+		for _, bi := range bis {
+			if bi.Id == id {
+				items = append(items, bi)
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			printFatal(fmt.Errorf("no stored bundle %s", id), "Querying store erred")
+		}
+	}
+	return items
+}
+
+// storeList prints the stored bundles, optionally filtered.
+func storeList(s *storage.Store, args []string) {
+	flags := flag.NewFlagSet("store list", flag.ExitOnError)
+	source := flags.String("source", "", "only list bundles whose source starts with this prefix")
+	destination := flags.String("destination", "", "only list bundles whose destination starts with this prefix")
+	pending := flags.Bool("pending", false, "only list pending bundles")
+	expired := flags.Bool("expired", false, "only list expired bundles")
+	_ = flags.Parse(args)
+
+	bis, err := s.QueryAll()
+	if err != nil {
+		printFatal(err, "Querying store erred")
+	}
+	sort.Slice(bis, func(i, j int) bool { return bis[i].Id < bis[j].Id })
+
+	now := time.Now()
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "ID\tDESTINATION\tSIZE\tPENDING\tEXPIRES\tFRAGMENTED")
+	for _, bi := range bis {
+		switch {
+		case !strings.HasPrefix(bi.BId.SourceNode.String(), *source):
+		case !strings.HasPrefix(bi.Destination, *destination):
+		case *pending && !bi.Pending:
+		case *expired && bi.Expires.After(now):
+
+		default:
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%d\t%t\t%s\t%t\n",
+				bi.Id, bi.Destination, bi.Size, bi.Pending, bi.Expires.Format(time.RFC3339), bi.Fragmented)
+		}
+	}
+	_ = w.Flush()
+}
+
+// storeShow prints a stored bundle's meta data and all its decoded blocks as JSON.
+func storeShow(s *storage.Store, args []string) {
+	if len(args) != 1 {
+		printUsage()
+	}
+
+	bi := queryStoreItems(s, args)[0]
+
+	b, err := bi.Load()
+	if err != nil {
+		printFatal(err, "Loading bundle erred")
+	}
+	bMsg, err := b.MarshalJSON()
+	if err != nil {
+		printFatal(err, "Marshaling JSON erred")
+	}
+
+	parts := make([]string, 0, len(bi.Parts))
+	for _, part := range bi.Parts {
+		parts = append(parts, part.Filename)
+	}
+
+	msg, err := json.MarshalIndent(struct {
+		Id         string                 `json:"id"`
+		Pending    bool                   `json:"pending"`
+		Expires    time.Time              `json:"expires"`
+		Size       uint64                 `json:"size"`
+		Fragmented bool                   `json:"fragmented"`
+		Parts      []string               `json:"parts"`
+		Properties map[string]interface{} `json:"properties,omitempty"`
+		Bundle     json.RawMessage        `json:"bundle"`
+	}{bi.Id, bi.Pending, bi.Expires, bi.Size, bi.Fragmented, parts, bi.Properties, bMsg}, "", "  ")
+	if err != nil {
+		printFatal(err, "Marshaling JSON erred")
+	}
+	fmt.Println(string(msg))
+}
+
+// storeDelete deletes stored bundles.
+func storeDelete(s *storage.Store, args []string) {
+	if len(args) == 0 {
+		printUsage()
+	}
+
+	for _, bi := range queryStoreItems(s, args) {
+		if err := s.Delete(bi.BId); err != nil {
+			printFatal(err, "Deleting bundle erred")
+		}
+		fmt.Printf("Deleted %s\n", bi.Id)
+	}
+}
+
+// storeExport writes a stored bundle, reassembled from its fragments, to the stdout (-) or a file.
+func storeExport(s *storage.Store, args []string) {
+	if len(args) != 2 {
+		printUsage()
+	}
+
+	bi := queryStoreItems(s, args[:1])[0]
+
+	b, err := bi.Load()
+	if err != nil {
+		printFatal(err, "Loading bundle erred")
+	}
+
+	var f io.WriteCloser
+	if args[1] == "-" {
+		f = os.Stdout
+	} else if f, err = os.Create(args[1]); err != nil {
+		printFatal(err, "Creating file erred")
+	}
+
+	if err = b.MarshalCbor(f); err != nil {
+		printFatal(err, "Writing Bundle erred")
+	}
+	if err = f.Close(); err != nil {
+		printFatal(err, "Closing file erred")
+	}
+}
+
+// inspectStore for the "store" CLI options, operating on an offline store directory.
+func inspectStore(args []string) {
+	if len(args) < 2 {
+		printUsage()
+	}
+
+	commands := map[string]func(*storage.Store, []string){
+		"list":   storeList,
+		"show":   storeShow,
+		"delete": storeDelete,
+		"export": storeExport,
+	}
+	command, ok := commands[args[0]]
+	if !ok {
+		printUsage()
+	}
+
+	s := openStore(args[1])
+	defer func() {
+		if err := s.Close(); err != nil {
+			printFatal(err, "Closing store erred")
+		}
+	}()
+
+	command(s, args[2:])
+}
@@ -0,0 +1,194 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+// dtnamp is an AMP manager over dtnd's WebSocket API. It sends controls to an AMP agent, e.g., dtnd's "amp" agent,
+// and prints the received reports. In listen mode, it prints all agents' registrations and reports.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/dtn7/cboring"
+
+	"github.com/dtn7/dtn7-go/pkg/agent"
+	"github.com/dtn7/dtn7-go/pkg/amp"
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+// setFlags collects each "-set ari=value" flag as a set_var control.
+type setFlags []amp.Control
+
+func (sf *setFlags) String() string {
+	return fmt.Sprintf("%v", *sf)
+}
+
+func (sf *setFlags) Set(s string) error {
+	ari, value, ok := strings.Cut(s, "=")
+	if !ok {
+		return fmt.Errorf("expected ari=value")
+	} else if _, err := amp.ParseARI(ari); err != nil {
+		return err
+	}
+
+	*sf = append(*sf, amp.Control{ARI: amp.SetVariable, Parameters: []amp.Value{ari, amp.ParseValue(value)}})
+	return nil
+}
+
+// printUsage of dtnamp and exit with an error code afterwards.
+func printUsage() {
+	_, _ = fmt.Fprintf(os.Stderr, "Usage of %s [flags] websocket manager agent [ari...]\n", os.Args[0])
+	_, _ = fmt.Fprintf(os.Stderr, "      or %s -listen websocket manager:\n\n", os.Args[0])
+
+	_, _ = fmt.Fprintf(os.Stderr, "  Sends the -set controls to the agent and prints the reports of the ARIs.\n")
+	_, _ = fmt.Fprintf(os.Stderr, "  In listen mode, all received registrations and reports are printed.\n\n")
+
+	_, _ = fmt.Fprintf(os.Stderr, "  %s ws://localhost:8080/ws dtn://ctrl/amp dtn://node/amp ari:/dtn7/EDD.bundles_received\n", os.Args[0])
+	_, _ = fmt.Fprintf(os.Stderr, "  %s -set ari:/dtn7/VAR.log_level=debug ws://localhost:8080/ws dtn://ctrl/amp dtn://node/amp\n\n", os.Args[0])
+
+	flag.PrintDefaults()
+	os.Exit(1)
+}
+
+// printFatal of an error with a short context description and exits afterwards.
+func printFatal(err error, msg string) {
+	_, _ = fmt.Fprintf(os.Stderr, "%s erred: %s\n  %v\n", os.Args[0], msg, err)
+	os.Exit(1)
+}
+
+// printMessage received from an agent.
+func printMessage(source bpv7.EndpointID, msg amp.Message) {
+	switch msg := msg.(type) {
+	case *amp.RegisterAgent:
+		fmt.Printf("%v registered agent %v\n", source, msg.Agent)
+
+	case *amp.ReportSet:
+		for _, rpt := range msg.Reports {
+			if rpt.Value == nil {
+				fmt.Printf("%v %v: unknown\n", source, rpt.ARI)
+			} else {
+				fmt.Printf("%v %v = %v\n", source, rpt.ARI, rpt.Value)
+			}
+		}
+	}
+}
+
+// readMessages of the next received bundle, which must be a MessageGroup.
+func readMessages(conn *agent.WebSocketAgentConnector) (bpv7.EndpointID, []amp.Message) {
+	b, err := conn.ReadBundle()
+	if err != nil {
+		printFatal(err, "receiving bundle")
+	}
+
+	payload, err := b.PayloadData()
+	if err != nil {
+		printFatal(err, "reading payload")
+	}
+
+	var mg amp.MessageGroup
+	if err := cboring.Unmarshal(&mg, bytes.NewReader(payload)); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "%s: bundle %v contains no message group: %v\n", os.Args[0], b.ID(), err)
+		return b.PrimaryBlock.SourceNode, nil
+	}
+	return b.PrimaryBlock.SourceNode, mg.Messages
+}
+
+func main() {
+	var sets setFlags
+	flag.Var(&sets, "set", "set a variable to a value, \"ari=value\", might be repeated")
+	listen := flag.Bool("listen", false, "print all received registrations and reports")
+	start := flag.Uint64("start", 0, "delay in seconds before the agent performs the controls")
+	lifetime := flag.Duration("lifetime", 24*time.Hour, "lifetime of the control bundle")
+	timeout := flag.Duration("timeout", time.Minute, "time to wait for the reports, zero waits forever")
+	flag.Usage = printUsage
+	flag.Parse()
+
+	if (*listen && flag.NArg() != 2) || (!*listen && flag.NArg() < 3) {
+		printUsage()
+	}
+	websocket, manager := flag.Arg(0), flag.Arg(1)
+
+	conn, err := agent.NewWebSocketAgentConnector(websocket, manager)
+	if err != nil {
+		printFatal(err, "connecting to websocket")
+	}
+	defer conn.Close()
+
+	if *listen {
+		for {
+			source, msgs := readMessages(conn)
+			for _, msg := range msgs {
+				printMessage(source, msg)
+			}
+		}
+	}
+
+	agentEid, err := bpv7.NewEndpointID(flag.Arg(2))
+	if err != nil {
+		printFatal(err, "parsing agent")
+	}
+
+	controls := []amp.Control(sets)
+	if flag.NArg() > 3 {
+		report := amp.Control{ARI: amp.GenReports}
+		for _, s := range flag.Args()[3:] {
+			if _, err := amp.ParseARI(s); err != nil {
+				printFatal(err, "parsing ARI")
+			}
+			report.Parameters = append(report.Parameters, s)
+		}
+		controls = append(controls, report)
+	}
+	if len(controls) == 0 {
+		printUsage()
+	}
+
+	buff := new(bytes.Buffer)
+	if err := cboring.Marshal(amp.NewMessageGroup(&amp.PerformControl{Start: *start, Controls: controls}), buff); err != nil {
+		printFatal(err, "creating message group")
+	}
+
+	b, err := bpv7.Builder().
+		Source(manager).
+		Destination(agentEid).
+		CreationTimestampNow().
+		Lifetime(*lifetime).
+		HopCountBlock(64).
+		PayloadBlock(buff.Bytes()).
+		Build()
+	if err != nil {
+		printFatal(err, "creating bundle")
+	}
+	if err := conn.WriteBundle(b); err != nil {
+		printFatal(err, "sending bundle")
+	}
+
+	// Only the gen_rpts control results in a report.
+	if flag.NArg() == 3 {
+		return
+	}
+
+	if *timeout > 0 {
+		time.AfterFunc(*timeout, func() {
+			printFatal(fmt.Errorf("no report arrived within %v", *timeout), "waiting for reports")
+		})
+	}
+	for {
+		source, msgs := readMessages(conn)
+		if source != agentEid {
+			continue
+		}
+
+		for _, msg := range msgs {
+			if _, ok := msg.(*amp.ReportSet); ok {
+				printMessage(source, msg)
+				return
+			}
+		}
+	}
+}
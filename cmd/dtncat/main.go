@@ -0,0 +1,169 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+// dtncat connects shell pipelines over DTN through dtnd's WebSocket API, like netcat. It either sends its stdin as a
+// bundle's payload or prints the payloads of bundles received for an endpoint to its stdout.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/agent"
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+// catConf is dtncat's configuration, parsed from its arguments.
+type catConf struct {
+	listen   bool
+	count    uint64
+	source   string
+	lifetime time.Duration
+
+	websocket string
+	// endpoint is the receiver of the sent bundle or the endpoint to listen on.
+	endpoint string
+}
+
+// newFlagSet of dtncat's flags, parsed into the configuration.
+func newFlagSet(conf *catConf) *flag.FlagSet {
+	flags := flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+	flags.BoolVar(&conf.listen, "l", false, "listen on an endpoint instead of sending")
+	flags.Uint64Var(&conf.count, "n", 0, "exit after receiving this many bundles in listen mode, zero listens forever")
+	flags.StringVar(&conf.source, "source", bpv7.DtnNone().String(), "source of the sent bundle, an endpoint of the dtnd or dtn:none")
+	flags.DurationVar(&conf.lifetime, "lifetime", 24*time.Hour, "lifetime of the sent bundle")
+	return flags
+}
+
+// parseArgs into a catConf. The endpoint and the source are checked to be valid endpoint IDs.
+func parseArgs(args []string) (conf catConf, err error) {
+	flags := newFlagSet(&conf)
+	flags.SetOutput(io.Discard)
+	if err = flags.Parse(args); err != nil {
+		return
+	}
+
+	if flags.NArg() != 2 {
+		err = fmt.Errorf("expected a websocket and an endpoint, got %d arguments", flags.NArg())
+		return
+	}
+	conf.websocket, conf.endpoint = flags.Arg(0), flags.Arg(1)
+
+	if _, err = bpv7.NewEndpointID(conf.endpoint); err != nil {
+		err = fmt.Errorf("parsing endpoint: %v", err)
+		return
+	}
+	if _, err = bpv7.NewEndpointID(conf.source); err != nil {
+		err = fmt.Errorf("parsing source: %v", err)
+		return
+	}
+	return
+}
+
+// printUsage of dtncat and exit with an error code afterwards.
+func printUsage() {
+	_, _ = fmt.Fprintf(os.Stderr, "Usage of %s [flags] websocket receiver | -l [flags] websocket endpoint:\n\n", os.Args[0])
+
+	_, _ = fmt.Fprintf(os.Stderr, "%s [-source endpoint] [-lifetime duration] websocket receiver\n", os.Args[0])
+	_, _ = fmt.Fprintf(os.Stderr, "  Sends the stdin as a bundle's payload to the receiver.\n\n")
+
+	_, _ = fmt.Fprintf(os.Stderr, "%s -l [-n count] websocket endpoint\n", os.Args[0])
+	_, _ = fmt.Fprintf(os.Stderr, "  Registers the endpoint and writes the payloads of received bundles to the\n")
+	_, _ = fmt.Fprintf(os.Stderr, "  stdout, until count bundles were received or forever.\n\n")
+
+	_, _ = fmt.Fprintf(os.Stderr, "  echo hello | %s ws://localhost:8080/ws dtn://bar/cat\n", os.Args[0])
+	_, _ = fmt.Fprintf(os.Stderr, "  %s -l -n 1 ws://localhost:8080/ws dtn://bar/cat > hello.txt\n\n", os.Args[0])
+
+	newFlagSet(new(catConf)).PrintDefaults()
+	os.Exit(1)
+}
+
+// printFatal of an error with a short context description and exits afterwards.
+func printFatal(err error, msg string) {
+	_, _ = fmt.Fprintf(os.Stderr, "%s erred: %s\n  %v\n", os.Args[0], msg, err)
+	os.Exit(1)
+}
+
+// send the input as a bundle's payload to the receiver, streamed over the WebSocket.
+func send(conf catConf, input io.Reader) error {
+	sourceEid, err := bpv7.NewEndpointID(conf.source)
+	if err != nil {
+		return fmt.Errorf("parsing source: %v", err)
+	}
+
+	// Anonymous bundles must not be fragmented.
+	var flags bpv7.BundleControlFlags
+	if sourceEid == bpv7.DtnNone() {
+		flags = bpv7.MustNotFragmented
+	}
+
+	b, err := bpv7.Builder().
+		CRC(bpv7.CRC32).
+		Source(sourceEid).
+		Destination(conf.endpoint).
+		BundleCtrlFlags(flags).
+		CreationTimestampNow().
+		Lifetime(conf.lifetime).
+		HopCountBlock(64).
+		PayloadBlock([]byte{}).
+		Build()
+	if err != nil {
+		return fmt.Errorf("creating bundle: %v", err)
+	}
+
+	conn, err := agent.NewAnonymousWebSocketAgentConnector(conf.websocket)
+	if err != nil {
+		return fmt.Errorf("connecting to websocket: %v", err)
+	}
+	defer conn.Close()
+
+	if err := conn.WriteBundleStream(b, input); err != nil {
+		return fmt.Errorf("sending bundle: %v", err)
+	}
+	return nil
+}
+
+// listen on an endpoint and write the received bundles' payloads to the output. Zero count listens forever.
+func listen(conf catConf, output io.Writer) error {
+	conn, err := agent.NewWebSocketAgentConnector(conf.websocket, conf.endpoint)
+	if err != nil {
+		return fmt.Errorf("connecting to websocket: %v", err)
+	}
+	defer conn.Close()
+
+	for i := uint64(0); conf.count == 0 || i < conf.count; i++ {
+		_, payload, err := conn.ReadBundleStream()
+		if err != nil {
+			return fmt.Errorf("receiving bundle: %v", err)
+		}
+
+		_, err = io.Copy(output, payload)
+		_ = payload.Close()
+		if err != nil {
+			return fmt.Errorf("writing payload: %v", err)
+		}
+	}
+	return nil
+}
+
+func main() {
+	conf, err := parseArgs(os.Args[1:])
+	if err == flag.ErrHelp {
+		printUsage()
+	} else if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "%s: %v\n\n", os.Args[0], err)
+		printUsage()
+	}
+
+	if conf.listen {
+		if err := listen(conf, os.Stdout); err != nil {
+			printFatal(err, "listening")
+		}
+	} else if err := send(conf, os.Stdin); err != nil {
+		printFatal(err, "sending")
+	}
+}
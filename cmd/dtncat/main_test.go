@@ -0,0 +1,133 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/agent"
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+func TestParseArgs(t *testing.T) {
+	tests := []struct {
+		args     []string
+		expected catConf
+		valid    bool
+	}{
+		{[]string{"ws://localhost/ws", "dtn://bar/cat"},
+			catConf{source: "dtn:none", lifetime: 24 * time.Hour, websocket: "ws://localhost/ws", endpoint: "dtn://bar/cat"}, true},
+		{[]string{"-source", "dtn://foo/cat", "-lifetime", "1h", "ws://localhost/ws", "dtn://bar/cat"},
+			catConf{source: "dtn://foo/cat", lifetime: time.Hour, websocket: "ws://localhost/ws", endpoint: "dtn://bar/cat"}, true},
+		{[]string{"-l", "-n", "3", "ws://localhost/ws", "dtn://bar/cat"},
+			catConf{listen: true, count: 3, source: "dtn:none", lifetime: 24 * time.Hour, websocket: "ws://localhost/ws", endpoint: "dtn://bar/cat"}, true},
+		{[]string{}, catConf{}, false},
+		{[]string{"ws://localhost/ws"}, catConf{}, false},
+		{[]string{"ws://localhost/ws", "dtn://bar/cat", "surplus"}, catConf{}, false},
+		{[]string{"ws://localhost/ws", "no endpoint"}, catConf{}, false},
+		{[]string{"-source", "no endpoint", "ws://localhost/ws", "dtn://bar/cat"}, catConf{}, false},
+		{[]string{"-unknown", "ws://localhost/ws", "dtn://bar/cat"}, catConf{}, false},
+		{[]string{"-n", "-1", "ws://localhost/ws", "dtn://bar/cat"}, catConf{}, false},
+	}
+
+	for _, test := range tests {
+		t.Run(strings.Join(test.args, " "), func(t *testing.T) {
+			conf, err := parseArgs(test.args)
+			if (err == nil) != test.valid {
+				t.Fatalf("expected valid %t, got error %v", test.valid, err)
+			} else if test.valid && conf != test.expected {
+				t.Fatalf("expected %+v, got %+v", test.expected, conf)
+			}
+		})
+	}
+}
+
+// startWebSocketAgent serves a new WebSocketAgent, acting as dtnd, and returns its URL.
+func startWebSocketAgent(t *testing.T) (ws *agent.WebSocketAgent, wsUrl string) {
+	ws = agent.NewWebSocketAgent()
+
+	server := httptest.NewServer(http.HandlerFunc(ws.ServeHTTP))
+	t.Cleanup(func() {
+		ws.MessageReceiver() <- agent.ShutdownMessage{}
+		server.Close()
+	})
+
+	return ws, "ws" + strings.TrimPrefix(server.URL, "http")
+}
+
+func TestSend(t *testing.T) {
+	ws, wsUrl := startWebSocketAgent(t)
+
+	conf := catConf{source: "dtn:none", lifetime: time.Hour, websocket: wsUrl, endpoint: "dtn://bar/cat"}
+	if err := send(conf, strings.NewReader("hello world")); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case msg := <-ws.MessageSender():
+		b := msg.(agent.BundleMessage).Bundle
+		if dst := b.PrimaryBlock.Destination; dst != bpv7.MustNewEndpointID("dtn://bar/cat") {
+			t.Fatalf("expected destination dtn://bar/cat, got %v", dst)
+		} else if !b.PrimaryBlock.BundleControlFlags.Has(bpv7.MustNotFragmented) {
+			t.Fatal("anonymous bundle might be fragmented")
+		} else if data, err := b.PayloadData(); err != nil {
+			t.Fatal(err)
+		} else if string(data) != "hello world" {
+			t.Fatalf("expected payload %q, got %q", "hello world", data)
+		}
+
+	case <-time.After(5 * time.Second):
+		t.Fatal("no bundle was sent")
+	}
+}
+
+func TestListen(t *testing.T) {
+	ws, wsUrl := startWebSocketAgent(t)
+	endpoint := bpv7.MustNewEndpointID("dtn://bar/cat")
+
+	var output bytes.Buffer
+	listened := make(chan error)
+	go func() {
+		listened <- listen(catConf{count: 2, websocket: wsUrl, endpoint: endpoint.String()}, &output)
+	}()
+
+	for deadline := time.Now().Add(5 * time.Second); len(ws.Endpoints()) == 0; {
+		if time.Now().After(deadline) {
+			t.Fatal("endpoint was not registered")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	for _, payload := range []string{"hello ", "world"} {
+		b, err := bpv7.Builder().
+			Source("dtn://foo/cat").
+			Destination(endpoint).
+			CreationTimestampNow().
+			Lifetime("10m").
+			PayloadBlock([]byte(payload)).
+			Build()
+		if err != nil {
+			t.Fatal(err)
+		}
+		ws.MessageReceiver() <- agent.BundleMessage{Bundle: b}
+	}
+
+	select {
+	case err := <-listened:
+		if err != nil {
+			t.Fatal(err)
+		} else if output.String() != "hello world" {
+			t.Fatalf("expected output %q, got %q", "hello world", output.String())
+		}
+
+	case <-time.After(5 * time.Second):
+		t.Fatal("listening did not end after two bundles")
+	}
+}
@@ -0,0 +1,100 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+// dtnctl controls a running dtnd over its control socket, as configured in dtnd's [control] section.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/dtn7/dtn7-go/pkg/control"
+)
+
+// bundleResponse mirrors dtnd's result of the "bundles" command.
+type bundleResponse struct {
+	Id         string `json:"id"`
+	Pending    bool   `json:"pending"`
+	Expires    string `json:"expires"`
+	Fragmented bool   `json:"fragmented"`
+}
+
+// printUsage of dtnctl and exit with an error code afterwards.
+func printUsage() {
+	_, _ = fmt.Fprintf(os.Stderr, "Usage of %s [-socket path] status|bundles|delete|add-peer|log-level:\n\n", os.Args[0])
+
+	_, _ = fmt.Fprintf(os.Stderr, "%s status\n", os.Args[0])
+	_, _ = fmt.Fprintf(os.Stderr, "  Prints the peers, store, active transfers, and routing table.\n\n")
+
+	_, _ = fmt.Fprintf(os.Stderr, "%s bundles\n", os.Args[0])
+	_, _ = fmt.Fprintf(os.Stderr, "  Lists all stored bundles.\n\n")
+
+	_, _ = fmt.Fprintf(os.Stderr, "%s delete bundle-id...\n", os.Args[0])
+	_, _ = fmt.Fprintf(os.Stderr, "  Deletes stored bundles by their IDs, as listed by bundles.\n\n")
+
+	_, _ = fmt.Fprintf(os.Stderr, "%s add-peer mtcp|stcp|tcpclv4|tcpclv4-ws|quicl endpoint [node-id]\n", os.Args[0])
+	_, _ = fmt.Fprintf(os.Stderr, "  Connects to a peer until dtnd's restart. MTCP and STCP peers require a node ID.\n\n")
+
+	_, _ = fmt.Fprintf(os.Stderr, "%s log-level level\n", os.Args[0])
+	_, _ = fmt.Fprintf(os.Stderr, "  Sets the log level, e.g., debug, until dtnd's reload or restart.\n\n")
+
+	flag.PrintDefaults()
+	os.Exit(1)
+}
+
+// printFatal of an error with a short context description and exits afterwards.
+func printFatal(err error, msg string) {
+	_, _ = fmt.Fprintf(os.Stderr, "%s erred: %s\n  %v\n", os.Args[0], msg, err)
+	os.Exit(1)
+}
+
+func main() {
+	socket := flag.String("socket", "/run/dtnd/control.sock", "path of dtnd's control socket")
+	flag.Usage = printUsage
+	flag.Parse()
+
+	if flag.NArg() < 1 {
+		printUsage()
+	}
+
+	command, args := flag.Arg(0), flag.Args()[1:]
+	switch command {
+	case "status", "bundles", "delete", "add-peer", "log-level":
+	default:
+		printUsage()
+	}
+
+	result, err := control.Call(*socket, command, args...)
+	if err != nil {
+		printFatal(err, command)
+	}
+
+	switch command {
+	case "status":
+		var status string
+		if err := json.Unmarshal(result, &status); err != nil {
+			printFatal(err, "parsing status")
+		}
+		fmt.Print(status)
+
+	case "bundles":
+		var bundles []bundleResponse
+		if err := json.Unmarshal(result, &bundles); err != nil {
+			printFatal(err, "parsing bundles")
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		_, _ = fmt.Fprintln(w, "ID\tPENDING\tEXPIRES\tFRAGMENTED")
+		for _, b := range bundles {
+			_, _ = fmt.Fprintf(w, "%s\t%t\t%s\t%t\n", b.Id, b.Pending, b.Expires, b.Fragmented)
+		}
+		_ = w.Flush()
+
+	default:
+		fmt.Println(string(result))
+	}
+}
@@ -0,0 +1,69 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package main
+
+import (
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/dtn7/dtn7-go/pkg/amp"
+	"github.com/dtn7/dtn7-go/pkg/routing"
+)
+
+// ampNamespace of dtnd's AMP objects, e.g., "ari:/dtn7/EDD.bundles_received".
+const ampNamespace = "dtn7"
+
+// ampEdds are the Core's metrics as AMP EDDs.
+func ampEdds(c *routing.Core) map[amp.ARI]func() amp.Value {
+	metric := func(f func(routing.Metrics) uint64) func() amp.Value {
+		return func() amp.Value { return f(c.Metrics()) }
+	}
+
+	edds := map[string]func() amp.Value{
+		"node_id": func() amp.Value { return c.NodeId.String() },
+
+		"bundles_received":  metric(func(m routing.Metrics) uint64 { return m.Received }),
+		"bundles_forwarded": metric(func(m routing.Metrics) uint64 { return m.Forwarded }),
+		"bundles_delivered": metric(func(m routing.Metrics) uint64 { return m.Delivered }),
+		"bundles_deleted": metric(func(m routing.Metrics) uint64 {
+			var n uint64
+			for _, deleted := range m.Deleted {
+				n += deleted
+			}
+			return n
+		}),
+		"store_bundles":   metric(func(m routing.Metrics) uint64 { return uint64(m.StoredBundles) }),
+		"peers_connected": metric(func(m routing.Metrics) uint64 { return uint64(m.ConnectedPeers) }),
+	}
+
+	aris := make(map[amp.ARI]func() amp.Value, len(edds))
+	for name, edd := range edds {
+		aris[amp.ARI{Namespace: ampNamespace, Type: amp.EDD, Name: name}] = edd
+	}
+	return aris
+}
+
+// ampVars are dtnd's settings, which can be changed at runtime, as AMP variables.
+func ampVars() map[amp.ARI]amp.Variable {
+	return map[amp.ARI]amp.Variable{
+		{Namespace: ampNamespace, Type: amp.VAR, Name: "log_level"}: {
+			Get: func() amp.Value { return log.GetLevel().String() },
+			Set: func(v amp.Value) error {
+				s, ok := v.(string)
+				if !ok {
+					return fmt.Errorf("log level %v is no string", v)
+				}
+
+				level, err := log.ParseLevel(s)
+				if err != nil {
+					return err
+				}
+				log.SetLevel(level)
+				return nil
+			},
+		},
+	}
+}
@@ -0,0 +1,376 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package main
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+	"github.com/dtn7/dtn7-go/pkg/energy"
+	"github.com/dtn7/dtn7-go/pkg/routing"
+)
+
+// configChecker collects all problems of a configuration, each prefixed by the affected section or key.
+type configChecker struct {
+	problems []error
+}
+
+// check records a non-nil error as a problem of a key.
+func (cc *configChecker) check(key string, err error) {
+	if err != nil {
+		cc.problems = append(cc.problems, fmt.Errorf("%s: %v", key, err))
+	}
+}
+
+// checkDuration of a key. Empty values are only accepted if the duration is optional.
+func (cc *configChecker) checkDuration(key, value string, optional bool) {
+	if value == "" && optional {
+		return
+	}
+	_, err := time.ParseDuration(value)
+	cc.check(key, err)
+}
+
+// checkEndpointID of a key. Empty values are accepted.
+func (cc *configChecker) checkEndpointID(key, value string) {
+	if value == "" {
+		return
+	}
+	_, err := bpv7.NewEndpointID(value)
+	cc.check(key, err)
+}
+
+// checkAddress of a key, a "host:port" to listen on or to connect to.
+func (cc *configChecker) checkAddress(key, value string) {
+	_, err := parseListenPort(value)
+	cc.check(key, err)
+}
+
+// checkStore checks if the store's directory is writable or could be created.
+func (cc *configChecker) checkStore(dir string) {
+	info, err := os.Stat(dir)
+	switch {
+	case os.IsNotExist(err):
+		// The store will be created within the nearest existing parent directory.
+		parent := filepath.Dir(filepath.Clean(dir))
+		for {
+			if _, err := os.Stat(parent); err == nil || filepath.Dir(parent) == parent {
+				break
+			}
+			parent = filepath.Dir(parent)
+		}
+		if err := checkWritable(parent); err != nil {
+			cc.check("core.store", fmt.Errorf("%s cannot be created within %s: %v", dir, parent, err))
+		}
+
+	case err != nil:
+		cc.check("core.store", err)
+
+	case !info.IsDir():
+		cc.check("core.store", fmt.Errorf("%s is not a directory", dir))
+
+	default:
+		if err := checkWritable(dir); err != nil {
+			cc.check("core.store", fmt.Errorf("%s is not writable: %v", dir, err))
+		}
+	}
+}
+
+// checkWritable checks if files can be created within a directory by creating and removing a temporary file.
+func checkWritable(dir string) error {
+	f, err := os.CreateTemp(dir, ".dtnd-check-")
+	if err != nil {
+		return err
+	}
+	_ = f.Close()
+	return os.Remove(f.Name())
+}
+
+// checkConvergence of a "listen" or "peer" block, identified by its key.
+func (cc *configChecker) checkConvergence(key string, conv convergenceConf, listen bool) {
+	cc.checkEndpointID(key+".node", conv.Node)
+
+	switch conv.Protocol {
+	case "bbc":
+		if !listen {
+			cc.check(key+".protocol", fmt.Errorf("bbc can only be used to listen"))
+		}
+
+	case "mtcp", "stcp", "tcpclv4", "quicl":
+		if !listen && (conv.Protocol == "mtcp" || conv.Protocol == "stcp") && conv.Node == "" {
+			cc.check(key+".node", fmt.Errorf("%s peers require a node", conv.Protocol))
+		}
+		cc.checkAddress(key+".endpoint", conv.Endpoint)
+
+	case "tcpclv4-ws":
+		if listen {
+			cc.checkAddress(key+".endpoint", conv.Endpoint)
+		} else if u, err := url.Parse(conv.Endpoint); err != nil {
+			cc.check(key+".endpoint", err)
+		} else if u.Scheme != "ws" && u.Scheme != "wss" {
+			cc.check(key+".endpoint", fmt.Errorf("%q is no ws or wss URL", conv.Endpoint))
+		}
+
+	default:
+		cc.check(key+".protocol", fmt.Errorf("unknown protocol %q", conv.Protocol))
+	}
+}
+
+// checkConfiguration parses and validates a configuration file without starting dtnd. Thus, neither the store is
+// opened nor any address is bound. All found problems are returned, an empty result indicates a valid configuration.
+func checkConfiguration(filename string) []error {
+	conf, meta, err := loadConfiguration(filename)
+	if err != nil {
+		return []error{err}
+	}
+
+	cc := &configChecker{}
+
+	for _, key := range meta.Undecoded() {
+		cc.check(key.String(), fmt.Errorf("unknown key"))
+	}
+
+	// Core
+	if conf.Core.Store == "" {
+		cc.check("core.store", fmt.Errorf("store is empty"))
+	} else {
+		cc.checkStore(conf.Core.Store)
+	}
+
+	if nodeId, err := bpv7.NewEndpointID(conf.Core.NodeId); err != nil {
+		cc.check("core.node-id", err)
+	} else if !nodeId.IsSingleton() {
+		cc.check("core.node-id", fmt.Errorf("%v is not a singleton", nodeId))
+	}
+
+	if conf.Core.SignPriv != "" {
+		if key, err := hex.DecodeString(conf.Core.SignPriv); err != nil {
+			cc.check("core.signature-private", err)
+		} else if len(key) != ed25519.PrivateKeySize {
+			cc.check("core.signature-private", fmt.Errorf("key has %d bytes, expected %d", len(key), ed25519.PrivateKeySize))
+		}
+	}
+
+	for _, alias := range conf.Core.NodeAliases {
+		cc.checkEndpointID("core.node-aliases", alias)
+	}
+	for _, group := range conf.Core.Groups {
+		if eid, err := bpv7.NewEndpointID(group); err != nil {
+			cc.check("core.group-memberships", err)
+		} else if eid.IsSingleton() {
+			cc.check("core.group-memberships", fmt.Errorf("%v is a singleton", eid))
+		}
+	}
+	cc.checkEndpointID("core.report-to", conf.Core.ReportTo)
+
+	if profile, err := routing.NewCompatibilityProfile(conf.Core.Compatibility); err != nil {
+		cc.check("core.compatibility", err)
+	} else if nodeId, err := bpv7.NewEndpointID(conf.Core.NodeId); err == nil {
+		var aliases []bpv7.EndpointID
+		for _, alias := range conf.Core.NodeAliases {
+			if eid, err := bpv7.NewEndpointID(alias); err == nil {
+				aliases = append(aliases, eid)
+			}
+		}
+		if conf.Core.IpnNode != 0 {
+			aliases = append(aliases, ipnNodeId(conf.Core.IpnNode))
+		}
+		cc.check("core.compatibility", profile.Check(nodeId, aliases, conf.Routing))
+	}
+
+	for _, duration := range []struct{ key, value string }{
+		{"core.custody-retransmit", conf.Core.CustodyRetransmit},
+		{"core.peer-budget-window", conf.Core.PeerBudgetWindow},
+		{"core.retry-initial", conf.Core.RetryInitial},
+		{"core.retry-max", conf.Core.RetryMax},
+		{"core.routing-checkpoint", conf.Core.RoutingCheckpoint},
+		{"core.shutdown-timeout", conf.Core.ShutdownTimeout},
+		{"core.clock-tolerance", conf.Core.ClockTolerance},
+		{"cron.jitter", conf.Cron.Jitter},
+	} {
+		cc.checkDuration(duration.key, duration.value, true)
+	}
+
+	if conf.Core.HopLimit > math.MaxUint8 {
+		cc.check("core.hop-limit", fmt.Errorf("%d exceeds %d", conf.Core.HopLimit, math.MaxUint8))
+	}
+	if conf.Core.BroadcastHopLimit > math.MaxUint8 {
+		cc.check("core.broadcast-hop-limit", fmt.Errorf("%d exceeds %d", conf.Core.BroadcastHopLimit, math.MaxUint8))
+	}
+	if shards := conf.Core.ErasureData + conf.Core.ErasureParity; shards > 256 {
+		cc.check("core.erasure-parity-shards", fmt.Errorf("%d shards exceed 256", shards))
+	}
+
+	_, err = parseCrcPolicy(conf.Core)
+	cc.check("core.crc", err)
+	_, err = parseSignaturePolicy(conf.Core)
+	cc.check("core.signature-keys", err)
+	_, err = parseValidationMode(conf.Core.Validation)
+	cc.check("core.validation", err)
+	_, err = parseStatusReportPolicy(conf.Core)
+	cc.check("core.disable-status-reports", err)
+	_, err = parsePriorityPolicy(conf.Core)
+	cc.check("core.priority-classes", err)
+	_, err = parsePolicyRules(conf.Policy)
+	cc.check("policy", err)
+	_, err = parseDestinationQuotas(conf.Quota)
+	cc.check("destination-quota", err)
+
+	// Cron
+	cc.checkDuration("cron.check-bundles", conf.Cron.CheckBundles, false)
+	cc.checkDuration("cron.clean-store", conf.Cron.CleanStore, false)
+	cc.checkDuration("cron.clean-id", conf.Cron.CleanID, false)
+
+	// Routing
+	cc.check("routing", conf.Routing.Validate())
+
+	// Logging
+	if conf.Logging.Level != "" {
+		_, err = log.ParseLevel(conf.Logging.Level)
+		cc.check("logging.level", err)
+	}
+	if format := conf.Logging.Format; format != "" && format != "text" && format != "json" {
+		cc.check("logging.format", fmt.Errorf("unknown format %q, expected text or json", format))
+	}
+
+	// Agents
+	cc.checkEndpointID("agents.ping", conf.Agents.Ping)
+	if (conf.Agents.Webserver != agentsWebserverConfig{}) {
+		if !conf.Agents.Webserver.Websocket && !conf.Agents.Webserver.Rest {
+			cc.check("agents.webserver", fmt.Errorf("webserver agent needs at least one of Websocket or REST"))
+		}
+		cc.checkAddress("agents.webserver.address", conf.Agents.Webserver.Address)
+		_, err = parseLocation(conf.Agents.Webserver.Location)
+		cc.check("agents.webserver.location", err)
+	}
+
+	if conf.Agents.AAP.Address != "" {
+		cc.checkAddress("agents.aap.address", conf.Agents.AAP.Address)
+		cc.checkDuration("agents.aap.lifetime", conf.Agents.AAP.Lifetime, true)
+	}
+
+	if conf.Agents.HTTPGateway.Address != "" {
+		gw := conf.Agents.HTTPGateway
+		cc.checkAddress("agents.http-gateway.address", gw.Address)
+		for _, eid := range []struct{ key, value string }{
+			{"agents.http-gateway.endpoint", gw.Endpoint},
+			{"agents.http-gateway.exit", gw.Exit},
+		} {
+			_, err := bpv7.NewEndpointID(eid.value)
+			cc.check(eid.key, err)
+		}
+		cc.checkDuration("agents.http-gateway.lifetime", gw.Lifetime, true)
+		cc.checkDuration("agents.http-gateway.timeout", gw.Timeout, true)
+	}
+	if conf.Agents.HTTPExit.Endpoint != "" {
+		cc.checkEndpointID("agents.http-exit.endpoint", conf.Agents.HTTPExit.Endpoint)
+		cc.checkDuration("agents.http-exit.timeout", conf.Agents.HTTPExit.Timeout, true)
+	}
+
+	if conf.Agents.Mail.Endpoint != "" {
+		mail := conf.Agents.Mail
+		cc.checkEndpointID("agents.mail.endpoint", mail.Endpoint)
+		if mail.Listen != "" {
+			cc.checkAddress("agents.mail.listen", mail.Listen)
+		}
+		if mail.Relay != "" {
+			cc.checkAddress("agents.mail.relay", mail.Relay)
+		}
+		for domain, route := range mail.Routes {
+			_, err := bpv7.NewEndpointID(route)
+			cc.check(fmt.Sprintf("agents.mail.routes.%q", domain), err)
+		}
+		cc.checkDuration("agents.mail.lifetime", mail.Lifetime, true)
+	}
+
+	if conf.Agents.AMP.Endpoint != "" {
+		cc.checkEndpointID("agents.amp.endpoint", conf.Agents.AMP.Endpoint)
+		if len(conf.Agents.AMP.Managers) == 0 {
+			cc.check("agents.amp.managers", fmt.Errorf("no managers are configured"))
+		}
+		for _, manager := range conf.Agents.AMP.Managers {
+			_, err := bpv7.NewEndpointID(manager)
+			cc.check("agents.amp.managers", err)
+		}
+		cc.checkDuration("agents.amp.lifetime", conf.Agents.AMP.Lifetime, true)
+	}
+
+	if conf.Agents.TimeSync.Service != "" {
+		cc.checkDuration("agents.timesync.interval", conf.Agents.TimeSync.Interval, true)
+		cc.checkDuration("agents.timesync.max-step", conf.Agents.TimeSync.MaxStep, true)
+		for _, peer := range conf.Agents.TimeSync.Peers {
+			cc.checkEndpointID("agents.timesync.peers", peer)
+		}
+		if conf.Agents.TimeSync.Discipline && len(conf.Agents.TimeSync.Peers) == 0 {
+			cc.check("agents.timesync.peers", fmt.Errorf("disciplining the clock requires trusted peers"))
+		}
+	}
+
+	// Metrics and Control
+	if conf.Metrics.Address != "" {
+		cc.checkAddress("metrics.address", conf.Metrics.Address)
+	}
+	if conf.Metrics.StatusFile != "" {
+		cc.checkDuration("metrics.status-interval", conf.Metrics.StatusInterval, true)
+		if info, err := os.Stat(filepath.Dir(conf.Metrics.StatusFile)); err != nil {
+			cc.check("metrics.status-file", err)
+		} else if !info.IsDir() {
+			cc.check("metrics.status-file", fmt.Errorf("%s is not a directory", filepath.Dir(conf.Metrics.StatusFile)))
+		}
+	}
+	if conf.Control.Socket != "" {
+		if info, err := os.Stat(filepath.Dir(conf.Control.Socket)); err != nil {
+			cc.check("control.socket", err)
+		} else if !info.IsDir() {
+			cc.check("control.socket", fmt.Errorf("%s is not a directory", filepath.Dir(conf.Control.Socket)))
+		}
+	}
+
+	// Energy
+	if conf.Energy.BatteryFile != "" {
+		cc.checkDuration("energy.check-interval", conf.Energy.CheckInterval, true)
+		if low, resume := conf.Energy.thresholds(); low < 0 || resume > 100 || low > resume {
+			cc.check("energy.resume-level", fmt.Errorf("thresholds of %v%% and %v%% are invalid", low, resume))
+		}
+		if _, err := energy.FileSource(conf.Energy.BatteryFile).Level(); err != nil {
+			cc.check("energy.battery-file", err)
+		}
+	}
+
+	// Listen and Peer
+	for i, conv := range conf.Listen {
+		cc.checkConvergence(fmt.Sprintf("listen[%d]", i), conv, true)
+	}
+	for i, conv := range conf.Peer {
+		cc.checkConvergence(fmt.Sprintf("peer[%d]", i), conv, false)
+	}
+
+	// Discovery
+	_, err = parseStaticPeers(conf.Discovery.Static)
+	cc.check("discovery.static", err)
+	if conf.Discovery.Key != "" {
+		_, err = hex.DecodeString(conf.Discovery.Key)
+		cc.check("discovery.key", err)
+		if conf.Discovery.DNSSD || conf.Discovery.IPND || conf.Discovery.BLE {
+			cc.check("discovery.key", fmt.Errorf("signed announcements are not supported by dnssd, ipnd, or ble"))
+		}
+	}
+	for _, iface := range conf.Discovery.Interfaces {
+		_, err = net.InterfaceByName(iface)
+		cc.check("discovery.interfaces", err)
+	}
+
+	return cc.problems
+}
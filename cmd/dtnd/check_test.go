@@ -0,0 +1,289 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// checkSections are the valid non-core sections of a configuration passed to writeCheckConfig.
+const checkSections = `
+[cron]
+check-bundles = "10s"
+clean-store = "10m"
+clean-id = "10m"
+
+[routing]
+algorithm = "epidemic"
+`
+
+// writeCheckConfig writes a configuration of core settings and further sections into a temporary directory. A store
+// and a node ID are added unless defined by the core settings. All "$TMP" are replaced by the temporary directory.
+func writeCheckConfig(t *testing.T, core, sections string) string {
+	dir := t.TempDir()
+
+	if !strings.Contains(core, "store =") {
+		core = "store = \"$TMP/store\"\n" + core
+	}
+	if !strings.Contains(core, "node-id =") {
+		core = "node-id = \"dtn://test/\"\n" + core
+	}
+
+	conf := strings.ReplaceAll("[core]\n"+core+"\n"+sections, "$TMP", dir)
+	filename := filepath.Join(dir, "dtnd.toml")
+	if err := os.WriteFile(filename, []byte(conf), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "battery"), []byte("50\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "file"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return filename
+}
+
+func TestCheckConfiguration(t *testing.T) {
+	const privKey = "c9b31c5f06d8c4ba1e0a4ba5e1e1a0ab98ab2cac2b4bd40f6bdb5b7be4ff3ebd" +
+		"e1b2ee4d7ae7d4fca6f8f5b0a3b1c0f4d8d4b5a2c6e3b4a5d6c7b8a9f0e1d2c3"
+	const pubKey = "e1b2ee4d7ae7d4fca6f8f5b0a3b1c0f4d8d4b5a2c6e3b4a5d6c7b8a9f0e1d2c3"
+
+	tests := []struct {
+		name     string
+		core     string
+		sections string
+		problem  string
+	}{
+		{"valid", "", checkSections, ""},
+		{"valid store within new directories", `store = "$TMP/a/b/store"`, checkSections, ""},
+		{"valid signatures", `signature-private = "` + privKey + `"
+signature-keys = { "dtn://other/" = "` + pubKey + `" }`, checkSections, ""},
+		{"valid agents", "", checkSections + `
+[agents]
+ping = "dtn://test/ping"
+
+[agents.webserver]
+address = "localhost:8080"
+websocket = true
+location = "52.5,13.4"`, ""},
+		{"valid listen and peer", "", checkSections + `
+[[listen]]
+protocol = "mtcp"
+endpoint = ":4556"
+
+[[peer]]
+node = "dtn://peer/"
+protocol = "tcpclv4-ws"
+endpoint = "ws://peer:8080/tcpclv4"`, ""},
+
+		{"unknown key", "nonsense = 1", checkSections, "core.nonsense"},
+		{"store is a file", `store = "$TMP/file"`, checkSections, "core.store"},
+		{"store is empty", `store = ""`, checkSections, "core.store"},
+		{"invalid node ID", `node-id = "node"`, checkSections, "core.node-id"},
+		{"non-singleton node ID", `node-id = "dtn://test/~all"`, checkSections, "core.node-id"},
+		{"invalid private key", `signature-private = "zz"`, checkSections, "core.signature-private"},
+		{"short private key", `signature-private = "` + pubKey + `"`, checkSections, "core.signature-private"},
+		{"invalid node alias", `node-aliases = ["alias"]`, checkSections, "core.node-aliases"},
+		{"singleton group", `group-memberships = ["dtn://group/"]`, checkSections, "core.group-memberships"},
+		{"invalid report-to", `report-to = "reports"`, checkSections, "core.report-to"},
+		{"unknown compatibility", `compatibility = "unknown"`, checkSections, "core.compatibility"},
+		{"incompatible node ID", `compatibility = "ion"`, checkSections, "core.compatibility"},
+		{"invalid duration", `retry-max = "soon"`, checkSections, "core.retry-max"},
+		{"hop limit", "hop-limit = 256", checkSections, "core.hop-limit"},
+		{"broadcast hop limit", "broadcast-hop-limit = 256", checkSections, "core.broadcast-hop-limit"},
+		{"erasure shards", "erasure-data-shards = 200\nerasure-parity-shards = 57", checkSections,
+			"core.erasure-parity-shards"},
+		{"unknown crc", `crc-primary = "crc64"`, checkSections, "core.crc"},
+		{"invalid signature key", `signature-keys = { "dtn://other/" = "zz" }`, checkSections, "core.signature-keys"},
+		{"unknown validation", `validation = "strict"`, checkSections, "core.validation"},
+		{"unknown status report", `disable-status-reports = ["sent"]`, checkSections,
+			"core.disable-status-reports"},
+		{"unknown priority", `priority-classes = { "dtn://other/" = "urgent" }`, checkSections,
+			"core.priority-classes"},
+		{"unknown policy stage", "", checkSections + `
+[[policy]]
+stage = "storage"
+action = "drop"`, "policy"},
+		{"quota without prefix", "", checkSections + `
+[[destination-quota]]
+bundles = 10`, "destination-quota"},
+
+		{"missing cron interval", "", `
+[cron]
+check-bundles = "10s"
+clean-store = "10m"
+
+[routing]
+algorithm = "epidemic"`, "cron.clean-id"},
+		{"invalid cron jitter", "", `
+[cron]
+check-bundles = "10s"
+clean-store = "10m"
+clean-id = "10m"
+jitter = "some"
+
+[routing]
+algorithm = "epidemic"`, "cron.jitter"},
+		{"unknown routing", "", `
+[cron]
+check-bundles = "10s"
+clean-store = "10m"
+clean-id = "10m"
+
+[routing]
+algorithm = "flooding"`, "routing"},
+
+		{"unknown log level", "", checkSections + `
+[logging]
+level = "loud"`, "logging.level"},
+		{"unknown log format", "", checkSections + `
+[logging]
+format = "xml"`, "logging.format"},
+
+		{"invalid ping endpoint", "", checkSections + `
+[agents]
+ping = "dtn:"`, "agents.ping"},
+		{"webserver without interface", "", checkSections + `
+[agents.webserver]
+address = "localhost:8080"`, "agents.webserver"},
+		{"invalid webserver address", "", checkSections + `
+[agents.webserver]
+address = "localhost"
+rest = true`, "agents.webserver.address"},
+		{"invalid webserver location", "", checkSections + `
+[agents.webserver]
+address = "localhost:8080"
+rest = true
+location = "north"`, "agents.webserver.location"},
+		{"invalid aap address", "", checkSections + `
+[agents.aap]
+address = "localhost"`, "agents.aap.address"},
+		{"invalid aap lifetime", "", checkSections + `
+[agents.aap]
+address = "localhost:4242"
+lifetime = "long"`, "agents.aap.lifetime"},
+		{"invalid http-gateway exit", "", checkSections + `
+[agents.http-gateway]
+address = "localhost:8080"
+endpoint = "dtn://test/http"
+exit = "exit"`, "agents.http-gateway.exit"},
+		{"invalid http-exit timeout", "", checkSections + `
+[agents.http-exit]
+endpoint = "dtn://test/http"
+timeout = "short"`, "agents.http-exit.timeout"},
+		{"invalid mail relay", "", checkSections + `
+[agents.mail]
+endpoint = "dtn://test/mail"
+relay = "localhost"`, "agents.mail.relay"},
+		{"invalid mail route", "", checkSections + `
+[agents.mail]
+endpoint = "dtn://test/mail"
+routes = { "example.org" = "example" }`, `agents.mail.routes."example.org"`},
+		{"amp without managers", "", checkSections + `
+[agents.amp]
+endpoint = "dtn://test/amp"`, "agents.amp.managers"},
+		{"timesync discipline without peers", "", checkSections + `
+[agents.timesync]
+service = "dtn://test/timesync"
+discipline = true`, "agents.timesync.peers"},
+
+		{"invalid metrics address", "", checkSections + `
+[metrics]
+address = "localhost"`, "metrics.address"},
+		{"missing status file directory", "", checkSections + `
+[metrics]
+status-file = "$TMP/missing/status.json"`, "metrics.status-file"},
+		{"control socket within a file", "", checkSections + `
+[control]
+socket = "$TMP/file/dtnd.sock"`, "control.socket"},
+
+		{"missing battery file", "", checkSections + `
+[energy]
+battery-file = "$TMP/missing"`, "energy.battery-file"},
+		{"invalid energy thresholds", "", checkSections + `
+[energy]
+battery-file = "$TMP/battery"
+low-level = 30
+resume-level = 20`, "energy.resume-level"},
+
+		{"unknown listen protocol", "", checkSections + `
+[[listen]]
+protocol = "udp"
+endpoint = ":4556"`, "listen[0].protocol"},
+		{"invalid listen endpoint", "", checkSections + `
+[[listen]]
+protocol = "tcpclv4"
+endpoint = "4556"`, "listen[0].endpoint"},
+		{"bbc peer", "", checkSections + `
+[[peer]]
+protocol = "bbc"
+endpoint = "rf95modem:/dev/ttyUSB0"`, "peer[0].protocol"},
+		{"mtcp peer without node", "", checkSections + `
+[[peer]]
+protocol = "mtcp"
+endpoint = "peer:4556"`, "peer[0].node"},
+		{"websocket peer without URL", "", checkSections + `
+[[peer]]
+node = "dtn://peer/"
+protocol = "tcpclv4-ws"
+endpoint = "http://peer:8080/tcpclv4"`, "peer[0].endpoint"},
+
+		{"unknown static protocol", "", checkSections + `
+[[discovery.static]]
+protocol = "bbc"
+endpoint = "peer:4556"`, "discovery.static"},
+		{"signed ipnd announcements", "", checkSections + `
+[discovery]
+ipnd = true
+key = "00ff"`, "discovery.key"},
+		{"unknown interface", "", checkSections + `
+[discovery]
+interfaces = ["dtnd-missing0"]`, "discovery.interfaces"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			problems := checkConfiguration(writeCheckConfig(t, test.core, test.sections))
+
+			if test.problem == "" {
+				if len(problems) != 0 {
+					t.Fatalf("expected a valid configuration, got %v", problems)
+				}
+				return
+			}
+
+			if len(problems) != 1 {
+				t.Fatalf("expected one problem of %s, got %v", test.problem, problems)
+			} else if !strings.HasPrefix(problems[0].Error(), test.problem+": ") {
+				t.Fatalf("expected a problem of %s, got %v", test.problem, problems[0])
+			}
+		})
+	}
+}
+
+func TestCheckConfigurationAllProblems(t *testing.T) {
+	problems := checkConfiguration(writeCheckConfig(t, "node-id = \"node\"\nhop-limit = 256", `
+[cron]
+check-bundles = "10s"
+clean-store = "10m"
+clean-id = "10m"
+
+[routing]
+algorithm = "flooding"`))
+
+	if len(problems) != 3 {
+		t.Fatalf("expected three problems, got %v", problems)
+	}
+}
+
+func TestCheckConfigurationMissingFile(t *testing.T) {
+	problems := checkConfiguration(filepath.Join(t.TempDir(), "missing.toml"))
+	if len(problems) != 1 {
+		t.Fatalf("expected one problem, got %v", problems)
+	}
+}
@@ -9,9 +9,15 @@ import (
 	"crypto/ed25519"
 	"encoding/hex"
 	"fmt"
+	"math"
 	"net"
 	"net/http"
+	"os"
+	"path"
+	"reflect"
 	"strconv"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/dtn7/dtn7-go/pkg/cla/quicl"
@@ -19,13 +25,13 @@ import (
 	"github.com/gorilla/mux"
 	log "github.com/sirupsen/logrus"
 
-	"github.com/BurntSushi/toml"
-
 	"github.com/dtn7/dtn7-go/pkg/agent"
+	"github.com/dtn7/dtn7-go/pkg/amp"
 	"github.com/dtn7/dtn7-go/pkg/bpv7"
 	"github.com/dtn7/dtn7-go/pkg/cla"
 	"github.com/dtn7/dtn7-go/pkg/cla/bbc"
 	"github.com/dtn7/dtn7-go/pkg/cla/mtcp"
+	"github.com/dtn7/dtn7-go/pkg/cla/stcp"
 	"github.com/dtn7/dtn7-go/pkg/cla/tcpclv4"
 	"github.com/dtn7/dtn7-go/pkg/discovery"
 	"github.com/dtn7/dtn7-go/pkg/routing"
@@ -53,6 +59,11 @@ type tomlConfig struct {
 	Logging   logConf
 	Discovery discoveryConf
 	Agents    agentsConfig
+	Metrics   metricsConf
+	Control   controlConf
+	Energy    energyConf
+	Policy    []policyConf
+	Quota     []quotaConf `toml:"destination-quota"`
 	Listen    []convergenceConf
 	Peer      []convergenceConf
 	Routing   routing.RoutingConf
@@ -61,15 +72,59 @@ type tomlConfig struct {
 // coreConf describes the Core-configuration block.
 type coreConf struct {
 	Store             string
-	InspectAllBundles bool   `toml:"inspect-all-bundles"`
-	NodeId            string `toml:"node-id"`
-	SignPriv          string `toml:"signature-private"`
+	InspectAllBundles bool              `toml:"inspect-all-bundles"`
+	NodeId            string            `toml:"node-id"`
+	NodeAliases       []string          `toml:"node-aliases"`
+	IpnNode           uint64            `toml:"ipn-node"`
+	Compatibility     string            `toml:"compatibility"`
+	Groups            []string          `toml:"group-memberships"`
+	SignPriv          string            `toml:"signature-private"`
+	FragmentMtu       uint              `toml:"fragment-mtu"`
+	HopLimit          uint              `toml:"hop-limit"`
+	TransitLog        uint              `toml:"transit-log"`
+	BroadcastHopLimit uint              `toml:"broadcast-hop-limit"`
+	BroadcastRegion   string            `toml:"broadcast-region"`
+	Clockless         bool              `toml:"clockless"`
+	CrcPrimary        string            `toml:"crc-primary"`
+	CrcCanonical      string            `toml:"crc-canonical"`
+	RequireCrc        bool              `toml:"require-crc"`
+	SignatureKeys     map[string]string `toml:"signature-keys"`
+	RequireSignatures bool              `toml:"require-signatures"`
+	PayloadStream     uint64            `toml:"payload-stream-threshold"`
+	Validation        string            `toml:"validation"`
+	ReportTo          string            `toml:"report-to"`
+	Custody           bool              `toml:"custody"`
+	CustodyRetransmit string            `toml:"custody-retransmit"`
+	DisableReports    []string          `toml:"disable-status-reports"`
+	ReportRateLimit   uint              `toml:"status-report-rate-limit"`
+	KnownBundles      uint              `toml:"known-bundles"`
+	PriorityClasses   map[string]string `toml:"priority-classes"`
+	StoreLimit        uint              `toml:"store-limit"`
+	PeerBudget        uint64            `toml:"peer-budget"`
+	PeerBudgetWindow  string            `toml:"peer-budget-window"`
+	RetryInitial      string            `toml:"retry-initial"`
+	RoutingCheckpoint string            `toml:"routing-checkpoint"`
+	RetryMax          string            `toml:"retry-max"`
+	ShutdownTimeout   string            `toml:"shutdown-timeout"`
+	Workers           uint              `toml:"processing-workers"`
+	StoreBatch        uint              `toml:"store-batch"`
+	StoreCache        uint              `toml:"store-cache"`
+	EventLog          string            `toml:"event-log"`
+	StatusDump        string            `toml:"status-dump"`
+	ClockTolerance    string            `toml:"clock-tolerance"`
+	MinFreeSpace      uint64            `toml:"min-free-space"`
+	StoreQuota        uint64            `toml:"store-quota"`
+	ContentCache      uint64            `toml:"content-cache"`
+	ErasureMinSize    uint64            `toml:"erasure-min-size"`
+	ErasureData       uint              `toml:"erasure-data-shards"`
+	ErasureParity     uint              `toml:"erasure-parity-shards"`
 }
 
 type cronConf struct {
 	CheckBundles string `toml:"check-bundles"`
 	CleanStore   string `toml:"clean-store"`
 	CleanID      string `toml:"clean-id"`
+	Jitter       string `toml:"jitter"`
 }
 
 // logConf describes the Logging-configuration block.
@@ -81,22 +136,120 @@ type logConf struct {
 
 // discoveryConf describes the Discovery-configuration block.
 type discoveryConf struct {
-	IPv4     bool
-	IPv6     bool
-	Interval uint
+	IPv4       bool
+	IPv6       bool
+	Interval   uint
+	TTL        uint
+	Expiry     uint
+	Announce   []string
+	DNSSD      bool
+	IPND       bool
+	BLE        bool
+	BLEDevice  uint16 `toml:"ble-device"`
+	Static     []convergenceConf
+	Key        string
+	Passive    bool
+	Interfaces []string
+	Gossip     bool
+}
+
+// policyConf describes a Policy-configuration block, a single routing.PolicyRule.
+type policyConf struct {
+	Stage       string
+	Source      string
+	Destination string
+	CLA         string `toml:"cla"`
+	MinSize     uint64 `toml:"min-size"`
+	MaxSize     uint64 `toml:"max-size"`
+	MinLifetime string `toml:"min-lifetime"`
+	MaxLifetime string `toml:"max-lifetime"`
+	Action      string
+	Priority    string
+	Limit       uint
+}
+
+// quotaConf describes a DestinationQuota-configuration block.
+type quotaConf struct {
+	Prefix  string
+	Bundles uint
+	Bytes   uint64
+}
+
+// metricsConf describes the Metrics-configuration block.
+type metricsConf struct {
+	Address        string
+	StatusFile     string `toml:"status-file"`
+	StatusInterval string `toml:"status-interval"`
 }
 
 // agentsConfig describes the ApplicationAgents/Agent-configuration block.
 type agentsConfig struct {
-	Ping      string
-	Webserver agentsWebserverConfig
+	Ping        string
+	Webserver   agentsWebserverConfig
+	AAP         agentsAAPConfig         `toml:"aap"`
+	HTTPGateway agentsHTTPGatewayConfig `toml:"http-gateway"`
+	HTTPExit    agentsHTTPExitConfig    `toml:"http-exit"`
+	Mail        agentsMailConfig
+	AMP         agentsAMPConfig      `toml:"amp"`
+	TimeSync    agentsTimeSyncConfig `toml:"timesync"`
+}
+
+// agentsHTTPGatewayConfig describes the nested "http-gateway" configuration for an HTTP proxy, whose requests are
+// performed by a remote node's "http-exit" agent.
+type agentsHTTPGatewayConfig struct {
+	Address  string
+	Endpoint string
+	Exit     string
+	Lifetime string
+	Timeout  string
+}
+
+// agentsHTTPExitConfig describes the nested "http-exit" configuration, performing the requests of HTTP gateways.
+type agentsHTTPExitConfig struct {
+	Endpoint string
+	Timeout  string
+}
+
+// agentsMailConfig describes the nested "mail" configuration for an email gateway. The Routes map recipients' mail
+// domains to other nodes' mail prefixes.
+type agentsMailConfig struct {
+	Endpoint string
+	Listen   string
+	Relay    string
+	Lifetime string
+	Routes   map[string]string
+}
+
+// agentsAMPConfig describes the nested "AMP" configuration for an agent of the Asynchronous Management Protocol.
+type agentsAMPConfig struct {
+	Endpoint string
+	Managers []string
+	Lifetime string
+}
+
+// agentsTimeSyncConfig describes the nested "timesync" configuration for the time synchronization with the peers.
+type agentsTimeSyncConfig struct {
+	Service    string
+	Interval   string
+	Discipline bool
+	Peers      []string
+	MinPeers   int    `toml:"min-peers"`
+	MaxStep    string `toml:"max-step"`
+}
+
+// agentsAAPConfig describes the nested "AAP" configuration for µD3TN's Application Agent Protocol.
+type agentsAAPConfig struct {
+	Address  string
+	Lifetime string
 }
 
 // agentsWebserverConfig describes the nested "Webserver" configuration for agents.
 type agentsWebserverConfig struct {
-	Address   string
-	Websocket bool
-	Rest      bool
+	Address          string
+	Websocket        bool
+	Rest             bool
+	Location         string
+	MaxStreamPayload uint64 `toml:"max-stream-payload"`
 }
 
 // convergenceConf describes the Convergence-configuration block, used for
@@ -105,6 +258,10 @@ type convergenceConf struct {
 	Node     string
 	Protocol string
 	Endpoint string
+
+	// Preference and Address are only used for announcing a "listen" CLA via discovery.
+	Preference uint
+	Address    string
 }
 
 func parseListenPort(endpoint string) (port int, err error) {
@@ -117,6 +274,27 @@ func parseListenPort(endpoint string) (port int, err error) {
 	return
 }
 
+// announceListener checks if a "listen" convergenceConf should be announced by the discovery. If no protocols are
+// configured for announcement, all are announced.
+func announceListener(disco discoveryConf, conv convergenceConf) bool {
+	if len(disco.Announce) == 0 {
+		return true
+	}
+
+	for _, protocol := range disco.Announce {
+		if protocol == conv.Protocol {
+			return true
+		}
+	}
+	return false
+}
+
+// ipnNodeId is the ipn node ID "ipn:N.0" for the configured core.ipn-node, which becomes a node alias. Bundles for any
+// of its services are routed to this node and delivered to the agent of the service number.
+func ipnNodeId(node uint64) bpv7.EndpointID {
+	return bpv7.EndpointID{EndpointType: bpv7.IpnEndpoint{Node: node}}
+}
+
 // parseListen inspects a "listen" convergenceConf and returns a Convergable.
 func parseListen(conv convergenceConf, nodeId bpv7.EndpointID) (cla.Convergable, bpv7.EndpointID, cla.CLAType, discovery.Announcement, error) {
 	log.WithFields(log.Fields{
@@ -150,13 +328,19 @@ func parseListen(conv convergenceConf, nodeId bpv7.EndpointID) (cla.Convergable,
 		}
 
 		msg := discovery.Announcement{
-			Type:     cla.MTCP,
-			Endpoint: nodeId,
-			Port:     uint(portInt),
+			Type:       cla.MTCP,
+			Endpoint:   nodeId,
+			Port:       uint(portInt),
+			Preference: conv.Preference,
+			Address:    conv.Address,
 		}
 
 		return mtcp.NewMTCPServer(conv.Endpoint, nodeId, true), nodeId, cla.MTCP, msg, nil
 
+	case "stcp":
+		// STCP is a gateway for BPv6 nodes, which do not support dtn7's discovery.
+		return stcp.NewSTCPServer(conv.Endpoint, nodeId, true), nodeId, cla.STCP, discovery.Announcement{}, nil
+
 	case "tcpclv4":
 		portInt, err := parseListenPort(conv.Endpoint)
 		if err != nil {
@@ -166,14 +350,21 @@ func parseListen(conv convergenceConf, nodeId bpv7.EndpointID) (cla.Convergable,
 		listener := tcpclv4.ListenTCP(conv.Endpoint, nodeId)
 
 		msg := discovery.Announcement{
-			Type:     cla.TCPCLv4,
-			Endpoint: nodeId,
-			Port:     uint(portInt),
+			Type:       cla.TCPCLv4,
+			Endpoint:   nodeId,
+			Port:       uint(portInt),
+			Preference: conv.Preference,
+			Address:    conv.Address,
 		}
 
 		return listener, nodeId, cla.TCPCLv4, msg, nil
 
 	case "tcpclv4-ws":
+		portInt, err := parseListenPort(conv.Endpoint)
+		if err != nil {
+			return nil, nodeId, cla.TCPCLv4WebSocket, discovery.Announcement{}, err
+		}
+
 		listener := tcpclv4.ListenWebSocket(nodeId)
 
 		httpMux := http.NewServeMux()
@@ -192,7 +383,15 @@ func parseListen(conv convergenceConf, nodeId bpv7.EndpointID) (cla.Convergable,
 			return nil, nodeId, cla.TCPCLv4WebSocket, discovery.Announcement{}, err
 
 		case <-time.After(100 * time.Millisecond):
-			return listener, nodeId, cla.TCPCLv4WebSocket, discovery.Announcement{}, nil
+			msg := discovery.Announcement{
+				Type:       cla.TCPCLv4WebSocket,
+				Endpoint:   nodeId,
+				Port:       uint(portInt),
+				Preference: conv.Preference,
+				Address:    conv.Address,
+			}
+
+			return listener, nodeId, cla.TCPCLv4WebSocket, msg, nil
 		}
 
 	case "quicl":
@@ -204,9 +403,11 @@ func parseListen(conv convergenceConf, nodeId bpv7.EndpointID) (cla.Convergable,
 		listener := quicl.NewQUICListener(conv.Endpoint, nodeId)
 
 		msg := discovery.Announcement{
-			Type:     cla.QUICL,
-			Endpoint: nodeId,
-			Port:     uint(portInt),
+			Type:       cla.QUICL,
+			Endpoint:   nodeId,
+			Port:       uint(portInt),
+			Preference: conv.Preference,
+			Address:    conv.Address,
 		}
 
 		return listener, nodeId, cla.QUICL, msg, nil
@@ -226,6 +427,13 @@ func parsePeer(conv convergenceConf, nodeId bpv7.EndpointID) (cla.ConvergenceSen
 			return mtcp.NewMTCPClient(conv.Endpoint, endpointID, true), nil
 		}
 
+	case "stcp":
+		if endpointID, err := bpv7.NewEndpointID(conv.Node); err != nil {
+			return nil, err
+		} else {
+			return stcp.NewSTCPClient(conv.Endpoint, endpointID, true), nil
+		}
+
 	case "tcpclv4":
 		return tcpclv4.DialTCP(conv.Endpoint, nodeId, true), nil
 
@@ -240,8 +448,74 @@ func parsePeer(conv convergenceConf, nodeId bpv7.EndpointID) (cla.ConvergenceSen
 	}
 }
 
-// parseAgents for the ApplicationAgents.
-func parseAgents(conf agentsConfig) (agents []agent.ApplicationAgent, err error) {
+// restartDiscoveryOnError creates an error callback for the discovery, restarting it after the given delay. Failed
+// restarts are retried, while concurrent errors result in a single restart.
+func restartDiscoveryOnError(ds *discovery.Manager, delay time.Duration) func(error) {
+	var restarting int32
+
+	return func(err error) {
+		if !atomic.CompareAndSwapInt32(&restarting, 0, 1) {
+			return
+		}
+
+		go func() {
+			defer atomic.StoreInt32(&restarting, 0)
+
+			for {
+				time.Sleep(delay)
+
+				if restartErr := ds.Restart(); restartErr != nil {
+					log.WithError(restartErr).WithField("cause", err).Warn("Failed to restart discovery, retrying")
+					continue
+				}
+
+				log.WithField("cause", err).Info("Restarted discovery")
+				return
+			}
+		}()
+	}
+}
+
+// parseStaticPeers inspects the "discovery.static" convergenceConfs for the discovery's static peer probing.
+func parseStaticPeers(convs []convergenceConf) (peers []discovery.Peer, err error) {
+	claTypes := map[string]cla.CLAType{
+		"mtcp":       cla.MTCP,
+		"tcpclv4":    cla.TCPCLv4,
+		"tcpclv4-ws": cla.TCPCLv4WebSocket,
+		"quicl":      cla.QUICL,
+	}
+
+	for _, conv := range convs {
+		claType, ok := claTypes[conv.Protocol]
+		if !ok {
+			err = fmt.Errorf("unknown discovery.static.protocol \"%s\"", conv.Protocol)
+			return
+		}
+
+		endpointID := bpv7.DtnNone()
+		if conv.Node != "" {
+			if endpointID, err = bpv7.NewEndpointID(conv.Node); err != nil {
+				return
+			}
+		} else if claType == cla.MTCP {
+			err = fmt.Errorf("discovery.static for mtcp at %s requires a node", conv.Endpoint)
+			return
+		}
+
+		peers = append(peers, discovery.Peer{
+			Type:     claType,
+			Endpoint: endpointID,
+			Address:  conv.Endpoint,
+		})
+	}
+
+	return
+}
+
+// parseAgents for the ApplicationAgents. The webserver additionally lists the Core's discovered peers at "/peers",
+// its routing state at "/routing", its metrics at "/metrics", and its status as JSON at "/status", streams topology
+// changes by a WebSocket at "/topology", and reloads the configuration on a POST to "/reload".
+func parseAgents(conf agentsConfig, c *routing.Core, reloadFunc func() error) (agents []agent.ApplicationAgent, err error) {
 	if conf.Ping != "" {
 		if pingEid, pingEidErr := bpv7.NewEndpointID(conf.Ping); pingEidErr != nil {
 			err = pingEidErr
@@ -251,16 +525,116 @@ func parseAgents(conf agentsConfig) (agents []agent.ApplicationAgent, err error)
 		}
 	}
 
+	if conf.AAP.Address != "" {
+		lifetime := 24 * time.Hour
+		if conf.AAP.Lifetime != "" {
+			if lifetime, err = time.ParseDuration(conf.AAP.Lifetime); err != nil {
+				return
+			}
+		}
+
+		listener, listenErr := net.Listen("tcp", conf.AAP.Address)
+		if listenErr != nil {
+			err = listenErr
+			return
+		}
+
+		agents = append(agents, agent.NewAAPAgent(listener, c.NodeId, lifetime))
+	}
+
+	if conf.HTTPGateway.Address != "" {
+		var gw *agent.HTTPGatewayAgent
+		if gw, err = parseHTTPGateway(conf.HTTPGateway); err != nil {
+			return
+		}
+
+		httpServer := &http.Server{
+			Addr:              conf.HTTPGateway.Address,
+			Handler:           gw,
+			ReadHeaderTimeout: 60 * time.Second,
+		}
+
+		errChan := make(chan error)
+		go func() { errChan <- httpServer.ListenAndServe() }()
+
+		select {
+		case err = <-errChan:
+			return
+
+		case <-time.After(100 * time.Millisecond):
+			agents = append(agents, gw)
+		}
+	}
+
+	if conf.HTTPExit.Endpoint != "" {
+		endpoint, endpointErr := bpv7.NewEndpointID(conf.HTTPExit.Endpoint)
+		if endpointErr != nil {
+			err = endpointErr
+			return
+		}
+
+		timeout := time.Minute
+		if conf.HTTPExit.Timeout != "" {
+			if timeout, err = time.ParseDuration(conf.HTTPExit.Timeout); err != nil {
+				return
+			}
+		}
+
+		agents = append(agents, agent.NewHTTPExitAgent(endpoint, timeout))
+	}
+
+	if conf.Mail.Endpoint != "" {
+		var ma *agent.MailAgent
+		if ma, err = parseMail(conf.Mail); err != nil {
+			return
+		}
+
+		agents = append(agents, ma)
+	}
+
+	if conf.AMP.Endpoint != "" {
+		var ampAgent *amp.Agent
+		if ampAgent, err = parseAMP(conf.AMP, c); err != nil {
+			return
+		}
+
+		agents = append(agents, ampAgent)
+	}
+
+	if conf.TimeSync.Service != "" {
+		var ts *agent.TimeSyncAgent
+		if ts, err = parseTimeSync(conf.TimeSync, c); err != nil {
+			return
+		}
+
+		agents = append(agents, ts)
+	}
+
 	if (conf.Webserver != agentsWebserverConfig{}) {
 		if !conf.Webserver.Websocket && !conf.Webserver.Rest {
 			err = fmt.Errorf("webserver agent needs at least one of Websocket or REST")
 			return
 		}
 
+		location, locationErr := parseLocation(conf.Webserver.Location)
+		if locationErr != nil {
+			err = locationErr
+			return
+		}
+
 		r := mux.NewRouter()
+		r.HandleFunc("/peers", peersHandler(c)).Methods(http.MethodGet)
+		r.HandleFunc("/routing", routingHandler(c, location)).Methods(http.MethodGet)
+		r.HandleFunc("/metrics", metricsHandler(c)).Methods(http.MethodGet)
+		r.HandleFunc("/status", statusHandler(c)).Methods(http.MethodGet)
+		r.HandleFunc("/topology", topologyHandler(c))
+		r.HandleFunc("/reload", reloadHandler(reloadFunc)).Methods(http.MethodPost)
 
 		if conf.Webserver.Websocket {
 			ws := agent.NewWebSocketAgent()
+			if conf.Webserver.MaxStreamPayload > 0 {
+				ws.SetMaxStreamPayload(conf.Webserver.MaxStreamPayload)
+			}
 			r.HandleFunc("/ws", ws.ServeHTTP)
 
 			agents = append(agents, ws)
@@ -294,48 +668,390 @@ func parseAgents(conf agentsConfig) (agents []agent.ApplicationAgent, err error)
 	return
 }
 
-func parseCron(config cronConf, c *routing.Core) (*routing.Cron, error) {
-	cron := routing.NewCron()
-
-	interval, err := time.ParseDuration(config.CheckBundles)
+// parseHTTPGateway creates an HTTPGatewayAgent. Its lifetime defaults to 24h, while HTTP clients wait up to an hour.
+func parseHTTPGateway(conf agentsHTTPGatewayConfig) (*agent.HTTPGatewayAgent, error) {
+	endpoint, err := bpv7.NewEndpointID(conf.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("endpoint: %v", err)
+	}
+	exit, err := bpv7.NewEndpointID(conf.Exit)
 	if err != nil {
-		return nil, NewConfigError(fmt.Sprintf("Error parsing duration: %v", config.CheckBundles), err)
+		return nil, fmt.Errorf("exit: %v", err)
+	}
+
+	lifetime, timeout := 24*time.Hour, time.Hour
+	if conf.Lifetime != "" {
+		if lifetime, err = time.ParseDuration(conf.Lifetime); err != nil {
+			return nil, err
+		}
 	}
-	if err := cron.Register("pending_bundles", c.CheckPendingBundles, interval); err != nil {
-		return nil, NewConfigError("Failed to register pending_bundles at cron", err)
+	if conf.Timeout != "" {
+		if timeout, err = time.ParseDuration(conf.Timeout); err != nil {
+			return nil, err
+		}
 	}
 
-	interval, err = time.ParseDuration(config.CleanStore)
+	return agent.NewHTTPGatewayAgent(endpoint, exit, lifetime, timeout), nil
+}
+
+// parseMail creates a MailAgent, listening for SMTP clients if an address is configured. Its lifetime defaults to 72h.
+func parseMail(conf agentsMailConfig) (*agent.MailAgent, error) {
+	prefix, err := bpv7.NewEndpointID(conf.Endpoint)
 	if err != nil {
-		return nil, NewConfigError(fmt.Sprintf("Error parsing duration: %v", config.CleanStore), err)
+		return nil, fmt.Errorf("endpoint: %v", err)
+	}
+
+	routes := make(map[string]bpv7.EndpointID)
+	for domain, route := range conf.Routes {
+		if routes[strings.ToLower(domain)], err = bpv7.NewEndpointID(route); err != nil {
+			return nil, fmt.Errorf("route %q: %v", domain, err)
+		}
+	}
+
+	lifetime := 72 * time.Hour
+	if conf.Lifetime != "" {
+		if lifetime, err = time.ParseDuration(conf.Lifetime); err != nil {
+			return nil, err
+		}
+	}
+
+	var listener net.Listener
+	if conf.Listen != "" {
+		if listener, err = net.Listen("tcp", conf.Listen); err != nil {
+			return nil, err
+		}
 	}
-	if err := cron.Register("clean_store", c.Store.DeleteExpired, interval); err != nil {
-		return nil, NewConfigError("Failed to register clean_store at cron", err)
+
+	ma, err := agent.NewMailAgent(prefix, conf.Relay, listener, routes, lifetime)
+	if err != nil && listener != nil {
+		_ = listener.Close()
 	}
+	return ma, err
+}
 
-	interval, err = time.ParseDuration(config.CleanID)
+// parseAMP creates an AMP Agent for the Core's metrics and dtnd's settings. Its lifetime defaults to 24h.
+func parseAMP(conf agentsAMPConfig, c *routing.Core) (*amp.Agent, error) {
+	endpoint, err := bpv7.NewEndpointID(conf.Endpoint)
 	if err != nil {
-		return nil, NewConfigError(fmt.Sprintf("Error parsing duration: %v", config.CleanID), err)
+		return nil, fmt.Errorf("endpoint: %v", err)
 	}
-	if err := cron.Register("clean_ids", c.IdKeeper.Clean, interval); err != nil {
-		return nil, NewConfigError("Failed to register clean_ids at cron", err)
+
+	managers := make([]bpv7.EndpointID, 0, len(conf.Managers))
+	for _, manager := range conf.Managers {
+		eid, err := bpv7.NewEndpointID(manager)
+		if err != nil {
+			return nil, fmt.Errorf("manager %q: %v", manager, err)
+		}
+		managers = append(managers, eid)
+	}
+
+	lifetime := 24 * time.Hour
+	if conf.Lifetime != "" {
+		if lifetime, err = time.ParseDuration(conf.Lifetime); err != nil {
+			return nil, err
+		}
 	}
 
-	return cron, nil
+	return amp.NewAgent(endpoint, managers, lifetime, ampEdds(c), ampVars())
 }
 
-// parseCore creates the Core based on the given TOML configuration.
-func parseCore(filename string) (c *routing.Core, ds *discovery.Manager, err error) {
-	var conf tomlConfig
-	if _, err = toml.DecodeFile(filename, &conf); err != nil {
+// parseTimeSync creates a TimeSyncAgent for the Core's connected peers. Its interval defaults to five minutes. If
+// enabled, the clock is disciplined by the configured peers.
+func parseTimeSync(conf agentsTimeSyncConfig, c *routing.Core) (*agent.TimeSyncAgent, error) {
+	interval := 5 * time.Minute
+	if conf.Interval != "" {
+		var err error
+		if interval, err = time.ParseDuration(conf.Interval); err != nil {
+			return nil, err
+		}
+	}
+
+	var discipline *agent.TimeSyncDiscipline
+	if conf.Discipline {
+		discipline = &agent.TimeSyncDiscipline{MinPeers: conf.MinPeers}
+
+		for _, peer := range conf.Peers {
+			eid, err := bpv7.NewEndpointID(peer)
+			if err != nil {
+				return nil, err
+			}
+			discipline.Peers = append(discipline.Peers, eid)
+		}
+
+		if conf.MaxStep != "" {
+			var err error
+			if discipline.MaxStep, err = time.ParseDuration(conf.MaxStep); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return agent.NewTimeSyncAgent(c.NodeId, conf.Service, c.ConnectedPeers, interval, discipline)
+}
+
+// parseCrcType for a configured CRC type, "none", "crc16", or "crc32c". An empty value results in the given default.
+func parseCrcType(value string, defaultType bpv7.CRCType) (bpv7.CRCType, error) {
+	switch value {
+	case "":
+		return defaultType, nil
+	case "none":
+		return bpv7.CRCNo, nil
+	case "crc16":
+		return bpv7.CRC16, nil
+	case "crc32", "crc32c":
+		return bpv7.CRC32, nil
+	default:
+		return bpv7.CRCNo, fmt.Errorf("unknown CRC type %q, expected none, crc16, or crc32c", value)
+	}
+}
+
+// parseCrcPolicy creates a routing.CRCPolicy for the core configuration. Unset CRC types fall back to the default
+// types of created blocks: CRC32 for primary blocks and none for canonical blocks.
+func parseCrcPolicy(conf coreConf) (policy routing.CRCPolicy, err error) {
+	policy.Enforce = conf.CrcPrimary != "" || conf.CrcCanonical != ""
+	policy.RequireCRC = conf.RequireCrc
+
+	if policy.Primary, err = parseCrcType(conf.CrcPrimary, bpv7.CRC32); err != nil {
 		return
 	}
+	policy.Canonical, err = parseCrcType(conf.CrcCanonical, bpv7.CRCNo)
+	return
+}
+
+// parseValidationMode for a configured strict validation mode, "off", "warn", or "reject". An empty value disables it.
+func parseValidationMode(value string) (routing.ValidationMode, error) {
+	switch value {
+	case "", "off":
+		return routing.ValidationOff, nil
+	case "warn":
+		return routing.ValidationWarn, nil
+	case "reject":
+		return routing.ValidationReject, nil
+	default:
+		return routing.ValidationOff, fmt.Errorf("unknown validation mode %q, expected off, warn, or reject", value)
+	}
+}
+
+// parseStatusReportPolicy creates a routing.StatusReportPolicy for the core configuration. Status reports can be
+// disabled for "received", "forwarded", "delivered", and "deleted" bundles.
+func parseStatusReportPolicy(conf coreConf) (policy routing.StatusReportPolicy, err error) {
+	policy.Disabled = make(map[bpv7.StatusInformationPos]bool)
+	policy.RateLimit = conf.ReportRateLimit
+
+	for _, value := range conf.DisableReports {
+		switch value {
+		case "received":
+			policy.Disabled[bpv7.ReceivedBundle] = true
+		case "forwarded":
+			policy.Disabled[bpv7.ForwardedBundle] = true
+		case "delivered":
+			policy.Disabled[bpv7.DeliveredBundle] = true
+		case "deleted":
+			policy.Disabled[bpv7.DeletedBundle] = true
+		default:
+			err = fmt.Errorf("unknown status report %q, expected received, forwarded, delivered, or deleted", value)
+			return
+		}
+	}
+	return
+}
+
+// parsePriorityPolicy creates a routing.PriorityPolicy for the core configuration, mapping endpoints to priorities.
+func parsePriorityPolicy(conf coreConf) (policy routing.PriorityPolicy, err error) {
+	policy.Classes = make(map[bpv7.EndpointID]bpv7.Priority)
+	policy.StoreLimit = conf.StoreLimit
+
+	for eidStr, priorityStr := range conf.PriorityClasses {
+		eid, eidErr := bpv7.NewEndpointID(eidStr)
+		if eidErr != nil {
+			err = eidErr
+			return
+		}
+
+		priority, priorityErr := bpv7.ParsePriority(priorityStr)
+		if priorityErr != nil {
+			err = priorityErr
+			return
+		}
+
+		policy.Classes[eid] = priority
+	}
+	return
+}
+
+// parseSignaturePolicy creates a routing.SignaturePolicy for the core configuration, mapping endpoints to their
+// hex-encoded ed25519 public keys.
+func parseSignaturePolicy(conf coreConf) (policy routing.SignaturePolicy, err error) {
+	policy.Keys = make(bpv7.SignatureKeys)
+	policy.Require = conf.RequireSignatures
+
+	for eidStr, keyStr := range conf.SignatureKeys {
+		eid, eidErr := bpv7.NewEndpointID(eidStr)
+		if eidErr != nil {
+			err = eidErr
+			return
+		}
+
+		key, keyErr := hex.DecodeString(keyStr)
+		if keyErr != nil {
+			err = fmt.Errorf("key for %v: %v", eid, keyErr)
+			return
+		} else if len(key) != ed25519.PublicKeySize {
+			err = fmt.Errorf("key for %v has %d bytes, expected %d", eid, len(key), ed25519.PublicKeySize)
+			return
+		}
+
+		policy.Keys[eid] = key
+	}
+	return
+}
+
+// parsePolicyRules creates the routing.PolicyRules of the configured policy blocks, keeping their order.
+func parsePolicyRules(confs []policyConf) (rules []routing.PolicyRule, err error) {
+	for _, conf := range confs {
+		rule := routing.PolicyRule{
+			Source:      conf.Source,
+			Destination: conf.Destination,
+			CLA:         conf.CLA,
+			MinSize:     conf.MinSize,
+			MaxSize:     conf.MaxSize,
+			Limit:       conf.Limit,
+		}
+
+		switch conf.Stage {
+		case "":
+			rule.Stage = routing.PolicyAnyStage
+		case "reception":
+			rule.Stage = routing.PolicyReception
+		case "forwarding":
+			rule.Stage = routing.PolicyForwarding
+		default:
+			err = fmt.Errorf("unknown policy stage %q, expected reception or forwarding", conf.Stage)
+			return
+		}
+
+		if conf.MinLifetime != "" {
+			if rule.MinLifetime, err = time.ParseDuration(conf.MinLifetime); err != nil {
+				return
+			}
+		}
+		if conf.MaxLifetime != "" {
+			if rule.MaxLifetime, err = time.ParseDuration(conf.MaxLifetime); err != nil {
+				return
+			}
+		}
+
+		if rule.Action, err = routing.ParsePolicyAction(conf.Action); err != nil {
+			return
+		}
+
+		switch rule.Action {
+		case routing.PolicyReprioritize:
+			if rule.Priority, err = bpv7.ParsePriority(conf.Priority); err != nil {
+				return
+			}
+		case routing.PolicyLimit:
+			if rule.Limit == 0 {
+				err = fmt.Errorf("policy action limit requires a limit")
+				return
+			}
+		}
+
+		rules = append(rules, rule)
+	}
+	return
+}
+
+// parseDestinationQuotas creates the routing.DestinationQuotas of the configured destination-quota blocks.
+func parseDestinationQuotas(confs []quotaConf) (quotas []routing.DestinationQuota, err error) {
+	for _, conf := range confs {
+		if conf.Prefix == "" {
+			err = fmt.Errorf("destination-quota requires a prefix")
+			return
+		}
+
+		quotas = append(quotas, routing.DestinationQuota{
+			Prefix:  conf.Prefix,
+			Bundles: conf.Bundles,
+			Bytes:   conf.Bytes,
+		})
+	}
+	return
+}
+
+// cronJob is a periodic task of the Core, configured in the cron section.
+type cronJob struct {
+	name     string
+	task     func()
+	interval time.Duration
+}
+
+// parseCronJobs parses the intervals of the Core's periodic tasks and the jitter delaying each execution.
+func parseCronJobs(config cronConf, c *routing.Core) (jobs []cronJob, jitter time.Duration, err error) {
+	jobConfs := []struct {
+		name     string
+		task     func()
+		interval string
+	}{
+		{"pending_bundles", c.CheckPendingBundles, config.CheckBundles},
+		{"clean_store", c.DeleteExpiredBundles, config.CleanStore},
+		{"clean_ids", c.IdKeeper.Clean, config.CleanID},
+	}
+
+	for _, jobConf := range jobConfs {
+		interval, intervalErr := time.ParseDuration(jobConf.interval)
+		if intervalErr != nil {
+			err = NewConfigError(fmt.Sprintf("Error parsing duration: %v", jobConf.interval), intervalErr)
+			return
+		}
+		jobs = append(jobs, cronJob{name: jobConf.name, task: jobConf.task, interval: interval})
+	}
+
+	if config.Jitter != "" {
+		if jitter, err = time.ParseDuration(config.Jitter); err != nil {
+			err = NewConfigError(fmt.Sprintf("Error parsing duration: %v", config.Jitter), err)
+			return
+		}
+	}
+	return
+}
+
+// parseCron registers the configured periodic tasks at the Core's Cron.
+func parseCron(config cronConf, c *routing.Core) error {
+	jobs, jitter, err := parseCronJobs(config, c)
+	if err != nil {
+		return err
+	}
+
+	for _, job := range jobs {
+		if err := c.Cron.RegisterWithJitter(job.name, job.task, job.interval, jitter); err != nil {
+			return NewConfigError(fmt.Sprintf("Failed to register %s at cron", job.name), err)
+		}
+	}
+	return nil
+}
+
+// rescheduleCron applies changed intervals of the Core's periodic tasks, registered by parseCron.
+func rescheduleCron(config cronConf, c *routing.Core) error {
+	jobs, jitter, err := parseCronJobs(config, c)
+	if err != nil {
+		return err
+	}
 
-	// Logging
-	if conf.Logging.Level != "" {
-		if lvl, err := log.ParseLevel(conf.Logging.Level); err != nil {
+	for _, job := range jobs {
+		if err := c.Cron.Reschedule(job.name, job.interval, jitter); err != nil {
+			return NewConfigError(fmt.Sprintf("Failed to reschedule %s at cron", job.name), err)
+		}
+	}
+	return nil
+}
+
+// applyLogging configures the logger. Invalid values are logged and ignored.
+func applyLogging(conf logConf) {
+	if conf.Level != "" {
+		if lvl, err := log.ParseLevel(conf.Level); err != nil {
 			log.WithFields(log.Fields{
-				"level":    conf.Logging.Level,
+				"level":    conf.Level,
 				"error":    err,
 				"provided": "panic,fatal,error,warn,info,debug,trace",
 			}).Warn("Failed to set log level. Please select one of the provided ones")
@@ -344,9 +1060,9 @@ func parseCore(filename string) (c *routing.Core, ds *discovery.Manager, err err
 		}
 	}
 
-	log.SetReportCaller(conf.Logging.ReportCaller)
+	log.SetReportCaller(conf.ReportCaller)
 
-	switch conf.Logging.Format {
+	switch conf.Format {
 	case "", "text":
 		log.SetFormatter(&log.TextFormatter{
 			FullTimestamp:   true,
@@ -361,6 +1077,211 @@ func parseCore(filename string) (c *routing.Core, ds *discovery.Manager, err err
 	default:
 		log.Warn("Unknown logging format")
 	}
+}
+
+// applyCoreSettings parses and applies the Core's settings which might be changed at runtime.
+func applyCoreSettings(conf tomlConfig, c *routing.Core) (err error) {
+	crcPolicy, crcErr := parseCrcPolicy(conf.Core)
+	if crcErr != nil {
+		err = crcErr
+		return
+	}
+
+	validation, validationErr := parseValidationMode(conf.Core.Validation)
+	if validationErr != nil {
+		err = validationErr
+		return
+	}
+
+	signaturePolicy, signatureErr := parseSignaturePolicy(conf.Core)
+	if signatureErr != nil {
+		err = signatureErr
+		return
+	}
+
+	var nodeAliases []bpv7.EndpointID
+	for _, alias := range conf.Core.NodeAliases {
+		if aliasEid, aliasErr := bpv7.NewEndpointID(alias); aliasErr != nil {
+			err = aliasErr
+			return
+		} else {
+			nodeAliases = append(nodeAliases, aliasEid)
+		}
+	}
+	if conf.Core.IpnNode != 0 {
+		nodeAliases = append(nodeAliases, ipnNodeId(conf.Core.IpnNode))
+	}
+
+	compatibility, compatibilityErr := routing.NewCompatibilityProfile(conf.Core.Compatibility)
+	if compatibilityErr != nil {
+		err = compatibilityErr
+		return
+	}
+	if err = compatibility.Check(c.NodeId, nodeAliases, conf.Routing); err != nil {
+		return
+	}
+
+	var groups []bpv7.EndpointID
+	for _, group := range conf.Core.Groups {
+		if groupEid, groupErr := bpv7.NewEndpointID(group); groupErr != nil {
+			err = groupErr
+			return
+		} else {
+			groups = append(groups, groupEid)
+		}
+	}
+
+	var reportTo bpv7.EndpointID
+	if conf.Core.ReportTo != "" {
+		if reportTo, err = bpv7.NewEndpointID(conf.Core.ReportTo); err != nil {
+			return
+		}
+	}
+
+	statusReportPolicy, statusReportErr := parseStatusReportPolicy(conf.Core)
+	if statusReportErr != nil {
+		err = statusReportErr
+		return
+	}
+
+	priorityPolicy, priorityErr := parsePriorityPolicy(conf.Core)
+	if priorityErr != nil {
+		err = priorityErr
+		return
+	}
+
+	policyRules, policyErr := parsePolicyRules(conf.Policy)
+	if policyErr != nil {
+		err = policyErr
+		return
+	}
+
+	quotas, quotaErr := parseDestinationQuotas(conf.Quota)
+	if quotaErr != nil {
+		err = quotaErr
+		return
+	}
+
+	trafficShaping := routing.TrafficShapingPolicy{Budget: conf.Core.PeerBudget, Window: time.Second}
+	if conf.Core.PeerBudgetWindow != "" {
+		if trafficShaping.Window, err = time.ParseDuration(conf.Core.PeerBudgetWindow); err != nil {
+			return
+		}
+	}
+
+	var retryPolicy routing.RetryPolicy
+	if conf.Core.RetryInitial != "" {
+		if retryPolicy.Initial, err = time.ParseDuration(conf.Core.RetryInitial); err != nil {
+			return
+		}
+	}
+	if conf.Core.RetryMax != "" {
+		if retryPolicy.Max, err = time.ParseDuration(conf.Core.RetryMax); err != nil {
+			return
+		}
+	}
+
+	var routingCheckpoint time.Duration
+	if conf.Core.RoutingCheckpoint != "" {
+		if routingCheckpoint, err = time.ParseDuration(conf.Core.RoutingCheckpoint); err != nil {
+			return
+		}
+	}
+
+	var clockSkew routing.ClockSkewPolicy
+	if conf.Core.ClockTolerance != "" {
+		if clockSkew.Tolerance, err = time.ParseDuration(conf.Core.ClockTolerance); err != nil {
+			return
+		}
+	}
+
+	custodyPolicy := routing.CustodyPolicy{Accept: conf.Core.Custody, Retransmit: 5 * time.Minute}
+	if conf.Core.CustodyRetransmit != "" {
+		if custodyPolicy.Retransmit, err = time.ParseDuration(conf.Core.CustodyRetransmit); err != nil {
+			return
+		}
+	}
+
+	if conf.Core.HopLimit > math.MaxUint8 {
+		err = fmt.Errorf("core.hop-limit %d exceeds %d", conf.Core.HopLimit, math.MaxUint8)
+		return
+	}
+	if conf.Core.BroadcastHopLimit > math.MaxUint8 {
+		err = fmt.Errorf("core.broadcast-hop-limit %d exceeds %d", conf.Core.BroadcastHopLimit, math.MaxUint8)
+		return
+	}
+
+	erasureCoding := routing.ErasureCoding{MinSize: conf.Core.ErasureMinSize, DataShards: 4, ParityShards: 2}
+	if conf.Core.ErasureData > 0 {
+		erasureCoding.DataShards, erasureCoding.ParityShards = int(conf.Core.ErasureData), int(conf.Core.ErasureParity)
+	}
+
+	if err = c.SetNodeAliases(nodeAliases); err != nil {
+		return
+	}
+
+	if err = c.SetGroupMemberships(groups); err != nil {
+		return
+	}
+
+	c.SetFragmentMtu(int(conf.Core.FragmentMtu))
+
+	c.SetHopLimit(uint8(conf.Core.HopLimit))
+	c.SetTransitLog(conf.Core.TransitLog)
+	c.SetBroadcastScope(routing.BroadcastScope{
+		HopLimit: uint8(conf.Core.BroadcastHopLimit),
+		Region:   conf.Core.BroadcastRegion,
+	})
+	c.SetClockless(conf.Core.Clockless)
+	c.SetCRCPolicy(crcPolicy)
+	c.SetSignaturePolicy(signaturePolicy)
+	c.SetCompatibilityProfile(compatibility)
+	c.SetValidationMode(validation)
+	c.SetClockSkewPolicy(clockSkew)
+	c.SetStorageAdmissionPolicy(routing.StorageAdmissionPolicy{
+		MinFreeSpace: conf.Core.MinFreeSpace,
+		Quota:        conf.Core.StoreQuota,
+	})
+	c.SetReportTo(reportTo)
+	c.SetCustodyPolicy(custodyPolicy)
+	c.SetStatusReportPolicy(statusReportPolicy)
+	c.SetKnownBundles(conf.Core.KnownBundles)
+	c.SetContentCache(conf.Core.ContentCache)
+	if err = c.SetErasureCoding(erasureCoding); err != nil {
+		return
+	}
+	c.SetPriorityPolicy(priorityPolicy)
+	c.SetTrafficShapingPolicy(trafficShaping)
+	c.SetRetryPolicy(retryPolicy)
+	c.SetPolicyRules(policyRules)
+	c.SetDestinationQuotas(quotas)
+	c.SetProcessingWorkers(conf.Core.Workers)
+	c.Store.SetWriteBatching(int(conf.Core.StoreBatch))
+	c.Store.SetBundleCache(int(conf.Core.StoreCache))
+
+	if err = c.SetEventLog(conf.Core.EventLog); err != nil {
+		return
+	}
+
+	if err = c.SetRoutingCheckpoint(routingCheckpoint); err != nil {
+		return
+	}
+
+	return
+}
+
+// parseCore creates the Core based on the given TOML configuration.
+func parseCore(filename string) (d *daemon, err error) {
+	var conf tomlConfig
+	if conf, _, err = loadConfiguration(filename); err != nil {
+		return
+	}
+
+	var c *routing.Core
+	var ds *discovery.Manager
+	d = newDaemon(filename, conf)
+
+	applyLogging(conf.Logging)
 
 	var discoveryMsgs []discovery.Announcement
 
@@ -387,19 +1308,34 @@ func parseCore(filename string) (c *routing.Core, ds *discovery.Manager, err err
 		}
 	}
 
+	// Large payloads are parsed into files below the store. Files of a previous run are obsolete.
+	if conf.Core.PayloadStream > 0 {
+		payloadDir := path.Join(conf.Core.Store, "payloads")
+		if err = os.RemoveAll(payloadDir); err != nil {
+			return
+		}
+		if err = os.MkdirAll(payloadDir, 0700); err != nil {
+			return
+		}
+		bpv7.SetPayloadStreamThreshold(conf.Core.PayloadStream, payloadDir)
+	}
+
 	if c, err = routing.NewCore(conf.Core.Store, nodeId, conf.Core.InspectAllBundles, conf.Routing, signPriv); err != nil {
 		return
 	}
+	d.core = c
 
-	cron, err := parseCron(conf.Cron, c)
-	if err != nil {
+	if err = applyCoreSettings(conf, c); err != nil {
+		return
+	}
+
+	if err = parseCron(conf.Cron, c); err != nil {
 		return
 	}
-	c.Cron = cron
 
 	// Agents
-	if conf.Agents != (agentsConfig{}) {
-		if appAgents, appErr := parseAgents(conf.Agents); appErr != nil {
+	if !reflect.DeepEqual(conf.Agents, agentsConfig{}) {
+		if appAgents, appErr := parseAgents(conf.Agents, c, d.reload); appErr != nil {
 			err = appErr
 			return
 		} else {
@@ -409,45 +1345,119 @@ func parseCore(filename string) (c *routing.Core, ds *discovery.Manager, err err
 		}
 	}
 
+	// Metrics
+	if conf.Metrics.Address != "" {
+		if err = startMetricsServer(conf.Metrics, c); err != nil {
+			return
+		}
+	}
+	if conf.Metrics.StatusFile != "" {
+		if err = startStatusFile(conf.Metrics, c); err != nil {
+			return
+		}
+	}
+
 	// Listen/ConvergenceReceiver
 	for _, conv := range conf.Listen {
-		if convRec, eid, claType, discoMsg, lErr := parseListen(conv, c.NodeId); lErr != nil {
-			err = lErr
+		if err = d.addListener(conv); err != nil {
 			return
-		} else {
-			c.RegisterCLA(convRec, claType, eid)
-			if discoMsg != (discovery.Announcement{}) {
-				discoveryMsgs = append(discoveryMsgs, discoMsg)
-			}
 		}
 	}
+	discoveryMsgs = d.announcements()
 
 	// Peer/ConvergenceSender
 	for _, conv := range conf.Peer {
-		convRec, err := parsePeer(conv, c.NodeId)
-		if err != nil {
-			log.WithFields(log.Fields{
-				"peer":  conv.Endpoint,
-				"error": err,
-			}).Warn("Failed to establish a connection to a peer")
-			continue
-		}
+		d.addPeer(conv)
+	}
 
-		c.RegisterConvergable(convRec)
+	// Control
+	if conf.Control.Socket != "" {
+		if err = d.startControlServer(conf.Control); err != nil {
+			return
+		}
 	}
 
 	// Discovery
-	if conf.Discovery.IPv4 || conf.Discovery.IPv6 {
+	if conf.Discovery.IPv4 || conf.Discovery.IPv6 || conf.Discovery.DNSSD || conf.Discovery.IPND ||
+		conf.Discovery.BLE || len(conf.Discovery.Static) > 0 {
 		if conf.Discovery.Interval == 0 {
 			conf.Discovery.Interval = 10
 		}
 
-		ds, err = discovery.NewManager(
-			c.NodeId, c.RegisterConvergable, discoveryMsgs,
-			time.Duration(conf.Discovery.Interval)*time.Second, conf.Discovery.IPv4, conf.Discovery.IPv6)
+		if conf.Discovery.Passive {
+			ds, err = discovery.NewPassiveManager(
+				c.NodeId, c.RegisterConvergable,
+				time.Duration(conf.Discovery.Interval)*time.Second, conf.Discovery.IPv4, conf.Discovery.IPv6,
+				conf.Discovery.Interfaces...)
+		} else {
+			ds, err = discovery.NewManager(
+				c.NodeId, c.RegisterConvergable, discoveryMsgs,
+				time.Duration(conf.Discovery.Interval)*time.Second, conf.Discovery.IPv4, conf.Discovery.IPv6,
+				conf.Discovery.Interfaces...)
+		}
 		if err != nil {
 			return
 		}
+		d.discovery = ds
+
+		ds.SetTTL(time.Duration(conf.Discovery.TTL) * time.Second)
+		ds.SetExpiry(conf.Discovery.Expiry, c.UnregisterConvergable)
+		// Appeared peers are reported by their CLAs once connected.
+		ds.SetEventFunc(func(event discovery.PeerEvent) {
+			if event.Type == discovery.PeerDisappeared {
+				c.ReportPeerUnreachable(event.Convergable)
+			}
+		})
+		c.SetDiscoveredPeersFunc(discoveredPeers(ds))
+		ds.SetErrorFunc(restartDiscoveryOnError(ds, time.Duration(conf.Discovery.Interval)*time.Second))
+
+		if conf.Discovery.Gossip {
+			ds.SetGossip(c.ConnectedPeers, c.ReportPeerNeighbors)
+		}
+
+		if conf.Discovery.Key != "" {
+			var key []byte
+			if key, err = hex.DecodeString(conf.Discovery.Key); err != nil {
+				return
+			}
+			ds.SetKey(key)
+		}
+
+		if conf.Discovery.DNSSD {
+			if err = ds.StartDNSSD(); err != nil {
+				return
+			}
+		}
+
+		if conf.Discovery.IPND {
+			if err = ds.StartIPND(); err != nil {
+				return
+			}
+		}
+
+		if conf.Discovery.BLE {
+			if err = ds.StartBLE(conf.Discovery.BLEDevice); err != nil {
+				return
+			}
+		}
+
+		if len(conf.Discovery.Static) > 0 {
+			var staticPeers []discovery.Peer
+			if staticPeers, err = parseStaticPeers(conf.Discovery.Static); err != nil {
+				return
+			}
+
+			if err = ds.StartStatic(staticPeers); err != nil {
+				return
+			}
+		}
+	}
+
+	// Energy
+	if conf.Energy.BatteryFile != "" {
+		if err = d.startEnergyMonitor(conf.Energy); err != nil {
+			return
+		}
 	}
 
 	return
@@ -0,0 +1,147 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/dtn7/dtn7-go/pkg/control"
+)
+
+// controlConf describes the Control-configuration block.
+type controlConf struct {
+	Socket string
+}
+
+// bundleResponse describes a stored bundle in the result of the "bundles" control command.
+type bundleResponse struct {
+	Id         string `json:"id"`
+	Pending    bool   `json:"pending"`
+	Expires    string `json:"expires"`
+	Fragmented bool   `json:"fragmented"`
+}
+
+// startControlServer listens on the configured Unix socket for the dtnctl commands.
+func (d *daemon) startControlServer(conf controlConf) error {
+	s, err := control.Listen(conf.Socket)
+	if err != nil {
+		return err
+	}
+
+	s.Handle("status", d.controlStatus)
+	s.Handle("bundles", d.controlBundles)
+	s.Handle("delete", d.controlDelete)
+	s.Handle("add-peer", d.controlAddPeer)
+	s.Handle("log-level", controlLogLevel)
+
+	d.control = s
+
+	log.WithField("socket", conf.Socket).Info("Started control server")
+	return nil
+}
+
+// controlStatus returns the Core's status, as for a status dump.
+func (d *daemon) controlStatus(_ []string) (interface{}, error) {
+	var b strings.Builder
+	err := d.core.WriteStatus(&b)
+	return b.String(), err
+}
+
+// controlBundles lists all stored bundles.
+func (d *daemon) controlBundles(_ []string) (interface{}, error) {
+	bis, err := d.core.Store.QueryAll()
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]bundleResponse, 0, len(bis))
+	for _, bi := range bis {
+		resp = append(resp, bundleResponse{
+			Id:         bi.Id,
+			Pending:    bi.Pending,
+			Expires:    bi.Expires.Format(time.RFC3339),
+			Fragmented: bi.Fragmented,
+		})
+	}
+	return resp, nil
+}
+
+// controlDelete deletes the stored bundles of the given IDs, as listed by controlBundles.
+func (d *daemon) controlDelete(args []string) (interface{}, error) {
+	if len(args) == 0 {
+		return nil, fmt.Errorf("delete requires at least one bundle ID")
+	}
+
+	bis, err := d.core.Store.QueryAll()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range args {
+		found := false
+		for _, bi := range bis {
+			if bi.Id != id {
+				continue
+			}
+
+			found = true
+			if err := d.core.DeleteBundle(bi.BId); err != nil {
+				return nil, err
+			}
+		}
+
+		if !found {
+			return nil, fmt.Errorf("bundle %s is unknown", id)
+		}
+	}
+	return len(args), nil
+}
+
+// controlAddPeer connects to a peer until the next restart: protocol, endpoint, and an optional node ID.
+func (d *daemon) controlAddPeer(args []string) (interface{}, error) {
+	if len(args) != 2 && len(args) != 3 {
+		return nil, fmt.Errorf("add-peer requires a protocol, an endpoint, and an optional node ID")
+	}
+
+	conv := convergenceConf{Protocol: args[0], Endpoint: args[1]}
+	if len(args) == 3 {
+		conv.Node = args[2]
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if _, exists := d.peers[conv]; exists {
+		return nil, fmt.Errorf("peer %s is already configured", conv.Endpoint)
+	}
+
+	convRec, err := parsePeer(conv, d.core.NodeId)
+	if err != nil {
+		return nil, err
+	}
+
+	d.core.RegisterConvergable(convRec)
+	d.peers[conv] = convRec
+	return conv.Endpoint, nil
+}
+
+// controlLogLevel sets the log level until the next reload or restart.
+func controlLogLevel(args []string) (interface{}, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("log-level requires a level")
+	}
+
+	level, err := log.ParseLevel(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	log.SetLevel(level)
+	return level.String(), nil
+}
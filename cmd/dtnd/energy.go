@@ -0,0 +1,172 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package main
+
+import (
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/dtn7/dtn7-go/pkg/cla"
+	"github.com/dtn7/dtn7-go/pkg/energy"
+)
+
+// energyConf describes the Energy-configuration block, restricting dtnd in a low power mode on a low battery.
+type energyConf struct {
+	BatteryFile       string   `toml:"battery-file"`
+	LowLevel          float64  `toml:"low-level"`
+	ResumeLevel       float64  `toml:"resume-level"`
+	CheckInterval     string   `toml:"check-interval"`
+	DiscoveryInterval uint     `toml:"discovery-interval"`
+	Pause             []string `toml:"pause"`
+	DeferBulk         bool     `toml:"defer-bulk"`
+}
+
+// thresholds of the battery level in percent for entering the low power mode and resuming the normal one, 20 and 10
+// percent above by default.
+func (conf energyConf) thresholds() (low, resume float64) {
+	low, resume = conf.LowLevel, conf.ResumeLevel
+	if low == 0 {
+		low = 20
+	}
+	if resume == 0 {
+		resume = low + 10
+		if resume > 100 {
+			resume = 100
+		}
+	}
+	return
+}
+
+// discoveryInterval in the low power mode, one minute by default.
+func (conf energyConf) discoveryInterval() time.Duration {
+	if conf.DiscoveryInterval == 0 {
+		return time.Minute
+	}
+	return time.Duration(conf.DiscoveryInterval) * time.Second
+}
+
+// pauses checks if a CLA of this protocol is paused in the low power mode.
+func (conf energyConf) pauses(protocol string) bool {
+	for _, p := range conf.Pause {
+		if p == protocol {
+			return true
+		}
+	}
+	return false
+}
+
+// startEnergyMonitor checks the configured battery level each interval, one minute by default, to switch between the
+// normal and the low power mode.
+func (d *daemon) startEnergyMonitor(conf energyConf) error {
+	interval := time.Minute
+	if conf.CheckInterval != "" {
+		var err error
+		if interval, err = time.ParseDuration(conf.CheckInterval); err != nil {
+			return err
+		}
+	}
+
+	low, resume := conf.thresholds()
+	monitor, err := energy.NewMonitor(energy.FileSource(conf.BatteryFile), low, resume, d.setEnergyMode)
+	if err != nil {
+		return err
+	}
+
+	log.WithFields(log.Fields{
+		"battery":  conf.BatteryFile,
+		"low":      low,
+		"resume":   resume,
+		"interval": interval,
+	}).Info("Starting energy monitor")
+
+	d.energy = monitor
+	monitor.Start(interval)
+	return nil
+}
+
+// setEnergyMode is called by the energy monitor for each mode change.
+func (d *daemon) setEnergyMode(mode energy.Mode) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.lowPower = mode == energy.LowPower
+	if err := d.applyEnergyMode(); err != nil {
+		log.WithError(err).WithField("mode", mode).Warn("Applying energy mode erred")
+	}
+}
+
+// applyEnergyMode restricts or restores the discovery's interval, the CLAs to be paused, and forwarding bulk bundles
+// for the current energy mode. It is also called after a configuration reload and requires the daemon's mutex.
+func (d *daemon) applyEnergyMode() error {
+	conf := d.conf.Energy
+
+	d.core.SetDeferBulk(d.lowPower && conf.DeferBulk)
+
+	d.pauseConvergences(d.listeners, d.pausedListeners, conf, d.addListener)
+	d.pauseConvergences(d.peers, d.pausedPeers, conf, func(conv convergenceConf) error {
+		d.addPeer(conv)
+		return nil
+	})
+
+	if d.discovery == nil {
+		return nil
+	}
+	if err := d.discovery.SetInterval(d.discoveryInterval()); err != nil {
+		return err
+	}
+	if !d.discovery.IsPassive() {
+		return d.discovery.SetAnnouncements(d.announcements())
+	}
+	return nil
+}
+
+// pauseConvergences unregisters CLAs of paused protocols in the low power mode. Otherwise, previously paused CLAs
+// which are still configured are started again by the addFunc.
+func (d *daemon) pauseConvergences(
+	convs map[convergenceConf]cla.Convergable, paused map[convergenceConf]bool, conf energyConf,
+	addFunc func(convergenceConf) error) {
+
+	for conv, convRec := range convs {
+		if paused[conv] || !d.lowPower || !conf.pauses(conv.Protocol) {
+			continue
+		}
+
+		d.unregisterConvergence(conv, convRec)
+		paused[conv] = true
+		log.WithField("cla", conv.Endpoint).Info("Paused CLA in low power mode")
+	}
+
+	for conv := range paused {
+		if d.lowPower && conf.pauses(conv.Protocol) {
+			continue
+		}
+
+		delete(paused, conv)
+		if _, configured := convs[conv]; !configured {
+			continue
+		}
+
+		delete(convs, conv)
+		if err := addFunc(conv); err != nil {
+			log.WithField("cla", conv.Endpoint).WithError(err).Warn("Failed to resume paused CLA")
+		} else {
+			log.WithField("cla", conv.Endpoint).Info("Resumed paused CLA")
+		}
+	}
+}
+
+// discoveryInterval of the current energy mode.
+func (d *daemon) discoveryInterval() time.Duration {
+	if d.lowPower {
+		return d.conf.Energy.discoveryInterval()
+	}
+
+	interval := d.conf.Discovery.Interval
+	if interval == 0 {
+		interval = 10
+	}
+	return time.Duration(interval) * time.Second
+}
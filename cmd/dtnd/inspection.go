@@ -0,0 +1,89 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/dtn7/dtn7-go/pkg/routing"
+)
+
+// neighborResponse describes a Neighbor in the JSON response of the "/routing" endpoint.
+type neighborResponse struct {
+	Endpoint  string  `json:"endpoint"`
+	Connected bool    `json:"connected"`
+	Quality   float64 `json:"quality"`
+	LastSeen  string  `json:"last_seen"`
+}
+
+// routingResponse describes the Core's routing state in the JSON response of the "/routing" endpoint. The location
+// is this node's configured [latitude, longitude], if any.
+type routingResponse struct {
+	Node         string             `json:"node"`
+	Time         string             `json:"time"`
+	Algorithm    string             `json:"algorithm"`
+	Location     []float64          `json:"location,omitempty"`
+	Neighbors    []neighborResponse `json:"neighbors"`
+	RoutingTable map[string]string  `json:"routing_table,omitempty"`
+}
+
+// parseLocation of a "latitude,longitude" pair in decimal degrees. An empty value results in no location.
+func parseLocation(value string) ([]float64, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	fields := strings.Split(value, ",")
+	if len(fields) != 2 {
+		return nil, fmt.Errorf("location %q is no \"latitude,longitude\" pair", value)
+	}
+
+	lat, err := strconv.ParseFloat(strings.TrimSpace(fields[0]), 64)
+	if err != nil || lat < -90 || lat > 90 {
+		return nil, fmt.Errorf("location %q has an invalid latitude", value)
+	}
+	lon, err := strconv.ParseFloat(strings.TrimSpace(fields[1]), 64)
+	if err != nil || lon < -180 || lon > 180 {
+		return nil, fmt.Errorf("location %q has an invalid longitude", value)
+	}
+
+	return []float64{lat, lon}, nil
+}
+
+// routingHandler returns the Core's routing state as JSON, e.g., for dtntopo to export the network's topology.
+func routingHandler(c *routing.Core, location []float64) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		inspection := c.Inspect()
+
+		resp := routingResponse{
+			Node:         inspection.Node.String(),
+			Time:         inspection.Time.Format(time.RFC3339),
+			Algorithm:    inspection.Algorithm,
+			Location:     location,
+			Neighbors:    make([]neighborResponse, 0, len(inspection.Neighbors)),
+			RoutingTable: inspection.RoutingTable,
+		}
+		for _, n := range inspection.Neighbors {
+			resp.Neighbors = append(resp.Neighbors, neighborResponse{
+				Endpoint:  n.Endpoint.String(),
+				Connected: n.Connected(),
+				Quality:   n.Quality(),
+				LastSeen:  n.LastSeen.Format(time.RFC3339),
+			})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			log.WithError(err).Warn("Failed to write routing response")
+		}
+	}
+}
@@ -0,0 +1,208 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"github.com/BurntSushi/toml"
+)
+
+// includeKey is the top-level key of a configuration file listing further files to be included.
+const includeKey = "include"
+
+// maxIncludeDepth limits nested includes as a safeguard besides the cycle detection.
+const maxIncludeDepth = 8
+
+// envVarPattern matches "${NAME}" and "${NAME:-default}" within string values.
+var envVarPattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(:-([^}]*))?\}`)
+
+// expandEnv replaces all environment variable references within a string value. Unset variables without a default
+// result in an error, as an empty node ID or key would otherwise be used silently.
+func expandEnv(value string) (string, error) {
+	var err error
+	expanded := envVarPattern.ReplaceAllStringFunc(value, func(ref string) string {
+		match := envVarPattern.FindStringSubmatch(ref)
+		if v, ok := os.LookupEnv(match[1]); ok {
+			return v
+		} else if match[2] != "" {
+			return match[3]
+		}
+
+		if err == nil {
+			err = fmt.Errorf("environment variable %s is not set", match[1])
+		}
+		return ref
+	})
+	return expanded, err
+}
+
+// expandEnvValues recursively replaces environment variable references within all string values of a decoded table.
+func expandEnvValues(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		return expandEnv(v)
+
+	case map[string]interface{}:
+		for key, elem := range v {
+			expanded, err := expandEnvValues(elem)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %v", key, err)
+			}
+			v[key] = expanded
+		}
+		return v, nil
+
+	case []map[string]interface{}:
+		for i, elem := range v {
+			if _, err := expandEnvValues(elem); err != nil {
+				return nil, fmt.Errorf("[%d]: %v", i, err)
+			}
+		}
+		return v, nil
+
+	case []interface{}:
+		for i, elem := range v {
+			expanded, err := expandEnvValues(elem)
+			if err != nil {
+				return nil, fmt.Errorf("[%d]: %v", i, err)
+			}
+			v[i] = expanded
+		}
+		return v, nil
+
+	default:
+		return v, nil
+	}
+}
+
+// mergeTables merges the src table into dst. Nested tables are merged recursively and arrays of tables, e.g., the
+// "listen" or "peer" blocks, are appended. All other values of src replace those of dst.
+func mergeTables(dst, src map[string]interface{}) {
+	for key, srcValue := range src {
+		switch s := srcValue.(type) {
+		case map[string]interface{}:
+			if d, ok := dst[key].(map[string]interface{}); ok {
+				mergeTables(d, s)
+				continue
+			}
+
+		case []map[string]interface{}:
+			if d, ok := dst[key].([]map[string]interface{}); ok {
+				dst[key] = append(d, s...)
+				continue
+			}
+		}
+
+		dst[key] = srcValue
+	}
+}
+
+// includedFiles lists the files of a table's include key, relative to the including file's directory. Each entry
+// might be a glob pattern, whose matches are included in lexical order.
+func includedFiles(table map[string]interface{}, filename string) ([]string, error) {
+	var patterns []string
+	switch v := table[includeKey].(type) {
+	case nil:
+		return nil, nil
+	case string:
+		patterns = []string{v}
+	case []interface{}:
+		for _, elem := range v {
+			pattern, ok := elem.(string)
+			if !ok {
+				return nil, fmt.Errorf("%s must be a list of file names", includeKey)
+			}
+			patterns = append(patterns, pattern)
+		}
+	default:
+		return nil, fmt.Errorf("%s must be a file name or a list of file names", includeKey)
+	}
+
+	var files []string
+	for _, pattern := range patterns {
+		if !filepath.IsAbs(pattern) {
+			pattern = filepath.Join(filepath.Dir(filename), pattern)
+		}
+
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, err
+		} else if len(matches) == 0 {
+			return nil, fmt.Errorf("included %s does not exist", pattern)
+		}
+
+		sort.Strings(matches)
+		files = append(files, matches...)
+	}
+	return files, nil
+}
+
+// loadTable of a configuration file with its environment variables expanded and all includes merged. Included files
+// are merged first in their listed order, allowing the including file to override their values. Errors of included
+// files are prefixed by their file names.
+func loadTable(filename string, parents []string) (map[string]interface{}, error) {
+	abs, err := filepath.Abs(filename)
+	if err != nil {
+		return nil, err
+	}
+	for _, parent := range parents {
+		if parent == abs {
+			return nil, fmt.Errorf("included recursively")
+		}
+	}
+	if len(parents) >= maxIncludeDepth {
+		return nil, fmt.Errorf("%s exceeds the maximum include depth of %d", filename, maxIncludeDepth)
+	}
+
+	table := make(map[string]interface{})
+	if _, err := toml.DecodeFile(filename, &table); err != nil {
+		return nil, err
+	}
+	if _, err := expandEnvValues(table); err != nil {
+		return nil, err
+	}
+
+	files, err := includedFiles(table, filename)
+	if err != nil {
+		return nil, err
+	}
+	delete(table, includeKey)
+
+	merged := make(map[string]interface{})
+	for _, file := range files {
+		included, err := loadTable(file, append(parents, abs))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", file, err)
+		}
+		mergeTables(merged, included)
+	}
+	mergeTables(merged, table)
+
+	return merged, nil
+}
+
+// loadConfiguration from a TOML file. String values may reference environment variables as "${NAME}" or
+// "${NAME:-default}", e.g., to inject secrets or node IDs into containers at runtime. Further files, possibly glob
+// patterns, can be included by a top-level "include" list; see loadTable for the merging.
+func loadConfiguration(filename string) (conf tomlConfig, meta toml.MetaData, err error) {
+	table, err := loadTable(filename, nil)
+	if err != nil {
+		return
+	}
+
+	// The merged table is decoded again into the typed configuration to reuse toml's type conversions.
+	var buf bytes.Buffer
+	if err = toml.NewEncoder(&buf).Encode(table); err != nil {
+		return
+	}
+	meta, err = toml.Decode(buf.String(), &conf)
+	return
+}
@@ -0,0 +1,238 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeConfigFiles writes files, mapping names to their content, into a temporary directory, which is returned.
+func writeConfigFiles(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	for name, content := range files {
+		filename := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filename, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestExpandEnv(t *testing.T) {
+	t.Setenv("DTND_TEST_NODE", "dtn://node/")
+	t.Setenv("DTND_TEST_EMPTY", "")
+
+	tests := []struct {
+		value    string
+		expanded string
+		valid    bool
+	}{
+		{"dtn://node/", "dtn://node/", true},
+		{"${DTND_TEST_NODE}", "dtn://node/", true},
+		{"${DTND_TEST_NODE}ping", "dtn://node/ping", true},
+		{"${DTND_TEST_NODE:-dtn://other/}", "dtn://node/", true},
+		{"${DTND_TEST_EMPTY}", "", true},
+		{"${DTND_TEST_EMPTY:-fallback}", "", true},
+		{"${DTND_TEST_UNSET:-fallback}", "fallback", true},
+		{"${DTND_TEST_UNSET:-}", "", true},
+		{"$DTND_TEST_NODE", "$DTND_TEST_NODE", true},
+		{"${DTND_TEST_UNSET}", "", false},
+		{"${DTND_TEST_NODE}${DTND_TEST_UNSET}", "", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			expanded, err := expandEnv(test.value)
+			if (err == nil) != test.valid {
+				t.Fatalf("expected valid %t, got %v", test.valid, err)
+			} else if test.valid && expanded != test.expanded {
+				t.Fatalf("expected %q, got %q", test.expanded, expanded)
+			}
+		})
+	}
+}
+
+func TestLoadConfigurationEnv(t *testing.T) {
+	t.Setenv("DTND_TEST_NODE", "dtn://node/")
+
+	dir := writeConfigFiles(t, map[string]string{
+		"dtnd.toml": `
+[core]
+node-id = "${DTND_TEST_NODE}"
+store = "${DTND_TEST_STORE:-store}"
+
+[[listen]]
+node = "${DTND_TEST_NODE}"
+protocol = "mtcp"
+endpoint = ":4556"
+
+[discovery]
+announce = ["${DTND_TEST_PROTOCOL:-mtcp}"]`,
+	})
+
+	conf, _, err := loadConfiguration(filepath.Join(dir, "dtnd.toml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if conf.Core.NodeId != "dtn://node/" || conf.Core.Store != "store" {
+		t.Fatalf("core was not expanded: %v", conf.Core)
+	}
+	if len(conf.Listen) != 1 || conf.Listen[0].Node != "dtn://node/" {
+		t.Fatalf("listen was not expanded: %v", conf.Listen)
+	}
+	if len(conf.Discovery.Announce) != 1 || conf.Discovery.Announce[0] != "mtcp" {
+		t.Fatalf("discovery was not expanded: %v", conf.Discovery.Announce)
+	}
+}
+
+func TestLoadConfigurationUnsetEnv(t *testing.T) {
+	tests := []struct {
+		name string
+		conf string
+	}{
+		{"string", "[core]\nnode-id = \"${DTND_TEST_UNSET}\""},
+		{"array", "[discovery]\nannounce = [\"${DTND_TEST_UNSET}\"]"},
+		{"array of tables", "[[listen]]\nnode = \"${DTND_TEST_UNSET}\""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dir := writeConfigFiles(t, map[string]string{"dtnd.toml": test.conf})
+
+			_, _, err := loadConfiguration(filepath.Join(dir, "dtnd.toml"))
+			if err == nil {
+				t.Fatal("unset environment variable was accepted")
+			} else if !strings.Contains(err.Error(), "DTND_TEST_UNSET") {
+				t.Fatalf("error does not name the variable: %v", err)
+			}
+		})
+	}
+}
+
+func TestLoadConfigurationInclude(t *testing.T) {
+	dir := writeConfigFiles(t, map[string]string{
+		"dtnd.toml": `
+include = ["common.toml", "conf.d/*.toml"]
+
+[core]
+node-id = "dtn://node/"
+
+[[listen]]
+protocol = "mtcp"
+endpoint = ":4556"`,
+		"common.toml": `
+[core]
+node-id = "dtn://common/"
+store = "common-store"
+
+[logging]
+level = "info"`,
+		"conf.d/10-peer.toml": `
+[[listen]]
+protocol = "tcpclv4"
+endpoint = ":4557"
+
+[logging]
+level = "debug"`,
+		"conf.d/20-peer.toml": `
+[[peer]]
+node = "dtn://peer/"
+protocol = "mtcp"
+endpoint = "peer:4556"`,
+	})
+
+	conf, meta, err := loadConfiguration(filepath.Join(dir, "dtnd.toml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if undecoded := meta.Undecoded(); len(undecoded) != 0 {
+		t.Fatalf("undecoded keys: %v", undecoded)
+	}
+	if conf.Core.NodeId != "dtn://node/" {
+		t.Fatalf("including file did not override the node ID: %s", conf.Core.NodeId)
+	}
+	if conf.Core.Store != "common-store" {
+		t.Fatalf("included store was not merged: %s", conf.Core.Store)
+	}
+	if conf.Logging.Level != "debug" {
+		t.Fatalf("later include did not override the log level: %s", conf.Logging.Level)
+	}
+	if len(conf.Listen) != 2 || conf.Listen[0].Protocol != "tcpclv4" || conf.Listen[1].Protocol != "mtcp" {
+		t.Fatalf("listen blocks were not appended in order: %v", conf.Listen)
+	}
+	if len(conf.Peer) != 1 || conf.Peer[0].Node != "dtn://peer/" {
+		t.Fatalf("included peer is missing: %v", conf.Peer)
+	}
+}
+
+func TestLoadConfigurationIncludeErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		err   string
+	}{
+		{"missing file", map[string]string{
+			"dtnd.toml": `include = ["missing.toml"]`,
+		}, "does not exist"},
+		{"glob without matches", map[string]string{
+			"dtnd.toml": `include = ["conf.d/*.toml"]`,
+		}, "does not exist"},
+		{"self include", map[string]string{
+			"dtnd.toml": `include = ["dtnd.toml"]`,
+		}, "included recursively"},
+		{"include cycle", map[string]string{
+			"dtnd.toml": `include = ["a.toml"]`,
+			"a.toml":    `include = ["b.toml"]`,
+			"b.toml":    `include = ["./dtnd.toml"]`,
+		}, "included recursively"},
+		{"invalid include key", map[string]string{
+			"dtnd.toml": `include = 42`,
+		}, "must be a file name"},
+		{"invalid include entry", map[string]string{
+			"dtnd.toml": `include = ["a.toml", 42]`,
+		}, "must be a list of file names"},
+		{"invalid included file", map[string]string{
+			"dtnd.toml": `include = ["a.toml"]`,
+			"a.toml":    `[core`,
+		}, "a.toml"},
+		{"unset variable in included file", map[string]string{
+			"dtnd.toml": `include = ["a.toml"]`,
+			"a.toml":    "[core]\nnode-id = \"${DTND_TEST_UNSET}\"",
+		}, "DTND_TEST_UNSET"},
+		{"maximum include depth", map[string]string{
+			"dtnd.toml": `include = ["1.toml"]`,
+			"1.toml":    `include = ["2.toml"]`,
+			"2.toml":    `include = ["3.toml"]`,
+			"3.toml":    `include = ["4.toml"]`,
+			"4.toml":    `include = ["5.toml"]`,
+			"5.toml":    `include = ["6.toml"]`,
+			"6.toml":    `include = ["7.toml"]`,
+			"7.toml":    `include = ["8.toml"]`,
+			"8.toml":    `include = ["9.toml"]`,
+			"9.toml":    ``,
+		}, "maximum include depth"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dir := writeConfigFiles(t, test.files)
+
+			_, _, err := loadConfiguration(filepath.Join(dir, "dtnd.toml"))
+			if err == nil {
+				t.Fatalf("expected an error containing %q", test.err)
+			} else if !strings.Contains(err.Error(), test.err) {
+				t.Fatalf("expected an error containing %q, got %v", test.err, err)
+			}
+		})
+	}
+}
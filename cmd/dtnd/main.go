@@ -5,38 +5,100 @@
 package main
 
 import (
+	"flag"
+	"fmt"
 	"os"
 	"os/signal"
+	"syscall"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 )
 
-// waitSigint blocks the current thread until a SIGINT appears.
-func waitSigint() {
+// waitSigint blocks the current thread until a SIGINT appears. Each SIGHUP reloads the daemon's configuration and
+// each SIGUSR1 dumps its status, if supported by the platform. If enabled, systemd's watchdog is petted from this loop.
+// Thus, a daemon hanging, e.g., while reloading, will be restarted.
+func waitSigint(d *daemon) {
 	sig := make(chan os.Signal, 1)
-	signal.Notify(sig, os.Interrupt)
+	signal.Notify(sig, append([]os.Signal{os.Interrupt, syscall.SIGHUP}, statusSignals...)...)
 
-	<-sig
+	var watchdog <-chan time.Time
+	if interval := sdWatchdogInterval(); interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		watchdog = ticker.C
+		log.WithField("interval", interval).Info("Petting systemd's watchdog")
+	}
+
+	for {
+		select {
+		case <-watchdog:
+			notifyServiceManager("WATCHDOG=1")
+
+		case s := <-sig:
+			switch {
+			case s == syscall.SIGHUP:
+				if err := d.reload(); err != nil {
+					log.WithError(err).Warn("Reloading configuration erred")
+				}
+
+			case isStatusSignal(s):
+				if err := d.dumpStatus(); err != nil {
+					log.WithError(err).Warn("Dumping status erred")
+				}
+
+			default:
+				return
+			}
+		}
+	}
+}
+
+// isStatusSignal checks if a signal requests a status dump.
+func isStatusSignal(s os.Signal) bool {
+	for _, statusSig := range statusSignals {
+		if s == statusSig {
+			return true
+		}
+	}
+	return false
 }
 
 func main() {
-	if len(os.Args) != 2 {
-		log.Fatalf("Usage: %s configuration.toml", os.Args[0])
+	check := flag.Bool("check", false, "validate the configuration and report all problems without starting")
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		log.Fatalf("Usage: %s [--check] configuration.toml", os.Args[0])
+	}
+
+	if *check {
+		problems := checkConfiguration(flag.Arg(0))
+		for _, problem := range problems {
+			_, _ = fmt.Fprintf(os.Stderr, "%s: %v\n", flag.Arg(0), problem)
+		}
+		if len(problems) > 0 {
+			os.Exit(1)
+		}
+
+		fmt.Printf("%s is valid\n", flag.Arg(0))
+		return
 	}
 
-	core, discovery, err := parseCore(os.Args[1])
+	d, err := parseCore(flag.Arg(0))
 	if err != nil {
 		log.WithFields(log.Fields{
 			"error": err,
 		}).Fatal("Failed to parse config")
 	}
 
-	waitSigint()
-	log.Info("Shutting down..")
+	// All CLAs and the store are up after parsing the configuration.
+	notifyServiceManager("READY=1")
 
-	core.Close()
+	waitSigint(d)
+	log.Info("Shutting down..")
+	notifyServiceManager("STOPPING=1")
 
-	if discovery != nil {
-		discovery.Close()
-	}
+	d.shutdown()
 }
@@ -0,0 +1,162 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/dtn7/dtn7-go/pkg/routing"
+)
+
+// metricsLabelEscaper escapes label values for the Prometheus text format.
+var metricsLabelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// writeMetric writes a single metric's help, type, and value in the Prometheus text format.
+func writeMetric(w io.Writer, name, kind, help string, value interface{}) {
+	_, _ = fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n%s %v\n", name, help, name, kind, name, value)
+}
+
+// writeLabeledMetric writes a metric with one value per label value, sorted by the label values.
+func writeLabeledMetric(w io.Writer, name, kind, help, label string, values map[string]uint64) {
+	_, _ = fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		_, _ = fmt.Fprintf(w, "%s{%s=\"%s\"} %d\n", name, label, metricsLabelEscaper.Replace(key), values[key])
+	}
+}
+
+// metricsHandler exports the Core's Metrics in the Prometheus text format.
+func metricsHandler(c *routing.Core) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		m := c.Metrics()
+
+		var b strings.Builder
+		writeMetric(&b, "dtnd_bundles_received_total", "counter", "Number of received bundles.", m.Received)
+		writeMetric(&b, "dtnd_bundles_forwarded_total", "counter", "Number of forwarded bundles.", m.Forwarded)
+		writeMetric(&b, "dtnd_bundles_delivered_total", "counter", "Number of locally delivered bundles.", m.Delivered)
+
+		deleted := make(map[string]uint64, len(m.Deleted))
+		for reason, n := range m.Deleted {
+			deleted[reason.String()] = n
+		}
+		writeLabeledMetric(&b, "dtnd_bundles_deleted_total", "counter",
+			"Number of deleted bundles by deletion reason.", "reason", deleted)
+
+		writeMetric(&b, "dtnd_store_bundles", "gauge", "Number of stored bundles.", m.StoredBundles)
+		writeMetric(&b, "dtnd_peers_connected", "gauge", "Number of connected peers.", m.ConnectedPeers)
+		if m.RoutingTableSize >= 0 {
+			writeMetric(&b, "dtnd_routing_table_size", "gauge",
+				"Number of entries in the routing algorithm's table.", m.RoutingTableSize)
+		}
+
+		writeLabeledMetric(&b, "dtnd_cla_sent_bytes_total", "counter",
+			"Serialized bundle bytes sent by CLA address.", "cla", m.BytesSent)
+		writeLabeledMetric(&b, "dtnd_cla_received_bytes_total", "counter",
+			"Serialized bundle bytes received by CLA address.", "cla", m.BytesReceived)
+
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		if _, err := io.WriteString(w, b.String()); err != nil {
+			log.WithError(err).Warn("Failed to write metrics response")
+		}
+	}
+}
+
+// statusHandler exports the Core's Status as a JSON document.
+func statusHandler(c *routing.Core) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(c.Status()); err != nil {
+			log.WithError(err).Warn("Failed to write status response")
+		}
+	}
+}
+
+// writeStatusFile writes the Core's Status as a JSON document to a file. The file is replaced atomically, thus readers,
+// e.g., a monitoring agent, never see a partial document.
+func writeStatusFile(c *routing.Core, path string) error {
+	f, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(f.Name()) }()
+
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(c.Status()); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Chmod(0644); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+// startStatusFile writes the status-file now and afterwards each status-interval, one minute by default.
+func startStatusFile(conf metricsConf, c *routing.Core) error {
+	interval := time.Minute
+	if conf.StatusInterval != "" {
+		var err error
+		if interval, err = time.ParseDuration(conf.StatusInterval); err != nil {
+			return err
+		}
+	}
+
+	if err := writeStatusFile(c, conf.StatusFile); err != nil {
+		return err
+	}
+
+	return c.Cron.Register("status_file", func() {
+		if err := writeStatusFile(c, conf.StatusFile); err != nil {
+			log.WithError(err).WithField("file", conf.StatusFile).Warn("Failed to write status file")
+		}
+	}, interval)
+}
+
+// startMetricsServer serves the metricsHandler at "/metrics" and the statusHandler at "/status" on its own HTTP server,
+// independent of the webserver agent.
+func startMetricsServer(conf metricsConf, c *routing.Core) error {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metricsHandler(c))
+	mux.Handle("/status", statusHandler(c))
+
+	httpServer := &http.Server{
+		Addr:              conf.Address,
+		Handler:           mux,
+		ReadHeaderTimeout: 60 * time.Second,
+	}
+
+	errChan := make(chan error)
+	go func() { errChan <- httpServer.ListenAndServe() }()
+
+	select {
+	case err := <-errChan:
+		return err
+
+	case <-time.After(100 * time.Millisecond):
+		log.WithField("address", conf.Address).Info("Started metrics server")
+		return nil
+	}
+}
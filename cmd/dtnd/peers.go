@@ -0,0 +1,65 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/dtn7/dtn7-go/pkg/discovery"
+	"github.com/dtn7/dtn7-go/pkg/routing"
+)
+
+// peerResponse describes a discovered peer in the JSON response of the "/peers" endpoint.
+type peerResponse struct {
+	Endpoint string  `json:"endpoint"`
+	CLA      string  `json:"cla"`
+	Address  string  `json:"address"`
+	LastSeen string  `json:"last_seen"`
+	Period   float64 `json:"period"`
+}
+
+// discoveredPeers converts the discovery's peers for the Core.
+func discoveredPeers(ds *discovery.Manager) func() []routing.DiscoveredPeer {
+	return func() []routing.DiscoveredPeer {
+		dps := ds.Peers()
+		peers := make([]routing.DiscoveredPeer, 0, len(dps))
+		for _, dp := range dps {
+			peers = append(peers, routing.DiscoveredPeer{
+				Endpoint: dp.Endpoint,
+				Type:     dp.Type,
+				Address:  dp.Address,
+				LastSeen: dp.LastSeen,
+				Period:   dp.Period,
+			})
+		}
+		return peers
+	}
+}
+
+// peersHandler lists the Core's discovered peers as JSON. The period is given in seconds.
+func peersHandler(c *routing.Core) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		peers := c.DiscoveredPeers()
+		resp := make([]peerResponse, 0, len(peers))
+		for _, peer := range peers {
+			resp = append(resp, peerResponse{
+				Endpoint: peer.Endpoint.String(),
+				CLA:      peer.Type.String(),
+				Address:  peer.Address,
+				LastSeen: peer.LastSeen.Format(time.RFC3339),
+				Period:   peer.Period.Seconds(),
+			})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			log.WithError(err).Warn("Failed to write discovered peers response")
+		}
+	}
+}
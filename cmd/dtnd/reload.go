@@ -0,0 +1,323 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+	"github.com/dtn7/dtn7-go/pkg/cla"
+	"github.com/dtn7/dtn7-go/pkg/control"
+	"github.com/dtn7/dtn7-go/pkg/discovery"
+	"github.com/dtn7/dtn7-go/pkg/energy"
+	"github.com/dtn7/dtn7-go/pkg/routing"
+)
+
+// daemon is a running dtnd instance, whose configuration might be reloaded without a restart.
+type daemon struct {
+	mutex    sync.Mutex
+	filename string
+	conf     tomlConfig
+
+	core      *routing.Core
+	discovery *discovery.Manager
+	control   *control.Server
+	energy    *energy.Monitor
+
+	listeners    map[convergenceConf]cla.Convergable
+	listenerIDs  map[convergenceConf]listenerID
+	listenerMsgs map[convergenceConf]discovery.Announcement
+	peers        map[convergenceConf]cla.Convergable
+
+	// lowPower is set by the energy monitor; paused CLAs are still configured, but unregistered until it ends.
+	lowPower        bool
+	pausedListeners map[convergenceConf]bool
+	pausedPeers     map[convergenceConf]bool
+}
+
+// listenerID is a listener's CLA type and endpoint ID, registered at the Core besides the listener itself.
+type listenerID struct {
+	claType cla.CLAType
+	eid     bpv7.EndpointID
+}
+
+// newDaemon for a configuration file and its parsed content.
+func newDaemon(filename string, conf tomlConfig) *daemon {
+	return &daemon{
+		filename:        filename,
+		conf:            conf,
+		listeners:       make(map[convergenceConf]cla.Convergable),
+		listenerIDs:     make(map[convergenceConf]listenerID),
+		listenerMsgs:    make(map[convergenceConf]discovery.Announcement),
+		peers:           make(map[convergenceConf]cla.Convergable),
+		pausedListeners: make(map[convergenceConf]bool),
+		pausedPeers:     make(map[convergenceConf]bool),
+	}
+}
+
+// addListener starts a configured "listen" CLA.
+func (d *daemon) addListener(conv convergenceConf) error {
+	convRec, eid, claType, discoMsg, err := parseListen(conv, d.core.NodeId)
+	if err != nil {
+		return err
+	}
+
+	d.core.RegisterCLA(convRec, claType, eid)
+	d.listeners[conv] = convRec
+	d.listenerIDs[conv] = listenerID{claType, eid}
+	if discoMsg != (discovery.Announcement{}) {
+		d.listenerMsgs[conv] = discoMsg
+	}
+	return nil
+}
+
+// addPeer connects to a configured "peer" CLA. Failures are only logged, as a peer might become reachable later.
+func (d *daemon) addPeer(conv convergenceConf) {
+	convRec, err := parsePeer(conv, d.core.NodeId)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"peer":  conv.Endpoint,
+			"error": err,
+		}).Warn("Failed to establish a connection to a peer")
+		return
+	}
+
+	d.core.RegisterConvergable(convRec)
+	d.peers[conv] = convRec
+}
+
+// unregisterConvergence of a configured "listen" or "peer" CLA. A listener's endpoint ID is unregistered as well.
+func (d *daemon) unregisterConvergence(conv convergenceConf, convRec cla.Convergable) {
+	if id, ok := d.listenerIDs[conv]; ok && d.listeners[conv] == convRec {
+		d.core.UnregisterCLA(convRec, id.claType, id.eid)
+		delete(d.listenerIDs, conv)
+	} else {
+		d.core.UnregisterConvergable(convRec)
+	}
+}
+
+// announcements of the current listeners to be announced by the discovery. Paused listeners are not announced.
+func (d *daemon) announcements() (msgs []discovery.Announcement) {
+	for _, conv := range d.conf.Listen {
+		if msg, ok := d.listenerMsgs[conv]; ok && !d.pausedListeners[conv] && announceListener(d.conf.Discovery, conv) {
+			msgs = append(msgs, msg)
+		}
+	}
+	return
+}
+
+// reload the configuration file and apply all changes possible at runtime. The store and its bundles are kept.
+// Changes requiring a restart, e.g., of the store or the node ID, are logged and ignored.
+func (d *daemon) reload() error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	conf, _, err := loadConfiguration(d.filename)
+	if err != nil {
+		return err
+	}
+
+	d.warnRestartRequired(conf)
+
+	applyLogging(conf.Logging)
+
+	if err := applyCoreSettings(conf, d.core); err != nil {
+		return err
+	}
+
+	if d.conf.Cron != conf.Cron {
+		if err := rescheduleCron(conf.Cron, d.core); err != nil {
+			return err
+		}
+		log.Info("Rescheduled cron jobs")
+	}
+
+	if !reflect.DeepEqual(d.conf.Routing, conf.Routing) {
+		if err := d.core.ReloadRoutingAlgorithm(conf.Routing); err != nil {
+			return err
+		}
+		log.WithField("routing", conf.Routing.Algorithm).Info("Reloaded routing algorithm")
+	}
+
+	oldConf := d.conf
+	d.conf = conf
+
+	d.reloadConvergences(oldConf.Listen, conf.Listen, d.listeners, d.addListener)
+	d.reloadConvergences(oldConf.Peer, conf.Peer, d.peers, func(conv convergenceConf) error {
+		d.addPeer(conv)
+		return nil
+	})
+
+	if err := d.reloadDiscovery(); err != nil {
+		return err
+	}
+
+	if err := d.applyEnergyMode(); err != nil {
+		return err
+	}
+
+	log.WithField("config", d.filename).Info("Reloaded configuration")
+	return nil
+}
+
+// reloadConvergences unregisters removed and adds new CLAs, leaving unchanged ones and their connections untouched.
+func (d *daemon) reloadConvergences(
+	oldConvs, newConvs []convergenceConf, convs map[convergenceConf]cla.Convergable,
+	addFunc func(convergenceConf) error) {
+
+	keep := make(map[convergenceConf]bool)
+	for _, conv := range newConvs {
+		keep[conv] = true
+	}
+
+	for _, conv := range oldConvs {
+		if keep[conv] {
+			continue
+		}
+
+		if convRec, ok := convs[conv]; ok {
+			// A paused CLA was already unregistered; it is dropped from the paused ones when applying the energy mode.
+			d.unregisterConvergence(conv, convRec)
+			delete(convs, conv)
+			delete(d.listenerMsgs, conv)
+		}
+		log.WithField("cla", conv.Endpoint).Info("Removed CLA by configuration reload")
+	}
+
+	for _, conv := range newConvs {
+		if _, ok := convs[conv]; ok {
+			continue
+		}
+
+		if err := addFunc(conv); err != nil {
+			log.WithField("cla", conv.Endpoint).WithError(err).Warn("Failed to add CLA by configuration reload")
+		} else {
+			log.WithField("cla", conv.Endpoint).Info("Added CLA by configuration reload")
+		}
+	}
+}
+
+// reloadDiscovery updates a running discovery's interval, TTL, interfaces, key, and announcements.
+func (d *daemon) reloadDiscovery() error {
+	if d.discovery == nil {
+		return nil
+	}
+
+	conf := d.conf.Discovery
+
+	d.discovery.SetTTL(time.Duration(conf.TTL) * time.Second)
+	d.discovery.SetExpiry(conf.Expiry, d.core.UnregisterConvergable)
+
+	var key []byte
+	if conf.Key != "" {
+		var err error
+		if key, err = hex.DecodeString(conf.Key); err != nil {
+			return err
+		}
+	}
+	d.discovery.SetKey(key)
+
+	if err := d.discovery.SetInterval(d.discoveryInterval()); err != nil {
+		return err
+	}
+	if !reflect.DeepEqual(d.discovery.Interfaces(), append([]string(nil), conf.Interfaces...)) {
+		if err := d.discovery.SetInterfaces(conf.Interfaces); err != nil {
+			return err
+		}
+	}
+	if !d.discovery.IsPassive() {
+		if err := d.discovery.SetAnnouncements(d.announcements()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// shutdown the daemon, first stopping the discovery to not establish new CLAs and then gracefully shutting down the
+// Core within the configured timeout, ten seconds by default.
+func (d *daemon) shutdown() {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.energy != nil {
+		d.energy.Close()
+	}
+	if d.discovery != nil {
+		d.discovery.Close()
+	}
+	if d.control != nil {
+		if err := d.control.Close(); err != nil {
+			log.WithError(err).Warn("Closing control server erred")
+		}
+	}
+
+	timeout := 10 * time.Second
+	if d.conf.Core.ShutdownTimeout != "" {
+		if t, err := time.ParseDuration(d.conf.Core.ShutdownTimeout); err != nil {
+			log.WithError(err).Warn("Invalid shutdown timeout, using the default")
+		} else {
+			timeout = t
+		}
+	}
+
+	d.core.Shutdown(timeout)
+}
+
+// warnRestartRequired logs changed settings which cannot be applied at runtime.
+func (d *daemon) warnRestartRequired(conf tomlConfig) {
+	var changed []string
+
+	if d.conf.Core.Store != conf.Core.Store || d.conf.Core.NodeId != conf.Core.NodeId ||
+		d.conf.Core.SignPriv != conf.Core.SignPriv || d.conf.Core.InspectAllBundles != conf.Core.InspectAllBundles ||
+		d.conf.Core.PayloadStream != conf.Core.PayloadStream {
+		changed = append(changed, "core")
+	}
+	if !reflect.DeepEqual(d.conf.Agents, conf.Agents) {
+		changed = append(changed, "agents")
+	}
+	if d.conf.Metrics != conf.Metrics {
+		changed = append(changed, "metrics")
+	}
+	if d.conf.Control != conf.Control {
+		changed = append(changed, "control")
+	}
+
+	oldEnergy, newEnergy := d.conf.Energy, conf.Energy
+	if oldEnergy.BatteryFile != newEnergy.BatteryFile || oldEnergy.LowLevel != newEnergy.LowLevel ||
+		oldEnergy.ResumeLevel != newEnergy.ResumeLevel || oldEnergy.CheckInterval != newEnergy.CheckInterval {
+		changed = append(changed, "energy")
+	}
+
+	oldDisco, newDisco := d.conf.Discovery, conf.Discovery
+	if oldDisco.IPv4 != newDisco.IPv4 || oldDisco.IPv6 != newDisco.IPv6 || oldDisco.DNSSD != newDisco.DNSSD ||
+		oldDisco.IPND != newDisco.IPND || oldDisco.BLE != newDisco.BLE || oldDisco.BLEDevice != newDisco.BLEDevice ||
+		oldDisco.Passive != newDisco.Passive || oldDisco.Gossip != newDisco.Gossip ||
+		!reflect.DeepEqual(oldDisco.Static, newDisco.Static) {
+		changed = append(changed, "discovery")
+	}
+
+	if len(changed) > 0 {
+		log.WithField("sections", changed).Warn("Configuration changes in these sections require a restart")
+	}
+}
+
+// reloadHandler reloads the configuration on request, e.g., as part of the webserver's management endpoints.
+func reloadHandler(reloadFunc func() error) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		if err := reloadFunc(); err != nil {
+			log.WithError(err).Warn("Reloading configuration erred")
+			http.Error(w, fmt.Sprintf("reloading configuration erred: %v", err), http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,236 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package main
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+	"github.com/dtn7/dtn7-go/pkg/cla"
+)
+
+// freeReloadPort returns a currently unused TCP port on localhost.
+func freeReloadPort(t *testing.T) int {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	return l.Addr().(*net.TCPAddr).Port
+}
+
+// writeReloadConfig (over)writes a daemon's configuration file of a routing section and further sections.
+func writeReloadConfig(t *testing.T, filename, routing, sections string) {
+	writeReloadCoreConfig(t, filename, "", routing, sections)
+}
+
+// writeReloadCoreConfig (over)writes a daemon's configuration file of additional core settings, a routing section,
+// and further sections.
+func writeReloadCoreConfig(t *testing.T, filename, core, routing, sections string) {
+	conf := fmt.Sprintf(`
+[core]
+store = %q
+node-id = "dtn://test/"
+%s
+
+[cron]
+check-bundles = "10s"
+clean-store = "10m"
+clean-id = "10m"
+
+[logging]
+level = "error"
+
+[routing]
+%s
+
+%s`, filepath.Join(filepath.Dir(filename), "store"), core, routing, sections)
+
+	if err := os.WriteFile(filename, []byte(conf), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// listenReloadConfig of an mtcp "listen" block for a port.
+func listenReloadConfig(port int) string {
+	return fmt.Sprintf("[[listen]]\nprotocol = \"mtcp\"\nendpoint = \"127.0.0.1:%d\"\n", port)
+}
+
+// waitListening until a port accepts connections or not, as some CLAs are started or closed in the background.
+func waitListening(t *testing.T, port int, listening bool) {
+	for i := 0; i < 50; i++ {
+		conn, err := net.DialTimeout("tcp", fmt.Sprintf("127.0.0.1:%d", port), 100*time.Millisecond)
+		if conn != nil {
+			_ = conn.Close()
+		}
+
+		if (err == nil) == listening {
+			return
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	t.Fatalf("port %d: expected listening %t", port, listening)
+}
+
+func TestDaemonReload(t *testing.T) {
+	portA, portB := freeReloadPort(t), freeReloadPort(t)
+	convA := convergenceConf{Protocol: "mtcp", Endpoint: fmt.Sprintf("127.0.0.1:%d", portA)}
+	convB := convergenceConf{Protocol: "mtcp", Endpoint: fmt.Sprintf("127.0.0.1:%d", portB)}
+
+	filename := filepath.Join(t.TempDir(), "dtnd.toml")
+	writeReloadConfig(t, filename, `algorithm = "epidemic"`, listenReloadConfig(portA))
+
+	d, err := parseCore(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d.shutdown()
+
+	waitListening(t, portA, true)
+	if routing := d.core.Status().Routing; routing != "epidemic" {
+		t.Fatalf("expected epidemic routing, got %s", routing)
+	}
+
+	// Replace listener A by B and change the routing algorithm.
+	writeReloadConfig(t, filename, `
+algorithm = "dtlsr"
+
+[routing.dtlsrconf]
+recomputetime = "30s"
+broadcasttime = "30s"
+purgetime = "10m"`, listenReloadConfig(portB))
+
+	if err := d.reload(); err != nil {
+		t.Fatal(err)
+	}
+
+	waitListening(t, portA, false)
+	waitListening(t, portB, true)
+	if _, ok := d.listeners[convA]; ok {
+		t.Fatal("removed listener is still present")
+	}
+	listenerB, ok := d.listeners[convB]
+	if !ok {
+		t.Fatal("added listener is missing")
+	}
+	if eids := d.core.RegisteredCLAs(cla.MTCP); len(eids) != 1 {
+		t.Fatalf("expected one registered MTCP CLA, got %v", eids)
+	}
+	if status := d.core.Status(); status.Routing == "epidemic" || status.RoutingTable == nil {
+		t.Fatalf("routing algorithm was not replaced by dtlsr: %s", status.Routing)
+	}
+
+	// Add listener A again, keeping B untouched, and revert the routing algorithm.
+	writeReloadConfig(t, filename, `algorithm = "epidemic"`, listenReloadConfig(portB)+listenReloadConfig(portA))
+
+	if err := d.reload(); err != nil {
+		t.Fatal(err)
+	}
+
+	waitListening(t, portA, true)
+	waitListening(t, portB, true)
+	if len(d.listeners) != 2 {
+		t.Fatalf("expected two listeners, got %v", d.listeners)
+	} else if d.listeners[convB] != listenerB {
+		t.Fatal("unchanged listener was replaced")
+	}
+	if routing := d.core.Status().Routing; routing != "epidemic" {
+		t.Fatalf("expected epidemic routing, got %s", routing)
+	}
+}
+
+func TestDaemonReloadAnnouncements(t *testing.T) {
+	portA, portB := freeReloadPort(t), freeReloadPort(t)
+
+	filename := filepath.Join(t.TempDir(), "dtnd.toml")
+	writeReloadConfig(t, filename, `algorithm = "epidemic"`, listenReloadConfig(portA))
+
+	d, err := parseCore(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d.shutdown()
+
+	if msgs := d.announcements(); len(msgs) != 1 || msgs[0].Port != uint(portA) {
+		t.Fatalf("expected an announcement of port %d, got %v", portA, msgs)
+	}
+
+	writeReloadConfig(t, filename, `algorithm = "epidemic"`, listenReloadConfig(portB))
+	if err := d.reload(); err != nil {
+		t.Fatal(err)
+	}
+
+	if msgs := d.announcements(); len(msgs) != 1 || msgs[0].Port != uint(portB) {
+		t.Fatalf("expected an announcement of port %d, got %v", portB, msgs)
+	}
+
+	writeReloadConfig(t, filename, `algorithm = "epidemic"`,
+		listenReloadConfig(portB)+"\n[discovery]\nannounce = [\"tcpclv4\"]")
+	if err := d.reload(); err != nil {
+		t.Fatal(err)
+	}
+
+	if msgs := d.announcements(); len(msgs) != 0 {
+		t.Fatalf("expected no announcements of excluded protocols, got %v", msgs)
+	}
+}
+
+// TestDaemonReloadWhileSending reloads the core settings while bundles are sent, being meant for the race detector.
+func TestDaemonReloadWhileSending(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "dtnd.toml")
+	writeReloadCoreConfig(t, filename, "hop-limit = 8", `algorithm = "epidemic"`, "")
+
+	d, err := parseCore(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d.shutdown()
+
+	stopSending := make(chan struct{})
+	sent := make(chan struct{})
+	go func() {
+		defer close(sent)
+
+		for i := 0; ; i++ {
+			select {
+			case <-stopSending:
+				return
+			default:
+			}
+
+			bndl, err := bpv7.Builder().
+				Source("dtn://test/").
+				Destination("dtn://other/").
+				CreationTimestampNow().
+				Lifetime("10m").
+				PayloadBlock([]byte{byte(i)}).
+				Build()
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			d.core.SendBundle(&bndl)
+		}
+	}()
+
+	for i := 0; i < 10; i++ {
+		core := fmt.Sprintf("hop-limit = %d\nknown-bundles = %d\nreport-to = \"dtn://monitor/\"\nvalidation = \"warn\"",
+			8+i, 100*(i%2+1))
+		writeReloadCoreConfig(t, filename, core, `algorithm = "epidemic"`, "")
+
+		if err := d.reload(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	close(stopSending)
+	<-sent
+}
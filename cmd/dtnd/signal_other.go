@@ -0,0 +1,13 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+//go:build windows || plan9
+// +build windows plan9
+
+package main
+
+import "os"
+
+// statusSignals request a status dump, which is not supported by signals on this platform.
+var statusSignals []os.Signal
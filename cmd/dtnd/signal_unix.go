@@ -0,0 +1,16 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+//go:build !windows && !plan9
+// +build !windows,!plan9
+
+package main
+
+import (
+	"os"
+	"syscall"
+)
+
+// statusSignals request a status dump.
+var statusSignals = []os.Signal{syscall.SIGUSR1}
@@ -0,0 +1,44 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package main
+
+import (
+	"os"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// dumpStatus writes the Core's status to the configured status-dump file or, if unset, to the log.
+func (d *daemon) dumpStatus() error {
+	d.mutex.Lock()
+	path := d.conf.Core.StatusDump
+	d.mutex.Unlock()
+
+	if path == "" {
+		var b strings.Builder
+		if err := d.core.WriteStatus(&b); err != nil {
+			return err
+		}
+
+		log.Info("Status dump:\n" + b.String())
+		return nil
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if err := d.core.WriteStatus(f); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	log.WithField("file", path).Info("Dumped status")
+	return nil
+}
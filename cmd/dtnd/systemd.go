@@ -0,0 +1,59 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package main
+
+import (
+	"net"
+	"os"
+	"strconv"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// sdNotify sends a state, e.g., "READY=1", to the service manager's notification socket, as sd_notify(3) does. If no
+// NOTIFY_SOCKET is set, dtnd was not started as a notify service and nothing is sent.
+func sdNotify(state string) error {
+	socket := os.Getenv("NOTIFY_SOCKET")
+	if socket == "" {
+		return nil
+	}
+
+	// A leading "@" denotes a socket in the abstract namespace.
+	if socket[0] == '@' {
+		socket = "\x00" + socket[1:]
+	}
+
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: socket, Net: "unixgram"})
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	_, err = conn.Write([]byte(state))
+	return err
+}
+
+// notifyServiceManager sends a state to the service manager, only logging failures.
+func notifyServiceManager(state string) {
+	if err := sdNotify(state); err != nil {
+		log.WithError(err).WithField("state", state).Warn("Notifying service manager erred")
+	}
+}
+
+// sdWatchdogInterval returns the interval for petting systemd's watchdog, half of the configured WatchdogSec as
+// recommended by sd_watchdog_enabled(3). Zero is returned if the watchdog is disabled or meant for another process.
+func sdWatchdogInterval() time.Duration {
+	usec, err := strconv.ParseUint(os.Getenv("WATCHDOG_USEC"), 10, 64)
+	if err != nil || usec == 0 {
+		return 0
+	}
+
+	if pid := os.Getenv("WATCHDOG_PID"); pid != "" && pid != strconv.Itoa(os.Getpid()) {
+		return 0
+	}
+
+	return time.Duration(usec) * time.Microsecond / 2
+}
@@ -0,0 +1,91 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+//go:build !windows && !plan9
+// +build !windows,!plan9
+
+package main
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// listenNotifySocket creates a unixgram socket acting as the service manager's NOTIFY_SOCKET.
+func listenNotifySocket(t *testing.T) *net.UnixConn {
+	addr := &net.UnixAddr{Name: filepath.Join(t.TempDir(), "notify"), Net: "unixgram"}
+	conn, err := net.ListenUnixgram("unixgram", addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	t.Setenv("NOTIFY_SOCKET", addr.Name)
+	return conn
+}
+
+func TestSdNotify(t *testing.T) {
+	conn := listenNotifySocket(t)
+
+	for _, state := range []string{"READY=1", "WATCHDOG=1", "STOPPING=1"} {
+		if err := sdNotify(state); err != nil {
+			t.Fatal(err)
+		}
+
+		buf := make([]byte, 64)
+		if err := conn.SetReadDeadline(time.Now().Add(time.Second)); err != nil {
+			t.Fatal(err)
+		}
+		n, err := conn.Read(buf)
+		if err != nil {
+			t.Fatal(err)
+		} else if received := string(buf[:n]); received != state {
+			t.Fatalf("expected %q, got %q", state, received)
+		}
+	}
+}
+
+func TestSdNotifyUnset(t *testing.T) {
+	t.Setenv("NOTIFY_SOCKET", "")
+
+	if err := sdNotify("READY=1"); err != nil {
+		t.Fatalf("notifying without a NOTIFY_SOCKET erred: %v", err)
+	}
+}
+
+func TestSdNotifyMissingSocket(t *testing.T) {
+	t.Setenv("NOTIFY_SOCKET", filepath.Join(t.TempDir(), "missing"))
+
+	if err := sdNotify("READY=1"); err == nil {
+		t.Fatal("notifying a missing socket did not err")
+	}
+}
+
+func TestSdWatchdogInterval(t *testing.T) {
+	tests := []struct {
+		usec     string
+		pid      string
+		expected time.Duration
+	}{
+		{"", "", 0},
+		{"0", "", 0},
+		{"invalid", "", 0},
+		{"10000000", "", 5 * time.Second},
+		{"10000000", strconv.Itoa(os.Getpid()), 5 * time.Second},
+		{"10000000", strconv.Itoa(os.Getpid() + 1), 0},
+	}
+
+	for _, test := range tests {
+		t.Setenv("WATCHDOG_USEC", test.usec)
+		t.Setenv("WATCHDOG_PID", test.pid)
+
+		if interval := sdWatchdogInterval(); interval != test.expected {
+			t.Fatalf("WATCHDOG_USEC=%q WATCHDOG_PID=%q: expected %v, got %v", test.usec, test.pid, test.expected, interval)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package main
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/dtn7/dtn7-go/pkg/routing"
+)
+
+// topologyQueueSize is the number of topology changes buffered for each client; further changes are dropped.
+const topologyQueueSize = 64
+
+// topologyChange describes a change of the network topology in the JSON messages of the "/topology" endpoint. The
+// node's links to its peers either appeared, disappeared, or were updated to the list of currently linked peers.
+type topologyChange struct {
+	Type  string   `json:"type"`
+	Time  string   `json:"time"`
+	Node  string   `json:"node"`
+	Peer  string   `json:"peer,omitempty"`
+	Peers []string `json:"peers,omitempty"`
+}
+
+// newTopologyChange from an Event of a Core.
+func newTopologyChange(c *routing.Core, e routing.Event) topologyChange {
+	change := topologyChange{
+		Type: e.Type.String(),
+		Time: e.Time.Format(time.RFC3339Nano),
+		Node: c.NodeId.String(),
+	}
+
+	if e.Type == routing.LinkUpdated {
+		change.Node = e.Peer.String()
+		change.Peers = make([]string, 0, len(e.Peers))
+		for _, peer := range e.Peers {
+			change.Peers = append(change.Peers, peer.String())
+		}
+	} else {
+		change.Peer = e.Peer.String()
+	}
+
+	return change
+}
+
+// topologyHandler streams the Core's topology changes to WebSocket clients, e.g., for live visualizations. Each client
+// first receives a "peer appeared" message for each currently connected neighbor, followed by all further changes.
+func topologyHandler(c *routing.Core) http.HandlerFunc {
+	upgrader := websocket.Upgrader{}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			log.WithError(err).Warn("Upgrading topology request to WebSocket erred")
+			return
+		}
+		defer conn.Close()
+
+		changes := make(chan topologyChange, topologyQueueSize)
+		unsubscribe := c.Subscribe(func(e routing.Event) {
+			select {
+			case changes <- newTopologyChange(c, e):
+			default:
+				log.WithField("client", r.RemoteAddr).Debug("Dropping topology change for a slow client")
+			}
+		}, routing.PeerAppeared, routing.PeerDisappeared, routing.LinkUpdated)
+		defer unsubscribe()
+
+		// Reading detects a closed connection; clients are not expected to send anything.
+		closed := make(chan struct{})
+		go func() {
+			defer close(closed)
+			for {
+				if _, _, err := conn.ReadMessage(); err != nil {
+					return
+				}
+			}
+		}()
+
+		for _, n := range c.Neighbors().Neighbors() {
+			if !n.Connected() {
+				continue
+			}
+			snapshot := newTopologyChange(c, routing.Event{Type: routing.PeerAppeared, Time: n.LastSeen, Peer: n.Endpoint})
+			if err := conn.WriteJSON(snapshot); err != nil {
+				return
+			}
+		}
+
+		for {
+			select {
+			case change := <-changes:
+				if err := conn.WriteJSON(change); err != nil {
+					log.WithField("client", r.RemoteAddr).WithError(err).Debug("Writing topology change failed")
+					return
+				}
+
+			case <-closed:
+				return
+			}
+		}
+	}
+}
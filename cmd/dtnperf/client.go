@@ -0,0 +1,112 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/agent"
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+// clientConf configures a dtnperf client's session.
+type clientConf struct {
+	websocket string
+	sender    string
+	server    string
+
+	count    uint64
+	size     int
+	rate     float64
+	lifetime time.Duration
+	wait     time.Duration
+}
+
+// runClient sends a session's bundles to the server and prints its report. The configuration is checked by
+// parseClientArgs.
+func runClient(conf clientConf) {
+	conn, err := agent.NewWebSocketAgentConnector(conf.websocket, conf.sender)
+	if err != nil {
+		printFatal(err, "connecting to websocket")
+	}
+	defer conn.Close()
+
+	reports := make(chan report)
+	go func() {
+		for {
+			b, err := conn.ReadBundle()
+			if err != nil {
+				return
+			}
+
+			payload, err := bundlePayload(b)
+			if err != nil {
+				continue
+			}
+			var r report
+			if json.Unmarshal(payload, &r) == nil {
+				reports <- r
+			}
+		}
+	}()
+
+	sessionId := uint64(time.Now().UnixNano())
+
+	send := func(h perfHeader, size int) {
+		b, err := bpv7.Builder().
+			CRC(bpv7.CRC32).
+			Source(conf.sender).
+			Destination(conf.server).
+			CreationTimestampNow().
+			Lifetime(conf.lifetime).
+			HopCountBlock(64).
+			PayloadBlock(h.encode(size)).
+			Build()
+		if err != nil {
+			printFatal(err, "creating bundle")
+		}
+
+		if err := conn.WriteBundle(b); err != nil {
+			printFatal(err, "sending bundle")
+		}
+	}
+
+	var interval time.Duration
+	if conf.rate > 0 {
+		interval = time.Duration(float64(time.Second) / conf.rate)
+	}
+
+	fmt.Printf("dtnperf session %d: sending %d bundles of %d bytes to %s\n", sessionId, conf.count, conf.size, conf.server)
+
+	start := time.Now()
+	for seq := uint64(0); seq < conf.count; seq++ {
+		if interval > 0 {
+			time.Sleep(time.Until(start.Add(time.Duration(seq) * interval)))
+		}
+		send(perfHeader{kind: kindData, session: sessionId, number: seq, sent: time.Now()}, conf.size)
+	}
+	send(perfHeader{kind: kindDone, session: sessionId, number: conf.count, sent: time.Now()}, perfHeaderLen)
+
+	sending := time.Since(start)
+	fmt.Printf("sent %d bytes in %v, %.3f Mbit/s\n\n", conf.count*uint64(conf.size), sending,
+		8*float64(conf.count*uint64(conf.size))/sending.Seconds()/1e6)
+
+	timeout := time.After(conf.wait)
+	for {
+		select {
+		case r := <-reports:
+			if r.Session != sessionId {
+				continue
+			}
+			fmt.Println(r)
+			return
+
+		case <-timeout:
+			printFatal(fmt.Errorf("no report within %v", conf.wait), "waiting for the server's report")
+		}
+	}
+}
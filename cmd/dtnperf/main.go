@@ -0,0 +1,147 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+// dtnperf benchmarks a DTN path through dtnd's WebSocket API. Its client generates bundles of a configurable size and
+// rate, while its server measures the goodput, the delivery delay distribution, and the loss, and reports them back.
+//
+// Delays are measured one-way, from the client's sending to the server's reception. Thus, both nodes' clocks must be
+// synchronized.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+// printUsage of dtnperf and exit with an error code afterwards.
+func printUsage() {
+	_, _ = fmt.Fprintf(os.Stderr, "Usage of %s server|client:\n\n", os.Args[0])
+
+	_, _ = fmt.Fprintf(os.Stderr, "%s server [-linger duration] websocket endpoint\n", os.Args[0])
+	_, _ = fmt.Fprintf(os.Stderr, "  Registers the endpoint, measures all incoming test sessions, and sends each\n")
+	_, _ = fmt.Fprintf(os.Stderr, "  session's report back to its client.\n\n")
+
+	_, _ = fmt.Fprintf(os.Stderr, "%s client [-n count] [-s size] [-r rate] [-lifetime duration] [-W duration]\n", os.Args[0])
+	_, _ = fmt.Fprintf(os.Stderr, "  websocket sender server\n")
+	_, _ = fmt.Fprintf(os.Stderr, "  Sends count bundles of size bytes at rate bundles per second from the sender\n")
+	_, _ = fmt.Fprintf(os.Stderr, "  to the server's endpoint and prints the server's report.\n\n")
+
+	_, _ = fmt.Fprintf(os.Stderr, "  %s server ws://localhost:8080/ws dtn://bar/perf\n", os.Args[0])
+	_, _ = fmt.Fprintf(os.Stderr, "  %s client -n 1000 -s 4096 ws://localhost:8080/ws dtn://foo/perf dtn://bar/perf\n\n", os.Args[0])
+
+	os.Exit(1)
+}
+
+// printFatal of an error with a short context description and exits afterwards.
+func printFatal(err error, msg string) {
+	_, _ = fmt.Fprintf(os.Stderr, "%s erred: %s\n  %v\n", os.Args[0], msg, err)
+	os.Exit(1)
+}
+
+// parseServerArgs of the server command, checking its endpoint and flags.
+func parseServerArgs(args []string) (websocket, endpoint string, linger time.Duration, err error) {
+	flags := flag.NewFlagSet(os.Args[0]+" server", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	flags.DurationVar(&linger, "linger", 10*time.Second, "time to wait for outstanding bundles after a session's end")
+	if err = flags.Parse(args); err != nil {
+		return
+	}
+
+	if flags.NArg() != 2 {
+		err = fmt.Errorf("expected a websocket and an endpoint, got %d arguments", flags.NArg())
+		return
+	}
+	websocket, endpoint = flags.Arg(0), flags.Arg(1)
+
+	if _, err = bpv7.NewEndpointID(endpoint); err != nil {
+		err = fmt.Errorf("parsing endpoint: %v", err)
+	} else if linger < 0 {
+		err = fmt.Errorf("negative linger time %v", linger)
+	}
+	return
+}
+
+// parseClientArgs of the client command, checking its endpoints and flags. A size below perfHeaderLen is raised.
+func parseClientArgs(args []string) (conf clientConf, err error) {
+	flags := flag.NewFlagSet(os.Args[0]+" client", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	flags.Uint64Var(&conf.count, "n", 100, "number of bundles to send")
+	flags.IntVar(&conf.size, "s", 1024, fmt.Sprintf("payload size of each bundle in bytes, at least %d", perfHeaderLen))
+	flags.Float64Var(&conf.rate, "r", 0, "bundles per second, zero sends as fast as possible")
+	flags.DurationVar(&conf.lifetime, "lifetime", time.Hour, "lifetime of each bundle")
+	flags.DurationVar(&conf.wait, "W", time.Minute, "time to wait for the server's report")
+	if err = flags.Parse(args); err != nil {
+		return
+	}
+
+	if flags.NArg() != 3 {
+		err = fmt.Errorf("expected a websocket, a sender, and a server, got %d arguments", flags.NArg())
+		return
+	}
+	conf.websocket, conf.sender, conf.server = flags.Arg(0), flags.Arg(1), flags.Arg(2)
+
+	switch {
+	case conf.rate < 0:
+		err = fmt.Errorf("negative rate %v", conf.rate)
+	case conf.lifetime <= 0:
+		err = fmt.Errorf("lifetime %v is not positive", conf.lifetime)
+	case conf.wait <= 0:
+		err = fmt.Errorf("waiting time %v is not positive", conf.wait)
+	}
+	if err != nil {
+		return
+	}
+
+	if _, err = bpv7.NewEndpointID(conf.sender); err != nil {
+		err = fmt.Errorf("parsing sender: %v", err)
+		return
+	}
+	if _, err = bpv7.NewEndpointID(conf.server); err != nil {
+		err = fmt.Errorf("parsing server: %v", err)
+		return
+	}
+
+	if conf.size < perfHeaderLen {
+		conf.size = perfHeaderLen
+	}
+	return
+}
+
+// printArgsError of an invalid command line and exit with the usage afterwards.
+func printArgsError(err error) {
+	if err != flag.ErrHelp {
+		_, _ = fmt.Fprintf(os.Stderr, "%s: %v\n\n", os.Args[0], err)
+	}
+	printUsage()
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		printUsage()
+	}
+
+	switch os.Args[1] {
+	case "server":
+		websocket, endpoint, linger, err := parseServerArgs(os.Args[2:])
+		if err != nil {
+			printArgsError(err)
+		}
+		serve(websocket, endpoint, linger)
+
+	case "client":
+		conf, err := parseClientArgs(os.Args[2:])
+		if err != nil {
+			printArgsError(err)
+		}
+		runClient(conf)
+
+	default:
+		printUsage()
+	}
+}
@@ -0,0 +1,94 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseServerArgs(t *testing.T) {
+	tests := []struct {
+		args   []string
+		linger time.Duration
+		valid  bool
+	}{
+		{[]string{"ws://localhost/ws", "dtn://bar/perf"}, 10 * time.Second, true},
+		{[]string{"-linger", "1m", "ws://localhost/ws", "dtn://bar/perf"}, time.Minute, true},
+		{[]string{"-linger", "-1s", "ws://localhost/ws", "dtn://bar/perf"}, 0, false},
+		{[]string{"ws://localhost/ws"}, 0, false},
+		{[]string{"ws://localhost/ws", "no endpoint"}, 0, false},
+		{[]string{"-unknown", "ws://localhost/ws", "dtn://bar/perf"}, 0, false},
+	}
+
+	for _, test := range tests {
+		t.Run(strings.Join(test.args, " "), func(t *testing.T) {
+			websocket, endpoint, linger, err := parseServerArgs(test.args)
+			if (err == nil) != test.valid {
+				t.Fatalf("expected valid %t, got error %v", test.valid, err)
+			} else if !test.valid {
+				return
+			}
+
+			if websocket != "ws://localhost/ws" || endpoint != "dtn://bar/perf" || linger != test.linger {
+				t.Fatalf("unexpected websocket %s, endpoint %s, or linger %v", websocket, endpoint, linger)
+			}
+		})
+	}
+}
+
+func TestParseClientArgs(t *testing.T) {
+	endpoints := []string{"ws://localhost/ws", "dtn://foo/perf", "dtn://bar/perf"}
+	defaults := clientConf{
+		websocket: "ws://localhost/ws",
+		sender:    "dtn://foo/perf",
+		server:    "dtn://bar/perf",
+		count:     100,
+		size:      1024,
+		lifetime:  time.Hour,
+		wait:      time.Minute,
+	}
+
+	tests := []struct {
+		flags    []string
+		expected func(conf *clientConf)
+		valid    bool
+	}{
+		{nil, func(*clientConf) {}, true},
+		{[]string{"-n", "10", "-s", "4096", "-r", "2.5", "-lifetime", "10m", "-W", "5s"}, func(conf *clientConf) {
+			conf.count, conf.size, conf.rate, conf.lifetime, conf.wait = 10, 4096, 2.5, 10*time.Minute, 5*time.Second
+		}, true},
+		{[]string{"-s", "1"}, func(conf *clientConf) { conf.size = perfHeaderLen }, true},
+		{[]string{"-r", "-1"}, nil, false},
+		{[]string{"-lifetime", "0s"}, nil, false},
+		{[]string{"-W", "-1s"}, nil, false},
+		{[]string{"-n", "many"}, nil, false},
+	}
+
+	for _, test := range tests {
+		t.Run(strings.Join(test.flags, " "), func(t *testing.T) {
+			conf, err := parseClientArgs(append(test.flags, endpoints...))
+			if (err == nil) != test.valid {
+				t.Fatalf("expected valid %t, got error %v", test.valid, err)
+			} else if !test.valid {
+				return
+			}
+
+			expected := defaults
+			test.expected(&expected)
+			if conf != expected {
+				t.Fatalf("expected %+v, got %+v", expected, conf)
+			}
+		})
+	}
+
+	for _, args := range [][]string{endpoints[:2], {"ws://localhost/ws", "no endpoint", "dtn://bar/perf"},
+		{"ws://localhost/ws", "dtn://foo/perf", "no endpoint"}} {
+		if _, err := parseClientArgs(args); err == nil {
+			t.Fatalf("invalid arguments %v were accepted", args)
+		}
+	}
+}
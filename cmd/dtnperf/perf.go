@@ -0,0 +1,200 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package main
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+// perfHeaderLen is the length of a dtnperf payload's header: its kind, the session, a number, and the sending time.
+const perfHeaderLen = 4 + 8 + 8 + 8
+
+const (
+	// kindData marks a test bundle, whose number is its sequence number.
+	kindData = "data"
+	// kindDone marks a session's end, whose number is the amount of sent test bundles.
+	kindDone = "done"
+)
+
+// perfHeader starts each payload sent from a dtnperf client to its server.
+type perfHeader struct {
+	kind    string
+	session uint64
+	number  uint64
+	sent    time.Time
+}
+
+// encode this header into a payload of the given size of at least perfHeaderLen, padded by zeros.
+func (h perfHeader) encode(size int) []byte {
+	if size < perfHeaderLen {
+		size = perfHeaderLen
+	}
+
+	payload := make([]byte, size)
+	copy(payload, h.kind)
+	binary.BigEndian.PutUint64(payload[4:], h.session)
+	binary.BigEndian.PutUint64(payload[12:], h.number)
+	binary.BigEndian.PutUint64(payload[20:], uint64(h.sent.UnixNano()))
+	return payload
+}
+
+// decodePerfHeader from a payload.
+func decodePerfHeader(payload []byte) (h perfHeader, err error) {
+	if len(payload) < perfHeaderLen {
+		err = fmt.Errorf("payload of %d bytes is shorter than a header", len(payload))
+		return
+	}
+
+	h.kind = string(payload[:4])
+	if h.kind != kindData && h.kind != kindDone {
+		err = fmt.Errorf("unknown kind %q", h.kind)
+		return
+	}
+
+	h.session = binary.BigEndian.Uint64(payload[4:])
+	h.number = binary.BigEndian.Uint64(payload[12:])
+	h.sent = time.Unix(0, int64(binary.BigEndian.Uint64(payload[20:])))
+	return
+}
+
+// bundlePayload returns a bundle's payload.
+func bundlePayload(b bpv7.Bundle) ([]byte, error) {
+	pb, err := b.PayloadBlock()
+	if err != nil {
+		return nil, err
+	}
+	return pb.Value.(*bpv7.PayloadBlock).Data(), nil
+}
+
+// report of a session, created by the server and sent back to the client as JSON.
+type report struct {
+	Session  uint64  `json:"session"`
+	Sent     uint64  `json:"sent"`
+	Received uint64  `json:"received"`
+	Loss     float64 `json:"loss"`
+	Bytes    uint64  `json:"bytes"`
+
+	// Duration from the first bundle's sending until the last bundle's reception.
+	Duration time.Duration `json:"duration"`
+	// Goodput of the payloads in bits per second over the Duration.
+	Goodput float64 `json:"goodput"`
+
+	// Delays are the minimum, the 50th, 90th, and 99th percentile, and the maximum delivery delay.
+	DelayMin time.Duration `json:"delay_min"`
+	DelayP50 time.Duration `json:"delay_p50"`
+	DelayP90 time.Duration `json:"delay_p90"`
+	DelayP99 time.Duration `json:"delay_p99"`
+	DelayMax time.Duration `json:"delay_max"`
+}
+
+// String representation of a report, as printed by both the client and the server.
+func (r report) String() string {
+	s := fmt.Sprintf("session %d: %d bundles sent, %d received, %.1f%% bundle loss\n", r.Session, r.Sent, r.Received, r.Loss)
+	s += fmt.Sprintf("%d bytes in %v, goodput %.3f Mbit/s", r.Bytes, r.Duration, r.Goodput/1e6)
+	if r.Received > 0 {
+		s += fmt.Sprintf("\ndelay min/p50/p90/p99/max = %v/%v/%v/%v/%v",
+			r.DelayMin, r.DelayP50, r.DelayP90, r.DelayP99, r.DelayMax)
+	}
+	return s
+}
+
+// marshal this report as JSON.
+func (r report) marshal() ([]byte, error) {
+	return json.Marshal(r)
+}
+
+// session is the server's state of a client's test run.
+type session struct {
+	id uint64
+
+	// expected number of bundles, known after receiving the done bundle, and the time to report at the latest.
+	expected uint64
+	deadline time.Time
+
+	received map[uint64]struct{}
+	bytes    uint64
+	delays   []time.Duration
+
+	start, end time.Time
+}
+
+// newSession for a session ID.
+func newSession(id uint64) *session {
+	return &session{id: id, received: make(map[uint64]struct{})}
+}
+
+// receive a data bundle's header and payload length at the given time. Duplicates are ignored.
+func (s *session) receive(h perfHeader, size int, now time.Time) {
+	if _, exists := s.received[h.number]; exists {
+		return
+	}
+	s.received[h.number] = struct{}{}
+
+	s.bytes += uint64(size)
+	s.delays = append(s.delays, now.Sub(h.sent))
+
+	if s.start.IsZero() || h.sent.Before(s.start) {
+		s.start = h.sent
+	}
+	if now.After(s.end) {
+		s.end = now
+	}
+}
+
+// done marks this session as ended, expecting the given amount of bundles until the deadline.
+func (s *session) done(expected uint64, deadline time.Time) {
+	s.expected = expected
+	s.deadline = deadline
+}
+
+// complete checks if the session ended and either all bundles were received or the deadline has passed.
+func (s *session) complete(now time.Time) bool {
+	if s.deadline.IsZero() {
+		return false
+	}
+	return uint64(len(s.received)) >= s.expected || !now.Before(s.deadline)
+}
+
+// report this session's measurements.
+func (s *session) report() report {
+	r := report{
+		Session:  s.id,
+		Sent:     s.expected,
+		Received: uint64(len(s.received)),
+		Bytes:    s.bytes,
+	}
+
+	if r.Sent > 0 && r.Received <= r.Sent {
+		r.Loss = 100 * float64(r.Sent-r.Received) / float64(r.Sent)
+	}
+
+	if len(s.delays) == 0 {
+		return r
+	}
+
+	r.Duration = s.end.Sub(s.start)
+	if r.Duration > 0 {
+		r.Goodput = 8 * float64(r.Bytes) / r.Duration.Seconds()
+	}
+
+	delays := append([]time.Duration(nil), s.delays...)
+	sort.Slice(delays, func(i, j int) bool { return delays[i] < delays[j] })
+
+	percentile := func(p float64) time.Duration {
+		return delays[int(p*float64(len(delays)-1))]
+	}
+	r.DelayMin = delays[0]
+	r.DelayP50 = percentile(0.5)
+	r.DelayP90 = percentile(0.9)
+	r.DelayP99 = percentile(0.99)
+	r.DelayMax = delays[len(delays)-1]
+	return r
+}
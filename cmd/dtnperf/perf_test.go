@@ -0,0 +1,92 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package main
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestPerfHeader(t *testing.T) {
+	h := perfHeader{kind: kindData, session: 23, number: 42, sent: time.Unix(0, 1234567890)}
+
+	for size, expected := range map[int]int{0: perfHeaderLen, perfHeaderLen: perfHeaderLen, 1024: 1024} {
+		payload := h.encode(size)
+		if len(payload) != expected {
+			t.Fatalf("size %d: expected %d bytes, got %d", size, expected, len(payload))
+		}
+
+		if h2, err := decodePerfHeader(payload); err != nil {
+			t.Fatal(err)
+		} else if h2.kind != h.kind || h2.session != h.session || h2.number != h.number || !h2.sent.Equal(h.sent) {
+			t.Fatalf("size %d: expected %v, got %v", size, h, h2)
+		}
+	}
+
+	invalid := h.encode(perfHeaderLen)
+	copy(invalid, "nope")
+	for _, payload := range [][]byte{h.encode(perfHeaderLen)[:perfHeaderLen-1], invalid} {
+		if _, err := decodePerfHeader(payload); err == nil {
+			t.Fatalf("invalid payload %x was decoded", payload)
+		}
+	}
+}
+
+func TestSessionReport(t *testing.T) {
+	start := time.Now()
+
+	// Three of four bundles of 1000 bytes arrive, each one second apart with an increasing delay.
+	s := newSession(23)
+	for i, delay := range []time.Duration{100 * time.Millisecond, 300 * time.Millisecond, 200 * time.Millisecond} {
+		sent := start.Add(time.Duration(i) * time.Second)
+		s.receive(perfHeader{kind: kindData, session: 23, number: uint64(i), sent: sent}, 1000, sent.Add(delay))
+	}
+	// A duplicate is ignored.
+	s.receive(perfHeader{kind: kindData, session: 23, number: 0, sent: start}, 1000, start.Add(5*time.Second))
+
+	if s.complete(start.Add(time.Hour)) {
+		t.Fatal("session is complete before its end was received")
+	}
+	s.done(4, start.Add(10*time.Second))
+	if s.complete(start.Add(5 * time.Second)) {
+		t.Fatal("session is complete before its deadline with a missing bundle")
+	} else if !s.complete(start.Add(10 * time.Second)) {
+		t.Fatal("session is incomplete after its deadline")
+	}
+
+	r := s.report()
+	if goodput := 8 * 3000 / 2.2; math.Abs(r.Goodput-goodput) > 1e-6 {
+		t.Fatalf("expected a goodput of %f bit/s, got %f", goodput, r.Goodput)
+	}
+	r.Goodput = 0
+
+	expected := report{
+		Session:  23,
+		Sent:     4,
+		Received: 3,
+		Loss:     25,
+		Bytes:    3000,
+		Duration: 2200 * time.Millisecond,
+		DelayMin: 100 * time.Millisecond,
+		DelayP50: 200 * time.Millisecond,
+		DelayP90: 200 * time.Millisecond,
+		DelayP99: 200 * time.Millisecond,
+		DelayMax: 300 * time.Millisecond,
+	}
+	if r != expected {
+		t.Fatalf("expected %+v, got %+v", expected, r)
+	}
+}
+
+func TestSessionReportEmpty(t *testing.T) {
+	s := newSession(23)
+	s.done(10, time.Now())
+
+	r := s.report()
+	if r.Loss != 100 || r.Received != 0 || r.Duration != 0 || r.Goodput != 0 {
+		t.Fatalf("expected a complete loss, got %+v", r)
+	}
+}
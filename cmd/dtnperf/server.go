@@ -0,0 +1,129 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/agent"
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+// sessionKey identifies a session by its client's endpoint and session ID.
+type sessionKey struct {
+	client  bpv7.EndpointID
+	session uint64
+}
+
+// serve registers the endpoint, measures incoming sessions, and reports them back to their clients. A session is
+// reported when all its bundles were received or the linger time after receiving its end has passed. The arguments
+// are checked by parseServerArgs.
+func serve(websocket, endpoint string, linger time.Duration) {
+	conn, err := agent.NewWebSocketAgentConnector(websocket, endpoint)
+	if err != nil {
+		printFatal(err, "connecting to websocket")
+	}
+	defer conn.Close()
+
+	bundles := make(chan bpv7.Bundle)
+	go func() {
+		defer close(bundles)
+		for {
+			b, err := conn.ReadBundle()
+			if err != nil {
+				return
+			}
+			bundles <- b
+		}
+	}()
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+
+	ticker := time.NewTicker(500 * time.Millisecond)
+	defer ticker.Stop()
+
+	sessions := make(map[sessionKey]*session)
+
+	finish := func(key sessionKey, s *session) {
+		delete(sessions, key)
+
+		r := s.report()
+		fmt.Printf("%v: %v\n\n", key.client, r)
+
+		data, err := r.marshal()
+		if err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "marshalling report erred: %v\n", err)
+			return
+		}
+
+		b, err := bpv7.Builder().
+			CRC(bpv7.CRC32).
+			Source(endpoint).
+			Destination(key.client).
+			CreationTimestampNow().
+			Lifetime(time.Hour).
+			HopCountBlock(64).
+			PayloadBlock(data).
+			Build()
+		if err == nil {
+			err = conn.WriteBundle(b)
+		}
+		if err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "sending report to %v erred: %v\n", key.client, err)
+		}
+	}
+
+	fmt.Printf("dtnperf server listening on %s\n\n", endpoint)
+
+	for {
+		select {
+		case b, ok := <-bundles:
+			if !ok {
+				printFatal(fmt.Errorf("connection was closed"), "receiving bundle")
+			}
+
+			payload, err := bundlePayload(b)
+			if err != nil {
+				continue
+			}
+			h, err := decodePerfHeader(payload)
+			if err != nil {
+				continue
+			}
+
+			key := sessionKey{client: b.PrimaryBlock.SourceNode, session: h.session}
+			s, exists := sessions[key]
+			if !exists {
+				s = newSession(h.session)
+				sessions[key] = s
+			}
+
+			now := time.Now()
+			if h.kind == kindDone {
+				s.done(h.number, now.Add(linger))
+			} else {
+				s.receive(h, len(payload), now)
+			}
+
+			if s.complete(now) {
+				finish(key, s)
+			}
+
+		case now := <-ticker.C:
+			for key, s := range sessions {
+				if s.complete(now) {
+					finish(key, s)
+				}
+			}
+
+		case <-interrupt:
+			return
+		}
+	}
+}
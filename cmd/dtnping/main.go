@@ -0,0 +1,163 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+// dtnping sends timestamped bundles to a remote node's ping endpoint over dtnd's WebSocket API and reports the
+// round-trip times of the acknowledgments and the loss, like ICMP's ping.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/agent"
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+// printUsage of dtnping and exit with an error code afterwards.
+func printUsage() {
+	_, _ = fmt.Fprintf(os.Stderr, "Usage of %s [flags] websocket sender receiver:\n\n", os.Args[0])
+
+	_, _ = fmt.Fprintf(os.Stderr, "  Sends bundles from sender, an endpoint registered over dtnd's websocket, to\n")
+	_, _ = fmt.Fprintf(os.Stderr, "  receiver, the ping endpoint of a remote dtnd. The round-trip time of each\n")
+	_, _ = fmt.Fprintf(os.Stderr, "  acknowledgment is printed, followed by a summary when stopped.\n\n")
+
+	_, _ = fmt.Fprintf(os.Stderr, "  %s ws://localhost:8080/ws dtn://foo/dtnping dtn://bar/ping\n\n", os.Args[0])
+
+	flag.PrintDefaults()
+	os.Exit(1)
+}
+
+// printFatal of an error with a short context description and exits afterwards.
+func printFatal(err error, msg string) {
+	_, _ = fmt.Fprintf(os.Stderr, "%s erred: %s\n  %v\n", os.Args[0], msg, err)
+	os.Exit(1)
+}
+
+func main() {
+	count := flag.Uint64("c", 0, "stop after sending this many bundles, zero pings until interrupted")
+	interval := flag.Duration("i", time.Second, "interval between two bundles")
+	wait := flag.Duration("W", 10*time.Second, "time to wait for outstanding acknowledgments after the last bundle")
+	lifetime := flag.Duration("lifetime", time.Minute, "lifetime of each bundle")
+	size := flag.Int("s", pingHeaderLen, fmt.Sprintf("payload size of each bundle in bytes, at least %d", pingHeaderLen))
+	flag.Usage = printUsage
+	flag.Parse()
+
+	if flag.NArg() != 3 {
+		printUsage()
+	}
+	if *size < pingHeaderLen {
+		*size = pingHeaderLen
+	}
+	websocket, sender, receiver := flag.Arg(0), flag.Arg(1), flag.Arg(2)
+
+	if _, err := bpv7.NewEndpointID(sender); err != nil {
+		printFatal(err, "parsing sender")
+	}
+	if _, err := bpv7.NewEndpointID(receiver); err != nil {
+		printFatal(err, "parsing receiver")
+	}
+
+	conn, err := agent.NewWebSocketAgentConnector(websocket, sender)
+	if err != nil {
+		printFatal(err, "connecting to websocket")
+	}
+	defer conn.Close()
+
+	pongs := make(chan bpv7.Bundle)
+	go func() {
+		defer close(pongs)
+		for {
+			b, err := conn.ReadBundle()
+			if err != nil {
+				return
+			}
+			pongs <- b
+		}
+	}()
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+
+	ticker := time.NewTicker(*interval)
+	defer ticker.Stop()
+
+	// done fires after the last bundle was sent and the waiting time for outstanding acknowledgments has elapsed.
+	var done <-chan time.Time
+
+	stats := newStatistics()
+	fmt.Printf("PING %s from %s: %d bytes of payload\n", receiver, sender, *size)
+
+	send := func() {
+		now := time.Now()
+		b, err := bpv7.Builder().
+			CRC(bpv7.CRC32).
+			Source(sender).
+			Destination(receiver).
+			BundleCtrlFlags(bpv7.MustNotFragmented).
+			CreationTimestampNow().
+			Lifetime(*lifetime).
+			HopCountBlock(64).
+			PayloadBlock(encodePing(stats.sent, now, *size)).
+			Build()
+		if err != nil {
+			printFatal(err, "creating bundle")
+		}
+
+		if err := conn.WriteBundle(b); err != nil {
+			printFatal(err, "sending bundle")
+		}
+		stats.sent++
+
+		if *count > 0 && stats.sent >= *count {
+			ticker.Stop()
+			done = time.After(*wait)
+		}
+	}
+
+	send()
+
+loop:
+	for {
+		select {
+		case <-ticker.C:
+			send()
+
+		case b, ok := <-pongs:
+			if !ok {
+				_, _ = fmt.Fprintf(os.Stderr, "websocket connection was closed\n")
+				break loop
+			}
+
+			payload, err := pongPayload(b)
+			if err != nil {
+				continue
+			}
+			seq, sent, err := decodePong(payload)
+			if err != nil {
+				continue
+			}
+
+			rtt := time.Since(sent)
+			if !stats.receive(seq, rtt) {
+				continue
+			}
+			fmt.Printf("%d bytes from %v: seq=%d time=%v\n", len(payload), b.PrimaryBlock.SourceNode, seq, rtt)
+
+			if done != nil && stats.complete() {
+				break loop
+			}
+
+		case <-done:
+			break loop
+
+		case <-interrupt:
+			break loop
+		}
+	}
+
+	fmt.Printf("\n--- %s dtnping statistics ---\n%v\n", receiver, stats)
+}
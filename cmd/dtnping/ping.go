@@ -0,0 +1,101 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+// pingHeaderLen is the length of a ping's payload prefix, "ping", its sequence number, and its sending time.
+const pingHeaderLen = 4 + 8 + 8
+
+// encodePing creates a ping's payload, "ping" followed by the sequence number, the sending time in nanoseconds since
+// the Unix epoch, and padding to reach the given size of at least pingHeaderLen. A PingAgent echoes everything after
+// the "ping" prefix.
+func encodePing(seq uint64, sent time.Time, size int) []byte {
+	payload := make([]byte, size)
+	copy(payload, "ping")
+	binary.BigEndian.PutUint64(payload[4:], seq)
+	binary.BigEndian.PutUint64(payload[12:], uint64(sent.UnixNano()))
+	return payload
+}
+
+// decodePong parses the sequence number and sending time of an acknowledged ping's payload.
+func decodePong(payload []byte) (seq uint64, sent time.Time, err error) {
+	if len(payload) < pingHeaderLen || !bytes.HasPrefix(payload, []byte("pong")) {
+		err = fmt.Errorf("payload is no pong of dtnping")
+		return
+	}
+
+	seq = binary.BigEndian.Uint64(payload[4:])
+	sent = time.Unix(0, int64(binary.BigEndian.Uint64(payload[12:])))
+	return
+}
+
+// pongPayload returns a bundle's payload.
+func pongPayload(b bpv7.Bundle) ([]byte, error) {
+	pb, err := b.PayloadBlock()
+	if err != nil {
+		return nil, err
+	}
+	return pb.Value.(*bpv7.PayloadBlock).Data(), nil
+}
+
+// statistics of a dtnping run.
+type statistics struct {
+	sent     uint64
+	received map[uint64]struct{}
+
+	rttMin, rttMax, rttSum time.Duration
+}
+
+// newStatistics creates empty statistics.
+func newStatistics() *statistics {
+	return &statistics{received: make(map[uint64]struct{})}
+}
+
+// receive records a pong's round-trip time. False is returned for duplicates or unknown sequence numbers.
+func (s *statistics) receive(seq uint64, rtt time.Duration) bool {
+	if _, exists := s.received[seq]; exists || seq >= s.sent {
+		return false
+	}
+	s.received[seq] = struct{}{}
+
+	if len(s.received) == 1 || rtt < s.rttMin {
+		s.rttMin = rtt
+	}
+	if rtt > s.rttMax {
+		s.rttMax = rtt
+	}
+	s.rttSum += rtt
+	return true
+}
+
+// complete checks if all sent pings were acknowledged.
+func (s *statistics) complete() bool {
+	return uint64(len(s.received)) == s.sent
+}
+
+// String summarizes the loss and round-trip times.
+func (s *statistics) String() string {
+	received := uint64(len(s.received))
+
+	loss := 0.0
+	if s.sent > 0 {
+		loss = 100 * float64(s.sent-received) / float64(s.sent)
+	}
+
+	summary := fmt.Sprintf("%d bundles transmitted, %d received, %.1f%% bundle loss", s.sent, received, loss)
+	if received > 0 {
+		summary += fmt.Sprintf("\nrtt min/avg/max = %v/%v/%v",
+			s.rttMin, s.rttSum/time.Duration(received), s.rttMax)
+	}
+	return summary
+}
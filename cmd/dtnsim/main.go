@@ -0,0 +1,102 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+// dtnsim runs a whole network, described by a scenario file, within one process and prints the delivery statistics,
+// e.g., for reproducible routing experiments.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/dtn7/dtn7-go/pkg/simulation"
+)
+
+// printUsage of dtnsim and exit with an error code afterwards.
+func printUsage() {
+	_, _ = fmt.Fprintf(os.Stderr, "Usage of %s [flags] scenario.toml:\n\n", os.Args[0])
+
+	_, _ = fmt.Fprintf(os.Stderr, "  Runs all nodes of the scenario, connected by in-memory links following its\n")
+	_, _ = fmt.Fprintf(os.Stderr, "  contact schedule, generates its traffic in real time, and finally prints\n")
+	_, _ = fmt.Fprintf(os.Stderr, "  the delivery statistics of each traffic flow and the counters of each node.\n\n")
+
+	flag.PrintDefaults()
+	os.Exit(1)
+}
+
+// printFatal of an error with a short context description and exits afterwards.
+func printFatal(err error, msg string) {
+	_, _ = fmt.Fprintf(os.Stderr, "%s erred: %s\n  %v\n", os.Args[0], msg, err)
+	os.Exit(1)
+}
+
+// printResult as human-readable tables.
+func printResult(r simulation.Result) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+
+	_, _ = fmt.Fprintln(w, "SOURCE\tDESTINATION\tSENT\tDELIVERED\tRATIO\tMIN DELAY\tMEAN DELAY\tMAX DELAY")
+	for _, fr := range r.Flows {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%.2f\t%v\t%v\t%v\n",
+			fr.Source, fr.Destination, fr.Sent, fr.Delivered, fr.DeliveryRatio(), fr.MinDelay, fr.MeanDelay, fr.MaxDelay)
+	}
+	_, _ = fmt.Fprintln(w)
+
+	_, _ = fmt.Fprintln(w, "NODE\tRECEIVED\tFORWARDED\tDELIVERED\tSTORED")
+	for _, nr := range r.Nodes {
+		_, _ = fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\n", nr.Node, nr.Received, nr.Forwarded, nr.Delivered, nr.Stored)
+	}
+
+	_ = w.Flush()
+}
+
+func main() {
+	jsonOutput := flag.Bool("json", false, "print the statistics as JSON")
+	storeDir := flag.String("store", "", "directory for the nodes' stores, a removed temporary directory by default")
+	logLevel := flag.String("log-level", "error", "log level of the simulated nodes")
+	flag.Usage = printUsage
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		printUsage()
+	}
+
+	level, err := log.ParseLevel(*logLevel)
+	if err != nil {
+		printFatal(err, "parsing log level")
+	}
+	log.SetLevel(level)
+
+	scenario, err := parseScenario(flag.Arg(0))
+	if err != nil {
+		printFatal(err, "parsing scenario")
+	}
+
+	dir := *storeDir
+	if dir == "" {
+		if dir, err = os.MkdirTemp("", "dtnsim-"); err != nil {
+			printFatal(err, "creating store directory")
+		}
+		defer os.RemoveAll(dir)
+	}
+
+	result, err := simulation.Run(scenario, dir)
+	if err != nil {
+		printFatal(err, "running scenario")
+	}
+
+	if *jsonOutput {
+		msg, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			printFatal(err, "marshaling JSON")
+		}
+		fmt.Println(string(msg))
+	} else {
+		printResult(result)
+	}
+}
@@ -0,0 +1,125 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/BurntSushi/toml"
+
+	"github.com/dtn7/dtn7-go/pkg/routing"
+	"github.com/dtn7/dtn7-go/pkg/simulation"
+)
+
+// tomlScenario is the TOML representation of a simulation.Scenario.
+type tomlScenario struct {
+	Duration string
+	Routing  routing.RoutingConf
+	Nodes    []string
+	Link     []tomlLink
+	Traffic  []tomlFlow
+}
+
+// tomlLink describes a "link" block, whose contacts are pairs of a start and an end duration.
+type tomlLink struct {
+	A, B     string
+	Delay    string
+	Contacts [][]string
+}
+
+// tomlFlow describes a "traffic" block.
+type tomlFlow struct {
+	Source      string
+	Destination string
+	Start       string
+	Interval    string
+	Count       int
+	Size        int
+	Lifetime    string
+}
+
+// parseDuration of a key, where empty values result in the default duration.
+func parseDuration(key, value string, def time.Duration) (time.Duration, error) {
+	if value == "" {
+		return def, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %v", key, err)
+	}
+	return d, nil
+}
+
+// parseScenario from a TOML file.
+func parseScenario(filename string) (s simulation.Scenario, err error) {
+	var conf tomlScenario
+	meta, err := toml.DecodeFile(filename, &conf)
+	if err != nil {
+		return
+	}
+	if undecoded := meta.Undecoded(); len(undecoded) > 0 {
+		err = fmt.Errorf("unknown key %s", undecoded[0])
+		return
+	}
+
+	if s.Duration, err = parseDuration("duration", conf.Duration, 0); err != nil {
+		return
+	}
+
+	s.Routing = conf.Routing
+	if s.Routing.Algorithm == "" {
+		s.Routing.Algorithm = "epidemic"
+	}
+	s.Nodes = conf.Nodes
+
+	for i, l := range conf.Link {
+		lc := simulation.LinkConf{A: l.A, B: l.B}
+		if lc.Delay, err = parseDuration(fmt.Sprintf("link[%d].delay", i), l.Delay, 0); err != nil {
+			return
+		}
+
+		for _, contact := range l.Contacts {
+			if len(contact) != 2 {
+				err = fmt.Errorf("link[%d].contacts: %v is no pair of a start and an end", i, contact)
+				return
+			}
+
+			var c simulation.Contact
+			if c.Start, err = parseDuration(fmt.Sprintf("link[%d].contacts", i), contact[0], 0); err != nil {
+				return
+			}
+			if c.End, err = parseDuration(fmt.Sprintf("link[%d].contacts", i), contact[1], 0); err != nil {
+				return
+			}
+			lc.Contacts = append(lc.Contacts, c)
+		}
+
+		s.Links = append(s.Links, lc)
+	}
+
+	for i, f := range conf.Traffic {
+		flow := simulation.Flow{Source: f.Source, Destination: f.Destination, Count: f.Count, Size: f.Size}
+		if flow.Count == 0 {
+			flow.Count = 1
+		}
+
+		key := fmt.Sprintf("traffic[%d]", i)
+		if flow.Start, err = parseDuration(key+".start", f.Start, 0); err != nil {
+			return
+		}
+		if flow.Interval, err = parseDuration(key+".interval", f.Interval, time.Second); err != nil {
+			return
+		}
+		if flow.Lifetime, err = parseDuration(key+".lifetime", f.Lifetime, 24*time.Hour); err != nil {
+			return
+		}
+
+		s.Traffic = append(s.Traffic, flow)
+	}
+
+	err = s.Validate()
+	return
+}
@@ -0,0 +1,80 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/dtn7/cboring"
+)
+
+// chunk of a file's version, the payload of each bundle sent by dtnsync.
+type chunk struct {
+	// path of the file relative to the synchronized directory, separated by slashes.
+	path string
+	// hash is the SHA-256 of the whole file, identifying this version.
+	hash    []byte
+	size    uint64
+	modTime time.Time
+	mode    os.FileMode
+
+	// index of this chunk within the count of chunks; an empty file has one empty chunk.
+	index uint64
+	count uint64
+	data  []byte
+}
+
+func (c *chunk) MarshalCbor(w io.Writer) error {
+	if err := cboring.WriteArrayLength(8, w); err != nil {
+		return err
+	}
+
+	if err := cboring.WriteTextString(c.path, w); err != nil {
+		return err
+	}
+	if err := cboring.WriteByteString(c.hash, w); err != nil {
+		return err
+	}
+	for _, n := range []uint64{c.size, uint64(c.modTime.UnixNano()), uint64(c.mode.Perm()), c.index, c.count} {
+		if err := cboring.WriteUInt(n, w); err != nil {
+			return err
+		}
+	}
+	return cboring.WriteByteString(c.data, w)
+}
+
+func (c *chunk) UnmarshalCbor(r io.Reader) (err error) {
+	if n, arrErr := cboring.ReadArrayLength(r); arrErr != nil {
+		return arrErr
+	} else if n != 8 {
+		return fmt.Errorf("expected array of 8 elements, got %d", n)
+	}
+
+	if c.path, err = cboring.ReadTextString(r); err != nil {
+		return
+	}
+	if c.hash, err = cboring.ReadByteString(r); err != nil {
+		return
+	}
+
+	var fields [5]uint64
+	for i := range fields {
+		if fields[i], err = cboring.ReadUInt(r); err != nil {
+			return
+		}
+	}
+	c.size, c.modTime, c.mode = fields[0], time.Unix(0, int64(fields[1])), os.FileMode(fields[2]).Perm()
+	c.index, c.count = fields[3], fields[4]
+
+	if c.index >= c.count {
+		return fmt.Errorf("chunk %d exceeds count of %d", c.index, c.count)
+	}
+
+	c.data, err = cboring.ReadByteString(r)
+	return
+}
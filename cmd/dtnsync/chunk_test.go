@@ -0,0 +1,43 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/dtn7/cboring"
+)
+
+func TestChunkCbor(t *testing.T) {
+	tests := []struct {
+		c     chunk
+		valid bool
+	}{
+		{chunk{path: "a.txt", hash: []byte{1, 2, 3}, size: 11, modTime: time.Unix(0, 1650000000123456789),
+			mode: 0640, index: 1, count: 3, data: []byte("o wo")}, true},
+		{chunk{path: "sub/empty", hash: []byte{}, modTime: time.Unix(1650000000, 0), mode: 0600, count: 1,
+			data: []byte{}}, true},
+		{chunk{path: "a.txt", hash: []byte{}, modTime: time.Unix(0, 0), index: 3, count: 3, data: []byte{}}, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.c.path, func(t *testing.T) {
+			buff := new(bytes.Buffer)
+			if err := cboring.Marshal(&test.c, buff); err != nil {
+				t.Fatal(err)
+			}
+
+			var c chunk
+			if err := cboring.Unmarshal(&c, buff); (err == nil) != test.valid {
+				t.Fatalf("expected valid %t, got error %v", test.valid, err)
+			} else if test.valid && !reflect.DeepEqual(c, test.c) {
+				t.Fatalf("expected %+v, got %+v", test.c, c)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+// dtnsync synchronizes a directory over dtnd's WebSocket API. A sending instance watches its directory and sends each
+// changed file in chunks to the subscribers' endpoints, where receiving instances reassemble and write the files.
+//
+// Both sides keep their state within the directory's ".dtnsync" subdirectory. The sender remembers the sent versions,
+// resuming with the files changed in the meantime after a restart. The receiver stores the chunks of each incomplete
+// file, which are completed by chunks arriving after a restart or a disruption. Deleted files are not synchronized.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+// stateDir within the synchronized directory, which is never synchronized itself.
+const stateDir = ".dtnsync"
+
+// syncConf configures a dtnsync instance.
+type syncConf struct {
+	websocket   string
+	endpoint    string
+	directory   string
+	subscribers []string
+
+	chunkSize int
+	lifetime  time.Duration
+	interval  time.Duration
+}
+
+// printUsage of dtnsync and exit with an error code afterwards.
+func printUsage() {
+	_, _ = fmt.Fprintf(os.Stderr, "Usage of %s [flags] send websocket endpoint directory subscriber [subscriber...]\n", os.Args[0])
+	_, _ = fmt.Fprintf(os.Stderr, "      or %s [flags] receive websocket endpoint directory:\n\n", os.Args[0])
+
+	_, _ = fmt.Fprintf(os.Stderr, "  send     watches the directory and sends changed files to the subscribers.\n")
+	_, _ = fmt.Fprintf(os.Stderr, "  receive  writes the files received at the endpoint to the directory.\n\n")
+
+	_, _ = fmt.Fprintf(os.Stderr, "  %s send ws://localhost:8080/ws dtn://foo/sync ~/share dtn://bar/sync\n", os.Args[0])
+	_, _ = fmt.Fprintf(os.Stderr, "  %s receive ws://localhost:8080/ws dtn://bar/sync ~/share\n\n", os.Args[0])
+
+	flag.PrintDefaults()
+	os.Exit(1)
+}
+
+// printFatal of an error with a short context description and exits afterwards.
+func printFatal(err error, msg string) {
+	_, _ = fmt.Fprintf(os.Stderr, "%s erred: %s\n  %v\n", os.Args[0], msg, err)
+	os.Exit(1)
+}
+
+func main() {
+	chunkSize := flag.Int("chunk-size", 256*1024, "maximum bytes of a file sent within one bundle")
+	lifetime := flag.Duration("lifetime", 24*time.Hour, "lifetime of sent bundles")
+	interval := flag.Duration("interval", time.Minute, "interval to rescan the directory, besides watching it")
+	flag.Usage = printUsage
+	flag.Parse()
+
+	if flag.NArg() < 4 || *chunkSize <= 0 || *interval <= 0 {
+		printUsage()
+	}
+
+	conf := syncConf{
+		websocket:   flag.Arg(1),
+		endpoint:    flag.Arg(2),
+		directory:   flag.Arg(3),
+		subscribers: flag.Args()[4:],
+
+		chunkSize: *chunkSize,
+		lifetime:  *lifetime,
+		interval:  *interval,
+	}
+
+	if _, err := bpv7.NewEndpointID(conf.endpoint); err != nil {
+		printFatal(err, "parsing endpoint")
+	}
+	if info, err := os.Stat(conf.directory); err != nil {
+		printFatal(err, "opening directory")
+	} else if !info.IsDir() {
+		printFatal(fmt.Errorf("%s is no directory", conf.directory), "opening directory")
+	}
+
+	switch flag.Arg(0) {
+	case "send":
+		if len(conf.subscribers) == 0 {
+			printUsage()
+		}
+		runSender(conf)
+
+	case "receive":
+		if len(conf.subscribers) != 0 {
+			printUsage()
+		}
+		runReceiver(conf)
+
+	default:
+		printUsage()
+	}
+}
@@ -0,0 +1,174 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/dtn7/cboring"
+
+	"github.com/dtn7/dtn7-go/pkg/agent"
+)
+
+// receiver writes chunks to the partial directory and reassembles each file after receiving all its chunks.
+type receiver struct {
+	conf syncConf
+}
+
+// runReceiver until the connection to dtnd is closed.
+func runReceiver(conf syncConf) {
+	conn, err := agent.NewWebSocketAgentConnector(conf.websocket, conf.endpoint)
+	if err != nil {
+		printFatal(err, "connecting to websocket")
+	}
+	defer conn.Close()
+
+	r := &receiver{conf: conf}
+	for {
+		b, err := conn.ReadBundle()
+		if err != nil {
+			printFatal(err, "receiving bundle")
+		}
+
+		payload, err := b.PayloadData()
+		if err != nil {
+			log.WithError(err).WithField("bundle", b.ID()).Warn("Reading payload erred")
+			continue
+		}
+
+		var c chunk
+		if err := cboring.Unmarshal(&c, bytes.NewReader(payload)); err != nil {
+			log.WithError(err).WithField("bundle", b.ID()).Info("Received bundle is no chunk")
+			continue
+		}
+
+		if err := r.receive(&c); err != nil {
+			log.WithError(err).WithFields(log.Fields{
+				"bundle": b.ID(),
+				"file":   c.path,
+			}).Warn("Receiving chunk erred")
+		}
+	}
+}
+
+// target file of a chunk's path, which must be located within the directory.
+func (r *receiver) target(path string) (string, error) {
+	rel := filepath.Clean(filepath.FromSlash(path))
+	if filepath.IsAbs(rel) || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) ||
+		rel == stateDir || strings.HasPrefix(rel, stateDir+string(filepath.Separator)) {
+		return "", fmt.Errorf("path %q is not within the directory", path)
+	}
+	return filepath.Join(r.conf.directory, rel), nil
+}
+
+// partial directory of a file's version, holding its chunks until all arrived.
+func (r *receiver) partial(c *chunk) string {
+	key := sha256.Sum256(append([]byte(c.path+"\x00"), c.hash...))
+	return filepath.Join(r.conf.directory, stateDir, "partial", hex.EncodeToString(key[:]))
+}
+
+// receive a chunk and write its file after all chunks arrived, unless a newer version is already present.
+func (r *receiver) receive(c *chunk) error {
+	target, err := r.target(c.path)
+	if err != nil {
+		return err
+	}
+
+	if info, err := os.Stat(target); err == nil && !info.ModTime().Before(c.modTime) {
+		log.WithField("file", c.path).Debug("Skipping chunk of an outdated version")
+		return nil
+	}
+
+	partial := r.partial(c)
+	if err := os.MkdirAll(partial, 0700); err != nil {
+		return err
+	}
+
+	chunkFile := filepath.Join(partial, strconv.FormatUint(c.index, 10))
+	if err := os.WriteFile(chunkFile+".tmp", c.data, 0600); err != nil {
+		return err
+	} else if err := os.Rename(chunkFile+".tmp", chunkFile); err != nil {
+		return err
+	}
+
+	for i := uint64(0); i < c.count; i++ {
+		if _, err := os.Stat(filepath.Join(partial, strconv.FormatUint(i, 10))); err != nil {
+			return nil
+		}
+	}
+
+	return r.assemble(c, partial, target)
+}
+
+// assemble a file from its partial directory's chunks and move it to its target after verifying its hash.
+func (r *receiver) assemble(c *chunk, partial, target string) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(target), ".dtnsync-")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	hash := sha256.New()
+	size, err := copyChunks(io.MultiWriter(tmp, hash), partial, c.count)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	// A mismatch results from a file changing while being sent; its next version is on its way.
+	if size != c.size || !bytes.Equal(hash.Sum(nil), c.hash) {
+		_ = os.RemoveAll(partial)
+		return fmt.Errorf("reassembled file does not match its hash")
+	}
+
+	if err := os.Chmod(tmp.Name(), c.mode); err != nil {
+		return err
+	} else if err := os.Chtimes(tmp.Name(), c.modTime, c.modTime); err != nil {
+		return err
+	} else if err := os.Rename(tmp.Name(), target); err != nil {
+		return err
+	}
+
+	log.WithFields(log.Fields{
+		"file": c.path,
+		"size": c.size,
+	}).Info("Received file")
+	return os.RemoveAll(partial)
+}
+
+// copyChunks of a partial directory in their order.
+func copyChunks(w io.Writer, partial string, count uint64) (uint64, error) {
+	var size uint64
+	for i := uint64(0); i < count; i++ {
+		f, err := os.Open(filepath.Join(partial, strconv.FormatUint(i, 10)))
+		if err != nil {
+			return size, err
+		}
+
+		n, err := io.Copy(w, f)
+		_ = f.Close()
+		if err != nil {
+			return size, err
+		}
+		size += uint64(n)
+	}
+	return size, nil
+}
@@ -0,0 +1,207 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// chunksOf a file's content, as sent by a sender with the given chunk size.
+func chunksOf(path string, data []byte, chunkSize int, modTime time.Time) (chunks []*chunk) {
+	hash := sha256.Sum256(data)
+	count := (len(data) + chunkSize - 1) / chunkSize
+	if count == 0 {
+		count = 1
+	}
+
+	for i := 0; i < count; i++ {
+		end := (i + 1) * chunkSize
+		if end > len(data) {
+			end = len(data)
+		}
+
+		chunks = append(chunks, &chunk{
+			path:    path,
+			hash:    hash[:],
+			size:    uint64(len(data)),
+			modTime: modTime,
+			mode:    0640,
+			index:   uint64(i),
+			count:   uint64(count),
+			data:    data[i*chunkSize : end],
+		})
+	}
+	return
+}
+
+// compareDirs fails if the synchronized files of both directories differ in their content, mode, or modification time.
+func compareDirs(t *testing.T, src, dst string) {
+	t.Helper()
+
+	files := func(dir string) map[string]fs.FileInfo {
+		infos := make(map[string]fs.FileInfo)
+		err := filepath.WalkDir(dir, func(file string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			} else if d.IsDir() && d.Name() == stateDir {
+				return filepath.SkipDir
+			} else if d.IsDir() {
+				return nil
+			}
+
+			rel, _ := filepath.Rel(dir, file)
+			infos[rel], err = d.Info()
+			return err
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return infos
+	}
+
+	srcFiles, dstFiles := files(src), files(dst)
+	if len(srcFiles) != len(dstFiles) {
+		t.Fatalf("expected %d files, got %d", len(srcFiles), len(dstFiles))
+	}
+
+	for rel, srcInfo := range srcFiles {
+		dstInfo, ok := dstFiles[rel]
+		if !ok {
+			t.Fatalf("file %s is missing", rel)
+		} else if srcInfo.Mode() != dstInfo.Mode() {
+			t.Fatalf("file %s: expected mode %v, got %v", rel, srcInfo.Mode(), dstInfo.Mode())
+		} else if !srcInfo.ModTime().Equal(dstInfo.ModTime()) {
+			t.Fatalf("file %s: expected modification time %v, got %v", rel, srcInfo.ModTime(), dstInfo.ModTime())
+		}
+
+		srcData, err := os.ReadFile(filepath.Join(src, rel))
+		if err != nil {
+			t.Fatal(err)
+		}
+		dstData, err := os.ReadFile(filepath.Join(dst, rel))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(srcData, dstData) {
+			t.Fatalf("file %s: expected content %q, got %q", rel, srcData, dstData)
+		}
+	}
+}
+
+func TestReceiverTarget(t *testing.T) {
+	dir := t.TempDir()
+	r := &receiver{conf: syncConf{directory: dir}}
+
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{"a.txt", filepath.Join(dir, "a.txt")},
+		{"sub/b.txt", filepath.Join(dir, "sub", "b.txt")},
+		{"sub/../a.txt", filepath.Join(dir, "a.txt")},
+		{"", ""},
+		{".", ""},
+		{"..", ""},
+		{"../a.txt", ""},
+		{"sub/../../a.txt", ""},
+		{"/etc/passwd", ""},
+		{stateDir, ""},
+		{stateDir + "/sent.json", ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			target, err := r.target(test.path)
+			if (err == nil) != (test.expected != "") {
+				t.Fatalf("expected target %q, got error %v", test.expected, err)
+			} else if target != test.expected {
+				t.Fatalf("expected target %q, got %q", test.expected, target)
+			}
+		})
+	}
+}
+
+func TestReceiverReceive(t *testing.T) {
+	dir := t.TempDir()
+	r := &receiver{conf: syncConf{directory: dir}}
+
+	modTime := time.Unix(0, 1650000000123456789)
+	chunks := chunksOf("sub/a.txt", []byte("hello world"), 4, modTime)
+	target := filepath.Join(dir, "sub", "a.txt")
+
+	// Chunks arrive in any order, and the file is not written before all arrived.
+	for _, i := range []int{2, 0} {
+		if err := r.receive(chunks[i]); err != nil {
+			t.Fatal(err)
+		} else if _, err := os.Stat(target); !os.IsNotExist(err) {
+			t.Fatalf("file was written after chunk %d: %v", i, err)
+		}
+	}
+
+	// Receiving the remaining chunk after a restart completes the file.
+	r = &receiver{conf: syncConf{directory: dir}}
+	if err := r.receive(chunks[1]); err != nil {
+		t.Fatal(err)
+	}
+
+	if data, err := os.ReadFile(target); err != nil {
+		t.Fatal(err)
+	} else if string(data) != "hello world" {
+		t.Fatalf("expected content %q, got %q", "hello world", data)
+	}
+	if info, err := os.Stat(target); err != nil {
+		t.Fatal(err)
+	} else if info.Mode() != 0640 {
+		t.Fatalf("expected mode %v, got %v", fs.FileMode(0640), info.Mode())
+	} else if !info.ModTime().Equal(modTime) {
+		t.Fatalf("expected modification time %v, got %v", modTime, info.ModTime())
+	}
+	if _, err := os.Stat(r.partial(chunks[0])); !os.IsNotExist(err) {
+		t.Fatalf("partial directory was not removed: %v", err)
+	}
+
+	// An older version does not replace the present file.
+	for _, c := range chunksOf("sub/a.txt", []byte("outdated"), 4, modTime.Add(-time.Second)) {
+		if err := r.receive(c); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if data, err := os.ReadFile(target); err != nil {
+		t.Fatal(err)
+	} else if string(data) != "hello world" {
+		t.Fatalf("outdated version replaced the file, got %q", data)
+	}
+}
+
+func TestReceiverReceiveMismatch(t *testing.T) {
+	dir := t.TempDir()
+	r := &receiver{conf: syncConf{directory: dir}}
+
+	// The file changed while being sent: its chunks belong to different versions.
+	chunks := chunksOf("a.txt", []byte("hello world"), 4, time.Unix(1650000000, 0))
+	chunks[1].data = []byte("o WO")
+
+	var err error
+	for _, c := range chunks {
+		if err = r.receive(c); err != nil {
+			break
+		}
+	}
+	if err == nil {
+		t.Fatal("mismatching file was accepted")
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "a.txt")); !os.IsNotExist(err) {
+		t.Fatalf("mismatching file was written: %v", err)
+	} else if _, err := os.Stat(r.partial(chunks[0])); !os.IsNotExist(err) {
+		t.Fatalf("partial directory was not removed: %v", err)
+	}
+}
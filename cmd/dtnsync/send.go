@@ -0,0 +1,285 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/fs"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/dtn7/cboring"
+
+	"github.com/dtn7/dtn7-go/pkg/agent"
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+// settleTime after the last filesystem event before the directory is scanned, letting writes finish.
+const settleTime = time.Second
+
+// fileState of a sent file's version.
+type fileState struct {
+	Size    int64     `json:"size"`
+	ModTime time.Time `json:"mod_time"`
+	Hash    string    `json:"hash"`
+}
+
+// sender watches a directory and sends each changed file's chunks to the subscribers.
+type sender struct {
+	conf syncConf
+	conn *agent.WebSocketAgentConnector
+
+	watcher *fsnotify.Watcher
+
+	// sent files' versions by their slash-separated path, persisted in the state directory.
+	sent map[string]fileState
+}
+
+// runSender until an interrupt is received.
+func runSender(conf syncConf) {
+	for _, subscriber := range conf.subscribers {
+		if _, err := bpv7.NewEndpointID(subscriber); err != nil {
+			printFatal(err, "parsing subscriber")
+		}
+	}
+
+	s := &sender{conf: conf, sent: make(map[string]fileState)}
+	if err := s.loadState(); err != nil {
+		printFatal(err, "reading state")
+	}
+
+	var err error
+	if s.conn, err = agent.NewWebSocketAgentConnector(conf.websocket, conf.endpoint); err != nil {
+		printFatal(err, "connecting to websocket")
+	}
+	defer s.conn.Close()
+
+	if s.watcher, err = fsnotify.NewWatcher(); err != nil {
+		printFatal(err, "starting file watcher")
+	}
+	defer func() { _ = s.watcher.Close() }()
+
+	// Bundles to this endpoint are not expected, but must be read to not block the connector.
+	go func() {
+		for {
+			if _, err := s.conn.ReadBundle(); err != nil {
+				return
+			}
+		}
+	}()
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+
+	ticker := time.NewTicker(conf.interval)
+	defer ticker.Stop()
+
+	settle := time.NewTimer(0)
+	for {
+		select {
+		case <-interrupt:
+			return
+
+		case <-settle.C:
+			s.scan()
+
+		case <-ticker.C:
+			s.scan()
+
+		case e, ok := <-s.watcher.Events:
+			if !ok {
+				return
+			}
+			log.WithField("event", e).Debug("Received filesystem event")
+			settle.Reset(settleTime)
+
+		case err, ok := <-s.watcher.Errors:
+			if !ok {
+				return
+			}
+			log.WithError(err).Warn("File watcher erred")
+		}
+	}
+}
+
+func (s *sender) statePath() string {
+	return filepath.Join(s.conf.directory, stateDir, "sent.json")
+}
+
+// loadState of the files sent before, if this directory was already synchronized.
+func (s *sender) loadState() error {
+	data, err := os.ReadFile(s.statePath())
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, &s.sent)
+}
+
+// saveState atomically, allowing to resume after an interruption.
+func (s *sender) saveState() error {
+	data, err := json.Marshal(s.sent)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.statePath()), 0700); err != nil {
+		return err
+	}
+	tmp := s.statePath() + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.statePath())
+}
+
+// scan the directory, watch its subdirectories, and send each changed file.
+func (s *sender) scan() {
+	err := filepath.WalkDir(s.conf.directory, func(file string, d fs.DirEntry, err error) error {
+		if err != nil {
+			log.WithError(err).WithField("file", file).Warn("Scanning erred")
+			return nil
+		}
+
+		rel, relErr := filepath.Rel(s.conf.directory, file)
+		if relErr != nil {
+			return relErr
+		}
+
+		switch {
+		case d.IsDir() && rel == stateDir:
+			return filepath.SkipDir
+
+		case d.IsDir():
+			if err := s.watcher.Add(file); err != nil {
+				log.WithError(err).WithField("directory", file).Warn("Watching directory erred")
+			}
+			return nil
+
+		case !d.Type().IsRegular():
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			log.WithError(err).WithField("file", file).Warn("Reading file information erred")
+			return nil
+		}
+
+		path := filepath.ToSlash(rel)
+		if state, ok := s.sent[path]; ok && state.Size == info.Size() && state.ModTime.Equal(info.ModTime()) {
+			return nil
+		}
+
+		if err := s.sendFile(file, path, info); err != nil {
+			log.WithError(err).WithField("file", file).Warn("Sending file erred")
+		}
+		return nil
+	})
+	if err != nil {
+		log.WithError(err).Warn("Scanning directory erred")
+	}
+}
+
+// sendFile's chunks to all subscribers, unless this version was already sent.
+func (s *sender) sendFile(file, path string, info fs.FileInfo) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return err
+	}
+	state := fileState{Size: info.Size(), ModTime: info.ModTime(), Hash: hex.EncodeToString(hash.Sum(nil))}
+
+	if s.sent[path].Hash == state.Hash {
+		s.sent[path] = state
+		return s.saveState()
+	}
+
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	count := uint64(info.Size()+int64(s.conf.chunkSize)-1) / uint64(s.conf.chunkSize)
+	if count == 0 {
+		count = 1
+	}
+
+	logger := log.WithFields(log.Fields{
+		"file":   path,
+		"hash":   state.Hash,
+		"chunks": count,
+	})
+	logger.Info("Sending changed file")
+
+	buff := make([]byte, s.conf.chunkSize)
+	for i := uint64(0); i < count; i++ {
+		n, err := io.ReadFull(f, buff)
+		if err != nil && err != io.ErrUnexpectedEOF && !(err == io.EOF && count == 1) {
+			return err
+		}
+
+		c := &chunk{
+			path:    path,
+			hash:    hash.Sum(nil),
+			size:    uint64(info.Size()),
+			modTime: info.ModTime(),
+			mode:    info.Mode(),
+			index:   i,
+			count:   count,
+			data:    buff[:n],
+		}
+		if err := s.sendChunk(c); err != nil {
+			return err
+		}
+	}
+
+	// A file changed while being sent fails the subscribers' verification, but is sent again by the next scan.
+	s.sent[path] = state
+	return s.saveState()
+}
+
+// sendChunk as a bundle to each subscriber.
+func (s *sender) sendChunk(c *chunk) error {
+	payload := new(bytes.Buffer)
+	if err := cboring.Marshal(c, payload); err != nil {
+		return err
+	}
+
+	for _, subscriber := range s.conf.subscribers {
+		b, err := bpv7.Builder().
+			CRC(bpv7.CRC32).
+			Source(s.conf.endpoint).
+			Destination(subscriber).
+			CreationTimestampNow().
+			Lifetime(s.conf.lifetime).
+			HopCountBlock(64).
+			PayloadBlock(payload.Bytes()).
+			Build()
+		if err != nil {
+			return err
+		}
+
+		if err := s.conn.WriteBundle(b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
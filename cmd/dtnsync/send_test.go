@@ -0,0 +1,192 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/dtn7/cboring"
+
+	"github.com/dtn7/dtn7-go/pkg/agent"
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+// startSender for a directory, connected to a new WebSocketAgent acting as dtnd. The chunks of all sent bundles are
+// forwarded to the returned channel.
+func startSender(t *testing.T, dir string) (*sender, <-chan *chunk) {
+	ws := agent.NewWebSocketAgent()
+	server := httptest.NewServer(http.HandlerFunc(ws.ServeHTTP))
+
+	chunks := make(chan *chunk, 64)
+	go func() {
+		for msg := range ws.MessageSender() {
+			bm, ok := msg.(agent.BundleMessage)
+			if !ok {
+				continue
+			}
+
+			if dst := bm.Bundle.PrimaryBlock.Destination; dst != bpv7.MustNewEndpointID("dtn://bar/sync") {
+				t.Errorf("expected destination dtn://bar/sync, got %v", dst)
+			}
+
+			c := new(chunk)
+			if payload, err := bm.Bundle.PayloadData(); err != nil {
+				t.Error(err)
+			} else if err := cboring.Unmarshal(c, bytes.NewReader(payload)); err != nil {
+				t.Error(err)
+			} else {
+				chunks <- c
+			}
+		}
+	}()
+
+	s := &sender{
+		conf: syncConf{
+			websocket:   "ws" + strings.TrimPrefix(server.URL, "http"),
+			endpoint:    "dtn://foo/sync",
+			directory:   dir,
+			subscribers: []string{"dtn://bar/sync"},
+			chunkSize:   4,
+			lifetime:    time.Hour,
+		},
+		sent: make(map[string]fileState),
+	}
+	if err := s.loadState(); err != nil {
+		t.Fatal(err)
+	}
+
+	var err error
+	if s.conn, err = agent.NewWebSocketAgentConnector(s.conf.websocket, s.conf.endpoint); err != nil {
+		t.Fatal(err)
+	}
+	if s.watcher, err = fsnotify.NewWatcher(); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		_ = s.watcher.Close()
+		s.conn.Close()
+		ws.MessageReceiver() <- agent.ShutdownMessage{}
+		server.Close()
+	})
+	return s, chunks
+}
+
+// scanChunks lets the sender scan its directory and returns the paths of the sent chunks, after passing them to the
+// receiver in reverse order.
+func scanChunks(t *testing.T, s *sender, chunks <-chan *chunk, r *receiver) (paths []string) {
+	t.Helper()
+	s.scan()
+
+	var received []*chunk
+	for done := false; !done; {
+		select {
+		case c := <-chunks:
+			received = append(received, c)
+		case <-time.After(250 * time.Millisecond):
+			done = true
+		}
+	}
+
+	for i := len(received) - 1; i >= 0; i-- {
+		if err := r.receive(received[i]); err != nil {
+			t.Fatal(err)
+		}
+		paths = append(paths, received[i].path)
+	}
+	sort.Strings(paths)
+	return
+}
+
+func TestSenderScan(t *testing.T) {
+	src, dst := t.TempDir(), t.TempDir()
+	r := &receiver{conf: syncConf{directory: dst}}
+
+	modTime := time.Unix(1650000000, 0)
+	for _, f := range []struct {
+		path string
+		data string
+		mode os.FileMode
+	}{
+		{"a.txt", "hello world", 0644},
+		{"empty", "", 0600},
+		{"sub/b.txt", "dtn", 0640},
+		{stateDir + "/ignored", "state", 0600},
+	} {
+		file := filepath.Join(src, filepath.FromSlash(f.path))
+		if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+			t.Fatal(err)
+		} else if err := os.WriteFile(file, []byte(f.data), f.mode); err != nil {
+			t.Fatal(err)
+		} else if err := os.Chmod(file, f.mode); err != nil {
+			t.Fatal(err)
+		} else if err := os.Chtimes(file, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s, chunks := startSender(t, src)
+
+	expected := []string{"a.txt", "a.txt", "a.txt", "empty", "sub/b.txt"}
+	if paths := scanChunks(t, s, chunks, r); strings.Join(paths, ",") != strings.Join(expected, ",") {
+		t.Fatalf("expected chunks of %v, got %v", expected, paths)
+	}
+	compareDirs(t, src, dst)
+
+	// Unchanged files are not sent again.
+	if paths := scanChunks(t, s, chunks, r); len(paths) != 0 {
+		t.Fatalf("unchanged files were sent: %v", paths)
+	}
+
+	// Neither are files whose content is unchanged, while their new modification time is remembered.
+	touched := modTime.Add(time.Minute)
+	if err := os.Chtimes(filepath.Join(src, "a.txt"), touched, touched); err != nil {
+		t.Fatal(err)
+	}
+	if paths := scanChunks(t, s, chunks, r); len(paths) != 0 {
+		t.Fatalf("touched files were sent: %v", paths)
+	} else if !s.sent["a.txt"].ModTime.Equal(touched) {
+		t.Fatalf("expected modification time %v, got %v", touched, s.sent["a.txt"].ModTime)
+	}
+
+	changed := modTime.Add(time.Hour)
+	if err := os.WriteFile(filepath.Join(src, "sub", "b.txt"), []byte("delay tolerant"), 0640); err != nil {
+		t.Fatal(err)
+	} else if err := os.Chtimes(filepath.Join(src, "sub", "b.txt"), changed, changed); err != nil {
+		t.Fatal(err)
+	}
+
+	expected = []string{"sub/b.txt", "sub/b.txt", "sub/b.txt", "sub/b.txt"}
+	if paths := scanChunks(t, s, chunks, r); strings.Join(paths, ",") != strings.Join(expected, ",") {
+		t.Fatalf("expected chunks of %v, got %v", expected, paths)
+	}
+
+	// The receiver's copy of a.txt keeps its original modification time, as the touched version was not sent.
+	if err := os.Chtimes(filepath.Join(dst, "a.txt"), touched, touched); err != nil {
+		t.Fatal(err)
+	}
+	compareDirs(t, src, dst)
+
+	// A restarted sender resumes from its state, sending only files changed in the meantime.
+	if err := os.WriteFile(filepath.Join(src, "c.txt"), []byte("new"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	s, chunks = startSender(t, src)
+	if paths := scanChunks(t, s, chunks, r); strings.Join(paths, ",") != "c.txt" {
+		t.Fatalf("expected chunks of [c.txt], got %v", paths)
+	}
+	compareDirs(t, src, dst)
+}
@@ -0,0 +1,115 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// exporters for each supported output format.
+var exporters = map[string]func(io.Writer, topology) error{
+	"dot":     exportDot,
+	"json":    exportJSON,
+	"geojson": exportGeoJSON,
+}
+
+// dotQuote a string as a GraphViz ID. In contrast to Go's %q, other escape sequences like "\n" are retained.
+func dotQuote(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
+
+// exportDot writes the topology as an undirected GraphViz graph. Nodes which were not queried are dashed.
+func exportDot(w io.Writer, t topology) error {
+	var b strings.Builder
+
+	b.WriteString("graph topology {\n")
+	for _, n := range t.Nodes {
+		label := n.Id
+		if n.Algorithm != "" {
+			label += "\\n" + n.Algorithm
+		}
+
+		style := ""
+		if !n.Queried {
+			style = ", style=dashed"
+		}
+		_, _ = fmt.Fprintf(&b, "  %s [label=%s%s];\n", dotQuote(n.Id), dotQuote(label), style)
+	}
+	for _, l := range t.Links {
+		_, _ = fmt.Fprintf(&b, "  %s -- %s [label=\"%.2f\"];\n", dotQuote(l.A), dotQuote(l.B), l.Quality)
+	}
+	b.WriteString("}\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// exportJSON writes the topology's nodes and links as JSON.
+func exportJSON(w io.Writer, t topology) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(t)
+}
+
+// geoJSONFeature is a GeoJSON Feature of either a Point, a node, or a LineString, a link.
+type geoJSONFeature struct {
+	Type     string `json:"type"`
+	Geometry struct {
+		Type        string      `json:"type"`
+		Coordinates interface{} `json:"coordinates"`
+	} `json:"geometry"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+// position of a node as GeoJSON's [longitude, latitude], if its location is known.
+func (n *node) position() ([]float64, bool) {
+	if len(n.Location) != 2 {
+		return nil, false
+	}
+	return []float64{n.Location[1], n.Location[0]}, true
+}
+
+// exportGeoJSON writes the topology as a GeoJSON FeatureCollection of Points for the nodes and LineStrings for their
+// links. Nodes without a configured location and their links are omitted.
+func exportGeoJSON(w io.Writer, t topology) error {
+	features := make([]geoJSONFeature, 0, len(t.Nodes)+len(t.Links))
+	positions := make(map[string][]float64)
+
+	for _, n := range t.Nodes {
+		pos, ok := n.position()
+		if !ok {
+			continue
+		}
+		positions[n.Id] = pos
+
+		f := geoJSONFeature{Type: "Feature", Properties: map[string]interface{}{"id": n.Id, "algorithm": n.Algorithm}}
+		f.Geometry.Type = "Point"
+		f.Geometry.Coordinates = pos
+		features = append(features, f)
+	}
+
+	for _, l := range t.Links {
+		posA, okA := positions[l.A]
+		posB, okB := positions[l.B]
+		if !okA || !okB {
+			continue
+		}
+
+		f := geoJSONFeature{Type: "Feature", Properties: map[string]interface{}{"a": l.A, "b": l.B, "quality": l.Quality}}
+		f.Geometry.Type = "LineString"
+		f.Geometry.Coordinates = [][]float64{posA, posB}
+		features = append(features, f)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(struct {
+		Type     string           `json:"type"`
+		Features []geoJSONFeature `json:"features"`
+	}{"FeatureCollection", features})
+}
@@ -0,0 +1,91 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+// dtntopo queries the routing state of one or several running dtnd instances through their webservers and exports
+// the merged network topology as a GraphViz graph, JSON, or GeoJSON, e.g., for reports.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// printUsage of dtntopo and exit with an error code afterwards.
+func printUsage() {
+	formats := make([]string, 0, len(exporters))
+	for format := range exporters {
+		formats = append(formats, format)
+	}
+	sort.Strings(formats)
+
+	_, _ = fmt.Fprintf(os.Stderr, "Usage of %s [flags] webserver...:\n\n", os.Args[0])
+
+	_, _ = fmt.Fprintf(os.Stderr, "  Queries the routing state of each dtnd's webserver, merges their neighbors\n")
+	_, _ = fmt.Fprintf(os.Stderr, "  to the network's topology, and writes it as %s.\n", strings.Join(formats, ", "))
+	_, _ = fmt.Fprintf(os.Stderr, "  Unreachable webservers are skipped with a warning.\n\n")
+
+	_, _ = fmt.Fprintf(os.Stderr, "  %s -format dot localhost:8080 localhost:8081 | dot -Tsvg > topology.svg\n\n", os.Args[0])
+
+	flag.PrintDefaults()
+	os.Exit(1)
+}
+
+// printFatal of an error with a short context description and exits afterwards.
+func printFatal(err error, msg string) {
+	_, _ = fmt.Fprintf(os.Stderr, "%s erred: %s\n  %v\n", os.Args[0], msg, err)
+	os.Exit(1)
+}
+
+func main() {
+	format := flag.String("format", "dot", "output format: dot, json, or geojson")
+	output := flag.String("o", "-", "output file, or - for the stdout")
+	timeout := flag.Duration("timeout", 5*time.Second, "timeout for querying each webserver")
+	flag.Usage = printUsage
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		printUsage()
+	}
+	export, ok := exporters[*format]
+	if !ok {
+		printFatal(fmt.Errorf("unknown format %q", *format), "parsing format")
+	}
+
+	client := &http.Client{Timeout: *timeout}
+
+	states := make([]routingState, 0, flag.NArg())
+	for _, address := range flag.Args() {
+		state, err := fetchRoutingState(client, address)
+		if err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "%s: skipping %s: %v\n", os.Args[0], address, err)
+			continue
+		}
+		states = append(states, state)
+	}
+	if len(states) == 0 {
+		printFatal(fmt.Errorf("none of %d webservers responded", flag.NArg()), "querying routing states")
+	}
+
+	var w io.WriteCloser = os.Stdout
+	if *output != "-" {
+		f, err := os.Create(*output)
+		if err != nil {
+			printFatal(err, "creating output file")
+		}
+		w = f
+	}
+
+	if err := export(w, mergeTopology(states)); err != nil {
+		printFatal(err, "writing topology")
+	}
+	if err := w.Close(); err != nil {
+		printFatal(err, "closing output")
+	}
+}
@@ -0,0 +1,137 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// routingState is a daemon's response of its webserver's "/routing" endpoint.
+type routingState struct {
+	Node      string    `json:"node"`
+	Time      string    `json:"time"`
+	Algorithm string    `json:"algorithm"`
+	Location  []float64 `json:"location"`
+	Neighbors []struct {
+		Endpoint  string  `json:"endpoint"`
+		Connected bool    `json:"connected"`
+		Quality   float64 `json:"quality"`
+		LastSeen  string  `json:"last_seen"`
+	} `json:"neighbors"`
+	RoutingTable map[string]string `json:"routing_table"`
+}
+
+// fetchRoutingState queries a daemon's routing state. The address is either the webserver's base URL or a
+// "host:port" pair, e.g., "localhost:8080".
+func fetchRoutingState(client *http.Client, address string) (state routingState, err error) {
+	if !strings.Contains(address, "://") {
+		address = "http://" + address
+	}
+
+	resp, err := client.Get(strings.TrimSuffix(address, "/") + "/routing")
+	if err != nil {
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		err = fmt.Errorf("%s responded %s", address, resp.Status)
+		return
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(&state)
+	return
+}
+
+// node of the merged topology. Nodes only known as another node's neighbor were not queried.
+type node struct {
+	Id           string            `json:"id"`
+	Queried      bool              `json:"queried"`
+	Algorithm    string            `json:"algorithm,omitempty"`
+	Location     []float64         `json:"location,omitempty"`
+	RoutingTable map[string]string `json:"routing_table,omitempty"`
+}
+
+// link between two nodes of the merged topology, reported by at least one of them. If both nodes reported the link,
+// the lower quality is kept.
+type link struct {
+	A       string  `json:"a"`
+	B       string  `json:"b"`
+	Quality float64 `json:"quality"`
+}
+
+// topology merged from the routing states of several daemons.
+type topology struct {
+	Time  time.Time `json:"time"`
+	Nodes []*node   `json:"nodes"`
+	Links []*link   `json:"links"`
+}
+
+// mergeTopology of several daemons' routing states. Only links to currently connected neighbors are included.
+func mergeTopology(states []routingState) topology {
+	nodes := make(map[string]*node)
+	links := make(map[[2]string]*link)
+
+	entry := func(id string) *node {
+		n, ok := nodes[id]
+		if !ok {
+			n = &node{Id: id}
+			nodes[id] = n
+		}
+		return n
+	}
+
+	for _, state := range states {
+		n := entry(state.Node)
+		n.Queried = true
+		n.Algorithm = state.Algorithm
+		n.Location = state.Location
+		n.RoutingTable = state.RoutingTable
+
+		for _, neighbor := range state.Neighbors {
+			if !neighbor.Connected {
+				continue
+			}
+			entry(neighbor.Endpoint)
+
+			key := [2]string{state.Node, neighbor.Endpoint}
+			if key[0] > key[1] {
+				key[0], key[1] = key[1], key[0]
+			}
+
+			if l, ok := links[key]; !ok {
+				links[key] = &link{A: key[0], B: key[1], Quality: neighbor.Quality}
+			} else if neighbor.Quality < l.Quality {
+				l.Quality = neighbor.Quality
+			}
+		}
+	}
+
+	t := topology{
+		Time:  time.Now(),
+		Nodes: make([]*node, 0, len(nodes)),
+		Links: make([]*link, 0, len(links)),
+	}
+	for _, n := range nodes {
+		t.Nodes = append(t.Nodes, n)
+	}
+	for _, l := range links {
+		t.Links = append(t.Links, l)
+	}
+
+	sort.Slice(t.Nodes, func(i, j int) bool { return t.Nodes[i].Id < t.Nodes[j].Id })
+	sort.Slice(t.Links, func(i, j int) bool {
+		if t.Links[i].A != t.Links[j].A {
+			return t.Links[i].A < t.Links[j].A
+		}
+		return t.Links[i].B < t.Links[j].B
+	})
+	return t
+}
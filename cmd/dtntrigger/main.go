@@ -0,0 +1,74 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+// dtntrigger registers an endpoint over dtnd's WebSocket API and executes a command for each received bundle, like
+// IBR-DTN's tool of the same name. The payload is passed on the command's stdin and the bundle's details, including
+// its metadata block, as environment variables.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/dtn7/dtn7-go/pkg/agent"
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+// printUsage of dtntrigger and exit with an error code afterwards.
+func printUsage() {
+	_, _ = fmt.Fprintf(os.Stderr, "Usage of %s [flags] websocket endpoint command [argument...]:\n\n", os.Args[0])
+
+	_, _ = fmt.Fprintf(os.Stderr, "  Registers the endpoint and executes the command for each received bundle,\n")
+	_, _ = fmt.Fprintf(os.Stderr, "  one at a time. The payload is written to the command's stdin. The bundle is\n")
+	_, _ = fmt.Fprintf(os.Stderr, "  described by the environment variables DTN_ENDPOINT, DTN_BUNDLE_ID,\n")
+	_, _ = fmt.Fprintf(os.Stderr, "  DTN_SOURCE, DTN_DESTINATION, DTN_REPORT_TO, DTN_CREATION_TIMESTAMP,\n")
+	_, _ = fmt.Fprintf(os.Stderr, "  DTN_SEQUENCE_NUMBER, DTN_LIFETIME in milliseconds, and %sKEY for each\n", metadataEnvPrefix)
+	_, _ = fmt.Fprintf(os.Stderr, "  entry of a metadata block.\n\n")
+
+	_, _ = fmt.Fprintf(os.Stderr, "  %s ws://localhost:8080/ws dtn://bar/mail sh -c 'mail -s \"$DTN_SOURCE\" root'\n\n", os.Args[0])
+
+	flag.PrintDefaults()
+	os.Exit(1)
+}
+
+// printFatal of an error with a short context description and exits afterwards.
+func printFatal(err error, msg string) {
+	_, _ = fmt.Fprintf(os.Stderr, "%s erred: %s\n  %v\n", os.Args[0], msg, err)
+	os.Exit(1)
+}
+
+func main() {
+	count := flag.Uint64("n", 0, "exit after this many bundles, zero runs forever")
+	timeout := flag.Duration("timeout", 0, "kill a command running longer, zero waits forever")
+	flag.Usage = printUsage
+	flag.Parse()
+
+	if flag.NArg() < 3 {
+		printUsage()
+	}
+	websocket, endpoint, command := flag.Arg(0), flag.Arg(1), flag.Args()[2:]
+
+	if _, err := bpv7.NewEndpointID(endpoint); err != nil {
+		printFatal(err, "parsing endpoint")
+	}
+
+	conn, err := agent.NewWebSocketAgentConnector(websocket, endpoint)
+	if err != nil {
+		printFatal(err, "connecting to websocket")
+	}
+	defer conn.Close()
+
+	for i := uint64(0); *count == 0 || i < *count; i++ {
+		b, payload, err := conn.ReadBundleStream()
+		if err != nil {
+			printFatal(err, "receiving bundle")
+		}
+
+		// A failing command only affects its bundle; the next bundles are still handled.
+		if err := trigger(command, b, payload, endpoint, *timeout); err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "%s: command for %v failed: %v\n", os.Args[0], b.ID(), err)
+		}
+	}
+}
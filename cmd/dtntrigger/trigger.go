@@ -0,0 +1,87 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+// metadataEnvPrefix prefixes the environment variables of a bundle's MetadataBlock entries.
+const metadataEnvPrefix = "DTN_META_"
+
+// envName converts a metadata key into an environment variable's name, e.g., "content-type" to
+// "DTN_META_CONTENT_TYPE". All characters except letters and digits are replaced by underscores.
+func envName(key string) string {
+	return metadataEnvPrefix + strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		default:
+			return '_'
+		}
+	}, key)
+}
+
+// bundleEnv describes a received bundle by environment variables for the triggered command.
+func bundleEnv(b bpv7.Bundle, endpoint string) []string {
+	pb := b.PrimaryBlock
+
+	env := []string{
+		"DTN_ENDPOINT=" + endpoint,
+		"DTN_BUNDLE_ID=" + b.ID().String(),
+		"DTN_SOURCE=" + pb.SourceNode.String(),
+		"DTN_DESTINATION=" + pb.Destination.String(),
+		"DTN_REPORT_TO=" + pb.ReportTo.String(),
+		"DTN_CREATION_TIMESTAMP=" + pb.CreationTimestamp.DtnTime().Time().Format(time.RFC3339Nano),
+		fmt.Sprintf("DTN_SEQUENCE_NUMBER=%d", pb.CreationTimestamp.SequenceNumber()),
+		fmt.Sprintf("DTN_LIFETIME=%d", pb.Lifetime),
+	}
+
+	if metadata, ok := b.Metadata(); ok {
+		keys := make([]string, 0, len(metadata))
+		for key := range metadata {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			env = append(env, envName(key)+"="+metadata[key])
+		}
+	}
+
+	return env
+}
+
+// trigger runs the command for a received bundle with its payload on the stdin. The payload is closed afterwards,
+// refusing any remainder the command did not read.
+func trigger(command []string, b bpv7.Bundle, payload io.ReadCloser, endpoint string, timeout time.Duration) error {
+	defer payload.Close()
+
+	ctx := context.Background()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	cmd := exec.CommandContext(ctx, command[0], command[1:]...)
+	cmd.Stdin = payload
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = append(os.Environ(), bundleEnv(b, endpoint)...)
+
+	return cmd.Run()
+}
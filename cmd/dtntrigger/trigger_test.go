@@ -0,0 +1,141 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package main
+
+import (
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+// closeRecorder is a payload remembering if it was closed.
+type closeRecorder struct {
+	io.Reader
+	closed bool
+}
+
+func (cr *closeRecorder) Close() error {
+	cr.closed = true
+	return nil
+}
+
+// triggerBundle with a metadata block to be passed to a command.
+func triggerBundle(t *testing.T) bpv7.Bundle {
+	b, err := bpv7.Builder().
+		Source("dtn://foo/app").
+		Destination("dtn://bar/trigger").
+		CreationTimestampNow().
+		Lifetime("10m").
+		MetadataBlock(map[string]string{"content-type": "text/plain", "Subject": "hello"}).
+		PayloadBlock([]byte("hello world")).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+// requireShell skips a test without a POSIX shell to run commands by.
+func requireShell(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("no sh available")
+	}
+}
+
+func TestEnvName(t *testing.T) {
+	tests := map[string]string{
+		"subject":      "DTN_META_SUBJECT",
+		"content-type": "DTN_META_CONTENT_TYPE",
+		"Key.2 ü":      "DTN_META_KEY_2__",
+	}
+
+	for key, expected := range tests {
+		if name := envName(key); name != expected {
+			t.Fatalf("%q: expected %s, got %s", key, expected, name)
+		}
+	}
+}
+
+func TestBundleEnv(t *testing.T) {
+	b := triggerBundle(t)
+	env := strings.Join(bundleEnv(b, "dtn://bar/trigger"), "\n")
+
+	for _, expected := range []string{
+		"DTN_ENDPOINT=dtn://bar/trigger",
+		"DTN_BUNDLE_ID=" + b.ID().String(),
+		"DTN_SOURCE=dtn://foo/app",
+		"DTN_DESTINATION=dtn://bar/trigger",
+		"DTN_LIFETIME=600000",
+		"DTN_META_CONTENT_TYPE=text/plain",
+		"DTN_META_SUBJECT=hello",
+	} {
+		if !strings.Contains(env, expected) {
+			t.Fatalf("environment lacks %q:\n%s", expected, env)
+		}
+	}
+}
+
+func TestTrigger(t *testing.T) {
+	requireShell(t)
+
+	out := filepath.Join(t.TempDir(), "out")
+	command := []string{"sh", "-c", `cat > "$1" && echo "$2 $DTN_SOURCE $DTN_META_SUBJECT" >> "$1"`, "sh", out, "arg"}
+	payload := &closeRecorder{Reader: strings.NewReader("hello world\n")}
+
+	if err := trigger(command, triggerBundle(t), payload, "dtn://bar/trigger", 0); err != nil {
+		t.Fatal(err)
+	}
+	if !payload.closed {
+		t.Fatal("payload was not closed")
+	}
+
+	if data, err := os.ReadFile(out); err != nil {
+		t.Fatal(err)
+	} else if expected := "hello world\narg dtn://foo/app hello\n"; string(data) != expected {
+		t.Fatalf("expected %q, got %q", expected, data)
+	}
+}
+
+func TestTriggerFailure(t *testing.T) {
+	requireShell(t)
+
+	tests := []struct {
+		name    string
+		command []string
+		timeout time.Duration
+	}{
+		{"exit code", []string{"sh", "-c", "exit 3"}, 0},
+		{"timeout", []string{"sh", "-c", "exec sleep 10"}, 100 * time.Millisecond},
+		{"missing command", []string{filepath.Join(t.TempDir(), "missing")}, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			payload := &closeRecorder{Reader: strings.NewReader("hello world")}
+
+			start := time.Now()
+			err := trigger(test.command, triggerBundle(t), payload, "dtn://bar/trigger", test.timeout)
+			if err == nil {
+				t.Fatal("failing command did not err")
+			} else if !payload.closed {
+				t.Fatal("payload was not closed")
+			} else if time.Since(start) > 5*time.Second {
+				t.Fatal("command was not killed by its timeout")
+			}
+
+			var exitErr *exec.ExitError
+			if test.name == "exit code" && (!errors.As(err, &exitErr) || exitErr.ExitCode() != 3) {
+				t.Fatalf("expected exit code 3, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+#!/bin/sh
+
+# SPDX-FileCopyrightText: 2026 agent
+#
+# SPDX-License-Identifier: GPL-3.0-or-later
+
+# Runs the benchmarks of the core pipelines and stores their results together
+# with CPU and memory profiles in an output directory, see README.md.
+
+set -eu
+
+OUT="${1:-bench-$(date +%Y%m%d-%H%M%S)}"
+BENCH="${BENCH:-.}"
+COUNT="${COUNT:-5}"
+BENCHTIME="${BENCHTIME:-1s}"
+PACKAGES="${PACKAGES:-bpv7 storage routing node}"
+
+cd "$(dirname "$0")/../.."
+mkdir -p "$OUT"
+
+for pkg in $PACKAGES; do
+  echo "Benchmarking pkg/$pkg"
+
+  go test ./pkg/"$pkg" \
+    -run '^$' -bench "$BENCH" -benchmem \
+    -count "$COUNT" -benchtime "$BENCHTIME" \
+    -o "$OUT/$pkg.test" \
+    -cpuprofile "$OUT/$pkg.cpu.pprof" \
+    -memprofile "$OUT/$pkg.mem.pprof" \
+    | tee "$OUT/$pkg.txt"
+done
+
+cat "$OUT"/*.txt > "$OUT/bench.txt"
+echo "Results are stored in $OUT"
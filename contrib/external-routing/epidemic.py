@@ -0,0 +1,76 @@
+#!/usr/bin/env python3
+
+# SPDX-FileCopyrightText: 2026 agent
+#
+# SPDX-License-Identifier: GPL-3.0-or-later
+
+"""Epidemic routing as an external routing process for dtnd's "external" algorithm.
+
+Each bundle is forwarded to all connected peers which have not received it yet,
+except the peer it was received from. Generate the gRPC stubs and start this
+script before dtnd:
+
+    python3 -m grpc_tools.protoc -I ../../pkg/routing/external \\
+        --python_out=. --grpc_python_out=. external.proto
+    ./epidemic.py localhost:4557
+"""
+
+import sys
+import threading
+from concurrent import futures
+
+import grpc
+from google.protobuf import empty_pb2
+
+import external_pb2
+import external_pb2_grpc
+
+
+class EpidemicRouting(external_pb2_grpc.ExternalRoutingServicer):
+    def __init__(self):
+        # Peers each bundle was sent to, by the bundle's ID.
+        self.sent = {}
+        self.lock = threading.Lock()
+
+    def NotifyNewBundle(self, bundle, context):
+        print("NotifyNewBundle", bundle.id, file=sys.stderr)
+        with self.lock:
+            self.sent.setdefault(bundle.id, set())
+        return empty_pb2.Empty()
+
+    def SenderForBundle(self, request, context):
+        bundle = request.bundle
+        with self.lock:
+            sent = self.sent.setdefault(bundle.id, set())
+            peers = [
+                peer for peer in request.peers
+                if peer.endpoint not in sent and peer.endpoint != bundle.previous_node
+            ]
+            sent.update(peer.endpoint for peer in peers)
+
+        print("SenderForBundle", bundle.id, [peer.endpoint for peer in peers], file=sys.stderr)
+        return external_pb2.SenderForBundleResponse(peers=peers, delete=False)
+
+    def ReportFailure(self, failure, context):
+        print("ReportFailure", failure.bundle.id, failure.peer.endpoint, file=sys.stderr)
+        with self.lock:
+            self.sent.get(failure.bundle.id, set()).discard(failure.peer.endpoint)
+        return empty_pb2.Empty()
+
+    def ReportPeerAppeared(self, peer, context):
+        print("ReportPeerAppeared", peer.endpoint, file=sys.stderr)
+        return empty_pb2.Empty()
+
+    def ReportPeerDisappeared(self, peer, context):
+        print("ReportPeerDisappeared", peer.endpoint, file=sys.stderr)
+        return empty_pb2.Empty()
+
+
+if __name__ == "__main__":
+    address = sys.argv[1] if len(sys.argv) > 1 else "localhost:4557"
+
+    server = grpc.server(futures.ThreadPoolExecutor(max_workers=4))
+    external_pb2_grpc.add_ExternalRoutingServicer_to_server(EpidemicRouting(), server)
+    server.add_insecure_port(address)
+    server.start()
+    server.wait_for_termination()
@@ -3,6 +3,7 @@ module github.com/dtn7/dtn7-go
 require (
 	github.com/BurntSushi/toml v1.1.0
 	github.com/RyanCarrier/dijkstra v1.1.0
+	github.com/dgraph-io/badger v1.6.2
 	github.com/dtn7/cboring v0.1.5
 	github.com/dtn7/rf95modem-go v0.3.1
 	github.com/fsnotify/fsnotify v1.5.4
@@ -15,20 +16,22 @@ require (
 	github.com/sirupsen/logrus v1.8.1
 	github.com/timshannon/badgerhold v1.0.0
 	github.com/ulikunitz/xz v0.5.10
+	golang.org/x/net v0.17.0
 	golang.org/x/sys v0.15.0
+	google.golang.org/grpc v1.56.3
+	google.golang.org/protobuf v1.30.0
 )
 
 require (
 	github.com/AndreasBriese/bbloom v0.0.0-20190825152654-46b345b51c96 // indirect
 	github.com/OneOfOne/xxhash v1.2.8 // indirect
 	github.com/cespare/xxhash v1.1.0 // indirect
-	github.com/dgraph-io/badger v1.6.2 // indirect
 	github.com/dgraph-io/ristretto v0.0.3 // indirect
 	github.com/dgryski/go-farm v0.0.0-20200201041132-a6ae2369ad13 // indirect
 	github.com/dustin/go-humanize v1.0.0 // indirect
 	github.com/go-task/slim-sprig v0.0.0-20210107165309-348f09dbbbc0 // indirect
 	github.com/golang/mock v1.6.0 // indirect
-	github.com/golang/protobuf v1.5.2 // indirect
+	github.com/golang/protobuf v1.5.3 // indirect
 	github.com/google/pprof v0.0.0-20210407192527-94a9f03dee38 // indirect
 	github.com/hashicorp/errwrap v1.1.0 // indirect
 	github.com/onsi/ginkgo/v2 v2.2.0 // indirect
@@ -38,10 +41,10 @@ require (
 	github.com/tarm/serial v0.0.0-20180830185346-98f6abe2eb07 // indirect
 	golang.org/x/crypto v0.17.0 // indirect
 	golang.org/x/exp v0.0.0-20221205204356-47842c84f3db // indirect
-	golang.org/x/mod v0.6.0 // indirect
-	golang.org/x/net v0.17.0 // indirect
-	golang.org/x/tools v0.2.0 // indirect
-	google.golang.org/protobuf v1.28.0 // indirect
+	golang.org/x/mod v0.8.0 // indirect
+	golang.org/x/text v0.14.0 // indirect
+	golang.org/x/tools v0.6.0 // indirect
+	google.golang.org/genproto v0.0.0-20230410155749-daa745c078e1 // indirect
 )
 
-go 1.18
+go 1.19
@@ -0,0 +1,312 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package agent
+
+import (
+	"bufio"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+	"github.com/dtn7/dtn7-go/pkg/internal/bufpool"
+)
+
+// aapMaxPayload limits the payload of a bundle sent by an AAP client, which is held in memory.
+const aapMaxPayload uint64 = 256 * 1024 * 1024
+
+// AAPAgent is an ApplicationAgent for clients of µD3TN's Application Agent Protocol (AAP) in version 1, e.g., µD3TN's
+// Python tools. Thus, applications written for µD3TN can be used with dtn7 as well.
+//
+// Each client registers an agent ID, which is appended to the node ID to form its endpoint, e.g., "dtn://node/sink"
+// for the agent ID "sink" or "ipn:23.42" for the agent ID "42". A client's sent payloads become bundles from its
+// endpoint with a default lifetime.
+type AAPAgent struct {
+	listener net.Listener
+	nodeId   bpv7.EndpointID
+	lifetime time.Duration
+
+	receiver  chan Message
+	clientMux *MuxAgent
+
+	// sequence numbers the created bundles, both as their creation timestamp's sequence number and as their
+	// identifier reported to the clients.
+	sequence uint64
+}
+
+// NewAAPAgent accepts AAP clients on a listener, e.g., a TCP or Unix socket, until the AAPAgent is shut down.
+func NewAAPAgent(listener net.Listener, nodeId bpv7.EndpointID, lifetime time.Duration) (aa *AAPAgent) {
+	aa = &AAPAgent{
+		listener: listener,
+		nodeId:   nodeId,
+		lifetime: lifetime,
+
+		receiver:  make(chan Message),
+		clientMux: NewMuxAgent(),
+	}
+
+	go aa.handler()
+	go aa.accept()
+
+	return
+}
+
+// handler passes Messages to the clients and closes the listener on a shutdown.
+func (aa *AAPAgent) handler() {
+	for msg := range aa.receiver {
+		aa.clientMux.MessageReceiver() <- msg
+
+		if _, isShutdown := msg.(ShutdownMessage); isShutdown {
+			log.Info("AAPAgent received a shutdown")
+			_ = aa.listener.Close()
+			return
+		}
+	}
+}
+
+// accept new clients until the listener is closed.
+func (aa *AAPAgent) accept() {
+	for {
+		conn, err := aa.listener.Accept()
+		if err != nil {
+			log.WithError(err).Debug("AAPAgent stops accepting clients")
+			return
+		}
+
+		client := newAapClient(aa, conn)
+		aa.clientMux.Register(client)
+
+		go client.start()
+	}
+}
+
+// endpoint for a client's agent ID, appended to the node ID.
+func (aa *AAPAgent) endpoint(agentId string) (bpv7.EndpointID, error) {
+	return serviceEndpoint(aa.nodeId, agentId)
+}
+
+func (aa *AAPAgent) Endpoints() []bpv7.EndpointID {
+	return aa.clientMux.Endpoints()
+}
+
+// MessageReceiver is a channel on which the ApplicationAgent must listen for incoming Messages.
+func (aa *AAPAgent) MessageReceiver() chan Message {
+	return aa.receiver
+}
+
+// MessageSender is a channel to which the ApplicationAgent can send outgoing Messages.
+func (aa *AAPAgent) MessageSender() chan Message {
+	return aa.clientMux.MessageSender()
+}
+
+// aapClient is a single connection of an AAPAgent, registered at its MuxAgent.
+type aapClient struct {
+	agent *AAPAgent
+	conn  net.Conn
+
+	// writeMutex serializes the answers of handleConn and the bundles of handleReceiver.
+	writeMutex sync.Mutex
+
+	endpointMutex sync.Mutex
+	endpoint      bpv7.EndpointID
+
+	receiver chan Message
+	sender   chan Message
+
+	shutdownOnce sync.Once
+}
+
+func newAapClient(agent *AAPAgent, conn net.Conn) *aapClient {
+	return &aapClient{
+		agent:    agent,
+		conn:     conn,
+		receiver: make(chan Message),
+		sender:   make(chan Message),
+	}
+}
+
+func (client *aapClient) start() {
+	go client.handleReceiver()
+	client.handleConn()
+}
+
+func (client *aapClient) shutdown() {
+	client.shutdownOnce.Do(func() {
+		log.WithField("aap client", client.conn.RemoteAddr().String()).Debug("Reached shutdown")
+
+		close(client.sender)
+		_ = client.conn.Close()
+	})
+}
+
+// write a message to the client.
+func (client *aapClient) write(msg aapMessage) error {
+	client.writeMutex.Lock()
+	defer client.writeMutex.Unlock()
+
+	w := bufpool.GetWriter(client.conn)
+	defer bufpool.PutWriter(w)
+
+	if err := msg.write(w); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+// handleReceiver passes received bundles to the client until a shutdown.
+func (client *aapClient) handleReceiver() {
+	defer client.shutdown()
+
+	var logger = log.WithField("aap client", client.conn.RemoteAddr().String())
+
+	for msg := range client.receiver {
+		switch msg := msg.(type) {
+		case ShutdownMessage:
+			logger.Debug("Received Shutdown")
+			return
+
+		case BundleMessage:
+			payload, err := msg.Bundle.PayloadData()
+			if err != nil {
+				logger.WithError(err).WithField("bundle", msg.Bundle.ID()).Warn("Reading payload erred")
+				continue
+			}
+
+			recvMsg := aapMessage{msgType: aapRecvBundle, eid: msg.Bundle.PrimaryBlock.SourceNode.String(), payload: payload}
+			if err := client.write(recvMsg); err != nil {
+				logger.WithError(err).Warn("Sending outgoing Bundle erred")
+				return
+			}
+			logger.WithField("bundle", msg.Bundle.ID()).Info("Sent Bundle to client")
+
+		default:
+			logger.WithField("message", msg).Debug("Received unknown / unsupported message")
+		}
+	}
+}
+
+// handleConn welcomes the client and handles its messages until the connection is closed.
+func (client *aapClient) handleConn() {
+	defer client.shutdown()
+
+	var logger = log.WithField("aap client", client.conn.RemoteAddr().String())
+
+	if err := client.write(aapMessage{msgType: aapWelcome, eid: client.agent.nodeId.String()}); err != nil {
+		logger.WithError(err).Warn("Sending welcome erred")
+		return
+	}
+
+	r := bufio.NewReader(client.conn)
+	for {
+		msg, err := readAapMessage(r, aapMaxPayload)
+		if err != nil {
+			logger.WithError(err).Debug("Reading AAP message erred")
+			return
+		}
+
+		var answer aapMessage
+		switch msg.msgType {
+		case aapRegister:
+			answer = client.handleRegister(msg)
+
+		case aapSendBundle:
+			answer = client.handleSendBundle(msg)
+
+		case aapPing:
+			answer = aapMessage{msgType: aapAck}
+
+		case aapAck, aapNack:
+			continue
+
+		default:
+			logger.WithField("type", msg.msgType).Info("Received unsupported AAP message")
+			answer = aapMessage{msgType: aapNack}
+		}
+
+		if err := client.write(answer); err != nil {
+			logger.WithError(err).Warn("Sending answer erred")
+			return
+		}
+	}
+}
+
+// handleRegister of the client's agent ID, which is only possible once.
+func (client *aapClient) handleRegister(msg aapMessage) aapMessage {
+	client.endpointMutex.Lock()
+	defer client.endpointMutex.Unlock()
+
+	var logger = log.WithFields(log.Fields{
+		"aap client": client.conn.RemoteAddr().String(),
+		"agent id":   msg.eid,
+	})
+
+	if client.endpoint != (bpv7.EndpointID{}) {
+		logger.Info("Client tried to register a second agent ID")
+		return aapMessage{msgType: aapNack}
+	}
+
+	endpoint, err := client.agent.endpoint(msg.eid)
+	if err != nil {
+		logger.WithError(err).Info("Client's registration failed")
+		return aapMessage{msgType: aapNack}
+	}
+
+	client.endpoint = endpoint
+	logger.WithField("endpoint", endpoint).Info("Client registered")
+	return aapMessage{msgType: aapAck}
+}
+
+// handleSendBundle creates a bundle from the client's endpoint and passes it on.
+func (client *aapClient) handleSendBundle(msg aapMessage) aapMessage {
+	var source = client.getEndpoint()
+	if source == (bpv7.EndpointID{}) {
+		return aapMessage{msgType: aapNack}
+	}
+
+	sequence := atomic.AddUint64(&client.agent.sequence, 1)
+
+	bndl, err := bpv7.Builder().
+		Source(source).
+		Destination(msg.eid).
+		CreationTimestampNow().
+		Lifetime(client.agent.lifetime).
+		HopCountBlock(64).
+		PayloadBlock(msg.payload).
+		Build()
+	if err != nil {
+		log.WithError(err).WithField("aap client", client.conn.RemoteAddr().String()).Info("Creating Bundle erred")
+		return aapMessage{msgType: aapNack}
+	}
+	// Bundles of an AAPAgent might be created within the same millisecond and are distinguished by their sequence.
+	bndl.PrimaryBlock.CreationTimestamp[1] = sequence
+
+	client.sender <- BundleMessage{bndl}
+	return aapMessage{msgType: aapSendConfirm, bundleId: sequence}
+}
+
+func (client *aapClient) getEndpoint() bpv7.EndpointID {
+	client.endpointMutex.Lock()
+	defer client.endpointMutex.Unlock()
+
+	return client.endpoint
+}
+
+func (client *aapClient) Endpoints() []bpv7.EndpointID {
+	if endpoint := client.getEndpoint(); endpoint != (bpv7.EndpointID{}) {
+		return []bpv7.EndpointID{endpoint}
+	}
+	return nil
+}
+
+func (client *aapClient) MessageReceiver() chan Message {
+	return client.receiver
+}
+
+func (client *aapClient) MessageSender() chan Message {
+	return client.sender
+}
@@ -0,0 +1,161 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package agent
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+)
+
+// aapVersion is the supported version of µD3TN's Application Agent Protocol (AAP), stored in the upper four bits of
+// each message's first byte.
+const aapVersion byte = 0x1
+
+// aapMessageType is stored in the lower four bits of each AAP message's first byte.
+type aapMessageType byte
+
+const (
+	// aapAck acknowledges a message without further information.
+	aapAck aapMessageType = 0x0
+	// aapNack rejects a message.
+	aapNack aapMessageType = 0x1
+	// aapRegister is sent by a client to register its agent ID.
+	aapRegister aapMessageType = 0x2
+	// aapSendBundle is sent by a client to send a payload to a destination EID.
+	aapSendBundle aapMessageType = 0x3
+	// aapRecvBundle passes a received bundle's source EID and payload to a client.
+	aapRecvBundle aapMessageType = 0x4
+	// aapSendConfirm answers an aapSendBundle with the bundle's identifier.
+	aapSendConfirm aapMessageType = 0x5
+	// aapCancelBundle is sent by a client to cancel a previously sent bundle.
+	aapCancelBundle aapMessageType = 0x6
+	// aapWelcome is sent to each new client, containing the node ID.
+	aapWelcome aapMessageType = 0x7
+	// aapPing is answered by an aapAck.
+	aapPing aapMessageType = 0x8
+)
+
+// aapMessage is a single AAP message. Depending on its type, only some fields are used.
+type aapMessage struct {
+	msgType aapMessageType
+
+	// eid is the agent ID for aapRegister, the destination for aapSendBundle, the source for aapRecvBundle, and the
+	// node ID for aapWelcome.
+	eid string
+	// payload of an aapSendBundle or aapRecvBundle.
+	payload []byte
+	// bundleId of an aapSendConfirm or aapCancelBundle.
+	bundleId uint64
+}
+
+// hasEid checks if this message type contains an EID.
+func (msg aapMessage) hasEid() bool {
+	switch msg.msgType {
+	case aapRegister, aapSendBundle, aapRecvBundle, aapWelcome:
+		return true
+	default:
+		return false
+	}
+}
+
+// hasPayload checks if this message type contains a payload.
+func (msg aapMessage) hasPayload() bool {
+	return msg.msgType == aapSendBundle || msg.msgType == aapRecvBundle
+}
+
+// hasBundleId checks if this message type contains a bundle identifier.
+func (msg aapMessage) hasBundleId() bool {
+	return msg.msgType == aapSendConfirm || msg.msgType == aapCancelBundle
+}
+
+// write this aapMessage in AAP's binary format, using big-endian integers.
+func (msg aapMessage) write(w io.Writer) error {
+	if _, err := w.Write([]byte{aapVersion<<4 | byte(msg.msgType)}); err != nil {
+		return err
+	}
+
+	if msg.hasEid() {
+		if len(msg.eid) > math.MaxUint16 {
+			return fmt.Errorf("EID of %d bytes exceeds AAP's limit", len(msg.eid))
+		}
+		if err := binary.Write(w, binary.BigEndian, uint16(len(msg.eid))); err != nil {
+			return err
+		}
+		if _, err := io.WriteString(w, msg.eid); err != nil {
+			return err
+		}
+	}
+
+	if msg.hasPayload() {
+		if err := binary.Write(w, binary.BigEndian, uint64(len(msg.payload))); err != nil {
+			return err
+		}
+		if _, err := w.Write(msg.payload); err != nil {
+			return err
+		}
+	}
+
+	if msg.hasBundleId() {
+		if err := binary.Write(w, binary.BigEndian, msg.bundleId); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readAapMessage from an io.Reader. Payloads exceeding maxPayload bytes are rejected before being read.
+func readAapMessage(r io.Reader, maxPayload uint64) (msg aapMessage, err error) {
+	var header [1]byte
+	if _, err = io.ReadFull(r, header[:]); err != nil {
+		return
+	}
+
+	if version := header[0] >> 4; version != aapVersion {
+		err = fmt.Errorf("unsupported AAP version %d", version)
+		return
+	}
+	msg.msgType = aapMessageType(header[0] & 0x0f)
+	if msg.msgType > aapPing {
+		err = fmt.Errorf("unknown AAP message type %d", msg.msgType)
+		return
+	}
+
+	if msg.hasEid() {
+		var eidLen uint16
+		if err = binary.Read(r, binary.BigEndian, &eidLen); err != nil {
+			return
+		}
+
+		eid := make([]byte, eidLen)
+		if _, err = io.ReadFull(r, eid); err != nil {
+			return
+		}
+		msg.eid = string(eid)
+	}
+
+	if msg.hasPayload() {
+		var payloadLen uint64
+		if err = binary.Read(r, binary.BigEndian, &payloadLen); err != nil {
+			return
+		} else if payloadLen > maxPayload {
+			err = fmt.Errorf("payload of %d bytes exceeds the limit of %d bytes", payloadLen, maxPayload)
+			return
+		}
+
+		msg.payload = make([]byte, payloadLen)
+		if _, err = io.ReadFull(r, msg.payload); err != nil {
+			return
+		}
+	}
+
+	if msg.hasBundleId() {
+		err = binary.Read(r, binary.BigEndian, &msg.bundleId)
+	}
+
+	return
+}
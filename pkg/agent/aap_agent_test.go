@@ -0,0 +1,158 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package agent
+
+import (
+	"bytes"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+func TestAapMessage(t *testing.T) {
+	tests := []struct {
+		msg  aapMessage
+		data []byte
+	}{
+		{aapMessage{msgType: aapAck}, []byte{0x10}},
+		{aapMessage{msgType: aapPing}, []byte{0x18}},
+		{aapMessage{msgType: aapRegister, eid: "sink"}, []byte{0x12, 0x00, 0x04, 's', 'i', 'n', 'k'}},
+		{aapMessage{msgType: aapSendBundle, eid: "ipn:1.2", payload: []byte("hi")},
+			[]byte{0x13, 0x00, 0x07, 'i', 'p', 'n', ':', '1', '.', '2', 0, 0, 0, 0, 0, 0, 0, 0x02, 'h', 'i'}},
+		{aapMessage{msgType: aapSendConfirm, bundleId: 0x0102}, []byte{0x15, 0, 0, 0, 0, 0, 0, 0x01, 0x02}},
+		{aapMessage{msgType: aapWelcome, eid: "dtn://a/"}, []byte{0x17, 0x00, 0x08, 'd', 't', 'n', ':', '/', '/', 'a', '/'}},
+	}
+
+	for _, test := range tests {
+		buff := new(bytes.Buffer)
+		if err := test.msg.write(buff); err != nil {
+			t.Fatal(err)
+		} else if !bytes.Equal(buff.Bytes(), test.data) {
+			t.Fatalf("%v serialized to %x, expected %x", test.msg, buff.Bytes(), test.data)
+		}
+
+		if msg, err := readAapMessage(bytes.NewReader(test.data), 1024); err != nil {
+			t.Fatal(err)
+		} else if !reflect.DeepEqual(msg, test.msg) {
+			t.Fatalf("%x parsed to %v, expected %v", test.data, msg, test.msg)
+		}
+	}
+
+	for _, data := range [][]byte{{0x20}, {0x1f}, {0x13, 0x00, 0x01, 'x', 0, 0, 0, 0, 0, 0, 0x08, 0x00}} {
+		if _, err := readAapMessage(bytes.NewReader(data), 1024); err == nil {
+			t.Fatalf("invalid message %x was parsed", data)
+		}
+	}
+}
+
+func TestAAPAgent(t *testing.T) {
+	l, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	aa := NewAAPAgent(l, bpv7.MustNewEndpointID("dtn://node/"), time.Hour)
+	defer func() { aa.MessageReceiver() <- ShutdownMessage{} }()
+
+	conn, err := net.Dial("tcp", l.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	expect := func(msgType aapMessageType) aapMessage {
+		if err := conn.SetReadDeadline(time.Now().Add(5 * time.Second)); err != nil {
+			t.Fatal(err)
+		}
+		msg, err := readAapMessage(conn, 1024)
+		if err != nil {
+			t.Fatal(err)
+		} else if msg.msgType != msgType {
+			t.Fatalf("expected message type %d, got %v", msgType, msg)
+		}
+		return msg
+	}
+	send := func(msg aapMessage) {
+		if err := msg.write(conn); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if msg := expect(aapWelcome); msg.eid != "dtn://node/" {
+		t.Fatalf("welcome contains %q", msg.eid)
+	}
+
+	// Sending requires a registration, which is only possible once.
+	send(aapMessage{msgType: aapSendBundle, eid: "dtn://other/", payload: []byte("early")})
+	expect(aapNack)
+	send(aapMessage{msgType: aapRegister, eid: "sink"})
+	expect(aapAck)
+	send(aapMessage{msgType: aapRegister, eid: "other"})
+	expect(aapNack)
+	send(aapMessage{msgType: aapPing})
+	expect(aapAck)
+
+	send(aapMessage{msgType: aapSendBundle, eid: "dtn://other/inbox", payload: []byte("hello")})
+	confirm := expect(aapSendConfirm)
+
+	select {
+	case msg := <-aa.MessageSender():
+		bndl := msg.(BundleMessage).Bundle
+		if src := bndl.PrimaryBlock.SourceNode.String(); src != "dtn://node/sink" {
+			t.Fatalf("bundle's source is %s", src)
+		} else if dst := bndl.PrimaryBlock.Destination.String(); dst != "dtn://other/inbox" {
+			t.Fatalf("bundle's destination is %s", dst)
+		} else if bndl.PrimaryBlock.CreationTimestamp.SequenceNumber() != confirm.bundleId {
+			t.Fatalf("bundle %v does not match confirmed ID %d", bndl.ID(), confirm.bundleId)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no bundle was sent")
+	}
+
+	if endpoints := aa.Endpoints(); len(endpoints) != 1 || endpoints[0].String() != "dtn://node/sink" {
+		t.Fatalf("unexpected endpoints %v", endpoints)
+	}
+
+	bndl, err := bpv7.Builder().
+		Source("dtn://other/outbox").
+		Destination("dtn://node/sink").
+		CreationTimestampNow().
+		Lifetime("10m").
+		PayloadBlock([]byte("hello sink")).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	aa.MessageReceiver() <- BundleMessage{bndl}
+
+	if msg := expect(aapRecvBundle); msg.eid != "dtn://other/outbox" || string(msg.payload) != "hello sink" {
+		t.Fatalf("unexpected received bundle %v", msg)
+	}
+}
+
+func TestAAPAgentEndpoint(t *testing.T) {
+	tests := []struct {
+		nodeId   string
+		agentId  string
+		endpoint string
+	}{
+		{"dtn://node/", "sink", "dtn://node/sink"},
+		{"dtn://node/", "a/b", "dtn://node/a/b"},
+		{"ipn:23.0", "42", "ipn:23.42"},
+		{"ipn:23.0", "sink", ""},
+	}
+
+	for _, test := range tests {
+		aa := &AAPAgent{nodeId: bpv7.MustNewEndpointID(test.nodeId)}
+		if endpoint, err := aa.endpoint(test.agentId); test.endpoint == "" && err == nil {
+			t.Fatalf("agent ID %q at %s resulted in %v", test.agentId, test.nodeId, endpoint)
+		} else if test.endpoint != "" && (err != nil || endpoint.String() != test.endpoint) {
+			t.Fatalf("agent ID %q at %s resulted in %v, %v", test.agentId, test.nodeId, endpoint, err)
+		}
+	}
+}
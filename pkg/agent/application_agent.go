@@ -4,7 +4,17 @@
 
 package agent
 
-import "github.com/dtn7/dtn7-go/pkg/bpv7"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+// endpointWildcard is the suffix of an EndpointID pattern, e.g., "dtn://foo/bar/*", matching all EndpointIDs below
+// this path prefix, e.g., "dtn://foo/bar/" or "dtn://foo/bar/baz".
+const endpointWildcard = "*"
 
 // ApplicationAgent is an interface to describe application agents, which can both receive and transmit Bundles.
 // Each implementation must provide the following methods to communicate its addresses. Furthermore two channels
@@ -13,7 +23,8 @@ import "github.com/dtn7/dtn7-go/pkg/bpv7"
 // On closing down, an ApplicationAgent MUST close its MessageSender channel and MUST leave the MessageReceiver
 // open. The supervising code MUST close the MessageReceiver of its subjects.
 type ApplicationAgent interface {
-	// Endpoints returns the EndpointIDs that this ApplicationAgent answers to.
+	// Endpoints returns the EndpointIDs that this ApplicationAgent answers to. An EndpointID ending with "/*" is a
+	// pattern, which matches all EndpointIDs starting with the same path prefix.
 	Endpoints() []bpv7.EndpointID
 
 	// MessageReceiver is a channel on which the ApplicationAgent must listen for incoming Messages.
@@ -23,7 +34,25 @@ type ApplicationAgent interface {
 	MessageSender() chan Message
 }
 
-// bagContainsEndpoint checks if some bag/array/slice of endpoints contains another collection of endpoints.
+// isEndpointPattern checks if an EndpointID is a pattern, ending with "/*".
+func isEndpointPattern(eid bpv7.EndpointID) bool {
+	return strings.HasSuffix(eid.String(), "/"+endpointWildcard)
+}
+
+// endpointMatches checks if an EndpointID is matched by another EndpointID, which might be a pattern.
+func endpointMatches(pattern bpv7.EndpointID, eid bpv7.EndpointID) bool {
+	if pattern == eid {
+		return true
+	} else if !isEndpointPattern(pattern) {
+		return false
+	}
+
+	prefix := strings.TrimSuffix(pattern.String(), endpointWildcard)
+	return strings.HasPrefix(eid.String(), prefix)
+}
+
+// bagContainsEndpoint checks if some bag/array/slice of endpoints contains another collection of endpoints. The
+// bag's endpoints might be patterns, as described for ApplicationAgent's Endpoints method.
 func bagContainsEndpoint(bag []bpv7.EndpointID, eids []bpv7.EndpointID) bool {
 	matches := map[bpv7.EndpointID]struct{}{}
 
@@ -36,12 +65,18 @@ func bagContainsEndpoint(bag []bpv7.EndpointID, eids []bpv7.EndpointID) bool {
 			return true
 		}
 	}
-	return false
-}
 
-// bagHasEndpoint checks if some bag/array/slice of endpoints contains another endpoint.
-func bagHasEndpoint(bag []bpv7.EndpointID, eid bpv7.EndpointID) bool {
-	return bagContainsEndpoint(bag, []bpv7.EndpointID{eid})
+	for _, pattern := range bag {
+		if !isEndpointPattern(pattern) {
+			continue
+		}
+		for _, eid := range eids {
+			if endpointMatches(pattern, eid) {
+				return true
+			}
+		}
+	}
+	return false
 }
 
 // AppAgentContainsEndpoint checks if an ApplicationAgent listens to at least one of the requested endpoints.
@@ -53,3 +88,21 @@ func AppAgentContainsEndpoint(app ApplicationAgent, eids []bpv7.EndpointID) bool
 func AppAgentHasEndpoint(app ApplicationAgent, eid bpv7.EndpointID) bool {
 	return AppAgentContainsEndpoint(app, []bpv7.EndpointID{eid})
 }
+
+// serviceEndpoint of a node for a service, e.g., "dtn://foo/bar" for the node "dtn://foo/" and the service "bar". For
+// an ipn node, the service must be a service number, e.g., "ipn:23.42" for the node "ipn:23.0" and the service "42".
+func serviceEndpoint(node bpv7.EndpointID, service string) (bpv7.EndpointID, error) {
+	switch node := node.EndpointType.(type) {
+	case bpv7.DtnEndpoint:
+		return bpv7.NewEndpointID(fmt.Sprintf("dtn://%s/%s", node.NodeName, service))
+
+	case bpv7.IpnEndpoint:
+		if _, err := strconv.ParseUint(service, 10, 64); err != nil {
+			return bpv7.EndpointID{}, fmt.Errorf("service %q is no ipn service number", service)
+		}
+		return bpv7.NewEndpointID(fmt.Sprintf("ipn:%d.%s", node.Node, service))
+
+	default:
+		return bpv7.EndpointID{}, fmt.Errorf("node ID %v has no supported scheme", node)
+	}
+}
@@ -35,3 +35,30 @@ func TestAppAgentContainsEndpoint(t *testing.T) {
 		}
 	}
 }
+
+func TestAppAgentContainsEndpointPattern(t *testing.T) {
+	appAgent := newMockAgent([]bpv7.EndpointID{bpv7.MustNewEndpointID("dtn://foo/app/*"), bpv7.MustNewEndpointID("dtn://bar/")})
+
+	tests := []struct {
+		eid   string
+		valid bool
+	}{
+		{"dtn://foo/app/*", true},
+		{"dtn://foo/app/", true},
+		{"dtn://foo/app/a", true},
+		{"dtn://foo/app/a/b", true},
+		{"dtn://foo/app", false},
+		{"dtn://foo/apps/a", false},
+		{"dtn://foo/", false},
+		{"dtn://foobar/app/a", false},
+		{"dtn://bar/", true},
+		{"dtn://bar/a", false},
+	}
+
+	for _, test := range tests {
+		contains := AppAgentHasEndpoint(appAgent, bpv7.MustNewEndpointID(test.eid))
+		if contains != test.valid {
+			t.Fatalf("erred for %s", test.eid)
+		}
+	}
+}
@@ -0,0 +1,392 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package agent
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/dtn7/cboring"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+// httpMaxBody limits the body of both requests and responses, which are held in memory and sent within one bundle.
+const httpMaxBody = 16 * 1024 * 1024
+
+// httpHopHeaders are only meaningful for a single HTTP connection and are not passed through a gateway.
+var httpHopHeaders = []string{
+	"Connection", "Proxy-Connection", "Keep-Alive", "Proxy-Authenticate", "Proxy-Authorization",
+	"Te", "Trailer", "Transfer-Encoding", "Upgrade", "Content-Length",
+}
+
+// removeHopHeaders from a copy of the header.
+func removeHopHeaders(header http.Header) http.Header {
+	header = header.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+	for _, name := range httpHopHeaders {
+		header.Del(name)
+	}
+	return header
+}
+
+// httpBundle creates a bundle with an httpRequest or httpResponse as its payload. The request's id becomes the
+// sequence number, as multiple bundles might be created within the same millisecond.
+func httpBundle(msg cboring.CborMarshaler, id uint64, src, dst bpv7.EndpointID, lifetime interface{}) (bpv7.Bundle, error) {
+	buff := new(bytes.Buffer)
+	if err := cboring.Marshal(msg, buff); err != nil {
+		return bpv7.Bundle{}, err
+	}
+
+	bndl, err := bpv7.Builder().
+		Source(src).
+		Destination(dst).
+		CreationTimestampNow().
+		Lifetime(lifetime).
+		HopCountBlock(64).
+		PayloadBlock(buff.Bytes()).
+		Build()
+	if err != nil {
+		return bpv7.Bundle{}, err
+	}
+	bndl.PrimaryBlock.CreationTimestamp[1] = id
+	return bndl, nil
+}
+
+// HTTPGatewayAgent is an HTTP proxy, forwarding each HTTP request as a bundle to an HTTPExitAgent on a remote node,
+// which performs the actual request. The response bundle is passed back to the waiting HTTP client. Thus, a
+// disconnected community might access the web through a DTN.
+//
+// The HTTPGatewayAgent is an http.Handler, which must be used as an HTTP proxy by the clients. As each response
+// might take as long as the DTN's round trip, clients should be configured with generous timeouts. HTTPS cannot be
+// tunneled, as the TLS session would span the DTN. However, "https" URLs might be requested in plain HTTP from the
+// proxy, whose exit node establishes the TLS connection.
+type HTTPGatewayAgent struct {
+	endpoint bpv7.EndpointID
+	exit     bpv7.EndpointID
+	lifetime time.Duration
+	timeout  time.Duration
+
+	receiver chan Message
+	sender   chan Message
+
+	pendingMutex sync.Mutex
+	pending      map[uint64]chan httpResponse
+	nextId       uint64
+}
+
+// NewHTTPGatewayAgent creates a new HTTPGatewayAgent at an endpoint, sending requests to the exit endpoint of an
+// HTTPExitAgent. Request bundles are created with the lifetime, while HTTP clients wait up to the timeout.
+func NewHTTPGatewayAgent(endpoint, exit bpv7.EndpointID, lifetime, timeout time.Duration) *HTTPGatewayAgent {
+	gw := &HTTPGatewayAgent{
+		endpoint: endpoint,
+		exit:     exit,
+		lifetime: lifetime,
+		timeout:  timeout,
+
+		receiver: make(chan Message),
+		sender:   make(chan Message),
+
+		pending: make(map[uint64]chan httpResponse),
+	}
+
+	// A random first identifier prevents mixing up late responses with requests after a restart.
+	var idBytes [8]byte
+	if _, err := rand.Read(idBytes[:]); err == nil {
+		gw.nextId = binary.BigEndian.Uint64(idBytes[:]) >> 1
+	}
+
+	go gw.handler()
+
+	return gw
+}
+
+func (gw *HTTPGatewayAgent) log() *log.Entry {
+	return log.WithField("HTTPGatewayAgent", gw.endpoint)
+}
+
+func (gw *HTTPGatewayAgent) handler() {
+	defer close(gw.sender)
+
+	for m := range gw.receiver {
+		switch m := m.(type) {
+		case BundleMessage:
+			gw.handleResponse(m.Bundle)
+
+		case ShutdownMessage:
+			return
+
+		default:
+			gw.log().WithField("message", m).Info("Received unsupported Message")
+		}
+	}
+}
+
+// handleResponse passes a response bundle to its waiting HTTP client.
+func (gw *HTTPGatewayAgent) handleResponse(b bpv7.Bundle) {
+	payload, err := b.PayloadData()
+	if err != nil {
+		gw.log().WithError(err).WithField("bundle", b.ID()).Warn("Reading payload erred")
+		return
+	}
+
+	var resp httpResponse
+	if err := cboring.Unmarshal(&resp, bytes.NewReader(payload)); err != nil {
+		gw.log().WithError(err).WithField("bundle", b.ID()).Info("Received bundle is no HTTP response")
+		return
+	}
+
+	gw.pendingMutex.Lock()
+	respChan, ok := gw.pending[resp.id]
+	gw.pendingMutex.Unlock()
+
+	if !ok {
+		gw.log().WithField("bundle", b.ID()).Info("Received HTTP response without a waiting request")
+		return
+	}
+
+	select {
+	case respChan <- resp:
+	default:
+	}
+}
+
+// register a new request and return its identifier and the channel for its response.
+func (gw *HTTPGatewayAgent) register() (uint64, chan httpResponse) {
+	gw.pendingMutex.Lock()
+	defer gw.pendingMutex.Unlock()
+
+	gw.nextId++
+	respChan := make(chan httpResponse, 1)
+	gw.pending[gw.nextId] = respChan
+	return gw.nextId, respChan
+}
+
+// unregister a finished request.
+func (gw *HTTPGatewayAgent) unregister(id uint64) {
+	gw.pendingMutex.Lock()
+	defer gw.pendingMutex.Unlock()
+
+	delete(gw.pending, id)
+}
+
+func (gw *HTTPGatewayAgent) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodConnect {
+		http.Error(w, "CONNECT tunnels cannot be established over a DTN", http.StatusMethodNotAllowed)
+		return
+	} else if !r.URL.IsAbs() {
+		http.Error(w, "requests must be sent to this gateway as an HTTP proxy", http.StatusBadRequest)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, httpMaxBody+1))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	} else if len(body) > httpMaxBody {
+		http.Error(w, "request body is too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	id, respChan := gw.register()
+	defer gw.unregister(id)
+
+	req := &httpRequest{id: id, method: r.Method, url: r.URL.String(), header: removeHopHeaders(r.Header), body: body}
+	bndl, err := httpBundle(req, id, gw.endpoint, gw.exit, gw.lifetime)
+	if err != nil {
+		gw.log().WithError(err).Warn("Creating request bundle erred")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	gw.log().WithFields(log.Fields{
+		"bundle": bndl.ID(),
+		"url":    req.url,
+	}).Debug("Sending HTTP request bundle")
+	gw.sender <- BundleMessage{bndl}
+
+	select {
+	case resp := <-respChan:
+		if resp.status < 100 || resp.status > 999 {
+			http.Error(w, fmt.Sprintf("exit node responded with invalid status %d", resp.status), http.StatusBadGateway)
+			return
+		}
+
+		for name, values := range removeHopHeaders(resp.header) {
+			w.Header()[name] = values
+		}
+		w.WriteHeader(int(resp.status))
+		_, _ = w.Write(resp.body)
+
+	case <-time.After(gw.timeout):
+		http.Error(w, "no response arrived from the exit node", http.StatusGatewayTimeout)
+
+	case <-r.Context().Done():
+	}
+}
+
+func (gw *HTTPGatewayAgent) Endpoints() []bpv7.EndpointID {
+	return []bpv7.EndpointID{gw.endpoint}
+}
+
+func (gw *HTTPGatewayAgent) MessageReceiver() chan Message {
+	return gw.receiver
+}
+
+func (gw *HTTPGatewayAgent) MessageSender() chan Message {
+	return gw.sender
+}
+
+// HTTPExitAgent performs the HTTP requests received from HTTPGatewayAgents and sends back their responses. Failed
+// requests are answered by a "502 Bad Gateway" response.
+//
+// As the HTTPExitAgent requests any "http" or "https" URL on behalf of every node able to send a bundle to it, its
+// endpoint should only be reachable within a trusted network.
+type HTTPExitAgent struct {
+	endpoint bpv7.EndpointID
+	client   *http.Client
+
+	receiver chan Message
+	sender   chan Message
+
+	// requests are currently performed, and must finish before the sender is closed.
+	requests sync.WaitGroup
+}
+
+// NewHTTPExitAgent creates a new HTTPExitAgent at an endpoint, whose requests are limited by the timeout.
+func NewHTTPExitAgent(endpoint bpv7.EndpointID, timeout time.Duration) *HTTPExitAgent {
+	exit := &HTTPExitAgent{
+		endpoint: endpoint,
+		client:   &http.Client{Timeout: timeout},
+
+		receiver: make(chan Message),
+		sender:   make(chan Message),
+	}
+
+	go exit.handler()
+
+	return exit
+}
+
+func (exit *HTTPExitAgent) log() *log.Entry {
+	return log.WithField("HTTPExitAgent", exit.endpoint)
+}
+
+func (exit *HTTPExitAgent) handler() {
+	defer func() {
+		exit.requests.Wait()
+		close(exit.sender)
+	}()
+
+	for m := range exit.receiver {
+		switch m := m.(type) {
+		case BundleMessage:
+			exit.requests.Add(1)
+			go func(b bpv7.Bundle) {
+				defer exit.requests.Done()
+				exit.handleRequest(b)
+			}(m.Bundle)
+
+		case ShutdownMessage:
+			return
+
+		default:
+			exit.log().WithField("message", m).Info("Received unsupported Message")
+		}
+	}
+}
+
+// handleRequest performs a request bundle's HTTP request and sends back the response bundle.
+func (exit *HTTPExitAgent) handleRequest(b bpv7.Bundle) {
+	payload, err := b.PayloadData()
+	if err != nil {
+		exit.log().WithError(err).WithField("bundle", b.ID()).Warn("Reading payload erred")
+		return
+	}
+
+	var req httpRequest
+	if err := cboring.Unmarshal(&req, bytes.NewReader(payload)); err != nil {
+		exit.log().WithError(err).WithField("bundle", b.ID()).Info("Received bundle is no HTTP request")
+		return
+	}
+
+	resp := exit.fetch(req)
+	exit.log().WithFields(log.Fields{
+		"bundle": b.ID(),
+		"url":    req.url,
+		"status": resp.status,
+	}).Debug("Performed HTTP request")
+
+	bndl, err := httpBundle(&resp, req.id, exit.endpoint, b.PrimaryBlock.SourceNode, b.PrimaryBlock.Lifetime)
+	if err != nil {
+		exit.log().WithError(err).Warn("Creating response bundle erred")
+		return
+	}
+	exit.sender <- BundleMessage{bndl}
+}
+
+// fetch performs an httpRequest. Errors result in a "502 Bad Gateway" httpResponse.
+func (exit *HTTPExitAgent) fetch(req httpRequest) httpResponse {
+	resp, err := exit.do(req)
+	if err != nil {
+		return httpResponse{
+			id:     req.id,
+			status: http.StatusBadGateway,
+			header: http.Header{"Content-Type": {"text/plain; charset=utf-8"}},
+			body:   []byte(err.Error()),
+		}
+	}
+	return resp
+}
+
+func (exit *HTTPExitAgent) do(req httpRequest) (resp httpResponse, err error) {
+	httpReq, err := http.NewRequest(req.method, req.url, bytes.NewReader(req.body))
+	if err != nil {
+		return
+	} else if httpReq.URL.Scheme != "http" && httpReq.URL.Scheme != "https" {
+		err = fmt.Errorf("unsupported URL scheme %q", httpReq.URL.Scheme)
+		return
+	}
+	httpReq.Header = removeHopHeaders(req.header)
+
+	httpResp, err := exit.client.Do(httpReq)
+	if err != nil {
+		return
+	}
+	defer httpResp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(httpResp.Body, httpMaxBody+1))
+	if err != nil {
+		return
+	} else if len(body) > httpMaxBody {
+		err = fmt.Errorf("response body exceeds %d bytes", httpMaxBody)
+		return
+	}
+
+	resp = httpResponse{id: req.id, status: uint64(httpResp.StatusCode), header: removeHopHeaders(httpResp.Header), body: body}
+	return
+}
+
+func (exit *HTTPExitAgent) Endpoints() []bpv7.EndpointID {
+	return []bpv7.EndpointID{exit.endpoint}
+}
+
+func (exit *HTTPExitAgent) MessageReceiver() chan Message {
+	return exit.receiver
+}
+
+func (exit *HTTPExitAgent) MessageSender() chan Message {
+	return exit.sender
+}
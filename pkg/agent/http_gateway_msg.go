@@ -0,0 +1,172 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package agent
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+
+	"github.com/dtn7/cboring"
+)
+
+// httpRequest is the payload of a request bundle, sent from an HTTPGatewayAgent to an HTTPExitAgent.
+type httpRequest struct {
+	// id identifies the request at the HTTPGatewayAgent and is echoed by the httpResponse.
+	id uint64
+
+	method string
+	url    string
+	header http.Header
+	body   []byte
+}
+
+func (req *httpRequest) MarshalCbor(w io.Writer) error {
+	if err := cboring.WriteArrayLength(5, w); err != nil {
+		return err
+	}
+
+	if err := cboring.WriteUInt(req.id, w); err != nil {
+		return err
+	}
+	for _, s := range []string{req.method, req.url} {
+		if err := cboring.WriteTextString(s, w); err != nil {
+			return err
+		}
+	}
+	if err := writeHttpHeader(req.header, w); err != nil {
+		return err
+	}
+	return cboring.WriteByteString(req.body, w)
+}
+
+func (req *httpRequest) UnmarshalCbor(r io.Reader) (err error) {
+	if n, arrErr := cboring.ReadArrayLength(r); arrErr != nil {
+		return arrErr
+	} else if n != 5 {
+		return fmt.Errorf("expected array of 5 elements, got %d", n)
+	}
+
+	if req.id, err = cboring.ReadUInt(r); err != nil {
+		return
+	}
+	if req.method, err = cboring.ReadTextString(r); err != nil {
+		return
+	}
+	if req.url, err = cboring.ReadTextString(r); err != nil {
+		return
+	}
+	if req.header, err = readHttpHeader(r); err != nil {
+		return
+	}
+	req.body, err = cboring.ReadByteString(r)
+	return
+}
+
+// httpResponse is the payload of a response bundle, sent from an HTTPExitAgent back to the HTTPGatewayAgent.
+type httpResponse struct {
+	// id of the answered httpRequest.
+	id uint64
+
+	status uint64
+	header http.Header
+	body   []byte
+}
+
+func (resp *httpResponse) MarshalCbor(w io.Writer) error {
+	if err := cboring.WriteArrayLength(4, w); err != nil {
+		return err
+	}
+
+	for _, n := range []uint64{resp.id, resp.status} {
+		if err := cboring.WriteUInt(n, w); err != nil {
+			return err
+		}
+	}
+	if err := writeHttpHeader(resp.header, w); err != nil {
+		return err
+	}
+	return cboring.WriteByteString(resp.body, w)
+}
+
+func (resp *httpResponse) UnmarshalCbor(r io.Reader) (err error) {
+	if n, arrErr := cboring.ReadArrayLength(r); arrErr != nil {
+		return arrErr
+	} else if n != 4 {
+		return fmt.Errorf("expected array of 4 elements, got %d", n)
+	}
+
+	if resp.id, err = cboring.ReadUInt(r); err != nil {
+		return
+	}
+	if resp.status, err = cboring.ReadUInt(r); err != nil {
+		return
+	}
+	if resp.header, err = readHttpHeader(r); err != nil {
+		return
+	}
+	resp.body, err = cboring.ReadByteString(r)
+	return
+}
+
+// writeHttpHeader as a CBOR map of each header's canonical name to an array of its values, ordered by the names.
+func writeHttpHeader(header http.Header, w io.Writer) error {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	if err := cboring.WriteMapPairLength(uint64(len(names)), w); err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		if err := cboring.WriteTextString(name, w); err != nil {
+			return err
+		}
+
+		values := header[name]
+		if err := cboring.WriteArrayLength(uint64(len(values)), w); err != nil {
+			return err
+		}
+		for _, value := range values {
+			if err := cboring.WriteTextString(value, w); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// readHttpHeader from a CBOR map, written by writeHttpHeader.
+func readHttpHeader(r io.Reader) (http.Header, error) {
+	n, err := cboring.ReadMapPairLength(r)
+	if err != nil {
+		return nil, err
+	}
+
+	header := make(http.Header)
+	for i := uint64(0); i < n; i++ {
+		name, err := cboring.ReadTextString(r)
+		if err != nil {
+			return nil, err
+		}
+
+		m, err := cboring.ReadArrayLength(r)
+		if err != nil {
+			return nil, err
+		}
+		for j := uint64(0); j < m; j++ {
+			value, err := cboring.ReadTextString(r)
+			if err != nil {
+				return nil, err
+			}
+			header.Add(name, value)
+		}
+	}
+	return header, nil
+}
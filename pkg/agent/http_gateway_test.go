@@ -0,0 +1,134 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package agent
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dtn7/cboring"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+func TestHttpMessageCbor(t *testing.T) {
+	tests := []cboring.CborMarshaler{
+		&httpRequest{id: 23, method: "GET", url: "http://example.org/", header: http.Header{}, body: []byte{}},
+		&httpRequest{id: 42, method: "POST", url: "http://example.org/form",
+			header: http.Header{"Accept": {"text/html", "text/plain"}, "Content-Type": {"text/plain"}}, body: []byte("hello")},
+		&httpResponse{id: 42, status: 200, header: http.Header{"Content-Type": {"text/plain"}}, body: []byte("world")},
+	}
+
+	for _, test := range tests {
+		buff := new(bytes.Buffer)
+		if err := cboring.Marshal(test, buff); err != nil {
+			t.Fatal(err)
+		}
+
+		parsed := reflect.New(reflect.TypeOf(test).Elem()).Interface().(cboring.CborMarshaler)
+		if err := cboring.Unmarshal(parsed, buff); err != nil {
+			t.Fatal(err)
+		} else if !reflect.DeepEqual(test, parsed) {
+			t.Fatalf("expected %v, got %v", test, parsed)
+		}
+	}
+}
+
+func TestHTTPGateway(t *testing.T) {
+	origin := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("X-Method", r.Method)
+		w.Header().Set("X-Agent", r.Header.Get("User-Agent"))
+		w.WriteHeader(http.StatusTeapot)
+		_, _ = w.Write(append([]byte("echo "), body...))
+	}))
+	defer origin.Close()
+
+	gw := NewHTTPGatewayAgent(
+		bpv7.MustNewEndpointID("dtn://village/http"), bpv7.MustNewEndpointID("dtn://exit/http"), time.Hour, 5*time.Second)
+	exit := NewHTTPExitAgent(bpv7.MustNewEndpointID("dtn://exit/http"), 5*time.Second)
+	defer func() {
+		gw.MessageReceiver() <- ShutdownMessage{}
+		exit.MessageReceiver() <- ShutdownMessage{}
+	}()
+
+	// Both agents' bundles are passed to each other, as by a DTN.
+	for _, link := range []struct{ from, to ApplicationAgent }{{gw, exit}, {exit, gw}} {
+		go func(from, to ApplicationAgent) {
+			for msg := range from.MessageSender() {
+				to.MessageReceiver() <- msg
+			}
+		}(link.from, link.to)
+	}
+
+	proxy := httptest.NewServer(gw)
+	defer proxy.Close()
+
+	proxyUrl, _ := url.Parse(proxy.URL)
+	client := &http.Client{Transport: &http.Transport{Proxy: http.ProxyURL(proxyUrl)}}
+
+	req, _ := http.NewRequest(http.MethodPost, origin.URL+"/path", strings.NewReader("hello"))
+	req.Header.Set("User-Agent", "dtn-test")
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+
+	if resp.StatusCode != http.StatusTeapot {
+		t.Fatalf("unexpected status %d", resp.StatusCode)
+	} else if resp.Header.Get("X-Method") != http.MethodPost || resp.Header.Get("X-Agent") != "dtn-test" {
+		t.Fatalf("unexpected header %v", resp.Header)
+	} else if string(body) != "echo hello" {
+		t.Fatalf("unexpected body %q", body)
+	}
+
+	// Unreachable servers are reported by the exit node.
+	resp, err = client.Get("http://localhost:1/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusBadGateway {
+		t.Fatalf("unreachable server resulted in status %d", resp.StatusCode)
+	}
+
+	// The gateway only acts as a proxy.
+	resp, err = http.Get(proxy.URL + "/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("direct request resulted in status %d", resp.StatusCode)
+	}
+}
+
+func TestHTTPGatewayTimeout(t *testing.T) {
+	gw := NewHTTPGatewayAgent(
+		bpv7.MustNewEndpointID("dtn://village/http"), bpv7.MustNewEndpointID("dtn://exit/http"), time.Hour, 100*time.Millisecond)
+	defer func() { gw.MessageReceiver() <- ShutdownMessage{} }()
+
+	// The request bundle gets lost.
+	go func() {
+		for range gw.MessageSender() {
+		}
+	}()
+
+	rec := httptest.NewRecorder()
+	gw.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "http://example.org/", nil))
+
+	if rec.Code != http.StatusGatewayTimeout {
+		t.Fatalf("lost request resulted in status %d", rec.Code)
+	}
+}
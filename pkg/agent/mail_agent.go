@@ -0,0 +1,241 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package agent
+
+import (
+	"fmt"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+// mailMaxSize limits an email, which is held in memory and sent within one bundle.
+const mailMaxSize = 16 * 1024 * 1024
+
+// MailAgent is a gateway between email and bundles, reviving DTN's classic email use case.
+//
+// Each email address is represented by a mail-style endpoint below a node's mail prefix, e.g., "alice@example.org"
+// by "dtn://gateway/mail/alice@example.org" for the prefix "dtn://gateway/mail/". Bundles to such an endpoint carry
+// an RFC 5322 message, which is submitted to the configured SMTP relay for this recipient.
+//
+// Optionally, the MailAgent accepts email by SMTP. Each recipient's domain is looked up in the routes, resulting in
+// another node's mail prefix, which might run another MailAgent. The email is wrapped into a bundle for each recipient,
+// sent from the sender's mail-style endpoint. The SMTP listener neither supports authentication nor encryption and
+// should only be reachable by trusted clients.
+type MailAgent struct {
+	prefix   bpv7.EndpointID
+	routes   map[string]bpv7.EndpointID
+	relay    string
+	lifetime time.Duration
+
+	listener net.Listener
+
+	receiver chan Message
+	sender   chan Message
+
+	// sendMail submits an email, smtp.SendMail outside of tests.
+	sendMail func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+
+	// sequence numbers the created bundles, as multiple bundles might be created within the same millisecond.
+	sequence uint64
+
+	// submissions are currently performed, and must finish before the sender is closed.
+	submissions sync.WaitGroup
+}
+
+// NewMailAgent creates a new MailAgent for a mail prefix, e.g., "dtn://gateway/mail/", submitting received emails
+// to the SMTP relay. Emails accepted by the optional listener are sent to the routes' mail prefixes, identified by
+// the recipients' domains; the empty domain is the default route. Created bundles have the given lifetime.
+func NewMailAgent(prefix bpv7.EndpointID, relay string, listener net.Listener, routes map[string]bpv7.EndpointID, lifetime time.Duration) (*MailAgent, error) {
+	if !strings.HasSuffix(prefix.String(), "/") {
+		return nil, fmt.Errorf("mail prefix %v does not end with a slash", prefix)
+	}
+	for domain, route := range routes {
+		if !strings.HasSuffix(route.String(), "/") {
+			return nil, fmt.Errorf("mail prefix %v for domain %q does not end with a slash", route, domain)
+		}
+	}
+
+	ma := &MailAgent{
+		prefix:   prefix,
+		routes:   routes,
+		relay:    relay,
+		lifetime: lifetime,
+
+		listener: listener,
+
+		receiver: make(chan Message),
+		sender:   make(chan Message),
+
+		sendMail: smtp.SendMail,
+	}
+
+	go ma.handler()
+	if listener != nil {
+		go ma.accept()
+	}
+
+	return ma, nil
+}
+
+func (ma *MailAgent) log() *log.Entry {
+	return log.WithField("MailAgent", ma.prefix)
+}
+
+func (ma *MailAgent) handler() {
+	defer func() {
+		ma.submissions.Wait()
+		close(ma.sender)
+	}()
+
+	for m := range ma.receiver {
+		switch m := m.(type) {
+		case BundleMessage:
+			ma.submissions.Add(1)
+			go func(b bpv7.Bundle) {
+				defer ma.submissions.Done()
+				ma.submit(b)
+			}(m.Bundle)
+
+		case ShutdownMessage:
+			if ma.listener != nil {
+				_ = ma.listener.Close()
+			}
+			return
+
+		default:
+			ma.log().WithField("message", m).Info("Received unsupported Message")
+		}
+	}
+}
+
+// address of a mail-style endpoint below a mail prefix.
+func address(prefix, eid bpv7.EndpointID) (string, error) {
+	local := strings.TrimPrefix(eid.String(), prefix.String())
+	if local == eid.String() {
+		return "", fmt.Errorf("endpoint %v is not below the mail prefix %v", eid, prefix)
+	}
+
+	addr, err := mail.ParseAddress(local)
+	if err != nil {
+		return "", fmt.Errorf("endpoint %v contains no email address: %v", eid, err)
+	}
+	return addr.Address, nil
+}
+
+// submit a bundle's email to the SMTP relay.
+func (ma *MailAgent) submit(b bpv7.Bundle) {
+	logger := ma.log().WithField("bundle", b.ID())
+
+	recipient, err := address(ma.prefix, b.PrimaryBlock.Destination)
+	if err != nil {
+		logger.WithError(err).Info("Received bundle for an invalid recipient")
+		return
+	}
+
+	payload, err := b.PayloadData()
+	if err != nil {
+		logger.WithError(err).Warn("Reading payload erred")
+		return
+	}
+
+	msg, err := mail.ReadMessage(strings.NewReader(string(payload)))
+	if err != nil {
+		logger.WithError(err).Info("Received bundle contains no email")
+		return
+	}
+	from, err := mail.ParseAddress(msg.Header.Get("From"))
+	if err != nil {
+		logger.WithError(err).Info("Received email has no valid sender")
+		return
+	}
+
+	if err := ma.sendMail(ma.relay, nil, from.Address, []string{recipient}, payload); err != nil {
+		logger.WithError(err).WithField("recipient", recipient).Warn("Submitting email erred")
+		return
+	}
+	logger.WithFields(log.Fields{
+		"from": from.Address,
+		"to":   recipient,
+	}).Info("Submitted email")
+}
+
+// route an email address to its mail-style endpoint below the route of its domain.
+func (ma *MailAgent) route(addr string) (bpv7.EndpointID, error) {
+	at := strings.LastIndex(addr, "@")
+	if at < 0 {
+		return bpv7.EndpointID{}, fmt.Errorf("email address %q has no domain", addr)
+	}
+
+	prefix, ok := ma.routes[strings.ToLower(addr[at+1:])]
+	if !ok {
+		if prefix, ok = ma.routes[""]; !ok {
+			return bpv7.EndpointID{}, fmt.Errorf("no route for email address %q", addr)
+		}
+	}
+	return bpv7.NewEndpointID(prefix.String() + addr)
+}
+
+// wrap an email, received by SMTP, into a bundle for each recipient.
+func (ma *MailAgent) wrap(from string, recipients []string, data []byte) error {
+	source, err := bpv7.NewEndpointID(ma.prefix.String() + from)
+	if err != nil {
+		source = ma.prefix
+	}
+
+	var bndls []bpv7.Bundle
+	for _, recipient := range recipients {
+		destination, err := ma.route(recipient)
+		if err != nil {
+			return err
+		}
+
+		bndl, err := bpv7.Builder().
+			Source(source).
+			Destination(destination).
+			CreationTimestampNow().
+			Lifetime(ma.lifetime).
+			HopCountBlock(64).
+			PayloadBlock(data).
+			Build()
+		if err != nil {
+			return err
+		}
+		bndl.PrimaryBlock.CreationTimestamp[1] = atomic.AddUint64(&ma.sequence, 1)
+
+		bndls = append(bndls, bndl)
+	}
+
+	for _, bndl := range bndls {
+		ma.log().WithFields(log.Fields{
+			"bundle": bndl.ID(),
+			"to":     bndl.PrimaryBlock.Destination,
+		}).Info("Wrapped email into bundle")
+		ma.sender <- BundleMessage{bndl}
+	}
+	return nil
+}
+
+func (ma *MailAgent) Endpoints() []bpv7.EndpointID {
+	// The pattern is a valid dtn endpoint, as the demux may contain arbitrary characters.
+	pattern, _ := bpv7.NewEndpointID(ma.prefix.String() + endpointWildcard)
+	return []bpv7.EndpointID{pattern}
+}
+
+func (ma *MailAgent) MessageReceiver() chan Message {
+	return ma.receiver
+}
+
+func (ma *MailAgent) MessageSender() chan Message {
+	return ma.sender
+}
@@ -0,0 +1,167 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package agent
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"time"
+)
+
+// smtpTimeout between two commands of an SMTP client.
+const smtpTimeout = 5 * time.Minute
+
+// accept SMTP connections until the listener is closed.
+func (ma *MailAgent) accept() {
+	for {
+		conn, err := ma.listener.Accept()
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				ma.log().WithError(err).Warn("Accepting SMTP connection erred")
+			}
+			return
+		}
+
+		go ma.serveSMTP(conn)
+	}
+}
+
+// smtpPath extracts the address of an SMTP command's path, e.g., "FROM:<alice@example.org> SIZE=23".
+func smtpPath(arg, keyword string) (string, bool) {
+	if len(arg) < len(keyword) || !strings.EqualFold(arg[:len(keyword)], keyword) {
+		return "", false
+	}
+
+	arg = strings.TrimSpace(arg[len(keyword):])
+	start, end := strings.Index(arg, "<"), strings.Index(arg, ">")
+	if start != 0 || end < start {
+		return "", false
+	}
+	return arg[start+1 : end], true
+}
+
+// serveSMTP speaks a minimal subset of SMTP, RFC 5321, to accept emails.
+func (ma *MailAgent) serveSMTP(conn net.Conn) {
+	logger := ma.log().WithField("smtp", conn.RemoteAddr())
+	defer func() { _ = conn.Close() }()
+
+	text := textproto.NewConn(conn)
+
+	var from string
+	var recipients []string
+	reset := func() {
+		from, recipients = "", nil
+	}
+
+	reply := func(code int, msg string) bool {
+		if err := text.PrintfLine("%d %s", code, msg); err != nil {
+			logger.WithError(err).Debug("Writing SMTP reply erred")
+			return false
+		}
+		return true
+	}
+
+	if !reply(220, "dtn7 mail gateway ready") {
+		return
+	}
+
+	for {
+		_ = conn.SetDeadline(time.Now().Add(smtpTimeout))
+
+		line, err := text.ReadLine()
+		if err != nil {
+			if err != io.EOF {
+				logger.WithError(err).Debug("Reading SMTP command erred")
+			}
+			return
+		}
+
+		verb, arg, _ := strings.Cut(line, " ")
+		arg = strings.TrimSpace(arg)
+
+		var ok bool
+		switch strings.ToUpper(verb) {
+		case "HELO", "EHLO":
+			reset()
+			ok = reply(250, "dtn7")
+
+		case "MAIL":
+			addr, valid := smtpPath(arg, "FROM:")
+			if !valid {
+				ok = reply(501, "Syntax: MAIL FROM:<address>")
+			} else {
+				reset()
+				from = addr
+				ok = reply(250, "OK")
+			}
+
+		case "RCPT":
+			addr, valid := smtpPath(arg, "TO:")
+			if !valid {
+				ok = reply(501, "Syntax: RCPT TO:<address>")
+			} else if parsed, err := mail.ParseAddress(addr); err != nil {
+				ok = reply(553, "Invalid recipient")
+			} else if _, err := ma.route(parsed.Address); err != nil {
+				ok = reply(550, "No route to recipient")
+			} else {
+				recipients = append(recipients, parsed.Address)
+				ok = reply(250, "OK")
+			}
+
+		case "DATA":
+			if len(recipients) == 0 {
+				ok = reply(503, "No valid recipients")
+				break
+			}
+			if !reply(354, "End data with <CR><LF>.<CR><LF>") {
+				return
+			}
+
+			data, err := io.ReadAll(io.LimitReader(text.DotReader(), mailMaxSize+1))
+			if err != nil {
+				logger.WithError(err).Debug("Reading SMTP data erred")
+				return
+			} else if len(data) > mailMaxSize {
+				// The remaining data cannot be skipped reliably.
+				_ = reply(552, "Message too large")
+				return
+			}
+
+			// The DotReader normalizes line endings to LF, while RFC 5322 requires CRLF.
+			data = bytes.ReplaceAll(data, []byte("\n"), []byte("\r\n"))
+
+			if err := ma.wrap(from, recipients, data); err != nil {
+				logger.WithError(err).Warn("Wrapping email into bundles erred")
+				ok = reply(451, "Requested action aborted")
+			} else {
+				ok = reply(250, "OK")
+			}
+			reset()
+
+		case "RSET":
+			reset()
+			ok = reply(250, "OK")
+
+		case "NOOP":
+			ok = reply(250, "OK")
+
+		case "QUIT":
+			_ = reply(221, "Bye")
+			return
+
+		default:
+			ok = reply(502, "Command not implemented")
+		}
+
+		if !ok {
+			return
+		}
+	}
+}
@@ -0,0 +1,144 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package agent
+
+import (
+	"net"
+	"net/smtp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+type submittedMail struct {
+	from string
+	to   []string
+	msg  string
+}
+
+func TestMailAgent(t *testing.T) {
+	listener, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	village, err := NewMailAgent(bpv7.MustNewEndpointID("dtn://village/mail/"), "", listener,
+		map[string]bpv7.EndpointID{"": bpv7.MustNewEndpointID("dtn://gateway/mail/")}, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gateway, err := NewMailAgent(bpv7.MustNewEndpointID("dtn://gateway/mail/"), "smtp.example.org:25", nil, nil, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	submissions := make(chan submittedMail, 10)
+	gateway.sendMail = func(addr string, _ smtp.Auth, from string, to []string, msg []byte) error {
+		if addr != "smtp.example.org:25" {
+			t.Errorf("submitted to %s", addr)
+		}
+		submissions <- submittedMail{from, to, string(msg)}
+		return nil
+	}
+
+	defer func() {
+		village.MessageReceiver() <- ShutdownMessage{}
+		gateway.MessageReceiver() <- ShutdownMessage{}
+	}()
+
+	// The village's bundles are passed to the gateway, as by a DTN.
+	go func() {
+		for msg := range village.MessageSender() {
+			if !AppAgentContainsEndpoint(gateway, msg.Recipients()) {
+				t.Errorf("gateway is no recipient of %v", msg.Recipients())
+			}
+			gateway.MessageReceiver() <- msg
+		}
+	}()
+
+	msg := "From: Alice <alice@village.example>\r\nSubject: hello\r\n\r\nHello from the village!\r\n"
+	err = smtp.SendMail(listener.Addr().String(), nil, "alice@village.example",
+		[]string{"bob@example.org", "carol@example.com"}, []byte(msg))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	recipients := make(map[string]bool)
+	for i := 0; i < 2; i++ {
+		select {
+		case sub := <-submissions:
+			if sub.from != "alice@village.example" {
+				t.Fatalf("submitted from %s", sub.from)
+			} else if len(sub.to) != 1 {
+				t.Fatalf("submitted to %v", sub.to)
+			} else if sub.msg != msg {
+				t.Fatalf("submitted message %q", sub.msg)
+			}
+			recipients[sub.to[0]] = true
+
+		case <-time.After(5 * time.Second):
+			t.Fatal("no email was submitted")
+		}
+	}
+	if !recipients["bob@example.org"] || !recipients["carol@example.com"] {
+		t.Fatalf("submitted to %v", recipients)
+	}
+}
+
+func TestMailAgentRoutes(t *testing.T) {
+	listener, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ma, err := NewMailAgent(bpv7.MustNewEndpointID("dtn://gateway/mail/"), "", listener,
+		map[string]bpv7.EndpointID{"village.example": bpv7.MustNewEndpointID("dtn://village/mail/")}, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { ma.MessageReceiver() <- ShutdownMessage{} }()
+
+	bundles := make(chan bpv7.Bundle, 10)
+	go func() {
+		for msg := range ma.MessageSender() {
+			bundles <- msg.(BundleMessage).Bundle
+		}
+	}()
+
+	msg := "From: bob@example.org\r\n\r\nHello village!\r\n"
+
+	// Recipients without a route are rejected.
+	err = smtp.SendMail(listener.Addr().String(), nil, "bob@example.org", []string{"carol@example.com"}, []byte(msg))
+	if err == nil || !strings.HasPrefix(err.Error(), "550") {
+		t.Fatalf("unroutable recipient resulted in %v", err)
+	}
+
+	err = smtp.SendMail(listener.Addr().String(), nil, "bob@example.org", []string{"alice@Village.Example"}, []byte(msg))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case b := <-bundles:
+		if src := b.PrimaryBlock.SourceNode; src != bpv7.MustNewEndpointID("dtn://gateway/mail/bob@example.org") {
+			t.Fatalf("bundle has source %v", src)
+		} else if dst := b.PrimaryBlock.Destination; dst != bpv7.MustNewEndpointID("dtn://village/mail/alice@Village.Example") {
+			t.Fatalf("bundle has destination %v", dst)
+		} else if payload, _ := b.PayloadData(); string(payload) != msg {
+			t.Fatalf("bundle has payload %q", payload)
+		}
+
+	case <-time.After(5 * time.Second):
+		t.Fatal("no bundle was sent")
+	}
+}
+
+func TestMailAgentPrefix(t *testing.T) {
+	if _, err := NewMailAgent(bpv7.MustNewEndpointID("dtn://gateway/mail"), "", nil, nil, time.Hour); err == nil {
+		t.Fatal("prefix without a trailing slash was accepted")
+	}
+}
@@ -5,12 +5,17 @@
 package agent
 
 import (
+	"bytes"
+
 	log "github.com/sirupsen/logrus"
 
 	"github.com/dtn7/dtn7-go/pkg/bpv7"
 )
 
 // PingAgent is a simple ApplicationAgent to "pong" / acknowledge incoming Bundles.
+//
+// If an incoming Bundle's payload starts with "ping", its remainder is echoed after the "pong" of the acknowledgment.
+// Thus, a client might identify its acknowledged pings, e.g., by a sequence number.
 type PingAgent struct {
 	endpoint bpv7.EndpointID
 	receiver chan Message
@@ -65,7 +70,7 @@ func (p *PingAgent) ackBundle(b bpv7.Bundle) {
 		CreationTimestampNow().
 		Lifetime(b.PrimaryBlock.Lifetime).
 		HopCountBlock(hopCount).
-		PayloadBlock([]byte("pong")).
+		PayloadBlock(pongPayload(b)).
 		Build()
 
 	if err != nil {
@@ -76,6 +81,18 @@ func (p *PingAgent) ackBundle(b bpv7.Bundle) {
 	}
 }
 
+// pongPayload creates an acknowledgment's payload, echoing the remainder of a ping's payload after its "ping" prefix.
+func pongPayload(b bpv7.Bundle) []byte {
+	payload := []byte("pong")
+
+	if pb, err := b.PayloadBlock(); err == nil {
+		if data := pb.Value.(*bpv7.PayloadBlock).Data(); bytes.HasPrefix(data, []byte("ping")) {
+			payload = append(payload, data[len("ping"):]...)
+		}
+	}
+	return payload
+}
+
 func (p *PingAgent) Endpoints() []bpv7.EndpointID {
 	return []bpv7.EndpointID{p.endpoint}
 }
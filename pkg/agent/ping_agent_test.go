@@ -5,6 +5,7 @@
 package agent
 
 import (
+	"bytes"
 	"testing"
 	"time"
 
@@ -46,3 +47,32 @@ func TestPingAgent(t *testing.T) {
 
 	ping.receiver <- ShutdownMessage{}
 }
+
+func TestPingAgentEcho(t *testing.T) {
+	tests := []struct {
+		ping []byte
+		pong []byte
+	}{
+		{[]byte(""), []byte("pong")},
+		{[]byte("ping"), []byte("pong")},
+		{[]byte("ping\x00\x17"), []byte("pong\x00\x17")},
+		{[]byte("hello"), []byte("pong")},
+	}
+
+	for _, test := range tests {
+		bndl, err := bpv7.Builder().
+			Source("dtn://bar/").
+			Destination("dtn://foo/ping").
+			CreationTimestampNow().
+			Lifetime("5m").
+			PayloadBlock(test.ping).
+			Build()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if pong := pongPayload(bndl); !bytes.Equal(pong, test.pong) {
+			t.Fatalf("ping %q resulted in %q, expected %q", test.ping, pong, test.pong)
+		}
+	}
+}
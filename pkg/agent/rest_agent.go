@@ -25,6 +25,9 @@ import (
 // retrieved or new bundles can be sent. For sending, bundles can be created by calling the BundleBuilder. Finally,
 // a client should unregister itself.
 //
+// Clients which only transmit bundles might skip the registration and use the one-shot /send endpoint instead. As no
+// endpoint is registered, the bundle's source might be any endpoint of this node or dtn:none.
+//
 // This is all done by HTTP POSTing JSON objects. Their structure is described in `rest_agent_messages.go` by the types
 // with the `Rest` prefix in their names.
 //
@@ -70,6 +73,18 @@ import (
 //	// 4. Unregister the client, POST to /unregister
 //	// -> {"uuid":"75be76e2-23fc-da0e-eeb8-4773f84a9d2f"}
 //	// <- {"error":""}
+//
+//	// 5. Alternatively, create and dispatch a new bundle without any registration, POST to /send
+//	// -> {
+//	//      "arguments": {
+//	//        "destination": "dtn://dst/",
+//	//        "source": "dtn:none",
+//	//        "creation_timestamp_now": 1,
+//	//        "lifetime": "24h",
+//	//        "payload_block": "hello world"
+//	//      }
+//	//    }
+//	// <- {"error":""}
 type RestAgent struct {
 	router *mux.Router
 
@@ -96,6 +111,7 @@ func NewRestAgent(router *mux.Router) (ra *RestAgent) {
 	ra.router.HandleFunc("/unregister", ra.handleUnregister).Methods(http.MethodPost)
 	ra.router.HandleFunc("/fetch", ra.handleFetch).Methods(http.MethodPost)
 	ra.router.HandleFunc("/build", ra.handleBuild).Methods(http.MethodPost)
+	ra.router.HandleFunc("/send", ra.handleSend).Methods(http.MethodPost)
 
 	go ra.handler()
 
@@ -125,7 +141,7 @@ func (ra *RestAgent) handler() {
 func (ra *RestAgent) receiveBundleMessage(msg BundleMessage) {
 	var uuids []string
 	ra.clients.Range(func(k, v interface{}) bool {
-		if bagHasEndpoint(msg.Recipients(), v.(bpv7.EndpointID)) {
+		if bagContainsEndpoint([]bpv7.EndpointID{v.(bpv7.EndpointID)}, msg.Recipients()) {
 			uuids = append(uuids, k.(string))
 		}
 		return false // multiple clients might be registered for some endpoint
@@ -295,6 +311,30 @@ func (ra *RestAgent) handleBuild(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// handleSend creates and dispatches a new bundle for an unregistered client, called by /send.
+func (ra *RestAgent) handleSend(w http.ResponseWriter, r *http.Request) {
+	var (
+		sendRequest  RestSendRequest
+		sendResponse RestSendResponse
+	)
+
+	if jsonErr := json.NewDecoder(r.Body).Decode(&sendRequest); jsonErr != nil {
+		log.WithError(jsonErr).Warn("Failed to parse REST send request")
+		sendResponse.Error = jsonErr.Error()
+	} else if b, bErr := bpv7.BuildFromMap(sendRequest.Args); bErr != nil {
+		log.WithError(bErr).Warn("Anonymous REST client failed to build a bundle")
+		sendResponse.Error = bErr.Error()
+	} else {
+		log.WithField("bundle", b.ID().String()).Info("Anonymous REST client sent bundle")
+		ra.sender <- BundleMessage{Bundle: b}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(sendResponse); err != nil {
+		log.WithError(err).Warn("Failed to write REST send response")
+	}
+}
+
 func (ra *RestAgent) Endpoints() (eids []bpv7.EndpointID) {
 	ra.clients.Range(func(_, v interface{}) bool {
 		eids = append(eids, v.(bpv7.EndpointID))
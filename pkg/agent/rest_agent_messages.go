@@ -48,3 +48,13 @@ type RestBuildRequest struct {
 type RestBuildResponse struct {
 	Error string `json:"error"`
 }
+
+// RestSendRequest describes a JSON to be POSTed to /send.
+type RestSendRequest struct {
+	Args map[string]interface{} `json:"arguments"`
+}
+
+// RestSendResponse describes a JSON response for /send.
+type RestSendResponse struct {
+	Error string `json:"error"`
+}
@@ -209,3 +209,94 @@ func TestRestAgentCycle(t *testing.T) {
 		t.Fatal("endpoint is still registered")
 	}
 }
+
+func TestRestAgentSend(t *testing.T) {
+	log.SetLevel(log.DebugLevel)
+
+	// Start REST server
+	addr := fmt.Sprintf("localhost:%d", randomPort(t))
+
+	r := mux.NewRouter()
+	restRouter := r.PathPrefix("/rest").Subrouter()
+	httpServer := &http.Server{
+		Addr:              addr,
+		Handler:           r,
+		ReadHeaderTimeout: 60 * time.Second,
+	}
+	go func() { _ = httpServer.ListenAndServe() }()
+
+	restAgent := NewRestAgent(restRouter)
+
+	for i := 1; i <= 3; i++ {
+		if isAddrReachable(addr) {
+			break
+		} else if i == 3 {
+			t.Fatal("RestAgent seems to be unreachable")
+		}
+	}
+
+	// Send bundle without any registration
+	sendUrl := fmt.Sprintf("http://%s/rest/send", addr)
+	sendR := strings.NewReader(`{
+		"arguments": {
+			"destination":              "dtn://dst/",
+			"source":                   "dtn://src/",
+			"creation_timestamp_epoch": 1,
+			"lifetime":                 "24h",
+			"bundle_age_block":         42000000,
+			"payload_block":            "hello world"
+		}
+	}`)
+	sendResponse := RestSendResponse{}
+
+	sendBndl, sendBndlErr := bpv7.Builder().
+		Destination("dtn://dst/").
+		Source("dtn://src/").
+		CreationTimestampEpoch().
+		Lifetime("24h").
+		BundleAgeBlock(42000000).
+		PayloadBlock([]byte("hello world")).
+		Build()
+	if sendBndlErr != nil {
+		t.Fatal(sendBndlErr)
+	}
+
+	var (
+		sendResponseBundle    bpv7.Bundle
+		sendResponseWaitGroup sync.WaitGroup
+	)
+	sendResponseWaitGroup.Add(1)
+
+	go func() {
+		defer sendResponseWaitGroup.Done()
+
+		select {
+		case msg := <-restAgent.MessageSender():
+			if bMsg, ok := msg.(BundleMessage); ok {
+				sendResponseBundle = bMsg.Bundle
+			}
+			return
+
+		case <-time.After(250 * time.Millisecond):
+			return
+		}
+	}()
+
+	if resp, err := http.Post(sendUrl, "application/json", sendR); err != nil {
+		t.Fatal(err)
+	} else if err := json.NewDecoder(resp.Body).Decode(&sendResponse); err != nil {
+		t.Fatal(err)
+	}
+
+	sendResponseWaitGroup.Wait()
+
+	if sendResponse.Error != "" {
+		t.Fatal(sendResponse.Error)
+	} else if !reflect.DeepEqual(sendResponseBundle, sendBndl) {
+		t.Fatalf("%v != %v", sendResponseBundle, sendBndl)
+	}
+
+	if eids := restAgent.Endpoints(); len(eids) != 0 {
+		t.Fatalf("anonymous sending registered endpoints: %v", eids)
+	}
+}
@@ -0,0 +1,380 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package agent
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/dtn7/cboring"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+const (
+	// timeSyncSamples is the number of recent samples per peer, from which the one of the shortest delay is used.
+	timeSyncSamples = 8
+
+	// timeSyncThreshold is the minimum offset to discipline the clock, ignoring jitter.
+	timeSyncThreshold = 50 * time.Millisecond
+
+	// timeSyncMinSamples is the minimum number of a peer's samples before its offset is used to discipline the clock.
+	timeSyncMinSamples = 3
+
+	// timeSyncMaxStep is the default maximum of a single adjustment of the clock.
+	timeSyncMaxStep = time.Minute
+)
+
+// timeSyncMessage is the payload of a time synchronization bundle, exchanging timestamps in Unix nanoseconds.
+type timeSyncMessage struct {
+	response bool
+
+	// origin is the request's transmission time, set by the requesting node. A response additionally contains the
+	// request's receive and the response's transmit time of the answering node.
+	origin   uint64
+	receive  uint64
+	transmit uint64
+}
+
+func (msg *timeSyncMessage) MarshalCbor(w io.Writer) error {
+	if err := cboring.WriteArrayLength(4, w); err != nil {
+		return err
+	}
+
+	if err := cboring.WriteBoolean(msg.response, w); err != nil {
+		return err
+	}
+	for _, ts := range []uint64{msg.origin, msg.receive, msg.transmit} {
+		if err := cboring.WriteUInt(ts, w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (msg *timeSyncMessage) UnmarshalCbor(r io.Reader) (err error) {
+	if n, arrErr := cboring.ReadArrayLength(r); arrErr != nil {
+		return arrErr
+	} else if n != 4 {
+		return fmt.Errorf("expected array of 4 elements, got %d", n)
+	}
+
+	if msg.response, err = cboring.ReadBoolean(r); err != nil {
+		return
+	}
+	for _, ts := range []*uint64{&msg.origin, &msg.receive, &msg.transmit} {
+		if *ts, err = cboring.ReadUInt(r); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// timeSyncSample of a peer's clock offset, positive if its clock is ahead, and the round-trip delay.
+type timeSyncSample struct {
+	offset time.Duration
+	delay  time.Duration
+}
+
+// TimeSyncDiscipline configures how a TimeSyncAgent disciplines the local DTN time.
+type TimeSyncDiscipline struct {
+	// Peers are the trusted node IDs whose offsets discipline the clock. Other peers' offsets are only estimated.
+	Peers []bpv7.EndpointID
+
+	// MinPeers is the minimum number of trusted peers with at least timeSyncMinSamples samples, defaulting to one.
+	MinPeers int
+
+	// MaxStep limits each adjustment, defaulting to timeSyncMaxStep. Larger offsets are corrected over several steps.
+	MaxStep time.Duration
+}
+
+// TimeSyncAgent estimates the clock offsets of its peers by exchanging timestamped bundles with their TimeSyncAgents
+// of the same service, like NTP: the offset is the mean of the differences between both nodes' times of the request's
+// and the response's transmission and reception. As the delay between nodes might be asymmetric, each peer's sample of
+// the shortest round-trip delay is used. Only responses to the latest request of a peer are accepted; responses arriving
+// after the interval are ignored.
+//
+// Optionally, the TimeSyncAgent disciplines the local DTN time, bpv7.Now, by the median of the trusted peers' offsets.
+// This allows nodes without another time source to share a common time, but lets the trusted peers shift the local
+// time, limited to a maximum step per adjustment.
+type TimeSyncAgent struct {
+	nodeId     bpv7.EndpointID
+	service    string
+	endpoint   bpv7.EndpointID
+	peers      func() []bpv7.EndpointID
+	interval   time.Duration
+	discipline *TimeSyncDiscipline
+	trusted    map[bpv7.EndpointID]bool
+
+	// samples of each peer by its node ID and the origin timestamps of the outstanding requests, only accessed by the
+	// handler.
+	samples map[bpv7.EndpointID][]timeSyncSample
+	pending map[bpv7.EndpointID]uint64
+
+	receiver chan Message
+	sender   chan Message
+}
+
+// NewTimeSyncAgent for a node's service, e.g., "timesync" or an ipn service number. Each interval, requests are sent
+// to the same service of each peer, as returned by the peers function, e.g., routing.Core.ConnectedPeers. If discipline
+// is not nil, the local DTN time is disciplined by its trusted peers.
+func NewTimeSyncAgent(nodeId bpv7.EndpointID, service string, peers func() []bpv7.EndpointID, interval time.Duration, discipline *TimeSyncDiscipline) (*TimeSyncAgent, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("interval %v is not positive", interval)
+	}
+
+	trusted := make(map[bpv7.EndpointID]bool)
+	if discipline != nil {
+		if len(discipline.Peers) == 0 {
+			return nil, fmt.Errorf("disciplining the clock requires trusted peers")
+		} else if discipline.MinPeers > len(discipline.Peers) {
+			return nil, fmt.Errorf("minimum of %d peers exceeds the %d trusted peers", discipline.MinPeers, len(discipline.Peers))
+		} else if discipline.MaxStep < 0 {
+			return nil, fmt.Errorf("maximum step %v is negative", discipline.MaxStep)
+		}
+
+		for _, peer := range discipline.Peers {
+			trusted[peer.NodeID()] = true
+		}
+	}
+
+	endpoint, err := serviceEndpoint(nodeId, service)
+	if err != nil {
+		return nil, err
+	}
+
+	ts := &TimeSyncAgent{
+		nodeId:     nodeId,
+		service:    service,
+		endpoint:   endpoint,
+		peers:      peers,
+		interval:   interval,
+		discipline: discipline,
+		trusted:    trusted,
+
+		samples: make(map[bpv7.EndpointID][]timeSyncSample),
+		pending: make(map[bpv7.EndpointID]uint64),
+
+		receiver: make(chan Message),
+		sender:   make(chan Message),
+	}
+
+	go ts.handler()
+
+	return ts, nil
+}
+
+func (ts *TimeSyncAgent) log() *log.Entry {
+	return log.WithField("TimeSyncAgent", ts.endpoint)
+}
+
+func (ts *TimeSyncAgent) handler() {
+	defer close(ts.sender)
+
+	ticker := time.NewTicker(ts.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case m := <-ts.receiver:
+			switch m := m.(type) {
+			case BundleMessage:
+				ts.receive(m.Bundle)
+
+			case ShutdownMessage:
+				return
+
+			default:
+				ts.log().WithField("message", m).Info("Received unsupported Message")
+			}
+
+		case <-ticker.C:
+			ts.request()
+		}
+	}
+}
+
+// now as Unix nanoseconds of the local DTN time.
+func (ts *TimeSyncAgent) now() uint64 {
+	return uint64(bpv7.Now().UnixNano())
+}
+
+// request the time of each peer. Requests of the previous interval are no longer outstanding.
+func (ts *TimeSyncAgent) request() {
+	ts.pending = make(map[bpv7.EndpointID]uint64)
+
+	for _, peer := range ts.peers() {
+		dst, err := serviceEndpoint(peer, ts.service)
+		if err != nil {
+			ts.log().WithError(err).WithField("peer", peer).Debug("Peer has no time synchronization endpoint")
+			continue
+		}
+
+		origin := ts.now()
+		ts.pending[peer.NodeID()] = origin
+		ts.send(dst, &timeSyncMessage{origin: origin})
+	}
+}
+
+// receive a request to be answered or a response to be sampled.
+func (ts *TimeSyncAgent) receive(b bpv7.Bundle) {
+	received := ts.now()
+	logger := ts.log().WithField("bundle", b.ID())
+
+	payload, err := b.PayloadData()
+	if err != nil {
+		logger.WithError(err).Warn("Reading payload erred")
+		return
+	}
+
+	var msg timeSyncMessage
+	if err := cboring.Unmarshal(&msg, bytes.NewReader(payload)); err != nil {
+		logger.WithError(err).Info("Received bundle is no time synchronization message")
+		return
+	}
+
+	if !msg.response {
+		ts.send(b.PrimaryBlock.SourceNode, &timeSyncMessage{
+			response: true,
+			origin:   msg.origin,
+			receive:  received,
+			transmit: ts.now(),
+		})
+		return
+	}
+
+	peer := b.PrimaryBlock.SourceNode.NodeID()
+	if origin, ok := ts.pending[peer]; !ok || origin != msg.origin {
+		logger.WithField("peer", peer).Debug("Ignoring response without an outstanding request")
+		return
+	}
+	delete(ts.pending, peer)
+
+	// The timestamps' differences are converted to signed durations before being combined to not overflow.
+	sample := timeSyncSample{
+		offset: (time.Duration(msg.receive-msg.origin) + time.Duration(msg.transmit-received)) / 2,
+		delay:  time.Duration(received-msg.origin) - time.Duration(msg.transmit-msg.receive),
+	}
+	if sample.delay < 0 || sample.delay > ts.interval {
+		logger.WithField("delay", sample.delay).Debug("Ignoring response of an invalid delay")
+		return
+	}
+
+	samples := append(ts.samples[peer], sample)
+	if len(samples) > timeSyncSamples {
+		samples = samples[len(samples)-timeSyncSamples:]
+	}
+	ts.samples[peer] = samples
+
+	logger.WithFields(log.Fields{
+		"peer":   peer,
+		"offset": ts.offset(peer),
+	}).Info("Estimated peer's clock offset")
+
+	if ts.discipline != nil && ts.trusted[peer] {
+		ts.adjust()
+	}
+}
+
+// offset of a peer, estimated by its sample of the shortest delay.
+func (ts *TimeSyncAgent) offset(peer bpv7.EndpointID) time.Duration {
+	best := ts.samples[peer][0]
+	for _, sample := range ts.samples[peer][1:] {
+		if sample.delay < best.delay {
+			best = sample
+		}
+	}
+	return best.offset
+}
+
+// adjust the local DTN time by the median of the trusted peers' offsets, once enough of them were sampled often enough.
+// The adjustment is limited to the maximum step. As all samples and outstanding requests were taken against the
+// previous time, they are discarded afterwards.
+func (ts *TimeSyncAgent) adjust() {
+	offsets := make([]time.Duration, 0, len(ts.samples))
+	for peer, samples := range ts.samples {
+		if ts.trusted[peer] && len(samples) >= timeSyncMinSamples {
+			offsets = append(offsets, ts.offset(peer))
+		}
+	}
+
+	minPeers := ts.discipline.MinPeers
+	if minPeers < 1 {
+		minPeers = 1
+	}
+	if len(offsets) < minPeers {
+		return
+	}
+	sort.Slice(offsets, func(i, j int) bool { return offsets[i] < offsets[j] })
+
+	median := offsets[len(offsets)/2]
+	if len(offsets)%2 == 0 {
+		median = (offsets[len(offsets)/2-1] + median) / 2
+	}
+	if -timeSyncThreshold < median && median < timeSyncThreshold {
+		return
+	}
+
+	adjustment, maxStep := median, ts.discipline.MaxStep
+	if maxStep == 0 {
+		maxStep = timeSyncMaxStep
+	}
+	if adjustment > maxStep {
+		adjustment = maxStep
+	} else if adjustment < -maxStep {
+		adjustment = -maxStep
+	}
+
+	bpv7.SetClockOffset(bpv7.ClockOffset() + adjustment)
+	ts.samples = make(map[bpv7.EndpointID][]timeSyncSample)
+	ts.pending = make(map[bpv7.EndpointID]uint64)
+
+	ts.log().WithFields(log.Fields{
+		"median":       median,
+		"adjustment":   adjustment,
+		"clock offset": bpv7.ClockOffset(),
+	}).Info("Disciplined DTN time")
+}
+
+// send a timeSyncMessage to an endpoint.
+func (ts *TimeSyncAgent) send(dst bpv7.EndpointID, msg *timeSyncMessage) {
+	buff := new(bytes.Buffer)
+	if err := cboring.Marshal(msg, buff); err != nil {
+		ts.log().WithError(err).Warn("Serializing time synchronization message erred")
+		return
+	}
+
+	bndl, err := bpv7.Builder().
+		Source(ts.endpoint).
+		Destination(dst).
+		CreationTimestampNow().
+		Lifetime(ts.interval).
+		HopCountBlock(1).
+		PayloadBlock(buff.Bytes()).
+		Build()
+	if err != nil {
+		ts.log().WithError(err).Warn("Creating bundle erred")
+		return
+	}
+
+	ts.sender <- BundleMessage{bndl}
+}
+
+func (ts *TimeSyncAgent) Endpoints() []bpv7.EndpointID {
+	return []bpv7.EndpointID{ts.endpoint}
+}
+
+func (ts *TimeSyncAgent) MessageReceiver() chan Message {
+	return ts.receiver
+}
+
+func (ts *TimeSyncAgent) MessageSender() chan Message {
+	return ts.sender
+}
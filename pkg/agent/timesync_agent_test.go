@@ -0,0 +1,151 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package agent
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/dtn7/cboring"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+// nextTimeSyncBundle sent by a TimeSyncAgent.
+func nextTimeSyncBundle(t *testing.T, ts *TimeSyncAgent) (bpv7.Bundle, timeSyncMessage) {
+	select {
+	case m := <-ts.MessageSender():
+		b := m.(BundleMessage).Bundle
+
+		payload, err := b.PayloadData()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var msg timeSyncMessage
+		if err := cboring.Unmarshal(&msg, bytes.NewReader(payload)); err != nil {
+			t.Fatal(err)
+		}
+		return b, msg
+
+	case <-time.After(5 * time.Second):
+		t.Fatal("TimeSyncAgent sent no bundle")
+		return bpv7.Bundle{}, timeSyncMessage{}
+	}
+}
+
+func TestTimeSyncAgentExchange(t *testing.T) {
+	peers := func(peer string) func() []bpv7.EndpointID {
+		return func() []bpv7.EndpointID { return []bpv7.EndpointID{bpv7.MustNewEndpointID(peer)} }
+	}
+
+	a, err := NewTimeSyncAgent(bpv7.MustNewEndpointID("dtn://a/"), "timesync", peers("dtn://b/"), 100*time.Millisecond, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { a.MessageReceiver() <- ShutdownMessage{} }()
+
+	b, err := NewTimeSyncAgent(bpv7.MustNewEndpointID("dtn://b/"), "timesync", func() []bpv7.EndpointID { return nil }, time.Hour, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { b.MessageReceiver() <- ShutdownMessage{} }()
+
+	req, reqMsg := nextTimeSyncBundle(t, a)
+	if dst := req.PrimaryBlock.Destination; dst != bpv7.MustNewEndpointID("dtn://b/timesync") {
+		t.Fatalf("request is addressed to %v", dst)
+	} else if reqMsg.response {
+		t.Fatal("request is marked as a response")
+	}
+
+	b.MessageReceiver() <- BundleMessage{req}
+	resp, respMsg := nextTimeSyncBundle(t, b)
+	if dst := resp.PrimaryBlock.Destination; dst != bpv7.MustNewEndpointID("dtn://a/timesync") {
+		t.Fatalf("response is addressed to %v", dst)
+	} else if !respMsg.response || respMsg.origin != reqMsg.origin {
+		t.Fatalf("response %v does not answer request %v", respMsg, reqMsg)
+	} else if respMsg.receive < respMsg.origin || respMsg.transmit < respMsg.receive {
+		t.Fatalf("response %v has invalid timestamps", respMsg)
+	}
+}
+
+// timeSyncResponse of a peer's node to a request, its clock being ahead by the offset.
+func timeSyncResponse(t *testing.T, peer string, dst bpv7.EndpointID, req timeSyncMessage, offset time.Duration) bpv7.Bundle {
+	payload := new(bytes.Buffer)
+	if err := cboring.Marshal(&timeSyncMessage{
+		response: true,
+		origin:   req.origin,
+		receive:  req.origin + uint64(offset),
+		transmit: req.origin + uint64(offset),
+	}, payload); err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := bpv7.Builder().
+		Source(peer).
+		Destination(dst).
+		CreationTimestampNow().
+		Lifetime("5m").
+		PayloadBlock(payload.Bytes()).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp
+}
+
+func TestTimeSyncAgentDiscipline(t *testing.T) {
+	defer bpv7.SetClockOffset(0)
+
+	// The interval is long enough to never tick, while requests and responses are handled by calling the methods.
+	peers := []bpv7.EndpointID{bpv7.MustNewEndpointID("ipn:2.0"), bpv7.MustNewEndpointID("ipn:3.0")}
+	ts, err := NewTimeSyncAgent(bpv7.MustNewEndpointID("ipn:1.0"), "123",
+		func() []bpv7.EndpointID { return peers }, time.Hour,
+		&TimeSyncDiscipline{Peers: peers[:1], MaxStep: 10 * time.Minute})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { ts.MessageReceiver() <- ShutdownMessage{} }()
+
+	for round := 1; round <= timeSyncMinSamples; round++ {
+		go ts.request()
+		reqs := make(map[bpv7.EndpointID]timeSyncMessage)
+		for range peers {
+			b, msg := nextTimeSyncBundle(t, ts)
+			reqs[b.PrimaryBlock.Destination] = msg
+		}
+		req2, req3 := reqs[bpv7.MustNewEndpointID("ipn:2.123")], reqs[bpv7.MustNewEndpointID("ipn:3.123")]
+
+		// Unsolicited responses and responses of untrusted peers must not shift the clock.
+		ts.receive(timeSyncResponse(t, "ipn:2.123", ts.endpoint, timeSyncMessage{origin: req2.origin + 1}, -time.Hour))
+		ts.receive(timeSyncResponse(t, "ipn:4.123", ts.endpoint, req2, -time.Hour))
+		ts.receive(timeSyncResponse(t, "ipn:3.123", ts.endpoint, req3, -time.Hour))
+
+		// The trusted peer's clock is an hour ahead, being corrected by the maximum step after enough samples.
+		ts.receive(timeSyncResponse(t, "ipn:2.123", ts.endpoint, req2, time.Hour))
+
+		offset := bpv7.ClockOffset()
+		if round < timeSyncMinSamples && offset != 0 {
+			t.Fatalf("clock was disciplined to %v after %d samples", offset, round)
+		} else if round == timeSyncMinSamples && (offset < 9*time.Minute || offset > 10*time.Minute) {
+			t.Fatalf("expected clock offset of the maximum step of 10m, got %v", offset)
+		}
+	}
+}
+
+func TestTimeSyncAgentDisciplineInvalid(t *testing.T) {
+	nodeId := bpv7.MustNewEndpointID("dtn://a/")
+	noPeers := func() []bpv7.EndpointID { return nil }
+
+	for _, discipline := range []*TimeSyncDiscipline{
+		{},
+		{Peers: []bpv7.EndpointID{bpv7.MustNewEndpointID("dtn://b/")}, MinPeers: 2},
+		{Peers: []bpv7.EndpointID{bpv7.MustNewEndpointID("dtn://b/")}, MaxStep: -time.Second},
+	} {
+		if _, err := NewTimeSyncAgent(nodeId, "timesync", noPeers, time.Hour, discipline); err == nil {
+			t.Fatalf("discipline %v did not error", discipline)
+		}
+	}
+}
@@ -6,12 +6,14 @@ package agent
 
 import (
 	"net/http"
+	"sync/atomic"
 
 	log "github.com/sirupsen/logrus"
 
 	"github.com/gorilla/websocket"
 
 	"github.com/dtn7/dtn7-go/pkg/bpv7"
+	"github.com/dtn7/dtn7-go/pkg/internal/bufpool"
 )
 
 // WebSocketAgent is a WebSocket based ApplicationAgent. It can be used together with the WebSocketAgentConnector to
@@ -21,6 +23,9 @@ type WebSocketAgent struct {
 	clientMux *MuxAgent
 
 	upgrader websocket.Upgrader
+
+	// maxStreamPayload limits the payload of Bundles streamed by clients, accessed atomically.
+	maxStreamPayload uint64
 }
 
 // NewWebSocketAgent will be started with its handler. The ServeHTTP function must be bound to the HTTP server.
@@ -29,7 +34,9 @@ func NewWebSocketAgent() (wa *WebSocketAgent) {
 		receiver:  make(chan Message),
 		clientMux: NewMuxAgent(),
 
-		upgrader: websocket.Upgrader{},
+		upgrader: websocket.Upgrader{WriteBufferPool: bufpool.WebSocketWriteBuffers},
+
+		maxStreamPayload: defaultMaxStreamPayload,
 	}
 
 	go wa.handler()
@@ -57,12 +64,19 @@ func (w *WebSocketAgent) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	client := newWebAgentClient(conn)
+	client := newWebAgentClient(conn, atomic.LoadUint64(&w.maxStreamPayload))
 	w.clientMux.Register(client)
 
 	client.start()
 }
 
+// SetMaxStreamPayload limits the payload size of Bundles streamed by clients connecting afterwards, defaulting to
+// 1 GiB. Streamed payloads are written to temporary files, see bpv7.SetPayloadStreamThreshold, and a stream exceeding
+// this size is refused.
+func (w *WebSocketAgent) SetMaxStreamPayload(max uint64) {
+	atomic.StoreUint64(&w.maxStreamPayload, max)
+}
+
 // Endpoints of all currently connected clients.
 func (w *WebSocketAgent) Endpoints() []bpv7.EndpointID {
 	return w.clientMux.Endpoints()
@@ -5,6 +5,7 @@
 package agent
 
 import (
+	"errors"
 	"fmt"
 	"net"
 	"sync"
@@ -24,15 +25,27 @@ type webAgentClient struct {
 	receiver chan Message
 	sender   chan Message
 
+	// chunkSize is the client's announced maximum chunk size for streamed Bundles; zero disables streaming.
+	chunkSize uint64
+	// streamAcks passes the client's acknowledgements for an outgoing stream from handleConn to handleReceiver.
+	streamAcks chan *wamStatus
+	// inStream and inPayload hold the state of an incoming stream, whose payload is written to a file of at most
+	// maxPayload bytes; only accessed from handleConn.
+	inStream   *wamBundleStream
+	inPayload  *spoolFile
+	maxPayload uint64
+
 	shutdownOnce sync.Once
 }
 
-func newWebAgentClient(conn *websocket.Conn) *webAgentClient {
+func newWebAgentClient(conn *websocket.Conn, maxPayload uint64) *webAgentClient {
 	return &webAgentClient{
-		conn:     conn,
-		endpoint: bpv7.EndpointID{},
-		receiver: make(chan Message),
-		sender:   make(chan Message),
+		conn:       conn,
+		maxPayload: maxPayload,
+		endpoint:   bpv7.EndpointID{},
+		receiver:   make(chan Message),
+		sender:     make(chan Message),
+		streamAcks: make(chan *wamStatus, 1),
 	}
 }
 
@@ -62,7 +75,9 @@ func (client *webAgentClient) handleReceiver() {
 			return
 
 		case BundleMessage:
-			if err := client.writeMessage(newBundleMessage(msg.Bundle)); err != nil {
+			if err := client.writeBundle(msg.Bundle); errors.Is(err, errStreamRefused) {
+				logger.WithError(err).Warn("Client refused streamed Bundle")
+			} else if err != nil {
 				logger.WithError(err).Warn("Sending outgoing Bundle erred")
 				return
 			} else {
@@ -85,6 +100,8 @@ func (client *webAgentClient) handleReceiver() {
 
 func (client *webAgentClient) handleConn() {
 	defer client.shutdown()
+	defer close(client.streamAcks)
+	defer client.discardIncomingStream()
 
 	var logger = log.WithField("web agent client", client.conn.RemoteAddr().String())
 
@@ -124,6 +141,24 @@ func (client *webAgentClient) handleConn() {
 					Request: msg.request,
 				}
 
+			case *wamStreamConfig:
+				logger.WithField("chunk size", msg.chunkSize).Debug("Received stream configuration")
+				client.setChunkSize(msg.chunkSize)
+
+			case *wamStatus:
+				select {
+				case client.streamAcks <- msg:
+				default:
+					logger.WithField("message", msg).Debug("Received unexpected status message")
+				}
+
+			case *wamBundleStream:
+				logger.WithField("bundle", msg.b).Debug("Received start of a streamed Bundle")
+				err = client.acknowledgeIncoming(client.handleIncomingStream(msg))
+
+			case *wamBundleChunk:
+				err = client.handleIncomingChunk(msg)
+
 			default:
 				logger.WithField("message", msg).Info("Received unknown / unsupported message")
 			}
@@ -161,6 +196,67 @@ func (client *webAgentClient) handleIncomingRegister(m *wamRegister) error {
 	}
 }
 
+// handleIncomingStream starts an incoming stream, superseding a previous one, and creates the file for its payload.
+func (client *webAgentClient) handleIncomingStream(m *wamBundleStream) error {
+	client.discardIncomingStream()
+
+	inPayload, err := newSpoolFile(client.maxPayload)
+	if err != nil {
+		return err
+	}
+
+	client.inStream, client.inPayload = m, inPayload
+	return nil
+}
+
+// handleIncomingChunk appends a chunk to the incoming stream's file. A chunk exceeding the maximum payload size is
+// refused and discards the whole stream, which is not resumed.
+func (client *webAgentClient) handleIncomingChunk(m *wamBundleChunk) error {
+	if client.inStream == nil {
+		return client.acknowledgeIncoming(fmt.Errorf("received a Bundle chunk without a preceding stream"))
+	}
+
+	if _, err := client.inPayload.Write(m.data); err != nil {
+		client.discardIncomingStream()
+		return client.writeMessage(newStatusMessage(err))
+	}
+	if err := client.acknowledgeIncoming(nil); err != nil {
+		return err
+	}
+
+	if !m.final {
+		return nil
+	}
+
+	b, inPayload := client.inStream.b, client.inPayload
+	client.inStream, client.inPayload = nil, nil
+
+	pb, err := b.PayloadBlock()
+	if err != nil {
+		inPayload.discard()
+		return err
+	}
+	if pb.Value, err = inPayload.payloadBlock(); err != nil {
+		return err
+	}
+
+	log.WithFields(log.Fields{
+		"web agent client": client.conn.RemoteAddr().String(),
+		"bundle":           b,
+		"payload size":     inPayload.size,
+	}).Info("Received streamed Bundle")
+	client.sender <- BundleMessage{b}
+	return nil
+}
+
+// discardIncomingStream removes an unfinished incoming stream's file.
+func (client *webAgentClient) discardIncomingStream() {
+	if client.inPayload != nil {
+		client.inPayload.discard()
+	}
+	client.inStream, client.inPayload = nil, nil
+}
+
 func (client *webAgentClient) acknowledgeIncoming(err error) error {
 	if writeErr := client.writeMessage(newStatusMessage(err)); writeErr != nil {
 		return writeErr
@@ -169,6 +265,42 @@ func (client *webAgentClient) acknowledgeIncoming(err error) error {
 	}
 }
 
+// writeBundle sends a Bundle to the client, either as a single message or, if the client supports it and the
+// payload exceeds its chunk size, as a stream.
+func (client *webAgentClient) writeBundle(b bpv7.Bundle) error {
+	chunkSize := client.getChunkSize()
+	if chunkSize == 0 {
+		return client.writeMessage(newBundleMessage(b))
+	}
+
+	if payloadLen, err := b.PayloadLen(); err != nil || payloadLen <= chunkSize {
+		return client.writeMessage(newBundleMessage(b))
+	}
+
+	payload, err := b.PayloadReader()
+	if err != nil {
+		return err
+	}
+
+	return writeBundleStream(b, payload, chunkSize, client.writeMessage, func() error {
+		return awaitStatus(client.streamAcks)
+	})
+}
+
+func (client *webAgentClient) setChunkSize(chunkSize uint64) {
+	client.Lock()
+	defer client.Unlock()
+
+	client.chunkSize = chunkSize
+}
+
+func (client *webAgentClient) getChunkSize() uint64 {
+	client.Lock()
+	defer client.Unlock()
+
+	return client.chunkSize
+}
+
 func (client *webAgentClient) writeMessage(msg webAgentMessage) error {
 	client.Lock()
 	defer client.Unlock()
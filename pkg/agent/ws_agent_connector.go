@@ -6,22 +6,41 @@ package agent
 
 import (
 	"fmt"
+	"io"
+	"sync"
 	"time"
 
 	"github.com/gorilla/websocket"
 
 	"github.com/dtn7/dtn7-go/pkg/bpv7"
+	"github.com/dtn7/dtn7-go/pkg/internal/bufpool"
 )
 
+// incomingBundle is a received Bundle. For streamed Bundles, the payload Reader is set and the Bundle's payload
+// block is empty.
+type incomingBundle struct {
+	bundle  bpv7.Bundle
+	payload io.ReadCloser
+}
+
 // WebSocketAgentConnector is the client side version of the WebSocketAgent.
+//
+// Bundles with large payloads might be exchanged as streams, split into multiple chunks. Those are written by
+// WriteBundleStream and read by ReadBundleStream. Each chunk is acknowledged by its receiver, resulting in a flow
+// control where no side must hold more than one chunk at a time.
 type WebSocketAgentConnector struct {
-	conn *websocket.Conn
+	conn       *websocket.Conn
+	writeMutex sync.Mutex
+
+	chunkSize   uint64
+	streamMutex sync.Mutex
 
 	msgOutChan chan webAgentMessage
 	msgOutErr  chan error
 
-	msgInBundleChan  chan bpv7.Bundle
+	msgInBundleChan  chan incomingBundle
 	msgInSyscallChan chan []byte
+	msgInStatusChan  chan *wamStatus
 
 	closeSyn chan struct{}
 	closeAck chan struct{}
@@ -29,36 +48,79 @@ type WebSocketAgentConnector struct {
 
 // NewWebSocketAgentConnector creates a new WebSocketAgentConnector connection to a WebSocketAgent.
 func NewWebSocketAgentConnector(apiUrl, endpointId string) (wac *WebSocketAgentConnector, err error) {
+	if wac, err = dialWebSocketAgentConnector(apiUrl); err != nil {
+		return
+	}
+
+	if err = wac.registerEndpoint(endpointId); err != nil {
+		_ = wac.conn.Close()
+		wac = nil
+		return
+	}
+
+	if err = wac.start(); err != nil {
+		wac = nil
+	}
+	return
+}
+
+// NewAnonymousWebSocketAgentConnector creates a new WebSocketAgentConnector connection to a WebSocketAgent without
+// registering an endpoint. Thus, it can only be used for sending Bundles, whose source might be any endpoint of the
+// server's node or dtn:none. No Bundles will be received.
+func NewAnonymousWebSocketAgentConnector(apiUrl string) (wac *WebSocketAgentConnector, err error) {
+	if wac, err = dialWebSocketAgentConnector(apiUrl); err != nil {
+		return
+	}
+
+	if err = wac.start(); err != nil {
+		wac = nil
+	}
+	return
+}
+
+// dialWebSocketAgentConnector connects to a WebSocketAgent, but neither registers nor starts the handlers.
+func dialWebSocketAgentConnector(apiUrl string) (wac *WebSocketAgentConnector, err error) {
 	var conn *websocket.Conn
-	if conn, _, err = websocket.DefaultDialer.Dial(apiUrl, nil); err != nil {
+	if conn, _, err = bufpool.WebSocketDialer().Dial(apiUrl, nil); err != nil {
 		return
 	}
 
 	wac = &WebSocketAgentConnector{
 		conn: conn,
 
+		chunkSize: defaultStreamChunkSize,
+
 		msgOutChan: make(chan webAgentMessage),
 		msgOutErr:  make(chan error),
 
-		msgInBundleChan:  make(chan bpv7.Bundle),
+		msgInBundleChan:  make(chan incomingBundle),
 		msgInSyscallChan: make(chan []byte),
+		msgInStatusChan:  make(chan *wamStatus, 1),
 
 		closeSyn: make(chan struct{}),
 		closeAck: make(chan struct{}),
 	}
 
-	if err = wac.registerEndpoint(endpointId); err != nil {
-		wac = nil
-		return
+	return
+}
+
+// start announces the stream configuration and starts the handlers.
+func (wac *WebSocketAgentConnector) start() error {
+	if err := wac.writeMessage(newStreamConfigMessage(wac.chunkSize)); err != nil {
+		_ = wac.conn.Close()
+		return err
 	}
 
 	go wac.handler()
 	go wac.handleReader()
 
-	return
+	return nil
 }
 
 func (wac *WebSocketAgentConnector) writeMessage(msg webAgentMessage) error {
+	wac.writeMutex.Lock()
+	defer wac.writeMutex.Unlock()
+
 	wc, wcErr := wac.conn.NextWriter(websocket.BinaryMessage)
 	if wcErr != nil {
 		return wcErr
@@ -103,6 +165,15 @@ func (wac *WebSocketAgentConnector) registerEndpoint(endpointId string) error {
 func (wac *WebSocketAgentConnector) handleReader() {
 	defer close(wac.msgInBundleChan)
 	defer close(wac.msgInSyscallChan)
+	defer close(wac.msgInStatusChan)
+
+	// stream is the writing end of the currently received streamed Bundle's payload.
+	var stream *io.PipeWriter
+	defer func() {
+		if stream != nil {
+			_ = stream.CloseWithError(fmt.Errorf("connection was closed"))
+		}
+	}()
 
 	for {
 		if msg, err := wac.readMessage(); err != nil {
@@ -110,11 +181,44 @@ func (wac *WebSocketAgentConnector) handleReader() {
 		} else {
 			switch msg := msg.(type) {
 			case *wamBundle:
-				wac.msgInBundleChan <- msg.b
+				wac.msgInBundleChan <- incomingBundle{bundle: msg.b}
 
 			case *wamSyscallResponse:
 				wac.msgInSyscallChan <- msg.response
 
+			case *wamStatus:
+				select {
+				case wac.msgInStatusChan <- msg:
+				default:
+				}
+
+			case *wamBundleStream:
+				if stream != nil {
+					_ = stream.CloseWithError(fmt.Errorf("stream was superseded by another one"))
+				}
+
+				var payload *io.PipeReader
+				payload, stream = io.Pipe()
+				wac.msgInBundleChan <- incomingBundle{bundle: msg.b, payload: payload}
+
+				if err := wac.writeMessage(newStatusMessage(nil)); err != nil {
+					return
+				}
+
+			case *wamBundleChunk:
+				var chunkErr error
+				if stream == nil {
+					chunkErr = fmt.Errorf("received a Bundle chunk without a preceding stream")
+				} else if _, chunkErr = stream.Write(msg.data); chunkErr != nil || msg.final {
+					_ = stream.Close()
+					stream = nil
+				}
+
+				// Acknowledging a chunk after it was consumed by the reader results in the flow control.
+				if err := wac.writeMessage(newStatusMessage(chunkErr)); err != nil {
+					return
+				}
+
 			default:
 				// oof
 			}
@@ -155,7 +259,38 @@ func (wac *WebSocketAgentConnector) WriteBundle(b bpv7.Bundle) (err error) {
 	return <-wac.msgOutErr
 }
 
-// ReadBundle returns the next incoming Bundle. This method blocks.
+// WriteBundleStream sends a Bundle to a server, streaming its payload from the given Reader instead of the Bundle's
+// payload block. This method blocks until the whole payload was acknowledged by the server.
+func (wac *WebSocketAgentConnector) WriteBundleStream(b bpv7.Bundle, payload io.Reader) error {
+	wac.streamMutex.Lock()
+	defer wac.streamMutex.Unlock()
+
+	return writeBundleStream(b, payload, wac.chunkSize, wac.writeMessage, func() error {
+		return awaitStatus(wac.msgInStatusChan)
+	})
+}
+
+// SetStreamChunkSize configures the maximum size of a streamed chunk, both for outgoing streams and announced to the
+// server for incoming ones. A chunk size of zero disables incoming streams, resulting in whole Bundles.
+func (wac *WebSocketAgentConnector) SetStreamChunkSize(chunkSize uint64) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+
+	wac.streamMutex.Lock()
+	defer wac.streamMutex.Unlock()
+
+	wac.msgOutChan <- newStreamConfigMessage(chunkSize)
+	if err = <-wac.msgOutErr; err == nil && chunkSize > 0 {
+		wac.chunkSize = chunkSize
+	}
+	return
+}
+
+// ReadBundle returns the next incoming Bundle. A streamed Bundle's payload will be read completely into its payload
+// block. This method blocks.
 func (wac *WebSocketAgentConnector) ReadBundle() (b bpv7.Bundle, err error) {
 	defer func() {
 		if r := recover(); r != nil {
@@ -163,10 +298,38 @@ func (wac *WebSocketAgentConnector) ReadBundle() (b bpv7.Bundle, err error) {
 		}
 	}()
 
-	if bIn, ok := <-wac.msgInBundleChan; ok {
-		b = bIn
+	if bIn, ok := <-wac.msgInBundleChan; !ok {
+		err = fmt.Errorf("channel was closed")
+	} else if bIn.payload == nil {
+		b = bIn.bundle
+	} else if data, readErr := io.ReadAll(bIn.payload); readErr != nil {
+		err = readErr
 	} else {
+		b = bIn.bundle
+		err = setPayload(&b, data)
+	}
+	return
+}
+
+// ReadBundleStream returns the next incoming Bundle with an empty payload block and a Reader for its payload.
+//
+// The payload must be read until EOF or closed before any further message can be received. Closing it early refuses
+// the remaining stream. This method blocks.
+func (wac *WebSocketAgentConnector) ReadBundleStream() (b bpv7.Bundle, payload io.ReadCloser, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+
+	if bIn, ok := <-wac.msgInBundleChan; !ok {
 		err = fmt.Errorf("channel was closed")
+	} else if bIn.payload != nil {
+		b, payload = bIn.bundle, bIn.payload
+	} else if bStripped, r, splitErr := splitPayload(bIn.bundle); splitErr != nil {
+		err = splitErr
+	} else {
+		b, payload = bStripped, io.NopCloser(r)
 	}
 	return
 }
@@ -6,7 +6,9 @@ package agent
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"reflect"
@@ -14,14 +16,15 @@ import (
 	"time"
 
 	log "github.com/sirupsen/logrus"
-)
 
-func TestWebAgentConnector(t *testing.T) {
-	log.SetLevel(log.DebugLevel)
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
 
+// startWebSocketAgent serves a new WebSocketAgent on a random port and returns it together with its URL.
+func startWebSocketAgent(t *testing.T) (ws *WebSocketAgent, wsUrl string) {
 	// Start WebSocketAgent server
 	addr := fmt.Sprintf("localhost:%d", randomPort(t))
-	ws := NewWebSocketAgent()
+	ws = NewWebSocketAgent()
 
 	httpMux := http.NewServeMux()
 	httpMux.HandleFunc("/ws", ws.ServeHTTP)
@@ -43,13 +46,32 @@ func TestWebAgentConnector(t *testing.T) {
 		}
 	}
 
-	// Attach Connector
 	u := url.URL{
 		Scheme: "ws",
 		Host:   addr,
 		Path:   "/ws",
 	}
-	wac, wacErr := NewWebSocketAgentConnector(u.String(), "dtn://foobar/23")
+	return ws, u.String()
+}
+
+// stopWebSocketAgent after all its connectors were closed.
+func stopWebSocketAgent(ws *WebSocketAgent) {
+	// Let the WebSocketAgent act on the closed connection
+	time.Sleep(250 * time.Millisecond)
+
+	ws.MessageReceiver() <- ShutdownMessage{}
+
+	// Let the WebSocketAgent shut itself down
+	time.Sleep(250 * time.Millisecond)
+}
+
+func TestWebAgentConnector(t *testing.T) {
+	log.SetLevel(log.DebugLevel)
+
+	ws, wsUrl := startWebSocketAgent(t)
+
+	// Attach Connector
+	wac, wacErr := NewWebSocketAgentConnector(wsUrl, "dtn://foobar/23")
 	if wacErr != nil {
 		t.Fatal(wacErr)
 	}
@@ -121,12 +143,178 @@ func TestWebAgentConnector(t *testing.T) {
 	}
 
 	wac.Close()
+	stopWebSocketAgent(ws)
+}
 
-	// Let the WebSocketAgent act on the closed connection
-	time.Sleep(250 * time.Millisecond)
+func TestWebAgentConnectorStream(t *testing.T) {
+	log.SetLevel(log.DebugLevel)
 
-	ws.MessageReceiver() <- ShutdownMessage{}
+	ws, wsUrl := startWebSocketAgent(t)
 
-	// Let the WebSocketAgent shut itself down
-	time.Sleep(250 * time.Millisecond)
+	// Attach Connector
+	wac, wacErr := NewWebSocketAgentConnector(wsUrl, "dtn://foobar/23")
+	if wacErr != nil {
+		t.Fatal(wacErr)
+	}
+
+	if err := wac.SetStreamChunkSize(100); err != nil {
+		t.Fatal(err)
+	}
+
+	// Stream a Bundle from the client to the server
+	payload := bytes.Repeat([]byte("0123456789"), 1337)
+	b := createBundle("dtn://foobar/23", "dtn://server/", t)
+	if err := wac.WriteBundleStream(b, bytes.NewReader(payload)); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case msg := <-ws.MessageSender():
+		if bMsg, ok := msg.(BundleMessage); !ok {
+			t.Fatalf("expected BundleMessage, got %T", msg)
+		} else if pb, err := bMsg.Bundle.PayloadBlock(); err != nil {
+			t.Fatal(err)
+		} else if spb, ok := pb.Value.(*bpv7.StreamPayloadBlock); !ok {
+			t.Fatalf("expected payload spooled to a file, got %T", pb.Value)
+		} else if data, err := spb.Data(); err != nil {
+			t.Fatal(err)
+		} else if !bytes.Equal(data, payload) {
+			t.Fatalf("payload differs, received %d bytes instead of %d", len(data), len(payload))
+		} else if err := spb.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+	case <-time.After(time.Second):
+		t.Fatal("WebSocketAgent did not received message; time out")
+	}
+
+	// Stream a Bundle from the server to the client, to be read as a stream
+	b, err := bpv7.Builder().
+		Source("dtn://server/").
+		Destination("dtn://foobar/23").
+		CreationTimestampNow().
+		Lifetime("10m").
+		PayloadBlock(payload).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() { ws.MessageReceiver() <- BundleMessage{b} }()
+
+	if b2, r, err := wac.ReadBundleStream(); err != nil {
+		t.Fatal(err)
+	} else if b2.PrimaryBlock.Destination != b.PrimaryBlock.Destination {
+		t.Fatalf("expected destination %v, got %v", b.PrimaryBlock.Destination, b2.PrimaryBlock.Destination)
+	} else if data, err := io.ReadAll(r); err != nil {
+		t.Fatal(err)
+	} else if !bytes.Equal(data, payload) {
+		t.Fatalf("payload differs, received %d bytes instead of %d", len(data), len(payload))
+	}
+
+	// Stream a Bundle from the server to the client, to be read as a whole Bundle
+	go func() { ws.MessageReceiver() <- BundleMessage{b} }()
+
+	if b2, err := wac.ReadBundle(); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(b, b2) {
+		t.Fatalf("expected %v, got %v", b, b2)
+	}
+
+	// Stream a Bundle whose payload can only be read once from the server to the client
+	pb, err := b.PayloadBlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pb.Value = bpv7.NewStreamPayloadBlock(struct{ io.Reader }{bytes.NewReader(payload)}, uint64(len(payload)))
+	go func() { ws.MessageReceiver() <- BundleMessage{b} }()
+
+	if _, r, err := wac.ReadBundleStream(); err != nil {
+		t.Fatal(err)
+	} else if data, err := io.ReadAll(r); err != nil {
+		t.Fatal(err)
+	} else if !bytes.Equal(data, payload) {
+		t.Fatalf("payload differs, received %d bytes instead of %d", len(data), len(payload))
+	}
+
+	wac.Close()
+
+	// Streams of clients connecting after limiting the payload size are refused, without closing the connection
+	ws.SetMaxStreamPayload(1000)
+
+	wac, wacErr = NewWebSocketAgentConnector(wsUrl, "dtn://foobar/42")
+	if wacErr != nil {
+		t.Fatal(wacErr)
+	}
+	if err := wac.SetStreamChunkSize(100); err != nil {
+		t.Fatal(err)
+	}
+
+	b = createBundle("dtn://foobar/42", "dtn://server/", t)
+	if err := wac.WriteBundleStream(b, bytes.NewReader(payload)); !errors.Is(err, errStreamRefused) {
+		t.Fatalf("expected refused stream, got %v", err)
+	} else if err := wac.WriteBundleStream(b, bytes.NewReader(payload[:1000])); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case msg := <-ws.MessageSender():
+		b2 := msg.(BundleMessage).Bundle
+		if data, err := b2.PayloadData(); err != nil {
+			t.Fatal(err)
+		} else if len(data) != 1000 {
+			t.Fatalf("received payload of %d bytes instead of 1000", len(data))
+		}
+
+	case <-time.After(time.Second):
+		t.Fatal("WebSocketAgent did not received message; time out")
+	}
+
+	wac.Close()
+	stopWebSocketAgent(ws)
+}
+
+func TestWebAgentConnectorAnonymous(t *testing.T) {
+	log.SetLevel(log.DebugLevel)
+
+	ws, wsUrl := startWebSocketAgent(t)
+
+	// Attach an anonymous Connector
+	wac, wacErr := NewAnonymousWebSocketAgentConnector(wsUrl)
+	if wacErr != nil {
+		t.Fatal(wacErr)
+	}
+
+	b, bErr := bpv7.Builder().
+		BundleCtrlFlags(bpv7.MustNotFragmented).
+		Source(bpv7.DtnNone()).
+		Destination("dtn://server/").
+		CreationTimestampNow().
+		Lifetime("24h").
+		PayloadBlock([]byte("hello world")).
+		Build()
+	if bErr != nil {
+		t.Fatal(bErr)
+	}
+	if err := wac.WriteBundle(b); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case msg := <-ws.MessageSender():
+		if bMsg, ok := msg.(BundleMessage); !ok {
+			t.Fatalf("expected BundleMessage, got %T", msg)
+		} else if !reflect.DeepEqual(b, bMsg.Bundle) {
+			t.Fatalf("expected %v, got %v", b, bMsg.Bundle)
+		}
+
+	case <-time.After(500 * time.Millisecond):
+		t.Fatal("WebSocketAgent did not received message; time out")
+	}
+
+	if eids := ws.Endpoints(); len(eids) != 0 {
+		t.Fatalf("anonymous connector registered endpoints: %v", eids)
+	}
+
+	wac.Close()
+	stopWebSocketAgent(ws)
 }
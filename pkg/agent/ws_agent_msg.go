@@ -30,6 +30,9 @@ const (
 	wamBundleCode          uint64 = 2
 	wamSyscallRequestCode  uint64 = 3
 	wamSyscallResponseCode uint64 = 4
+	wamStreamConfigCode    uint64 = 5
+	wamBundleStreamCode    uint64 = 6
+	wamBundleChunkCode     uint64 = 7
 )
 
 var wamMapping = map[interface{}]reflect.Type{
@@ -38,6 +41,9 @@ var wamMapping = map[interface{}]reflect.Type{
 	wamBundleCode:          reflect.TypeOf(wamBundle{}),
 	wamSyscallRequestCode:  reflect.TypeOf(wamSyscallRequest{}),
 	wamSyscallResponseCode: reflect.TypeOf(wamSyscallResponse{}),
+	wamStreamConfigCode:    reflect.TypeOf(wamStreamConfig{}),
+	wamBundleStreamCode:    reflect.TypeOf(wamBundleStream{}),
+	wamBundleChunkCode:     reflect.TypeOf(wamBundleChunk{}),
 }
 
 // marshalCbor writes a webAgentMessage wrapped with its type code as CBOR.
@@ -163,3 +163,115 @@ func (wsr *wamSyscallResponse) UnmarshalCbor(r io.Reader) error {
 
 	return nil
 }
+
+// wamStreamConfig is a webAgentMessage sent from a client to the server to announce its support for streamed Bundles.
+// Afterwards, Bundles with a payload larger than chunkSize will be sent as a wamBundleStream and multiple
+// wamBundleChunks. A chunkSize of zero disables streaming again.
+type wamStreamConfig struct {
+	chunkSize uint64
+}
+
+// newStreamConfigMessage creates a new wamStreamConfig webAgentMessage.
+func newStreamConfigMessage(chunkSize uint64) *wamStreamConfig {
+	return &wamStreamConfig{chunkSize}
+}
+
+func (_ *wamStreamConfig) typeCode() uint64 {
+	return wamStreamConfigCode
+}
+
+func (wsc *wamStreamConfig) MarshalCbor(w io.Writer) error {
+	return cboring.WriteUInt(wsc.chunkSize, w)
+}
+
+func (wsc *wamStreamConfig) UnmarshalCbor(r io.Reader) (err error) {
+	wsc.chunkSize, err = cboring.ReadUInt(r)
+	return
+}
+
+// wamBundleStream is a webAgentMessage which starts the transmission of a streamed Bundle. The Bundle's payload block
+// is left empty and its data follows in wamBundleChunks. Each of those messages must be acknowledged by a wamStatus
+// before the next one is sent.
+// This message might be initiated from both a client or a server.
+type wamBundleStream struct {
+	b bpv7.Bundle
+}
+
+// newBundleStreamMessage creates a new wamBundleStream webAgentMessage. The passed Bundle's payload will be dropped.
+func newBundleStreamMessage(b bpv7.Bundle) (*wamBundleStream, error) {
+	if bStripped, err := stripPayload(b); err != nil {
+		return nil, err
+	} else {
+		return &wamBundleStream{bStripped}, nil
+	}
+}
+
+func (_ *wamBundleStream) typeCode() uint64 {
+	return wamBundleStreamCode
+}
+
+func (wbs *wamBundleStream) MarshalCbor(w io.Writer) error {
+	return cboring.Marshal(&wbs.b, w)
+}
+
+func (wbs *wamBundleStream) UnmarshalCbor(r io.Reader) error {
+	return cboring.Unmarshal(&wbs.b, r)
+}
+
+// wamBundleChunk is a webAgentMessage for a part of a streamed Bundle's payload, following a wamBundleStream.
+// The last chunk of a stream has its final flag set.
+// This message might be initiated from both a client or a server.
+type wamBundleChunk struct {
+	data  []byte
+	final bool
+}
+
+// newBundleChunkMessage creates a new wamBundleChunk webAgentMessage.
+func newBundleChunkMessage(data []byte, final bool) *wamBundleChunk {
+	return &wamBundleChunk{
+		data:  data,
+		final: final,
+	}
+}
+
+func (_ *wamBundleChunk) typeCode() uint64 {
+	return wamBundleChunkCode
+}
+
+func (wbc *wamBundleChunk) MarshalCbor(w io.Writer) error {
+	if err := cboring.WriteArrayLength(2, w); err != nil {
+		return err
+	}
+
+	if err := cboring.WriteByteString(wbc.data, w); err != nil {
+		return err
+	}
+
+	if err := cboring.WriteBoolean(wbc.final, w); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (wbc *wamBundleChunk) UnmarshalCbor(r io.Reader) error {
+	if n, err := cboring.ReadArrayLength(r); err != nil {
+		return err
+	} else if n != 2 {
+		return fmt.Errorf("expected CBOR array of 2 elments, not %d", n)
+	}
+
+	if data, err := cboring.ReadByteString(r); err != nil {
+		return err
+	} else {
+		wbc.data = data
+	}
+
+	if final, err := cboring.ReadBoolean(r); err != nil {
+		return err
+	} else {
+		wbc.final = final
+	}
+
+	return nil
+}
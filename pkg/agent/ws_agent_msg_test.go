@@ -34,6 +34,15 @@ func TestWebsocketAgentMessageEnDecode(t *testing.T) {
 		newBundleMessage(b),
 		newSyscallRequestMessage("test"),
 		newSyscallResponseMessage("foobar", []byte{0x23, 0x42, 0xAC, 0xAB}),
+		newStreamConfigMessage(1024),
+		newBundleChunkMessage([]byte("hello"), false),
+		newBundleChunkMessage([]byte(" world"), true),
+	}
+
+	if msg, err := newBundleStreamMessage(b); err != nil {
+		t.Fatal(err)
+	} else {
+		msgs = append(msgs, msg)
 	}
 
 	for _, msg := range msgs {
@@ -0,0 +1,165 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package agent
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+// defaultStreamChunkSize is the maximum payload size of a wamBundleChunk, as announced by a WebSocketAgentConnector
+// unless configured otherwise.
+const defaultStreamChunkSize uint64 = 64 * 1024
+
+// defaultMaxStreamPayload is the maximum payload size of a Bundle streamed to a WebSocketAgent unless configured
+// otherwise.
+const defaultMaxStreamPayload uint64 = 1 << 30
+
+// errStreamRefused is wrapped by errors resulting from a peer's negative acknowledgement of a streamed message.
+var errStreamRefused = errors.New("stream was refused by peer")
+
+// stripPayload returns a copy of the Bundle with an empty payload block. The original payload is not read.
+func stripPayload(b bpv7.Bundle) (bpv7.Bundle, error) {
+	canonicals := make([]bpv7.CanonicalBlock, len(b.CanonicalBlocks))
+	copy(canonicals, b.CanonicalBlocks)
+	b.CanonicalBlocks = canonicals
+
+	pb, err := b.PayloadBlock()
+	if err != nil {
+		return b, err
+	}
+	pb.Value = bpv7.NewPayloadBlock([]byte{})
+
+	return b, nil
+}
+
+// splitPayload returns a copy of the Bundle with an empty payload block and a reader for the original payload. A
+// bpv7.StreamPayloadBlock's payload is streamed from its file instead of being read into memory.
+func splitPayload(b bpv7.Bundle) (bpv7.Bundle, io.Reader, error) {
+	payload, err := b.PayloadReader()
+	if err != nil {
+		return b, nil, err
+	}
+
+	bStripped, err := stripPayload(b)
+	return bStripped, payload, err
+}
+
+// spoolFile is a temporary file to which an incoming streamed payload is written, limited to a maximum size.
+type spoolFile struct {
+	file *os.File
+	size uint64
+	max  uint64
+}
+
+// newSpoolFile creates a temporary file within bpv7.PayloadStreamConfig's directory or os.TempDir.
+func newSpoolFile(max uint64) (*spoolFile, error) {
+	_, dir := bpv7.PayloadStreamConfig()
+	f, err := os.CreateTemp(dir, "payload-")
+	if err != nil {
+		return nil, err
+	}
+	return &spoolFile{file: f, max: max}, nil
+}
+
+// Write appends data, failing if the maximum size would be exceeded.
+func (sf *spoolFile) Write(data []byte) (int, error) {
+	if sf.size+uint64(len(data)) > sf.max {
+		return 0, fmt.Errorf("streamed payload exceeds the maximum size of %d bytes", sf.max)
+	}
+
+	n, err := sf.file.Write(data)
+	sf.size += uint64(n)
+	return n, err
+}
+
+// payloadBlock hands the file over to a bpv7.StreamPayloadBlock.
+func (sf *spoolFile) payloadBlock() (*bpv7.StreamPayloadBlock, error) {
+	if _, err := sf.file.Seek(0, io.SeekStart); err != nil {
+		sf.discard()
+		return nil, err
+	}
+	return bpv7.NewFileStreamPayloadBlock(sf.file, sf.size), nil
+}
+
+// discard closes and removes the file.
+func (sf *spoolFile) discard() {
+	_ = sf.file.Close()
+	_ = os.Remove(sf.file.Name())
+}
+
+// setPayload replaces the Bundle's payload.
+func setPayload(b *bpv7.Bundle, data []byte) error {
+	pb, err := b.PayloadBlock()
+	if err != nil {
+		return err
+	}
+
+	pb.Value = bpv7.NewPayloadBlock(data)
+	return nil
+}
+
+// statusError converts a received wamStatus into an error, wrapping errStreamRefused.
+func statusError(status *wamStatus) error {
+	if status.errorMsg == "" {
+		return nil
+	}
+	return fmt.Errorf("%w: %s", errStreamRefused, status.errorMsg)
+}
+
+// awaitStatus waits for the next wamStatus on the channel and converts it into an error. The channel must be closed
+// when the underlying connection is closed.
+func awaitStatus(statusChan chan *wamStatus) error {
+	if status, ok := <-statusChan; !ok {
+		return fmt.Errorf("connection was closed while awaiting an acknowledgement")
+	} else {
+		return statusError(status)
+	}
+}
+
+// writeBundleStream transmits a Bundle as a wamBundleStream, followed by its payload in wamBundleChunks of at most
+// chunkSize bytes. After each message, awaitAck must return the peer's acknowledgement. Thus, the peer controls the
+// flow and no more than one chunk is buffered at a time.
+func writeBundleStream(b bpv7.Bundle, payload io.Reader, chunkSize uint64,
+	write func(webAgentMessage) error, awaitAck func() error) error {
+	if chunkSize == 0 {
+		return fmt.Errorf("chunk size must be greater than zero")
+	}
+
+	streamMsg, err := newBundleStreamMessage(b)
+	if err != nil {
+		return err
+	}
+
+	if err := write(streamMsg); err != nil {
+		return err
+	}
+	if err := awaitAck(); err != nil {
+		return err
+	}
+
+	buff := make([]byte, chunkSize)
+	for final := false; !final; {
+		n, readErr := io.ReadFull(payload, buff)
+		if readErr == io.EOF || readErr == io.ErrUnexpectedEOF {
+			final = true
+		} else if readErr != nil {
+			return readErr
+		}
+
+		if err := write(newBundleChunkMessage(buff[:n], final)); err != nil {
+			return err
+		}
+		if err := awaitAck(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
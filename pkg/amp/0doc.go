@@ -0,0 +1,16 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+// Package amp implements a subset of the Asynchronous Management Protocol (AMP) of the DTN management architecture.
+//
+// An AMP Agent runs on a managed node and is controlled by AMP managers through management bundles. Each bundle's
+// payload is a MessageGroup of Messages: an Agent announces itself by RegisterAgent, a manager sends PerformControl
+// to execute controls, and the Agent answers with a ReportSet. Objects are identified by ARIs, which are exchanged in
+// their text form, e.g., "ari:/dtn7/EDD.bundles_received". Supported are externally defined data (EDD), which are
+// read-only values like counters, and variables (VAR), which might also be set.
+//
+// The Agent implements the controls "ari:/amp_agent/CTRL.gen_rpts", reporting the values of its parameters' ARIs, and
+// "ari:/amp_agent/CTRL.set_var", setting the variable of its first parameter to the value of the second one. Other
+// parts of the AMM, e.g., rules, tables, and the compressed binary ARI encoding, are not supported.
+package amp
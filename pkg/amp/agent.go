@@ -0,0 +1,289 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package amp
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/dtn7/cboring"
+
+	"github.com/dtn7/dtn7-go/pkg/agent"
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+var (
+	// GenReports is the control reporting the values of its parameters' ARIs to the requesting manager.
+	GenReports = ARI{Namespace: "amp_agent", Type: CTRL, Name: "gen_rpts"}
+
+	// SetVariable is the control setting the variable of its first parameter's ARI to its second parameter's value.
+	SetVariable = ARI{Namespace: "amp_agent", Type: CTRL, Name: "set_var"}
+)
+
+// Variable of an Agent, which can be read and set.
+type Variable struct {
+	Get func() Value
+	Set func(Value) error
+}
+
+// scheduledControl is a PerformControl, whose start delay has passed.
+type scheduledControl struct {
+	manager bpv7.EndpointID
+	pc      *PerformControl
+}
+
+// Agent is the AMP agent role, an ApplicationAgent executing the controls of its managers and reporting its EDDs
+// and variables. Management bundles of other endpoints are ignored.
+type Agent struct {
+	endpoint bpv7.EndpointID
+	managers []bpv7.EndpointID
+	lifetime time.Duration
+
+	edds map[ARI]func() Value
+	vars map[ARI]Variable
+
+	receiver chan agent.Message
+	sender   chan agent.Message
+
+	// scheduled PerformControls are passed back to the handler, unless it was closed by the done channel.
+	scheduled chan scheduledControl
+	done      chan struct{}
+}
+
+// NewAgent for an endpoint, registering itself at its managers. Its EDDs and variables are identified by their ARIs,
+// whose object types must be EDD or VAR respectively. Sent bundles have the given lifetime.
+func NewAgent(endpoint bpv7.EndpointID, managers []bpv7.EndpointID, lifetime time.Duration,
+	edds map[ARI]func() Value, vars map[ARI]Variable) (*Agent, error) {
+	for ari := range edds {
+		if ari.Type != EDD {
+			return nil, fmt.Errorf("EDD %v has object type %s", ari, ari.Type)
+		}
+	}
+	for ari := range vars {
+		if ari.Type != VAR {
+			return nil, fmt.Errorf("variable %v has object type %s", ari, ari.Type)
+		}
+	}
+
+	a := &Agent{
+		endpoint: endpoint,
+		managers: managers,
+		lifetime: lifetime,
+
+		edds: edds,
+		vars: vars,
+
+		receiver: make(chan agent.Message),
+		sender:   make(chan agent.Message),
+
+		scheduled: make(chan scheduledControl),
+		done:      make(chan struct{}),
+	}
+
+	go a.handler()
+
+	return a, nil
+}
+
+func (a *Agent) log() *log.Entry {
+	return log.WithField("AMPAgent", a.endpoint)
+}
+
+func (a *Agent) handler() {
+	defer close(a.sender)
+	defer close(a.done)
+
+	for _, manager := range a.managers {
+		a.send(manager, &RegisterAgent{Agent: a.endpoint})
+	}
+
+	for {
+		select {
+		case m := <-a.receiver:
+			switch m := m.(type) {
+			case agent.BundleMessage:
+				a.receive(m.Bundle)
+
+			case agent.ShutdownMessage:
+				return
+
+			default:
+				a.log().WithField("message", m).Info("Received unsupported Message")
+			}
+
+		case sc := <-a.scheduled:
+			a.perform(sc.manager, sc.pc)
+		}
+	}
+}
+
+// isManager checks if an endpoint is one of this Agent's managers.
+func (a *Agent) isManager(eid bpv7.EndpointID) bool {
+	for _, manager := range a.managers {
+		if manager == eid {
+			return true
+		}
+	}
+	return false
+}
+
+// receive a management bundle and perform or schedule its PerformControls.
+func (a *Agent) receive(b bpv7.Bundle) {
+	logger := a.log().WithField("bundle", b.ID())
+
+	manager := b.PrimaryBlock.SourceNode
+	if !a.isManager(manager) {
+		logger.WithField("source", manager).Info("Ignoring management bundle of an unknown manager")
+		return
+	}
+
+	payload, err := b.PayloadData()
+	if err != nil {
+		logger.WithError(err).Warn("Reading payload erred")
+		return
+	}
+
+	var mg MessageGroup
+	if err := cboring.Unmarshal(&mg, bytes.NewReader(payload)); err != nil {
+		logger.WithError(err).Info("Received bundle contains no message group")
+		return
+	}
+
+	for _, msg := range mg.Messages {
+		pc, ok := msg.(*PerformControl)
+		if !ok {
+			logger.WithField("type", msg.Type()).Info("Ignoring message of unsupported type")
+			continue
+		}
+
+		if pc.Start == 0 {
+			a.perform(manager, pc)
+			continue
+		}
+
+		time.AfterFunc(time.Duration(pc.Start)*time.Second, func() {
+			select {
+			case a.scheduled <- scheduledControl{manager, pc}:
+			case <-a.done:
+			}
+		})
+	}
+}
+
+// perform a PerformControl's Controls, sending the generated Reports to the manager.
+func (a *Agent) perform(manager bpv7.EndpointID, pc *PerformControl) {
+	var reports []Report
+
+	for _, ctrl := range pc.Controls {
+		logger := a.log().WithFields(log.Fields{
+			"manager": manager,
+			"control": ctrl.ARI,
+		})
+
+		switch ctrl.ARI {
+		case GenReports:
+			for _, param := range ctrl.Parameters {
+				ari, err := paramARI(param)
+				if err != nil {
+					logger.WithError(err).Info("Control has an invalid parameter")
+					continue
+				}
+				reports = append(reports, a.report(ari))
+			}
+
+		case SetVariable:
+			if err := a.setVariable(ctrl.Parameters); err != nil {
+				logger.WithError(err).Info("Setting variable erred")
+			} else {
+				logger.WithField("value", ctrl.Parameters[1]).Info("Manager set variable")
+			}
+
+		default:
+			logger.Info("Control is not supported")
+		}
+	}
+
+	if len(reports) > 0 {
+		a.send(manager, &ReportSet{Reports: reports})
+	}
+}
+
+// paramARI of a control's parameter, an ARI's text form.
+func paramARI(param Value) (ARI, error) {
+	s, ok := param.(string)
+	if !ok {
+		return ARI{}, fmt.Errorf("parameter %v is no ARI", param)
+	}
+	return ParseARI(s)
+}
+
+// report an EDD's or a variable's current Value. Unknown objects are reported without a Value.
+func (a *Agent) report(ari ARI) Report {
+	rpt := Report{ARI: ari, Timestamp: bpv7.DtnTimeNow()}
+
+	if edd, ok := a.edds[ari]; ok {
+		rpt.Value = edd()
+	} else if v, ok := a.vars[ari]; ok {
+		rpt.Value = v.Get()
+	}
+	return rpt
+}
+
+// setVariable of the set_var control's parameters, the variable's ARI and the new value.
+func (a *Agent) setVariable(params []Value) error {
+	if len(params) != 2 {
+		return fmt.Errorf("expected 2 parameters, got %d", len(params))
+	}
+
+	ari, err := paramARI(params[0])
+	if err != nil {
+		return err
+	}
+
+	v, ok := a.vars[ari]
+	if !ok {
+		return fmt.Errorf("unknown variable %v", ari)
+	}
+	return v.Set(params[1])
+}
+
+// send a Message within its own MessageGroup to a manager.
+func (a *Agent) send(manager bpv7.EndpointID, msg Message) {
+	buff := new(bytes.Buffer)
+	if err := cboring.Marshal(NewMessageGroup(msg), buff); err != nil {
+		a.log().WithError(err).Warn("Creating message group erred")
+		return
+	}
+
+	bndl, err := bpv7.Builder().
+		Source(a.endpoint).
+		Destination(manager).
+		CreationTimestampNow().
+		Lifetime(a.lifetime).
+		HopCountBlock(64).
+		PayloadBlock(buff.Bytes()).
+		Build()
+	if err != nil {
+		a.log().WithError(err).Warn("Creating bundle erred")
+		return
+	}
+
+	a.sender <- agent.BundleMessage{Bundle: bndl}
+}
+
+func (a *Agent) Endpoints() []bpv7.EndpointID {
+	return []bpv7.EndpointID{a.endpoint}
+}
+
+func (a *Agent) MessageReceiver() chan agent.Message {
+	return a.receiver
+}
+
+func (a *Agent) MessageSender() chan agent.Message {
+	return a.sender
+}
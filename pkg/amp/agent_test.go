@@ -0,0 +1,148 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package amp
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dtn7/cboring"
+
+	"github.com/dtn7/dtn7-go/pkg/agent"
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+// managementBundle from a manager to the Agent, carrying a MessageGroup of msgs.
+func managementBundle(t *testing.T, manager string, msgs ...Message) agent.BundleMessage {
+	buff := new(bytes.Buffer)
+	if err := cboring.Marshal(NewMessageGroup(msgs...), buff); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := bpv7.Builder().
+		Source(manager).
+		Destination("dtn://node/amp").
+		CreationTimestampNow().
+		Lifetime("10m").
+		PayloadBlock(buff.Bytes()).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return agent.BundleMessage{Bundle: b}
+}
+
+// receiveMessage of the Agent's next bundle, which must be addressed to the manager.
+func receiveMessage(t *testing.T, a *Agent, manager string) Message {
+	select {
+	case msg := <-a.MessageSender():
+		b := msg.(agent.BundleMessage).Bundle
+		if dst := b.PrimaryBlock.Destination; dst != bpv7.MustNewEndpointID(manager) {
+			t.Fatalf("bundle is addressed to %v", dst)
+		}
+
+		payload, err := b.PayloadData()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var mg MessageGroup
+		if err := cboring.Unmarshal(&mg, bytes.NewReader(payload)); err != nil {
+			t.Fatal(err)
+		} else if len(mg.Messages) != 1 {
+			t.Fatalf("message group has %d messages", len(mg.Messages))
+		}
+		return mg.Messages[0]
+
+	case <-time.After(5 * time.Second):
+		t.Fatal("agent sent no bundle")
+		return nil
+	}
+}
+
+func TestAgent(t *testing.T) {
+	level := "info"
+	a, err := NewAgent(bpv7.MustNewEndpointID("dtn://node/amp"), []bpv7.EndpointID{bpv7.MustNewEndpointID("dtn://mgr/amp")},
+		time.Hour,
+		map[ARI]func() Value{
+			MustParseARI("ari:/dtn7/EDD.bundles_received"): func() Value { return uint64(23) },
+		},
+		map[ARI]Variable{
+			MustParseARI("ari:/dtn7/VAR.log_level"): {
+				Get: func() Value { return level },
+				Set: func(v Value) error {
+					s, ok := v.(string)
+					if !ok {
+						return fmt.Errorf("no string")
+					}
+					level = s
+					return nil
+				},
+			},
+		})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { a.MessageReceiver() <- agent.ShutdownMessage{} }()
+
+	if ra, ok := receiveMessage(t, a, "dtn://mgr/amp").(*RegisterAgent); !ok || ra.Agent != bpv7.MustNewEndpointID("dtn://node/amp") {
+		t.Fatalf("agent did not register, %v", ra)
+	}
+
+	a.MessageReceiver() <- managementBundle(t, "dtn://mgr/amp", &PerformControl{Controls: []Control{
+		{ARI: SetVariable, Parameters: []Value{"ari:/dtn7/VAR.log_level", "debug"}},
+	}})
+
+	// Controls of unknown managers are ignored.
+	a.MessageReceiver() <- managementBundle(t, "dtn://evil/amp", &PerformControl{Controls: []Control{
+		{ARI: SetVariable, Parameters: []Value{"ari:/dtn7/VAR.log_level", "trace"}},
+	}})
+
+	a.MessageReceiver() <- managementBundle(t, "dtn://mgr/amp", &PerformControl{Controls: []Control{
+		{ARI: GenReports, Parameters: []Value{
+			"ari:/dtn7/EDD.bundles_received", "ari:/dtn7/VAR.log_level", "ari:/dtn7/EDD.unknown"}},
+	}})
+
+	rs, ok := receiveMessage(t, a, "dtn://mgr/amp").(*ReportSet)
+	if !ok || len(rs.Reports) != 3 {
+		t.Fatalf("agent sent no report set of three reports, %v", rs)
+	}
+	for i, v := range []Value{uint64(23), "debug", nil} {
+		if rs.Reports[i].Value != v {
+			t.Fatalf("report %d has value %v, expected %v", i, rs.Reports[i].Value, v)
+		}
+	}
+}
+
+func TestAgentDelayedControl(t *testing.T) {
+	a, err := NewAgent(bpv7.MustNewEndpointID("dtn://node/amp"), []bpv7.EndpointID{bpv7.MustNewEndpointID("dtn://mgr/amp")},
+		time.Hour, map[ARI]func() Value{MustParseARI("ari:/dtn7/EDD.uptime"): func() Value { return uint64(1) }}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { a.MessageReceiver() <- agent.ShutdownMessage{} }()
+
+	_ = receiveMessage(t, a, "dtn://mgr/amp")
+
+	start := time.Now()
+	a.MessageReceiver() <- managementBundle(t, "dtn://mgr/amp", &PerformControl{Start: 1, Controls: []Control{
+		{ARI: GenReports, Parameters: []Value{"ari:/dtn7/EDD.uptime"}},
+	}})
+
+	if _, ok := receiveMessage(t, a, "dtn://mgr/amp").(*ReportSet); !ok {
+		t.Fatal("agent sent no report set")
+	} else if d := time.Since(start); d < time.Second {
+		t.Fatalf("delayed control was performed after %v", d)
+	}
+}
+
+func TestNewAgentObjectTypes(t *testing.T) {
+	_, err := NewAgent(bpv7.MustNewEndpointID("dtn://node/amp"), nil, time.Hour,
+		map[ARI]func() Value{MustParseARI("ari:/dtn7/VAR.foo"): func() Value { return nil }}, nil)
+	if err == nil {
+		t.Fatal("variable was accepted as an EDD")
+	}
+}
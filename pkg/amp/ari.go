@@ -0,0 +1,73 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package amp
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ObjectType of an ARI, e.g., EDD for externally defined data.
+type ObjectType string
+
+const (
+	// EDD is externally defined, read-only data, e.g., a counter.
+	EDD ObjectType = "EDD"
+
+	// VAR is a variable, which might be read and set.
+	VAR ObjectType = "VAR"
+
+	// CTRL is a control, executed by an Agent.
+	CTRL ObjectType = "CTRL"
+)
+
+// ariScheme prefixes each ARI's text form.
+const ariScheme = "ari:/"
+
+// ARI identifies an object within a namespace, written as "ari:/namespace/TYPE.name".
+type ARI struct {
+	Namespace string
+	Type      ObjectType
+	Name      string
+}
+
+// ParseARI from its text form, e.g., "ari:/dtn7/EDD.bundles_received".
+func ParseARI(s string) (ARI, error) {
+	rest := strings.TrimPrefix(s, ariScheme)
+	if rest == s {
+		return ARI{}, fmt.Errorf("ARI %q does not start with %q", s, ariScheme)
+	}
+
+	namespace, object, ok := strings.Cut(rest, "/")
+	if !ok || namespace == "" {
+		return ARI{}, fmt.Errorf("ARI %q has no namespace", s)
+	}
+
+	objType, name, ok := strings.Cut(object, ".")
+	if !ok || name == "" {
+		return ARI{}, fmt.Errorf("ARI %q has no object name", s)
+	}
+
+	switch t := ObjectType(objType); t {
+	case EDD, VAR, CTRL:
+		return ARI{Namespace: namespace, Type: t, Name: name}, nil
+
+	default:
+		return ARI{}, fmt.Errorf("ARI %q has an unsupported object type %q", s, objType)
+	}
+}
+
+// MustParseARI is like ParseARI, but panics for an invalid ARI.
+func MustParseARI(s string) ARI {
+	ari, err := ParseARI(s)
+	if err != nil {
+		panic(err)
+	}
+	return ari
+}
+
+func (ari ARI) String() string {
+	return fmt.Sprintf("%s%s/%s.%s", ariScheme, ari.Namespace, ari.Type, ari.Name)
+}
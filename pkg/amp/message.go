@@ -0,0 +1,318 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package amp
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/dtn7/cboring"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+// MessageType identifies each Message within a MessageGroup.
+type MessageType uint64
+
+const (
+	// RegisterAgentType is the MessageType of RegisterAgent.
+	RegisterAgentType MessageType = 0
+
+	// ReportSetType is the MessageType of ReportSet.
+	ReportSetType MessageType = 1
+
+	// PerformControlType is the MessageType of PerformControl.
+	PerformControlType MessageType = 2
+)
+
+// Message within a MessageGroup, encoded as a CBOR array starting with its MessageType.
+type Message interface {
+	cboring.CborMarshaler
+
+	// Type of this Message.
+	Type() MessageType
+}
+
+// MessageGroup is a management bundle's payload, a CBOR array of its creation time followed by its Messages.
+type MessageGroup struct {
+	Timestamp bpv7.DtnTime
+	Messages  []Message
+}
+
+// NewMessageGroup of some Messages, created now.
+func NewMessageGroup(msgs ...Message) *MessageGroup {
+	return &MessageGroup{Timestamp: bpv7.DtnTimeNow(), Messages: msgs}
+}
+
+func (mg *MessageGroup) MarshalCbor(w io.Writer) error {
+	if err := cboring.WriteArrayLength(uint64(1+len(mg.Messages)), w); err != nil {
+		return err
+	}
+	if err := cboring.WriteUInt(uint64(mg.Timestamp), w); err != nil {
+		return err
+	}
+
+	for _, msg := range mg.Messages {
+		if err := cboring.Marshal(msg, w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (mg *MessageGroup) UnmarshalCbor(r io.Reader) error {
+	n, err := cboring.ReadArrayLength(r)
+	if err != nil {
+		return err
+	} else if n == 0 {
+		return fmt.Errorf("message group lacks its timestamp")
+	}
+
+	if ts, err := cboring.ReadUInt(r); err != nil {
+		return err
+	} else {
+		mg.Timestamp = bpv7.DtnTime(ts)
+	}
+
+	mg.Messages = make([]Message, 0, n-1)
+	for i := uint64(1); i < n; i++ {
+		msg, err := readMessage(r)
+		if err != nil {
+			return err
+		}
+		mg.Messages = append(mg.Messages, msg)
+	}
+	return nil
+}
+
+// writeMessageHeader starts a Message's CBOR array of n fields, besides its MessageType.
+func writeMessageHeader(t MessageType, n uint64, w io.Writer) error {
+	if err := cboring.WriteArrayLength(1+n, w); err != nil {
+		return err
+	}
+	return cboring.WriteUInt(uint64(t), w)
+}
+
+// readMessage of any supported MessageType.
+func readMessage(r io.Reader) (Message, error) {
+	n, err := cboring.ReadArrayLength(r)
+	if err != nil {
+		return nil, err
+	} else if n == 0 {
+		return nil, fmt.Errorf("message lacks its type")
+	}
+
+	t, err := cboring.ReadUInt(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var msg Message
+	var fields uint64
+	switch MessageType(t) {
+	case RegisterAgentType:
+		msg, fields = &RegisterAgent{}, 1
+	case ReportSetType:
+		msg, fields = &ReportSet{}, 1
+	case PerformControlType:
+		msg, fields = &PerformControl{}, 2
+	default:
+		return nil, fmt.Errorf("unsupported message type %d", t)
+	}
+
+	if n-1 != fields {
+		return nil, fmt.Errorf("message type %d expects %d fields, got %d", t, fields, n-1)
+	}
+	return msg, msg.UnmarshalCbor(r)
+}
+
+// RegisterAgent announces an Agent to its managers.
+type RegisterAgent struct {
+	Agent bpv7.EndpointID
+}
+
+func (ra *RegisterAgent) Type() MessageType {
+	return RegisterAgentType
+}
+
+func (ra *RegisterAgent) MarshalCbor(w io.Writer) error {
+	if err := writeMessageHeader(RegisterAgentType, 1, w); err != nil {
+		return err
+	}
+	return cboring.WriteTextString(ra.Agent.String(), w)
+}
+
+// UnmarshalCbor reads the fields after the MessageType, which was already consumed by the MessageGroup.
+func (ra *RegisterAgent) UnmarshalCbor(r io.Reader) error {
+	s, err := cboring.ReadTextString(r)
+	if err != nil {
+		return err
+	}
+	ra.Agent, err = bpv7.NewEndpointID(s)
+	return err
+}
+
+// Control to be executed, identified by its ARI, with its parameters.
+type Control struct {
+	ARI        ARI
+	Parameters []Value
+}
+
+// PerformControl requests an Agent to execute Controls after a delay of Start seconds.
+type PerformControl struct {
+	Start    uint64
+	Controls []Control
+}
+
+func (pc *PerformControl) Type() MessageType {
+	return PerformControlType
+}
+
+func (pc *PerformControl) MarshalCbor(w io.Writer) error {
+	if err := writeMessageHeader(PerformControlType, 2, w); err != nil {
+		return err
+	}
+	if err := cboring.WriteUInt(pc.Start, w); err != nil {
+		return err
+	}
+
+	if err := cboring.WriteArrayLength(uint64(len(pc.Controls)), w); err != nil {
+		return err
+	}
+	for _, ctrl := range pc.Controls {
+		if err := cboring.WriteArrayLength(2, w); err != nil {
+			return err
+		}
+		if err := cboring.WriteTextString(ctrl.ARI.String(), w); err != nil {
+			return err
+		}
+
+		if err := cboring.WriteArrayLength(uint64(len(ctrl.Parameters)), w); err != nil {
+			return err
+		}
+		for _, param := range ctrl.Parameters {
+			if err := writeValue(param, w); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// UnmarshalCbor reads the fields after the MessageType, which was already consumed by the MessageGroup.
+func (pc *PerformControl) UnmarshalCbor(r io.Reader) (err error) {
+	if pc.Start, err = cboring.ReadUInt(r); err != nil {
+		return
+	}
+
+	n, err := cboring.ReadArrayLength(r)
+	if err != nil {
+		return
+	}
+	pc.Controls = make([]Control, n)
+	for i := range pc.Controls {
+		if l, lErr := cboring.ReadArrayLength(r); lErr != nil {
+			return lErr
+		} else if l != 2 {
+			return fmt.Errorf("expected control array of 2 elements, got %d", l)
+		}
+
+		if pc.Controls[i].ARI, err = readARI(r); err != nil {
+			return
+		}
+
+		m, mErr := cboring.ReadArrayLength(r)
+		if mErr != nil {
+			return mErr
+		}
+		pc.Controls[i].Parameters = make([]Value, m)
+		for j := range pc.Controls[i].Parameters {
+			if pc.Controls[i].Parameters[j], err = readValue(r); err != nil {
+				return
+			}
+		}
+	}
+	return
+}
+
+// Report of an object's Value at a time. An unknown object is reported without a Value.
+type Report struct {
+	ARI       ARI
+	Timestamp bpv7.DtnTime
+	Value     Value
+}
+
+// ReportSet of an Agent's Reports, sent to a manager.
+type ReportSet struct {
+	Reports []Report
+}
+
+func (rs *ReportSet) Type() MessageType {
+	return ReportSetType
+}
+
+func (rs *ReportSet) MarshalCbor(w io.Writer) error {
+	if err := writeMessageHeader(ReportSetType, 1, w); err != nil {
+		return err
+	}
+
+	if err := cboring.WriteArrayLength(uint64(len(rs.Reports)), w); err != nil {
+		return err
+	}
+	for _, rpt := range rs.Reports {
+		if err := cboring.WriteArrayLength(3, w); err != nil {
+			return err
+		}
+		if err := cboring.WriteTextString(rpt.ARI.String(), w); err != nil {
+			return err
+		}
+		if err := cboring.WriteUInt(uint64(rpt.Timestamp), w); err != nil {
+			return err
+		}
+		if err := writeValue(rpt.Value, w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UnmarshalCbor reads the fields after the MessageType, which was already consumed by the MessageGroup.
+func (rs *ReportSet) UnmarshalCbor(r io.Reader) error {
+	n, err := cboring.ReadArrayLength(r)
+	if err != nil {
+		return err
+	}
+
+	rs.Reports = make([]Report, n)
+	for i := range rs.Reports {
+		if l, err := cboring.ReadArrayLength(r); err != nil {
+			return err
+		} else if l != 3 {
+			return fmt.Errorf("expected report array of 3 elements, got %d", l)
+		}
+
+		if rs.Reports[i].ARI, err = readARI(r); err != nil {
+			return err
+		}
+		if ts, err := cboring.ReadUInt(r); err != nil {
+			return err
+		} else {
+			rs.Reports[i].Timestamp = bpv7.DtnTime(ts)
+		}
+		if rs.Reports[i].Value, err = readValue(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readARI from its CBOR text string.
+func readARI(r io.Reader) (ARI, error) {
+	s, err := cboring.ReadTextString(r)
+	if err != nil {
+		return ARI{}, err
+	}
+	return ParseARI(s)
+}
@@ -0,0 +1,92 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package amp
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/dtn7/cboring"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+func TestParseARI(t *testing.T) {
+	tests := []struct {
+		s     string
+		ari   ARI
+		valid bool
+	}{
+		{"ari:/dtn7/EDD.bundles_received", ARI{"dtn7", EDD, "bundles_received"}, true},
+		{"ari:/dtn7/VAR.log_level", ARI{"dtn7", VAR, "log_level"}, true},
+		{"ari:/amp_agent/CTRL.gen_rpts", GenReports, true},
+		{"ari:/dtn7/RPTT.foo", ARI{}, false},
+		{"ari:/dtn7/EDD", ARI{}, false},
+		{"ari:/EDD.foo", ARI{}, false},
+		{"dtn7/EDD.foo", ARI{}, false},
+	}
+
+	for _, test := range tests {
+		ari, err := ParseARI(test.s)
+		if (err == nil) != test.valid {
+			t.Fatalf("%s: expected validity %t, got %v", test.s, test.valid, err)
+		} else if !test.valid {
+			continue
+		}
+
+		if ari != test.ari {
+			t.Fatalf("%s: expected %v, got %v", test.s, test.ari, ari)
+		} else if ari.String() != test.s {
+			t.Fatalf("%s: expected the same text form, got %s", test.s, ari.String())
+		}
+	}
+}
+
+func TestMessageGroupCbor(t *testing.T) {
+	mg := &MessageGroup{
+		Timestamp: bpv7.DtnTime(23),
+		Messages: []Message{
+			&RegisterAgent{Agent: bpv7.MustNewEndpointID("dtn://node/amp")},
+			&PerformControl{Start: 42, Controls: []Control{
+				{ARI: GenReports, Parameters: []Value{"ari:/dtn7/EDD.bundles_received"}},
+				{ARI: SetVariable, Parameters: []Value{"ari:/dtn7/VAR.foo", uint64(1), true, false, nil}},
+			}},
+			&ReportSet{Reports: []Report{
+				{ARI: MustParseARI("ari:/dtn7/EDD.bundles_received"), Timestamp: 5, Value: uint64(1000)},
+				{ARI: MustParseARI("ari:/dtn7/VAR.log_level"), Timestamp: 5, Value: "info"},
+				{ARI: MustParseARI("ari:/dtn7/EDD.unknown"), Timestamp: 5, Value: nil},
+			}},
+		},
+	}
+
+	buff := new(bytes.Buffer)
+	if err := cboring.Marshal(mg, buff); err != nil {
+		t.Fatal(err)
+	}
+
+	var parsed MessageGroup
+	if err := cboring.Unmarshal(&parsed, buff); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(mg, &parsed) {
+		t.Fatalf("expected %v, got %v", mg, parsed)
+	}
+}
+
+func TestParseValue(t *testing.T) {
+	tests := map[string]Value{
+		"23":    uint64(23),
+		"true":  true,
+		"false": false,
+		"debug": "debug",
+		"-1":    "-1",
+	}
+
+	for s, v := range tests {
+		if parsed := ParseValue(s); parsed != v {
+			t.Fatalf("%s: expected %v, got %v", s, v, parsed)
+		}
+	}
+}
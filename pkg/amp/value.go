@@ -0,0 +1,78 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package amp
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/dtn7/cboring"
+)
+
+// Value of an object or a parameter, either an uint64, a string, a bool, or nil for no value.
+type Value interface{}
+
+// cborNull is CBOR's simple value null, encoding a nil Value.
+const cborNull = 22
+
+// simple values of CBOR's booleans.
+const (
+	cborFalse = 20
+	cborTrue  = 21
+)
+
+// writeValue of a supported type as its CBOR data item.
+func writeValue(v Value, w io.Writer) error {
+	switch v := v.(type) {
+	case nil:
+		return cboring.WriteMajors(cboring.SimpleData, cborNull, w)
+	case uint64:
+		return cboring.WriteUInt(v, w)
+	case string:
+		return cboring.WriteTextString(v, w)
+	case bool:
+		return cboring.WriteBoolean(v, w)
+	default:
+		return fmt.Errorf("unsupported value type %T", v)
+	}
+}
+
+// readValue of a supported type from its CBOR data item.
+func readValue(r io.Reader) (Value, error) {
+	m, n, err := cboring.ReadMajors(r)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case m == cboring.UInt:
+		return n, nil
+
+	case m == cboring.TextString:
+		data, err := cboring.ReadRawBytes(n, r)
+		return string(data), err
+
+	case m == cboring.SimpleData && n == cborFalse:
+		return false, nil
+	case m == cboring.SimpleData && n == cborTrue:
+		return true, nil
+	case m == cboring.SimpleData && n == cborNull:
+		return nil, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported value of major type 0x%x", m)
+	}
+}
+
+// ParseValue from a string, as entered by a user: unsigned integers and booleans are parsed, others kept as strings.
+func ParseValue(s string) Value {
+	if n, err := strconv.ParseUint(s, 10, 64); err == nil {
+		return n
+	} else if b, err := strconv.ParseBool(s); err == nil {
+		return b
+	}
+	return s
+}
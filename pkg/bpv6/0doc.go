@@ -0,0 +1,20 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+// Package bpv6 provides parsing and serialization of Bundles as defined in the Bundle Protocol Version 6 (RFC 5050),
+// including the Compressed Bundle Header Encoding (CBHE, RFC 6260) for ipn endpoints. Its purpose is to act as a
+// gateway between legacy BPv6 deployments and BPv7. Thus, Bundles can be converted from and to a bpv7.Bundle.
+//
+//	// A received BPv6 Bundle is parsed and converted into a BPv7 Bundle.
+//	b6, err1 := bpv6.ParseBundle(r)
+//	b7, err2 := b6.ToBpv7()
+//
+//	// A BPv7 Bundle is converted into a BPv6 Bundle and serialized.
+//	b6, err3 := bpv6.FromBpv7(b7)
+//	err4 := b6.WriteBundle(w)
+//
+// Only the bundle's primary block and payload are converted. Extension blocks are specific to one version and are
+// discarded. Administrative records differ between both versions. Bundle status reports are converted, while custody
+// signals cannot be converted.
+package bpv6
@@ -0,0 +1,36 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package bpv6
+
+// BlockControlFlags are the block processing control flags, as defined in RFC 5050, section 4.3.
+type BlockControlFlags uint64
+
+const (
+	// ReplicateBlock requires this block to be replicated in every fragment.
+	ReplicateBlock BlockControlFlags = 0x01
+
+	// StatusReportBlock requests a status report if this block cannot be processed.
+	StatusReportBlock BlockControlFlags = 0x02
+
+	// DeleteBundle requests the bundle's deletion if this block cannot be processed.
+	DeleteBundle BlockControlFlags = 0x04
+
+	// LastBlock marks the bundle's last block. It is set while serializing a Bundle.
+	LastBlock BlockControlFlags = 0x08
+
+	// DiscardBlock requests this block's removal if it cannot be processed.
+	DiscardBlock BlockControlFlags = 0x10
+
+	// ForwardedUnprocessed indicates that this block was forwarded without being processed.
+	ForwardedUnprocessed BlockControlFlags = 0x20
+
+	// EIDReferences indicates that this block contains an EID reference field. It is set while serializing a Bundle.
+	EIDReferences BlockControlFlags = 0x40
+)
+
+// Has returns true if a given flag or mask of flags is set.
+func (bcf BlockControlFlags) Has(flag BlockControlFlags) bool {
+	return (bcf & flag) == flag
+}
@@ -0,0 +1,124 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package bpv6
+
+import (
+	"fmt"
+	"io"
+)
+
+// Bundle of BPv6, as defined in RFC 5050, section 4.
+type Bundle struct {
+	PrimaryBlock    PrimaryBlock
+	CanonicalBlocks []CanonicalBlock
+}
+
+// ParseBundle reads a new BPv6 Bundle. The io.Reader is read byte by byte, unless it is an io.ByteReader, to not
+// consume any data following the Bundle.
+func ParseBundle(r io.Reader) (b Bundle, err error) {
+	br := newByteReader(r)
+
+	var dict []byte
+	if b.PrimaryBlock, dict, err = readPrimaryBlock(br); err != nil {
+		err = fmt.Errorf("primary block failed: %v", err)
+		return
+	}
+
+	for {
+		cb, cbErr := readCanonicalBlock(br, dict)
+		if cbErr != nil {
+			err = fmt.Errorf("canonical block failed: %v", cbErr)
+			return
+		}
+
+		last := cb.BlockControlFlags.Has(LastBlock)
+		cb.BlockControlFlags &^= LastBlock | EIDReferences
+		b.CanonicalBlocks = append(b.CanonicalBlocks, cb)
+
+		if last {
+			break
+		}
+	}
+
+	err = b.CheckValid()
+	return
+}
+
+// WriteBundle serializes this Bundle. If all of the primary block's EndpointIDs use the ipn scheme and no block has
+// EID references, the Compressed Bundle Header Encoding (CBHE) of RFC 6260 is used.
+func (b *Bundle) WriteBundle(w io.Writer) error {
+	if err := b.CheckValid(); err != nil {
+		return err
+	}
+
+	var dict *dictionary
+	if !b.isCBHE() {
+		dict = new(dictionary)
+		for _, eid := range b.PrimaryBlock.endpoints() {
+			dict.addEndpoint(*eid)
+		}
+		for _, cb := range b.CanonicalBlocks {
+			for _, eid := range cb.EIDReferences {
+				dict.addEndpoint(eid)
+			}
+		}
+	}
+
+	if err := b.PrimaryBlock.write(w, dict); err != nil {
+		return fmt.Errorf("primary block failed: %v", err)
+	}
+
+	for i := range b.CanonicalBlocks {
+		if err := b.CanonicalBlocks[i].write(w, dict, i == len(b.CanonicalBlocks)-1); err != nil {
+			return fmt.Errorf("canonical block failed: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// isCBHE checks if this Bundle can be serialized with the Compressed Bundle Header Encoding.
+func (b *Bundle) isCBHE() bool {
+	if !b.PrimaryBlock.isCBHE() {
+		return false
+	}
+
+	for _, cb := range b.CanonicalBlocks {
+		if len(cb.EIDReferences) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// PayloadBlock returns this Bundle's payload block.
+func (b *Bundle) PayloadBlock() (*CanonicalBlock, error) {
+	for i := range b.CanonicalBlocks {
+		if b.CanonicalBlocks[i].BlockType == BlockTypePayload {
+			return &b.CanonicalBlocks[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no payload block exists")
+}
+
+// CheckValid returns an error for a Bundle without exactly one payload block or for an inconsistent fragment.
+func (b Bundle) CheckValid() error {
+	payloadBlocks := 0
+	for _, cb := range b.CanonicalBlocks {
+		if cb.BlockType == BlockTypePayload {
+			payloadBlocks++
+		}
+	}
+	if payloadBlocks != 1 {
+		return fmt.Errorf("bundle has %d payload blocks instead of one", payloadBlocks)
+	}
+
+	pb := b.PrimaryBlock
+	if pb.BundleControlFlags.Has(IsFragment) && pb.BundleControlFlags.Has(MustNotFragmented) {
+		return fmt.Errorf("bundle is a fragment, but must not be fragmented")
+	}
+
+	return nil
+}
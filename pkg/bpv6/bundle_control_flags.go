@@ -0,0 +1,75 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package bpv6
+
+// BundleControlFlags are the bundle processing control flags, as defined in RFC 5050, section 4.2.
+type BundleControlFlags uint64
+
+const (
+	// IsFragment indicates that the bundle is a fragment.
+	IsFragment BundleControlFlags = 0x000001
+
+	// AdministrativeRecordPayload indicates that the application data unit is an administrative record.
+	AdministrativeRecordPayload BundleControlFlags = 0x000002
+
+	// MustNotFragmented indicates that the bundle must not be fragmented.
+	MustNotFragmented BundleControlFlags = 0x000004
+
+	// CustodyTransferRequested requests the custody transfer.
+	CustodyTransferRequested BundleControlFlags = 0x000008
+
+	// DestinationIsSingleton indicates that the destination endpoint is a singleton.
+	DestinationIsSingleton BundleControlFlags = 0x000010
+
+	// RequestApplicationAck requests an acknowledgement by the application.
+	RequestApplicationAck BundleControlFlags = 0x000020
+
+	// StatusRequestReception requests reporting of bundle reception.
+	StatusRequestReception BundleControlFlags = 0x004000
+
+	// StatusRequestCustodyAcceptance requests reporting of custody acceptance.
+	StatusRequestCustodyAcceptance BundleControlFlags = 0x008000
+
+	// StatusRequestForward requests reporting of bundle forwarding.
+	StatusRequestForward BundleControlFlags = 0x010000
+
+	// StatusRequestDelivery requests reporting of bundle delivery.
+	StatusRequestDelivery BundleControlFlags = 0x020000
+
+	// StatusRequestDeletion requests reporting of bundle deletion.
+	StatusRequestDeletion BundleControlFlags = 0x040000
+
+	// priorityMask covers the two bits of the class of service's priority.
+	priorityMask BundleControlFlags = 0x000180
+	// priorityShift is the priority's position within the BundleControlFlags.
+	priorityShift = 7
+)
+
+// Priority is the class of service's priority, as defined in RFC 5050, section 4.2.
+type Priority uint8
+
+const (
+	// PriorityBulk is the lowest priority.
+	PriorityBulk Priority = 0
+	// PriorityNormal is the default priority.
+	PriorityNormal Priority = 1
+	// PriorityExpedited is the highest priority.
+	PriorityExpedited Priority = 2
+)
+
+// Has returns true if a given flag or mask of flags is set.
+func (bcf BundleControlFlags) Has(flag BundleControlFlags) bool {
+	return (bcf & flag) == flag
+}
+
+// Priority returns the class of service's priority.
+func (bcf BundleControlFlags) Priority() Priority {
+	return Priority((bcf & priorityMask) >> priorityShift)
+}
+
+// WithPriority returns these BundleControlFlags with another priority.
+func (bcf BundleControlFlags) WithPriority(priority Priority) BundleControlFlags {
+	return (bcf &^ priorityMask) | (BundleControlFlags(priority)<<priorityShift)&priorityMask
+}
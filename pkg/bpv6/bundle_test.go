@@ -0,0 +1,128 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package bpv6
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"testing/iotest"
+)
+
+func TestParseBundleCBHE(t *testing.T) {
+	data := []byte{
+		// Primary block: version, flags, block length
+		0x06, 0x10, 0x0D,
+		// CBHE: destination ipn:2.1, source ipn:1.1, report-to ipn:1.1, custodian dtn:none
+		0x02, 0x01, 0x01, 0x01, 0x01, 0x01, 0x00, 0x00,
+		// Creation timestamp, lifetime of 3600s, dictionary length
+		0x01, 0x00, 0x9C, 0x10, 0x00,
+		// Payload block: type, last block flag, length, data
+		0x01, 0x08, 0x05, 'h', 'e', 'l', 'l', 'o',
+	}
+
+	expected := Bundle{
+		PrimaryBlock: PrimaryBlock{
+			BundleControlFlags: DestinationIsSingleton,
+			Destination:        MustNewEndpointID("ipn:2.1"),
+			SourceNode:         MustNewEndpointID("ipn:1.1"),
+			ReportTo:           MustNewEndpointID("ipn:1.1"),
+			Custodian:          DtnNone(),
+			CreationTimestamp:  CreationTimestamp{Seconds: 1},
+			Lifetime:           3600,
+		},
+		CanonicalBlocks: []CanonicalBlock{NewPayloadBlock([]byte("hello"))},
+	}
+
+	b, err := ParseBundle(bytes.NewBuffer(data))
+	if err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(b, expected) {
+		t.Fatalf("expected %v, got %v", expected, b)
+	}
+
+	buff := new(bytes.Buffer)
+	if err := b.WriteBundle(buff); err != nil {
+		t.Fatal(err)
+	} else if !bytes.Equal(buff.Bytes(), data) {
+		t.Fatalf("expected %x, got %x", data, buff.Bytes())
+	}
+}
+
+func TestBundleRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		b    Bundle
+	}{
+		{"dictionary", Bundle{
+			PrimaryBlock: PrimaryBlock{
+				BundleControlFlags: (DestinationIsSingleton | StatusRequestDelivery).WithPriority(PriorityExpedited),
+				Destination:        MustNewEndpointID("dtn://dst/app"),
+				SourceNode:         MustNewEndpointID("dtn://src/"),
+				ReportTo:           MustNewEndpointID("dtn://src/"),
+				Custodian:          DtnNone(),
+				CreationTimestamp:  CreationTimestamp{Seconds: 700000000, Sequence: 23},
+				Lifetime:           86400,
+			},
+			CanonicalBlocks: []CanonicalBlock{
+				{BlockType: 5, BlockControlFlags: DiscardBlock, EIDReferences: []EndpointID{
+					MustNewEndpointID("dtn://prev/"), MustNewEndpointID("ipn:1.0"),
+				}, Data: []byte{0x01, 0x02}},
+				NewPayloadBlock(bytes.Repeat([]byte("hello"), 100)),
+			},
+		}},
+		{"fragment", Bundle{
+			PrimaryBlock: PrimaryBlock{
+				BundleControlFlags: IsFragment,
+				Destination:        MustNewEndpointID("ipn:2.1"),
+				SourceNode:         MustNewEndpointID("ipn:1.1"),
+				ReportTo:           DtnNone(),
+				Custodian:          MustNewEndpointID("ipn:3.0"),
+				Lifetime:           60,
+				FragmentOffset:     1024,
+				TotalDataLength:    4096,
+			},
+			CanonicalBlocks: []CanonicalBlock{NewPayloadBlock([]byte("hello"))},
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			buff := new(bytes.Buffer)
+			if err := test.b.WriteBundle(buff); err != nil {
+				t.Fatal(err)
+			}
+
+			// Append another Bundle's start, which must not be consumed.
+			_ = buff.WriteByte(version)
+
+			b, err := ParseBundle(iotest.OneByteReader(buff))
+			if err != nil {
+				t.Fatal(err)
+			} else if !reflect.DeepEqual(b, test.b) {
+				t.Fatalf("expected %v, got %v", test.b, b)
+			} else if buff.Len() != 1 {
+				t.Fatalf("expected one remaining byte, got %d", buff.Len())
+			}
+		})
+	}
+}
+
+func TestParseBundleInvalid(t *testing.T) {
+	tests := map[string][]byte{
+		"empty":             {},
+		"version":           {0x07, 0x10, 0x0D},
+		"truncated primary": {0x06, 0x10, 0x0D, 0x02, 0x01},
+		"dictionary offset": {0x06, 0x00, 0x0E, 0x05, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x02, 'x', 0x00},
+		"no payload block":  {0x06, 0x00, 0x0C, 0x02, 0x01, 0x01, 0x01, 0x01, 0x01, 0x00, 0x00, 0x01, 0x00, 0x01, 0x00, 0x05, 0x08, 0x00},
+		"missing last":      {0x06, 0x00, 0x0C, 0x02, 0x01, 0x01, 0x01, 0x01, 0x01, 0x00, 0x00, 0x01, 0x00, 0x01, 0x00, 0x01, 0x00, 0x00},
+	}
+
+	for name, data := range tests {
+		if b, err := ParseBundle(bytes.NewBuffer(data)); err == nil {
+			t.Fatalf("%s: expected error, got %v", name, b)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package bpv6
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+)
+
+// BlockTypePayload is the block type code of the payload block, as defined in RFC 5050, section 4.5.2.
+const BlockTypePayload uint8 = 1
+
+// CanonicalBlock of a BPv6 Bundle, as defined in RFC 5050, section 4.5.2. Its block-type-specific data is kept raw.
+type CanonicalBlock struct {
+	BlockType         uint8
+	BlockControlFlags BlockControlFlags
+	EIDReferences     []EndpointID
+	Data              []byte
+}
+
+// NewPayloadBlock creates a payload block for the given data.
+func NewPayloadBlock(data []byte) CanonicalBlock {
+	return CanonicalBlock{BlockType: BlockTypePayload, Data: data}
+}
+
+// write this CanonicalBlock. The LastBlock and EIDReferences flags are set based on the arguments and this block's
+// EIDReferences. EIDReferences are resolved by the dictionary, which must not be nil for CBHE.
+func (cb *CanonicalBlock) write(w io.Writer, dict *dictionary, last bool) error {
+	flags := cb.BlockControlFlags &^ (LastBlock | EIDReferences)
+	if last {
+		flags |= LastBlock
+	}
+	if len(cb.EIDReferences) > 0 {
+		if dict == nil {
+			return fmt.Errorf("EID references cannot be used with CBHE")
+		}
+		flags |= EIDReferences
+	}
+
+	buff := new(bytes.Buffer)
+	_ = buff.WriteByte(cb.BlockType)
+	_ = writeSdnv(uint64(flags), buff)
+
+	if len(cb.EIDReferences) > 0 {
+		_ = writeSdnv(uint64(len(cb.EIDReferences)), buff)
+		for _, eid := range cb.EIDReferences {
+			schemeOffset, sspOffset := dict.addEndpoint(eid)
+			_ = writeSdnv(schemeOffset, buff)
+			_ = writeSdnv(sspOffset, buff)
+		}
+	}
+
+	_ = writeSdnv(uint64(len(cb.Data)), buff)
+
+	if _, err := w.Write(buff.Bytes()); err != nil {
+		return err
+	}
+	_, err := w.Write(cb.Data)
+	return err
+}
+
+// readCanonicalBlock reads a CanonicalBlock, resolving EID references by the primary block's dictionary.
+func readCanonicalBlock(r io.Reader, dict []byte) (cb CanonicalBlock, err error) {
+	br := newByteReader(r)
+
+	if cb.BlockType, err = br.ReadByte(); err != nil {
+		return
+	}
+
+	var flags uint64
+	if flags, err = readSdnv(br); err != nil {
+		return
+	}
+	cb.BlockControlFlags = BlockControlFlags(flags)
+
+	if cb.BlockControlFlags.Has(EIDReferences) {
+		var refs uint64
+		if refs, err = readSdnv(br); err != nil {
+			return
+		} else if dict == nil && refs > 0 {
+			err = fmt.Errorf("block has EID references, but no dictionary exists")
+			return
+		}
+
+		for i := uint64(0); i < refs; i++ {
+			var schemeOffset, sspOffset uint64
+			if schemeOffset, err = readSdnv(br); err != nil {
+				return
+			}
+			if sspOffset, err = readSdnv(br); err != nil {
+				return
+			}
+
+			var eid EndpointID
+			if eid, err = lookupEndpoint(dict, schemeOffset, sspOffset); err != nil {
+				return
+			}
+			cb.EIDReferences = append(cb.EIDReferences, eid)
+		}
+	}
+
+	var dataLen uint64
+	if dataLen, err = readSdnv(br); err != nil {
+		return
+	}
+	cb.Data, err = readBytes(dataLen, br)
+	return
+}
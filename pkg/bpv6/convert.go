@@ -0,0 +1,188 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package bpv6
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+// sharedBundleControlFlags are identically defined for BPv6 and BPv7.
+const sharedBundleControlFlags = IsFragment | AdministrativeRecordPayload | MustNotFragmented | RequestApplicationAck |
+	StatusRequestReception | StatusRequestForward | StatusRequestDelivery | StatusRequestDeletion
+
+// sharedBlockControlFlags are identically defined for BPv6 and BPv7.
+const sharedBlockControlFlags = ReplicateBlock | StatusReportBlock | DeleteBundle | DiscardBlock
+
+// ToBpv7 converts this Bundle into a bpv7.Bundle.
+//
+// BPv6 specific flags, e.g., for custody transfer, and the custodian are dropped. Extension blocks are discarded,
+// unless they require the bundle's deletion if they cannot be processed, resulting in an error. A zero creation time
+// results in a Bundle Age Block, as required by BPv7. A priority other than normal results in a bpv7.PriorityBlock.
+// An administrative record's StatusReport is converted into a bpv7.StatusReport, while custody signals result in an
+// error.
+func (b Bundle) ToBpv7() (bndl bpv7.Bundle, err error) {
+	pb := b.PrimaryBlock
+
+	var eids [3]bpv7.EndpointID
+	for i, eid := range []EndpointID{pb.Destination, pb.SourceNode, pb.ReportTo} {
+		if eids[i], err = eid.toBpv7(); err != nil {
+			return
+		}
+	}
+
+	primary := bpv7.NewPrimaryBlock(
+		bpv7.BundleControlFlags(pb.BundleControlFlags&sharedBundleControlFlags),
+		eids[0], eids[1],
+		bpv7.NewCreationTimestamp(bpv7.DtnTime(pb.CreationTimestamp.Seconds*1000), pb.CreationTimestamp.Sequence),
+		pb.Lifetime*1000)
+	primary.ReportTo = eids[2]
+	primary.FragmentOffset = pb.FragmentOffset
+	primary.TotalDataLength = pb.TotalDataLength
+
+	var canonicals []bpv7.CanonicalBlock
+	if primary.CreationTimestamp.IsZeroTime() {
+		canonicals = append(canonicals, bpv7.NewCanonicalBlock(2, bpv7.ReplicateBlock, bpv7.NewBundleAgeBlock(0)))
+	}
+	if priority := pb.BundleControlFlags.Priority(); priority != PriorityNormal {
+		canonicals = append(canonicals, bpv7.NewCanonicalBlock(3, bpv7.ReplicateBlock,
+			bpv7.NewPriorityBlock(bpv7.Priority(priority))))
+	}
+
+	for _, cb := range b.CanonicalBlocks {
+		if cb.BlockType == BlockTypePayload {
+			data := cb.Data
+			if pb.BundleControlFlags.Has(AdministrativeRecordPayload) {
+				if data, err = statusReportToBpv7(data); err != nil {
+					return
+				}
+			}
+
+			canonicals = append(canonicals, bpv7.NewCanonicalBlock(1,
+				bpv7.BlockControlFlags(cb.BlockControlFlags&sharedBlockControlFlags), bpv7.NewPayloadBlock(data)))
+		} else if cb.BlockControlFlags.Has(DeleteBundle) {
+			err = fmt.Errorf("block of type %d cannot be converted, but must be processed", cb.BlockType)
+			return
+		}
+	}
+
+	return bpv7.NewBundle(primary, canonicals)
+}
+
+// FromBpv7 converts a bpv7.Bundle into a Bundle.
+//
+// BPv7 specific flags and all extension blocks are dropped, except for a bpv7.PriorityBlock's priority. Times are rounded to seconds, while the lifetime is
+// rounded up. As BPv6 lacks a Bundle Age Block, a zero creation time is replaced by the current time minus the age.
+// An administrative record's bpv7.StatusReport is converted into a StatusReport, while other records result in an
+// error.
+func FromBpv7(bndl bpv7.Bundle) (b Bundle, err error) {
+	pb := bndl.PrimaryBlock
+
+	b.PrimaryBlock = PrimaryBlock{
+		BundleControlFlags: (BundleControlFlags(pb.BundleControlFlags) & sharedBundleControlFlags).WithPriority(Priority(bndl.Priority())),
+		Custodian:          DtnNone(),
+		CreationTimestamp: CreationTimestamp{
+			Seconds:  uint64(pb.CreationTimestamp.DtnTime()) / 1000,
+			Sequence: pb.CreationTimestamp.SequenceNumber(),
+		},
+		Lifetime:        (pb.Lifetime + 999) / 1000,
+		FragmentOffset:  pb.FragmentOffset,
+		TotalDataLength: pb.TotalDataLength,
+	}
+	if pb.Destination.IsSingleton() {
+		b.PrimaryBlock.BundleControlFlags |= DestinationIsSingleton
+	}
+
+	eids := []*EndpointID{&b.PrimaryBlock.Destination, &b.PrimaryBlock.SourceNode, &b.PrimaryBlock.ReportTo}
+	for i, eid := range []bpv7.EndpointID{pb.Destination, pb.SourceNode, pb.ReportTo} {
+		if *eids[i], err = endpointFromBpv7(eid); err != nil {
+			return
+		}
+	}
+
+	if pb.CreationTimestamp.IsZeroTime() {
+		bab, babErr := bndl.ExtensionBlock(bpv7.ExtBlockTypeBundleAgeBlock)
+		if babErr != nil {
+			err = fmt.Errorf("zero creation time, but no Bundle Age Block: %v", babErr)
+			return
+		}
+
+		created := time.Now().Add(-time.Duration(bab.Value.(*bpv7.BundleAgeBlock).Age()) * time.Millisecond)
+		b.PrimaryBlock.CreationTimestamp.Seconds = uint64(bpv7.DtnTimeFromTime(created)) / 1000
+	}
+
+	payloadBlock, payloadErr := bndl.PayloadBlock()
+	if payloadErr != nil {
+		err = payloadErr
+		return
+	}
+	payload, payloadErr := bndl.PayloadData()
+	if payloadErr != nil {
+		err = payloadErr
+		return
+	}
+	if bndl.IsAdministrativeRecord() {
+		if payload, err = statusReportFromBpv7(bndl); err != nil {
+			return
+		}
+	}
+
+	b.CanonicalBlocks = []CanonicalBlock{{
+		BlockType:         BlockTypePayload,
+		BlockControlFlags: BlockControlFlags(payloadBlock.BlockControlFlags) & sharedBlockControlFlags,
+		Data:              payload,
+	}}
+
+	err = b.CheckValid()
+	return
+}
+
+// statusReportToBpv7 converts an administrative record's payload, which must be a StatusReport, into a BPv7
+// administrative record's payload.
+func statusReportToBpv7(data []byte) ([]byte, error) {
+	sr, err := ParseStatusReport(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("administrative record cannot be converted: %v", err)
+	}
+
+	report, err := sr.ToBpv7()
+	if err != nil {
+		return nil, err
+	}
+
+	buff := new(bytes.Buffer)
+	if err := bpv7.GetAdministrativeRecordManager().WriteAdministrativeRecord(report, buff); err != nil {
+		return nil, err
+	}
+	return buff.Bytes(), nil
+}
+
+// statusReportFromBpv7 converts a BPv7 administrative record, which must be a bpv7.StatusReport, into a BPv6
+// administrative record's payload.
+func statusReportFromBpv7(bndl bpv7.Bundle) ([]byte, error) {
+	ar, err := bndl.AdministrativeRecord()
+	if err != nil {
+		return nil, err
+	}
+
+	report, ok := ar.(*bpv7.StatusReport)
+	if !ok {
+		return nil, fmt.Errorf("administrative record of type %d cannot be converted", ar.RecordTypeCode())
+	}
+
+	sr, err := StatusReportFromBpv7(report)
+	if err != nil {
+		return nil, err
+	}
+
+	buff := new(bytes.Buffer)
+	if err := sr.WriteStatusReport(buff); err != nil {
+		return nil, err
+	}
+	return buff.Bytes(), nil
+}
@@ -0,0 +1,132 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package bpv6
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+func TestConvertBpv7(t *testing.T) {
+	now := uint64(bpv7.DtnTimeNow()) / 1000
+
+	b6 := Bundle{
+		PrimaryBlock: PrimaryBlock{
+			BundleControlFlags: (DestinationIsSingleton | CustodyTransferRequested | StatusRequestDelivery).WithPriority(PriorityExpedited),
+			Destination:        MustNewEndpointID("ipn:2.1"),
+			SourceNode:         MustNewEndpointID("ipn:1.1"),
+			ReportTo:           MustNewEndpointID("ipn:1.0"),
+			Custodian:          MustNewEndpointID("ipn:1.0"),
+			CreationTimestamp:  CreationTimestamp{Seconds: now, Sequence: 42},
+			Lifetime:           3600,
+		},
+		CanonicalBlocks: []CanonicalBlock{
+			{BlockType: 20, BlockControlFlags: DiscardBlock, Data: []byte{0x00}},
+			NewPayloadBlock([]byte("hello world")),
+		},
+	}
+
+	b7, err := b6.ToBpv7()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pb7 := b7.PrimaryBlock
+	if pb7.BundleControlFlags != bpv7.StatusRequestDelivery {
+		t.Fatalf("unexpected bundle control flags %v", pb7.BundleControlFlags)
+	} else if pb7.Destination != bpv7.MustNewEndpointID("ipn:2.1") || pb7.ReportTo != bpv7.MustNewEndpointID("ipn:1.0") {
+		t.Fatalf("unexpected endpoints %v, %v", pb7.Destination, pb7.ReportTo)
+	} else if pb7.CreationTimestamp != bpv7.NewCreationTimestamp(bpv7.DtnTime(now*1000), 42) {
+		t.Fatalf("unexpected creation timestamp %v", pb7.CreationTimestamp)
+	} else if pb7.Lifetime != 3600000 {
+		t.Fatalf("unexpected lifetime %d", pb7.Lifetime)
+	} else if len(b7.CanonicalBlocks) != 2 {
+		t.Fatalf("expected the payload and priority block, got %v", b7.CanonicalBlocks)
+	} else if b7.Priority() != bpv7.PriorityExpedited {
+		t.Fatalf("unexpected priority %v", b7.Priority())
+	} else if payload, err := b7.PayloadData(); err != nil || !bytes.Equal(payload, []byte("hello world")) {
+		t.Fatalf("unexpected payload %x: %v", payload, err)
+	}
+
+	// The BPv7 Bundle must be serializable.
+	if err := b7.WriteBundle(new(bytes.Buffer)); err != nil {
+		t.Fatal(err)
+	}
+
+	b6b, err := FromBpv7(b7)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := b6
+	expected.PrimaryBlock.BundleControlFlags = (DestinationIsSingleton | StatusRequestDelivery).WithPriority(PriorityExpedited)
+	expected.PrimaryBlock.Custodian = DtnNone()
+	expected.CanonicalBlocks = expected.CanonicalBlocks[1:]
+
+	if !reflect.DeepEqual(b6b, expected) {
+		t.Fatalf("expected %v, got %v", expected, b6b)
+	}
+}
+
+func TestConvertBpv7ZeroTime(t *testing.T) {
+	b7, err := bpv7.Builder().
+		Source("dtn://src/").
+		Destination("dtn://dst/").
+		CreationTimestampEpoch().
+		Lifetime("10m").
+		BundleAgeBlock(60000).
+		PayloadBlock([]byte("hello world")).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b6, err := FromBpv7(b7)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	created := uint64(bpv7.DtnTimeNow())/1000 - 60
+	if s := b6.PrimaryBlock.CreationTimestamp.Seconds; s < created-1 || s > created+1 {
+		t.Fatalf("expected creation time of about %d, got %d", created, s)
+	}
+
+	// A zero creation time results in a Bundle Age Block.
+	b6.PrimaryBlock.CreationTimestamp.Seconds = 0
+	if b7b, err := b6.ToBpv7(); err != nil {
+		t.Fatal(err)
+	} else if !b7b.HasExtensionBlock(bpv7.ExtBlockTypeBundleAgeBlock) {
+		t.Fatal("no Bundle Age Block was created")
+	}
+}
+
+func TestConvertBpv7Unsupported(t *testing.T) {
+	b6 := Bundle{
+		PrimaryBlock: PrimaryBlock{
+			Destination: MustNewEndpointID("dtn://dst/"),
+			SourceNode:  MustNewEndpointID("dtn://src/"),
+			ReportTo:    DtnNone(),
+			Custodian:   DtnNone(),
+			Lifetime:    60,
+		},
+		CanonicalBlocks: []CanonicalBlock{
+			{BlockType: 20, BlockControlFlags: DeleteBundle, Data: []byte{0x00}},
+			NewPayloadBlock([]byte("hello world")),
+		},
+	}
+
+	if _, err := b6.ToBpv7(); err == nil {
+		t.Fatal("block requiring processing was converted")
+	}
+
+	b6.CanonicalBlocks = b6.CanonicalBlocks[1:]
+	b6.PrimaryBlock.BundleControlFlags = AdministrativeRecordPayload
+	if _, err := b6.ToBpv7(); err == nil {
+		t.Fatal("administrative record was converted")
+	}
+}
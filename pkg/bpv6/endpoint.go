@@ -0,0 +1,138 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package bpv6
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+// EndpointID of BPv6 consists of a scheme name and a scheme-specific part (SSP), e.g., "dtn" and "//foo/bar", as
+// defined in RFC 5050, section 4.4.
+type EndpointID struct {
+	Scheme string
+	SSP    string
+}
+
+// NewEndpointID from an URI, e.g., "dtn://foo/bar" or "ipn:23.42".
+func NewEndpointID(uri string) (eid EndpointID, err error) {
+	parts := strings.SplitN(uri, ":", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		err = fmt.Errorf("URI %q misses a scheme name or a scheme-specific part", uri)
+		return
+	}
+
+	eid = EndpointID{Scheme: parts[0], SSP: parts[1]}
+	return
+}
+
+// MustNewEndpointID from an URI like NewEndpointID, but panics on an error.
+func MustNewEndpointID(uri string) EndpointID {
+	if eid, err := NewEndpointID(uri); err != nil {
+		panic(err)
+	} else {
+		return eid
+	}
+}
+
+// DtnNone returns the null endpoint "dtn:none".
+func DtnNone() EndpointID {
+	return EndpointID{Scheme: "dtn", SSP: "none"}
+}
+
+func (eid EndpointID) String() string {
+	return eid.Scheme + ":" + eid.SSP
+}
+
+// ipn returns the node and service numbers for CBHE, RFC 6260. The null endpoint is represented as "ipn:0.0".
+func (eid EndpointID) ipn() (node, service uint64, ok bool) {
+	if eid == DtnNone() {
+		return 0, 0, true
+	} else if eid.Scheme != "ipn" {
+		return 0, 0, false
+	}
+
+	parts := strings.SplitN(eid.SSP, ".", 2)
+	if len(parts) != 2 {
+		return 0, 0, false
+	}
+
+	var nodeErr, serviceErr error
+	node, nodeErr = strconv.ParseUint(parts[0], 10, 64)
+	service, serviceErr = strconv.ParseUint(parts[1], 10, 64)
+	return node, service, nodeErr == nil && serviceErr == nil
+}
+
+// ipnEndpointID creates an EndpointID from CBHE's node and service numbers, RFC 6260.
+func ipnEndpointID(node, service uint64) EndpointID {
+	if node == 0 && service == 0 {
+		return DtnNone()
+	}
+	return EndpointID{Scheme: "ipn", SSP: fmt.Sprintf("%d.%d", node, service)}
+}
+
+// toBpv7 converts this EndpointID into a bpv7.EndpointID.
+func (eid EndpointID) toBpv7() (bpv7.EndpointID, error) {
+	return bpv7.NewEndpointID(eid.String())
+}
+
+// endpointFromBpv7 converts a bpv7.EndpointID into an EndpointID.
+func endpointFromBpv7(eid bpv7.EndpointID) (EndpointID, error) {
+	return NewEndpointID(eid.String())
+}
+
+// dictionary is a primary block's byte array of null-terminated strings, referenced by offsets, RFC 5050, section 4.5.
+type dictionary struct {
+	buff    bytes.Buffer
+	offsets map[string]uint64
+}
+
+// add a string to this dictionary, if not already present, and return its offset.
+func (dict *dictionary) add(s string) uint64 {
+	if dict.offsets == nil {
+		dict.offsets = make(map[string]uint64)
+	}
+
+	if offset, ok := dict.offsets[s]; ok {
+		return offset
+	}
+
+	offset := uint64(dict.buff.Len())
+	dict.offsets[s] = offset
+	_, _ = dict.buff.WriteString(s)
+	_ = dict.buff.WriteByte(0)
+	return offset
+}
+
+// addEndpoint adds an EndpointID's scheme name and SSP and returns their offsets.
+func (dict *dictionary) addEndpoint(eid EndpointID) (schemeOffset, sspOffset uint64) {
+	return dict.add(eid.Scheme), dict.add(eid.SSP)
+}
+
+// lookupDictionary returns the null-terminated string at an offset of a dictionary's byte array.
+func lookupDictionary(dict []byte, offset uint64) (string, error) {
+	if offset >= uint64(len(dict)) {
+		return "", fmt.Errorf("dictionary offset %d exceeds its length %d", offset, len(dict))
+	}
+
+	end := bytes.IndexByte(dict[offset:], 0)
+	if end < 0 {
+		return "", fmt.Errorf("dictionary string at offset %d is not null-terminated", offset)
+	}
+	return string(dict[offset : offset+uint64(end)]), nil
+}
+
+// lookupEndpoint returns the EndpointID for a scheme name offset and a SSP offset of a dictionary's byte array.
+func lookupEndpoint(dict []byte, schemeOffset, sspOffset uint64) (eid EndpointID, err error) {
+	if eid.Scheme, err = lookupDictionary(dict, schemeOffset); err != nil {
+		return
+	}
+	eid.SSP, err = lookupDictionary(dict, sspOffset)
+	return
+}
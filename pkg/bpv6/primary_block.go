@@ -0,0 +1,176 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package bpv6
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+)
+
+// version of the Bundle Protocol, as defined in RFC 5050.
+const version byte = 0x06
+
+// CreationTimestamp of a bundle, consisting of the seconds since the start of the year 2000 (UTC) and a sequence
+// number, as defined in RFC 5050, section 4.5.1.
+type CreationTimestamp struct {
+	Seconds  uint64
+	Sequence uint64
+}
+
+// PrimaryBlock of a BPv6 Bundle, as defined in RFC 5050, section 4.5.1.
+type PrimaryBlock struct {
+	BundleControlFlags BundleControlFlags
+
+	Destination EndpointID
+	SourceNode  EndpointID
+	ReportTo    EndpointID
+	Custodian   EndpointID
+
+	CreationTimestamp CreationTimestamp
+	// Lifetime in seconds.
+	Lifetime uint64
+
+	FragmentOffset  uint64
+	TotalDataLength uint64
+}
+
+// endpoints of this PrimaryBlock in their serialization order.
+func (pb *PrimaryBlock) endpoints() []*EndpointID {
+	return []*EndpointID{&pb.Destination, &pb.SourceNode, &pb.ReportTo, &pb.Custodian}
+}
+
+// isCBHE checks if this PrimaryBlock can use the Compressed Bundle Header Encoding, as all EndpointIDs are ipn.
+func (pb *PrimaryBlock) isCBHE() bool {
+	for _, eid := range pb.endpoints() {
+		if _, _, ok := eid.ipn(); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// write this PrimaryBlock. The dictionary must already contain all EndpointIDs, including those of canonical blocks.
+// For CBHE, the dictionary is nil.
+func (pb *PrimaryBlock) write(w io.Writer, dict *dictionary) error {
+	body := new(bytes.Buffer)
+
+	for _, eid := range pb.endpoints() {
+		var schemeOffset, sspOffset uint64
+		if dict == nil {
+			schemeOffset, sspOffset, _ = eid.ipn()
+		} else {
+			schemeOffset, sspOffset = dict.addEndpoint(*eid)
+		}
+
+		for _, n := range []uint64{schemeOffset, sspOffset} {
+			_ = writeSdnv(n, body)
+		}
+	}
+
+	for _, n := range []uint64{pb.CreationTimestamp.Seconds, pb.CreationTimestamp.Sequence, pb.Lifetime} {
+		_ = writeSdnv(n, body)
+	}
+
+	if dict == nil {
+		_ = writeSdnv(0, body)
+	} else {
+		_ = writeSdnv(uint64(dict.buff.Len()), body)
+		_, _ = body.Write(dict.buff.Bytes())
+	}
+
+	if pb.BundleControlFlags.Has(IsFragment) {
+		_ = writeSdnv(pb.FragmentOffset, body)
+		_ = writeSdnv(pb.TotalDataLength, body)
+	}
+
+	header := new(bytes.Buffer)
+	_ = header.WriteByte(version)
+	_ = writeSdnv(uint64(pb.BundleControlFlags), header)
+	_ = writeSdnv(uint64(body.Len()), header)
+
+	if _, err := w.Write(header.Bytes()); err != nil {
+		return err
+	}
+	_, err := w.Write(body.Bytes())
+	return err
+}
+
+// readPrimaryBlock reads a PrimaryBlock and returns its dictionary's byte array. For CBHE, the dictionary is nil.
+func readPrimaryBlock(r io.Reader) (pb PrimaryBlock, dict []byte, err error) {
+	br := newByteReader(r)
+
+	if v, vErr := br.ReadByte(); vErr != nil {
+		err = vErr
+		return
+	} else if v != version {
+		err = fmt.Errorf("expected version %d, got %d", version, v)
+		return
+	}
+
+	var flags, blockLen uint64
+	if flags, err = readSdnv(br); err != nil {
+		return
+	}
+	pb.BundleControlFlags = BundleControlFlags(flags)
+
+	if blockLen, err = readSdnv(br); err != nil {
+		return
+	}
+
+	body, bodyErr := readBytes(blockLen, br)
+	if bodyErr != nil {
+		err = bodyErr
+		return
+	}
+	bodyReader := bytes.NewReader(body)
+
+	var fields [11]uint64
+	for i := range fields {
+		if fields[i], err = readSdnv(bodyReader); err != nil {
+			return
+		}
+	}
+
+	pb.CreationTimestamp = CreationTimestamp{Seconds: fields[8], Sequence: fields[9]}
+	pb.Lifetime = fields[10]
+
+	dictLen, dictLenErr := readSdnv(bodyReader)
+	if dictLenErr != nil {
+		err = dictLenErr
+		return
+	} else if dictLen > uint64(bodyReader.Len()) {
+		err = fmt.Errorf("dictionary length %d exceeds primary block", dictLen)
+		return
+	}
+
+	if dictLen > 0 {
+		dict = make([]byte, dictLen)
+		_, _ = io.ReadFull(bodyReader, dict)
+	}
+
+	for i, eid := range pb.endpoints() {
+		schemeOffset, sspOffset := fields[2*i], fields[2*i+1]
+		if dict == nil {
+			*eid = ipnEndpointID(schemeOffset, sspOffset)
+		} else if *eid, err = lookupEndpoint(dict, schemeOffset, sspOffset); err != nil {
+			return
+		}
+	}
+
+	if pb.BundleControlFlags.Has(IsFragment) {
+		if pb.FragmentOffset, err = readSdnv(bodyReader); err != nil {
+			return
+		}
+		if pb.TotalDataLength, err = readSdnv(bodyReader); err != nil {
+			return
+		}
+	}
+
+	if bodyReader.Len() > 0 {
+		err = fmt.Errorf("primary block has %d trailing bytes", bodyReader.Len())
+	}
+	return
+}
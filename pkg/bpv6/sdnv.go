@@ -0,0 +1,104 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package bpv6
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+)
+
+// byteReader reads single bytes from an io.Reader without buffering, as a buffer might consume the following bundle.
+type byteReader struct {
+	io.Reader
+	buff [1]byte
+}
+
+// newByteReader returns the io.Reader itself, if it is an io.ByteReader, or wraps it in a byteReader.
+func newByteReader(r io.Reader) interface {
+	io.Reader
+	io.ByteReader
+} {
+	if br, ok := r.(interface {
+		io.Reader
+		io.ByteReader
+	}); ok {
+		return br
+	}
+	return &byteReader{Reader: r}
+}
+
+// ReadByte reads a single byte.
+func (br *byteReader) ReadByte() (byte, error) {
+	if _, err := io.ReadFull(br.Reader, br.buff[:]); err != nil {
+		return 0, err
+	}
+	return br.buff[0], nil
+}
+
+// writeSdnv writes an unsigned integer as a Self-Delimiting Numeric Value (SDNV), RFC 5050, section 4.1.
+func writeSdnv(n uint64, w io.Writer) error {
+	var buff [10]byte
+	i := len(buff) - 1
+	buff[i] = byte(n & 0x7F)
+
+	for n >>= 7; n > 0; n >>= 7 {
+		i--
+		buff[i] = byte(n&0x7F) | 0x80
+	}
+
+	_, err := w.Write(buff[i:])
+	return err
+}
+
+// readSdnv reads a Self-Delimiting Numeric Value (SDNV), which must fit into an uint64.
+func readSdnv(r io.ByteReader) (n uint64, err error) {
+	for i := 0; i < 10; i++ {
+		var b byte
+		if b, err = r.ReadByte(); err != nil {
+			if err == io.EOF && i > 0 {
+				err = io.ErrUnexpectedEOF
+			}
+			return
+		}
+
+		if n > (1<<64-1)>>7 {
+			err = fmt.Errorf("SDNV exceeds 64 bit")
+			return
+		}
+
+		n = n<<7 | uint64(b&0x7F)
+		if b&0x80 == 0 {
+			return
+		}
+	}
+
+	err = fmt.Errorf("SDNV exceeds 64 bit")
+	return
+}
+
+// readBytes reads the next l bytes. Larger data is read into a growing buffer, mitigating resource exhaustion attacks
+// by announcing a huge length.
+func readBytes(l uint64, r io.Reader) ([]byte, error) {
+	if l <= 1024*1024 {
+		data := make([]byte, l)
+		_, err := io.ReadFull(r, data)
+		return data, err
+	}
+
+	if l > math.MaxInt64 {
+		return nil, fmt.Errorf("cannot read %d bytes", l)
+	}
+
+	var buff bytes.Buffer
+	if n, err := io.CopyN(&buff, r, int64(l)); err != nil {
+		if err == io.EOF && n > 0 {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	return buff.Bytes(), nil
+}
@@ -0,0 +1,55 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package bpv6
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestSdnv(t *testing.T) {
+	tests := []struct {
+		n    uint64
+		data []byte
+	}{
+		{0, []byte{0x00}},
+		{0x7F, []byte{0x7F}},
+		{0x80, []byte{0x81, 0x00}},
+		{0xABC, []byte{0x95, 0x3C}},
+		{0x1234, []byte{0xA4, 0x34}},
+		{0x4234, []byte{0x81, 0x84, 0x34}},
+		{1<<64 - 1, []byte{0x81, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0x7F}},
+	}
+
+	for _, test := range tests {
+		buff := new(bytes.Buffer)
+		if err := writeSdnv(test.n, buff); err != nil {
+			t.Fatal(err)
+		} else if !bytes.Equal(buff.Bytes(), test.data) {
+			t.Fatalf("expected %x for %d, got %x", test.data, test.n, buff.Bytes())
+		}
+
+		if n, err := readSdnv(buff); err != nil {
+			t.Fatal(err)
+		} else if n != test.n {
+			t.Fatalf("expected %d, got %d", test.n, n)
+		}
+	}
+}
+
+func TestSdnvInvalid(t *testing.T) {
+	tests := [][]byte{
+		{},
+		{0x81},
+		{0x82, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0x7F},
+		{0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x00},
+	}
+
+	for _, test := range tests {
+		if n, err := readSdnv(bytes.NewBuffer(test)); err == nil {
+			t.Fatalf("%x resulted in %d", test, n)
+		}
+	}
+}
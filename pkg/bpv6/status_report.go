@@ -0,0 +1,260 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package bpv6
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+const (
+	// adminRecordStatusReport is the administrative record type of a bundle status report, stored in the upper four
+	// bits of an administrative record's first byte, RFC 5050, section 6.1.
+	adminRecordStatusReport byte = 0x1
+	// adminRecordForFragment is the administrative record flag for records referencing a fragment, stored in the lower
+	// four bits of an administrative record's first byte.
+	adminRecordForFragment byte = 0x1
+)
+
+// StatusFlags of a StatusReport, indicating the reported bundle status, as defined in RFC 5050, section 6.1.1.
+type StatusFlags uint8
+
+const (
+	// ReportReceived reports the bundle's reception.
+	ReportReceived StatusFlags = 0x01
+	// ReportCustodyAccepted reports the acceptance of the bundle's custody.
+	ReportCustodyAccepted StatusFlags = 0x02
+	// ReportForwarded reports the bundle's forwarding.
+	ReportForwarded StatusFlags = 0x04
+	// ReportDelivered reports the bundle's delivery.
+	ReportDelivered StatusFlags = 0x08
+	// ReportDeleted reports the bundle's deletion.
+	ReportDeleted StatusFlags = 0x10
+)
+
+// statusFlagsOrder lists all StatusFlags in the order of their times within a StatusReport.
+var statusFlagsOrder = []StatusFlags{ReportReceived, ReportCustodyAccepted, ReportForwarded, ReportDelivered, ReportDeleted}
+
+// DtnTime of RFC 5050, section 4.5.1, consisting of the seconds and nanoseconds since the start of the year 2000 (UTC).
+type DtnTime struct {
+	Seconds     uint64
+	Nanoseconds uint64
+}
+
+// StatusReport is a BPv6 bundle status report, an administrative record defined in RFC 5050, section 6.1.1.
+type StatusReport struct {
+	StatusFlags StatusFlags
+	// ReasonCode shares the values 0 to 8 with bpv7.StatusReportReason.
+	ReasonCode uint8
+
+	// IsFragment indicates a referenced fragment, identified by its FragmentOffset and FragmentLength.
+	IsFragment     bool
+	FragmentOffset uint64
+	FragmentLength uint64
+
+	// Times of each status, only present for the set StatusFlags.
+	Times map[StatusFlags]DtnTime
+
+	CreationTimestamp CreationTimestamp
+	SourceNode        EndpointID
+}
+
+// ParseStatusReport from an administrative record's payload.
+func ParseStatusReport(r io.Reader) (sr StatusReport, err error) {
+	br := newByteReader(r)
+
+	header, headerErr := br.ReadByte()
+	if headerErr != nil {
+		err = headerErr
+		return
+	} else if recordType := header >> 4; recordType != adminRecordStatusReport {
+		err = fmt.Errorf("administrative record type %d is no status report", recordType)
+		return
+	}
+	sr.IsFragment = header&adminRecordForFragment != 0
+
+	var flags byte
+	if flags, err = br.ReadByte(); err != nil {
+		return
+	}
+	sr.StatusFlags = StatusFlags(flags)
+	if sr.ReasonCode, err = br.ReadByte(); err != nil {
+		return
+	}
+
+	if sr.IsFragment {
+		if sr.FragmentOffset, err = readSdnv(br); err != nil {
+			return
+		}
+		if sr.FragmentLength, err = readSdnv(br); err != nil {
+			return
+		}
+	}
+
+	sr.Times = make(map[StatusFlags]DtnTime)
+	for _, flag := range statusFlagsOrder {
+		if sr.StatusFlags&flag == 0 {
+			continue
+		}
+
+		var t DtnTime
+		if t.Seconds, err = readSdnv(br); err != nil {
+			return
+		}
+		if t.Nanoseconds, err = readSdnv(br); err != nil {
+			return
+		}
+		sr.Times[flag] = t
+	}
+
+	if sr.CreationTimestamp.Seconds, err = readSdnv(br); err != nil {
+		return
+	}
+	if sr.CreationTimestamp.Sequence, err = readSdnv(br); err != nil {
+		return
+	}
+
+	eidLen, eidLenErr := readSdnv(br)
+	if eidLenErr != nil {
+		err = eidLenErr
+		return
+	}
+	eid, eidErr := readBytes(eidLen, br)
+	if eidErr != nil {
+		err = eidErr
+		return
+	}
+	sr.SourceNode, err = NewEndpointID(string(eid))
+	return
+}
+
+// WriteStatusReport serializes this StatusReport as an administrative record's payload.
+func (sr StatusReport) WriteStatusReport(w io.Writer) error {
+	buff := new(bytes.Buffer)
+
+	header := adminRecordStatusReport << 4
+	if sr.IsFragment {
+		header |= adminRecordForFragment
+	}
+	_ = buff.WriteByte(header)
+	_ = buff.WriteByte(byte(sr.StatusFlags))
+	_ = buff.WriteByte(sr.ReasonCode)
+
+	if sr.IsFragment {
+		_ = writeSdnv(sr.FragmentOffset, buff)
+		_ = writeSdnv(sr.FragmentLength, buff)
+	}
+
+	for _, flag := range statusFlagsOrder {
+		if sr.StatusFlags&flag != 0 {
+			_ = writeSdnv(sr.Times[flag].Seconds, buff)
+			_ = writeSdnv(sr.Times[flag].Nanoseconds, buff)
+		}
+	}
+
+	_ = writeSdnv(sr.CreationTimestamp.Seconds, buff)
+	_ = writeSdnv(sr.CreationTimestamp.Sequence, buff)
+
+	source := sr.SourceNode.String()
+	_ = writeSdnv(uint64(len(source)), buff)
+	_, _ = buff.WriteString(source)
+
+	_, err := w.Write(buff.Bytes())
+	return err
+}
+
+// statusInformationFlags maps bpv7's StatusInformationPos to the StatusFlags.
+var statusInformationFlags = map[bpv7.StatusInformationPos]StatusFlags{
+	bpv7.ReceivedBundle:  ReportReceived,
+	bpv7.ForwardedBundle: ReportForwarded,
+	bpv7.DeliveredBundle: ReportDelivered,
+	bpv7.DeletedBundle:   ReportDeleted,
+}
+
+// ToBpv7 converts this StatusReport into a bpv7.StatusReport.
+//
+// As BPv7 lacks custody transfer, a custody acceptance is dropped. A report without any other status results in an
+// error. The fragment length is passed as the bpv7.BundleID's TotalDataLength.
+func (sr StatusReport) ToBpv7() (*bpv7.StatusReport, error) {
+	source, err := sr.SourceNode.toBpv7()
+	if err != nil {
+		return nil, err
+	}
+
+	report := &bpv7.StatusReport{
+		StatusInformation: make([]bpv7.BundleStatusItem, len(statusInformationFlags)),
+		ReportReason:      bpv7.StatusReportReason(sr.ReasonCode),
+		RefBundle: bpv7.BundleID{
+			SourceNode:      source,
+			Timestamp:       bpv7.NewCreationTimestamp(bpv7.DtnTime(sr.CreationTimestamp.Seconds*1000), sr.CreationTimestamp.Sequence),
+			IsFragment:      sr.IsFragment,
+			FragmentOffset:  sr.FragmentOffset,
+			TotalDataLength: sr.FragmentLength,
+		},
+	}
+
+	asserted := false
+	for sip, flag := range statusInformationFlags {
+		if sr.StatusFlags&flag == 0 {
+			report.StatusInformation[sip] = bpv7.NewBundleStatusItem(false)
+			continue
+		}
+
+		t := sr.Times[flag]
+		report.StatusInformation[sip] = bpv7.NewTimeReportingBundleStatusItem(bpv7.DtnTime(t.Seconds*1000 + t.Nanoseconds/1000000))
+		asserted = true
+	}
+
+	if !asserted {
+		return nil, fmt.Errorf("status report with flags %x has no status known to BPv7", sr.StatusFlags)
+	}
+	return report, nil
+}
+
+// StatusReportFromBpv7 converts a bpv7.StatusReport into a StatusReport.
+//
+// BPv7's reason codes unknown to BPv6, e.g., bpv7.HopLimitExceeded, become bpv7.NoInformation. Status times which
+// were not requested are reported as zero.
+func StatusReportFromBpv7(report *bpv7.StatusReport) (sr StatusReport, err error) {
+	if sr.SourceNode, err = endpointFromBpv7(report.RefBundle.SourceNode); err != nil {
+		return
+	}
+
+	if report.ReportReason <= bpv7.BlockUnintelligible {
+		sr.ReasonCode = uint8(report.ReportReason)
+	}
+
+	sr.IsFragment = report.RefBundle.IsFragment
+	sr.FragmentOffset = report.RefBundle.FragmentOffset
+	sr.FragmentLength = report.RefBundle.TotalDataLength
+
+	sr.CreationTimestamp = CreationTimestamp{
+		Seconds:  uint64(report.RefBundle.Timestamp.DtnTime()) / 1000,
+		Sequence: report.RefBundle.Timestamp.SequenceNumber(),
+	}
+
+	sr.Times = make(map[StatusFlags]DtnTime)
+	for _, sip := range report.StatusInformations() {
+		flag, ok := statusInformationFlags[sip]
+		if !ok {
+			continue
+		}
+
+		sr.StatusFlags |= flag
+		if item := report.StatusInformation[sip]; item.StatusRequested {
+			sr.Times[flag] = DtnTime{Seconds: uint64(item.Time) / 1000, Nanoseconds: uint64(item.Time) % 1000 * 1000000}
+		} else {
+			sr.Times[flag] = DtnTime{}
+		}
+	}
+
+	if sr.StatusFlags == 0 {
+		err = fmt.Errorf("status report asserts no status")
+	}
+	return
+}
@@ -0,0 +1,139 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package bpv6
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+func TestStatusReportSerialization(t *testing.T) {
+	sr := StatusReport{
+		StatusFlags:       ReportReceived | ReportDelivered,
+		ReasonCode:        0,
+		Times:             map[StatusFlags]DtnTime{ReportReceived: {Seconds: 1, Nanoseconds: 2}, ReportDelivered: {Seconds: 3}},
+		CreationTimestamp: CreationTimestamp{Seconds: 300, Sequence: 4},
+		SourceNode:        MustNewEndpointID("ipn:1.1"),
+	}
+	data := []byte{
+		0x10, 0x09, 0x00, // status report, received and delivered, no information
+		0x01, 0x02, // received time
+		0x03, 0x00, // delivered time
+		0x82, 0x2c, 0x04, // creation timestamp
+		0x07, 'i', 'p', 'n', ':', '1', '.', '1',
+	}
+
+	buff := new(bytes.Buffer)
+	if err := sr.WriteStatusReport(buff); err != nil {
+		t.Fatal(err)
+	} else if !bytes.Equal(buff.Bytes(), data) {
+		t.Fatalf("expected %x, got %x", data, buff.Bytes())
+	}
+
+	if sr2, err := ParseStatusReport(bytes.NewReader(data)); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(sr, sr2) {
+		t.Fatalf("expected %v, got %v", sr, sr2)
+	}
+
+	// Fragments are referenced by their offset and length.
+	sr.IsFragment, sr.FragmentOffset, sr.FragmentLength = true, 23, 42
+	buff.Reset()
+	if err := sr.WriteStatusReport(buff); err != nil {
+		t.Fatal(err)
+	} else if sr2, err := ParseStatusReport(buff); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(sr, sr2) {
+		t.Fatalf("expected %v, got %v", sr, sr2)
+	}
+
+	if _, err := ParseStatusReport(bytes.NewReader([]byte{0x20, 0x00})); err == nil {
+		t.Fatal("custody signal was parsed as a status report")
+	}
+}
+
+func TestConvertBpv7StatusReport(t *testing.T) {
+	now := bpv7.DtnTime(uint64(bpv7.DtnTimeNow()) / 1000 * 1000)
+
+	subject, err := bpv7.Builder().
+		Source("ipn:1.1").
+		Destination("ipn:2.1").
+		CreationTimestampTime(now.Time()).
+		Lifetime("10m").
+		BundleCtrlFlags(bpv7.RequestStatusTime | bpv7.StatusRequestDelivery).
+		PayloadBlock([]byte("hello world")).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b7, err := bpv7.Builder().
+		Source("ipn:2.0").
+		Destination("ipn:1.0").
+		CreationTimestampNow().
+		Lifetime("10m").
+		StatusReport(subject, bpv7.DeliveredBundle, bpv7.NoInformation, now).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b6, err := FromBpv7(b7)
+	if err != nil {
+		t.Fatal(err)
+	} else if !b6.PrimaryBlock.BundleControlFlags.Has(AdministrativeRecordPayload) {
+		t.Fatal("converted bundle is no administrative record")
+	}
+
+	payload, _ := b6.PayloadBlock()
+	sr, err := ParseStatusReport(bytes.NewReader(payload.Data))
+	if err != nil {
+		t.Fatal(err)
+	} else if sr.StatusFlags != ReportDelivered || sr.Times[ReportDelivered].Seconds != uint64(now)/1000 {
+		t.Fatalf("unexpected status report %v", sr)
+	} else if sr.SourceNode != MustNewEndpointID("ipn:1.1") || sr.CreationTimestamp.Seconds != uint64(now)/1000 {
+		t.Fatalf("status report references %v, %v", sr.SourceNode, sr.CreationTimestamp)
+	}
+
+	b7b, err := b6.ToBpv7()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ar, err := b7b.AdministrativeRecord()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected, _ := b7.AdministrativeRecord()
+	if !reflect.DeepEqual(ar, expected) {
+		t.Fatalf("expected %v, got %v", expected, ar)
+	}
+}
+
+func TestConvertBpv7StatusReportCustody(t *testing.T) {
+	sr := StatusReport{
+		StatusFlags:       ReportCustodyAccepted,
+		Times:             map[StatusFlags]DtnTime{ReportCustodyAccepted: {Seconds: 1}},
+		CreationTimestamp: CreationTimestamp{Seconds: 300, Sequence: 4},
+		SourceNode:        MustNewEndpointID("ipn:1.1"),
+	}
+
+	if _, err := sr.ToBpv7(); err == nil {
+		t.Fatal("custody acceptance was converted")
+	}
+
+	sr.StatusFlags |= ReportForwarded
+	sr.Times[ReportForwarded] = DtnTime{Seconds: 2, Nanoseconds: 500000000}
+	if report, err := sr.ToBpv7(); err != nil {
+		t.Fatal(err)
+	} else if sips := report.StatusInformations(); len(sips) != 1 || sips[0] != bpv7.ForwardedBundle {
+		t.Fatalf("unexpected status information %v", sips)
+	} else if tm := report.StatusInformation[bpv7.ForwardedBundle].Time; tm != 2500 {
+		t.Fatalf("unexpected forwarding time %d", tm)
+	}
+}
@@ -18,6 +18,9 @@ import (
 const (
 	// AdminRecordTypeStatusReport is the administrative record type code for a status report.
 	AdminRecordTypeStatusReport uint64 = 1
+
+	// AdminRecordTypeCustodySignal is the administrative record type code for a custody signal.
+	AdminRecordTypeCustodySignal uint64 = 4
 )
 
 // AdministrativeRecord describes an administrative record, e.g., a status report.
@@ -125,21 +128,23 @@ func GetAdministrativeRecordManager() *AdministrativeRecordManager {
 		administrativeRecordManager = NewAdministrativeRecordManager()
 
 		_ = administrativeRecordManager.Register(&StatusReport{})
+		_ = administrativeRecordManager.Register(&CustodySignal{})
 	}
 
 	return administrativeRecordManager
 }
 
 // NewAdministrativeRecordFromCbor creates a new AdministrativeRecord from a given byte array.
-// TODO: remove this function; replace by AdministrativeRecordManager
+//
+// Deprecated: Use the AdministrativeRecordManager's ReadAdministrativeRecord or Bundle.AdministrativeRecord instead.
 func NewAdministrativeRecordFromCbor(data []byte) (ar AdministrativeRecord, err error) {
-	buff := bytes.NewBuffer(data)
-	return GetAdministrativeRecordManager().ReadAdministrativeRecord(buff)
+	return GetAdministrativeRecordManager().ReadAdministrativeRecord(bytes.NewBuffer(data))
 }
 
 // AdministrativeRecordToCbor creates a canonical block, containing this administrative record. The surrounding
 // bundle _must_ have a set AdministrativeRecordPayload bundle processing control flag.
-// TODO: remove this function; replace by AdministrativeRecordManager
+//
+// Deprecated: Use BundleBuilder.AdministrativeRecord instead, which also sets this flag.
 func AdministrativeRecordToCbor(ar AdministrativeRecord) (blk CanonicalBlock, err error) {
 	buff := new(bytes.Buffer)
 	if err = GetAdministrativeRecordManager().WriteAdministrativeRecord(ar, buff); err != nil {
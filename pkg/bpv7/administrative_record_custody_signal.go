@@ -0,0 +1,104 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package bpv7
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/dtn7/cboring"
+)
+
+// CustodySignal is sent to a Bundle's custodian, as named in its CustodyTransferBlock, to report if custody was
+// accepted by another node. An accepted custody allows the former custodian to delete its copy of the Bundle.
+type CustodySignal struct {
+	Accepted   bool
+	Reason     StatusReportReason
+	SignalTime DtnTime
+	RefBundle  BundleID
+}
+
+// NewCustodySignal creates a CustodySignal for the given Bundle, indicating if custody was accepted. A refused
+// custody should be explained by a StatusReportReason.
+func NewCustodySignal(bndl Bundle, accepted bool, reason StatusReportReason, time DtnTime) *CustodySignal {
+	return &CustodySignal{
+		Accepted:   accepted,
+		Reason:     reason,
+		SignalTime: time,
+		RefBundle:  bndl.ID(),
+	}
+}
+
+// MarshalCbor writes the CBOR representation of a CustodySignal.
+func (cs *CustodySignal) MarshalCbor(w io.Writer) error {
+	if err := cboring.WriteArrayLength(3+cs.RefBundle.Len(), w); err != nil {
+		return err
+	}
+
+	if err := cboring.WriteBoolean(cs.Accepted, w); err != nil {
+		return err
+	}
+
+	for _, n := range []uint64{uint64(cs.Reason), uint64(cs.SignalTime)} {
+		if err := cboring.WriteUInt(n, w); err != nil {
+			return err
+		}
+	}
+
+	if err := cboring.Marshal(&cs.RefBundle, w); err != nil {
+		return fmt.Errorf("marshalling BundleID failed: %v", err)
+	}
+
+	return nil
+}
+
+// UnmarshalCbor reads a CBOR representation of a CustodySignal.
+func (cs *CustodySignal) UnmarshalCbor(r io.Reader) error {
+	if n, err := cboring.ReadArrayLength(r); err != nil {
+		return err
+	} else if n == 5 {
+		cs.RefBundle.IsFragment = false
+	} else if n == 7 {
+		cs.RefBundle.IsFragment = true
+	} else {
+		return fmt.Errorf("expected array of length 5 or 7, got %d", n)
+	}
+
+	if b, err := cboring.ReadBoolean(r); err != nil {
+		return err
+	} else {
+		cs.Accepted = b
+	}
+
+	if n, err := cboring.ReadUInt(r); err != nil {
+		return err
+	} else {
+		cs.Reason = StatusReportReason(n)
+	}
+
+	if n, err := cboring.ReadUInt(r); err != nil {
+		return err
+	} else {
+		cs.SignalTime = DtnTime(n)
+	}
+
+	if err := cboring.Unmarshal(&cs.RefBundle, r); err != nil {
+		return fmt.Errorf("unmarshalling BundleID failed: %v", err)
+	}
+
+	return nil
+}
+
+// RecordTypeCode returns this AdministrativeRecord's type code.
+func (cs *CustodySignal) RecordTypeCode() uint64 {
+	return AdminRecordTypeCustodySignal
+}
+
+func (cs CustodySignal) String() string {
+	if cs.Accepted {
+		return fmt.Sprintf("CustodySignal(accepted, %v, %v)", cs.SignalTime, cs.RefBundle)
+	}
+	return fmt.Sprintf("CustodySignal(refused, %v, %v, %v)", cs.Reason, cs.SignalTime, cs.RefBundle)
+}
@@ -0,0 +1,63 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package bpv7
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestCustodySignalCbor(t *testing.T) {
+	bndl, err := Builder().
+		Source("dtn://src/").
+		Destination("dtn://dest/").
+		CreationTimestampNow().
+		Lifetime("60s").
+		CustodyTransferBlock("dtn://src/").
+		PayloadBlock([]byte("hello world!")).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	frag := bndl
+	frag.PrimaryBlock.BundleControlFlags |= IsFragment
+	frag.PrimaryBlock.FragmentOffset = 6
+	frag.PrimaryBlock.TotalDataLength = 12
+
+	signals := []*CustodySignal{
+		NewCustodySignal(bndl, true, NoInformation, DtnTimeNow()),
+		NewCustodySignal(bndl, false, DepletedStorage, DtnTimeNow()),
+		NewCustodySignal(frag, true, NoInformation, DtnTimeNow()),
+	}
+
+	for _, signal := range signals {
+		buff := new(bytes.Buffer)
+		if err := GetAdministrativeRecordManager().WriteAdministrativeRecord(signal, buff); err != nil {
+			t.Fatal(err)
+		}
+
+		if ar, err := GetAdministrativeRecordManager().ReadAdministrativeRecord(buff); err != nil {
+			t.Fatal(err)
+		} else if !reflect.DeepEqual(ar, signal) {
+			t.Fatalf("expected %v, got %v", signal, ar)
+		}
+	}
+}
+
+func TestCustodyTransferBlockAdministrativeRecord(t *testing.T) {
+	_, err := Builder().
+		Source("dtn://src/").
+		Destination("dtn://dest/").
+		CreationTimestampNow().
+		Lifetime("60s").
+		CustodyTransferBlock("dtn://src/").
+		AdministrativeRecord(&CustodySignal{}).
+		Build()
+	if err == nil {
+		t.Fatal("administrative record with a Custody Transfer Block is valid")
+	}
+}
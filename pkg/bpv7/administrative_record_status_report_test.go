@@ -130,18 +130,12 @@ func TestStatusReportApplicationRecord(t *testing.T) {
 	statusRep := NewStatusReport(
 		bndl, ReceivedBundle, NoInformation, initTime)
 
-	adminRec, adminRecErr := AdministrativeRecordToCbor(statusRep)
-	if adminRecErr != nil {
-		t.Fatal(adminRecErr)
-	}
-
 	outBndl, err := Builder().
 		Source("dtn://foo/").
 		Destination(bndl.PrimaryBlock.ReportTo).
 		CreationTimestampNow().
 		Lifetime("60m").
-		BundleCtrlFlags(AdministrativeRecordPayload).
-		Canonical(adminRec).
+		AdministrativeRecord(statusRep).
 		Build()
 	if err != nil {
 		t.Fatalf("Creating new bundle failed: %v", err)
@@ -5,6 +5,7 @@
 package bpv7
 
 import (
+	"reflect"
 	"testing"
 )
 
@@ -18,6 +19,8 @@ func TestAdministrativeRecordManager_Register(t *testing.T) {
 	}{
 		{"1st status report", &StatusReport{}, false},
 		{"2nd status report", &StatusReport{}, true},
+		{"1st custody signal", &CustodySignal{}, false},
+		{"2nd custody signal", &CustodySignal{}, true},
 	}
 	for _, test := range tests {
 		if err := arm.Register(test.ar); (err != nil) != test.wantErr {
@@ -25,3 +28,30 @@ func TestAdministrativeRecordManager_Register(t *testing.T) {
 		}
 	}
 }
+
+func TestAdministrativeRecordCborDeprecated(t *testing.T) {
+	bndl, err := Builder().
+		Source("dtn://src/").
+		Destination("dtn://dst/").
+		CreationTimestampNow().
+		Lifetime("60m").
+		PayloadBlock([]byte("hello world")).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sr := NewStatusReport(bndl, ReceivedBundle, NoInformation, DtnTimeNow())
+
+	blk, err := AdministrativeRecordToCbor(sr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ar, err := NewAdministrativeRecordFromCbor(blk.Value.(*PayloadBlock).Data())
+	if err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(ar, sr) {
+		t.Fatalf("expected %v, got %v", sr, ar)
+	}
+}
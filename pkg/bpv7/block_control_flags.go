@@ -6,6 +6,7 @@ package bpv7
 
 import (
 	"encoding/json"
+	"fmt"
 	"strings"
 )
 
@@ -39,19 +40,20 @@ func (bcf BlockControlFlags) CheckValid() error {
 	return nil
 }
 
+// blockControlFlagNames are the string representations of the BlockControlFlags.
+var blockControlFlagNames = []struct {
+	field BlockControlFlags
+	text  string
+}{
+	{DeleteBundle, "DELETE_BUNDLE"},
+	{StatusReportBlock, "REQUEST_STATUS_REPORT"},
+	{RemoveBlock, "REMOVE_BLOCK"},
+	{ReplicateBlock, "REPLICATE_BLOCK"},
+}
+
 // Strings returns an array of all flags as a string representation.
 func (bcf BlockControlFlags) Strings() (fields []string) {
-	checks := []struct {
-		field BlockControlFlags
-		text  string
-	}{
-		{DeleteBundle, "DELETE_BUNDLE"},
-		{StatusReportBlock, "REQUEST_STATUS_REPORT"},
-		{RemoveBlock, "REMOVE_BLOCK"},
-		{ReplicateBlock, "REPLICATE_BLOCK"},
-	}
-
-	for _, check := range checks {
+	for _, check := range blockControlFlagNames {
 		if bcf.Has(check.field) {
 			fields = append(fields, check.text)
 		}
@@ -65,6 +67,32 @@ func (bcf BlockControlFlags) MarshalJSON() ([]byte, error) {
 	return json.Marshal(bcf.Strings())
 }
 
+// UnmarshalJSON reads a JSON array of control flags, as created by MarshalJSON.
+func (bcf *BlockControlFlags) UnmarshalJSON(data []byte) error {
+	var fields []string
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	*bcf = 0
+	for _, field := range fields {
+		known := false
+		for _, check := range blockControlFlagNames {
+			if check.text == field {
+				*bcf |= check.field
+				known = true
+				break
+			}
+		}
+
+		if !known {
+			return fmt.Errorf("unknown block control flag %q", field)
+		}
+	}
+
+	return nil
+}
+
 func (bcf BlockControlFlags) String() string {
 	return strings.Join(bcf.Strings(), ",")
 }
@@ -8,6 +8,7 @@ package bpv7
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"sort"
@@ -186,6 +187,36 @@ func (b *Bundle) SetCRCType(crcType CRCType) {
 	})
 }
 
+// SetCRCTypes sets distinct CRCTypes for the primary block and for all canonical blocks. Blocks encrypted by a BCB are
+// skipped, as their CRC was removed in favor of the BCB. A primary block always requires a CRC, see
+// PrimaryBlock.SetCRCType.
+func (b *Bundle) SetCRCTypes(primary, canonical CRCType) {
+	b.PrimaryBlock.SetCRCType(primary)
+
+	for i := range b.CanonicalBlocks {
+		if b.isEncryptedBlock(b.CanonicalBlocks[i].BlockNumber) {
+			continue
+		}
+		b.CanonicalBlocks[i].SetCRCType(canonical)
+	}
+}
+
+// CheckCRCPresence returns an error if some block lacks a CRC. Blocks encrypted by a BCB are exempted, as the BCB
+// replaces their CRC.
+func (b *Bundle) CheckCRCPresence() error {
+	if !b.PrimaryBlock.HasCRC() {
+		return fmt.Errorf("primary block has no CRC")
+	}
+
+	for _, cb := range b.CanonicalBlocks {
+		if !cb.HasCRC() && !b.isEncryptedBlock(cb.BlockNumber) {
+			return fmt.Errorf("canonical block %d (%s) has no CRC", cb.BlockNumber, cb.Value.BlockTypeName())
+		}
+	}
+
+	return nil
+}
+
 // ID returns a BundleID representing this Bundle.
 func (b Bundle) ID() BundleID {
 	return BundleID{
@@ -214,7 +245,7 @@ func (b Bundle) IsLifetimeExceeded() bool {
 
 	maxTimestamp := b.PrimaryBlock.CreationTimestamp.DtnTime().Time().Add(
 		time.Duration(b.PrimaryBlock.Lifetime) * time.Millisecond)
-	return time.Now().After(maxTimestamp)
+	return Now().After(maxTimestamp)
 }
 
 // CheckValid returns an array of errors for incorrect data.
@@ -300,12 +331,12 @@ func (b Bundle) AdministrativeRecord() (AdministrativeRecord, error) {
 		return nil, fmt.Errorf("bundle is not an administrative record")
 	}
 
-	payload, err := b.PayloadBlock()
+	payload, err := b.PayloadData()
 	if err != nil {
 		return nil, err
 	}
 
-	buff := bytes.NewBuffer(payload.Value.(*PayloadBlock).Data())
+	buff := bytes.NewBuffer(payload)
 	return GetAdministrativeRecordManager().ReadAdministrativeRecord(buff)
 }
 
@@ -342,10 +373,15 @@ func (b *Bundle) UnmarshalCbor(r io.Reader) error {
 		return fmt.Errorf("PrimaryBlock failed: %v", err)
 	}
 
+	var undecodableErrs []*undecodableBlockError
 	for {
 		cb := CanonicalBlock{}
+		var undecodableErr *undecodableBlockError
 		if err := cboring.Unmarshal(&cb, r); err == cboring.FlagBreakCode {
 			break
+		} else if errors.As(err, &undecodableErr) {
+			undecodableErrs = append(undecodableErrs, undecodableErr)
+			b.CanonicalBlocks = append(b.CanonicalBlocks, cb)
 		} else if err != nil {
 			return fmt.Errorf("CanonicalBlock failed: %v", err)
 		} else {
@@ -353,6 +389,13 @@ func (b *Bundle) UnmarshalCbor(r io.Reader) error {
 		}
 	}
 
+	// Only blocks encrypted by a BCB are allowed to be undecodable.
+	for _, undecodableErr := range undecodableErrs {
+		if !b.isEncryptedBlock(undecodableErr.blockNumber) {
+			return fmt.Errorf("CanonicalBlock failed: %v", undecodableErr)
+		}
+	}
+
 	return b.CheckValid()
 }
 
@@ -371,3 +414,36 @@ func (b Bundle) MarshalJSON() ([]byte, error) {
 		CanonicalBlocks: canonicals,
 	})
 }
+
+// UnmarshalJSON reads a JSON object of a Bundle, as created by MarshalJSON, and checks its validity.
+func (b *Bundle) UnmarshalJSON(data []byte) error {
+	var tmp struct {
+		PrimaryBlock    PrimaryBlock      `json:"primaryBlock"`
+		CanonicalBlocks []json.RawMessage `json:"canonicalBlocks"`
+	}
+	if err := json.Unmarshal(data, &tmp); err != nil {
+		return err
+	}
+
+	b.PrimaryBlock = tmp.PrimaryBlock
+	b.CanonicalBlocks = make([]CanonicalBlock, len(tmp.CanonicalBlocks))
+
+	var undecodableErrs []*undecodableBlockError
+	for i, raw := range tmp.CanonicalBlocks {
+		var undecodableErr *undecodableBlockError
+		if err := b.CanonicalBlocks[i].UnmarshalJSON(raw); errors.As(err, &undecodableErr) {
+			undecodableErrs = append(undecodableErrs, undecodableErr)
+		} else if err != nil {
+			return fmt.Errorf("CanonicalBlock failed: %v", err)
+		}
+	}
+
+	// Only blocks encrypted by a BCB are allowed to be undecodable.
+	for _, undecodableErr := range undecodableErrs {
+		if !b.isEncryptedBlock(undecodableErr.blockNumber) {
+			return fmt.Errorf("CanonicalBlock failed: %v", undecodableErr)
+		}
+	}
+
+	return b.CheckValid()
+}
@@ -419,6 +419,119 @@ func (bldr *BundleBuilder) PreviousNodeBlock(args ...interface{}) *BundleBuilder
 	return bldr.Canonical(NewPreviousNodeBlock(eid), flags)
 }
 
+// CustodyTransferBlock adds a custody transfer block to this bundle, requesting custody transfer. The parameters
+// are:
+//
+//	Custodian[, BlockControlFlags]
+//
+//	where Custodian is an EndpointID or a string describing an endpoint, e.g., dtn:none for a locally created
+//	bundle, and BlockControlFlags are _optional_ block processing control flags
+func (bldr *BundleBuilder) CustodyTransferBlock(args ...interface{}) *BundleBuilder {
+	if bldr.err != nil {
+		return bldr
+	}
+
+	eid, eidErr := bldrParseEndpoint(args[0])
+	if eidErr != nil {
+		bldr.err = eidErr
+	}
+
+	flags := bldr.canonicalParseFlags(args) | ReplicateBlock
+
+	return bldr.Canonical(NewCustodyTransferBlock(eid), flags)
+}
+
+// PriorityBlock adds a priority block to this bundle. The parameters are:
+//
+//	Priority[, BlockControlFlags]
+//
+//	where Priority is the bundle's Priority and
+//	BlockControlFlags are _optional_ block processing control flags
+func (bldr *BundleBuilder) PriorityBlock(args ...interface{}) *BundleBuilder {
+	if bldr.err != nil {
+		return bldr
+	}
+
+	var priority Priority
+	switch p := args[0].(type) {
+	case Priority:
+		priority = p
+	case string:
+		priority, bldr.err = ParsePriority(p)
+	default:
+		bldr.err = fmt.Errorf("PriorityBlock received wrong parameter type")
+	}
+
+	flags := bldr.canonicalParseFlags(args) | ReplicateBlock
+
+	return bldr.Canonical(NewPriorityBlock(priority), flags)
+}
+
+// AckRequestBlock adds an ack request block to this bundle, requesting an application acknowledgment from its
+// destination.
+func (bldr *BundleBuilder) AckRequestBlock() *BundleBuilder {
+	if bldr.err != nil {
+		return bldr
+	}
+
+	return bldr.Canonical(NewAckRequestBlock(), ReplicateBlock)
+}
+
+// TransitLogBlock adds an empty transit log block to this bundle, recording the path of forwarding nodes supporting
+// this block.
+func (bldr *BundleBuilder) TransitLogBlock() *BundleBuilder {
+	if bldr.err != nil {
+		return bldr
+	}
+
+	return bldr.Canonical(NewTransitLogBlock(), ReplicateBlock)
+}
+
+// RegionBlock adds a region block to this bundle, confining it to the routing area of the given name.
+func (bldr *BundleBuilder) RegionBlock(region string) *BundleBuilder {
+	if bldr.err != nil {
+		return bldr
+	}
+
+	return bldr.Canonical(NewRegionBlock(region), ReplicateBlock)
+}
+
+// SupersessionBlock adds a supersession block to this bundle, marking it as the version of an application's stream.
+func (bldr *BundleBuilder) SupersessionBlock(stream string, version uint64) *BundleBuilder {
+	if bldr.err != nil {
+		return bldr
+	}
+
+	return bldr.Canonical(NewSupersessionBlock(stream, version), ReplicateBlock)
+}
+
+// MetadataBlock adds a metadata block to this bundle, annotating it with the given key-value pairs.
+func (bldr *BundleBuilder) MetadataBlock(metadata map[string]string) *BundleBuilder {
+	if bldr.err != nil {
+		return bldr
+	}
+
+	return bldr.Canonical(NewMetadataBlock(metadata), ReplicateBlock)
+}
+
+// ContentBlock adds a content block to this bundle, naming its payload's content for caching nodes.
+func (bldr *BundleBuilder) ContentBlock(name string) *BundleBuilder {
+	if bldr.err != nil {
+		return bldr
+	}
+
+	return bldr.Canonical(NewContentBlock(name), ReplicateBlock)
+}
+
+// InterestBlock adds an interest block to this bundle, requesting the content of the given name.
+func (bldr *BundleBuilder) InterestBlock(name string) *BundleBuilder {
+	if bldr.err != nil {
+		return bldr
+	}
+
+	return bldr.Canonical(NewInterestBlock(name), ReplicateBlock)
+}
+
 // AdministrativeRecord configures an AdministrativeRecord as the Payload. Furthermore, the AdministrativeRecordPayload
 // BundleControlFlags is set.
 func (bldr *BundleBuilder) AdministrativeRecord(ar AdministrativeRecord) *BundleBuilder {
@@ -563,6 +676,81 @@ func BuildFromMap(m map[string]interface{}) (bndl Bundle, err error) {
 		case "previous_node_block":
 			bldr.PreviousNodeBlock(args)
 
+		// func (bldr *BundleBuilder) PriorityBlock(args ...interface{}) *BundleBuilder
+		case "priority_block":
+			bldr.PriorityBlock(args)
+
+		// func (bldr *BundleBuilder) CustodyTransferBlock(args ...interface{}) *BundleBuilder
+		case "custody_transfer_block":
+			bldr.CustodyTransferBlock(args)
+
+		// func (bldr *BundleBuilder) AckRequestBlock() *BundleBuilder
+		case "ack_request_block":
+			bldr.AckRequestBlock()
+
+		// func (bldr *BundleBuilder) TransitLogBlock() *BundleBuilder
+		case "transit_log_block":
+			bldr.TransitLogBlock()
+
+		// func (bldr *BundleBuilder) RegionBlock(region string) *BundleBuilder
+		case "region_block":
+			if region, ok := args.(string); ok {
+				bldr.RegionBlock(region)
+			} else {
+				err = fmt.Errorf("region_block expects a string, got %T", args)
+			}
+
+		// func (bldr *BundleBuilder) SupersessionBlock(stream string, version uint64) *BundleBuilder
+		case "supersession_block":
+			argsMap, ok := args.(map[string]interface{})
+			if !ok {
+				err = fmt.Errorf("supersession_block expects a map of stream and version, got %T", args)
+				break
+			}
+
+			stream, streamOk := argsMap["stream"].(string)
+			version, versionOk := argsMap["version"].(float64)
+			if !streamOk || !versionOk || version < 0 {
+				err = fmt.Errorf("supersession_block expects a string stream and a non-negative number version")
+			} else {
+				bldr.SupersessionBlock(stream, uint64(version))
+			}
+
+		// func (bldr *BundleBuilder) MetadataBlock(metadata map[string]string) *BundleBuilder
+		case "metadata_block":
+			argsMap, ok := args.(map[string]interface{})
+			if !ok {
+				err = fmt.Errorf("metadata_block expects a map of strings, got %T", args)
+				break
+			}
+
+			metadata := make(map[string]string, len(argsMap))
+			for key, value := range argsMap {
+				if metadata[key], ok = value.(string); !ok {
+					err = fmt.Errorf("metadata_block expects a string value for %s, got %T", key, value)
+					break
+				}
+			}
+			if err == nil {
+				bldr.MetadataBlock(metadata)
+			}
+
+		// func (bldr *BundleBuilder) ContentBlock(name string) *BundleBuilder
+		case "content_block":
+			if name, ok := args.(string); ok {
+				bldr.ContentBlock(name)
+			} else {
+				err = fmt.Errorf("content_block expects a string, got %T", args)
+			}
+
+		// func (bldr *BundleBuilder) InterestBlock(name string) *BundleBuilder
+		case "interest_block":
+			if name, ok := args.(string); ok {
+				bldr.InterestBlock(name)
+			} else {
+				err = fmt.Errorf("interest_block expects a string, got %T", args)
+			}
+
 		default:
 			err = fmt.Errorf("method %s is either not implemented or not existing", method)
 		}
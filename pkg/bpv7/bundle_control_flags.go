@@ -73,24 +73,25 @@ func (bcf BundleControlFlags) CheckValid() (errs error) {
 	return
 }
 
+// bundleControlFlagNames are the string representations of the BundleControlFlags.
+var bundleControlFlagNames = []struct {
+	field BundleControlFlags
+	text  string
+}{
+	{StatusRequestDeletion, "REQUESTED_DELETION_STATUS_REPORT"},
+	{StatusRequestDelivery, "REQUESTED_DELIVERY_STATUS_REPORT"},
+	{StatusRequestForward, "REQUESTED_FORWARD_STATUS_REPORT"},
+	{StatusRequestReception, "REQUESTED_RECEPTION_STATUS_REPORT"},
+	{RequestStatusTime, "REQUESTED_TIME_IN_STATUS_REPORT"},
+	{RequestUserApplicationAck, "REQUESTED_APPLICATION_ACK"},
+	{MustNotFragmented, "MUST_NOT_BE_FRAGMENTED"},
+	{AdministrativeRecordPayload, "ADMINISTRATIVE_PAYLOAD"},
+	{IsFragment, "IS_FRAGMENT"},
+}
+
 // Strings returns an array of all flags as a string representation.
 func (bcf BundleControlFlags) Strings() (fields []string) {
-	checks := []struct {
-		field BundleControlFlags
-		text  string
-	}{
-		{StatusRequestDeletion, "REQUESTED_DELETION_STATUS_REPORT"},
-		{StatusRequestDelivery, "REQUESTED_DELIVERY_STATUS_REPORT"},
-		{StatusRequestForward, "REQUESTED_FORWARD_STATUS_REPORT"},
-		{StatusRequestReception, "REQUESTED_RECEPTION_STATUS_REPORT"},
-		{RequestStatusTime, "REQUESTED_TIME_IN_STATUS_REPORT"},
-		{RequestUserApplicationAck, "REQUESTED_APPLICATION_ACK"},
-		{MustNotFragmented, "MUST_NOT_BE_FRAGMENTED"},
-		{AdministrativeRecordPayload, "ADMINISTRATIVE_PAYLOAD"},
-		{IsFragment, "IS_FRAGMENT"},
-	}
-
-	for _, check := range checks {
+	for _, check := range bundleControlFlagNames {
 		if bcf.Has(check.field) {
 			fields = append(fields, check.text)
 		}
@@ -104,6 +105,32 @@ func (bcf BundleControlFlags) MarshalJSON() ([]byte, error) {
 	return json.Marshal(bcf.Strings())
 }
 
+// UnmarshalJSON reads a JSON array of control flags, as created by MarshalJSON.
+func (bcf *BundleControlFlags) UnmarshalJSON(data []byte) error {
+	var fields []string
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	*bcf = 0
+	for _, field := range fields {
+		known := false
+		for _, check := range bundleControlFlagNames {
+			if check.text == field {
+				*bcf |= check.field
+				known = true
+				break
+			}
+		}
+
+		if !known {
+			return fmt.Errorf("unknown bundle control flag %q", field)
+		}
+	}
+
+	return nil
+}
+
 func (bcf BundleControlFlags) String() string {
 	return strings.Join(bcf.Strings(), ",")
 }
@@ -0,0 +1,40 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package bpv7
+
+// Copy returns a copy of this Bundle, whose blocks can be modified independently of the original ones, e.g., when the
+// same Bundle is processed multiple times.
+//
+// Payloads are shared, as they are replaced instead of being modified in place. All other blocks, which are usually
+// small, are copied by encoding and decoding them.
+func (b Bundle) Copy() (Bundle, error) {
+	c := b
+	c.CanonicalBlocks = make([]CanonicalBlock, len(b.CanonicalBlocks))
+
+	ebm := GetExtensionBlockManager()
+	for i, cb := range b.CanonicalBlocks {
+		c.CanonicalBlocks[i] = cb
+
+		switch value := cb.Value.(type) {
+		case *PayloadBlock, *StreamPayloadBlock:
+
+		case *GenericExtensionBlock:
+			// A GenericExtensionBlock might hold the encrypted data of a known block type, see DecryptBlocks.
+			data, _ := value.MarshalBinary()
+			c.CanonicalBlocks[i].Value = NewGenericExtensionBlock(append([]byte(nil), data...), value.BlockTypeCode())
+
+		default:
+			data, err := ebm.encodeBlock(value)
+			if err != nil {
+				return b, err
+			}
+			if c.CanonicalBlocks[i].Value, err = ebm.decodeBlock(value.BlockTypeCode(), data); err != nil {
+				return b, err
+			}
+		}
+	}
+
+	return c, nil
+}
@@ -0,0 +1,55 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package bpv7
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestBundleCopy(t *testing.T) {
+	b, err := Builder().
+		CRC(CRC32).
+		Source("dtn://src/").
+		Destination("dtn://dst/").
+		CreationTimestampNow().
+		Lifetime("10m").
+		HopCountBlock(64).
+		BundleAgeBlock(0).
+		PayloadBlock([]byte("hello world")).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := b.AddExtensionBlock(NewCanonicalBlock(0, 0, NewGenericExtensionBlock([]byte{0x23}, 192))); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := b.Copy()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buffB, buffC := new(bytes.Buffer), new(bytes.Buffer)
+	if err := b.WriteBundle(buffB); err != nil {
+		t.Fatal(err)
+	} else if err := c.WriteBundle(buffC); err != nil {
+		t.Fatal(err)
+	} else if !bytes.Equal(buffB.Bytes(), buffC.Bytes()) {
+		t.Fatal("serialization of copy differs")
+	}
+
+	hcBlock, err := c.ExtensionBlock(ExtBlockTypeHopCountBlock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hcBlock.Value.(*HopCountBlock).Increment()
+
+	if hcBlock, err := b.ExtensionBlock(ExtBlockTypeHopCountBlock); err != nil {
+		t.Fatal(err)
+	} else if hc := hcBlock.Value.(*HopCountBlock); hc.Count != 0 {
+		t.Fatalf("modifying the copy changed the original's hop count to %d", hc.Count)
+	}
+}
@@ -0,0 +1,137 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package bpv7
+
+// cborHeadSize is the length of a CBOR head for the argument n, e.g., an unsigned integer or a string's length.
+func cborHeadSize(n uint64) uint64 {
+	switch {
+	case n < 24:
+		return 1
+	case n < 1<<8:
+		return 2
+	case n < 1<<16:
+		return 3
+	case n < 1<<32:
+		return 5
+	default:
+		return 9
+	}
+}
+
+// crcSize is the length of a CRC value's CBOR byte string, or zero for CRCNo.
+func crcSize(crcType CRCType) uint64 {
+	switch crcType {
+	case CRC16:
+		return 1 + 2
+	case CRC32:
+		return 1 + 4
+	default:
+		return 0
+	}
+}
+
+// countingWriter is an io.Writer discarding all data, but counting its length.
+type countingWriter uint64
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	*cw += countingWriter(len(p))
+	return len(p), nil
+}
+
+// serializedSize is the length of this EndpointID's CBOR representation.
+func (eid EndpointID) serializedSize() uint64 {
+	switch et := eid.EndpointType.(type) {
+	case DtnEndpoint:
+		if et.IsDtnNone {
+			return 1 + 1 + 1
+		}
+		sspLen := uint64(len("//") + len(et.NodeName) + len("/") + len(et.Demux))
+		return 1 + 1 + cborHeadSize(sspLen) + sspLen
+
+	case IpnEndpoint:
+		return 1 + 1 + 1 + cborHeadSize(et.Node) + cborHeadSize(et.Service)
+
+	case nil:
+		return DtnNone().serializedSize()
+
+	default:
+		var cw countingWriter
+		_ = eid.MarshalCbor(&cw)
+		return uint64(cw)
+	}
+}
+
+// SerializedSize is the exact length of this PrimaryBlock's CBOR representation, calculated without serializing it.
+func (pb PrimaryBlock) SerializedSize() uint64 {
+	size := 1 + cborHeadSize(dtnVersion) + cborHeadSize(uint64(pb.BundleControlFlags)) + cborHeadSize(uint64(pb.CRCType))
+
+	for _, eid := range []EndpointID{pb.Destination, pb.SourceNode, pb.ReportTo} {
+		size += eid.serializedSize()
+	}
+
+	size += 1 + cborHeadSize(pb.CreationTimestamp[0]) + cborHeadSize(pb.CreationTimestamp[1])
+	size += cborHeadSize(pb.Lifetime)
+
+	if pb.HasFragmentation() {
+		size += cborHeadSize(pb.FragmentOffset) + cborHeadSize(pb.TotalDataLength)
+	}
+
+	if pb.HasCRC() {
+		size += crcSize(pb.CRCType)
+	}
+
+	return size
+}
+
+// blockDataSize is the length of an ExtensionBlock's block-type-specific data. Payload blocks are measured directly,
+// other blocks, which are usually small, are encoded.
+func blockDataSize(eb ExtensionBlock) (uint64, error) {
+	switch eb := eb.(type) {
+	case streamingBlock:
+		return eb.Len(), nil
+
+	case *PayloadBlock:
+		return uint64(len(eb.Data())), nil
+
+	default:
+		data, err := GetExtensionBlockManager().encodeBlock(eb)
+		return uint64(len(data)), err
+	}
+}
+
+// SerializedSize is the exact length of this CanonicalBlock's CBOR representation. The payload is not serialized.
+func (cb CanonicalBlock) SerializedSize() (uint64, error) {
+	dataLen, err := blockDataSize(cb.Value)
+	if err != nil {
+		return 0, err
+	}
+
+	size := 1 + cborHeadSize(cb.TypeCode()) + cborHeadSize(cb.BlockNumber) +
+		cborHeadSize(uint64(cb.BlockControlFlags)) + cborHeadSize(uint64(cb.CRCType))
+	size += cborHeadSize(dataLen) + dataLen
+
+	if cb.HasCRC() {
+		size += crcSize(cb.CRCType)
+	}
+
+	return size, nil
+}
+
+// SerializedSize is the exact length of this Bundle's CBOR representation, as written by MarshalCbor. It is calculated
+// without serializing the payload, e.g., to check if a Bundle fits into a contact or an MTU.
+func (b Bundle) SerializedSize() (uint64, error) {
+	// Indefinite-length array's start and its break code
+	size := uint64(2) + b.PrimaryBlock.SerializedSize()
+
+	for _, cb := range b.CanonicalBlocks {
+		if cbSize, err := cb.SerializedSize(); err != nil {
+			return 0, err
+		} else {
+			size += cbSize
+		}
+	}
+
+	return size, nil
+}
@@ -0,0 +1,96 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package bpv7
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+func TestBundleSerializedSize(t *testing.T) {
+	payloadSizes := []int{0, 23, 24, 255, 256, 65536}
+	crcTypes := []CRCType{CRCNo, CRC16, CRC32}
+	endpoints := [][2]string{
+		{"dtn://src/", "dtn://dst/foo"},
+		{"ipn:1.0", "ipn:4294967296.65536"},
+		{"dtn:none", "ipn:23.42"},
+	}
+
+	for _, payloadSize := range payloadSizes {
+		for _, crcType := range crcTypes {
+			for _, eids := range endpoints {
+				t.Run(fmt.Sprintf("%d-%v-%s", payloadSize, crcType, eids[0]), func(t *testing.T) {
+					bldr := Builder().
+						CRC(crcType).
+						Source(eids[0]).
+						Destination(eids[1]).
+						CreationTimestampNow().
+						Lifetime("10m").
+						HopCountBlock(64).
+						BundleAgeBlock(1000).
+						PayloadBlock(make([]byte, payloadSize))
+					if eids[0] == "dtn:none" {
+						bldr = bldr.BundleCtrlFlags(MustNotFragmented)
+					}
+
+					b, err := bldr.Build()
+					if err != nil {
+						t.Fatal(err)
+					}
+
+					bundles := []Bundle{b}
+					if payloadSize > 1024 && eids[0] != "dtn:none" {
+						if frags, err := b.Fragment(1024); err != nil {
+							t.Fatal(err)
+						} else {
+							bundles = append(bundles, frags...)
+						}
+					}
+
+					for _, bndl := range bundles {
+						buff := new(bytes.Buffer)
+						if err := bndl.MarshalCbor(buff); err != nil {
+							t.Fatal(err)
+						}
+
+						if size, err := bndl.SerializedSize(); err != nil {
+							t.Fatal(err)
+						} else if size != uint64(buff.Len()) {
+							t.Fatalf("expected size %d, got %d", buff.Len(), size)
+						}
+					}
+				})
+			}
+		}
+	}
+}
+
+func TestBundleSerializedSizeStream(t *testing.T) {
+	payload := []byte("hello world")
+
+	b, err := Builder().
+		CRC(CRC32).
+		Source("dtn://src/").
+		Destination("dtn://dst/").
+		CreationTimestampNow().
+		Lifetime("10m").
+		Canonical(NewStreamPayloadBlock(bytes.NewReader(payload), uint64(len(payload)))).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buff := new(bytes.Buffer)
+	if err := b.MarshalCbor(buff); err != nil {
+		t.Fatal(err)
+	}
+
+	if size, err := b.SerializedSize(); err != nil {
+		t.Fatal(err)
+	} else if size != uint64(buff.Len()) {
+		t.Fatalf("expected size %d, got %d", buff.Len(), size)
+	}
+}
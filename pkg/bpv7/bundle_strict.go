@@ -0,0 +1,88 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package bpv7
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+// DefaultClockTolerance is the maximum duration a Bundle's creation time might lie in the future for CheckStrict,
+// compensating for clock skew between nodes.
+const DefaultClockTolerance = 5 * time.Minute
+
+// strictSingletonBlocks are extension block types which must not occur more than once within a Bundle.
+var strictSingletonBlocks = []uint64{
+	ExtBlockTypePayloadBlock,
+	ExtBlockTypePreviousNodeBlock,
+	ExtBlockTypeBundleAgeBlock,
+	ExtBlockTypeHopCountBlock,
+}
+
+// CheckStrict performs CheckValid and additionally enforces further rules of RFC 9171, which are not required to
+// process a Bundle, but indicate a malformed one:
+//
+//   - canonical blocks must not use the primary block's number zero,
+//   - the payload block must have the block number one,
+//   - payload, previous node, bundle age, and hop count blocks must occur at most once,
+//   - a fragment's payload must lie within its total application data unit length, and
+//   - the lifetime must be positive and the creation time must not lie in the future, see DefaultClockTolerance.
+func (b Bundle) CheckStrict() error {
+	return b.CheckStrictClockTolerance(DefaultClockTolerance)
+}
+
+// CheckStrictClockTolerance performs CheckStrict, but allows the creation time to lie up to the given tolerance in the
+// future, e.g., to compensate a known clock skew of the Bundle's source.
+func (b Bundle) CheckStrictClockTolerance(tolerance time.Duration) (errs error) {
+	if err := b.CheckValid(); err != nil {
+		errs = multierror.Append(errs, err)
+	}
+
+	blockTypes := make(map[uint64]int)
+	for _, cb := range b.CanonicalBlocks {
+		blockTypes[cb.TypeCode()]++
+
+		if cb.BlockNumber == 0 {
+			errs = multierror.Append(errs, fmt.Errorf(
+				"Bundle: %s uses the primary block's number zero", cb.Value.BlockTypeName()))
+		}
+	}
+
+	for _, blockType := range strictSingletonBlocks {
+		if n := blockTypes[blockType]; n > 1 {
+			errs = multierror.Append(errs, fmt.Errorf("Bundle: block type %d occurred %d times", blockType, n))
+		}
+	}
+
+	if payloadBlock, err := b.PayloadBlock(); err == nil {
+		if payloadBlock.BlockNumber != 1 {
+			errs = multierror.Append(errs, fmt.Errorf(
+				"Bundle: payload block has block number %d instead of 1", payloadBlock.BlockNumber))
+		}
+
+		pb := b.PrimaryBlock
+		payloadLen := payloadBlockSize(payloadBlock.Value)
+		if pb.HasFragmentation() && !b.isEncryptedBlock(payloadBlock.BlockNumber) &&
+			(pb.TotalDataLength == 0 || pb.FragmentOffset+payloadLen > pb.TotalDataLength) {
+			errs = multierror.Append(errs, fmt.Errorf(
+				"Bundle: fragment of %d bytes at offset %d exceeds total data length of %d bytes",
+				payloadLen, pb.FragmentOffset, pb.TotalDataLength))
+		}
+	}
+
+	if b.PrimaryBlock.Lifetime == 0 {
+		errs = multierror.Append(errs, fmt.Errorf("Bundle: lifetime is zero"))
+	}
+
+	if ts := b.PrimaryBlock.CreationTimestamp; !ts.IsZeroTime() {
+		if created := ts.DtnTime().Time(); created.After(Now().Add(tolerance)) {
+			errs = multierror.Append(errs, fmt.Errorf("Bundle: creation time %v lies in the future", created))
+		}
+	}
+
+	return
+}
@@ -0,0 +1,89 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package bpv7
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBundleCheckStrict(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(b *Bundle)
+		valid  bool
+	}{
+		{"valid", func(_ *Bundle) {}, true},
+		{"payload block number", func(b *Bundle) {
+			pb, _ := b.PayloadBlock()
+			pb.BlockNumber = 23
+		}, false},
+		{"block number zero", func(b *Bundle) {
+			b.CanonicalBlocks[0].BlockNumber = 0
+		}, false},
+		{"duplicate hop count block", func(b *Bundle) {
+			b.CanonicalBlocks = append([]CanonicalBlock{NewCanonicalBlock(5, 0, NewHopCountBlock(64))},
+				b.CanonicalBlocks...)
+		}, false},
+		{"zero lifetime", func(b *Bundle) {
+			b.PrimaryBlock.Lifetime = 0
+		}, false},
+		{"future creation time", func(b *Bundle) {
+			b.PrimaryBlock.CreationTimestamp = NewCreationTimestamp(DtnTimeFromTime(time.Now().Add(time.Hour)), 0)
+		}, false},
+		{"fragment within total length", func(b *Bundle) {
+			b.PrimaryBlock.BundleControlFlags |= IsFragment
+			b.PrimaryBlock.FragmentOffset = 5
+			b.PrimaryBlock.TotalDataLength = 16
+		}, true},
+		{"fragment exceeds total length", func(b *Bundle) {
+			b.PrimaryBlock.BundleControlFlags |= IsFragment
+			b.PrimaryBlock.FragmentOffset = 6
+			b.PrimaryBlock.TotalDataLength = 16
+		}, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bndl, err := Builder().
+				Source("dtn://src/").
+				Destination("dtn://dst/").
+				CreationTimestampNow().
+				Lifetime("10m").
+				HopCountBlock(64).
+				PayloadBlock([]byte("hello world")).
+				Build()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			test.modify(&bndl)
+
+			if err := bndl.CheckStrict(); (err == nil) != test.valid {
+				t.Fatalf("expected valid = %t, got %v", test.valid, err)
+			}
+		})
+	}
+}
+
+func TestBundleCheckStrictClockTolerance(t *testing.T) {
+	bndl, err := Builder().
+		Source("dtn://src/").
+		Destination("dtn://dst/").
+		CreationTimestampTime(time.Now().Add(time.Hour)).
+		Lifetime("10m").
+		PayloadBlock([]byte("hello world")).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := bndl.CheckStrictClockTolerance(time.Minute); err == nil {
+		t.Fatal("creation time an hour ahead was accepted with a minute of tolerance")
+	}
+	if err := bndl.CheckStrictClockTolerance(2 * time.Hour); err != nil {
+		t.Fatalf("creation time an hour ahead was rejected with two hours of tolerance: %v", err)
+	}
+}
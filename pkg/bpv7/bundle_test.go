@@ -7,6 +7,7 @@ package bpv7
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"math/rand"
 	"reflect"
@@ -59,6 +60,59 @@ func TestBundleApplyCRC(t *testing.T) {
 	}
 }
 
+func TestBundleSetCRCTypes(t *testing.T) {
+	bndl, err := Builder().
+		Source("dtn://src/").
+		Destination("dtn://dst/").
+		CreationTimestampNow().
+		Lifetime("10m").
+		HopCountBlock(64).
+		PayloadBlock([]byte("hello world")).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bndl.SetCRCTypes(CRC16, CRCNo)
+	if ty := bndl.PrimaryBlock.GetCRCType(); ty != CRC16 {
+		t.Fatalf("primary block has CRC type %v, expected %v", ty, CRC16)
+	}
+	if err := bndl.CheckCRCPresence(); err == nil {
+		t.Fatal("bundle without canonical CRCs passed CheckCRCPresence")
+	}
+
+	bndl.SetCRCTypes(CRCNo, CRC32)
+	if ty := bndl.PrimaryBlock.GetCRCType(); ty != CRC32 {
+		t.Fatalf("primary block has CRC type %v, expected %v", ty, CRC32)
+	}
+	for _, cb := range bndl.CanonicalBlocks {
+		if ty := cb.GetCRCType(); ty != CRC32 {
+			t.Fatalf("canonical block %d has CRC type %v, expected %v", cb.BlockNumber, ty, CRC32)
+		}
+	}
+	if err := bndl.CheckCRCPresence(); err != nil {
+		t.Fatal(err)
+	}
+
+	key := make([]byte, 16)
+	if err := bndl.EncryptBlocks(MustNewEndpointID("dtn://src/"), key, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	bndl.SetCRCTypes(CRC32, CRC16)
+	if payload, err := bndl.PayloadBlock(); err != nil {
+		t.Fatal(err)
+	} else if payload.HasCRC() {
+		t.Fatal("encrypted payload block got a CRC")
+	}
+	if err := bndl.CheckCRCPresence(); err != nil {
+		t.Fatalf("encrypted payload block without CRC was not exempted: %v", err)
+	}
+	if err := bndl.DecryptBlocks(key); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestBundleCbor(t *testing.T) {
 	var epDest, _ = NewEndpointID("dtn://desty/")
 	var epSource, _ = NewEndpointID("dtn://gumo/")
@@ -109,6 +163,74 @@ func TestBundleCbor(t *testing.T) {
 	}
 }
 
+func TestBundleJson(t *testing.T) {
+	bndl, err := Builder().
+		CRC(CRC32).
+		Source("dtn://src/").
+		Destination("dtn://dst/").
+		CreationTimestampEpoch().
+		Lifetime("10m").
+		BundleCtrlFlags(StatusRequestDelivery | RequestStatusTime).
+		BundleAgeBlock(42).
+		HopCountBlock(64).
+		PreviousNodeBlock("dtn://prev/").
+		PayloadBlock([]byte("hello world")).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fragments, err := bndl.Fragment(128)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	encrypted, err := Builder().
+		Source("dtn://src/").
+		Destination("dtn://dst/").
+		CreationTimestampNow().
+		Lifetime("10m").
+		HopCountBlock(64).
+		PayloadBlock([]byte("hello world")).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := encrypted.EncryptBlocks(MustNewEndpointID("dtn://src/"), make([]byte, 32), 1); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, bndl1 := range append([]Bundle{bndl, encrypted}, fragments...) {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			jsonData, err := json.Marshal(bndl1)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var bndl2 Bundle
+			if err := json.Unmarshal(jsonData, &bndl2); err != nil {
+				t.Fatalf("unmarshalling %s failed: %v", jsonData, err)
+			}
+
+			cbor1, cbor2 := new(bytes.Buffer), new(bytes.Buffer)
+			if err := bndl1.MarshalCbor(cbor1); err != nil {
+				t.Fatal(err)
+			}
+			if err := bndl2.MarshalCbor(cbor2); err != nil {
+				t.Fatal(err)
+			}
+
+			if !bytes.Equal(cbor1.Bytes(), cbor2.Bytes()) {
+				t.Fatalf("CBOR representations differ after JSON round trip:\n- %x\n- %x", cbor1.Bytes(), cbor2.Bytes())
+			}
+		})
+	}
+
+	if err := encrypted.DecryptBlocks(make([]byte, 32)); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestBundleExtensionBlock(t *testing.T) {
 	var bndl, err = NewBundle(
 		NewPrimaryBlock(
@@ -333,3 +455,67 @@ func BenchmarkBundleDeserializationCboring(b *testing.B) {
 		}
 	}
 }
+
+// benchmarkBundle creates a bundle with typical extension blocks and a random payload of the given size.
+func benchmarkBundle(b *testing.B, size int) Bundle {
+	payload := make([]byte, size)
+	rand.Seed(0)
+	rand.Read(payload)
+
+	bndl, err := Builder().
+		CRC(CRC32).
+		Source("dtn://src/").
+		Destination("dtn://dest/").
+		CreationTimestampEpoch().
+		Lifetime("60m").
+		BundleAgeBlock(0).
+		HopCountBlock(64).
+		PreviousNodeBlock("dtn://prev/").
+		PayloadBlock(payload).
+		Build()
+	if err != nil {
+		b.Fatal(err)
+	}
+	return bndl
+}
+
+func BenchmarkWriteBundle(b *testing.B) {
+	for _, size := range []int{0, 1024, 1048576} {
+		bndl := benchmarkBundle(b, size)
+
+		b.Run(fmt.Sprintf("%d", size), func(b *testing.B) {
+			buff := new(bytes.Buffer)
+
+			b.SetBytes(int64(size))
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				buff.Reset()
+				if err := bndl.WriteBundle(buff); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkParseBundle(b *testing.B) {
+	for _, size := range []int{0, 1024, 1048576} {
+		bndl := benchmarkBundle(b, size)
+
+		buff := new(bytes.Buffer)
+		if err := bndl.WriteBundle(buff); err != nil {
+			b.Fatal(err)
+		}
+		data := buff.Bytes()
+
+		b.Run(fmt.Sprintf("%d", size), func(b *testing.B) {
+			b.SetBytes(int64(size))
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := ParseBundle(bytes.NewReader(data)); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -13,6 +13,8 @@ import (
 
 	"github.com/dtn7/cboring"
 	"github.com/hashicorp/go-multierror"
+
+	"github.com/dtn7/dtn7-go/pkg/internal/bufpool"
 )
 
 // CanonicalBlock represents the canonical bundle block defined in section 4.2.3.
@@ -62,9 +64,13 @@ func (cb *CanonicalBlock) MarshalCbor(w io.Writer) error {
 		blockLen = 6
 	}
 
-	crcBuff := new(bytes.Buffer)
+	// Calculate the CRC while writing to allow streaming blocks.
+	crcH, crcErr := newCRCHash(cb.CRCType)
+	if crcErr != nil {
+		return crcErr
+	}
 	if cb.HasCRC() {
-		w = io.MultiWriter(w, crcBuff)
+		w = io.MultiWriter(w, crcH)
 	}
 
 	if err := cboring.WriteArrayLength(blockLen, w); err != nil {
@@ -84,7 +90,7 @@ func (cb *CanonicalBlock) MarshalCbor(w io.Writer) error {
 	}
 
 	if cb.HasCRC() {
-		if crcVal, crcErr := calculateCRCBuff(crcBuff, cb.CRCType); crcErr != nil {
+		if crcVal, crcErr := crcH.sum(); crcErr != nil {
 			return crcErr
 		} else if err := cboring.WriteByteString(crcVal, w); err != nil {
 			return err
@@ -99,7 +105,7 @@ func (cb *CanonicalBlock) MarshalCbor(w io.Writer) error {
 // UnmarshalCbor creates this Canonical Block based on a CBOR representation.
 func (cb *CanonicalBlock) UnmarshalCbor(r io.Reader) error {
 	var blockLen uint64
-	if bl, err := cboring.ReadArrayLength(r); err != nil {
+	if bl, err := readArrayLength(r); err != nil {
 		return err
 	} else if bl != 5 && bl != 6 {
 		return fmt.Errorf("expected array with length 5 or 6, got %d", bl)
@@ -107,49 +113,77 @@ func (cb *CanonicalBlock) UnmarshalCbor(r io.Reader) error {
 		blockLen = bl
 	}
 
-	// Pipe incoming bytes into a separate CRC buffer
-	crcBuff := new(bytes.Buffer)
+	// Pipe the incoming header into a separate CRC buffer until the CRC type is known
+	src := r
+	crcBuff := bufpool.GetBuffer()
+	defer bufpool.PutBuffer(crcBuff)
 	if blockLen == 6 {
 		// Replay array's start
 		if err := cboring.WriteArrayLength(blockLen, crcBuff); err != nil {
 			return err
 		}
-		r = io.TeeReader(r, crcBuff)
+		r = newTeeByteReader(r, crcBuff)
 	}
 
 	var blockType uint64
-	if bt, err := cboring.ReadUInt(r); err != nil {
+	if bt, err := readUInt(r); err != nil {
 		return err
 	} else {
 		blockType = bt
 	}
 
-	if bn, err := cboring.ReadUInt(r); err != nil {
+	if bn, err := readUInt(r); err != nil {
 		return err
 	} else {
 		cb.BlockNumber = bn
 	}
 
-	if bcf, err := cboring.ReadUInt(r); err != nil {
+	if bcf, err := readUInt(r); err != nil {
 		return err
 	} else {
 		cb.BlockControlFlags = BlockControlFlags(bcf)
 	}
 
-	if crcT, err := cboring.ReadUInt(r); err != nil {
+	if crcT, err := readUInt(r); err != nil {
 		return err
 	} else {
 		cb.CRCType = CRCType(crcT)
 	}
 
-	if b, err := GetExtensionBlockManager().ReadBlock(blockType, r); err != nil {
+	// Continue calculating the CRC incrementally, allowing to stream the block-type-specific data
+	crcH, crcErr := newCRCHash(cb.CRCType)
+	if crcErr != nil {
+		return crcErr
+	}
+	if blockLen == 6 {
+		_, _ = crcH.Write(crcBuff.Bytes())
+		r = newTeeByteReader(src, crcH)
+	}
+
+	dataLen, dataLenErr := readByteStringLen(r)
+	if dataLenErr != nil {
+		return fmt.Errorf("unmarshalling block type %d failed: %v", blockType, dataLenErr)
+	}
+
+	var decodeErr error
+	if threshold, dir := PayloadStreamConfig(); blockType == ExtBlockTypePayloadBlock && threshold > 0 && dataLen > threshold {
+		if spb, err := newTempStreamPayloadBlock(r, dataLen, dir); err != nil {
+			return fmt.Errorf("unmarshalling block type %d failed: %v", blockType, err)
+		} else {
+			cb.Value = spb
+		}
+	} else if data, err := readRawBytes(dataLen, r); err != nil {
 		return fmt.Errorf("unmarshalling block type %d failed: %v", blockType, err)
+	} else if b, err := GetExtensionBlockManager().decodeBlock(blockType, data); err != nil {
+		// The block-type-specific data might be encrypted by a BCB. Thus, keep its raw data and let the Bundle decide.
+		cb.Value = NewGenericExtensionBlock(data, blockType)
+		decodeErr = &undecodableBlockError{blockNumber: cb.BlockNumber, err: fmt.Errorf("unmarshalling block type %d failed: %v", blockType, err)}
 	} else {
 		cb.Value = b
 	}
 
 	if blockLen == 6 {
-		if crcCalc, crcErr := calculateCRCBuff(crcBuff, cb.CRCType); crcErr != nil {
+		if crcCalc, crcErr := crcH.sum(); crcErr != nil {
 			return crcErr
 		} else if crcVal, err := cboring.ReadByteString(r); err != nil {
 			return err
@@ -160,7 +194,22 @@ func (cb *CanonicalBlock) UnmarshalCbor(r io.Reader) error {
 		}
 	}
 
-	return nil
+	return decodeErr
+}
+
+// undecodableBlockError is returned from CanonicalBlock.UnmarshalCbor if a known block's data could not be decoded.
+// The CanonicalBlock is still read completely and holds the raw data as a GenericExtensionBlock.
+type undecodableBlockError struct {
+	blockNumber uint64
+	err         error
+}
+
+func (e *undecodableBlockError) Error() string {
+	return e.err.Error()
+}
+
+func (e *undecodableBlockError) Unwrap() error {
+	return e.err
 }
 
 // MarshalJSON writes a JSON object for this Canonical Block.
@@ -183,15 +232,67 @@ func (cb CanonicalBlock) MarshalJSON() ([]byte, error) {
 		BlockType     string            `json:"blockType"`
 		ControlFlags  BlockControlFlags `json:"blockControlFlags"`
 		Data          interface{}       `json:"data"`
+		CRCType       CRCType           `json:"crcType"`
 	}{
 		BlockNumber:   cb.BlockNumber,
 		BlockType:     cb.Value.BlockTypeName(),
 		BlockTypeCode: cb.Value.BlockTypeCode(),
 		ControlFlags:  cb.BlockControlFlags,
 		Data:          dataField,
+		CRCType:       cb.CRCType,
 	})
 }
 
+// UnmarshalJSON reads a JSON object of a Canonical Block, as created by MarshalJSON.
+//
+// As for UnmarshalCbor, a known block failing to decode results in a GenericExtensionBlock and an error, which might
+// be inspected by the Bundle in case of an encrypted block.
+func (cb *CanonicalBlock) UnmarshalJSON(data []byte) error {
+	var tmp struct {
+		BlockNumber   uint64            `json:"blockNumber"`
+		BlockTypeCode uint64            `json:"blockTypeCode"`
+		ControlFlags  BlockControlFlags `json:"blockControlFlags"`
+		Data          json.RawMessage   `json:"data"`
+		CRCType       CRCType           `json:"crcType"`
+	}
+	if err := json.Unmarshal(data, &tmp); err != nil {
+		return err
+	}
+
+	cb.BlockNumber = tmp.BlockNumber
+	cb.BlockControlFlags = tmp.ControlFlags
+	cb.CRCType = tmp.CRCType
+	cb.CRC = nil
+
+	value := GetExtensionBlockManager().createBlock(tmp.BlockTypeCode)
+	if jsonValue, ok := value.(json.Unmarshaler); ok {
+		if err := jsonValue.UnmarshalJSON(tmp.Data); err != nil {
+			return fmt.Errorf("unmarshalling block type %d failed: %v", tmp.BlockTypeCode, err)
+		}
+		cb.Value = value
+		return nil
+	}
+
+	var cborData []byte
+	if err := json.Unmarshal(tmp.Data, &cborData); err != nil {
+		return fmt.Errorf("unmarshalling block type %d failed: %v", tmp.BlockTypeCode, err)
+	}
+
+	blockData, err := cboring.ReadByteString(bytes.NewBuffer(cborData))
+	if err != nil {
+		return fmt.Errorf("unmarshalling block type %d failed: %v", tmp.BlockTypeCode, err)
+	}
+
+	if b, err := GetExtensionBlockManager().decodeBlock(tmp.BlockTypeCode, blockData); err != nil {
+		cb.Value = NewGenericExtensionBlock(blockData, tmp.BlockTypeCode)
+		return &undecodableBlockError{blockNumber: cb.BlockNumber, err: fmt.Errorf("unmarshalling block type %d failed: %v", tmp.BlockTypeCode, err)}
+	} else {
+		cb.Value = b
+	}
+
+	return nil
+}
+
 // CheckValid returns an array of errors for incorrect data.
 func (cb CanonicalBlock) CheckValid() (errs error) {
 	if bcfErr := cb.BlockControlFlags.CheckValid(); bcfErr != nil {
@@ -0,0 +1,154 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package bpv7
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/dtn7/cboring"
+)
+
+// This file contains allocation-free variants of cboring's reading functions for the hot path of parsing bundles.
+// The cboring functions read each item through a temporary buffer, which escapes to the heap. If the io.Reader is
+// also an io.ByteReader, e.g., a bytes.Buffer, a bufio.Reader, or a teeByteReader, the variants below read byte by
+// byte instead. Otherwise, they fall back to cboring.
+
+// lengthReader is an in-memory io.Reader knowing its number of unread bytes, e.g., a bytes.Buffer or a bytes.Reader.
+type lengthReader interface {
+	io.Reader
+	Len() int
+}
+
+// readRawBytes reads the next l bytes, like cboring.ReadRawBytes. For a lengthReader holding enough data, the bytes are
+// read into a single, exactly sized slice. Otherwise, cboring's growing buffer protects against huge announced lengths.
+func readRawBytes(l uint64, r io.Reader) ([]byte, error) {
+	if lr, ok := r.(lengthReader); ok && lr.Len() >= 0 && l <= uint64(lr.Len()) {
+		data := make([]byte, l)
+		_, err := io.ReadFull(r, data)
+		return data, err
+	}
+
+	return cboring.ReadRawBytes(l, r)
+}
+
+// readMajors parses a (major) type definition, like cboring.ReadMajors.
+func readMajors(r io.Reader) (m cboring.MajorType, n uint64, err error) {
+	br, ok := r.(io.ByteReader)
+	if !ok {
+		return cboring.ReadMajors(r)
+	}
+
+	var b byte
+	if b, err = br.ReadByte(); err != nil {
+		return
+	}
+
+	switch b {
+	case cboring.IndefiniteArray:
+		err = cboring.FlagIndefiniteArray
+		return
+
+	case cboring.BreakCode:
+		err = cboring.FlagBreakCode
+		return
+	}
+
+	m, adds := b&0xE0, b&0x1F
+	switch {
+	case adds <= 23:
+		n = uint64(adds)
+
+	case adds <= 27:
+		for i := 0; i < 1<<(adds-24); i++ {
+			if b, err = br.ReadByte(); err != nil {
+				if err == io.EOF {
+					err = io.ErrUnexpectedEOF
+				}
+				return
+			}
+			n = n<<8 | uint64(b)
+		}
+
+	default:
+		err = fmt.Errorf("ReadMajors: Other additional information 0x%x", adds)
+	}
+
+	return
+}
+
+// readExpectMajors parses the next (major) type, which must equal the requested one, like cboring.ReadExpectMajors.
+func readExpectMajors(m cboring.MajorType, r io.Reader) (n uint64, err error) {
+	mTmp, n, err := readMajors(r)
+	if err == nil && m != mTmp {
+		err = fmt.Errorf("ReadExpectMajors: Wrong Major Type: 0x%x instead of 0x%x", m, mTmp)
+	}
+	return
+}
+
+// readUInt expects an unsigned integer, like cboring.ReadUInt.
+func readUInt(r io.Reader) (uint64, error) {
+	return readExpectMajors(cboring.UInt, r)
+}
+
+// readArrayLength expects an array and returns its length, like cboring.ReadArrayLength.
+func readArrayLength(r io.Reader) (uint64, error) {
+	return readExpectMajors(cboring.Array, r)
+}
+
+// readByteStringLen expects a byte string and returns its length, like cboring.ReadByteStringLen.
+func readByteStringLen(r io.Reader) (uint64, error) {
+	return readExpectMajors(cboring.ByteString, r)
+}
+
+// teeByteReader is an io.TeeReader, writing all read bytes into w, which is also an io.ByteReader.
+type teeByteReader struct {
+	r io.Reader
+	w io.Writer
+
+	buff [1]byte
+}
+
+// newTeeByteReader creates a teeByteReader, writing all bytes read from r into w.
+func newTeeByteReader(r io.Reader, w io.Writer) *teeByteReader {
+	return &teeByteReader{r: r, w: w}
+}
+
+// Read like an io.TeeReader.
+func (t *teeByteReader) Read(p []byte) (n int, err error) {
+	n, err = t.r.Read(p)
+	if n > 0 {
+		if wn, wErr := t.w.Write(p[:n]); wErr != nil {
+			return wn, wErr
+		}
+	}
+	return
+}
+
+// ReadByte reads and writes a single byte.
+func (t *teeByteReader) ReadByte() (byte, error) {
+	if br, ok := t.r.(io.ByteReader); ok {
+		b, err := br.ReadByte()
+		if err == nil {
+			t.buff[0] = b
+			_, err = t.w.Write(t.buff[:])
+		}
+		return b, err
+	}
+
+	if _, err := io.ReadFull(t, t.buff[:]); err != nil {
+		return 0, err
+	}
+	return t.buff[0], nil
+}
+
+// Len of the underlying io.Reader's unread bytes, if it is a lengthReader. Otherwise, -1 is returned, which is never
+// sufficient for readRawBytes.
+func (t *teeByteReader) Len() int {
+	if lr, ok := t.r.(lengthReader); ok {
+		return lr.Len()
+	}
+	return -1
+}
@@ -0,0 +1,86 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package bpv7
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"testing/iotest"
+
+	"github.com/dtn7/cboring"
+)
+
+func TestReadMajors(t *testing.T) {
+	values := []uint64{0, 1, 23, 24, 255, 256, 65535, 65536, 1<<32 - 1, 1 << 32, 1<<64 - 1}
+	majors := []cboring.MajorType{cboring.UInt, cboring.ByteString, cboring.Array}
+
+	for _, major := range majors {
+		for _, value := range values {
+			buff := new(bytes.Buffer)
+			if err := cboring.WriteMajors(major, value, buff); err != nil {
+				t.Fatal(err)
+			}
+			data := buff.Bytes()
+
+			// A bytes.Buffer is an io.ByteReader, an iotest.OneByteReader is not.
+			readers := []io.Reader{bytes.NewBuffer(data), iotest.OneByteReader(bytes.NewBuffer(data))}
+			for _, r := range readers {
+				if m, n, err := readMajors(r); err != nil {
+					t.Fatal(err)
+				} else if m != major || n != value {
+					t.Fatalf("expected (%x, %d), got (%x, %d)", major, value, m, n)
+				}
+			}
+
+			if _, _, err := readMajors(bytes.NewBuffer(data[:len(data)-1])); len(data) > 1 && err == nil {
+				t.Fatalf("truncated data %x resulted in no error", data[:len(data)-1])
+			}
+		}
+	}
+}
+
+func TestReadMajorsFlags(t *testing.T) {
+	tests := []struct {
+		data byte
+		err  error
+	}{
+		{cboring.IndefiniteArray, cboring.FlagIndefiniteArray},
+		{cboring.BreakCode, cboring.FlagBreakCode},
+	}
+
+	for _, test := range tests {
+		if _, _, err := readMajors(bytes.NewBuffer([]byte{test.data})); err != test.err {
+			t.Fatalf("expected %v, got %v", test.err, err)
+		}
+	}
+}
+
+func TestTeeByteReader(t *testing.T) {
+	data := []byte{0x82, 0x17, 0x18, 0x2A, 0x43, 0x01, 0x02, 0x03}
+
+	for _, src := range []io.Reader{bytes.NewBuffer(data), iotest.OneByteReader(bytes.NewBuffer(data))} {
+		tee := new(bytes.Buffer)
+		r := newTeeByteReader(src, tee)
+
+		if n, err := readArrayLength(r); err != nil || n != 2 {
+			t.Fatalf("expected array of length 2, got %d: %v", n, err)
+		}
+		for _, expected := range []uint64{23, 42} {
+			if n, err := readUInt(r); err != nil || n != expected {
+				t.Fatalf("expected %d, got %d: %v", expected, n, err)
+			}
+		}
+		if n, err := readByteStringLen(r); err != nil || n != 3 {
+			t.Fatalf("expected byte string of length 3, got %d: %v", n, err)
+		} else if b, err := readRawBytes(n, r); err != nil || !bytes.Equal(b, data[5:]) {
+			t.Fatalf("expected %x, got %x: %v", data[5:], b, err)
+		}
+
+		if !bytes.Equal(tee.Bytes(), data) {
+			t.Fatalf("expected tee %x, got %x", data, tee.Bytes())
+		}
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"bytes"
 	"encoding/binary"
 	"fmt"
+	"hash"
 	"hash/crc32"
 
 	"github.com/dtn7/cboring"
@@ -49,30 +50,72 @@ var (
 
 // calculateCRCBuff calculates a block's CRC value for serialization.
 func calculateCRCBuff(buff *bytes.Buffer, crcType CRCType) ([]byte, error) {
-	// Append CRC type's empty bytes
-	data, typeErr := emptyCRC(crcType)
-	if typeErr != nil {
-		return nil, typeErr
-	}
-
-	if err := cboring.WriteByteString(data, buff); err != nil {
+	h, err := newCRCHash(crcType)
+	if err != nil {
 		return nil, err
 	}
 
-	// Write CRC value for buff's data into data
+	_, _ = h.Write(buff.Bytes())
+	return h.sum()
+}
+
+// crcHash calculates a block's CRC value incrementally, e.g., while streaming a block's data.
+type crcHash struct {
+	crcType CRCType
+	crc16   crc16.Hash16
+	crc32   hash.Hash32
+}
+
+// newCRCHash creates a crcHash for a CRCType. For CRCNo, no CRC value will be calculated.
+func newCRCHash(crcType CRCType) (*crcHash, error) {
+	h := &crcHash{crcType: crcType}
+
 	switch crcType {
 	case CRCNo:
 
 	case CRC16:
-		binary.BigEndian.PutUint16(data, crc16.Checksum(buff.Bytes(), crc16table))
+		h.crc16 = crc16.New(crc16table)
 
 	case CRC32:
-		binary.BigEndian.PutUint32(data, crc32.Checksum(buff.Bytes(), crc32table))
+		h.crc32 = crc32.New(crc32table)
 
 	default:
 		return nil, fmt.Errorf("unknown CRCType %d", crcType)
 	}
 
+	return h, nil
+}
+
+// Write more of a block's serialized data into the crcHash.
+func (h *crcHash) Write(p []byte) (int, error) {
+	switch h.crcType {
+	case CRC16:
+		return h.crc16.Write(p)
+	case CRC32:
+		return h.crc32.Write(p)
+	default:
+		return len(p), nil
+	}
+}
+
+// sum finishes the CRC calculation by appending the CRC type's empty bytes and returns the CRC value.
+func (h *crcHash) sum() ([]byte, error) {
+	data, typeErr := emptyCRC(h.crcType)
+	if typeErr != nil {
+		return nil, typeErr
+	}
+
+	if err := cboring.WriteByteString(data, h); err != nil {
+		return nil, err
+	}
+
+	switch h.crcType {
+	case CRC16:
+		binary.BigEndian.PutUint16(data, h.crc16.Sum16())
+	case CRC32:
+		binary.BigEndian.PutUint32(data, h.crc32.Sum32())
+	}
+
 	return data, nil
 }
 
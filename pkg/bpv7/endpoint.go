@@ -9,7 +9,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"reflect"
 	"regexp"
 	"sync"
 
@@ -44,8 +43,8 @@ type EndpointType interface {
 }
 
 type endpointManager struct {
-	typeMap map[uint64]reflect.Type
-	newMap  map[string]func(string) (EndpointType, error)
+	unmarshalMap map[uint64]func(io.Reader) (EndpointType, error)
+	newMap       map[string]func(string) (EndpointType, error)
 }
 
 var (
@@ -59,8 +58,8 @@ func getEndpointManager() *endpointManager {
 
 	if endpointMngr == nil {
 		endpointMngr = &endpointManager{
-			typeMap: make(map[uint64]reflect.Type),
-			newMap:  make(map[string]func(string) (EndpointType, error)),
+			unmarshalMap: make(map[uint64]func(io.Reader) (EndpointType, error)),
+			newMap:       make(map[string]func(string) (EndpointType, error)),
 		}
 
 		epTypes := []struct {
@@ -68,13 +67,14 @@ func getEndpointManager() *endpointManager {
 			schemeName string
 			impl       interface{}
 			newFunc    func(string) (EndpointType, error)
+			unmarshal  func(io.Reader) (EndpointType, error)
 		}{
-			{dtnEndpointSchemeNo, dtnEndpointSchemeName, DtnEndpoint{}, NewDtnEndpoint},
-			{ipnEndpointSchemeNo, ipnEndpointSchemeName, IpnEndpoint{}, NewIpnEndpoint},
+			{dtnEndpointSchemeNo, dtnEndpointSchemeName, DtnEndpoint{}, NewDtnEndpoint, unmarshalDtnEndpoint},
+			{ipnEndpointSchemeNo, ipnEndpointSchemeName, IpnEndpoint{}, NewIpnEndpoint, unmarshalIpnEndpoint},
 		}
 
 		for _, epType := range epTypes {
-			endpointMngr.typeMap[epType.schemeNo] = reflect.TypeOf(epType.impl)
+			endpointMngr.unmarshalMap[epType.schemeNo] = epType.unmarshal
 			endpointMngr.newMap[epType.schemeName] = epType.newFunc
 			gob.Register(epType.impl)
 		}
@@ -89,10 +89,12 @@ type EndpointID struct {
 	EndpointType EndpointType
 }
 
+// endpointURIRegexp matches an URI's scheme name.
+var endpointURIRegexp = regexp.MustCompile("^([[:alnum:]]+):.+$")
+
 // NewEndpointID based on an URI, e.g., "dtn://seven/".
 func NewEndpointID(uri string) (e EndpointID, err error) {
-	re := regexp.MustCompile("^([[:alnum:]]+):.+$")
-	matches := re.FindStringSubmatch(uri)
+	matches := endpointURIRegexp.FindStringSubmatch(uri)
 
 	if len(matches) == 0 {
 		err = fmt.Errorf("given URI does not match URI regexp")
@@ -144,30 +146,28 @@ func (eid *EndpointID) MarshalCbor(w io.Writer) error {
 
 // UnmarshalCbor creates this Endpoint ID based on a CBOR representation.
 func (eid *EndpointID) UnmarshalCbor(r io.Reader) error {
-	if l, err := cboring.ReadArrayLength(r); err != nil {
+	if l, err := readArrayLength(r); err != nil {
 		return err
 	} else if l != 2 {
 		return fmt.Errorf("EndpointID expects array of 2 elements, not %d", l)
 	}
 
-	var epType reflect.Type
+	var unmarshal func(io.Reader) (EndpointType, error)
 
 	// URI scheme name code
-	if scheme, err := cboring.ReadUInt(r); err != nil {
+	if scheme, err := readUInt(r); err != nil {
 		return err
-	} else if ept, ok := getEndpointManager().typeMap[scheme]; !ok {
+	} else if f, ok := getEndpointManager().unmarshalMap[scheme]; !ok {
 		return fmt.Errorf("no URI scheme registered for scheme number %d", scheme)
 	} else {
-		epType = ept
+		unmarshal = f
 	}
 
 	// SSP
-	tmpEt := reflect.New(epType)
-	tmpEtUnmarshalCbor := tmpEt.MethodByName("UnmarshalCbor")
-	if err := tmpEtUnmarshalCbor.Call([]reflect.Value{reflect.ValueOf(r)})[0].Interface(); err != nil {
-		return err.(error)
+	if et, err := unmarshal(r); err != nil {
+		return err
 	} else {
-		eid.EndpointType = tmpEt.Elem().Interface().(EndpointType)
+		eid.EndpointType = et
 	}
 
 	return nil
@@ -178,6 +178,22 @@ func (eid EndpointID) MarshalJSON() ([]byte, error) {
 	return json.Marshal(eid.String())
 }
 
+// UnmarshalJSON reads the JSON representation of an EndpointID, as created by MarshalJSON.
+func (eid *EndpointID) UnmarshalJSON(data []byte) error {
+	var uri string
+	if err := json.Unmarshal(data, &uri); err != nil {
+		return err
+	}
+
+	e, err := NewEndpointID(uri)
+	if err != nil {
+		return err
+	}
+
+	*eid = e
+	return nil
+}
+
 // Authority is the authority part of the Endpoint URI, e.g., "foo" for "dtn://foo/bar".
 func (eid EndpointID) Authority() string {
 	return eid.EndpointType.Authority()
@@ -210,6 +226,25 @@ func (eid EndpointID) SameNode(other EndpointID) bool {
 	}
 }
 
+// NodeID of the node this singleton Endpoint belongs to, e.g., "dtn://foo/" for "dtn://foo/bar" or "ipn:23.0" for
+// "ipn:23.42". Thus, bundles can be routed on the node part, while being delivered on the complete Endpoint. Other
+// Endpoints, e.g., "dtn:none" or non-singletons, are returned unchanged.
+func (eid EndpointID) NodeID() EndpointID {
+	switch et := eid.EndpointType.(type) {
+	case DtnEndpoint:
+		if et.IsDtnNone || !et.IsSingleton() {
+			return eid
+		}
+		return EndpointID{DtnEndpoint{NodeName: et.NodeName}}
+
+	case IpnEndpoint:
+		return EndpointID{IpnEndpoint{Node: et.Node}}
+
+	default:
+		return eid
+	}
+}
+
 // CheckValid returns an array of errors for incorrect data.
 func (eid EndpointID) CheckValid() error {
 	if eid.EndpointType == nil {
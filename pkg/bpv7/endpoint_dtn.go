@@ -23,6 +23,11 @@ const (
 	dtnEndpointRegexpFull = "^" + dtnEndpointSchemeName + ":(none|" + dtnEndpointRegexpSsp + ")$"
 )
 
+var (
+	dtnEndpointSspRegexp  = regexp.MustCompile("^" + dtnEndpointRegexpSsp + "$")
+	dtnEndpointFullRegexp = regexp.MustCompile(dtnEndpointRegexpFull)
+)
+
 // DtnEndpoint describes the dtn URI for EndpointIDs, as defined in ietf-dtn-bpbis.
 //
 //	Format of a "normal" dtn URI:
@@ -49,13 +54,11 @@ func parseDtnSsp(ssp string) (nodeName, demux string, isDtnNone bool, err error)
 		return
 	}
 
-	re := regexp.MustCompile("^" + dtnEndpointRegexpSsp + "$")
-	if !re.MatchString(ssp) {
+	switch submatches := dtnEndpointSspRegexp.FindStringSubmatch(ssp); len(submatches) {
+	case 0:
 		err = fmt.Errorf("ssp does not match a dtn endpoint")
 		return
-	}
 
-	switch submatches := re.FindStringSubmatch(ssp); len(submatches) {
 	case 2:
 		nodeName = submatches[1]
 		demux = ""
@@ -134,7 +137,7 @@ func (e DtnEndpoint) IsSingleton() bool {
 
 // CheckValid returns an error for incorrect data.
 func (e DtnEndpoint) CheckValid() (err error) {
-	if !regexp.MustCompile(dtnEndpointRegexpFull).MatchString(e.String()) {
+	if !dtnEndpointFullRegexp.MatchString(e.String()) {
 		err = fmt.Errorf("dtn URI does not match regexp")
 	}
 	return
@@ -144,7 +147,7 @@ func (e DtnEndpoint) String() string {
 	if e.IsDtnNone {
 		return dtnEndpointDtnNone
 	} else {
-		return fmt.Sprintf("%s://%s/%s", dtnEndpointSchemeName, e.NodeName, e.Demux)
+		return dtnEndpointSchemeName + "://" + e.NodeName + "/" + e.Demux
 	}
 }
 
@@ -153,14 +156,13 @@ func (e DtnEndpoint) MarshalCbor(w io.Writer) error {
 	if e.IsDtnNone {
 		return cboring.WriteUInt(0, w)
 	} else {
-		ssp := fmt.Sprintf("//%s/%s", e.NodeName, e.Demux)
-		return cboring.WriteTextString(ssp, w)
+		return cboring.WriteTextString("//"+e.NodeName+"/"+e.Demux, w)
 	}
 }
 
 // UnmarshalCbor reads a CBOR representation.
 func (e *DtnEndpoint) UnmarshalCbor(r io.Reader) error {
-	if m, n, err := cboring.ReadMajors(r); err != nil {
+	if m, n, err := readMajors(r); err != nil {
 		return err
 	} else {
 		switch m {
@@ -170,7 +172,7 @@ func (e *DtnEndpoint) UnmarshalCbor(r io.Reader) error {
 
 		case cboring.TextString:
 			// dtn://node-name/[demux]
-			if ssp, err := cboring.ReadRawBytes(n, r); err != nil {
+			if ssp, err := readRawBytes(n, r); err != nil {
 				return err
 			} else if nodeName, demux, isDtnNode, parseErr := parseDtnSsp(string(ssp)); parseErr != nil {
 				return parseErr
@@ -190,6 +192,13 @@ func (e *DtnEndpoint) UnmarshalCbor(r io.Reader) error {
 	return nil
 }
 
+// unmarshalDtnEndpoint reads a DtnEndpoint's CBOR representation for the endpointManager.
+func unmarshalDtnEndpoint(r io.Reader) (EndpointType, error) {
+	var e DtnEndpoint
+	err := e.UnmarshalCbor(r)
+	return e, err
+}
+
 // DtnNone returns a new instance of the null endpoint "dtn:none".
 func DtnNone() EndpointID {
 	return EndpointID{DtnEndpoint{IsDtnNone: true}}
@@ -18,7 +18,10 @@ const (
 	ipnEndpointSchemeNo   uint64 = 2
 )
 
-// IpnEndpoint describes the ipn URI for EndpointIDs, as defined in RFC 6260.
+// ipnEndpointRegexp matches the textual representation of an ipn URI, "ipn:" node number "." service number.
+var ipnEndpointRegexp = regexp.MustCompile("^" + ipnEndpointSchemeName + ":(\\d+)\\.(\\d+)$")
+
+// IpnEndpoint describes the ipn URI for EndpointIDs, as defined in RFC 6260 and updated by RFC 9171.
 type IpnEndpoint struct {
 	Node    uint64
 	Service uint64
@@ -26,13 +29,12 @@ type IpnEndpoint struct {
 
 // NewIpnEndpoint from an URI with the ipn scheme.
 func NewIpnEndpoint(uri string) (e EndpointType, err error) {
-	// As defined in RFC 6260, section 2.1:
+	// As defined in RFC 6260, section 2.1, and RFC 9171, section 4.2.5.1.2:
 	// - node number: ASCII numeric digits between 1 and (2^64-1)
 	// - an ASCII dot
-	// - service number: ASCII numeric digits between 1 and (2^64-1)
+	// - service number: ASCII numeric digits between 0 and (2^64-1)
 
-	re := regexp.MustCompile("^" + ipnEndpointSchemeName + ":(\\d+)\\.(\\d+)$")
-	matches := re.FindStringSubmatch(uri)
+	matches := ipnEndpointRegexp.FindStringSubmatch(uri)
 	if len(matches) != 3 {
 		err = fmt.Errorf("uri does not match an ipn endpoint")
 		return
@@ -80,9 +82,11 @@ func (_ IpnEndpoint) IsSingleton() bool {
 }
 
 // CheckValid returns an array of errors for incorrect data.
+//
+// The service number zero is allowed, as RFC 9171 uses "ipn:N.0" as the administrative endpoint and node ID of node N.
 func (e IpnEndpoint) CheckValid() error {
-	if e.Node < 1 || e.Service < 1 {
-		return fmt.Errorf("ipn's node and Service number must be >= 1")
+	if e.Node < 1 {
+		return fmt.Errorf("ipn's node number must be >= 1")
 	}
 
 	return nil
@@ -109,14 +113,14 @@ func (e IpnEndpoint) MarshalCbor(w io.Writer) error {
 
 // UnmarshalCbor reads a CBOR representation for an IpnEndpoint.
 func (e *IpnEndpoint) UnmarshalCbor(r io.Reader) error {
-	if n, err := cboring.ReadArrayLength(r); err != nil {
+	if n, err := readArrayLength(r); err != nil {
 		return err
 	} else if n != 2 {
 		return fmt.Errorf("ipn uri expected array of 2 elements, not %d", n)
 	}
 
 	for _, n := range []*uint64{&e.Node, &e.Service} {
-		if i, err := cboring.ReadUInt(r); err != nil {
+		if i, err := readUInt(r); err != nil {
 			return err
 		} else {
 			*n = i
@@ -125,3 +129,10 @@ func (e *IpnEndpoint) UnmarshalCbor(r io.Reader) error {
 
 	return nil
 }
+
+// unmarshalIpnEndpoint reads an IpnEndpoint's CBOR representation for the endpointManager.
+func unmarshalIpnEndpoint(r io.Reader) (EndpointType, error) {
+	var e IpnEndpoint
+	err := e.UnmarshalCbor(r)
+	return e, err
+}
@@ -20,7 +20,8 @@ func TestNewIpnEndpoint(t *testing.T) {
 		{"ipn:1.1", 1, 1, true},
 		{"ipn:23.42", 23, 42, true},
 		{"ipn:0.1", 0, 0, false},
-		{"ipn:1.0", 0, 0, false},
+		{"ipn:1.0", 1, 0, true},
+		{"ipn:0.0", 0, 0, false},
 		{"ipn:99999999999999999999.1", 0, 0, false},
 		{"ipn:11", 0, 0, false},
 		{"ipn1.1", 0, 0, false},
@@ -49,6 +50,7 @@ func TestIpnEndpointCbor(t *testing.T) {
 	}{
 		{IpnEndpoint{1, 1}, []byte{0x82, 0x01, 0x01}},
 		{IpnEndpoint{23, 42}, []byte{0x82, 0x17, 0x18, 0x2A}},
+		{IpnEndpoint{23, 0}, []byte{0x82, 0x17, 0x00}},
 	}
 
 	for _, test := range tests {
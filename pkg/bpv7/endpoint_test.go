@@ -38,7 +38,7 @@ func TestEndpointCheckValid(t *testing.T) {
 		{EndpointID{&DtnEndpoint{IsDtnNone: true}}, true},
 		{EndpointID{&IpnEndpoint{0, 0}}, false},
 		{EndpointID{&IpnEndpoint{0, 1}}, false},
-		{EndpointID{&IpnEndpoint{1, 0}}, false},
+		{EndpointID{&IpnEndpoint{1, 0}}, true},
 		{EndpointID{&IpnEndpoint{1, 1}}, true},
 	}
 
@@ -205,6 +205,12 @@ func TestEndpointIDSameNode(t *testing.T) {
 			sameNode: false,
 			equals:   false,
 		},
+		{
+			eid1:     MustNewEndpointID("ipn:23.0"),
+			eid2:     MustNewEndpointID("ipn:23.42"),
+			sameNode: true,
+			equals:   false,
+		},
 		{
 			eid1:     MustNewEndpointID("ipn:23.42"),
 			eid2:     MustNewEndpointID("dtn://23/42"),
@@ -267,3 +273,28 @@ func TestEndpointIDSameNode(t *testing.T) {
 		}
 	}
 }
+
+func TestEndpointIDNodeID(t *testing.T) {
+	tests := []struct {
+		eid    string
+		nodeId string
+	}{
+		{"dtn:none", "dtn:none"},
+		{"dtn://foo/", "dtn://foo/"},
+		{"dtn://foo/bar", "dtn://foo/"},
+		{"dtn://foo/bar/buz", "dtn://foo/"},
+		{"dtn://foo/~bar", "dtn://foo/~bar"},
+		{"ipn:23.0", "ipn:23.0"},
+		{"ipn:23.42", "ipn:23.0"},
+	}
+
+	for _, test := range tests {
+		eid := MustNewEndpointID(test.eid)
+		if nodeId := eid.NodeID(); nodeId != MustNewEndpointID(test.nodeId) {
+			t.Fatalf("%s: expected node ID %s, got %v", test.eid, test.nodeId, nodeId)
+		}
+		if !eid.NodeID().SameNode(eid) {
+			t.Fatalf("%s: node ID %v is not on the same node", test.eid, eid.NodeID())
+		}
+	}
+}
@@ -15,6 +15,8 @@ import (
 	"sync"
 
 	"github.com/dtn7/cboring"
+
+	"github.com/dtn7/dtn7-go/pkg/internal/bufpool"
 )
 
 // Sorted list of all known block type codes to prevent double usage.
@@ -48,6 +50,39 @@ const (
 
 	// ExtBlockTypeSignatureBlock is the custom block type code for a SignatureBlock, bpv7/extension_block_signature.go
 	ExtBlockTypeSignatureBlock uint64 = 195
+
+	// ExtBlockTypeCustodyTransferBlock is the custom block type code for a CustodyTransferBlock, bpv7/extension_block_custody_transfer.go
+	ExtBlockTypeCustodyTransferBlock uint64 = 196
+
+	// ExtBlockTypePriorityBlock is the custom block type code for a PriorityBlock, bpv7/extension_block_priority.go
+	ExtBlockTypePriorityBlock uint64 = 197
+
+	// ExtBlockTypeAckRequestBlock is the custom block type code for an AckRequestBlock, bpv7/extension_block_ack.go
+	ExtBlockTypeAckRequestBlock uint64 = 198
+
+	// ExtBlockTypeAckBlock is the custom block type code for an AckBlock, bpv7/extension_block_ack.go
+	ExtBlockTypeAckBlock uint64 = 199
+
+	// ExtBlockTypeTransitLogBlock is the custom block type code for a TransitLogBlock, bpv7/extension_block_transit_log.go
+	ExtBlockTypeTransitLogBlock uint64 = 200
+
+	// ExtBlockTypeRegionBlock is the custom block type code for a RegionBlock, bpv7/extension_block_region.go
+	ExtBlockTypeRegionBlock uint64 = 201
+
+	// ExtBlockTypeSupersessionBlock is the custom block type code for a SupersessionBlock, bpv7/extension_block_supersession.go
+	ExtBlockTypeSupersessionBlock uint64 = 202
+
+	// ExtBlockTypeMetadataBlock is the custom block type code for a MetadataBlock, bpv7/extension_block_metadata.go
+	ExtBlockTypeMetadataBlock uint64 = 203
+
+	// ExtBlockTypeContentBlock is the custom block type code for a ContentBlock, bpv7/extension_block_content.go
+	ExtBlockTypeContentBlock uint64 = 204
+
+	// ExtBlockTypeInterestBlock is the custom block type code for an InterestBlock, bpv7/extension_block_content.go
+	ExtBlockTypeInterestBlock uint64 = 205
+
+	// ExtBlockTypeShardBlock is the custom block type code for a ShardBlock, bpv7/extension_block_shard.go
+	ExtBlockTypeShardBlock uint64 = 206
 )
 
 // ExtensionBlock describes the block-type specific data of any Canonical Block.
@@ -139,46 +174,76 @@ func (ebm *ExtensionBlockManager) createBlock(typeCode uint64) ExtensionBlock {
 // WriteBlock writes an ExtensionBlock in its correct binary format into the io.Writer.
 // Unknown block types are treated as GenericExtensionBlock.
 func (ebm *ExtensionBlockManager) WriteBlock(b ExtensionBlock, w io.Writer) error {
+	if sb, ok := b.(streamingBlock); ok {
+		if err := cboring.WriteByteStringLen(sb.Len(), w); err != nil {
+			return err
+		}
+		return sb.writeData(w)
+	}
+
+	// CBOR encoded blocks are serialized into a pooled buffer first, as the enclosing byte string needs their length.
+	if _, ok := b.(encoding.BinaryMarshaler); !ok {
+		if cm, ok := b.(cboring.CborMarshaler); ok {
+			buff := bufpool.GetBuffer()
+			defer bufpool.PutBuffer(buff)
+
+			if err := cboring.Marshal(cm, buff); err != nil {
+				return fmt.Errorf("marshalling CBOR for Block erred: %v", err)
+			}
+			return cboring.WriteByteString(buff.Bytes(), w)
+		}
+	}
+
+	if data, err := ebm.encodeBlock(b); err != nil {
+		return err
+	} else {
+		return cboring.WriteByteString(data, w)
+	}
+}
+
+// encodeBlock returns an ExtensionBlock's block-type-specific data, without the enclosing CBOR byte string.
+func (ebm *ExtensionBlockManager) encodeBlock(b ExtensionBlock) ([]byte, error) {
 	switch b := b.(type) {
 	case encoding.BinaryMarshaler:
 		if data, err := b.MarshalBinary(); err != nil {
-			return fmt.Errorf("marshalling binary for Block erred: %v", err)
+			return nil, fmt.Errorf("marshalling binary for Block erred: %v", err)
 		} else {
-			return cboring.WriteByteString(data, w)
+			return data, nil
 		}
 
 	case cboring.CborMarshaler:
 		var buff bytes.Buffer
 		if err := cboring.Marshal(b, &buff); err != nil {
-			return fmt.Errorf("marshalling CBOR for Block erred: %v", err)
+			return nil, fmt.Errorf("marshalling CBOR for Block erred: %v", err)
 		}
-		return cboring.WriteByteString(buff.Bytes(), w)
+		return buff.Bytes(), nil
 
 	default:
-		return fmt.Errorf("ExtensionBlock does not implement any expected types")
+		return nil, fmt.Errorf("ExtensionBlock does not implement any expected types")
 	}
 }
 
 // ReadBlock reads an ExtensionBlock from its correct binary format from the io.Reader.
 // Unknown block types are treated as GenericExtensionBlock.
 func (ebm *ExtensionBlockManager) ReadBlock(typeCode uint64, r io.Reader) (b ExtensionBlock, err error) {
+	if data, dataErr := cboring.ReadByteString(r); dataErr != nil {
+		return nil, dataErr
+	} else {
+		return ebm.decodeBlock(typeCode, data)
+	}
+}
+
+// decodeBlock creates an ExtensionBlock from its block-type-specific data.
+// Unknown block types are treated as GenericExtensionBlock.
+func (ebm *ExtensionBlockManager) decodeBlock(typeCode uint64, data []byte) (b ExtensionBlock, err error) {
 	b = ebm.createBlock(typeCode)
 
 	switch b := b.(type) {
 	case encoding.BinaryUnmarshaler:
-		if data, dataErr := cboring.ReadByteString(r); dataErr != nil {
-			err = dataErr
-		} else {
-			err = b.UnmarshalBinary(data)
-		}
+		err = b.UnmarshalBinary(data)
 
 	case cboring.CborMarshaler:
-		if data, dataErr := cboring.ReadByteString(r); dataErr != nil {
-			err = dataErr
-		} else {
-			var buff = bytes.NewBuffer(data)
-			err = cboring.Unmarshal(b, buff)
-		}
+		err = cboring.Unmarshal(b, bytes.NewBuffer(data))
 
 	default:
 		err = fmt.Errorf("ExtensionBlock does not implement any expected types")
@@ -195,6 +260,10 @@ var (
 // GetExtensionBlockManager returns the singleton ExtensionBlockManager. If none
 // exists, a new ExtensionBlockManager will be generated with a knowledge of the
 // PayloadBlock, PreviousNodeBlock, BundleAgeBlock and HopCountBlock.
+//
+// This registry is deliberately process-wide: it only maps block type codes to
+// their Go types, which are required while parsing a bundle, e.g., within a
+// CLA, before any Core is involved.
 func GetExtensionBlockManager() *ExtensionBlockManager {
 	extensionBlockManagerMutex.Lock()
 	defer extensionBlockManagerMutex.Unlock()
@@ -206,6 +275,17 @@ func GetExtensionBlockManager() *ExtensionBlockManager {
 		_ = extensionBlockManager.Register(NewPreviousNodeBlock(DtnNone()))
 		_ = extensionBlockManager.Register(NewBundleAgeBlock(0))
 		_ = extensionBlockManager.Register(NewHopCountBlock(0))
+		_ = extensionBlockManager.Register(NewCustodyTransferBlock(DtnNone()))
+		_ = extensionBlockManager.Register(NewPriorityBlock(PriorityNormal))
+		_ = extensionBlockManager.Register(NewAckRequestBlock())
+		_ = extensionBlockManager.Register(NewAckBlock(BundleID{}))
+		_ = extensionBlockManager.Register(NewTransitLogBlock())
+		_ = extensionBlockManager.Register(NewRegionBlock(""))
+		_ = extensionBlockManager.Register(NewSupersessionBlock("", 0))
+		_ = extensionBlockManager.Register(NewMetadataBlock(nil))
+		_ = extensionBlockManager.Register(NewContentBlock(""))
+		_ = extensionBlockManager.Register(NewInterestBlock(""))
+		_ = extensionBlockManager.Register(new(ShardBlock))
 		_ = extensionBlockManager.Register(new(BIBIOPHMACSHA2))
 		_ = extensionBlockManager.Register(new(BCBIOPAESGCM))
 	}
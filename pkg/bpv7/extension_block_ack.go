@@ -0,0 +1,155 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package bpv7
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/dtn7/cboring"
+)
+
+// AckRequestBlock requests an application acknowledgment from its Bundle's destination. After a successful local
+// delivery, the destination's node returns an acknowledgment Bundle with an AckBlock to the Bundle's source.
+//
+// In contrast to status reports, which are sent by the bundle protocol agent, the acknowledgment confirms the
+// delivery to an application agent.
+type AckRequestBlock struct{}
+
+// BlockTypeCode must return a constant integer, indicating the block type code.
+func (arb *AckRequestBlock) BlockTypeCode() uint64 {
+	return ExtBlockTypeAckRequestBlock
+}
+
+// BlockTypeName must return a constant string, this block's name.
+func (arb *AckRequestBlock) BlockTypeName() string {
+	return "Ack Request Block"
+}
+
+// NewAckRequestBlock creates a new AckRequestBlock.
+func NewAckRequestBlock() *AckRequestBlock {
+	return &AckRequestBlock{}
+}
+
+// MarshalCbor writes the CBOR representation of an AckRequestBlock, an empty array.
+func (arb *AckRequestBlock) MarshalCbor(w io.Writer) error {
+	return cboring.WriteArrayLength(0, w)
+}
+
+// UnmarshalCbor reads the CBOR representation of an AckRequestBlock.
+func (arb *AckRequestBlock) UnmarshalCbor(r io.Reader) error {
+	if n, err := cboring.ReadArrayLength(r); err != nil {
+		return err
+	} else if n != 0 {
+		return fmt.Errorf("AckRequestBlock: expected an empty array, got %d elements", n)
+	}
+	return nil
+}
+
+// CheckValid returns no error, as an AckRequestBlock has no content.
+func (arb *AckRequestBlock) CheckValid() error {
+	return nil
+}
+
+// CheckContextValid that there is at most one Ack Request Block.
+func (arb *AckRequestBlock) CheckContextValid(b *Bundle) error {
+	cb, err := b.ExtensionBlock(ExtBlockTypeAckRequestBlock)
+
+	if err != nil {
+		return err
+	} else if cb.Value != arb {
+		return fmt.Errorf("AckRequestBlock's pointer differs, %p != %p", cb.Value, arb)
+	} else {
+		return nil
+	}
+}
+
+// AckBlock marks an acknowledgment Bundle, carrying the BundleID of the acknowledged Bundle.
+type AckBlock BundleID
+
+// BlockTypeCode must return a constant integer, indicating the block type code.
+func (ab *AckBlock) BlockTypeCode() uint64 {
+	return ExtBlockTypeAckBlock
+}
+
+// BlockTypeName must return a constant string, this block's name.
+func (ab *AckBlock) BlockTypeName() string {
+	return "Ack Block"
+}
+
+// NewAckBlock creates a new AckBlock, acknowledging the Bundle of the given BundleID.
+func NewAckBlock(bid BundleID) *AckBlock {
+	ab := AckBlock(bid)
+	return &ab
+}
+
+// BundleID returns the acknowledged Bundle's BundleID.
+func (ab *AckBlock) BundleID() BundleID {
+	return BundleID(*ab)
+}
+
+// MarshalCbor writes the CBOR representation of an AckBlock, an array of the BundleID's fields.
+func (ab *AckBlock) MarshalCbor(w io.Writer) error {
+	bid := ab.BundleID()
+	if err := cboring.WriteArrayLength(bid.Len(), w); err != nil {
+		return err
+	}
+	return bid.MarshalCbor(w)
+}
+
+// UnmarshalCbor reads the CBOR representation of an AckBlock. The array's length indicates a fragment.
+func (ab *AckBlock) UnmarshalCbor(r io.Reader) error {
+	n, err := cboring.ReadArrayLength(r)
+	if err != nil {
+		return err
+	} else if n != 2 && n != 4 {
+		return fmt.Errorf("AckBlock: expected an array of 2 or 4 elements, got %d", n)
+	}
+
+	bid := BundleID{IsFragment: n == 4}
+	if err := bid.UnmarshalCbor(r); err != nil {
+		return err
+	}
+
+	*ab = AckBlock(bid)
+	return nil
+}
+
+// MarshalJSON writes the JSON representation of an AckBlock, the acknowledged BundleID's String.
+func (ab *AckBlock) MarshalJSON() ([]byte, error) {
+	return json.Marshal(ab.BundleID().String())
+}
+
+// CheckValid checks the acknowledged BundleID's source node.
+func (ab *AckBlock) CheckValid() error {
+	return ab.SourceNode.CheckValid()
+}
+
+// CheckContextValid that there is at most one Ack Block.
+func (ab *AckBlock) CheckContextValid(b *Bundle) error {
+	cb, err := b.ExtensionBlock(ExtBlockTypeAckBlock)
+
+	if err != nil {
+		return err
+	} else if cb.Value != ab {
+		return fmt.Errorf("AckBlock's pointer differs, %p != %p", cb.Value, ab)
+	} else {
+		return nil
+	}
+}
+
+// RequestsAck checks if this Bundle requests an application acknowledgment by an AckRequestBlock.
+func (b Bundle) RequestsAck() bool {
+	return b.HasExtensionBlock(ExtBlockTypeAckRequestBlock)
+}
+
+// AcknowledgedBundle returns the BundleID acknowledged by this Bundle's AckBlock, if this is an acknowledgment.
+func (b Bundle) AcknowledgedBundle() (bid BundleID, ok bool) {
+	if cb, err := b.ExtensionBlock(ExtBlockTypeAckBlock); err == nil {
+		return cb.Value.(*AckBlock).BundleID(), true
+	}
+	return
+}
@@ -0,0 +1,62 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package bpv7
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestAckBlocks(t *testing.T) {
+	acked := BundleID{
+		SourceNode:      MustNewEndpointID("dtn://src/"),
+		Timestamp:       NewCreationTimestamp(DtnTimeEpoch, 23),
+		IsFragment:      true,
+		FragmentOffset:  10,
+		TotalDataLength: 100,
+	}
+
+	request, err := Builder().
+		Source("dtn://src/").
+		Destination("dtn://dst/").
+		CreationTimestampNow().
+		Lifetime("10m").
+		AckRequestBlock().
+		PayloadBlock([]byte("hello world")).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ack, err := Builder().
+		Source("dtn://dst/").
+		Destination("dtn://src/").
+		CreationTimestampNow().
+		Lifetime("10m").
+		Canonical(NewAckBlock(acked), ReplicateBlock).
+		PayloadBlock([]byte{}).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, b := range []Bundle{request, ack} {
+		buff := new(bytes.Buffer)
+		if err := b.MarshalCbor(buff); err != nil {
+			t.Fatal(err)
+		}
+		b2, err := ParseBundle(buff)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		bid, isAck := b2.AcknowledgedBundle()
+		if b2.RequestsAck() == isAck {
+			t.Fatalf("bundle requests an ack %t, is an ack %t", b2.RequestsAck(), isAck)
+		} else if isAck && bid != acked {
+			t.Fatalf("acknowledged %v, expected %v", bid, acked)
+		}
+	}
+}
@@ -86,10 +86,42 @@ func (bcb *BCBIOPAESGCM) CheckValid() error {
 	return nil
 }
 
-// CheckContextValid  TODO: Check BSPEC stuff
-func (bcb *BCBIOPAESGCM) CheckContextValid(*Bundle) error {
+// CheckContextValid checks that all security targets exist and are allowed to be encrypted.
+func (bcb *BCBIOPAESGCM) CheckContextValid(b *Bundle) error {
+	if err := bcb.CheckValid(); err != nil {
+		return err
+	}
+
+	for _, securityTarget := range bcb.Asb.SecurityTargets {
+		securityTargetBlock, err := b.GetExtensionBlockByBlockNumber(securityTarget)
+		if err != nil {
+			return fmt.Errorf("BCB-IOP-AES-GCM security target: %v", err)
+		}
+		if !isEncryptableBlockType(securityTargetBlock.TypeCode()) {
+			return fmt.Errorf("BCB-IOP-AES-GCM security target %d of block type code %d must not be encrypted",
+				securityTarget, securityTargetBlock.TypeCode())
+		}
+	}
+
+	return nil
+}
+
+// isEncryptableBlockType checks if a block of this type code might be the target of a BCB. Next to the BCB itself,
+// this excludes blocks which are read or updated by each node along the bundle's path.
+func isEncryptableBlockType(typeCode uint64) bool {
+	switch typeCode {
+	case ExtBlockTypeBlockConfidentialityBlock,
+		ExtBlockTypePreviousNodeBlock,
+		ExtBlockTypeBundleAgeBlock,
+		ExtBlockTypeHopCountBlock,
+		ExtBlockTypeBinarySprayBlock,
+		ExtBlockTypeDTLSRBlock,
+		ExtBlockTypeProphetBlock:
+		return false
 
-	return bcb.CheckValid()
+	default:
+		return true
+	}
 }
 
 // NewBCBIOPAESGCM creates a new BCB-IOP-AES-GCM block
@@ -142,18 +174,14 @@ func NewBCBIOPAESGCM(aesVariant *uint64, wrappedKey *[]byte, AADScopeFlags *uint
 
 }
 
-// extractPlainText extracts the plaintext used during encryption according to RFC9173 4.7.1
+// extractPlainText extracts the plaintext used during encryption according to RFC9173 4.7.1, the security target's
+// block-type-specific data.
 func (bcb *BCBIOPAESGCM) extractPlainText(securityTargetBlock *CanonicalBlock) (plainText *bytes.Buffer, err error) {
-	// 1. Create a buffer for the plaintext
-	plainText = new(bytes.Buffer)
-
-	// 2. Get Plaintext from Payloadblock
-	payLoadBlock := securityTargetBlock.Value.(*PayloadBlock)
-	_, err = plainText.Write(payLoadBlock.Data())
+	data, err := GetExtensionBlockManager().encodeBlock(securityTargetBlock.Value)
 	if err != nil {
 		return nil, err
 	}
-	return
+	return bytes.NewBuffer(data), nil
 }
 
 // prepareAAD constructs the "Additional Authenticated Data" using the process defined in RFC9173 4.7.2
@@ -211,20 +239,17 @@ func (bcb *BCBIOPAESGCM) prepareAAD(b Bundle, securityTargetBlock *CanonicalBloc
 	// calculated and, in that order, appended to the AAD.
 	if aadScopeFlag&SecurityHeaderFlagBCBIOPAESGCM == SecurityHeaderFlagBCBIOPAESGCM {
 
-		if err = cboring.WriteUInt(bcb.BlockTypeCode(), aad); err != nil {
-			return nil, err
-		}
-		if err = cboring.WriteUInt(bcbBlockNumber, aad); err != nil {
+		var bcbCanonicalBlock *CanonicalBlock
+		bcbCanonicalBlock, err = b.GetExtensionBlockByBlockNumber(bcbBlockNumber)
+		if err != nil {
 			return nil, err
 		}
 
-		var bcbCanonicalBlock *CanonicalBlock
-		bcbCanonicalBlock, err = b.ExtensionBlock(bcb.BlockTypeCode())
-		if err != nil {
+		if err = cboring.WriteUInt(bcb.BlockTypeCode(), aad); err != nil {
 			return nil, err
 		}
 
-		if err = cboring.WriteUInt(bcbCanonicalBlock.BlockNumber, aad); err != nil {
+		if err = cboring.WriteUInt(bcbBlockNumber, aad); err != nil {
 			return nil, err
 		}
 
@@ -334,14 +359,20 @@ func checkKeyLengthAgainstAESVariantParameter(bcb *BCBIOPAESGCM, privateKey []by
 }
 
 // EncryptTarget encrypts the target block using the BCB-IOP-AES-GCM security operation.
+//
+// The target might be the payload or an extension block. As all targets of a BCB share the same IV, exactly one
+// security target is supported. Use Bundle.EncryptBlocks to encrypt multiple blocks.
 func (bcb *BCBIOPAESGCM) EncryptTarget(b Bundle, bcbBlockNumber uint64, privateKey []byte) (err error) {
+	if len(bcb.Asb.SecurityTargets) != 1 {
+		return fmt.Errorf("BCB-IOP-AES-GCM encryption requires exactly one security target, got %d", len(bcb.Asb.SecurityTargets))
+	}
 
-	// Check if the target is a supported payload block
+	// Check if the target is a supported block
 	securityTargetBlock, err := b.GetExtensionBlockByBlockNumber(bcb.Asb.SecurityTargets[0])
 	if err != nil {
 		return err
 	}
-	if securityTargetBlock.Value.BlockTypeCode() != ExtBlockTypePayloadBlock {
+	if !isEncryptableBlockType(securityTargetBlock.TypeCode()) {
 		return fmt.Errorf("unsupported security target block type code %d, %s", securityTargetBlock.Value.BlockTypeCode(), securityTargetBlock.Value.BlockTypeName())
 	}
 
@@ -369,8 +400,12 @@ func (bcb *BCBIOPAESGCM) EncryptTarget(b Bundle, bcbBlockNumber uint64, privateK
 		return err
 	}
 
-	// Set the cipherText as the payload
-	securityTargetBlock.Value = NewPayloadBlock(cipherText)
+	// Set the cipherText as the block-type-specific data. A payload remains a PayloadBlock.
+	if securityTargetBlock.TypeCode() == ExtBlockTypePayloadBlock {
+		securityTargetBlock.Value = NewPayloadBlock(cipherText)
+	} else {
+		securityTargetBlock.Value = NewGenericExtensionBlock(cipherText, securityTargetBlock.TypeCode())
+	}
 
 	// Set the authenticationTag as security result
 	bcb.Asb.SecurityResults[0].results = append(bcb.Asb.SecurityResults[0].results, &IDValueTupleByteString{
@@ -382,37 +417,41 @@ func (bcb *BCBIOPAESGCM) EncryptTarget(b Bundle, bcbBlockNumber uint64, privateK
 
 }
 
-// DecryptTarget decrypts the payload of the security target block and verifies the authentication tag
+// DecryptTarget decrypts the security target blocks and verifies their authentication tags. Afterwards, each target
+// holds its original ExtensionBlock again.
 func (bcb *BCBIOPAESGCM) DecryptTarget(b Bundle, bcbBlockNumber uint64, privateKey []byte) (err error) {
+	for i, securityTarget := range bcb.Asb.SecurityTargets {
+		securityTargetBlock, targetErr := b.GetExtensionBlockByBlockNumber(securityTarget)
+		if targetErr != nil {
+			return targetErr
+		}
 
-	// Check if the target is a supported payload block
-	securityTargetBlock, err := b.GetExtensionBlockByBlockNumber(bcb.Asb.SecurityTargets[0])
-	if err != nil {
-		return err
-	}
-
-	if securityTargetBlock.Value.BlockTypeCode() != ExtBlockTypePayloadBlock {
-		return fmt.Errorf("unsupported security target block type code %d, %s", securityTargetBlock.Value.BlockTypeCode(), securityTargetBlock.Value.BlockTypeName())
-	}
+		if !isEncryptableBlockType(securityTargetBlock.TypeCode()) {
+			return fmt.Errorf("unsupported security target block type code %d, %s", securityTargetBlock.Value.BlockTypeCode(), securityTargetBlock.Value.BlockTypeName())
+		}
 
-	// Decrypt and Authenticate
-	plainText, err := bcb.decryptAndAuthenticate(b, securityTargetBlock, bcbBlockNumber, privateKey)
-	if err != nil {
-		return err
-	}
+		// Decrypt and Authenticate
+		plainText, decryptErr := bcb.decryptAndAuthenticate(b, i, securityTargetBlock, bcbBlockNumber, privateKey)
+		if decryptErr != nil {
+			return decryptErr
+		}
 
-	// Set the plainText as the payload
-	securityTargetBlock.Value = NewPayloadBlock(plainText)
+		// Restore the original block from its block-type-specific data
+		value, decodeErr := GetExtensionBlockManager().decodeBlock(securityTargetBlock.TypeCode(), plainText)
+		if decodeErr != nil {
+			return fmt.Errorf("decrypted security target %d: %v", securityTarget, decodeErr)
+		}
+		securityTargetBlock.Value = value
 
-	// Set CRC
-	securityTargetBlock.CRCType = CRC32
+		// Set CRC
+		securityTargetBlock.CRCType = CRC32
+	}
 
 	return
 }
 
-// Decrypt and authenticate the security target
-func (bcb *BCBIOPAESGCM) decryptAndAuthenticate(b Bundle, targetBlock *CanonicalBlock, number uint64, key []byte) (plainText []byte, err error) {
-
+// Decrypt and authenticate the security target at the given index of the security targets.
+func (bcb *BCBIOPAESGCM) decryptAndAuthenticate(b Bundle, index int, targetBlock *CanonicalBlock, number uint64, key []byte) (plainText []byte, err error) {
 	// Get the AES IV
 	aesIVParameter := func() *[]byte {
 		for _, scp := range bcb.Asb.SecurityContextParameters {
@@ -425,8 +464,11 @@ func (bcb *BCBIOPAESGCM) decryptAndAuthenticate(b Bundle, targetBlock *Canonical
 	}()
 
 	// Get the authentication tag
+	if index >= len(bcb.Asb.SecurityResults) {
+		return nil, fmt.Errorf("security results for security target %d are missing", targetBlock.BlockNumber)
+	}
 	authenticationTag := func() *[]byte {
-		for _, scp := range bcb.Asb.SecurityResults[0].results {
+		for _, scp := range bcb.Asb.SecurityResults[index].results {
 			if scp.ID() == SecConResultIDBCBIOPAESGCMAuthenticationTag {
 				scpValue := scp.Value().([]byte)
 				return &scpValue
@@ -446,7 +488,10 @@ func (bcb *BCBIOPAESGCM) decryptAndAuthenticate(b Bundle, targetBlock *Canonical
 	}
 
 	// Get the cipherText
-	cipherText := targetBlock.Value.(*PayloadBlock).Data()
+	cipherText, err := GetExtensionBlockManager().encodeBlock(targetBlock.Value)
+	if err != nil {
+		return nil, err
+	}
 
 	// Prepare the AAD
 	aad, err := bcb.prepareAAD(b, targetBlock, number)
@@ -471,7 +516,7 @@ func (bcb *BCBIOPAESGCM) decryptAndAuthenticate(b Bundle, targetBlock *Canonical
 		return nil, err
 	}
 
-	fullChipherText := append(cipherText, *authenticationTag...)
+	fullChipherText := append(append([]byte{}, cipherText...), *authenticationTag...)
 
 	// Decrypt
 	plainText, err = gcm.Open(nil, *aesIVParameter, fullChipherText, aad.Bytes())
@@ -481,3 +526,103 @@ func (bcb *BCBIOPAESGCM) decryptAndAuthenticate(b Bundle, targetBlock *Canonical
 
 	return plainText, nil
 }
+
+// EncryptBlocks encrypts the blocks of the given block numbers with BCB-IOP-AES-GCM. The AES variant is derived from
+// the key's length, 16 bytes for A128GCM or 32 bytes for A256GCM.
+//
+// Each target gets its own BCB because all targets of a BCB would share the same IV. A BCB targeting the payload is
+// replicated in every fragment.
+func (b *Bundle) EncryptBlocks(securitySource EndpointID, key []byte, targets ...uint64) error {
+	aesVariant := A256GCM
+	if len(key) == 16 {
+		aesVariant = A128GCM
+	}
+
+	for _, target := range targets {
+		securityTargetBlock, err := b.GetExtensionBlockByBlockNumber(target)
+		if err != nil {
+			return err
+		}
+
+		var blockControlFlags BlockControlFlags
+		if securityTargetBlock.TypeCode() == ExtBlockTypePayloadBlock {
+			blockControlFlags = ReplicateBlock
+		}
+
+		bcb := NewBCBIOPAESGCM(&aesVariant, nil, nil, target, securitySource)
+		if err := b.AddExtensionBlock(NewCanonicalBlock(0, blockControlFlags, bcb)); err != nil {
+			return err
+		}
+
+		bcbBlock, err := b.securityBlock(bcb)
+		if err != nil {
+			return err
+		}
+
+		if err := bcb.EncryptTarget(*b, bcbBlock.BlockNumber, key); err != nil {
+			b.RemoveExtensionBlockByBlockNumber(bcbBlock.BlockNumber)
+			return fmt.Errorf("encrypting block %d erred: %v", target, err)
+		}
+	}
+
+	return nil
+}
+
+// DecryptBlocks decrypts all blocks protected by a BCB-IOP-AES-GCM and removes those BCBs afterwards. If an error
+// occurs, the Bundle might be partially decrypted.
+func (b *Bundle) DecryptBlocks(key []byte) error {
+	var bcbs []*BCBIOPAESGCM
+	for _, cb := range b.CanonicalBlocks {
+		if bcb, ok := cb.Value.(*BCBIOPAESGCM); ok {
+			bcbs = append(bcbs, bcb)
+		}
+	}
+
+	for _, bcb := range bcbs {
+		bcbBlock, err := b.securityBlock(bcb)
+		if err != nil {
+			return err
+		}
+
+		if err := bcb.DecryptTarget(*b, bcbBlock.BlockNumber, key); err != nil {
+			return fmt.Errorf("decrypting BCB %d erred: %v", bcbBlock.BlockNumber, err)
+		}
+
+		b.RemoveExtensionBlockByBlockNumber(bcbBlock.BlockNumber)
+	}
+
+	return nil
+}
+
+// securityBlock returns the CanonicalBlock holding this exact ExtensionBlock instance.
+func (b *Bundle) securityBlock(eb ExtensionBlock) (*CanonicalBlock, error) {
+	for i := range b.CanonicalBlocks {
+		if b.CanonicalBlocks[i].Value == eb {
+			return &b.CanonicalBlocks[i], nil
+		}
+	}
+	return nil, fmt.Errorf("block %s not found", eb.BlockTypeName())
+}
+
+// isEncryptedBlock checks if the block of this block number is the security target of a BCB.
+func (b *Bundle) isEncryptedBlock(blockNumber uint64) bool {
+	for _, cb := range b.CanonicalBlocks {
+		if cb.BlockNumber == blockNumber && !isEncryptableBlockType(cb.TypeCode()) {
+			return false
+		}
+	}
+
+	for _, cb := range b.CanonicalBlocks {
+		bcb, ok := cb.Value.(*BCBIOPAESGCM)
+		if !ok {
+			continue
+		}
+
+		for _, securityTarget := range bcb.Asb.SecurityTargets {
+			if securityTarget == blockNumber {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -147,3 +147,121 @@ func TestBCBBlockCbor(t *testing.T) {
 		}
 	}
 }
+
+func TestBundleEncryptBlocks(t *testing.T) {
+	payload := []byte("hello world!")
+
+	for _, key := range []string{"dtnislovedtnislo", "dtnislovedtnislovedtnislovedtnis"} {
+		b, err := Builder().
+			CRC(CRC32).
+			Source("dtn://src/").
+			Destination("dtn://dst/").
+			CreationTimestampNow().
+			Lifetime(30 * time.Minute).
+			HopCountBlock(64).
+			PayloadBlock(payload).
+			Build()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		shaVariant := HMAC256SHA256
+		bib := NewBIBIOPHMACSHA2(&shaVariant, nil, nil, []uint64{1}, b.PrimaryBlock.SourceNode)
+		if err := b.AddExtensionBlock(NewCanonicalBlock(0, 0, bib)); err != nil {
+			t.Fatal(err)
+		}
+		bibBlock, _ := b.ExtensionBlock(ExtBlockTypeBlockIntegrityBlock)
+		if err := bib.SignTargets(b, bibBlock.BlockNumber, []byte("secret")); err != nil {
+			t.Fatal(err)
+		}
+		bibData, _ := GetExtensionBlockManager().encodeBlock(bib)
+
+		hcBlock, _ := b.ExtensionBlock(ExtBlockTypeHopCountBlock)
+		if err := b.EncryptBlocks(b.PrimaryBlock.SourceNode, []byte(key), hcBlock.BlockNumber); err == nil {
+			t.Fatal("encrypting a Hop Count Block did not fail")
+		} else if b.HasExtensionBlock(ExtBlockTypeBlockConfidentialityBlock) {
+			t.Fatal("BCB of failed encryption was not removed")
+		}
+
+		if err := b.EncryptBlocks(b.PrimaryBlock.SourceNode, []byte(key), 1, bibBlock.BlockNumber); err != nil {
+			t.Fatal(err)
+		}
+
+		if bcbs, _ := b.ExtensionBlocks(ExtBlockTypeBlockConfidentialityBlock); len(bcbs) != 2 {
+			t.Fatalf("expected two BCBs, got %d", len(bcbs))
+		}
+
+		// A relay must be able to parse the bundle with an undecodable, encrypted BIB.
+		buff := new(bytes.Buffer)
+		if err := b.MarshalCbor(buff); err != nil {
+			t.Fatal(err)
+		}
+
+		var relayed Bundle
+		if err := relayed.UnmarshalCbor(buff); err != nil {
+			t.Fatal(err)
+		}
+
+		if pb, _ := relayed.PayloadBlock(); bytes.Equal(pb.Value.(*PayloadBlock).Data(), payload) {
+			t.Fatal("payload was not encrypted")
+		}
+		if encBib, _ := relayed.ExtensionBlock(ExtBlockTypeBlockIntegrityBlock); encBib == nil {
+			t.Fatal("encrypted BIB is missing")
+		} else if _, ok := encBib.Value.(*GenericExtensionBlock); !ok {
+			t.Fatalf("encrypted BIB was decoded as %T", encBib.Value)
+		}
+
+		if err := relayed.DecryptBlocks([]byte("wrongkeywrongkey")); err == nil {
+			t.Fatal("decrypting with a wrong key did not fail")
+		}
+
+		relayed = Bundle{}
+		buff.Reset()
+		if err := b.MarshalCbor(buff); err != nil {
+			t.Fatal(err)
+		}
+		if err := relayed.UnmarshalCbor(buff); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := relayed.DecryptBlocks([]byte(key)); err != nil {
+			t.Fatal(err)
+		}
+
+		if relayed.HasExtensionBlock(ExtBlockTypeBlockConfidentialityBlock) {
+			t.Fatal("BCBs were not removed")
+		}
+		if pb, _ := relayed.PayloadBlock(); !bytes.Equal(pb.Value.(*PayloadBlock).Data(), payload) {
+			t.Fatalf("decrypted payload differs: %q", pb.Value.(*PayloadBlock).Data())
+		}
+		if decBib, err := relayed.ExtensionBlock(ExtBlockTypeBlockIntegrityBlock); err != nil {
+			t.Fatal(err)
+		} else if decData, _ := GetExtensionBlockManager().encodeBlock(decBib.Value); !bytes.Equal(decData, bibData) {
+			t.Fatal("decrypted BIB differs")
+		}
+	}
+}
+
+func TestBundleUndecodableBlock(t *testing.T) {
+	b, err := Builder().
+		Source("dtn://src/").
+		Destination("dtn://dst/").
+		CreationTimestampNow().
+		Lifetime(30 * time.Minute).
+		Canonical(NewGenericExtensionBlock([]byte{0xff}, ExtBlockTypeBlockIntegrityBlock)).
+		PayloadBlock([]byte("hello world!")).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buff := new(bytes.Buffer)
+	if err := b.MarshalCbor(buff); err != nil {
+		t.Fatal(err)
+	}
+
+	var b2 Bundle
+	if err := b2.UnmarshalCbor(buff); err == nil {
+		t.Fatal("unmarshalling an undecodable block without a BCB did not fail")
+	}
+}
@@ -63,6 +63,22 @@ func (bab *BundleAgeBlock) MarshalJSON() ([]byte, error) {
 	return json.Marshal(fmt.Sprintf("%d ms", bab.Age()))
 }
 
+// UnmarshalJSON reads a JSON representation of a Bundle Age Block, as created by MarshalJSON.
+func (bab *BundleAgeBlock) UnmarshalJSON(data []byte) error {
+	var age string
+	if err := json.Unmarshal(data, &age); err != nil {
+		return err
+	}
+
+	var ms uint64
+	if _, err := fmt.Sscanf(age, "%d ms", &ms); err != nil {
+		return fmt.Errorf("invalid bundle age %q: %v", age, err)
+	}
+
+	*bab = BundleAgeBlock(ms)
+	return nil
+}
+
 // CheckValid returns an array of errors for incorrect data.
 func (bab *BundleAgeBlock) CheckValid() error {
 	return nil
@@ -0,0 +1,165 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package bpv7
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/dtn7/cboring"
+)
+
+// ContentBlock names the content carried by a Bundle's payload, e.g., "weather/2022-06-01". Nodes with a content
+// cache retain such Bundles to answer later InterestBlocks for the same name themselves.
+type ContentBlock string
+
+// BlockTypeCode must return a constant integer, indicating the block type code.
+func (cb *ContentBlock) BlockTypeCode() uint64 {
+	return ExtBlockTypeContentBlock
+}
+
+// BlockTypeName must return a constant string, this block's name.
+func (cb *ContentBlock) BlockTypeName() string {
+	return "Content Block"
+}
+
+// NewContentBlock creates a new ContentBlock for a content's name.
+func NewContentBlock(name string) *ContentBlock {
+	cb := ContentBlock(name)
+	return &cb
+}
+
+// Name returns this ContentBlock's content name.
+func (cb *ContentBlock) Name() string {
+	return string(*cb)
+}
+
+// MarshalCbor writes the CBOR representation of a ContentBlock, a text string.
+func (cb *ContentBlock) MarshalCbor(w io.Writer) error {
+	return cboring.WriteTextString(cb.Name(), w)
+}
+
+// UnmarshalCbor reads the CBOR representation of a ContentBlock.
+func (cb *ContentBlock) UnmarshalCbor(r io.Reader) error {
+	if name, err := cboring.ReadTextString(r); err != nil {
+		return err
+	} else {
+		*cb = ContentBlock(name)
+		return nil
+	}
+}
+
+// MarshalJSON writes the JSON representation of a ContentBlock, its content name.
+func (cb *ContentBlock) MarshalJSON() ([]byte, error) {
+	return json.Marshal(cb.Name())
+}
+
+// CheckValid checks for a non-empty content name.
+func (cb *ContentBlock) CheckValid() error {
+	if cb.Name() == "" {
+		return fmt.Errorf("ContentBlock: empty name")
+	}
+	return nil
+}
+
+// CheckContextValid that there is at most one Content Block and no Interest Block.
+func (cb *ContentBlock) CheckContextValid(b *Bundle) error {
+	if b.HasExtensionBlock(ExtBlockTypeInterestBlock) {
+		return fmt.Errorf("ContentBlock: bundle also has an InterestBlock")
+	}
+
+	block, err := b.ExtensionBlock(ExtBlockTypeContentBlock)
+	if err != nil {
+		return err
+	} else if block.Value != cb {
+		return fmt.Errorf("ContentBlock's pointer differs, %p != %p", block.Value, cb)
+	} else {
+		return nil
+	}
+}
+
+// InterestBlock requests the content of a name, as given by a ContentBlock. A Bundle carrying an InterestBlock is
+// addressed to the content's producer, but a node along the path with this content in its cache answers the request
+// with a new Bundle to the interested source, not forwarding the request any further.
+type InterestBlock string
+
+// BlockTypeCode must return a constant integer, indicating the block type code.
+func (ib *InterestBlock) BlockTypeCode() uint64 {
+	return ExtBlockTypeInterestBlock
+}
+
+// BlockTypeName must return a constant string, this block's name.
+func (ib *InterestBlock) BlockTypeName() string {
+	return "Interest Block"
+}
+
+// NewInterestBlock creates a new InterestBlock for a content's name.
+func NewInterestBlock(name string) *InterestBlock {
+	ib := InterestBlock(name)
+	return &ib
+}
+
+// Name returns this InterestBlock's requested content name.
+func (ib *InterestBlock) Name() string {
+	return string(*ib)
+}
+
+// MarshalCbor writes the CBOR representation of an InterestBlock, a text string.
+func (ib *InterestBlock) MarshalCbor(w io.Writer) error {
+	return cboring.WriteTextString(ib.Name(), w)
+}
+
+// UnmarshalCbor reads the CBOR representation of an InterestBlock.
+func (ib *InterestBlock) UnmarshalCbor(r io.Reader) error {
+	if name, err := cboring.ReadTextString(r); err != nil {
+		return err
+	} else {
+		*ib = InterestBlock(name)
+		return nil
+	}
+}
+
+// MarshalJSON writes the JSON representation of an InterestBlock, its content name.
+func (ib *InterestBlock) MarshalJSON() ([]byte, error) {
+	return json.Marshal(ib.Name())
+}
+
+// CheckValid checks for a non-empty content name.
+func (ib *InterestBlock) CheckValid() error {
+	if ib.Name() == "" {
+		return fmt.Errorf("InterestBlock: empty name")
+	}
+	return nil
+}
+
+// CheckContextValid that there is at most one Interest Block.
+func (ib *InterestBlock) CheckContextValid(b *Bundle) error {
+	block, err := b.ExtensionBlock(ExtBlockTypeInterestBlock)
+	if err != nil {
+		return err
+	} else if block.Value != ib {
+		return fmt.Errorf("InterestBlock's pointer differs, %p != %p", block.Value, ib)
+	} else {
+		return nil
+	}
+}
+
+// Content name of this Bundle, as given by its ContentBlock. Bundles without a ContentBlock return false.
+func (b Bundle) Content() (name string, ok bool) {
+	if cb, err := b.ExtensionBlock(ExtBlockTypeContentBlock); err == nil {
+		return cb.Value.(*ContentBlock).Name(), true
+	}
+	return
+}
+
+// Interest of this Bundle in a content name, as given by its InterestBlock. Bundles without an InterestBlock return
+// false.
+func (b Bundle) Interest() (name string, ok bool) {
+	if cb, err := b.ExtensionBlock(ExtBlockTypeInterestBlock); err == nil {
+		return cb.Value.(*InterestBlock).Name(), true
+	}
+	return
+}
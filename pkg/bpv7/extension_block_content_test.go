@@ -0,0 +1,89 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package bpv7
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestBundleContent(t *testing.T) {
+	b, err := BuildFromMap(map[string]interface{}{
+		"destination":            "dtn://village/",
+		"source":                 "dtn://weather/",
+		"creation_timestamp_now": true,
+		"lifetime":               "24h",
+		"content_block":          "weather/today",
+		"payload_block":          "sunny",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buff := new(bytes.Buffer)
+	if err := b.MarshalCbor(buff); err != nil {
+		t.Fatal(err)
+	}
+	b2, err := ParseBundle(buff)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if name, ok := b2.Content(); !ok || name != "weather/today" {
+		t.Fatalf("unexpected content %q (%t)", name, ok)
+	} else if _, ok := b2.Interest(); ok {
+		t.Fatal("content bundle has an interest")
+	}
+}
+
+func TestBundleInterest(t *testing.T) {
+	b, err := Builder().
+		Source("dtn://village/").
+		Destination("dtn://weather/").
+		CreationTimestampNow().
+		Lifetime("24h").
+		InterestBlock("weather/today").
+		PayloadBlock([]byte{}).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buff := new(bytes.Buffer)
+	if err := b.MarshalCbor(buff); err != nil {
+		t.Fatal(err)
+	}
+	b2, err := ParseBundle(buff)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if name, ok := b2.Interest(); !ok || name != "weather/today" {
+		t.Fatalf("unexpected interest %q (%t)", name, ok)
+	}
+
+	if _, err := Builder().
+		Source("dtn://village/").
+		Destination("dtn://weather/").
+		CreationTimestampNow().
+		Lifetime("24h").
+		InterestBlock("weather/today").
+		ContentBlock("weather/today").
+		PayloadBlock([]byte{}).
+		Build(); err == nil {
+		t.Fatal("bundle of both a content and an interest block was accepted")
+	}
+
+	if _, err := Builder().
+		Source("dtn://village/").
+		Destination("dtn://weather/").
+		CreationTimestampNow().
+		Lifetime("24h").
+		InterestBlock("").
+		PayloadBlock([]byte{}).
+		Build(); err == nil {
+		t.Fatal("interest block of an empty name was accepted")
+	}
+}
@@ -0,0 +1,94 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package bpv7
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/dtn7/cboring"
+)
+
+// CustodyTransferBlock requests custody transfer for its Bundle and names the Bundle's current custodian.
+//
+// A node accepting custody informs the named custodian by a CustodySignal and replaces the custodian by itself.
+// Until then, the custodian must retain the Bundle and retransmit it if no CustodySignal arrives in time.
+type CustodyTransferBlock EndpointID
+
+// BlockTypeCode must return a constant integer, indicating the block type code.
+func (ctb *CustodyTransferBlock) BlockTypeCode() uint64 {
+	return ExtBlockTypeCustodyTransferBlock
+}
+
+// BlockTypeName must return a constant string, this block's name.
+func (ctb *CustodyTransferBlock) BlockTypeName() string {
+	return "Custody Transfer Block"
+}
+
+// NewCustodyTransferBlock creates a new Custody Transfer Block for the current custodian. A locally created Bundle
+// might use dtn:none, which is replaced by the first node accepting custody.
+func NewCustodyTransferBlock(custodian EndpointID) *CustodyTransferBlock {
+	ctb := CustodyTransferBlock(custodian)
+	return &ctb
+}
+
+// Custodian returns the current custodian's Endpoint ID.
+func (ctb *CustodyTransferBlock) Custodian() EndpointID {
+	return EndpointID(*ctb)
+}
+
+// MarshalCbor writes the CBOR representation of a CustodyTransferBlock.
+func (ctb *CustodyTransferBlock) MarshalCbor(w io.Writer) error {
+	endpoint := EndpointID(*ctb)
+	return cboring.Marshal(&endpoint, w)
+}
+
+// UnmarshalCbor reads a CBOR representation of a CustodyTransferBlock.
+func (ctb *CustodyTransferBlock) UnmarshalCbor(r io.Reader) error {
+	endpoint := EndpointID{}
+	if err := cboring.Unmarshal(&endpoint, r); err != nil {
+		return err
+	}
+
+	*ctb = CustodyTransferBlock(endpoint)
+	return nil
+}
+
+// MarshalJSON writes the JSON representation of a CustodyTransferBlock.
+func (ctb *CustodyTransferBlock) MarshalJSON() ([]byte, error) {
+	return json.Marshal(ctb.Custodian())
+}
+
+// UnmarshalJSON reads the JSON representation of a CustodyTransferBlock, as created by MarshalJSON.
+func (ctb *CustodyTransferBlock) UnmarshalJSON(data []byte) error {
+	var eid EndpointID
+	if err := json.Unmarshal(data, &eid); err != nil {
+		return err
+	}
+
+	*ctb = CustodyTransferBlock(eid)
+	return nil
+}
+
+// CheckValid returns an array of errors for incorrect data.
+func (ctb *CustodyTransferBlock) CheckValid() error {
+	return EndpointID(*ctb).CheckValid()
+}
+
+// CheckContextValid that there is at most one Custody Transfer Block and that the Bundle is no administrative record.
+func (ctb *CustodyTransferBlock) CheckContextValid(b *Bundle) error {
+	cb, err := b.ExtensionBlock(ExtBlockTypeCustodyTransferBlock)
+
+	if err != nil {
+		return err
+	} else if cb.Value != ctb {
+		return fmt.Errorf("CustodyTransferBlock's pointer differs, %p != %p", cb.Value, ctb)
+	} else if b.IsAdministrativeRecord() {
+		return fmt.Errorf("CustodyTransferBlock must not be attached to an administrative record")
+	} else {
+		return nil
+	}
+}
@@ -8,6 +8,7 @@ package bpv7
 import (
 	"fmt"
 	"io"
+	"sort"
 
 	"github.com/dtn7/cboring"
 )
@@ -34,6 +35,18 @@ func (pd DTLSRPeerData) ShouldReplace(other DTLSRPeerData) bool {
 	return pd.Timestamp > other.Timestamp
 }
 
+// ConnectedPeers returns the peers still connected to the sending node, ordered by their EndpointIDs.
+func (pd DTLSRPeerData) ConnectedPeers() (peers []EndpointID) {
+	for peer, timestamp := range pd.Peers {
+		if timestamp == 0 {
+			peers = append(peers, peer)
+		}
+	}
+
+	sort.Slice(peers, func(i, j int) bool { return peers[i].String() < peers[j].String() })
+	return
+}
+
 // DTLSRBlock contains metadata used by the "Delay-Tolerant Link State Routing"-algorithm.
 // It is a basic transmission-encapsulation of the DTLSRPeerData type,.
 //
@@ -99,6 +99,20 @@ func (hcb *HopCountBlock) MarshalJSON() ([]byte, error) {
 	}{hcb.Limit, hcb.Count})
 }
 
+// UnmarshalJSON reads a JSON representation of a Hop Count Block, as created by MarshalJSON.
+func (hcb *HopCountBlock) UnmarshalJSON(data []byte) error {
+	var tmp struct {
+		Limit uint8 `json:"limit"`
+		Count uint8 `json:"count"`
+	}
+	if err := json.Unmarshal(data, &tmp); err != nil {
+		return err
+	}
+
+	hcb.Limit, hcb.Count = tmp.Limit, tmp.Count
+	return nil
+}
+
 // CheckValid returns an array of errors for incorrect data.
 func (hcb *HopCountBlock) CheckValid() error {
 	if hcb.IsExceeded() {
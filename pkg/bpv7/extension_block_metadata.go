@@ -0,0 +1,131 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package bpv7
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/dtn7/cboring"
+)
+
+// MetadataBlock annotates a Bundle with application-defined key-value pairs, e.g., a content type or a test run's
+// identifier, without touching the payload.
+type MetadataBlock map[string]string
+
+// BlockTypeCode must return a constant integer, indicating the block type code.
+func (mb *MetadataBlock) BlockTypeCode() uint64 {
+	return ExtBlockTypeMetadataBlock
+}
+
+// BlockTypeName must return a constant string, this block's name.
+func (mb *MetadataBlock) BlockTypeName() string {
+	return "Metadata Block"
+}
+
+// NewMetadataBlock creates a new MetadataBlock of the given key-value pairs.
+func NewMetadataBlock(metadata map[string]string) *MetadataBlock {
+	mb := make(MetadataBlock, len(metadata))
+	for key, value := range metadata {
+		mb[key] = value
+	}
+	return &mb
+}
+
+// Metadata returns this MetadataBlock's key-value pairs.
+func (mb *MetadataBlock) Metadata() map[string]string {
+	return *mb
+}
+
+// MarshalCbor writes the CBOR representation of a MetadataBlock, a map of text strings ordered by their keys.
+func (mb *MetadataBlock) MarshalCbor(w io.Writer) error {
+	keys := make([]string, 0, len(*mb))
+	for key := range *mb {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	if err := cboring.WriteMapPairLength(uint64(len(keys)), w); err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if err := cboring.WriteTextString(key, w); err != nil {
+			return err
+		}
+		if err := cboring.WriteTextString((*mb)[key], w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UnmarshalCbor reads the CBOR representation of a MetadataBlock.
+func (mb *MetadataBlock) UnmarshalCbor(r io.Reader) error {
+	n, err := cboring.ReadMapPairLength(r)
+	if err != nil {
+		return err
+	}
+
+	metadata := make(MetadataBlock)
+	for i := uint64(0); i < n; i++ {
+		key, err := cboring.ReadTextString(r)
+		if err != nil {
+			return err
+		}
+		value, err := cboring.ReadTextString(r)
+		if err != nil {
+			return err
+		}
+
+		if _, exists := metadata[key]; exists {
+			return fmt.Errorf("MetadataBlock: duplicate key %q", key)
+		}
+		metadata[key] = value
+	}
+
+	*mb = metadata
+	return nil
+}
+
+// MarshalJSON writes the JSON representation of a MetadataBlock, an object of its key-value pairs.
+func (mb *MetadataBlock) MarshalJSON() ([]byte, error) {
+	return json.Marshal(mb.Metadata())
+}
+
+// CheckValid checks for at least one key-value pair and non-empty keys.
+func (mb *MetadataBlock) CheckValid() error {
+	if len(*mb) == 0 {
+		return fmt.Errorf("MetadataBlock: no key-value pairs")
+	}
+	for key := range *mb {
+		if key == "" {
+			return fmt.Errorf("MetadataBlock: empty key")
+		}
+	}
+	return nil
+}
+
+// CheckContextValid that there is at most one Metadata Block.
+func (mb *MetadataBlock) CheckContextValid(b *Bundle) error {
+	cb, err := b.ExtensionBlock(ExtBlockTypeMetadataBlock)
+
+	if err != nil {
+		return err
+	} else if cb.Value != mb {
+		return fmt.Errorf("MetadataBlock's pointer differs, %p != %p", cb.Value, mb)
+	} else {
+		return nil
+	}
+}
+
+// Metadata of this Bundle, as given by its MetadataBlock. Bundles without a MetadataBlock return false.
+func (b Bundle) Metadata() (metadata map[string]string, ok bool) {
+	if cb, err := b.ExtensionBlock(ExtBlockTypeMetadataBlock); err == nil {
+		return cb.Value.(*MetadataBlock).Metadata(), true
+	}
+	return
+}
@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package bpv7
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestBundleMetadata(t *testing.T) {
+	metadata := map[string]string{"content-type": "text/plain", "run": "42"}
+
+	b, err := BuildFromMap(map[string]interface{}{
+		"destination":            "dtn://dst/",
+		"source":                 "dtn://src/",
+		"creation_timestamp_now": true,
+		"lifetime":               "24h",
+		"metadata_block":         map[string]interface{}{"content-type": "text/plain", "run": "42"},
+		"payload_block":          "hello metadata",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buff := new(bytes.Buffer)
+	if err := b.MarshalCbor(buff); err != nil {
+		t.Fatal(err)
+	}
+	b2, err := ParseBundle(buff)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if md, ok := b2.Metadata(); !ok || !reflect.DeepEqual(md, metadata) {
+		t.Fatalf("expected metadata %v, got %v (%t)", metadata, md, ok)
+	}
+
+	for _, invalid := range []map[string]string{{}, {"": "empty key"}} {
+		if _, err := Builder().
+			Source("dtn://src/").
+			Destination("dtn://dst/").
+			CreationTimestampNow().
+			Lifetime("10m").
+			MetadataBlock(invalid).
+			PayloadBlock([]byte("hello metadata")).
+			Build(); err == nil {
+			t.Fatalf("invalid metadata %v was accepted", invalid)
+		}
+	}
+}
+
+func TestMetadataBlockCborDeterministic(t *testing.T) {
+	mb := NewMetadataBlock(map[string]string{"b": "2", "a": "1", "c": "3"})
+
+	var first bytes.Buffer
+	if err := mb.MarshalCbor(&first); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 10; i++ {
+		var buff bytes.Buffer
+		if err := mb.MarshalCbor(&buff); err != nil {
+			t.Fatal(err)
+		} else if !bytes.Equal(first.Bytes(), buff.Bytes()) {
+			t.Fatalf("CBOR representation changed: %x != %x", first.Bytes(), buff.Bytes())
+		}
+	}
+}
@@ -48,6 +48,17 @@ func (pb *PayloadBlock) MarshalJSON() ([]byte, error) {
 	return json.Marshal(pb.Data())
 }
 
+// UnmarshalJSON reads the base64 encoded payload, as created by MarshalJSON.
+func (pb *PayloadBlock) UnmarshalJSON(data []byte) error {
+	var payload []byte
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+
+	*pb = payload
+	return nil
+}
+
 // CheckValid returns an array of errors for incorrect data.
 func (pb *PayloadBlock) CheckValid() error {
 	return nil
@@ -0,0 +1,279 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package bpv7
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"sync"
+)
+
+// streamingBlock is an ExtensionBlock whose block-type-specific data is written as a stream by WriteBlock, instead of
+// being serialized into memory first.
+type streamingBlock interface {
+	ExtensionBlock
+
+	// Len returns the length of the block-type-specific data.
+	Len() uint64
+
+	// writeData writes exactly Len bytes of block-type-specific data.
+	writeData(w io.Writer) error
+}
+
+// payloadStream configures when parsed payloads are stored on disk, see SetPayloadStreamThreshold.
+var payloadStream struct {
+	threshold uint64
+	dir       string
+	mutex     sync.Mutex
+}
+
+// SetPayloadStreamThreshold configures parsing of payloads larger than the threshold in bytes into a temporary file
+// within the given directory, resulting in a StreamPayloadBlock. An empty directory results in os.TempDir. A zero
+// threshold, the default, disables this behavior and keeps all payloads in memory. As bundles are parsed independently
+// of their receiving node, this setting applies to the whole process; the temporary files' names do not collide.
+func SetPayloadStreamThreshold(threshold uint64, dir string) {
+	payloadStream.mutex.Lock()
+	defer payloadStream.mutex.Unlock()
+
+	payloadStream.threshold = threshold
+	payloadStream.dir = dir
+}
+
+// PayloadStreamConfig returns the values configured by SetPayloadStreamThreshold, e.g., for a CLA to spool large
+// incoming transfers to disk as well.
+func PayloadStreamConfig() (threshold uint64, dir string) {
+	payloadStream.mutex.Lock()
+	defer payloadStream.mutex.Unlock()
+
+	return payloadStream.threshold, payloadStream.dir
+}
+
+// StreamPayloadBlock is a Payload Block backed by an io.Reader of a known length, e.g., a file. Its payload is streamed
+// while being serialized and thus is never fully resident in memory.
+//
+// If the io.Reader also implements io.ReaderAt or io.Seeker, the StreamPayloadBlock might be serialized multiple
+// times. Otherwise, the payload can only be read once.
+type StreamPayloadBlock struct {
+	reader io.Reader
+	length uint64
+
+	// file is a temporary file, created while parsing, which is removed on Close.
+	file *os.File
+
+	consumed bool
+	mutex    sync.Mutex
+}
+
+// NewStreamPayloadBlock creates a new StreamPayloadBlock for a payload of length bytes, read from the io.Reader.
+func NewStreamPayloadBlock(r io.Reader, length uint64) *StreamPayloadBlock {
+	return &StreamPayloadBlock{
+		reader: r,
+		length: length,
+	}
+}
+
+// newTempStreamPayloadBlock copies length bytes of payload from the io.Reader into a temporary file within dir and
+// creates a StreamPayloadBlock for this file. The file is removed on Close or when the block gets garbage collected.
+func newTempStreamPayloadBlock(r io.Reader, length uint64, dir string) (*StreamPayloadBlock, error) {
+	f, err := os.CreateTemp(dir, "payload-")
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := io.CopyN(f, r, int64(length)); err != nil {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+		return nil, err
+	}
+
+	return NewFileStreamPayloadBlock(f, length), nil
+}
+
+// NewFileStreamPayloadBlock creates a StreamPayloadBlock for the first length bytes of a temporary file, e.g., a
+// payload spooled to disk while being received. The StreamPayloadBlock takes ownership of the file, which is removed on
+// Close or when the block gets garbage collected.
+func NewFileStreamPayloadBlock(f *os.File, length uint64) *StreamPayloadBlock {
+	spb := NewStreamPayloadBlock(f, length)
+	spb.file = f
+	runtime.SetFinalizer(spb, func(spb *StreamPayloadBlock) { _ = spb.Close() })
+
+	return spb
+}
+
+// BlockTypeCode must return a constant integer, indicating the block type code.
+func (spb *StreamPayloadBlock) BlockTypeCode() uint64 {
+	return ExtBlockTypePayloadBlock
+}
+
+// BlockTypeName must return a constant string, this block's name.
+func (spb *StreamPayloadBlock) BlockTypeName() string {
+	return "Payload Block"
+}
+
+// Len returns the payload's length in bytes.
+func (spb *StreamPayloadBlock) Len() uint64 {
+	return spb.length
+}
+
+// Reader returns an io.Reader for the payload, starting at its beginning.
+func (spb *StreamPayloadBlock) Reader() (io.Reader, error) {
+	spb.mutex.Lock()
+	defer spb.mutex.Unlock()
+
+	switch r := spb.reader.(type) {
+	case io.ReaderAt:
+		return io.NewSectionReader(r, 0, int64(spb.length)), nil
+
+	case io.Seeker:
+		if _, err := r.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+		return io.LimitReader(spb.reader, int64(spb.length)), nil
+
+	default:
+		if spb.consumed {
+			return nil, fmt.Errorf("payload reader was already consumed")
+		}
+		spb.consumed = true
+		return io.LimitReader(spb.reader, int64(spb.length)), nil
+	}
+}
+
+// writeData streams the payload into the io.Writer.
+func (spb *StreamPayloadBlock) writeData(w io.Writer) error {
+	r, err := spb.Reader()
+	if err != nil {
+		return err
+	}
+
+	if n, err := io.Copy(w, r); err != nil {
+		return err
+	} else if uint64(n) != spb.length {
+		return fmt.Errorf("payload reader returned %d bytes instead of %d", n, spb.length)
+	}
+	return nil
+}
+
+// Data reads and returns the whole payload. This requires the payload to fit in memory.
+func (spb *StreamPayloadBlock) Data() ([]byte, error) {
+	var buff bytes.Buffer
+	if err := spb.writeData(&buff); err != nil {
+		return nil, err
+	}
+	return buff.Bytes(), nil
+}
+
+// MarshalBinary writes the binary representation of a StreamPayloadBlock, which requires reading the whole payload.
+func (spb *StreamPayloadBlock) MarshalBinary() ([]byte, error) {
+	return spb.Data()
+}
+
+// UnmarshalBinary reads a binary StreamPayloadBlock, which is backed by the data in memory.
+func (spb *StreamPayloadBlock) UnmarshalBinary(data []byte) error {
+	spb.mutex.Lock()
+	defer spb.mutex.Unlock()
+
+	spb.reader = bytes.NewReader(data)
+	spb.length = uint64(len(data))
+	return nil
+}
+
+// MarshalJSON writes the payload like a PayloadBlock, which requires reading the whole payload.
+func (spb *StreamPayloadBlock) MarshalJSON() ([]byte, error) {
+	data, err := spb.Data()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(data)
+}
+
+// CheckValid returns an array of errors for incorrect data.
+func (spb *StreamPayloadBlock) CheckValid() error {
+	return nil
+}
+
+// CheckContextValid has no implementation for a Payload Block.
+func (spb *StreamPayloadBlock) CheckContextValid(*Bundle) error {
+	return nil
+}
+
+// Close removes a temporary file, created while parsing. Afterwards, the payload is no longer available.
+func (spb *StreamPayloadBlock) Close() error {
+	spb.mutex.Lock()
+	defer spb.mutex.Unlock()
+
+	if spb.file == nil {
+		return nil
+	}
+
+	f := spb.file
+	spb.file = nil
+	_ = f.Close()
+	return os.Remove(f.Name())
+}
+
+// payloadBlockData returns the payload of a PayloadBlock or a StreamPayloadBlock.
+func payloadBlockData(eb ExtensionBlock) ([]byte, error) {
+	switch pb := eb.(type) {
+	case *PayloadBlock:
+		return pb.Data(), nil
+	case *StreamPayloadBlock:
+		return pb.Data()
+	default:
+		return nil, fmt.Errorf("block type %d is no payload block", eb.BlockTypeCode())
+	}
+}
+
+// payloadBlockSize returns the payload's length of a PayloadBlock or a StreamPayloadBlock, without reading the payload.
+func payloadBlockSize(eb ExtensionBlock) uint64 {
+	switch pb := eb.(type) {
+	case *PayloadBlock:
+		return uint64(len(pb.Data()))
+	case *StreamPayloadBlock:
+		return pb.Len()
+	default:
+		return 0
+	}
+}
+
+// PayloadData returns this Bundle's payload. For a StreamPayloadBlock, the whole payload is read into memory.
+func (b *Bundle) PayloadData() ([]byte, error) {
+	payloadBlock, err := b.PayloadBlock()
+	if err != nil {
+		return nil, err
+	}
+	return payloadBlockData(payloadBlock.Value)
+}
+
+// PayloadLen returns the length of this Bundle's payload, without reading the payload.
+func (b *Bundle) PayloadLen() (uint64, error) {
+	payloadBlock, err := b.PayloadBlock()
+	if err != nil {
+		return 0, err
+	}
+	return payloadBlockSize(payloadBlock.Value), nil
+}
+
+// PayloadReader returns an io.Reader for this Bundle's payload. Unlike PayloadData, a StreamPayloadBlock's payload is
+// not read into memory, but streamed from its underlying io.Reader.
+func (b *Bundle) PayloadReader() (io.Reader, error) {
+	payloadBlock, err := b.PayloadBlock()
+	if err != nil {
+		return nil, err
+	}
+
+	switch pb := payloadBlock.Value.(type) {
+	case *PayloadBlock:
+		return bytes.NewReader(pb.Data()), nil
+	case *StreamPayloadBlock:
+		return pb.Reader()
+	default:
+		return nil, fmt.Errorf("block type %d is no payload block", pb.BlockTypeCode())
+	}
+}
@@ -0,0 +1,152 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package bpv7
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"testing"
+)
+
+func TestStreamPayloadBlock(t *testing.T) {
+	payload := bytes.Repeat([]byte("hello world "), 1024)
+
+	bndlMem, err := Builder().
+		CRC(CRC32).
+		Source("dtn://src/").
+		Destination("dtn://dst/").
+		CreationTimestampNow().
+		Lifetime("10m").
+		PayloadBlock(payload).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bndlStream := bndlMem
+	bndlStream.CanonicalBlocks = []CanonicalBlock{bndlMem.CanonicalBlocks[0]}
+	bndlStream.CanonicalBlocks[0].Value = NewStreamPayloadBlock(bytes.NewReader(payload), uint64(len(payload)))
+
+	buffMem, buffStream := new(bytes.Buffer), new(bytes.Buffer)
+	if err := bndlMem.WriteBundle(buffMem); err != nil {
+		t.Fatal(err)
+	}
+	// A StreamPayloadBlock backed by an io.ReaderAt might be serialized multiple times.
+	for i := 0; i < 2; i++ {
+		buffStream.Reset()
+		if err := bndlStream.WriteBundle(buffStream); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if !bytes.Equal(buffMem.Bytes(), buffStream.Bytes()) {
+		t.Fatal("serialization of StreamPayloadBlock differs from PayloadBlock")
+	}
+}
+
+func TestStreamPayloadBlockSingleUse(t *testing.T) {
+	spb := NewStreamPayloadBlock(bytes.NewBufferString("hello world"), 11)
+
+	if data, err := spb.Data(); err != nil {
+		t.Fatal(err)
+	} else if string(data) != "hello world" {
+		t.Fatalf("expected hello world, got %s", data)
+	}
+
+	if _, err := spb.Data(); err == nil {
+		t.Fatal("consumed io.Reader was read twice")
+	}
+}
+
+func TestSetPayloadStreamThreshold(t *testing.T) {
+	dir := t.TempDir()
+	SetPayloadStreamThreshold(1024, dir)
+	defer SetPayloadStreamThreshold(0, "")
+
+	for _, payloadLen := range []int{1024, 1025, 64 * 1024} {
+		payload := bytes.Repeat([]byte{0x23}, payloadLen)
+
+		bndl, err := Builder().
+			CRC(CRC32).
+			Source("dtn://src/").
+			Destination("dtn://dst/").
+			CreationTimestampNow().
+			Lifetime("10m").
+			HopCountBlock(64).
+			PayloadBlock(payload).
+			Build()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		buff := new(bytes.Buffer)
+		if err := bndl.WriteBundle(buff); err != nil {
+			t.Fatal(err)
+		}
+		bndlData := buff.Bytes()
+
+		bndl2, err := ParseBundle(bytes.NewReader(bndlData))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		payloadBlock, err := bndl2.PayloadBlock()
+		if err != nil {
+			t.Fatal(err)
+		}
+		spb, isStream := payloadBlock.Value.(*StreamPayloadBlock)
+		if isStream != (payloadLen > 1024) {
+			t.Fatalf("payload of %d bytes resulted in a %T", payloadLen, payloadBlock.Value)
+		}
+
+		if data, err := bndl2.PayloadData(); err != nil {
+			t.Fatal(err)
+		} else if !bytes.Equal(payload, data) {
+			t.Fatalf("payload of %d bytes differs after parsing", payloadLen)
+		}
+
+		if n, err := bndl2.PayloadLen(); err != nil {
+			t.Fatal(err)
+		} else if n != uint64(payloadLen) {
+			t.Fatalf("expected payload length %d, got %d", payloadLen, n)
+		}
+
+		if r, err := bndl2.PayloadReader(); err != nil {
+			t.Fatal(err)
+		} else if data, err := io.ReadAll(r); err != nil {
+			t.Fatal(err)
+		} else if !bytes.Equal(payload, data) {
+			t.Fatalf("payload reader of %d bytes differs after parsing", payloadLen)
+		}
+
+		buff2 := new(bytes.Buffer)
+		if err := bndl2.WriteBundle(buff2); err != nil {
+			t.Fatal(err)
+		} else if !bytes.Equal(bndlData, buff2.Bytes()) {
+			t.Fatalf("serialization of %d bytes payload differs after parsing", payloadLen)
+		}
+
+		if !isStream {
+			continue
+		}
+
+		if files, err := os.ReadDir(dir); err != nil {
+			t.Fatal(err)
+		} else if len(files) != 1 {
+			t.Fatalf("expected one temporary payload file, got %d", len(files))
+		}
+
+		if err := spb.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		if files, err := os.ReadDir(dir); err != nil {
+			t.Fatal(err)
+		} else if len(files) != 0 {
+			t.Fatalf("expected no temporary payload file after Close, got %d", len(files))
+		}
+	}
+}
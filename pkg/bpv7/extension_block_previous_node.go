@@ -58,6 +58,17 @@ func (pnb *PreviousNodeBlock) MarshalJSON() ([]byte, error) {
 	return json.Marshal(pnb.Endpoint())
 }
 
+// UnmarshalJSON reads the JSON representation of a PreviousNodeBlock, as created by MarshalJSON.
+func (pnb *PreviousNodeBlock) UnmarshalJSON(data []byte) error {
+	var eid EndpointID
+	if err := json.Unmarshal(data, &eid); err != nil {
+		return err
+	}
+
+	*pnb = PreviousNodeBlock(eid)
+	return nil
+}
+
 // CheckValid returns an array of errors for incorrect data.
 func (pnb *PreviousNodeBlock) CheckValid() error {
 	return EndpointID(*pnb).CheckValid()
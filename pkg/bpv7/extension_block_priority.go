@@ -0,0 +1,144 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package bpv7
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/dtn7/cboring"
+)
+
+// Priority of a Bundle, ordering its forwarding, transmission, and eviction under storage pressure.
+type Priority uint8
+
+const (
+	// PriorityBulk is the lowest priority, e.g., for telemetry.
+	PriorityBulk Priority = 0
+
+	// PriorityNormal is the default priority of Bundles without a PriorityBlock.
+	PriorityNormal Priority = 1
+
+	// PriorityExpedited is the highest priority, e.g., for emergency traffic.
+	PriorityExpedited Priority = 2
+)
+
+func (p Priority) String() string {
+	switch p {
+	case PriorityBulk:
+		return "bulk"
+
+	case PriorityNormal:
+		return "normal"
+
+	case PriorityExpedited:
+		return "expedited"
+
+	default:
+		return "unknown"
+	}
+}
+
+// ParsePriority from its string representation, as returned by String.
+func ParsePriority(s string) (Priority, error) {
+	for _, p := range []Priority{PriorityBulk, PriorityNormal, PriorityExpedited} {
+		if p.String() == s {
+			return p, nil
+		}
+	}
+	return PriorityNormal, fmt.Errorf("unknown priority %q, expected bulk, normal, or expedited", s)
+}
+
+// PriorityBlock carries a Bundle's Priority. As BPv7 lacks BPv6's class of service, this custom block restores it.
+type PriorityBlock Priority
+
+// BlockTypeCode must return a constant integer, indicating the block type code.
+func (pb *PriorityBlock) BlockTypeCode() uint64 {
+	return ExtBlockTypePriorityBlock
+}
+
+// BlockTypeName must return a constant string, this block's name.
+func (pb *PriorityBlock) BlockTypeName() string {
+	return "Priority Block"
+}
+
+// NewPriorityBlock creates a new PriorityBlock for a Priority.
+func NewPriorityBlock(priority Priority) *PriorityBlock {
+	pb := PriorityBlock(priority)
+	return &pb
+}
+
+// Priority returns this PriorityBlock's Priority.
+func (pb *PriorityBlock) Priority() Priority {
+	return Priority(*pb)
+}
+
+// MarshalCbor writes the CBOR representation of a PriorityBlock.
+func (pb *PriorityBlock) MarshalCbor(w io.Writer) error {
+	return cboring.WriteUInt(uint64(*pb), w)
+}
+
+// UnmarshalCbor reads the CBOR representation of a PriorityBlock.
+func (pb *PriorityBlock) UnmarshalCbor(r io.Reader) error {
+	if n, err := cboring.ReadUInt(r); err != nil {
+		return err
+	} else if n > uint64(PriorityExpedited) {
+		return fmt.Errorf("PriorityBlock: unknown priority %d", n)
+	} else {
+		*pb = PriorityBlock(n)
+		return nil
+	}
+}
+
+// MarshalJSON writes the JSON representation of a PriorityBlock, e.g., "expedited".
+func (pb *PriorityBlock) MarshalJSON() ([]byte, error) {
+	return json.Marshal(pb.Priority().String())
+}
+
+// UnmarshalJSON reads the JSON representation of a PriorityBlock, as created by MarshalJSON.
+func (pb *PriorityBlock) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	priority, err := ParsePriority(s)
+	if err != nil {
+		return err
+	}
+
+	*pb = PriorityBlock(priority)
+	return nil
+}
+
+// CheckValid checks for a known Priority.
+func (pb *PriorityBlock) CheckValid() error {
+	if pb.Priority() > PriorityExpedited {
+		return fmt.Errorf("PriorityBlock: unknown priority %d", *pb)
+	}
+	return nil
+}
+
+// CheckContextValid that there is at most one Priority Block.
+func (pb *PriorityBlock) CheckContextValid(b *Bundle) error {
+	cb, err := b.ExtensionBlock(ExtBlockTypePriorityBlock)
+
+	if err != nil {
+		return err
+	} else if cb.Value != pb {
+		return fmt.Errorf("PriorityBlock's pointer differs, %p != %p", cb.Value, pb)
+	} else {
+		return nil
+	}
+}
+
+// Priority of this Bundle, as given by its PriorityBlock. Bundles without a PriorityBlock have PriorityNormal.
+func (b Bundle) Priority() Priority {
+	if cb, err := b.ExtensionBlock(ExtBlockTypePriorityBlock); err == nil {
+		return cb.Value.(*PriorityBlock).Priority()
+	}
+	return PriorityNormal
+}
@@ -0,0 +1,62 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package bpv7
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestBundlePriority(t *testing.T) {
+	tests := []struct {
+		priority interface{}
+		expected Priority
+	}{
+		{nil, PriorityNormal},
+		{PriorityBulk, PriorityBulk},
+		{"expedited", PriorityExpedited},
+	}
+
+	for _, test := range tests {
+		bldr := Builder().
+			Source("dtn://src/").
+			Destination("dtn://dst/").
+			CreationTimestampNow().
+			Lifetime("10m")
+		if test.priority != nil {
+			bldr = bldr.PriorityBlock(test.priority)
+		}
+
+		b, err := bldr.PayloadBlock([]byte("hello world")).Build()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		buff := new(bytes.Buffer)
+		if err := b.MarshalCbor(buff); err != nil {
+			t.Fatal(err)
+		}
+		b2, err := ParseBundle(buff)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if p := b2.Priority(); p != test.expected {
+			t.Fatalf("expected priority %v, got %v", test.expected, p)
+		}
+	}
+}
+
+func TestParsePriority(t *testing.T) {
+	for _, p := range []Priority{PriorityBulk, PriorityNormal, PriorityExpedited} {
+		if p2, err := ParsePriority(p.String()); err != nil || p2 != p {
+			t.Fatalf("expected %v, got %v: %v", p, p2, err)
+		}
+	}
+
+	if _, err := ParsePriority("urgent"); err == nil {
+		t.Fatal("unknown priority was parsed")
+	}
+}
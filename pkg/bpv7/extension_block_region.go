@@ -0,0 +1,87 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package bpv7
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/dtn7/cboring"
+)
+
+// RegionBlock tags a Bundle with the routing area it is confined to, e.g., a broadcast of routing metadata. Nodes of
+// other regions drop such a Bundle instead of forwarding it.
+type RegionBlock string
+
+// BlockTypeCode must return a constant integer, indicating the block type code.
+func (rb *RegionBlock) BlockTypeCode() uint64 {
+	return ExtBlockTypeRegionBlock
+}
+
+// BlockTypeName must return a constant string, this block's name.
+func (rb *RegionBlock) BlockTypeName() string {
+	return "Region Block"
+}
+
+// NewRegionBlock creates a new RegionBlock for a region's name.
+func NewRegionBlock(region string) *RegionBlock {
+	rb := RegionBlock(region)
+	return &rb
+}
+
+// Region returns this RegionBlock's region name.
+func (rb *RegionBlock) Region() string {
+	return string(*rb)
+}
+
+// MarshalCbor writes the CBOR representation of a RegionBlock, a text string.
+func (rb *RegionBlock) MarshalCbor(w io.Writer) error {
+	return cboring.WriteTextString(rb.Region(), w)
+}
+
+// UnmarshalCbor reads the CBOR representation of a RegionBlock.
+func (rb *RegionBlock) UnmarshalCbor(r io.Reader) error {
+	if region, err := cboring.ReadTextString(r); err != nil {
+		return err
+	} else {
+		*rb = RegionBlock(region)
+		return nil
+	}
+}
+
+// MarshalJSON writes the JSON representation of a RegionBlock, its region name.
+func (rb *RegionBlock) MarshalJSON() ([]byte, error) {
+	return json.Marshal(rb.Region())
+}
+
+// CheckValid checks for a non-empty region name.
+func (rb *RegionBlock) CheckValid() error {
+	if rb.Region() == "" {
+		return fmt.Errorf("RegionBlock: empty region")
+	}
+	return nil
+}
+
+// CheckContextValid that there is at most one Region Block.
+func (rb *RegionBlock) CheckContextValid(b *Bundle) error {
+	cb, err := b.ExtensionBlock(ExtBlockTypeRegionBlock)
+
+	if err != nil {
+		return err
+	} else if cb.Value != rb {
+		return fmt.Errorf("RegionBlock's pointer differs, %p != %p", cb.Value, rb)
+	} else {
+		return nil
+	}
+}
+
+// Region of this Bundle, as given by its RegionBlock. Bundles without a RegionBlock return false.
+func (b Bundle) Region() (region string, ok bool) {
+	if cb, err := b.ExtensionBlock(ExtBlockTypeRegionBlock); err == nil {
+		return cb.Value.(*RegionBlock).Region(), true
+	}
+	return
+}
@@ -0,0 +1,48 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package bpv7
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestBundleRegion(t *testing.T) {
+	b, err := Builder().
+		Source("dtn://src/").
+		Destination("dtn://routing/broadcast/").
+		CreationTimestampNow().
+		Lifetime("10m").
+		RegionBlock("north").
+		PayloadBlock([]byte("hello region")).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buff := new(bytes.Buffer)
+	if err := b.MarshalCbor(buff); err != nil {
+		t.Fatal(err)
+	}
+	b2, err := ParseBundle(buff)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if region, ok := b2.Region(); !ok || region != "north" {
+		t.Fatalf("expected region north, got %q (%t)", region, ok)
+	}
+
+	if _, err := Builder().
+		Source("dtn://src/").
+		Destination("dtn://dst/").
+		CreationTimestampNow().
+		Lifetime("10m").
+		RegionBlock("").
+		PayloadBlock([]byte("hello region")).
+		Build(); err == nil {
+		t.Fatal("empty region was accepted")
+	}
+}
@@ -0,0 +1,148 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package bpv7
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/dtn7/cboring"
+)
+
+// ShardBlock marks a Bundle as an erasure-coded shard of another Bundle, as created by Bundle.EncodeShards. Any DataShards of
+// a Bundle's TotalShards shards suffice to reassemble it by ReassembleShards.
+type ShardBlock struct {
+	// Bundle is the BundleID of the sharded Bundle.
+	Bundle BundleID
+	// Index of this shard; the first DataShards shards carry the serialized Bundle, the others parity data.
+	Index       uint64
+	DataShards  uint64
+	TotalShards uint64
+	// Length of the serialized Bundle in bytes, which is padded to be split into shards of the same size.
+	Length uint64
+}
+
+// BlockTypeCode must return a constant integer, indicating the block type code.
+func (sb *ShardBlock) BlockTypeCode() uint64 {
+	return ExtBlockTypeShardBlock
+}
+
+// BlockTypeName must return a constant string, this block's name.
+func (sb *ShardBlock) BlockTypeName() string {
+	return "Shard Block"
+}
+
+// NewShardBlock creates a new ShardBlock for the index of a Bundle's shards.
+func NewShardBlock(bid BundleID, index, dataShards, totalShards, length uint64) *ShardBlock {
+	return &ShardBlock{
+		Bundle:      bid,
+		Index:       index,
+		DataShards:  dataShards,
+		TotalShards: totalShards,
+		Length:      length,
+	}
+}
+
+// MarshalCbor writes the CBOR representation of a ShardBlock, an array of the sharded BundleID, the index, the
+// number of data shards and of all shards, and the length.
+func (sb *ShardBlock) MarshalCbor(w io.Writer) error {
+	if err := cboring.WriteArrayLength(5, w); err != nil {
+		return err
+	}
+
+	if err := cboring.WriteArrayLength(sb.Bundle.Len(), w); err != nil {
+		return err
+	}
+	if err := sb.Bundle.MarshalCbor(w); err != nil {
+		return err
+	}
+
+	for _, f := range []uint64{sb.Index, sb.DataShards, sb.TotalShards, sb.Length} {
+		if err := cboring.WriteUInt(f, w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UnmarshalCbor reads the CBOR representation of a ShardBlock. The BundleID's array length indicates a fragment.
+func (sb *ShardBlock) UnmarshalCbor(r io.Reader) error {
+	if n, err := cboring.ReadArrayLength(r); err != nil {
+		return err
+	} else if n != 5 {
+		return fmt.Errorf("ShardBlock: expected an array of 5 elements, got %d", n)
+	}
+
+	n, err := cboring.ReadArrayLength(r)
+	if err != nil {
+		return err
+	} else if n != 2 && n != 4 {
+		return fmt.Errorf("ShardBlock: expected a BundleID of 2 or 4 elements, got %d", n)
+	}
+
+	sb.Bundle = BundleID{IsFragment: n == 4}
+	if err := sb.Bundle.UnmarshalCbor(r); err != nil {
+		return err
+	}
+
+	for _, f := range []*uint64{&sb.Index, &sb.DataShards, &sb.TotalShards, &sb.Length} {
+		if *f, err = cboring.ReadUInt(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MarshalJSON writes the JSON representation of a ShardBlock.
+func (sb *ShardBlock) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Bundle      string `json:"bundle"`
+		Index       uint64 `json:"index"`
+		DataShards  uint64 `json:"data_shards"`
+		TotalShards uint64 `json:"total_shards"`
+		Length      uint64 `json:"length"`
+	}{sb.Bundle.String(), sb.Index, sb.DataShards, sb.TotalShards, sb.Length})
+}
+
+// CheckValid checks the sharded BundleID and the shards' numbers.
+func (sb *ShardBlock) CheckValid() error {
+	if err := sb.Bundle.SourceNode.CheckValid(); err != nil {
+		return err
+	}
+
+	switch {
+	case sb.DataShards == 0:
+		return fmt.Errorf("ShardBlock: no data shards")
+	case sb.TotalShards < sb.DataShards || sb.TotalShards > maxShards:
+		return fmt.Errorf("ShardBlock: %d shards for %d data shards exceed the limit of %d",
+			sb.TotalShards, sb.DataShards, maxShards)
+	case sb.Index >= sb.TotalShards:
+		return fmt.Errorf("ShardBlock: index %d exceeds %d shards", sb.Index, sb.TotalShards)
+	default:
+		return nil
+	}
+}
+
+// CheckContextValid that there is at most one Shard Block.
+func (sb *ShardBlock) CheckContextValid(b *Bundle) error {
+	cb, err := b.ExtensionBlock(ExtBlockTypeShardBlock)
+
+	if err != nil {
+		return err
+	} else if cb.Value != sb {
+		return fmt.Errorf("ShardBlock's pointer differs, %p != %p", cb.Value, sb)
+	} else {
+		return nil
+	}
+}
+
+// Shard returns this Bundle's ShardBlock, if it is a shard of another Bundle.
+func (b Bundle) Shard() (sb ShardBlock, ok bool) {
+	if cb, err := b.ExtensionBlock(ExtBlockTypeShardBlock); err == nil {
+		return *cb.Value.(*ShardBlock), true
+	}
+	return
+}
@@ -28,6 +28,8 @@ import (
 //	sb, sbErr := bpv7.NewSignatureBlock(b, priv)
 //	b.AddExtensionBlock(bpv7.NewCanonicalBlock(0, bpv7.ReplicateBlock|bpv7.DeleteBundle, sb))
 //
+// The Bundle's Sign and VerifySignature methods wrap these steps for deployments trusting a known set of keys.
+//
 // The block-type-specific data in a SignatureBlock MUST be represented as a CBOR array comprising two elements. These
 // elements are firstly the PublicKey and secondly the Signature, both represented as a CBOR byte string. Both the array
 // and the byte strings MUST be of a defined length, NOT indefinite-length items.
@@ -188,3 +190,80 @@ func (s *SignatureBlock) UnmarshalCbor(r io.Reader) error {
 
 	return nil
 }
+
+// Sign this Bundle's Primary Block and Payload Block with an ed25519 private key by attaching a SignatureBlock. An
+// already existing SignatureBlock is replaced. As the Primary Block's CRC is part of the signature, CRC types must be
+// set before signing.
+func (b *Bundle) Sign(priv ed25519.PrivateKey) error {
+	if cbs, err := b.ExtensionBlocks(ExtBlockTypeSignatureBlock); err == nil {
+		for _, cb := range cbs {
+			b.RemoveExtensionBlockByBlockNumber(cb.BlockNumber)
+		}
+	}
+
+	sb, err := NewSignatureBlock(*b, priv)
+	if err != nil {
+		return err
+	}
+
+	cb := NewCanonicalBlock(0, ReplicateBlock|DeleteBundle, sb)
+	cb.SetCRCType(CRC32)
+	return b.AddExtensionBlock(cb)
+}
+
+// SignatureKeys maps EndpointIDs to their trusted ed25519 public keys, used to verify SignatureBlocks. A key for a node
+// ID, e.g., "dtn://foo/" or "ipn:23.0", covers all of this node's endpoints, e.g., "dtn://foo/bar" or "ipn:23.42".
+type SignatureKeys map[EndpointID]ed25519.PublicKey
+
+// isNodeID checks if an EndpointID identifies a whole node, e.g., "dtn://foo/" or "ipn:23.0".
+func isNodeID(eid EndpointID) bool {
+	switch et := eid.EndpointType.(type) {
+	case DtnEndpoint:
+		return !et.IsDtnNone && et.Demux == ""
+	case IpnEndpoint:
+		return et.Service == 0
+	default:
+		return false
+	}
+}
+
+// Lookup the trusted public key for an EndpointID. A key for the exact EndpointID is preferred over its node's key.
+func (keys SignatureKeys) Lookup(eid EndpointID) (pub ed25519.PublicKey, ok bool) {
+	if pub, ok = keys[eid]; ok {
+		return
+	}
+
+	for keyEid, keyPub := range keys {
+		if isNodeID(keyEid) && keyEid.SameNode(eid) {
+			return keyPub, true
+		}
+	}
+	return nil, false
+}
+
+// VerifySignature checks if this Bundle carries a SignatureBlock, created by its source node's trusted key.
+//
+// To parse received Bundles with SignatureBlocks, a SignatureBlock must be registered at the ExtensionBlockManager.
+func (b Bundle) VerifySignature(keys SignatureKeys) error {
+	src := b.PrimaryBlock.SourceNode
+	pub, ok := keys.Lookup(src)
+	if !ok {
+		return fmt.Errorf("no trusted key for source %v", src)
+	}
+
+	cb, err := b.ExtensionBlock(ExtBlockTypeSignatureBlock)
+	if err != nil {
+		return fmt.Errorf("bundle is not signed: %v", err)
+	}
+
+	sb, ok := cb.Value.(*SignatureBlock)
+	if !ok {
+		return fmt.Errorf("signature block has an unexpected type %T", cb.Value)
+	} else if !bytes.Equal(sb.PublicKey, pub) {
+		return fmt.Errorf("bundle is signed by an untrusted key for source %v", src)
+	} else if !sb.Verify(b) {
+		return fmt.Errorf("signature verification failed")
+	}
+
+	return nil
+}
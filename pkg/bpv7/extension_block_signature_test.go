@@ -289,3 +289,81 @@ func TestSignatureBlockFragmentSimple(t *testing.T) {
 		t.Fatal("Verification failed")
 	}
 }
+
+func TestBundleSignVerifySignature(t *testing.T) {
+	if regErr := GetExtensionBlockManager().Register(&SignatureBlock{}); regErr != nil {
+		t.Fatal(regErr)
+	}
+	defer GetExtensionBlockManager().Unregister(&SignatureBlock{})
+
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherPub, otherPriv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b1, err := Builder().
+		CRC(CRC32).
+		Source("dtn://src/app").
+		Destination("dtn://dst/").
+		CreationTimestampNow().
+		Lifetime("10m").
+		PayloadBlock([]byte("hello world")).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Signing twice replaces the first SignatureBlock.
+	for _, key := range []ed25519.PrivateKey{otherPriv, priv} {
+		if err := b1.Sign(key); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if cbs, _ := b1.ExtensionBlocks(ExtBlockTypeSignatureBlock); len(cbs) != 1 {
+		t.Fatalf("expected one SignatureBlock, got %d", len(cbs))
+	}
+
+	var buff bytes.Buffer
+	var b2 Bundle
+	if err := b1.MarshalCbor(&buff); err != nil {
+		t.Fatal(err)
+	} else if err := b2.UnmarshalCbor(&buff); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		keys  SignatureKeys
+		valid bool
+	}{
+		{"endpoint key", SignatureKeys{MustNewEndpointID("dtn://src/app"): pub}, true},
+		{"node key", SignatureKeys{MustNewEndpointID("dtn://src/"): pub}, true},
+		{"endpoint key preferred", SignatureKeys{
+			MustNewEndpointID("dtn://src/"):    otherPub,
+			MustNewEndpointID("dtn://src/app"): pub,
+		}, true},
+		{"other endpoint's key", SignatureKeys{MustNewEndpointID("dtn://src/other"): pub}, false},
+		{"other node's key", SignatureKeys{MustNewEndpointID("dtn://foo/"): pub}, false},
+		{"untrusted key", SignatureKeys{MustNewEndpointID("dtn://src/"): otherPub}, false},
+		{"no keys", SignatureKeys{}, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := b2.VerifySignature(test.keys); (err == nil) != test.valid {
+				t.Fatalf("expected valid = %t, got %v", test.valid, err)
+			}
+		})
+	}
+
+	// Altering the payload invalidates the signature.
+	payloadBlock, _ := b2.PayloadBlock()
+	payloadBlock.Value = NewPayloadBlock([]byte("hello mars!"))
+	if err := b2.VerifySignature(SignatureKeys{MustNewEndpointID("dtn://src/"): pub}); err == nil {
+		t.Fatal("altered bundle was verified")
+	}
+}
@@ -0,0 +1,106 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package bpv7
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/dtn7/cboring"
+)
+
+// SupersessionBlock marks a Bundle as a version of an application's stream, e.g., a sensor's readings. A Bundle of a
+// higher Version supersedes all Bundles of the same stream, i.e., the same source, destination, and Stream name.
+// Thus, stale Bundles still being queued on relays can be dropped.
+type SupersessionBlock struct {
+	Stream  string
+	Version uint64
+}
+
+// BlockTypeCode must return a constant integer, indicating the block type code.
+func (sb *SupersessionBlock) BlockTypeCode() uint64 {
+	return ExtBlockTypeSupersessionBlock
+}
+
+// BlockTypeName must return a constant string, this block's name.
+func (sb *SupersessionBlock) BlockTypeName() string {
+	return "Supersession Block"
+}
+
+// NewSupersessionBlock creates a new SupersessionBlock for a stream's version.
+func NewSupersessionBlock(stream string, version uint64) *SupersessionBlock {
+	return &SupersessionBlock{Stream: stream, Version: version}
+}
+
+// MarshalCbor writes the CBOR representation of a SupersessionBlock, an array of the stream and its version.
+func (sb *SupersessionBlock) MarshalCbor(w io.Writer) error {
+	if err := cboring.WriteArrayLength(2, w); err != nil {
+		return err
+	}
+	if err := cboring.WriteTextString(sb.Stream, w); err != nil {
+		return err
+	}
+	return cboring.WriteUInt(sb.Version, w)
+}
+
+// UnmarshalCbor reads the CBOR representation of a SupersessionBlock.
+func (sb *SupersessionBlock) UnmarshalCbor(r io.Reader) error {
+	if n, err := cboring.ReadArrayLength(r); err != nil {
+		return err
+	} else if n != 2 {
+		return fmt.Errorf("SupersessionBlock: expected an array of 2 elements, got %d", n)
+	}
+
+	if stream, err := cboring.ReadTextString(r); err != nil {
+		return err
+	} else {
+		sb.Stream = stream
+	}
+
+	if version, err := cboring.ReadUInt(r); err != nil {
+		return err
+	} else {
+		sb.Version = version
+	}
+	return nil
+}
+
+// MarshalJSON writes the JSON representation of a SupersessionBlock.
+func (sb *SupersessionBlock) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Stream  string `json:"stream"`
+		Version uint64 `json:"version"`
+	}{sb.Stream, sb.Version})
+}
+
+// CheckValid checks for a non-empty stream name.
+func (sb *SupersessionBlock) CheckValid() error {
+	if sb.Stream == "" {
+		return fmt.Errorf("SupersessionBlock: empty stream")
+	}
+	return nil
+}
+
+// CheckContextValid that there is at most one Supersession Block.
+func (sb *SupersessionBlock) CheckContextValid(b *Bundle) error {
+	cb, err := b.ExtensionBlock(ExtBlockTypeSupersessionBlock)
+
+	if err != nil {
+		return err
+	} else if cb.Value != sb {
+		return fmt.Errorf("SupersessionBlock's pointer differs, %p != %p", cb.Value, sb)
+	} else {
+		return nil
+	}
+}
+
+// Supersession returns this Bundle's SupersessionBlock, if it has one.
+func (b Bundle) Supersession() (sb *SupersessionBlock, ok bool) {
+	if cb, err := b.ExtensionBlock(ExtBlockTypeSupersessionBlock); err == nil {
+		return cb.Value.(*SupersessionBlock), true
+	}
+	return
+}
@@ -0,0 +1,48 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package bpv7
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestBundleSupersession(t *testing.T) {
+	b, err := BuildFromMap(map[string]interface{}{
+		"destination":            "dtn://dst/",
+		"source":                 "dtn://sensor/",
+		"creation_timestamp_now": true,
+		"lifetime":               "24h",
+		"supersession_block":     map[string]interface{}{"stream": "temperature", "version": float64(23)},
+		"payload_block":          "21.5",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buff := new(bytes.Buffer)
+	if err := b.MarshalCbor(buff); err != nil {
+		t.Fatal(err)
+	}
+	b2, err := ParseBundle(buff)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if sb, ok := b2.Supersession(); !ok || sb.Stream != "temperature" || sb.Version != 23 {
+		t.Fatalf("unexpected supersession %v (%t)", sb, ok)
+	}
+
+	if _, err := BuildFromMap(map[string]interface{}{
+		"destination":            "dtn://dst/",
+		"source":                 "dtn://sensor/",
+		"creation_timestamp_now": true,
+		"lifetime":               "24h",
+		"supersession_block":     "temperature",
+		"payload_block":          "21.5",
+	}); err == nil {
+		t.Fatal("supersession block without a version was accepted")
+	}
+}
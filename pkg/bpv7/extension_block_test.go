@@ -53,6 +53,8 @@ func TestExtensionBlockManagerRWBlock(t *testing.T) {
 		{NewBundleAgeBlock(23), []byte{0x41, 0x17}, ExtBlockTypeBundleAgeBlock},
 		{NewHopCountBlock(16), []byte{0x43, 0x82, 0x10, 0x00}, ExtBlockTypeHopCountBlock},
 		{NewPreviousNodeBlock(MustNewEndpointID("dtn://23/")), []byte{0x48, 0x82, 0x01, 0x65, 0x2F, 0x2F, 0x32, 0x33, 0x2F}, ExtBlockTypePreviousNodeBlock},
+		{NewCustodyTransferBlock(MustNewEndpointID("ipn:23.0")), []byte{0x45, 0x82, 0x02, 0x82, 0x17, 0x00}, ExtBlockTypeCustodyTransferBlock},
+		{NewPriorityBlock(PriorityExpedited), []byte{0x41, 0x02}, ExtBlockTypePriorityBlock},
 
 		// Binary; also wrapped, of course
 		{NewGenericExtensionBlock([]byte{0xFF}, 192), []byte{0x41, 0xFF}, 192},
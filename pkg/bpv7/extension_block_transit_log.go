@@ -0,0 +1,166 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package bpv7
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/dtn7/cboring"
+)
+
+// TransitRecord is a single hop within a TransitLogBlock, the forwarding node and its time of forwarding. Nodes
+// without an accurate clock record the DtnTimeEpoch.
+type TransitRecord struct {
+	Node EndpointID
+	Time DtnTime
+}
+
+// MarshalCbor writes the CBOR representation of a TransitRecord.
+func (tr *TransitRecord) MarshalCbor(w io.Writer) error {
+	if err := cboring.WriteArrayLength(2, w); err != nil {
+		return err
+	}
+	if err := cboring.Marshal(&tr.Node, w); err != nil {
+		return fmt.Errorf("marshalling node failed: %v", err)
+	}
+	return cboring.WriteUInt(uint64(tr.Time), w)
+}
+
+// UnmarshalCbor reads the CBOR representation of a TransitRecord.
+func (tr *TransitRecord) UnmarshalCbor(r io.Reader) error {
+	if n, err := cboring.ReadArrayLength(r); err != nil {
+		return err
+	} else if n != 2 {
+		return fmt.Errorf("TransitRecord: expected an array of 2 elements, got %d", n)
+	}
+
+	if err := cboring.Unmarshal(&tr.Node, r); err != nil {
+		return fmt.Errorf("unmarshalling node failed: %v", err)
+	}
+
+	if t, err := cboring.ReadUInt(r); err != nil {
+		return err
+	} else {
+		tr.Time = DtnTime(t)
+	}
+	return nil
+}
+
+// TransitLogBlock records the path of its Bundle, one TransitRecord for each forwarding node. To limit its size,
+// forwarding nodes stop appending records after a maximum number and only count the Omitted hops.
+type TransitLogBlock struct {
+	Records []TransitRecord
+	Omitted uint64
+}
+
+// BlockTypeCode must return a constant integer, indicating the block type code.
+func (tlb *TransitLogBlock) BlockTypeCode() uint64 {
+	return ExtBlockTypeTransitLogBlock
+}
+
+// BlockTypeName must return a constant string, this block's name.
+func (tlb *TransitLogBlock) BlockTypeName() string {
+	return "Transit Log Block"
+}
+
+// NewTransitLogBlock creates a new, empty TransitLogBlock.
+func NewTransitLogBlock() *TransitLogBlock {
+	return &TransitLogBlock{}
+}
+
+// Append a TransitRecord unless this block already holds maxRecords records. Otherwise, the hop is counted as
+// omitted and false is returned.
+func (tlb *TransitLogBlock) Append(record TransitRecord, maxRecords int) bool {
+	if len(tlb.Records) >= maxRecords {
+		tlb.Omitted++
+		return false
+	}
+
+	tlb.Records = append(tlb.Records, record)
+	return true
+}
+
+// MarshalCbor writes the CBOR representation of a TransitLogBlock, an array of the omitted hops' number followed by
+// all TransitRecords.
+func (tlb *TransitLogBlock) MarshalCbor(w io.Writer) error {
+	if err := cboring.WriteArrayLength(uint64(len(tlb.Records))+1, w); err != nil {
+		return err
+	}
+	if err := cboring.WriteUInt(tlb.Omitted, w); err != nil {
+		return err
+	}
+
+	for i := range tlb.Records {
+		if err := cboring.Marshal(&tlb.Records[i], w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UnmarshalCbor reads the CBOR representation of a TransitLogBlock.
+func (tlb *TransitLogBlock) UnmarshalCbor(r io.Reader) error {
+	n, err := cboring.ReadArrayLength(r)
+	if err != nil {
+		return err
+	} else if n == 0 {
+		return fmt.Errorf("TransitLogBlock: expected at least the number of omitted hops")
+	}
+
+	if tlb.Omitted, err = cboring.ReadUInt(r); err != nil {
+		return err
+	}
+
+	tlb.Records = make([]TransitRecord, n-1)
+	for i := range tlb.Records {
+		if err := cboring.Unmarshal(&tlb.Records[i], r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MarshalJSON writes a human-readable JSON representation of a TransitLogBlock.
+func (tlb *TransitLogBlock) MarshalJSON() ([]byte, error) {
+	type jsonRecord struct {
+		Node string `json:"node"`
+		Time string `json:"time"`
+	}
+
+	records := make([]jsonRecord, 0, len(tlb.Records))
+	for _, record := range tlb.Records {
+		records = append(records, jsonRecord{Node: record.Node.String(), Time: record.Time.String()})
+	}
+
+	return json.Marshal(struct {
+		Records []jsonRecord `json:"records"`
+		Omitted uint64       `json:"omitted"`
+	}{records, tlb.Omitted})
+}
+
+// CheckValid checks the recorded nodes' Endpoint IDs.
+func (tlb *TransitLogBlock) CheckValid() error {
+	for _, record := range tlb.Records {
+		if err := record.Node.CheckValid(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CheckContextValid that there is at most one Transit Log Block.
+func (tlb *TransitLogBlock) CheckContextValid(b *Bundle) error {
+	cb, err := b.ExtensionBlock(ExtBlockTypeTransitLogBlock)
+
+	if err != nil {
+		return err
+	} else if cb.Value != tlb {
+		return fmt.Errorf("TransitLogBlock's pointer differs, %p != %p", cb.Value, tlb)
+	} else {
+		return nil
+	}
+}
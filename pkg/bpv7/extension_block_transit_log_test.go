@@ -0,0 +1,61 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package bpv7
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestTransitLogBlock(t *testing.T) {
+	b, err := Builder().
+		Source("dtn://src/").
+		Destination("dtn://dst/").
+		CreationTimestampNow().
+		Lifetime("10m").
+		TransitLogBlock().
+		PayloadBlock([]byte("hello world")).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cb, err := b.ExtensionBlock(ExtBlockTypeTransitLogBlock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tlb := cb.Value.(*TransitLogBlock)
+
+	records := []TransitRecord{
+		{Node: MustNewEndpointID("dtn://a/"), Time: DtnTimeNow()},
+		{Node: MustNewEndpointID("ipn:23.0"), Time: DtnTimeEpoch},
+	}
+	for _, record := range records {
+		if !tlb.Append(record, 2) {
+			t.Fatalf("record %v was omitted", record)
+		}
+	}
+	if tlb.Append(TransitRecord{Node: MustNewEndpointID("dtn://c/")}, 2) {
+		t.Fatal("record exceeding the limit was appended")
+	}
+
+	buff := new(bytes.Buffer)
+	if err := b.MarshalCbor(buff); err != nil {
+		t.Fatal(err)
+	}
+	b2, err := ParseBundle(buff)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cb2, err := b2.ExtensionBlock(ExtBlockTypeTransitLogBlock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tlb2 := cb2.Value.(*TransitLogBlock); !reflect.DeepEqual(tlb2.Records, records) || tlb2.Omitted != 1 {
+		t.Fatalf("parsed %v, expected %v with one omitted hop", tlb2, records)
+	}
+}
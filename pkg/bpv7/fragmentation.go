@@ -7,14 +7,17 @@ package bpv7
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
+	"io"
 	"math"
 	"sort"
 
 	"github.com/dtn7/cboring"
 )
 
-// Fragment a Bundle into multiple Bundles, with each serialized Bundle limited to mtu bytes.
+// Fragment a Bundle into multiple Bundles, with each serialized Bundle limited to mtu bytes. A fragment might be
+// fragmented again. If the Bundle fits into the mtu, it is returned unaltered as the only element.
 func (b Bundle) Fragment(mtu int) (bs []Bundle, err error) {
 	if b.PrimaryBlock.BundleControlFlags.Has(MustNotFragmented) {
 		err = fmt.Errorf("bundle control flags forbids bundle fragmentation")
@@ -27,25 +30,35 @@ func (b Bundle) Fragment(mtu int) (bs []Bundle, err error) {
 		extOtherOverhead int
 
 		payloadBlock    *CanonicalBlock
+		payloadData     []byte
 		payloadBlockLen int
 	)
 
 	if payloadBlock, err = b.PayloadBlock(); err != nil {
 		return
 	}
-	payloadBlockLen = len(payloadBlock.Value.(*PayloadBlock).Data())
+	if payloadData, err = payloadBlockData(payloadBlock.Value); err != nil {
+		return
+	}
+	payloadBlockLen = len(payloadData)
 
 	if extFirstOverhead, extOtherOverhead, err = fragmentExtensionBlocksLen(b, mtu); err != nil {
 		return
 	}
 
+	// A fragment might be fragmented again. Its fragments' offsets are relative to the original Bundle's payload.
+	baseOffset, totalDataLength := 0, payloadBlockLen
+	if b.PrimaryBlock.BundleControlFlags.Has(IsFragment) {
+		baseOffset, totalDataLength = int(b.PrimaryBlock.FragmentOffset), int(b.PrimaryBlock.TotalDataLength)
+	}
+
 	for i := 0; i < payloadBlockLen; {
 		var (
 			fragPrimaryBlock PrimaryBlock
 			primaryOverhead  int
 		)
 
-		if fragPrimaryBlock, primaryOverhead, err = fragmentPrimaryBlock(b.PrimaryBlock, i, payloadBlockLen); err != nil {
+		if fragPrimaryBlock, primaryOverhead, err = fragmentPrimaryBlock(b.PrimaryBlock, baseOffset+i, totalDataLength); err != nil {
 			return
 		}
 
@@ -78,11 +91,11 @@ func (b Bundle) Fragment(mtu int) (bs []Bundle, err error) {
 
 		fragPayloadBlockLen := mtu - overhead
 
-		offset := int(math.Min(float64(i+fragPayloadBlockLen), float64(len(payloadBlock.Value.(*PayloadBlock).Data()))))
+		offset := int(math.Min(float64(i+fragPayloadBlockLen), float64(payloadBlockLen)))
 		if err = fragBundle.AddExtensionBlock(CanonicalBlock{
 			BlockControlFlags: payloadBlock.BlockControlFlags,
 			CRCType:           payloadBlock.CRCType,
-			Value:             NewPayloadBlock(payloadBlock.Value.(*PayloadBlock).Data()[i:offset]),
+			Value:             NewPayloadBlock(payloadData[i:offset]),
 		}); err != nil {
 			return
 		}
@@ -117,10 +130,7 @@ func fragmentPrimaryBlock(pb PrimaryBlock, fragmentOffset, totalDataLength int)
 		TotalDataLength:    uint64(totalDataLength),
 	}
 
-	buff := new(bytes.Buffer)
-
-	err = fragPb.MarshalCbor(buff)
-	l = buff.Len()
+	l = int(fragPb.SerializedSize())
 	return
 }
 
@@ -187,7 +197,7 @@ func prepareReassembly(bs []Bundle) error {
 		} else if payloadBlock, err := b.PayloadBlock(); err != nil {
 			return err
 		} else {
-			lastIndex = fragOff + uint64(len(payloadBlock.Value.(*PayloadBlock).Data()))
+			lastIndex = fragOff + payloadBlockSize(payloadBlock.Value)
 		}
 	}
 
@@ -219,7 +229,9 @@ func mergeFragmentPayload(bs []Bundle) (data []byte, err error) {
 		if fragPayloadBlock, err = b.PayloadBlock(); err != nil {
 			return
 		}
-		fragPayloadData = fragPayloadBlock.Value.(*PayloadBlock).Data()
+		if fragPayloadData, err = payloadBlockData(fragPayloadBlock.Value); err != nil {
+			return
+		}
 
 		data = append(data, fragPayloadData[lastIndex-fragStartIndex:]...)
 		lastIndex = fragStartIndex + len(fragPayloadData)
@@ -271,3 +283,202 @@ func ReassembleFragments(bs []Bundle) (b Bundle, err error) {
 	err = b.CheckValid()
 	return
 }
+
+// payloadDataOffset returns the offset of the payload data within the CBOR serialization of a Bundle, based on the
+// serialization's total length. The payload block is the last block, only followed by its CRC and the array's end.
+func payloadDataOffset(serializedLen int, payloadBlock *CanonicalBlock, payloadLen int) int {
+	trailerLen := 1
+	switch payloadBlock.CRCType {
+	case CRC16:
+		trailerLen += 1 + 2
+	case CRC32:
+		trailerLen += 1 + 4
+	}
+
+	return serializedLen - trailerLen - payloadLen
+}
+
+// ReactiveFragment creates a fragment of the payload which was not transmitted, after a transfer of this Bundle's
+// serialization was interrupted after the given number of bytes. The receiver is expected to keep the transmitted part
+// as a fragment itself, e.g., created by ParseReactiveFragment.
+func (b Bundle) ReactiveFragment(transmitted int) (frag Bundle, err error) {
+	if b.PrimaryBlock.BundleControlFlags.Has(MustNotFragmented) {
+		err = fmt.Errorf("bundle control flags forbids bundle fragmentation")
+		return
+	}
+
+	payloadBlock, err := b.PayloadBlock()
+	if err != nil {
+		return
+	}
+	payloadData, err := payloadBlockData(payloadBlock.Value)
+	if err != nil {
+		return
+	}
+
+	buff := new(bytes.Buffer)
+	if err = b.MarshalCbor(buff); err != nil {
+		return
+	}
+
+	received := transmitted - payloadDataOffset(buff.Len(), payloadBlock, len(payloadData))
+	if received <= 0 {
+		err = fmt.Errorf("no payload data was transmitted")
+		return
+	} else if received >= len(payloadData) {
+		err = fmt.Errorf("payload data was transmitted completely")
+		return
+	}
+
+	baseOffset, totalDataLength := 0, len(payloadData)
+	if b.PrimaryBlock.BundleControlFlags.Has(IsFragment) {
+		baseOffset, totalDataLength = int(b.PrimaryBlock.FragmentOffset), int(b.PrimaryBlock.TotalDataLength)
+	}
+
+	fragPrimaryBlock, _, err := fragmentPrimaryBlock(b.PrimaryBlock, baseOffset+received, totalDataLength)
+	if err != nil {
+		return
+	}
+
+	var canonicals []CanonicalBlock
+	for _, cb := range b.CanonicalBlocks {
+		if cb.TypeCode() == ExtBlockTypePayloadBlock {
+			cb = CanonicalBlock{
+				BlockNumber:       cb.BlockNumber,
+				BlockControlFlags: cb.BlockControlFlags,
+				CRCType:           cb.CRCType,
+				Value:             NewPayloadBlock(payloadData[received:]),
+			}
+		} else if !cb.BlockControlFlags.Has(ReplicateBlock) {
+			continue
+		}
+
+		canonicals = append(canonicals, cb)
+	}
+
+	frag, err = NewBundle(fragPrimaryBlock, canonicals)
+	return
+}
+
+// ParseReactiveFragment creates a fragment from the prefix of a Bundle's serialization, e.g., received by an
+// interrupted transfer. At least the blocks in front of the payload and some of its payload data must be present.
+func ParseReactiveFragment(data []byte) (frag Bundle, err error) {
+	r := bytes.NewReader(data)
+
+	if err = cboring.ReadExpect(cboring.IndefiniteArray, r); err != nil {
+		return
+	}
+
+	var primaryBlock PrimaryBlock
+	if err = cboring.Unmarshal(&primaryBlock, r); err != nil {
+		err = fmt.Errorf("PrimaryBlock failed: %v", err)
+		return
+	}
+
+	var canonicals []CanonicalBlock
+	for {
+		blockOffset := len(data) - r.Len()
+
+		cb := CanonicalBlock{}
+		var undecodableErr *undecodableBlockError
+		if cbErr := cboring.Unmarshal(&cb, r); cbErr == nil {
+			canonicals = append(canonicals, cb)
+			continue
+		} else if errors.As(cbErr, &undecodableErr) {
+			// Blocks encrypted by a BCB are checked below, after all blocks are known.
+			canonicals = append(canonicals, cb)
+			continue
+		} else if cbErr == cboring.FlagBreakCode {
+			// The whole Bundle was received.
+			return ParseBundle(bytes.NewReader(data))
+		}
+
+		// This block was cut off, which is only supported for the payload block.
+		r = bytes.NewReader(data[blockOffset:])
+
+		var (
+			payloadBlock    CanonicalBlock
+			totalDataLength uint64
+		)
+		if payloadBlock, totalDataLength, err = parseReactivePayloadBlock(r); err != nil {
+			return
+		}
+		payloadLen := len(payloadBlock.Value.(*PayloadBlock).Data())
+
+		offset := uint64(0)
+		if primaryBlock.BundleControlFlags.Has(IsFragment) {
+			offset, totalDataLength = primaryBlock.FragmentOffset, primaryBlock.TotalDataLength
+		}
+
+		if primaryBlock.BundleControlFlags.Has(MustNotFragmented) {
+			err = fmt.Errorf("bundle control flags forbids bundle fragmentation")
+			return
+		} else if payloadLen == 0 {
+			err = fmt.Errorf("no payload data was received")
+			return
+		}
+
+		var fragPrimaryBlock PrimaryBlock
+		if fragPrimaryBlock, _, err = fragmentPrimaryBlock(primaryBlock, int(offset), int(totalDataLength)); err != nil {
+			return
+		}
+
+		frag = MustNewBundle(fragPrimaryBlock, append(canonicals, payloadBlock))
+		for _, cb := range canonicals {
+			if _, ok := cb.Value.(*GenericExtensionBlock); ok && GetExtensionBlockManager().IsKnown(cb.TypeCode()) && !frag.isEncryptedBlock(cb.BlockNumber) {
+				err = fmt.Errorf("block %d of type %d is undecodable", cb.BlockNumber, cb.TypeCode())
+				return
+			}
+		}
+
+		err = frag.CheckValid()
+		return
+	}
+}
+
+// parseReactivePayloadBlock reads a payload block whose data might be cut off, next to its announced data length.
+func parseReactivePayloadBlock(r *bytes.Reader) (cb CanonicalBlock, dataLen uint64, err error) {
+	if blockLen, blockLenErr := cboring.ReadArrayLength(r); blockLenErr != nil {
+		err = blockLenErr
+		return
+	} else if blockLen != 5 && blockLen != 6 {
+		err = fmt.Errorf("expected array with length 5 or 6, got %d", blockLen)
+		return
+	}
+
+	var fields [4]uint64
+	for i := range fields {
+		if fields[i], err = cboring.ReadUInt(r); err != nil {
+			err = fmt.Errorf("cut off block header: %v", err)
+			return
+		}
+	}
+
+	if fields[0] != ExtBlockTypePayloadBlock {
+		err = fmt.Errorf("transfer was interrupted within block of type %d, not the payload", fields[0])
+		return
+	}
+
+	if dataLen, err = cboring.ReadByteStringLen(r); err != nil {
+		err = fmt.Errorf("cut off payload header: %v", err)
+		return
+	}
+
+	available := uint64(r.Len())
+	if available > dataLen {
+		available = dataLen
+	}
+
+	payloadData := make([]byte, available)
+	if _, err = io.ReadFull(r, payloadData); err != nil {
+		return
+	}
+
+	cb = CanonicalBlock{
+		BlockNumber:       fields[1],
+		BlockControlFlags: BlockControlFlags(fields[2]),
+		CRCType:           CRCType(fields[3]),
+		Value:             NewPayloadBlock(payloadData),
+	}
+	return
+}
@@ -224,3 +224,149 @@ func TestReassembleFragmentsMissing(t *testing.T) {
 		t.Fatalf("Expected error for missing fragment")
 	}
 }
+
+func TestReassembleRefragmented(t *testing.T) {
+	payloadData := make([]byte, 1024)
+	rand.Seed(23)
+	_, _ = rand.Read(payloadData)
+
+	bndl, err := Builder().
+		Source("dtn://src/").
+		Destination("dtn://dst/").
+		CreationTimestampNow().
+		Lifetime("5m").
+		PayloadBlock(payloadData).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	frags, err := bndl.Fragment(512)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Fragment each fragment again, as a node with a smaller MTU along the path would do.
+	var refrags []Bundle
+	for _, frag := range frags {
+		if fs, err := frag.Fragment(128); err != nil {
+			t.Fatal(err)
+		} else {
+			refrags = append(refrags, fs...)
+		}
+	}
+
+	if len(refrags) <= len(frags) {
+		t.Fatalf("Expected more than %d fragments, got %d", len(frags), len(refrags))
+	}
+	for _, refrag := range refrags {
+		if total := refrag.PrimaryBlock.TotalDataLength; total != uint64(len(payloadData)) {
+			t.Fatalf("Fragment's total data length is %d instead of %d", total, len(payloadData))
+		}
+	}
+
+	bndl2, err := ReassembleFragments(refrags)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if pb, err := bndl2.PayloadBlock(); err != nil {
+		t.Fatal(err)
+	} else if !bytes.Equal(pb.Value.(*PayloadBlock).Data(), payloadData) {
+		t.Fatal("Reassembled payload differs")
+	}
+}
+
+func TestReactiveFragment(t *testing.T) {
+	payloadData := make([]byte, 1024)
+	rand.Seed(23)
+	_, _ = rand.Read(payloadData)
+
+	for _, crcType := range []CRCType{CRCNo, CRC16, CRC32} {
+		for _, transmitted := range []int{128, 512, 1000} {
+			t.Run(fmt.Sprintf("crc=%v,transmitted=%d", crcType, transmitted), func(t *testing.T) {
+				bndl, err := Builder().
+					CRC(crcType).
+					Source("dtn://src/").
+					Destination("dtn://dst/").
+					CreationTimestampNow().
+					Lifetime("5m").
+					HopCountBlock(64).
+					PayloadBlock(payloadData).
+					Build()
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				var buff bytes.Buffer
+				if err := bndl.MarshalCbor(&buff); err != nil {
+					t.Fatal(err)
+				}
+
+				head, err := ParseReactiveFragment(buff.Bytes()[:transmitted])
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				tail, err := bndl.ReactiveFragment(transmitted)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				headPayload, _ := head.PayloadBlock()
+				if offset := tail.PrimaryBlock.FragmentOffset; offset != uint64(len(headPayload.Value.(*PayloadBlock).Data())) {
+					t.Fatalf("Remaining fragment starts at %d, received fragment has %d bytes",
+						offset, len(headPayload.Value.(*PayloadBlock).Data()))
+				}
+
+				bndl2, err := ReassembleFragments([]Bundle{tail, head})
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if pb, err := bndl2.PayloadBlock(); err != nil {
+					t.Fatal(err)
+				} else if !bytes.Equal(pb.Value.(*PayloadBlock).Data(), payloadData) {
+					t.Fatal("Reassembled payload differs")
+				}
+				if !bndl2.HasExtensionBlock(ExtBlockTypeHopCountBlock) {
+					t.Fatal("Reassembled bundle misses its Hop Count Block")
+				}
+			})
+		}
+	}
+}
+
+func TestReactiveFragmentNoPayload(t *testing.T) {
+	bndl, err := Builder().
+		Source("dtn://src/").
+		Destination("dtn://dst/").
+		CreationTimestampNow().
+		Lifetime("5m").
+		PayloadBlock(make([]byte, 1024)).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buff bytes.Buffer
+	if err := bndl.MarshalCbor(&buff); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ParseReactiveFragment(buff.Bytes()[:16]); err == nil {
+		t.Fatal("Parsing a cut off primary block did not fail")
+	}
+	if _, err := bndl.ReactiveFragment(16); err == nil {
+		t.Fatal("Fragmenting without transmitted payload did not fail")
+	}
+	if _, err := bndl.ReactiveFragment(buff.Len()); err == nil {
+		t.Fatal("Fragmenting a completely transmitted bundle did not fail")
+	}
+
+	if b, err := ParseReactiveFragment(buff.Bytes()); err != nil {
+		t.Fatal(err)
+	} else if b.PrimaryBlock.BundleControlFlags.Has(IsFragment) {
+		t.Fatal("Completely received bundle is a fragment")
+	}
+}
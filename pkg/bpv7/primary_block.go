@@ -13,6 +13,8 @@ import (
 
 	"github.com/dtn7/cboring"
 	"github.com/hashicorp/go-multierror"
+
+	"github.com/dtn7/dtn7-go/pkg/internal/bufpool"
 )
 
 const dtnVersion uint64 = 7
@@ -92,7 +94,7 @@ func (pb *PrimaryBlock) SetCRCType(crcType CRCType) {
 // must be called both when creating the block and when changing its CRC.
 func (pb *PrimaryBlock) calculateCRC() error {
 	pb.CRC = nil
-	return pb.MarshalCbor(new(bytes.Buffer))
+	return pb.MarshalCbor(io.Discard)
 }
 
 // MarshalCbor writes the CBOR representation of a PrimaryBlock.
@@ -112,7 +114,8 @@ func (pb *PrimaryBlock) MarshalCbor(w io.Writer) error {
 		}
 	}()
 
-	crcBuff := new(bytes.Buffer)
+	crcBuff := bufpool.GetBuffer()
+	defer bufpool.PutBuffer(crcBuff)
 	w = io.MultiWriter(w, crcBuff)
 
 	if err := cboring.WriteArrayLength(blockLen, w); err != nil {
@@ -166,11 +169,12 @@ func (pb *PrimaryBlock) MarshalCbor(w io.Writer) error {
 // UnmarshalCbor reads the CBOR representation of a PrimaryBlock.
 func (pb *PrimaryBlock) UnmarshalCbor(r io.Reader) error {
 	// Pipe incoming bytes into a separate CRC buffer
-	crcBuff := new(bytes.Buffer)
-	r = io.TeeReader(r, crcBuff)
+	crcBuff := bufpool.GetBuffer()
+	defer bufpool.PutBuffer(crcBuff)
+	r = newTeeByteReader(r, crcBuff)
 
 	var blockLen uint64
-	if bl, err := cboring.ReadArrayLength(r); err != nil {
+	if bl, err := readArrayLength(r); err != nil {
 		return err
 	} else if !(8 <= bl && bl <= 11) {
 		return fmt.Errorf("expected array with 8 to 11 elements, got %d", bl)
@@ -178,7 +182,7 @@ func (pb *PrimaryBlock) UnmarshalCbor(r io.Reader) error {
 		blockLen = bl
 	}
 
-	if version, err := cboring.ReadUInt(r); err != nil {
+	if version, err := readUInt(r); err != nil {
 		return err
 	} else if version != dtnVersion {
 		return fmt.Errorf("expected version %d, got %d", dtnVersion, version)
@@ -186,13 +190,13 @@ func (pb *PrimaryBlock) UnmarshalCbor(r io.Reader) error {
 		pb.Version = dtnVersion
 	}
 
-	if bcf, err := cboring.ReadUInt(r); err != nil {
+	if bcf, err := readUInt(r); err != nil {
 		return err
 	} else {
 		pb.BundleControlFlags = BundleControlFlags(bcf)
 	}
 
-	if crcT, err := cboring.ReadUInt(r); err != nil {
+	if crcT, err := readUInt(r); err != nil {
 		return err
 	} else {
 		pb.CRCType = CRCType(crcT)
@@ -209,7 +213,7 @@ func (pb *PrimaryBlock) UnmarshalCbor(r io.Reader) error {
 		return fmt.Errorf("CreationTimestamp failed: %v", err)
 	}
 
-	if lt, err := cboring.ReadUInt(r); err != nil {
+	if lt, err := readUInt(r); err != nil {
 		return err
 	} else {
 		pb.Lifetime = lt
@@ -218,7 +222,7 @@ func (pb *PrimaryBlock) UnmarshalCbor(r io.Reader) error {
 	if blockLen == 10 || blockLen == 11 {
 		fields := []*uint64{&pb.FragmentOffset, &pb.TotalDataLength}
 		for _, f := range fields {
-			if x, err := cboring.ReadUInt(r); err != nil {
+			if x, err := readUInt(r); err != nil {
 				return err
 			} else {
 				*f = x
@@ -250,6 +254,9 @@ func (pb PrimaryBlock) MarshalJSON() ([]byte, error) {
 		ReportTo          string             `json:"reportTo"`
 		CreationTimestamp CreationTimestamp  `json:"creationTimestamp"`
 		Lifetime          uint64             `json:"lifetime"`
+		FragmentOffset    uint64             `json:"fragmentOffset,omitempty"`
+		TotalDataLength   uint64             `json:"totalDataLength,omitempty"`
+		CRCType           CRCType            `json:"crcType"`
 	}{
 		ControlFlags:      pb.BundleControlFlags,
 		Destination:       pb.Destination.String(),
@@ -257,9 +264,45 @@ func (pb PrimaryBlock) MarshalJSON() ([]byte, error) {
 		ReportTo:          pb.ReportTo.String(),
 		CreationTimestamp: pb.CreationTimestamp,
 		Lifetime:          pb.Lifetime,
+		FragmentOffset:    pb.FragmentOffset,
+		TotalDataLength:   pb.TotalDataLength,
+		CRCType:           pb.CRCType,
 	})
 }
 
+// UnmarshalJSON reads a JSON object of a PrimaryBlock, as created by MarshalJSON. The CRC value is recalculated.
+func (pb *PrimaryBlock) UnmarshalJSON(data []byte) error {
+	var tmp struct {
+		ControlFlags      BundleControlFlags `json:"bundleControlFlags"`
+		Destination       EndpointID         `json:"destination"`
+		Source            EndpointID         `json:"source"`
+		ReportTo          EndpointID         `json:"reportTo"`
+		CreationTimestamp CreationTimestamp  `json:"creationTimestamp"`
+		Lifetime          uint64             `json:"lifetime"`
+		FragmentOffset    uint64             `json:"fragmentOffset"`
+		TotalDataLength   uint64             `json:"totalDataLength"`
+		CRCType           CRCType            `json:"crcType"`
+	}
+	if err := json.Unmarshal(data, &tmp); err != nil {
+		return err
+	}
+
+	*pb = PrimaryBlock{
+		Version:            dtnVersion,
+		BundleControlFlags: tmp.ControlFlags,
+		CRCType:            tmp.CRCType,
+		Destination:        tmp.Destination,
+		SourceNode:         tmp.Source,
+		ReportTo:           tmp.ReportTo,
+		CreationTimestamp:  tmp.CreationTimestamp,
+		Lifetime:           tmp.Lifetime,
+		FragmentOffset:     tmp.FragmentOffset,
+		TotalDataLength:    tmp.TotalDataLength,
+	}
+
+	return pb.calculateCRC()
+}
+
 // CheckValid returns an array of errors for incorrect data.
 func (pb PrimaryBlock) CheckValid() (errs error) {
 	if pb.Version != dtnVersion {
@@ -106,7 +106,7 @@ func TestPrimaryBlockJson(t *testing.T) {
 			ReportTo:           MustNewEndpointID("dtn://rprt/"),
 			CreationTimestamp:  NewCreationTimestamp(0, 42),
 			Lifetime:           3600,
-		}, []byte(`{"bundleControlFlags":null,"destination":"dtn://dst/","source":"dtn://src/","reportTo":"dtn://rprt/","creationTimestamp":{"date":"2000-01-01 00:00:00.000","sequenceNo":42},"lifetime":3600}`)},
+		}, []byte(`{"bundleControlFlags":null,"destination":"dtn://dst/","source":"dtn://src/","reportTo":"dtn://rprt/","creationTimestamp":{"date":"2000-01-01 00:00:00.000","sequenceNo":42},"lifetime":3600,"crcType":2}`)},
 		{PrimaryBlock{
 			BundleControlFlags: MustNotFragmented,
 			CRCType:            CRCNo,
@@ -115,7 +115,7 @@ func TestPrimaryBlockJson(t *testing.T) {
 			ReportTo:           MustNewEndpointID("dtn://bar/"),
 			CreationTimestamp:  NewCreationTimestamp(0, 0),
 			Lifetime:           10,
-		}, []byte(`{"bundleControlFlags":["MUST_NOT_BE_FRAGMENTED"],"destination":"ipn:23.42","source":"dtn://foo/","reportTo":"dtn://bar/","creationTimestamp":{"date":"2000-01-01 00:00:00.000","sequenceNo":0},"lifetime":10}`)},
+		}, []byte(`{"bundleControlFlags":["MUST_NOT_BE_FRAGMENTED"],"destination":"ipn:23.42","source":"dtn://foo/","reportTo":"dtn://bar/","creationTimestamp":{"date":"2000-01-01 00:00:00.000","sequenceNo":0},"lifetime":10,"crcType":0}`)},
 	}
 
 	for _, test := range tests {
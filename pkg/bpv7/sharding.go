@@ -0,0 +1,263 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package bpv7
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+)
+
+// maxShards is the maximum number of shards of a Bundle, limited by the size of the Galois field GF(2^8).
+const maxShards = 256
+
+// gfExp and gfLog are the exponentiation and logarithm tables of GF(2^8), generated by 2 for the polynomial 0x11d.
+var gfExp, gfLog = func() (exp [510]byte, log [256]byte) {
+	x := 1
+	for i := 0; i < 255; i++ {
+		exp[i], exp[i+255] = byte(x), byte(x)
+		log[x] = byte(i)
+
+		x <<= 1
+		if x&0x100 != 0 {
+			x ^= 0x11d
+		}
+	}
+	return
+}()
+
+// gfMul multiplies two elements of GF(2^8).
+func gfMul(a, b byte) byte {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return gfExp[int(gfLog[a])+int(gfLog[b])]
+}
+
+// gfInv inverts a non-zero element of GF(2^8).
+func gfInv(a byte) byte {
+	return gfExp[255-int(gfLog[a])]
+}
+
+// shardCoefficients of a shard's index, combining the data shards to this shard. The first dataShards rows form an
+// identity matrix, keeping the data shards unaltered, followed by a Cauchy matrix for the parity shards. Thus, any
+// dataShards rows form an invertible matrix.
+func shardCoefficients(index, dataShards int) []byte {
+	row := make([]byte, dataShards)
+	if index < dataShards {
+		row[index] = 1
+		return row
+	}
+
+	for j := range row {
+		row[j] = gfInv(byte(index) ^ byte(j))
+	}
+	return row
+}
+
+// invertMatrix over GF(2^8) by a Gauss-Jordan elimination.
+func invertMatrix(m [][]byte) ([][]byte, error) {
+	n := len(m)
+	inv := make([][]byte, n)
+	for i := range inv {
+		inv[i] = make([]byte, n)
+		inv[i][i] = 1
+	}
+
+	for col := 0; col < n; col++ {
+		pivot := col
+		for pivot < n && m[pivot][col] == 0 {
+			pivot++
+		}
+		if pivot == n {
+			return nil, fmt.Errorf("matrix is singular")
+		}
+		m[col], m[pivot] = m[pivot], m[col]
+		inv[col], inv[pivot] = inv[pivot], inv[col]
+
+		scale := gfInv(m[col][col])
+		for j := 0; j < n; j++ {
+			m[col][j] = gfMul(m[col][j], scale)
+			inv[col][j] = gfMul(inv[col][j], scale)
+		}
+
+		for row := 0; row < n; row++ {
+			if row == col || m[row][col] == 0 {
+				continue
+			}
+			factor := m[row][col]
+			for j := 0; j < n; j++ {
+				m[row][j] ^= gfMul(factor, m[col][j])
+				inv[row][j] ^= gfMul(factor, inv[col][j])
+			}
+		}
+	}
+	return inv, nil
+}
+
+// combineShards linearly by the coefficients, one for each shard.
+func combineShards(coefficients []byte, shards [][]byte) []byte {
+	out := make([]byte, len(shards[0]))
+	for i, shard := range shards {
+		c := coefficients[i]
+		if c == 0 {
+			continue
+		}
+		for j, v := range shard {
+			out[j] ^= gfMul(c, v)
+		}
+	}
+	return out
+}
+
+// remainingLifetime of a Bundle, based on its creation time or, without a clock, its Bundle Age Block.
+func (b Bundle) remainingLifetime() (time.Duration, error) {
+	lifetime := time.Duration(b.PrimaryBlock.Lifetime) * time.Millisecond
+
+	if b.PrimaryBlock.CreationTimestamp.IsZeroTime() {
+		bab, err := b.ExtensionBlock(ExtBlockTypeBundleAgeBlock)
+		if err != nil {
+			return 0, fmt.Errorf("bundle has neither a creation time nor a bundle age block")
+		}
+		return lifetime - time.Duration(bab.Value.(*BundleAgeBlock).Age())*time.Millisecond, nil
+	}
+
+	return b.PrimaryBlock.CreationTimestamp.DtnTime().Time().Add(lifetime).Sub(Now()), nil
+}
+
+// EncodeShards splits this serialized Bundle into dataShards data and parityShards parity shards by a Reed-Solomon
+// erasure code. Any dataShards of these shards suffice to reassemble the Bundle by ReassembleShards.
+//
+// Each shard is a new Bundle from the source to this Bundle's destination, carrying a ShardBlock, the shard as its
+// payload, and this Bundle's priority. Its lifetime is this Bundle's remaining lifetime. The shards' creation
+// timestamps are the current time and their index as the sequence number; a caller creating multiple Bundles of the
+// same source within the same millisecond must assign unique sequence numbers.
+func (b Bundle) EncodeShards(source EndpointID, dataShards, parityShards int) (shards []Bundle, err error) {
+	if dataShards < 1 || parityShards < 0 || dataShards+parityShards > maxShards {
+		err = fmt.Errorf("%d data and %d parity shards are invalid, up to %d shards are possible",
+			dataShards, parityShards, maxShards)
+		return
+	}
+
+	lifetime, err := b.remainingLifetime()
+	if err != nil {
+		return
+	} else if lifetime < time.Millisecond {
+		err = fmt.Errorf("bundle's lifetime is exceeded")
+		return
+	}
+
+	buff := new(bytes.Buffer)
+	if err = b.WriteBundle(buff); err != nil {
+		return
+	}
+	length := buff.Len()
+
+	size := (length + dataShards - 1) / dataShards
+	data := make([]byte, size*dataShards)
+	copy(data, buff.Bytes())
+
+	dataParts := make([][]byte, dataShards)
+	for i := range dataParts {
+		dataParts[i] = data[i*size : (i+1)*size]
+	}
+
+	now := DtnTimeNow()
+	total := dataShards + parityShards
+	for i := 0; i < total; i++ {
+		var payload []byte
+		if i < dataShards {
+			payload = dataParts[i]
+		} else {
+			payload = combineShards(shardCoefficients(i, dataShards), dataParts)
+		}
+
+		var shard Bundle
+		shard, err = Builder().
+			CRC(CRC32).
+			Source(source).
+			Destination(b.PrimaryBlock.Destination).
+			ReportTo(DtnNone()).
+			CreationTimestampNow().
+			Lifetime(lifetime).
+			Canonical(NewShardBlock(b.ID(), uint64(i), uint64(dataShards), uint64(total), uint64(length)), ReplicateBlock).
+			PriorityBlock(b.Priority()).
+			PayloadBlock(payload).
+			Build()
+		if err != nil {
+			return
+		}
+		shard.PrimaryBlock.CreationTimestamp = NewCreationTimestamp(now, uint64(i))
+
+		shards = append(shards, shard)
+	}
+	return
+}
+
+// ReassembleShards of a Bundle, as created by EncodeShards. At least as many shards of the same Bundle as its number
+// of data shards must be passed; additional and duplicate shards are ignored.
+func ReassembleShards(bs []Bundle) (b Bundle, err error) {
+	if len(bs) == 0 {
+		err = fmt.Errorf("slice of shards is empty")
+		return
+	}
+
+	first, ok := bs[0].Shard()
+	if !ok {
+		err = fmt.Errorf("bundle is not a shard")
+		return
+	}
+	dataShards := int(first.DataShards)
+
+	var (
+		indices = make(map[uint64]bool)
+		rows    [][]byte
+		parts   [][]byte
+	)
+	for _, shard := range bs {
+		sb, ok := shard.Shard()
+		if !ok {
+			err = fmt.Errorf("bundle %v is not a shard", shard.ID())
+			return
+		} else if sb.Bundle != first.Bundle || sb.DataShards != first.DataShards || sb.Length != first.Length {
+			err = fmt.Errorf("shard %v belongs to another bundle", shard.ID())
+			return
+		} else if indices[sb.Index] || len(parts) == dataShards {
+			continue
+		}
+
+		var payload []byte
+		if payload, err = shard.PayloadData(); err != nil {
+			return
+		} else if len(parts) > 0 && len(payload) != len(parts[0]) {
+			err = fmt.Errorf("shard %v has a payload of %d bytes instead of %d", shard.ID(), len(payload), len(parts[0]))
+			return
+		}
+
+		indices[sb.Index] = true
+		rows = append(rows, shardCoefficients(int(sb.Index), dataShards))
+		parts = append(parts, payload)
+	}
+
+	if len(parts) < dataShards {
+		err = fmt.Errorf("%d of %d required shards are present", len(parts), dataShards)
+		return
+	} else if uint64(len(parts[0])*dataShards) < first.Length {
+		err = fmt.Errorf("shards are too short for %d bytes", first.Length)
+		return
+	}
+
+	inv, err := invertMatrix(rows)
+	if err != nil {
+		return
+	}
+
+	data := make([]byte, 0, len(parts[0])*dataShards)
+	for i := 0; i < dataShards; i++ {
+		data = append(data, combineShards(inv[i], parts)...)
+	}
+
+	return ParseBundle(bytes.NewReader(data[:first.Length]))
+}
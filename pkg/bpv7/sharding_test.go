@@ -0,0 +1,120 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package bpv7
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func TestBundleEncodeShards(t *testing.T) {
+	tests := []struct {
+		payloadLen   int
+		dataShards   int
+		parityShards int
+	}{
+		{1024, 4, 2},
+		{1000, 3, 3},
+		{17, 5, 1},
+		{4096, 1, 2},
+		{512, 8, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("payload=%d,data=%d,parity=%d", test.payloadLen, test.dataShards, test.parityShards), func(t *testing.T) {
+			testBundleEncodeShards(t, test.payloadLen, test.dataShards, test.parityShards)
+		})
+	}
+}
+
+func testBundleEncodeShards(t *testing.T, payloadLen, dataShards, parityShards int) {
+	payload := make([]byte, payloadLen)
+	rand.Read(payload)
+
+	bndl, err := Builder().
+		Source("dtn://src/").
+		Destination("dtn://dst/").
+		CreationTimestampNow().
+		Lifetime("5m").
+		PayloadBlock(payload).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := new(bytes.Buffer)
+	if err := bndl.WriteBundle(expected); err != nil {
+		t.Fatal(err)
+	}
+
+	shards, err := bndl.EncodeShards(MustNewEndpointID("dtn://relay/"), dataShards, parityShards)
+	if err != nil {
+		t.Fatal(err)
+	} else if len(shards) != dataShards+parityShards {
+		t.Fatalf("expected %d shards, got %d", dataShards+parityShards, len(shards))
+	}
+
+	// Serialize and parse each shard, as being transmitted.
+	for i := range shards {
+		buff := new(bytes.Buffer)
+		if err := shards[i].WriteBundle(buff); err != nil {
+			t.Fatal(err)
+		}
+		if shards[i], err = ParseBundle(buff); err != nil {
+			t.Fatal(err)
+		}
+
+		if sb, ok := shards[i].Shard(); !ok || sb.Index != uint64(i) || sb.Bundle != bndl.ID() {
+			t.Fatalf("shard %d has an unexpected shard block %v", i, sb)
+		}
+	}
+
+	// Each subset of dataShards shards must reassemble the bundle, as the parity shards replace lost ones.
+	for trial := 0; trial < 10; trial++ {
+		perm := rand.Perm(len(shards))[:dataShards]
+		subset := make([]Bundle, 0, dataShards)
+		for _, i := range perm {
+			subset = append(subset, shards[i])
+		}
+
+		reassembled, err := ReassembleShards(subset)
+		if err != nil {
+			t.Fatalf("reassembling shards %v erred: %v", perm, err)
+		}
+
+		buff := new(bytes.Buffer)
+		if err := reassembled.WriteBundle(buff); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(buff.Bytes(), expected.Bytes()) {
+			t.Fatalf("shards %v were reassembled to another bundle", perm)
+		}
+	}
+
+	if _, err := ReassembleShards(shards[:dataShards-1]); err == nil {
+		t.Fatal("too few shards were reassembled")
+	}
+}
+
+func TestBundleEncodeShardsInvalid(t *testing.T) {
+	bndl, err := Builder().
+		Source("dtn://src/").
+		Destination("dtn://dst/").
+		CreationTimestampNow().
+		Lifetime("5m").
+		PayloadBlock([]byte("hello")).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, shards := range [][2]int{{0, 2}, {2, -1}, {200, 57}} {
+		if _, err := bndl.EncodeShards(MustNewEndpointID("dtn://src/"), shards[0], shards[1]); err == nil {
+			t.Fatalf("%d data and %d parity shards were accepted", shards[0], shards[1])
+		}
+	}
+}
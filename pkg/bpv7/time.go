@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"sync/atomic"
 	"time"
 
 	"github.com/dtn7/cboring"
@@ -39,9 +40,12 @@ func (t DtnTime) Time() time.Time {
 	return time.Unix(unixSec, unixNano).UTC()
 }
 
+// dtnTimeLayout is the time layout of a DtnTime's string representation.
+const dtnTimeLayout = "2006-01-02 15:04:05.000"
+
 // String returns this DtnTime's string representation.
 func (t DtnTime) String() string {
-	return t.Time().Format("2006-01-02 15:04:05.000")
+	return t.Time().Format(dtnTimeLayout)
 }
 
 // DtnTimeFromTime returns the DtnTime for the time.Time.
@@ -49,9 +53,32 @@ func DtnTimeFromTime(t time.Time) DtnTime {
 	return (DtnTime)((t.UTC().UnixNano() / nanoToMilli) - milliseconds1970To2k)
 }
 
+// clockOffset in nanoseconds is added to the system clock, e.g., disciplined by a time synchronization service.
+//
+// Like the system clock it corrects, the offset is deliberately shared by all Cores within one process. Thus, at most
+// one time synchronization service per process should set it.
+var clockOffset int64
+
+// SetClockOffset to be added to the system clock for the current time, which is, e.g., used for creation timestamps
+// and the bundles' expiration. This allows synchronizing the DTN time with other nodes without altering the system
+// clock. Zero relies on the system clock.
+func SetClockOffset(offset time.Duration) {
+	atomic.StoreInt64(&clockOffset, int64(offset))
+}
+
+// ClockOffset returns the offset added to the system clock, as set by SetClockOffset.
+func ClockOffset() time.Duration {
+	return time.Duration(atomic.LoadInt64(&clockOffset))
+}
+
+// Now returns the current time of the system clock, corrected by the ClockOffset.
+func Now() time.Time {
+	return time.Now().Add(ClockOffset())
+}
+
 // DtnTimeNow returns the current (UTC) time as DtnTime.
 func DtnTimeNow() DtnTime {
-	return DtnTimeFromTime(time.Now())
+	return DtnTimeFromTime(Now())
 }
 
 // CreationTimestamp is a tuple of a DtnTime and a sequence number (to differ
@@ -102,14 +129,14 @@ func (ct *CreationTimestamp) MarshalCbor(w io.Writer) error {
 
 // UnmarshalCbor reads a CBOR representation of a CreationTimestamp.
 func (ct *CreationTimestamp) UnmarshalCbor(r io.Reader) error {
-	if l, err := cboring.ReadArrayLength(r); err != nil {
+	if l, err := readArrayLength(r); err != nil {
 		return err
 	} else if l != 2 {
 		return fmt.Errorf("expected array with length 2, got %d", l)
 	}
 
 	for i := 0; i < 2; i++ {
-		if f, err := cboring.ReadUInt(r); err != nil {
+		if f, err := readUInt(r); err != nil {
 			return err
 		} else {
 			ct[i] = f
@@ -129,3 +156,22 @@ func (ct CreationTimestamp) MarshalJSON() ([]byte, error) {
 		Seq:  ct.SequenceNumber(),
 	})
 }
+
+// UnmarshalJSON reads a JSON object of a CreationTimestamp, as created by MarshalJSON.
+func (ct *CreationTimestamp) UnmarshalJSON(data []byte) error {
+	var tmp struct {
+		Date string `json:"date"`
+		Seq  uint64 `json:"sequenceNo"`
+	}
+	if err := json.Unmarshal(data, &tmp); err != nil {
+		return err
+	}
+
+	t, err := time.ParseInLocation(dtnTimeLayout, tmp.Date, time.UTC)
+	if err != nil {
+		return err
+	}
+
+	*ct = NewCreationTimestamp(DtnTimeFromTime(t), tmp.Seq)
+	return nil
+}
@@ -93,3 +93,16 @@ func TestCreationTimestampJson(t *testing.T) {
 		})
 	}
 }
+
+func TestClockOffset(t *testing.T) {
+	defer SetClockOffset(0)
+
+	SetClockOffset(time.Hour)
+	if offset := ClockOffset(); offset != time.Hour {
+		t.Fatalf("expected offset of an hour, got %v", offset)
+	}
+
+	if d := DtnTimeNow().Time().Sub(time.Now()); d < 59*time.Minute || d > 61*time.Minute {
+		t.Fatalf("DTN time differs by %v from the system clock, expected an hour", d)
+	}
+}
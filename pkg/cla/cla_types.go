@@ -28,6 +28,9 @@ const (
 
 	QUICL CLAType = 30
 
+	// STCP identifies ION's Simple TCP convergence layer for BPv6 bundles, implemented in cla/stcp.
+	STCP CLAType = 40
+
 	unknownClaTypeString string = "unknown CLA type"
 )
 
@@ -56,6 +59,9 @@ func (claType CLAType) String() string {
 	case QUICL:
 		return "QUICL"
 
+	case STCP:
+		return "STCP"
+
 	default:
 		return unknownClaTypeString
 	}
@@ -24,6 +24,7 @@
 package cla
 
 import (
+	"fmt"
 	"io"
 
 	"github.com/dtn7/dtn7-go/pkg/bpv7"
@@ -95,6 +96,39 @@ type ConvergenceSender interface {
 	GetPeerEndpointID() bpv7.EndpointID
 }
 
+// InterruptedTransferError is returned by a ConvergenceSender's Send method if a transfer was interrupted after the
+// peer has already acknowledged a part of the serialized Bundle. This allows a reactive fragmentation of the remaining
+// payload, while the peer keeps the received part as a fragment.
+type InterruptedTransferError struct {
+	// Acknowledged is the number of the serialized Bundle's bytes acknowledged by the peer.
+	Acknowledged int
+
+	// Err is the cause of the interruption.
+	Err error
+}
+
+func (e *InterruptedTransferError) Error() string {
+	return fmt.Sprintf("transfer interrupted after %d acknowledged bytes: %v", e.Acknowledged, e.Err)
+}
+
+func (e *InterruptedTransferError) Unwrap() error {
+	return e.Err
+}
+
+// ConvergenceMtu is an optional interface for ConvergenceSenders with a limited maximum transmission unit (MTU).
+// Bundles exceeding this MTU are proactively fragmented before being sent.
+type ConvergenceMtu interface {
+	// Mtu returns the maximum length of a serialized Bundle in bytes. Zero indicates no limit.
+	Mtu() int
+}
+
+// ConvergenceActivity might be implemented by a Convergence to report its unfinished transfers, e.g., to drain them
+// before shutting down.
+type ConvergenceActivity interface {
+	// ActiveTransfers returns the number of incoming and outgoing transfers in progress.
+	ActiveTransfers() int
+}
+
 // ConvergenceProvider is a more general kind of CLA service which does not
 // transfer any Bundles by itself, but supplies/creates new Convergence types.
 // Those Convergence objects will be passed to a Manager. Thus, one might think
@@ -9,6 +9,7 @@ package cla
 
 import (
 	"sync"
+	"sync/atomic"
 	"time"
 
 	log "github.com/sirupsen/logrus"
@@ -31,7 +32,14 @@ type Manager struct {
 	// convs: Map[string]*convergenceElem
 	convs *sync.Map
 
-	listenerIDs map[CLAType][]bpv7.EndpointID
+	// snapshot holds a *convergenceSnapshot of the active senders and receivers. It is rebuilt after each change of
+	// convs or of an element's activity, while Sender and Receiver only load the current snapshot without locking.
+	// snapshotMutex serializes the rebuilds.
+	snapshot      atomic.Value
+	snapshotMutex sync.Mutex
+
+	listenerIDs      map[CLAType][]bpv7.EndpointID
+	listenerIDsMutex sync.RWMutex
 
 	// providers is an array of ConvergenceProvider. Those will report their
 	// created Convergence objects to this Manager, which also supervises it.
@@ -73,11 +81,48 @@ func NewManager() *Manager {
 		stopFlag: false,
 	}
 
+	manager.snapshot.Store(new(convergenceSnapshot))
+
 	go manager.handler()
 
 	return manager
 }
 
+// convergenceSnapshot is an immutable list of the active senders and receivers at some point in time.
+type convergenceSnapshot struct {
+	senders   []ConvergenceSender
+	receivers []ConvergenceReceiver
+}
+
+// updateSnapshot rebuilds the snapshot from the currently active convergenceElems.
+func (manager *Manager) updateSnapshot() {
+	manager.snapshotMutex.Lock()
+	defer manager.snapshotMutex.Unlock()
+
+	snapshot := new(convergenceSnapshot)
+	manager.convs.Range(func(_, convElem interface{}) bool {
+		ce := convElem.(*convergenceElem)
+		if !ce.isActive() {
+			return true
+		}
+
+		if cs, ok := ce.asSender(); ok {
+			snapshot.senders = append(snapshot.senders, cs)
+		}
+		if cr, ok := ce.asReceiver(); ok {
+			snapshot.receivers = append(snapshot.receivers, cr)
+		}
+		return true
+	})
+
+	manager.snapshot.Store(snapshot)
+}
+
+// loadSnapshot returns the current snapshot, which must not be modified.
+func (manager *Manager) loadSnapshot() *convergenceSnapshot {
+	return manager.snapshot.Load().(*convergenceSnapshot)
+}
+
 // handler is the internal goroutine for management.
 func (manager *Manager) handler() {
 	activateTicker := time.NewTicker(manager.retryTime)
@@ -126,13 +171,16 @@ func (manager *Manager) handler() {
 			}
 
 		case <-activateTicker.C:
+			changed := false
 			manager.convs.Range(func(key, convElem interface{}) bool {
 				ce := convElem.(*convergenceElem)
 				if ce.isActive() {
 					return true
 				}
 
-				if successful, retry := ce.activate(); !successful && !retry {
+				if successful, retry := ce.activate(); successful {
+					changed = true
+				} else if !retry {
 					log.WithFields(log.Fields{
 						"cla": ce.conv,
 					}).Warn("Startup of CLA failed, a retry should not be made")
@@ -141,6 +189,10 @@ func (manager *Manager) handler() {
 				}
 				return true
 			})
+
+			if changed {
+				manager.updateSnapshot()
+			}
 		}
 	}
 }
@@ -223,6 +275,7 @@ func (manager *Manager) registerConvergence(conv Convergence) {
 		}).Warn("Startup of CLA  failed, a retry should not be made")
 	} else {
 		manager.convs.Store(conv.Address(), ce)
+		manager.updateSnapshot()
 	}
 }
 
@@ -279,8 +332,10 @@ func (manager *Manager) unregisterConvergence(conv Convergence) {
 		return
 	}
 
-	element.deactivate(manager.queueTtl)
 	manager.convs.Delete(conv.Address())
+	manager.updateSnapshot()
+
+	element.deactivate(manager.queueTtl)
 }
 
 func (manager *Manager) unregisterProvider(conv ConvergenceProvider) {
@@ -302,15 +357,20 @@ func (manager *Manager) Restart(conv Convergable) {
 }
 
 // Sender returns an array of all active ConvergenceSenders.
+//
+// The array is a copy of the current snapshot, which is not blocked by concurrent (un)registrations.
 func (manager *Manager) Sender() (css []ConvergenceSender) {
-	manager.convs.Range(func(_, convElem interface{}) bool {
-		ce := convElem.(*convergenceElem)
-		if !ce.isActive() {
-			return true
-		}
+	if senders := manager.loadSnapshot().senders; len(senders) > 0 {
+		css = append(css, senders...)
+	}
+	return
+}
 
-		if cs, ok := ce.asSender(); ok {
-			css = append(css, cs)
+// ActiveTransfers returns the number of unfinished transfers of all Convergences implementing ConvergenceActivity.
+func (manager *Manager) ActiveTransfers() (n int) {
+	manager.convs.Range(func(_, convElem interface{}) bool {
+		if ca, ok := convElem.(*convergenceElem).conv.(ConvergenceActivity); ok {
+			n += ca.ActiveTransfers()
 		}
 		return true
 	})
@@ -318,22 +378,19 @@ func (manager *Manager) Sender() (css []ConvergenceSender) {
 }
 
 // Receiver returns an array of all active ConvergenceReceivers.
+//
+// The array is a copy of the current snapshot, which is not blocked by concurrent (un)registrations.
 func (manager *Manager) Receiver() (crs []ConvergenceReceiver) {
-	manager.convs.Range(func(_, convElem interface{}) bool {
-		ce := convElem.(*convergenceElem)
-		if !ce.isActive() {
-			return true
-		}
-
-		if cr, ok := ce.asReceiver(); ok {
-			crs = append(crs, cr)
-		}
-		return true
-	})
+	if receivers := manager.loadSnapshot().receivers; len(receivers) > 0 {
+		crs = append(crs, receivers...)
+	}
 	return
 }
 
 func (manager *Manager) RegisterEndpointID(claType CLAType, eid bpv7.EndpointID) {
+	manager.listenerIDsMutex.Lock()
+	defer manager.listenerIDsMutex.Unlock()
+
 	clas, ok := manager.listenerIDs[claType]
 
 	if ok {
@@ -345,9 +402,35 @@ func (manager *Manager) RegisterEndpointID(claType CLAType, eid bpv7.EndpointID)
 	manager.listenerIDs[claType] = clas
 }
 
+// UnregisterEndpointID removes one occurrence of an EndpointID registered by RegisterEndpointID, e.g., for a stopped
+// listener. Other listeners of the same type might still share this EndpointID.
+func (manager *Manager) UnregisterEndpointID(claType CLAType, eid bpv7.EndpointID) {
+	manager.listenerIDsMutex.Lock()
+	defer manager.listenerIDsMutex.Unlock()
+
+	clas := manager.listenerIDs[claType]
+	for i, id := range clas {
+		if id != eid {
+			continue
+		}
+
+		// A new slice is created, as EndpointIDs' callers might still use the old one.
+		remaining := append(append([]bpv7.EndpointID{}, clas[:i]...), clas[i+1:]...)
+		if len(remaining) == 0 {
+			delete(manager.listenerIDs, claType)
+		} else {
+			manager.listenerIDs[claType] = remaining
+		}
+		return
+	}
+}
+
 // EndpointIDs returns the EndpointIDs of all registered CLAs of the specified type.
 // Returns an empty slice if no CLAs of the tye exist.
 func (manager *Manager) EndpointIDs(claType CLAType) []bpv7.EndpointID {
+	manager.listenerIDsMutex.RLock()
+	defer manager.listenerIDsMutex.RUnlock()
+
 	if clas, ok := manager.listenerIDs[claType]; ok {
 		return clas
 	} else {
@@ -356,9 +439,12 @@ func (manager *Manager) EndpointIDs(claType CLAType) []bpv7.EndpointID {
 }
 
 func (manager *Manager) HasEndpoint(endpoint bpv7.EndpointID) bool {
+	manager.listenerIDsMutex.RLock()
+	defer manager.listenerIDsMutex.RUnlock()
+
 	for _, clas := range manager.listenerIDs {
 		for _, adapter := range clas {
-			if adapter.Authority() == endpoint.Authority() {
+			if adapter.SameNode(endpoint) {
 				return true
 			}
 		}
@@ -117,3 +117,94 @@ func TestManager(t *testing.T) {
 		}
 	}
 }
+
+func TestManagerSenderSnapshot(t *testing.T) {
+	const senderNo = 50
+
+	var manager = NewManager()
+	defer func() { _ = manager.Close() }()
+
+	go func(ch chan ConvergenceStatus) {
+		for range ch {
+		}
+	}(manager.Channel())
+
+	var sender [senderNo]ConvergenceSender
+	for i := 0; i < senderNo; i++ {
+		sender[i] = newMockConvSender(
+			true, fmt.Sprintf("mock://sender_%d/", i),
+			bpv7.MustNewEndpointID(fmt.Sprintf("dtn://ms_%d/", i)))
+
+		manager.Register(sender[i])
+	}
+
+	// Concurrent readers must always see a consistent snapshot, while senders are being unregistered.
+	var readErrCh = make(chan error, 4)
+	var stopCh = make(chan struct{})
+	var readWg sync.WaitGroup
+	readWg.Add(cap(readErrCh))
+	for i := 0; i < cap(readErrCh); i++ {
+		go func() {
+			defer readWg.Done()
+			for {
+				select {
+				case <-stopCh:
+					return
+				default:
+				}
+
+				if l := len(manager.Sender()); l < senderNo/2 || l > senderNo {
+					readErrCh <- fmt.Errorf("snapshot has %d senders", l)
+					return
+				}
+			}
+		}()
+	}
+
+	for i := 0; i < senderNo/2; i++ {
+		manager.Unregister(sender[i])
+
+		for _, cs := range manager.Sender() {
+			if cs == sender[i] {
+				t.Fatalf("unregistered sender %v is still part of the snapshot", cs)
+			}
+		}
+	}
+
+	close(stopCh)
+	readWg.Wait()
+	close(readErrCh)
+	for err := range readErrCh {
+		t.Fatal(err)
+	}
+
+	if css := manager.Sender(); len(css) != senderNo/2 {
+		t.Fatalf("Wrong amount of senders, expected: %d, got: %d", senderNo/2, len(css))
+	}
+}
+
+func TestManagerUnregisterEndpointID(t *testing.T) {
+	manager := NewManager()
+	defer func() { _ = manager.Close() }()
+
+	a, b := bpv7.MustNewEndpointID("dtn://a/"), bpv7.MustNewEndpointID("dtn://b/")
+	manager.RegisterEndpointID(MTCP, a)
+	manager.RegisterEndpointID(MTCP, a)
+	manager.RegisterEndpointID(MTCP, b)
+
+	manager.UnregisterEndpointID(MTCP, a)
+	if eids := manager.EndpointIDs(MTCP); len(eids) != 2 || !manager.HasEndpoint(a) {
+		t.Fatalf("expected one remaining occurrence of %v, got %v", a, eids)
+	}
+
+	manager.UnregisterEndpointID(MTCP, a)
+	manager.UnregisterEndpointID(TCPCLv4, b)
+	if eids := manager.EndpointIDs(MTCP); len(eids) != 1 || eids[0] != b || manager.HasEndpoint(a) {
+		t.Fatalf("expected only %v, got %v", b, eids)
+	}
+
+	manager.UnregisterEndpointID(MTCP, b)
+	if eids := manager.EndpointIDs(MTCP); len(eids) != 0 {
+		t.Fatalf("expected no endpoint IDs, got %v", eids)
+	}
+}
@@ -8,8 +8,6 @@
 package mtcp
 
 import (
-	"bufio"
-	"bytes"
 	"fmt"
 	"net"
 	"sync"
@@ -21,6 +19,7 @@ import (
 
 	"github.com/dtn7/dtn7-go/pkg/bpv7"
 	"github.com/dtn7/dtn7-go/pkg/cla"
+	"github.com/dtn7/dtn7-go/pkg/internal/bufpool"
 )
 
 // MTCPClient is an implementation of a Minimal TCP Convergence-Layer client
@@ -126,21 +125,24 @@ func (client *MTCPClient) Send(bndl bpv7.Bundle) (err error) {
 	client.mutex.Lock()
 	defer client.mutex.Unlock()
 
-	connWriter := bufio.NewWriter(client.conn)
-
-	buff := new(bytes.Buffer)
-	if cborErr := cboring.Marshal(&bndl, buff); cborErr != nil {
-		err = cborErr
+	// The Bundle is streamed into the connection, prefixed by its precalculated length. Thus, a large payload backed
+	// by a file is never fully resident in memory.
+	bndlLen, sizeErr := bndl.SerializedSize()
+	if sizeErr != nil {
+		err = sizeErr
 		return
 	}
 
-	if bsErr := cboring.WriteByteStringLen(uint64(buff.Len()), connWriter); bsErr != nil {
+	connWriter := bufpool.GetWriter(client.conn)
+	defer bufpool.PutWriter(connWriter)
+
+	if bsErr := cboring.WriteByteStringLen(bndlLen, connWriter); bsErr != nil {
 		err = bsErr
 		return
 	}
 
-	if _, plErr := buff.WriteTo(connWriter); plErr != nil {
-		err = plErr
+	if cborErr := cboring.Marshal(&bndl, connWriter); cborErr != nil {
+		err = cborErr
 		return
 	}
 
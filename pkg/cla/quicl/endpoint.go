@@ -20,6 +20,7 @@ import (
 	"github.com/dtn7/dtn7-go/pkg/bpv7"
 	"github.com/dtn7/dtn7-go/pkg/cla"
 	"github.com/dtn7/dtn7-go/pkg/cla/quicl/internal"
+	"github.com/dtn7/dtn7-go/pkg/internal/bufpool"
 	"github.com/quic-go/quic-go"
 	log "github.com/sirupsen/logrus"
 )
@@ -180,17 +181,14 @@ func (endpoint *Endpoint) Send(bndl bpv7.Bundle) error {
 		return err
 	}
 
-	buff := new(bytes.Buffer)
-	if err = cboring.Marshal(&bndl, buff); err != nil {
-		stream.CancelWrite(internal.DataMarshalError)
-		_ = stream.Close()
-		return err
-	}
-
+	// The Bundle is marshalled directly into the stream, allowing large payloads to be streamed from disk. A failed
+	// marshalling cancels the stream, so the peer discards the partial Bundle.
 	// TODO: Do we actually need the bufio-wrapper?
-	writer := bufio.NewWriter(stream)
-	if _, err = buff.WriteTo(writer); err != nil {
-		stream.CancelWrite(internal.StreamTransmissionError)
+	writer := bufpool.GetWriter(stream)
+	defer bufpool.PutWriter(writer)
+
+	if err = cboring.Marshal(&bndl, writer); err != nil {
+		stream.CancelWrite(internal.DataMarshalError)
 		_ = stream.Close()
 		return err
 	}
@@ -0,0 +1,15 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+// Package stcp provides the Simple TCP convergence layer of ION, carrying BPv6 bundles, RFC 5050, each prefixed by its
+// length as a 32-bit unsigned integer in network byte order. A zero length is a keepalive.
+//
+// Thus, dtn7 acts as a gateway for legacy BPv6 nodes: received BPv6 bundles are converted into BPv7 bundles and sent
+// BPv7 bundles are converted into BPv6 bundles, as implemented in the bpv6 package. Bundles which cannot be converted,
+// e.g., custody signals, are dropped.
+//
+// Like the mtcp package, both an STCPServer and an STCPClient exist due to STCP's unidirectional design. The
+// STCPServer implements the ConvergenceReceiver and the STCPClient the ConvergenceSender interfaces defined in the
+// parent cla package.
+package stcp
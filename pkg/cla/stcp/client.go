@@ -0,0 +1,163 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package stcp
+
+import (
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+	"github.com/dtn7/dtn7-go/pkg/cla"
+	"github.com/dtn7/dtn7-go/pkg/internal/bufpool"
+)
+
+// STCPClient connects to a STCP server, e.g., of an ION node, converting the sent BPv7 bundles into BPv6 bundles. This
+// struct implements a ConvergenceSender.
+type STCPClient struct {
+	conn       net.Conn
+	peer       bpv7.EndpointID
+	mutex      sync.Mutex
+	reportChan chan cla.ConvergenceStatus
+
+	permanent bool
+	address   string
+
+	stopSyn chan struct{}
+	stopAck chan struct{}
+}
+
+// NewSTCPClient creates a new STCPClient, connected to the given address for the peer's endpoint ID. The permanent
+// flag indicates if this STCPClient should never be removed from the core.
+func NewSTCPClient(address string, peer bpv7.EndpointID, permanent bool) *STCPClient {
+	return &STCPClient{
+		peer:      peer,
+		permanent: permanent,
+		address:   address,
+	}
+}
+
+func (client *STCPClient) Start() (err error, retry bool) {
+	retry = true
+
+	dialer := &net.Dialer{
+		Timeout:   time.Second,
+		KeepAlive: 5 * time.Second,
+	}
+	conn, connErr := dialer.Dial("tcp", client.address)
+	if connErr != nil {
+		err = connErr
+		return
+	}
+
+	client.reportChan = make(chan cla.ConvergenceStatus)
+	client.stopSyn = make(chan struct{})
+	client.stopAck = make(chan struct{})
+
+	client.conn = conn
+
+	go client.handler()
+	return
+}
+
+func (client *STCPClient) handler() {
+	var ticker = time.NewTicker(5 * time.Second)
+	defer ticker.Stop()
+
+	// Introduce ourselves once
+	client.reportChan <- cla.NewConvergencePeerAppeared(client, client.GetPeerEndpointID())
+
+	for {
+		select {
+		case <-client.stopSyn:
+			_ = client.conn.Close()
+
+			close(client.reportChan)
+			close(client.stopAck)
+
+			return
+
+		case <-ticker.C:
+			client.mutex.Lock()
+			err := writeKeepalive(client.conn)
+			client.mutex.Unlock()
+
+			if err != nil {
+				log.WithFields(log.Fields{
+					"client": client.String(),
+					"error":  err,
+				}).Error("STCPClient: Keepalive erred")
+
+				client.reportChan <- cla.NewConvergencePeerDisappeared(client, client.GetPeerEndpointID())
+			}
+		}
+	}
+}
+
+func (client *STCPClient) Send(bndl bpv7.Bundle) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("STCPClient.Send: %v", r)
+		}
+	}()
+
+	// A bundle which cannot be converted does not affect the connection.
+	data, encErr := encodeBundle(bndl)
+	if encErr != nil {
+		return fmt.Errorf("bundle cannot be converted into BPv6: %v", encErr)
+	}
+
+	defer func() {
+		if err != nil {
+			client.reportChan <- cla.NewConvergencePeerDisappeared(client, client.GetPeerEndpointID())
+		}
+	}()
+
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+
+	connWriter := bufpool.GetWriter(client.conn)
+	defer bufpool.PutWriter(connWriter)
+
+	if err = writeFrame(data, connWriter); err != nil {
+		return
+	}
+	err = connWriter.Flush()
+	return
+}
+
+func (client *STCPClient) Channel() chan cla.ConvergenceStatus {
+	return client.reportChan
+}
+
+func (client *STCPClient) Close() error {
+	close(client.stopSyn)
+	<-client.stopAck
+
+	return nil
+}
+
+func (client *STCPClient) GetPeerEndpointID() bpv7.EndpointID {
+	return client.peer
+}
+
+func (client *STCPClient) Address() string {
+	return client.address
+}
+
+func (client *STCPClient) IsPermanent() bool {
+	return client.permanent
+}
+
+func (client *STCPClient) String() string {
+	if client.conn != nil {
+		return fmt.Sprintf("stcp://%v", client.conn.RemoteAddr())
+	} else {
+		return fmt.Sprintf("stcp://%s", client.address)
+	}
+}
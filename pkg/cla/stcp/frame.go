@@ -0,0 +1,76 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package stcp
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv6"
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+// maxBundleSize limits a received BPv6 bundle, which is held in memory for its conversion.
+const maxBundleSize uint32 = 256 * 1024 * 1024
+
+// encodeBundle converts a BPv7 bundle into a serialized BPv6 bundle.
+func encodeBundle(bndl bpv7.Bundle) ([]byte, error) {
+	b6, err := bpv6.FromBpv7(bndl)
+	if err != nil {
+		return nil, err
+	}
+
+	buff := new(bytes.Buffer)
+	if err := b6.WriteBundle(buff); err != nil {
+		return nil, err
+	} else if uint64(buff.Len()) > math.MaxUint32 {
+		return nil, fmt.Errorf("BPv6 bundle of %d bytes exceeds STCP's limit", buff.Len())
+	}
+	return buff.Bytes(), nil
+}
+
+// writeFrame writes a serialized BPv6 bundle, prefixed by its length.
+func writeFrame(data []byte, w io.Writer) error {
+	if err := binary.Write(w, binary.BigEndian, uint32(len(data))); err != nil {
+		return err
+	}
+	_, err := w.Write(data)
+	return err
+}
+
+// writeKeepalive writes an empty frame.
+func writeKeepalive(w io.Writer) error {
+	return binary.Write(w, binary.BigEndian, uint32(0))
+}
+
+// readFrame reads the next frame's BPv6 bundle. For a keepalive, the data is nil.
+func readFrame(r io.Reader) ([]byte, error) {
+	var l uint32
+	if err := binary.Read(r, binary.BigEndian, &l); err != nil {
+		return nil, err
+	} else if l == 0 {
+		return nil, nil
+	} else if l > maxBundleSize {
+		return nil, fmt.Errorf("frame of %d bytes exceeds the limit of %d bytes", l, maxBundleSize)
+	}
+
+	data := make([]byte, l)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// convertBundle parses a BPv6 bundle and converts it into BPv7.
+func convertBundle(data []byte) (bpv7.Bundle, error) {
+	b6, err := bpv6.ParseBundle(bytes.NewReader(data))
+	if err != nil {
+		return bpv7.Bundle{}, err
+	}
+	return b6.ToBpv7()
+}
@@ -0,0 +1,154 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package stcp
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+	"github.com/dtn7/dtn7-go/pkg/cla"
+)
+
+// STCPServer accepts BPv6 bundles from multiple connections, converts them into BPv7 bundles, and forwards them to
+// its channel. This struct implements a ConvergenceReceiver.
+type STCPServer struct {
+	listenAddress string
+	reportChan    chan cla.ConvergenceStatus
+	endpointID    bpv7.EndpointID
+	permanent     bool
+
+	stopSyn chan struct{}
+	stopAck chan struct{}
+}
+
+// NewSTCPServer creates a new STCPServer for the given listen address. The permanent flag indicates if this
+// STCPServer should never be removed from the core.
+func NewSTCPServer(listenAddress string, endpointID bpv7.EndpointID, permanent bool) *STCPServer {
+	return &STCPServer{
+		listenAddress: listenAddress,
+		reportChan:    make(chan cla.ConvergenceStatus),
+		endpointID:    endpointID,
+		permanent:     permanent,
+		stopSyn:       make(chan struct{}),
+		stopAck:       make(chan struct{}),
+	}
+}
+
+func (serv *STCPServer) Start() (error, bool) {
+	tcpAddr, err := net.ResolveTCPAddr("tcp", serv.listenAddress)
+	if err != nil {
+		return err, false
+	}
+
+	ln, err := net.ListenTCP("tcp", tcpAddr)
+	if err != nil {
+		return err, true
+	}
+
+	go func(ln *net.TCPListener) {
+		for {
+			select {
+			case <-serv.stopSyn:
+				_ = ln.Close()
+				close(serv.reportChan)
+				close(serv.stopAck)
+
+				return
+
+			default:
+				if err := ln.SetDeadline(time.Now().Add(50 * time.Millisecond)); err != nil {
+					log.WithFields(log.Fields{
+						"cla":   serv,
+						"error": err,
+					}).Error("STCPServer failed to set deadline on TCP socket")
+
+					_ = serv.Close()
+				} else if conn, err := ln.Accept(); err == nil {
+					go serv.handleSender(conn)
+				}
+			}
+		}
+	}(ln)
+
+	return nil, true
+}
+
+func (serv *STCPServer) handleSender(conn net.Conn) {
+	logger := log.WithFields(log.Fields{
+		"cla":  serv,
+		"conn": conn.RemoteAddr(),
+	})
+
+	defer func() {
+		_ = conn.Close()
+
+		// The report channel is closed on a shutdown, possibly while a received bundle is passed on.
+		if r := recover(); r != nil {
+			logger.WithField("error", r).Debug("STCPServer's sender failed")
+		}
+	}()
+	logger.Debug("STCP connection was established")
+
+	connReader := bufio.NewReader(conn)
+	for {
+		data, err := readFrame(connReader)
+		if err != nil {
+			if err != io.EOF {
+				logger.WithError(err).Warn("STCP connection failed to read a frame")
+			}
+			return
+		} else if data == nil {
+			continue
+		}
+
+		// A bundle which cannot be converted is dropped, while the connection is kept.
+		bndl, err := convertBundle(data)
+		if err != nil {
+			logger.WithError(err).Info("STCP connection dropped a BPv6 bundle which cannot be converted")
+			continue
+		}
+
+		logger.WithField("bundle", bndl.ID()).Debug("STCP connection received a BPv6 bundle")
+
+		select {
+		case serv.reportChan <- cla.NewConvergenceReceivedBundle(serv, serv.endpointID, &bndl):
+		case <-serv.stopSyn:
+			return
+		}
+	}
+}
+
+func (serv *STCPServer) Channel() chan cla.ConvergenceStatus {
+	return serv.reportChan
+}
+
+func (serv *STCPServer) Close() error {
+	close(serv.stopSyn)
+	<-serv.stopAck
+
+	return nil
+}
+
+func (serv *STCPServer) GetEndpointID() bpv7.EndpointID {
+	return serv.endpointID
+}
+
+func (serv *STCPServer) Address() string {
+	return fmt.Sprintf("stcp://%s", serv.listenAddress)
+}
+
+func (serv *STCPServer) IsPermanent() bool {
+	return serv.permanent
+}
+
+func (serv *STCPServer) String() string {
+	return serv.Address()
+}
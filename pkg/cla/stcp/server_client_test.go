@@ -0,0 +1,134 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package stcp
+
+import (
+	"bytes"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv6"
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+	"github.com/dtn7/dtn7-go/pkg/cla"
+)
+
+func getRandomAddress(t *testing.T) string {
+	l, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = l.Close() }()
+
+	return l.Addr().String()
+}
+
+// receiveBundle from the STCPServer's channel.
+func receiveBundle(t *testing.T, serv *STCPServer) *bpv7.Bundle {
+	select {
+	case cs := <-serv.Channel():
+		if cs.MessageType != cla.ReceivedBundle {
+			t.Fatalf("wrong message type %v", cs.MessageType)
+		}
+		return cs.Message.(cla.ConvergenceReceivedBundle).Bundle
+
+	case <-time.After(5 * time.Second):
+		t.Fatal("no bundle was received")
+		return nil
+	}
+}
+
+func TestSTCPServerClient(t *testing.T) {
+	address := getRandomAddress(t)
+
+	serv := NewSTCPServer(address, bpv7.MustNewEndpointID("ipn:1.0"), false)
+	if err, _ := serv.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = serv.Close() }()
+
+	client := NewSTCPClient(address, bpv7.MustNewEndpointID("ipn:1.0"), false)
+	if err, _ := client.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = client.Close() }()
+	go func() {
+		for range client.Channel() {
+		}
+	}()
+
+	bndl, err := bpv7.Builder().
+		Source("ipn:2.1").
+		Destination("ipn:1.1").
+		CreationTimestampNow().
+		Lifetime("60s").
+		PayloadBlock([]byte("hello bpv6")).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := client.Send(bndl); err != nil {
+		t.Fatal(err)
+	}
+
+	recBndl := receiveBundle(t, serv)
+	if recBndl.PrimaryBlock.SourceNode != bndl.PrimaryBlock.SourceNode || recBndl.PrimaryBlock.Destination != bndl.PrimaryBlock.Destination {
+		t.Fatalf("received bundle has endpoints %v, %v", recBndl.PrimaryBlock.SourceNode, recBndl.PrimaryBlock.Destination)
+	} else if ts := bndl.PrimaryBlock.CreationTimestamp; recBndl.PrimaryBlock.CreationTimestamp.DtnTime() != ts.DtnTime()/1000*1000 {
+		t.Fatalf("received bundle has creation timestamp %v, expected %v", recBndl.PrimaryBlock.CreationTimestamp, ts)
+	} else if payload, err := recBndl.PayloadData(); err != nil || string(payload) != "hello bpv6" {
+		t.Fatalf("received bundle has payload %q, %v", payload, err)
+	}
+}
+
+func TestSTCPServerFrames(t *testing.T) {
+	address := getRandomAddress(t)
+
+	serv := NewSTCPServer(address, bpv7.MustNewEndpointID("dtn://gateway/"), false)
+	if err, _ := serv.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = serv.Close() }()
+
+	conn, err := net.Dial("tcp", address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	b6 := bpv6.Bundle{
+		PrimaryBlock: bpv6.PrimaryBlock{
+			BundleControlFlags: bpv6.DestinationIsSingleton,
+			Destination:        bpv6.MustNewEndpointID("dtn://gateway/inbox"),
+			SourceNode:         bpv6.MustNewEndpointID("dtn://legacy/outbox"),
+			ReportTo:           bpv6.DtnNone(),
+			Custodian:          bpv6.DtnNone(),
+			CreationTimestamp:  bpv6.CreationTimestamp{Seconds: uint64(bpv7.DtnTimeNow()) / 1000},
+			Lifetime:           60,
+		},
+		CanonicalBlocks: []bpv6.CanonicalBlock{bpv6.NewPayloadBlock([]byte("hello bpv7"))},
+	}
+	buff := new(bytes.Buffer)
+	if err := b6.WriteBundle(buff); err != nil {
+		t.Fatal(err)
+	}
+
+	// A keepalive and a frame which cannot be converted are skipped.
+	if err := writeKeepalive(conn); err != nil {
+		t.Fatal(err)
+	} else if err := writeFrame([]byte("no bundle"), conn); err != nil {
+		t.Fatal(err)
+	} else if err := writeFrame(buff.Bytes(), conn); err != nil {
+		t.Fatal(err)
+	}
+
+	recBndl := receiveBundle(t, serv)
+	if dst := recBndl.PrimaryBlock.Destination; dst != bpv7.MustNewEndpointID("dtn://gateway/inbox") {
+		t.Fatalf("received bundle has destination %v", dst)
+	} else if payload, err := recBndl.PayloadData(); err != nil || string(payload) != "hello bpv7" {
+		t.Fatalf("received bundle has payload %q, %v", payload, err)
+	}
+}
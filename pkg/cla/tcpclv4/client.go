@@ -8,7 +8,9 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	log "github.com/sirupsen/logrus"
@@ -41,6 +43,9 @@ type Client struct {
 	nodeId     bpv7.EndpointID
 	peerNodeId bpv7.EndpointID
 
+	// transferMtu is the peer's Transfer MRU, accessed atomically.
+	transferMtu uint64
+
 	reportChan chan cla.ConvergenceStatus
 
 	closeChanSyn chan struct{}
@@ -121,6 +126,7 @@ func (client *Client) Start() (err error, retry bool) {
 			},
 			PostHook: func(_ *stages.StageHandler, state *stages.State) error {
 				client.peerNodeId = state.PeerNodeId
+				atomic.StoreUint64(&client.transferMtu, state.TransferMtu)
 				return nil
 			},
 		},
@@ -162,10 +168,20 @@ func (client *Client) handle() {
 	defer func() {
 		client.log().Info("Closing down TCPCLv4")
 
+		if err := client.transferManager.Close(); err != nil {
+			client.log().WithError(err).Debug("Error occurred while closing the transfer manager")
+		}
+
+		// Keep the received parts of interrupted incoming transfers as reactive fragments.
+		for _, b := range client.transferManager.InterruptedBundles() {
+			b := b
+			client.log().WithField("bundle", b).Info("Received reactive fragment of an interrupted transfer")
+			client.reportChan <- cla.NewConvergenceReceivedBundle(client, client.nodeId, &b)
+		}
+
 		client.reportChan <- cla.NewConvergencePeerDisappeared(client, client.peerNodeId)
 
 		closeErrFuncs := []func() error{
-			client.transferManager.Close,
 			client.stageHandler.Close,
 			client.messageSwitch.Close,
 			func() error {
@@ -224,6 +240,24 @@ func (client *Client) Send(b bpv7.Bundle) error {
 	return client.transferManager.Send(b)
 }
 
+// Mtu returns the peer's Transfer MRU, the maximum length of a serialized Bundle. Before the session is established,
+// zero is returned for no known limit.
+func (client *Client) Mtu() int {
+	if mtu := atomic.LoadUint64(&client.transferMtu); mtu > math.MaxInt {
+		return math.MaxInt
+	} else {
+		return int(mtu)
+	}
+}
+
+// ActiveTransfers returns the number of unfinished incoming and outgoing transfers, see cla.ConvergenceActivity.
+func (client *Client) ActiveTransfers() int {
+	if tm := client.transferManager; tm != nil {
+		return tm.ActiveTransfers()
+	}
+	return 0
+}
+
 // Close signals this Client to shut down.
 func (client *Client) Close() error {
 	close(client.closeChanSyn)
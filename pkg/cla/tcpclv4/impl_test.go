@@ -65,6 +65,8 @@ func handleListener(
 
 				if sender, ok := cs.Sender.(cla.ConvergenceSender); !ok {
 					errs <- fmt.Errorf("listener: new peer is not a ConvergenceSender; %v", cs)
+				} else if mtu := cs.Sender.(cla.ConvergenceMtu).Mtu(); mtu != 1073741824 {
+					errs <- fmt.Errorf("listener: peer's MTU is %d instead of its Transfer MRU", mtu)
 				} else {
 					dst := cs.Sender.(cla.ConvergenceSender).GetPeerEndpointID()
 					bndl, err := bpv7.Builder().
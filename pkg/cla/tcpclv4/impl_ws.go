@@ -15,6 +15,7 @@ import (
 	"github.com/dtn7/dtn7-go/pkg/bpv7"
 	"github.com/dtn7/dtn7-go/pkg/cla"
 	"github.com/dtn7/dtn7-go/pkg/cla/tcpclv4/internal/utils"
+	"github.com/dtn7/dtn7-go/pkg/internal/bufpool"
 )
 
 // WebSocketListener is a TCPCLv4 server as a http.Handler to accept incoming TCPCLv4 connections via WebSockets.
@@ -33,7 +34,7 @@ type WebSocketListener struct {
 func ListenWebSocket(endpointID bpv7.EndpointID) *WebSocketListener {
 	return &WebSocketListener{
 		endpointID: endpointID,
-		upgrader:   websocket.Upgrader{},
+		upgrader:   websocket.Upgrader{WriteBufferPool: bufpool.WebSocketWriteBuffers},
 	}
 }
 
@@ -71,7 +72,7 @@ func (listener *WebSocketListener) ServeHTTP(writer http.ResponseWriter, request
 
 // webSocketClientStart is the Client's customStartFunc for WebSockets.
 func webSocketClientStart(client *Client) error {
-	if conn, _, err := websocket.DefaultDialer.Dial(client.address, nil); err != nil {
+	if conn, _, err := bufpool.WebSocketDialer().Dial(client.address, nil); err != nil {
 		return err
 	} else {
 		client.connCloser = conn
@@ -8,24 +8,31 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"os"
 
 	"github.com/dtn7/dtn7-go/pkg/bpv7"
 	"github.com/dtn7/dtn7-go/pkg/cla/tcpclv4/internal/msgs"
+	"github.com/dtn7/dtn7-go/pkg/internal/bufpool"
 )
 
 // IncomingTransfer represents an incoming Bundle Transfer for the TCPCLv4.
+//
+// The received data is buffered in memory. If a payload stream threshold is configured by
+// bpv7.SetPayloadStreamThreshold, a Transfer exceeding it is spooled into a temporary file instead.
 type IncomingTransfer struct {
 	Id uint64
 
 	endFlag bool
 	buf     *bytes.Buffer
+	file    *os.File
+	size    uint64
 }
 
 // NewIncomingTransfer creates a new IncomingTransfer for the given Transfer ID.
 func NewIncomingTransfer(id uint64) *IncomingTransfer {
 	return &IncomingTransfer{
 		Id:  id,
-		buf: new(bytes.Buffer),
+		buf: bufpool.GetBuffer(),
 	}
 }
 
@@ -38,6 +45,25 @@ func (t IncomingTransfer) IsFinished() bool {
 	return t.endFlag
 }
 
+// spool the buffered data into a temporary file within dir, which receives all further data.
+func (t *IncomingTransfer) spool(dir string) error {
+	f, err := os.CreateTemp(dir, "transfer-")
+	if err != nil {
+		return err
+	}
+
+	if _, err := t.buf.WriteTo(f); err != nil {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+		return err
+	}
+
+	t.file = f
+	bufpool.PutBuffer(t.buf)
+	t.buf = nil
+	return nil
+}
+
 // NextSegment reads data from a XFER_SEGMENT and returns a XFER_ACK or an error.
 func (t *IncomingTransfer) NextSegment(dtm *msgs.DataTransmissionMessage) (dam *msgs.DataAcknowledgementMessage, err error) {
 	if t.IsFinished() {
@@ -50,19 +76,34 @@ func (t *IncomingTransfer) NextSegment(dtm *msgs.DataTransmissionMessage) (dam *
 		return
 	}
 
-	if n, dtmErr := t.buf.Write(dtm.Data); dtmErr != nil && dtmErr != io.EOF {
+	if t.file == nil {
+		if threshold, dir := bpv7.PayloadStreamConfig(); threshold > 0 && t.size+uint64(len(dtm.Data)) > threshold {
+			if err = t.spool(dir); err != nil {
+				return
+			}
+		}
+	}
+
+	var w io.Writer = t.buf
+	if t.file != nil {
+		w = t.file
+	}
+
+	if n, dtmErr := w.Write(dtm.Data); dtmErr != nil && dtmErr != io.EOF {
 		err = dtmErr
 		return
 	} else if n != len(dtm.Data) {
 		err = fmt.Errorf("expected %d bytes instead of  %d", len(dtm.Data), n)
 		return
+	} else {
+		t.size += uint64(n)
 	}
 
 	if dtm.Flags&msgs.SegmentEnd != 0 {
 		t.endFlag = true
 	}
 
-	dam = msgs.NewDataAcknowledgementMessage(dtm.Flags, dtm.TransferId, uint64(t.buf.Len()))
+	dam = msgs.NewDataAcknowledgementMessage(dtm.Flags, dtm.TransferId, t.size)
 	return
 }
 
@@ -73,6 +114,53 @@ func (t *IncomingTransfer) ToBundle() (bndl bpv7.Bundle, err error) {
 		return
 	}
 
-	err = bndl.UnmarshalCbor(t.buf)
+	if t.file == nil {
+		err = bndl.UnmarshalCbor(t.buf)
+		return
+	}
+
+	// A spooled Transfer is parsed from its file, resulting in a bpv7.StreamPayloadBlock for a large payload.
+	if _, err = t.file.Seek(0, io.SeekStart); err != nil {
+		return
+	}
+
+	r := bufpool.GetReader(t.file)
+	defer bufpool.PutReader(r)
+
+	err = bndl.UnmarshalCbor(r)
 	return
 }
+
+// Release the Transfer's buffer or temporary file, e.g., after its Bundle was unmarshalled by ToBundle. The Transfer
+// must not be used afterwards.
+func (t *IncomingTransfer) Release() {
+	if t.buf != nil {
+		bufpool.PutBuffer(t.buf)
+		t.buf = nil
+	}
+
+	if t.file != nil {
+		_ = t.file.Close()
+		_ = os.Remove(t.file.Name())
+		t.file = nil
+	}
+}
+
+// ToReactiveFragment returns a fragment for the received part of an unfinished Transfer. A spooled Transfer's data
+// is read back into memory for this.
+func (t *IncomingTransfer) ToReactiveFragment() (bndl bpv7.Bundle, err error) {
+	if t.IsFinished() {
+		err = fmt.Errorf("transfer has been finished")
+		return
+	}
+
+	if t.file == nil {
+		return bpv7.ParseReactiveFragment(t.buf.Bytes())
+	}
+
+	data, err := os.ReadFile(t.file.Name())
+	if err != nil {
+		return
+	}
+	return bpv7.ParseReactiveFragment(data)
+}
@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/dtn7/dtn7-go/pkg/bpv7"
+	"github.com/dtn7/dtn7-go/pkg/cla"
 	"github.com/dtn7/dtn7-go/pkg/cla/tcpclv4/internal/msgs"
 )
 
@@ -34,6 +35,7 @@ type TransferManager struct {
 	outFeedback sync.Map // map[uint64]chan msgs.Message
 
 	stopChan chan struct{}
+	doneChan chan struct{}
 	stopped  uint32
 }
 
@@ -49,6 +51,7 @@ func NewTransferManager(msgIn <-chan msgs.Message, msgOut chan<- msgs.Message, s
 		segmentMtu: segmentMtu,
 
 		stopChan: make(chan struct{}),
+		doneChan: make(chan struct{}),
 	}
 
 	go tm.handle()
@@ -74,7 +77,43 @@ func (tm *TransferManager) Close() (err error) {
 	return
 }
 
+// InterruptedBundles returns reactive fragments for the received parts of unfinished incoming transfers. This method
+// must be called after Close.
+func (tm *TransferManager) InterruptedBundles() (bs []bpv7.Bundle) {
+	select {
+	case <-tm.doneChan:
+	case <-time.After(time.Second):
+		return
+	}
+
+	tm.inTransfers.Range(func(id, transferI interface{}) bool {
+		transfer := transferI.(*IncomingTransfer)
+		tm.inTransfers.Delete(id)
+
+		if b, err := transfer.ToReactiveFragment(); err == nil {
+			bs = append(bs, b)
+		}
+		transfer.Release()
+		return true
+	})
+	return
+}
+
+// ActiveTransfers returns the number of unfinished incoming and outgoing transfers.
+func (tm *TransferManager) ActiveTransfers() (n int) {
+	count := func(_, _ interface{}) bool {
+		n++
+		return true
+	}
+
+	tm.inTransfers.Range(count)
+	tm.outFeedback.Range(count)
+	return
+}
+
 func (tm *TransferManager) handle() {
+	defer close(tm.doneChan)
+
 	for {
 		select {
 		case <-tm.stopChan:
@@ -101,7 +140,12 @@ func (tm *TransferManager) handle() {
 
 			// Related to incoming messages
 			case *msgs.DataTransmissionMessage:
-				transferI, _ := tm.inTransfers.LoadOrStore(msg.TransferId, NewIncomingTransfer(msg.TransferId))
+				// A new IncomingTransfer is only created for the first segment, as it takes a pooled buffer.
+				transferI, ok := tm.inTransfers.Load(msg.TransferId)
+				if !ok {
+					transferI = NewIncomingTransfer(msg.TransferId)
+					tm.inTransfers.Store(msg.TransferId, transferI)
+				}
 				transfer := transferI.(*IncomingTransfer)
 
 				if dam, err := transfer.NextSegment(msg); err != nil {
@@ -112,13 +156,15 @@ func (tm *TransferManager) handle() {
 				}
 
 				if transfer.IsFinished() {
-					if b, err := transfer.ToBundle(); err != nil {
+					b, err := transfer.ToBundle()
+					tm.inTransfers.Delete(msg.TransferId)
+					transfer.Release()
+
+					if err != nil {
 						tm.chanErrors <- err
 						return
-					} else {
-						tm.chanBundles <- b
 					}
-					tm.inTransfers.Delete(msg.TransferId)
+					tm.chanBundles <- b
 				}
 
 			// Everything else
@@ -171,10 +217,19 @@ func (tm *TransferManager) Send(b bpv7.Bundle) error {
 	}()
 
 	var inLen, outLen int
+
+	// interrupted wraps an error if the peer has already acknowledged some data.
+	interrupted := func(err error) error {
+		if inLen > 0 {
+			return &cla.InterruptedTransferError{Acknowledged: inLen, Err: err}
+		}
+		return err
+	}
+
 	for {
 		select {
 		case err := <-errChan:
-			return err
+			return interrupted(err)
 
 		case outLen = <-lenChan:
 			if outLen == inLen {
@@ -190,13 +245,13 @@ func (tm *TransferManager) Send(b bpv7.Bundle) error {
 
 			default:
 				atomic.StoreUint32(&stopped, 1)
-				return fmt.Errorf("received unexpected message: %T, %v", response, response)
+				return interrupted(fmt.Errorf("received unexpected message: %T, %v", response, response))
 			}
 
 		case <-time.After(10 * time.Second):
 			atomic.StoreUint32(&stopped, 1)
-			return fmt.Errorf("timeout: waiting for segment acknowledgement; id = %d, stopped = %t",
-				transfer.Id, atomic.LoadUint32(&tm.stopped) != 0)
+			return interrupted(fmt.Errorf("timeout: waiting for segment acknowledgement; id = %d, stopped = %t",
+				transfer.Id, atomic.LoadUint32(&tm.stopped) != 0))
 		}
 	}
 }
@@ -5,12 +5,12 @@
 package utils
 
 import (
-	"bufio"
 	"fmt"
 	"io"
 
 	"github.com/dtn7/dtn7-go/pkg/bpv7"
 	"github.com/dtn7/dtn7-go/pkg/cla/tcpclv4/internal/msgs"
+	"github.com/dtn7/dtn7-go/pkg/internal/bufpool"
 )
 
 // OutgoingTransfer represents an outgoing Bundle Transfer for the TCPCLv4.
@@ -42,11 +42,13 @@ func NewBundleOutgoingTransfer(id uint64, b bpv7.Bundle) *OutgoingTransfer {
 	var t, w = NewOutgoingTransfer(id)
 
 	go func(w *io.PipeWriter) {
-		bw := bufio.NewWriter(w)
+		bw := bufpool.GetWriter(w)
 
 		_ = b.MarshalCbor(bw)
 		_ = bw.Flush()
 		_ = w.Close()
+
+		bufpool.PutWriter(bw)
 	}(w.(*io.PipeWriter))
 
 	return t
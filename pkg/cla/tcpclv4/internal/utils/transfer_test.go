@@ -5,13 +5,16 @@
 package utils
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"math/rand"
+	"os"
 	"reflect"
 	"testing"
 
 	"github.com/dtn7/dtn7-go/pkg/bpv7"
+	"github.com/dtn7/dtn7-go/pkg/cla"
 	"github.com/dtn7/dtn7-go/pkg/cla/tcpclv4/internal/msgs"
 )
 
@@ -70,6 +73,59 @@ func TestTransfer(t *testing.T) {
 	}
 }
 
+func TestTransferSpool(t *testing.T) {
+	dir := t.TempDir()
+	bpv7.SetPayloadStreamThreshold(4096, dir)
+	defer bpv7.SetPayloadStreamThreshold(0, "")
+
+	payload := testGetRandomData(65536)
+	bndlOut, err := bpv7.Builder().
+		CRC(bpv7.CRC32).
+		Source("dtn://src/").
+		Destination("dtn://dst/").
+		CreationTimestampNow().
+		Lifetime("30m").
+		PayloadBlock(payload).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out := NewBundleOutgoingTransfer(23, bndlOut)
+	in := NewIncomingTransfer(23)
+
+	for !in.IsFinished() {
+		if dtm, err := out.NextSegment(1400); err != nil {
+			t.Fatal(err)
+		} else if _, err := in.NextSegment(dtm); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if in.file == nil {
+		t.Fatal("transfer exceeding the threshold was not spooled")
+	}
+
+	bndlIn, err := in.ToBundle()
+	if err != nil {
+		t.Fatal(err)
+	}
+	in.Release()
+
+	if data, err := bndlIn.PayloadData(); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(payload, data) {
+		t.Fatal("payloads differ")
+	}
+
+	// Only the parsed Bundle's payload file should be left.
+	if files, err := os.ReadDir(dir); err != nil {
+		t.Fatal(err)
+	} else if len(files) != 1 {
+		t.Fatalf("expected one file, got %d", len(files))
+	}
+}
+
 func TestTransferManager(t *testing.T) {
 	msgIn := make(chan msgs.Message)
 	msgOut := make(chan msgs.Message)
@@ -127,3 +183,84 @@ func TestTransferManager(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestTransferManagerInterrupted(t *testing.T) {
+	c1In, c1Out := make(chan msgs.Message), make(chan msgs.Message)
+	c2In, c2Out := make(chan msgs.Message), make(chan msgs.Message)
+
+	tm1 := NewTransferManager(c1In, c1Out, 1024)
+	tm2 := NewTransferManager(c2In, c2Out, 1024)
+
+	payload := testGetRandomData(65536)
+	bndlOut, err := bpv7.Builder().
+		CRC(bpv7.CRC32).
+		Source("dtn://src/").
+		Destination("dtn://dst/").
+		CreationTimestampNow().
+		Lifetime("30m").
+		HopCountBlock(64).
+		PayloadBlock(payload).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Relay the first three segments and their acknowledgements, drop everything afterwards.
+	cut := make(chan struct{})
+	go func() {
+		for i := 0; ; i++ {
+			msg := <-c1Out
+			if i >= 3 {
+				continue
+			}
+
+			c2In <- msg
+			c1In <- <-c2Out
+
+			if i == 2 {
+				close(cut)
+			}
+		}
+	}()
+
+	sendErr := make(chan error)
+	go func() { sendErr <- tm1.Send(bndlOut) }()
+
+	<-cut
+	if n := tm1.ActiveTransfers(); n != 1 {
+		t.Fatalf("expected one active outgoing transfer, got %d", n)
+	}
+	if n := tm2.ActiveTransfers(); n != 1 {
+		t.Fatalf("expected one active incoming transfer, got %d", n)
+	}
+
+	if err := tm2.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := tm1.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	var interruptedErr *cla.InterruptedTransferError
+	if err := <-sendErr; !errors.As(err, &interruptedErr) {
+		t.Fatalf("expected an InterruptedTransferError, got %v", err)
+	}
+
+	received := tm2.InterruptedBundles()
+	if len(received) != 1 {
+		t.Fatalf("expected one reactive fragment, got %d", len(received))
+	}
+
+	remaining, err := bndlOut.ReactiveFragment(interruptedErr.Acknowledged)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if bndlIn, err := bpv7.ReassembleFragments([]bpv7.Bundle{received[0], remaining}); err != nil {
+		t.Fatal(err)
+	} else if pb, err := bndlIn.PayloadBlock(); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(pb.Value.(*bpv7.PayloadBlock).Data(), payload) {
+		t.Fatal("reassembled payload differs")
+	}
+}
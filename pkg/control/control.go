@@ -0,0 +1,184 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+// Package control implements a local control protocol for a running daemon over a Unix socket.
+//
+// Each connection carries a single Request, a command and its arguments, answered by a Response. Both are JSON
+// encoded. A Server dispatches Requests to the HandlerFunc registered for their command; Call sends a Request as a
+// client, e.g., from the dtnctl command.
+package control
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// timeout limits reading a Request and writing its Response.
+const timeout = 30 * time.Second
+
+// Request to a Server.
+type Request struct {
+	Command string   `json:"command"`
+	Args    []string `json:"args,omitempty"`
+}
+
+// Response of a Server. Either Error or Result is set.
+type Response struct {
+	Error  string          `json:"error,omitempty"`
+	Result json.RawMessage `json:"result,omitempty"`
+}
+
+// HandlerFunc handles a command's arguments. Its result must be JSON encodable.
+type HandlerFunc func(args []string) (interface{}, error)
+
+// Server accepts Requests on a Unix socket.
+type Server struct {
+	path     string
+	listener net.Listener
+
+	handlers map[string]HandlerFunc
+	mutex    sync.RWMutex
+
+	wg sync.WaitGroup
+}
+
+// Listen on a Unix socket, only accessible by the current user. A stale socket file is replaced.
+func Listen(path string) (*Server, error) {
+	if _, err := os.Stat(path); err == nil {
+		if conn, err := net.Dial("unix", path); err == nil {
+			_ = conn.Close()
+			return nil, fmt.Errorf("control socket %s is already in use", path)
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, err
+		}
+	}
+
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(path, 0600); err != nil {
+		_ = listener.Close()
+		return nil, err
+	}
+
+	s := &Server{
+		path:     path,
+		listener: listener,
+		handlers: make(map[string]HandlerFunc),
+	}
+
+	s.wg.Add(1)
+	go s.serve()
+
+	return s, nil
+}
+
+// Handle a command by a HandlerFunc, replacing a previous one.
+func (s *Server) Handle(command string, handler HandlerFunc) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.handlers[command] = handler
+}
+
+// Close the Server and remove its socket file.
+func (s *Server) Close() error {
+	err := s.listener.Close()
+	s.wg.Wait()
+	return err
+}
+
+func (s *Server) serve() {
+	defer s.wg.Done()
+
+	for {
+		conn, err := s.listener.Accept()
+		if errors.Is(err, net.ErrClosed) {
+			return
+		} else if err != nil {
+			log.WithError(err).Warn("Accepting control connection erred")
+			continue
+		}
+
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.handleConn(conn)
+		}()
+	}
+}
+
+func (s *Server) handleConn(conn net.Conn) {
+	defer func() { _ = conn.Close() }()
+	_ = conn.SetDeadline(time.Now().Add(timeout))
+
+	var req Request
+	if err := json.NewDecoder(conn).Decode(&req); err != nil {
+		log.WithError(err).Debug("Reading control request erred")
+		return
+	}
+
+	if err := json.NewEncoder(conn).Encode(s.dispatch(req)); err != nil {
+		log.WithField("command", req.Command).WithError(err).Debug("Writing control response erred")
+	}
+}
+
+// dispatch a Request to its HandlerFunc.
+func (s *Server) dispatch(req Request) (resp Response) {
+	s.mutex.RLock()
+	handler, ok := s.handlers[req.Command]
+	s.mutex.RUnlock()
+
+	if !ok {
+		resp.Error = fmt.Sprintf("unknown command %q", req.Command)
+		return
+	}
+
+	log.WithFields(log.Fields{
+		"command": req.Command,
+		"args":    req.Args,
+	}).Info("Handling control request")
+
+	result, err := handler(req.Args)
+	if err != nil {
+		resp.Error = err.Error()
+		return
+	}
+
+	if resp.Result, err = json.Marshal(result); err != nil {
+		resp.Error = err.Error()
+	}
+	return
+}
+
+// Call a command on the Server listening on a Unix socket and return its JSON encoded result.
+func Call(path, command string, args ...string) (json.RawMessage, error) {
+	conn, err := net.DialTimeout("unix", path, timeout)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = conn.Close() }()
+	_ = conn.SetDeadline(time.Now().Add(timeout))
+
+	if err := json.NewEncoder(conn).Encode(Request{Command: command, Args: args}); err != nil {
+		return nil, err
+	}
+
+	var resp Response
+	if err := json.NewDecoder(conn).Decode(&resp); err != nil {
+		return nil, err
+	} else if resp.Error != "" {
+		return nil, errors.New(resp.Error)
+	}
+	return resp.Result, nil
+}
@@ -0,0 +1,52 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package control
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestServer(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "control.sock")
+
+	s, err := Listen(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = s.Close() }()
+
+	if _, err := Listen(path); err == nil {
+		t.Fatal("socket was used twice")
+	}
+
+	s.Handle("join", func(args []string) (interface{}, error) {
+		if len(args) == 0 {
+			return nil, fmt.Errorf("no arguments")
+		}
+		return strings.Join(args, ","), nil
+	})
+
+	result, err := Call(path, "join", "a", "b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var joined string
+	if err := json.Unmarshal(result, &joined); err != nil {
+		t.Fatal(err)
+	} else if joined != "a,b" {
+		t.Fatalf("expected a,b, got %s", joined)
+	}
+
+	if _, err := Call(path, "join"); err == nil || err.Error() != "no arguments" {
+		t.Fatalf("expected the handler's error, got %v", err)
+	}
+	if _, err := Call(path, "unknown"); err == nil {
+		t.Fatal("unknown command succeeded")
+	}
+}
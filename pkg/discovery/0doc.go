@@ -2,7 +2,8 @@
 //
 // SPDX-License-Identifier: GPL-3.0-or-later
 
-// Package discovery contains code for peer/neighbor discovery of other DTN nodes through UDP multicast packages.
+// Package discovery contains code for peer/neighbor discovery of other DTN nodes through UDP multicast packages or
+// DNS-SD service records via multicast DNS.
 package discovery
 
 const (
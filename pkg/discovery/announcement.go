@@ -1,5 +1,5 @@
 // SPDX-FileCopyrightText: 2020 Markus Sommer
-// SPDX-FileCopyrightText: 2020, 2021 Alvar Penning
+// SPDX-FileCopyrightText: 2020, 2021, 2022 Alvar Penning
 //
 // SPDX-License-Identifier: GPL-3.0-or-later
 
@@ -7,6 +7,7 @@ package discovery
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
 
@@ -21,31 +22,99 @@ type Announcement struct {
 	Type     cla.CLAType
 	Endpoint bpv7.EndpointID
 	Port     uint
+
+	// Preference of this CLA compared to the node's other announced CLAs. A receiver establishes a connection to the
+	// supported CLA with the highest Preference.
+	Preference uint
+
+	// Address of this CLA, which is optional. If empty, the address of the received discovery message is used. Receivers
+	// only honor an arbitrary Address for HMAC-signed beacons; otherwise, it must be an IP address within the sender's
+	// network.
+	Address string
 }
 
-// UnmarshalAnnouncements creates a new array of Announcement based on a CBOR byte string.
+// cborTag is CBOR's major type 6 for tagged data items, which is not defined by cboring.
+const cborTag byte = 0xC0
+
+// announcementLegacyFields is the number of fields of an Announcement in the original format: type, endpoint, and
+// port. Older nodes only accept Announcements of exactly these fields. Thus, the optional Preference, Address, and
+// neighbors are only appended as trailing fields if set, and messages consist solely of the Announcements.
+//
+// Decoding is tolerant: additional trailing fields are skipped, Announcements of unknown CLA types are omitted, and a
+// leading unsigned integer, e.g., a format version, is ignored.
+const announcementLegacyFields = 3
+
+// gossipMaxNeighbors limits the number of neighbors attached to an Announcement for second-hop gossip.
+const gossipMaxNeighbors = 16
+
+// errUnknownCLAType is returned for an Announcement of an unknown CLA type, which might be skipped.
+var errUnknownCLAType = errors.New("unknown CLA type")
+
+// UnmarshalAnnouncements creates a new array of Announcement based on a CBOR byte string. Announcements for unknown CLA
+// types are omitted.
 func UnmarshalAnnouncements(data []byte) (announcements []Announcement, err error) {
+	announcements, _, err = unmarshalBeacon(data)
+	return
+}
+
+// unmarshalBeacon creates Announcements and the announced neighbors, attached for second-hop gossip, per Endpoint.
+func unmarshalBeacon(data []byte) (announcements []Announcement, neighbors map[bpv7.EndpointID][]bpv7.EndpointID, err error) {
 	buff := bytes.NewBuffer(data)
 
-	if l, cErr := cboring.ReadArrayLength(buff); cErr != nil {
+	l, cErr := cboring.ReadArrayLength(buff)
+	if cErr != nil {
 		err = cErr
 		return
-	} else {
-		announcements = make([]Announcement, l)
 	}
 
-	for i := 0; i < len(announcements); i++ {
-		if cErr := cboring.Unmarshal(&announcements[i], buff); cErr != nil {
+	// A message starts directly with an Announcement, being a CBOR array, unless prefixed by a version.
+	if l > 0 && buff.Len() > 0 && buff.Bytes()[0]&0xE0 == cboring.UInt {
+		if _, vErr := cboring.ReadUInt(buff); vErr != nil {
+			err = fmt.Errorf("unmarshalling version failed: %v", vErr)
+			return
+		}
+		l--
+	}
+
+	announcements = make([]Announcement, 0, l)
+	for i := uint64(0); i < l; i++ {
+		var announcement Announcement
+		announcementNeighbors, cErr := announcement.unmarshalCbor(buff)
+		if errors.Is(cErr, errUnknownCLAType) {
+			continue
+		} else if cErr != nil {
 			err = fmt.Errorf("unmarshalling Announcement %d failed: %v", i, cErr)
 			return
 		}
+
+		announcements = append(announcements, announcement)
+
+		if len(announcementNeighbors) > 0 {
+			if neighbors == nil {
+				neighbors = make(map[bpv7.EndpointID][]bpv7.EndpointID)
+			}
+			if _, ok := neighbors[announcement.Endpoint]; !ok {
+				neighbors[announcement.Endpoint] = announcementNeighbors
+			}
+		}
 	}
 
 	return
 }
 
-// MarshalAnnouncements into a CBOR byte string.
+// MarshalAnnouncements into a CBOR byte string. Announcements without a Preference or an Address are understood by
+// older nodes.
 func MarshalAnnouncements(announcements []Announcement) (data []byte, err error) {
+	return marshalBeacon(announcements, nil)
+}
+
+// marshalBeacon works like MarshalAnnouncements, but attaches the neighbors to each Announcement for second-hop gossip.
+// At most gossipMaxNeighbors are attached.
+func marshalBeacon(announcements []Announcement, neighbors []bpv7.EndpointID) (data []byte, err error) {
+	if len(neighbors) > gossipMaxNeighbors {
+		neighbors = neighbors[:gossipMaxNeighbors]
+	}
+
 	buff := new(bytes.Buffer)
 
 	if cErr := cboring.WriteArrayLength(uint64(len(announcements)), buff); cErr != nil {
@@ -56,7 +125,7 @@ func MarshalAnnouncements(announcements []Announcement) (data []byte, err error)
 	for i := range announcements {
 		// Don't "range" variable because gosec's G601: Implicit memory aliasing in for loop.
 		announcement := announcements[i]
-		if cErr := cboring.Marshal(&announcement, buff); cErr != nil {
+		if cErr := announcement.marshalCbor(neighbors, buff); cErr != nil {
 			err = fmt.Errorf("marshalling Announcement %d (%v) failed: %v", i, announcement, cErr)
 			return
 		}
@@ -68,7 +137,23 @@ func MarshalAnnouncements(announcements []Announcement) (data []byte, err error)
 
 // MarshalCbor creates a CBOR representation for an Announcement.
 func (announcement *Announcement) MarshalCbor(w io.Writer) error {
-	if err := cboring.WriteArrayLength(3, w); err != nil {
+	return announcement.marshalCbor(nil, w)
+}
+
+// marshalCbor creates a CBOR representation for an Announcement. The Preference, the Address, and the neighbors are
+// optional trailing fields, only written up to the last one being set.
+func (announcement *Announcement) marshalCbor(neighbors []bpv7.EndpointID, w io.Writer) error {
+	fields := uint64(announcementLegacyFields)
+	switch {
+	case len(neighbors) > 0:
+		fields = 6
+	case announcement.Address != "":
+		fields = 5
+	case announcement.Preference > 0:
+		fields = 4
+	}
+
+	if err := cboring.WriteArrayLength(fields, w); err != nil {
 		return err
 	}
 
@@ -81,37 +166,176 @@ func (announcement *Announcement) MarshalCbor(w io.Writer) error {
 	if err := cboring.WriteUInt(uint64(announcement.Port), w); err != nil {
 		return err
 	}
+	if fields >= 4 {
+		if err := cboring.WriteUInt(uint64(announcement.Preference), w); err != nil {
+			return err
+		}
+	}
+	if fields >= 5 {
+		if err := cboring.WriteTextString(announcement.Address, w); err != nil {
+			return err
+		}
+	}
+
+	if fields >= 6 {
+		if err := cboring.WriteArrayLength(uint64(len(neighbors)), w); err != nil {
+			return err
+		}
+		for i := range neighbors {
+			if err := cboring.Marshal(&neighbors[i], w); err != nil {
+				return fmt.Errorf("marshalling neighbor %d failed: %v", i, err)
+			}
+		}
+	}
 
 	return nil
 }
 
-// UnmarshalCbor creates an Announcement from its CBOR representation.
+// UnmarshalCbor creates an Announcement from its CBOR representation. The Preference and Address fields are optional
+// for compatibility with older nodes. Additional trailing fields are skipped. For an
+// unknown CLA type, the whole Announcement is consumed and an error wrapping errUnknownCLAType is returned.
 func (announcement *Announcement) UnmarshalCbor(r io.Reader) error {
-	if l, err := cboring.ReadArrayLength(r); err != nil {
-		return err
-	} else if l != 3 {
-		return fmt.Errorf("wrong array length: %d instead of 3", l)
+	_, err := announcement.unmarshalCbor(r)
+	return err
+}
+
+// unmarshalCbor works like UnmarshalCbor, but also returns the optional neighbors of the sixth field.
+func (announcement *Announcement) unmarshalCbor(r io.Reader) (neighbors []bpv7.EndpointID, err error) {
+	l, err := cboring.ReadArrayLength(r)
+	if err != nil {
+		return nil, err
+	} else if l < announcementLegacyFields {
+		return nil, fmt.Errorf("wrong array length: %d instead of at least %d", l, announcementLegacyFields)
 	}
 
 	if n, err := cboring.ReadUInt(r); err != nil {
-		return err
-	} else if claType := cla.CLAType(n); claType.CheckValid() != nil {
-		return claType.CheckValid()
+		return nil, err
 	} else {
-		announcement.Type = claType
+		announcement.Type = cla.CLAType(n)
 	}
 	if err := cboring.Unmarshal(&announcement.Endpoint, r); err != nil {
-		return fmt.Errorf("unmarshalling endpoint failed: %v", err)
+		return nil, fmt.Errorf("unmarshalling endpoint failed: %v", err)
 	}
 	if n, err := cboring.ReadUInt(r); err != nil {
-		return err
+		return nil, err
 	} else {
 		announcement.Port = uint(n)
 	}
+	if l >= 4 {
+		if n, err := cboring.ReadUInt(r); err != nil {
+			return nil, err
+		} else {
+			announcement.Preference = uint(n)
+		}
+	}
+	if l >= 5 {
+		if addr, err := cboring.ReadTextString(r); err != nil {
+			return nil, err
+		} else {
+			announcement.Address = addr
+		}
+	}
 
-	return nil
+	// The sixth field contains the neighbors. For tolerance, any other data type is skipped.
+	if l >= 6 {
+		m, n, err := cboring.ReadMajors(r)
+		if err != nil {
+			return nil, err
+		} else if m != cboring.Array {
+			if err := skipCborContent(m, n, r); err != nil {
+				return nil, fmt.Errorf("skipping additional field 5 failed: %v", err)
+			}
+			n = 0
+		}
+
+		for i := uint64(0); i < n; i++ {
+			var neighbor bpv7.EndpointID
+			if err := cboring.Unmarshal(&neighbor, r); err != nil {
+				return nil, fmt.Errorf("unmarshalling neighbor %d failed: %v", i, err)
+			}
+			neighbors = append(neighbors, neighbor)
+		}
+	}
+
+	for i := uint64(6); i < l; i++ {
+		if err := skipCborItem(r); err != nil {
+			return nil, fmt.Errorf("skipping additional field %d failed: %v", i, err)
+		}
+	}
+
+	if announcement.Type.CheckValid() != nil {
+		return nil, fmt.Errorf("%w: %d", errUnknownCLAType, uint(announcement.Type))
+	}
+
+	return neighbors, nil
+}
+
+// skipCborItem reads and discards the next CBOR data item, including nested items. Indefinite-length items are not
+// supported.
+func skipCborItem(r io.Reader) error {
+	m, n, err := cboring.ReadMajors(r)
+	if err != nil {
+		return err
+	}
+
+	return skipCborContent(m, n, r)
+}
+
+// skipCborContent discards the content of a CBOR data item, whose major type and additional information were already
+// read.
+func skipCborContent(m byte, n uint64, r io.Reader) (err error) {
+	switch m {
+	case cboring.ByteString, cboring.TextString:
+		_, err = cboring.ReadRawBytes(n, r)
+		return err
+
+	case cboring.Array:
+		for i := uint64(0); i < n; i++ {
+			if err := skipCborItem(r); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case cboring.Map:
+		for i := uint64(0); i < 2*n; i++ {
+			if err := skipCborItem(r); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case cborTag:
+		return skipCborItem(r)
+
+	default:
+		// Unsigned and negative integers as well as simple values and floats are already consumed.
+		return nil
+	}
+}
+
+// selectAnnouncements picks the supported Announcement with the highest Preference for each announced node. For equal
+// Preferences, the first Announcement is chosen.
+func selectAnnouncements(announcements []Announcement, supported func(Announcement) bool) (selected []Announcement) {
+	best := make(map[bpv7.EndpointID]int)
+
+	for _, announcement := range announcements {
+		if !supported(announcement) {
+			continue
+		}
+
+		if i, ok := best[announcement.Endpoint]; !ok {
+			best[announcement.Endpoint] = len(selected)
+			selected = append(selected, announcement)
+		} else if announcement.Preference > selected[i].Preference {
+			selected[i] = announcement
+		}
+	}
+
+	return
 }
 
 func (announcement Announcement) String() string {
-	return fmt.Sprintf("Announcement(%v,%v,%d)", announcement.Type, announcement.Endpoint, announcement.Port)
+	return fmt.Sprintf("Announcement(%v,%v,%d,%d,%s)",
+		announcement.Type, announcement.Endpoint, announcement.Port, announcement.Preference, announcement.Address)
 }
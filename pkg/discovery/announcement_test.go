@@ -6,9 +6,13 @@
 package discovery
 
 import (
+	"bytes"
+	"fmt"
 	"reflect"
 	"testing"
 
+	"github.com/dtn7/cboring"
+
 	"github.com/dtn7/dtn7-go/pkg/bpv7"
 	"github.com/dtn7/dtn7-go/pkg/cla"
 )
@@ -35,6 +39,13 @@ func TestDiscoveryMessageCbor(t *testing.T) {
 			Endpoint: bpv7.MustNewEndpointID("ipn:1337.23"),
 			Port:     12345,
 		},
+		{
+			Type:       cla.QUICL,
+			Endpoint:   bpv7.MustNewEndpointID("dtn://foobar/"),
+			Port:       35038,
+			Preference: 10,
+			Address:    "dtn.example.org",
+		},
 	}
 
 	for _, dmIn := range tests {
@@ -58,3 +69,210 @@ func TestDiscoveryMessageCbor(t *testing.T) {
 		}
 	}
 }
+
+func TestUnmarshalAnnouncementsTolerant(t *testing.T) {
+	eid := bpv7.MustNewEndpointID("dtn://foobar/")
+
+	writeAnnouncement := func(buff *bytes.Buffer, claType uint64, extra func(*bytes.Buffer)) {
+		fields := uint64(3)
+		if extra != nil {
+			fields = 6
+		}
+
+		_ = cboring.WriteArrayLength(fields, buff)
+		_ = cboring.WriteUInt(claType, buff)
+		_ = cboring.Marshal(&eid, buff)
+		_ = cboring.WriteUInt(8000, buff)
+		if extra != nil {
+			_ = cboring.WriteUInt(0, buff)
+			_ = cboring.WriteTextString("", buff)
+			extra(buff)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		create func(*bytes.Buffer)
+	}{
+		{"unversioned", func(buff *bytes.Buffer) {
+			_ = cboring.WriteArrayLength(1, buff)
+			writeAnnouncement(buff, uint64(cla.TCPCLv4), nil)
+		}},
+		{"version", func(buff *bytes.Buffer) {
+			_ = cboring.WriteArrayLength(2, buff)
+			_ = cboring.WriteUInt(23, buff)
+			writeAnnouncement(buff, uint64(cla.TCPCLv4), nil)
+		}},
+		{"additional fields", func(buff *bytes.Buffer) {
+			_ = cboring.WriteArrayLength(1, buff)
+			writeAnnouncement(buff, uint64(cla.TCPCLv4), func(buff *bytes.Buffer) {
+				_ = cboring.WriteMapPairLength(2, buff)
+				_ = cboring.WriteTextString("capabilities", buff)
+				_ = cboring.WriteArrayLength(2, buff)
+				_ = cboring.WriteUInt(1, buff)
+				_ = cboring.WriteByteString([]byte{0x23, 0x42}, buff)
+				_ = cboring.WriteUInt(42, buff)
+				_ = cboring.WriteBoolean(true, buff)
+			})
+		}},
+		{"unknown CLA type", func(buff *bytes.Buffer) {
+			_ = cboring.WriteArrayLength(2, buff)
+			writeAnnouncement(buff, 9001, func(buff *bytes.Buffer) {
+				_ = cboring.WriteTextString("future", buff)
+			})
+			writeAnnouncement(buff, uint64(cla.TCPCLv4), nil)
+		}},
+	}
+
+	expected := []Announcement{{Type: cla.TCPCLv4, Endpoint: eid, Port: 8000}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			buff := new(bytes.Buffer)
+			test.create(buff)
+
+			if announcements, err := UnmarshalAnnouncements(buff.Bytes()); err != nil {
+				t.Fatal(err)
+			} else if !reflect.DeepEqual(announcements, expected) {
+				t.Fatalf("expected %v, got %v", expected, announcements)
+			}
+		})
+	}
+}
+
+// legacyUnmarshalAnnouncements is the decoder of older nodes, only accepting Announcements of the legacy fields.
+func legacyUnmarshalAnnouncements(data []byte) ([]Announcement, error) {
+	buff := bytes.NewBuffer(data)
+
+	l, err := cboring.ReadArrayLength(buff)
+	if err != nil {
+		return nil, err
+	}
+
+	announcements := make([]Announcement, l)
+	for i := range announcements {
+		if n, err := cboring.ReadArrayLength(buff); err != nil {
+			return nil, err
+		} else if n != announcementLegacyFields {
+			return nil, fmt.Errorf("wrong array length: %d instead of 3", n)
+		}
+
+		if n, err := cboring.ReadUInt(buff); err != nil {
+			return nil, err
+		} else {
+			announcements[i].Type = cla.CLAType(n)
+		}
+		if err := cboring.Unmarshal(&announcements[i].Endpoint, buff); err != nil {
+			return nil, err
+		}
+		if n, err := cboring.ReadUInt(buff); err != nil {
+			return nil, err
+		} else {
+			announcements[i].Port = uint(n)
+		}
+	}
+	return announcements, nil
+}
+
+func TestMarshalAnnouncementsLegacy(t *testing.T) {
+	announcements := []Announcement{
+		{cla.TCPCLv4, bpv7.MustNewEndpointID("dtn://foo/"), 4556, 0, ""},
+		{cla.MTCP, bpv7.MustNewEndpointID("dtn://foo/"), 35037, 0, ""},
+	}
+
+	data, err := MarshalAnnouncements(announcements)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if as, err := legacyUnmarshalAnnouncements(data); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(as, announcements) {
+		t.Fatalf("expected %v, got %v", announcements, as)
+	}
+
+	// Optional fields are only written up to the last one being set
+	for _, test := range []struct {
+		announcement Announcement
+		fields       byte
+	}{
+		{Announcement{cla.TCPCLv4, announcements[0].Endpoint, 4556, 5, ""}, 4},
+		{Announcement{cla.TCPCLv4, announcements[0].Endpoint, 4556, 0, "10.0.0.1"}, 5},
+	} {
+		if data, err := MarshalAnnouncements([]Announcement{test.announcement}); err != nil {
+			t.Fatal(err)
+		} else if data[1] != 0x80|test.fields {
+			t.Fatalf("%v resulted in %x, expected %d fields", test.announcement, data, test.fields)
+		} else if as, err := UnmarshalAnnouncements(data); err != nil {
+			t.Fatal(err)
+		} else if !reflect.DeepEqual(as, []Announcement{test.announcement}) {
+			t.Fatalf("expected %v, got %v", test.announcement, as)
+		}
+	}
+}
+
+func TestSelectAnnouncements(t *testing.T) {
+	foo := bpv7.MustNewEndpointID("dtn://foo/")
+	bar := bpv7.MustNewEndpointID("dtn://bar/")
+
+	announcements := []Announcement{
+		{Type: cla.MTCP, Endpoint: foo, Port: 35037, Preference: 1},
+		{Type: cla.TCPCLv4, Endpoint: foo, Port: 4556, Preference: 5},
+		{Type: cla.QUICL, Endpoint: foo, Port: 35038, Preference: 10},
+		{Type: cla.TCPCLv4, Endpoint: bar, Port: 4556},
+		{Type: cla.MTCP, Endpoint: bar, Port: 35037},
+	}
+	supported := func(announcement Announcement) bool { return announcement.Type != cla.QUICL }
+
+	expected := []Announcement{announcements[1], announcements[3]}
+	if selected := selectAnnouncements(announcements, supported); !reflect.DeepEqual(selected, expected) {
+		t.Fatalf("expected %v, got %v", expected, selected)
+	}
+}
+
+func TestBeaconGossip(t *testing.T) {
+	announcements := []Announcement{
+		{cla.TCPCLv4, bpv7.MustNewEndpointID("dtn://foo/"), 4556, 10, ""},
+		{cla.MTCP, bpv7.MustNewEndpointID("dtn://foo/"), 35037, 0, ""},
+	}
+
+	var neighbors []bpv7.EndpointID
+	for i := 0; i < gossipMaxNeighbors+4; i++ {
+		neighbors = append(neighbors, bpv7.MustNewEndpointID(fmt.Sprintf("dtn://neighbor-%d/", i)))
+	}
+
+	data, err := marshalBeacon(announcements, neighbors)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Nodes without gossip support ignore the neighbors
+	if as, err := UnmarshalAnnouncements(data); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(as, announcements) {
+		t.Fatalf("expected %v, got %v", announcements, as)
+	}
+
+	as, ns, err := unmarshalBeacon(data)
+	if err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(as, announcements) {
+		t.Fatalf("expected %v, got %v", announcements, as)
+	}
+
+	expected := map[bpv7.EndpointID][]bpv7.EndpointID{
+		bpv7.MustNewEndpointID("dtn://foo/"): neighbors[:gossipMaxNeighbors],
+	}
+	if !reflect.DeepEqual(ns, expected) {
+		t.Fatalf("expected %v, got %v", expected, ns)
+	}
+
+	// Without neighbors, the beacon equals the plain Announcements
+	if plain, err := MarshalAnnouncements(announcements); err != nil {
+		t.Fatal(err)
+	} else if noGossip, err := marshalBeacon(announcements, nil); err != nil {
+		t.Fatal(err)
+	} else if !bytes.Equal(plain, noGossip) {
+		t.Fatalf("beacon without neighbors differs: %x != %x", noGossip, plain)
+	}
+}
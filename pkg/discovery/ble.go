@@ -0,0 +1,418 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package discovery
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+	"github.com/dtn7/dtn7-go/pkg/cla"
+)
+
+const (
+	// bleMaxData is the maximum length of legacy BLE advertising and scan response data.
+	bleMaxData = 31
+
+	// bleCompanyID is the company identifier of the manufacturer specific data, 0xFFFF being reserved for tests and
+	// internal use by the Bluetooth SIG.
+	bleCompanyID uint16 = 0xFFFF
+
+	// bleMagic follows the company identifier, marking dtn7's advertisements.
+	bleMagic = "d7"
+
+	// bleMaxPending limits the number of devices whose advertisement misses its scan response, or vice versa.
+	bleMaxPending = 256
+)
+
+// BLE advertising data types, as assigned by the Bluetooth SIG.
+const (
+	bleAdFlags        byte = 0x01
+	bleAdCompleteName byte = 0x09
+	bleAdManufacturer byte = 0xFF
+)
+
+// BLE advertising report event types of legacy advertisements.
+const (
+	bleReportScannable    byte = 0x02
+	bleReportScanResponse byte = 0x04
+)
+
+// bleDevice is a Bluetooth controller, accessed by HCI commands and events.
+type bleDevice interface {
+	// command sends an HCI command of an OGF and OCF with its parameters.
+	command(ogf, ocf uint16, params []byte) error
+
+	// readEvent reads the next HCI event packet, without the packet type indicator. A nil slice indicates a timeout.
+	readEvent() ([]byte, error)
+
+	close() error
+}
+
+// HCI command group and commands of the LE controller, as specified in the Bluetooth Core Specification, Vol 4, Part E.
+const (
+	hciOgfLE                 uint16 = 0x08
+	hciLESetAdvParams        uint16 = 0x0006
+	hciLESetAdvData          uint16 = 0x0008
+	hciLESetScanResponseData uint16 = 0x0009
+	hciLESetAdvEnable        uint16 = 0x000A
+	hciLESetScanParams       uint16 = 0x000B
+	hciLESetScanEnable       uint16 = 0x000C
+
+	hciEventCommandComplete byte = 0x0E
+	hciEventLEMeta          byte = 0x3E
+	hciLEAdvertisingReport  byte = 0x02
+)
+
+// bleAdStructure creates an advertising data structure of a type.
+func bleAdStructure(adType byte, data []byte) []byte {
+	return append([]byte{byte(len(data) + 1), adType}, data...)
+}
+
+// marshalBleAdvertisement creates the advertising and scan response data for an Announcement, reachable at an IPv4
+// address. As BLE cannot carry the CLA itself, the advertisement contains the CLA type, its port, and the IPv4 address
+// as manufacturer specific data. The scan response contains the Endpoint as the complete local name, limited to 29
+// bytes.
+func marshalBleAdvertisement(announcement Announcement, ip net.IP) (adv, scanResponse []byte, err error) {
+	ip4 := ip.To4()
+	if ip4 == nil {
+		err = fmt.Errorf("BLE announcements require an IPv4 address, not %v", ip)
+		return
+	} else if announcement.Port > 0xFFFF {
+		err = fmt.Errorf("port %d exceeds 16 bit", announcement.Port)
+		return
+	}
+
+	manufacturer := new(bytes.Buffer)
+	_ = binary.Write(manufacturer, binary.LittleEndian, bleCompanyID)
+	_, _ = manufacturer.WriteString(bleMagic)
+	_ = manufacturer.WriteByte(byte(announcement.Type))
+	_ = binary.Write(manufacturer, binary.BigEndian, uint16(announcement.Port))
+	_, _ = manufacturer.Write(ip4)
+
+	adv = append(bleAdStructure(bleAdFlags, []byte{0x06}), bleAdStructure(bleAdManufacturer, manufacturer.Bytes())...)
+
+	scanResponse = bleAdStructure(bleAdCompleteName, []byte(announcement.Endpoint.String()))
+	if len(scanResponse) > bleMaxData {
+		err = fmt.Errorf("endpoint %v exceeds BLE's scan response", announcement.Endpoint)
+	}
+	return
+}
+
+// bleAdStructures splits advertising data into its structures by their type.
+func bleAdStructures(data []byte) map[byte][]byte {
+	structures := make(map[byte][]byte)
+	for len(data) > 1 {
+		l := int(data[0])
+		if l == 0 || l >= len(data) {
+			break
+		}
+		structures[data[1]] = data[2 : l+1]
+		data = data[l+1:]
+	}
+	return structures
+}
+
+// parseBleAdvertisement extracts the CLA type, port, and IPv4 address of an advertisement. The boolean indicates if
+// this is a dtn7 advertisement.
+func parseBleAdvertisement(data []byte) (claType cla.CLAType, port uint, ip net.IP, ok bool) {
+	manufacturer, exists := bleAdStructures(data)[bleAdManufacturer]
+	if !exists || len(manufacturer) != 2+len(bleMagic)+1+2+net.IPv4len ||
+		binary.LittleEndian.Uint16(manufacturer) != bleCompanyID || string(manufacturer[2:4]) != bleMagic {
+		return
+	}
+
+	claType = cla.CLAType(manufacturer[4])
+	port = uint(binary.BigEndian.Uint16(manufacturer[5:7]))
+	ip = net.IPv4(manufacturer[7], manufacturer[8], manufacturer[9], manufacturer[10])
+	ok = true
+	return
+}
+
+// parseBleScanResponse extracts the Endpoint of a scan response.
+func parseBleScanResponse(data []byte) (endpoint bpv7.EndpointID, ok bool) {
+	name, exists := bleAdStructures(data)[bleAdCompleteName]
+	if !exists {
+		return
+	}
+
+	endpoint, err := bpv7.NewEndpointID(string(name))
+	return endpoint, err == nil
+}
+
+// blePending is a device's advertisement, waiting for its scan response, or vice versa.
+type blePending struct {
+	advertisement []byte
+	scanResponse  []byte
+}
+
+// ble announces and discovers CLAs by Bluetooth Low Energy advertisements, e.g., for nodes whose IP network does not
+// support multicast. Each interval, another one of the Manager's Announcements is advertised. Received advertisements
+// are only accepted for addresses within this node's IPv4 networks, as BLE neighbors share its physical location.
+//
+// As there is no BLE CLA, BLE only serves to find the IPv4 address and port of a peer's IP based CLA, over which
+// bundles are exchanged afterwards. Advertisements cannot be signed. Thus, while the Manager has a key, advertisements
+// are neither sent nor accepted.
+type ble struct {
+	manager *Manager
+	index   uint16
+	device  bleDevice
+
+	pending map[string]*blePending
+	next    int
+
+	stopChan  chan struct{}
+	closeOnce sync.Once
+}
+
+// openBleDevice opens the HCI device of an index, replaceable for tests.
+var openBleDevice = openHciDevice
+
+// startBle starts BLE on the HCI device of an index.
+func startBle(manager *Manager, index uint16) (*ble, error) {
+	device, err := openBleDevice(index)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := startBleWithDevice(manager, device)
+	if err != nil {
+		return nil, err
+	}
+	b.index = index
+	return b, nil
+}
+
+// startBleWithDevice starts scanning and, for an active Manager, advertising on a bleDevice.
+func startBleWithDevice(manager *Manager, device bleDevice) (*ble, error) {
+	b := &ble{
+		manager:  manager,
+		device:   device,
+		pending:  make(map[string]*blePending),
+		stopChan: make(chan struct{}),
+	}
+
+	// Active scanning, requesting scan responses, with an interval of 60 ms and a window of 30 ms.
+	scanParams := []byte{0x01, 0x60, 0x00, 0x30, 0x00, 0x00, 0x00}
+	for _, cmd := range []struct {
+		ocf    uint16
+		params []byte
+	}{
+		{hciLESetScanEnable, []byte{0x00, 0x00}},
+		{hciLESetScanParams, scanParams},
+		{hciLESetScanEnable, []byte{0x01, 0x00}},
+	} {
+		if err := device.command(hciOgfLE, cmd.ocf, cmd.params); err != nil {
+			_ = device.close()
+			return nil, fmt.Errorf("BLE failed to start scanning: %w", err)
+		}
+	}
+
+	go b.handleReader()
+	go b.handleAdvertiser()
+
+	return b, nil
+}
+
+// handleReader passes the Announcements of received advertisements to the Manager.
+func (b *ble) handleReader() {
+	for {
+		select {
+		case <-b.stopChan:
+			return
+		default:
+		}
+
+		event, err := b.device.readEvent()
+		if err != nil {
+			select {
+			case <-b.stopChan:
+			default:
+				b.manager.reportError(fmt.Errorf("BLE failed to read: %w", err))
+			}
+			return
+		} else if event != nil {
+			b.handleEvent(event)
+		}
+	}
+}
+
+// handleEvent of an HCI event packet, consisting of the event code, the parameters' length, and the parameters.
+func (b *ble) handleEvent(event []byte) {
+	if len(event) < 2 || int(event[1]) != len(event)-2 {
+		return
+	}
+	params := event[2:]
+
+	switch event[0] {
+	case hciEventCommandComplete:
+		if len(params) >= 4 && params[3] != 0x00 {
+			log.WithFields(log.Fields{
+				"discovery": b.manager,
+				"opcode":    fmt.Sprintf("%#04x", binary.LittleEndian.Uint16(params[1:3])),
+				"status":    params[3],
+			}).Debug("BLE command failed")
+		}
+
+	case hciEventLEMeta:
+		if len(params) >= 2 && params[0] == hciLEAdvertisingReport {
+			b.handleReports(params[2:], int(params[1]))
+		}
+	}
+}
+
+// handleReports of an LE Advertising Report event. Each report consists of the event type, the address type, the
+// address, the data's length, the data, and the RSSI.
+func (b *ble) handleReports(reports []byte, n int) {
+	for i := 0; i < n; i++ {
+		if len(reports) < 9 || len(reports) < 9+int(reports[8])+1 {
+			return
+		}
+
+		eventType, addr := reports[0], fmt.Sprintf("%x/%d", reports[2:8], reports[1])
+		data := reports[9 : 9+int(reports[8])]
+		reports = reports[9+len(data)+1:]
+
+		b.handleReport(addr, eventType, data)
+	}
+}
+
+// handleReport combines a device's advertisement and scan response to an Announcement.
+func (b *ble) handleReport(addr string, eventType byte, data []byte) {
+	if b.manager.getKey() != nil {
+		return
+	}
+
+	pending, ok := b.pending[addr]
+	if !ok {
+		if len(b.pending) >= bleMaxPending {
+			b.pending = make(map[string]*blePending)
+		}
+		pending = &blePending{}
+		b.pending[addr] = pending
+	}
+
+	switch eventType {
+	case bleReportScanResponse:
+		pending.scanResponse = append([]byte(nil), data...)
+	default:
+		pending.advertisement = append([]byte(nil), data...)
+	}
+
+	if pending.advertisement == nil || pending.scanResponse == nil {
+		return
+	}
+
+	claType, port, ip, isDtn := parseBleAdvertisement(pending.advertisement)
+	endpoint, hasEndpoint := parseBleScanResponse(pending.scanResponse)
+	pending.advertisement, pending.scanResponse = nil, nil
+	if !isDtn || !hasEndpoint {
+		return
+	}
+
+	announcement := Announcement{Type: claType, Endpoint: endpoint, Port: port}
+	if !isSupported(announcement) {
+		return
+	} else if !inLocalNetwork(ip) {
+		log.WithFields(log.Fields{
+			"discovery": b.manager,
+			"peer":      ip,
+			"device":    addr,
+		}).Debug("BLE ignored an announcement outside the local networks")
+		return
+	}
+
+	go b.manager.handleDiscovery(announcement, ip.String(), false)
+}
+
+// handleAdvertiser advertises the next supported Announcement each interval. A passive Manager does not advertise.
+func (b *ble) handleAdvertiser() {
+	if b.manager.IsPassive() {
+		return
+	}
+
+	for {
+		if err := b.advertise(); err != nil {
+			log.WithError(err).WithField("discovery", b.manager).Debug("BLE failed to advertise")
+		}
+
+		select {
+		case <-b.stopChan:
+			_ = b.device.command(hciOgfLE, hciLESetAdvEnable, []byte{0x00})
+			return
+
+		case <-time.After(b.manager.Interval()):
+		}
+	}
+}
+
+// advertise the next supported Announcement. Announcements without an IPv4 address are advertised with the first
+// local IPv4 address. Advertising is disabled while the Manager has a key.
+func (b *ble) advertise() error {
+	var announcements []Announcement
+	if b.manager.getKey() == nil {
+		for _, announcement := range b.manager.Announcements() {
+			if isSupported(announcement) {
+				announcements = append(announcements, announcement)
+			}
+		}
+	}
+	if len(announcements) == 0 {
+		return b.device.command(hciOgfLE, hciLESetAdvEnable, []byte{0x00})
+	}
+
+	announcement := announcements[b.next%len(announcements)]
+	b.next++
+
+	ip := net.ParseIP(announcement.Address)
+	if ip == nil {
+		ip = localIPv4()
+	}
+
+	adv, scanResponse, err := marshalBleAdvertisement(announcement, ip)
+	if err != nil {
+		return err
+	}
+
+	// A scannable undirected advertisement with an interval of 1.28 s on all three advertising channels.
+	advParams := []byte{0x00, 0x08, 0x00, 0x08, 0x02, 0x00, 0x00, 0, 0, 0, 0, 0, 0, 0x07, 0x00}
+	padded := func(data []byte) []byte {
+		params := make([]byte, 1+bleMaxData)
+		params[0] = byte(len(data))
+		copy(params[1:], data)
+		return params
+	}
+
+	for _, cmd := range []struct {
+		ocf    uint16
+		params []byte
+	}{
+		{hciLESetAdvEnable, []byte{0x00}},
+		{hciLESetAdvParams, advParams},
+		{hciLESetAdvData, padded(adv)},
+		{hciLESetScanResponseData, padded(scanResponse)},
+		{hciLESetAdvEnable, []byte{0x01}},
+	} {
+		if err := b.device.command(hciOgfLE, cmd.ocf, cmd.params); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// close this BLE advertiser and scanner.
+func (b *ble) close() {
+	b.closeOnce.Do(func() {
+		close(b.stopChan)
+		_ = b.device.command(hciOgfLE, hciLESetScanEnable, []byte{0x00, 0x00})
+		_ = b.device.close()
+	})
+}
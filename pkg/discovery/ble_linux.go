@@ -0,0 +1,98 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+//go:build linux
+
+package discovery
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+
+	"golang.org/x/sys/unix"
+)
+
+// Socket options of raw HCI sockets, as defined in BlueZ' hci.h, but missing in x/sys/unix.
+const (
+	solHci    = 0
+	hciFilter = 2
+
+	hciCommandPkt byte = 0x01
+	hciEventPkt   byte = 0x04
+)
+
+// hciDevice is a bleDevice, backed by a raw HCI socket. Opening it requires the CAP_NET_RAW capability.
+type hciDevice struct {
+	fd        int
+	buff      []byte
+	closeOnce sync.Once
+}
+
+// openHciDevice opens a raw HCI socket for the device of an index, only receiving the events used by ble.
+func openHciDevice(index uint16) (bleDevice, error) {
+	fd, err := unix.Socket(unix.AF_BLUETOOTH, unix.SOCK_RAW|unix.SOCK_CLOEXEC, unix.BTPROTO_HCI)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HCI socket: %w", err)
+	}
+
+	if err := setupHciSocket(fd, index); err != nil {
+		_ = unix.Close(fd)
+		return nil, fmt.Errorf("hci%d: %w", index, err)
+	}
+
+	return &hciDevice{fd: fd, buff: make([]byte, 260)}, nil
+}
+
+// setupHciSocket binds the socket and installs its event filter and read timeout.
+func setupHciSocket(fd int, index uint16) error {
+	if err := unix.Bind(fd, &unix.SockaddrHCI{Dev: index, Channel: unix.HCI_CHANNEL_RAW}); err != nil {
+		return err
+	}
+
+	// struct hci_filter: the packet type mask, the event mask, and the opcode.
+	filter := make([]byte, 14)
+	binary.LittleEndian.PutUint32(filter[0:], 1<<hciEventPkt)
+	for _, event := range []byte{hciEventCommandComplete, 0x0F, hciEventLEMeta} {
+		offset := 4 + 4*(event/32)
+		binary.LittleEndian.PutUint32(filter[offset:],
+			binary.LittleEndian.Uint32(filter[offset:])|1<<(event%32))
+	}
+	if err := unix.SetsockoptString(fd, solHci, hciFilter, string(filter)); err != nil {
+		return err
+	}
+
+	// The timeout lets the reader check for its closing.
+	return unix.SetsockoptTimeval(fd, unix.SOL_SOCKET, unix.SO_RCVTIMEO, &unix.Timeval{Sec: 1})
+}
+
+func (device *hciDevice) command(ogf, ocf uint16, params []byte) error {
+	packet := []byte{hciCommandPkt, 0, 0, byte(len(params))}
+	binary.LittleEndian.PutUint16(packet[1:], ogf<<10|ocf)
+	_, err := unix.Write(device.fd, append(packet, params...))
+	return err
+}
+
+func (device *hciDevice) readEvent() ([]byte, error) {
+	n, err := unix.Read(device.fd, device.buff)
+	if errors.Is(err, unix.EAGAIN) || errors.Is(err, unix.EINTR) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else if n < 1 || device.buff[0] != hciEventPkt {
+		return nil, nil
+	}
+
+	event := make([]byte, n-1)
+	copy(event, device.buff[1:n])
+	return event, nil
+}
+
+func (device *hciDevice) close() (err error) {
+	device.closeOnce.Do(func() {
+		err = unix.Close(device.fd)
+	})
+	return
+}
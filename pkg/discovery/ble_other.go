@@ -0,0 +1,14 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+//go:build !linux
+
+package discovery
+
+import "fmt"
+
+// openHciDevice fails, as raw HCI sockets are only available on Linux.
+func openHciDevice(index uint16) (bleDevice, error) {
+	return nil, fmt.Errorf("hci%d: BLE is only supported on Linux", index)
+}
@@ -0,0 +1,220 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package discovery
+
+import (
+	"bytes"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+	"github.com/dtn7/dtn7-go/pkg/cla"
+)
+
+// fakeBleDevice records sent commands and returns queued events.
+type fakeBleDevice struct {
+	mutex    sync.Mutex
+	commands [][]byte
+	events   chan []byte
+	closed   chan struct{}
+}
+
+func newFakeBleDevice() *fakeBleDevice {
+	return &fakeBleDevice{events: make(chan []byte, 8), closed: make(chan struct{})}
+}
+
+func (device *fakeBleDevice) command(_, ocf uint16, params []byte) error {
+	device.mutex.Lock()
+	defer device.mutex.Unlock()
+
+	device.commands = append(device.commands, append([]byte{byte(ocf)}, params...))
+	return nil
+}
+
+func (device *fakeBleDevice) readEvent() ([]byte, error) {
+	select {
+	case event := <-device.events:
+		return event, nil
+	case <-device.closed:
+		return nil, nil
+	case <-time.After(10 * time.Millisecond):
+		return nil, nil
+	}
+}
+
+func (device *fakeBleDevice) close() error {
+	select {
+	case <-device.closed:
+	default:
+		close(device.closed)
+	}
+	return nil
+}
+
+// sentData returns the last advertising data of an OCF.
+func (device *fakeBleDevice) sentData(ocf uint16) []byte {
+	device.mutex.Lock()
+	defer device.mutex.Unlock()
+
+	for i := len(device.commands) - 1; i >= 0; i-- {
+		if cmd := device.commands[i]; uint16(cmd[0]) == ocf {
+			return cmd[2 : 2+cmd[1]]
+		}
+	}
+	return nil
+}
+
+// bleReportEvent creates an LE Advertising Report event of a single report.
+func bleReportEvent(eventType byte, addr byte, data []byte) []byte {
+	report := append([]byte{eventType, 0x01, addr, 0, 0, 0, 0, 0, byte(len(data))}, data...)
+	params := append([]byte{hciLEAdvertisingReport, 1}, append(report, 0xC0)...)
+	return append([]byte{hciEventLEMeta, byte(len(params))}, params...)
+}
+
+func TestBleAdvertisement(t *testing.T) {
+	announcement := Announcement{cla.TCPCLv4, bpv7.MustNewEndpointID("dtn://node-with-a-long-name/"), 4556, 0, ""}
+
+	adv, scanResponse, err := marshalBleAdvertisement(announcement, net.ParseIP("10.0.0.1"))
+	if err != nil {
+		t.Fatal(err)
+	} else if len(adv) > bleMaxData || len(scanResponse) > bleMaxData {
+		t.Fatalf("advertisement of %d and scan response of %d bytes exceed BLE", len(adv), len(scanResponse))
+	}
+
+	if claType, port, ip, ok := parseBleAdvertisement(adv); !ok {
+		t.Fatalf("advertisement %x was not parsed", adv)
+	} else if claType != announcement.Type || port != announcement.Port || !ip.Equal(net.ParseIP("10.0.0.1")) {
+		t.Fatalf("advertisement resulted in %v, %d, %v", claType, port, ip)
+	}
+
+	if endpoint, ok := parseBleScanResponse(scanResponse); !ok || endpoint != announcement.Endpoint {
+		t.Fatalf("scan response resulted in %v", endpoint)
+	}
+
+	if _, _, err := marshalBleAdvertisement(announcement, net.ParseIP("2001:db8::1")); err == nil {
+		t.Fatal("IPv6 address did not error")
+	}
+
+	announcement.Endpoint = bpv7.MustNewEndpointID("dtn://" + strings.Repeat("x", 24) + "/")
+	if _, _, err := marshalBleAdvertisement(announcement, net.ParseIP("10.0.0.1")); err == nil {
+		t.Fatal("too long endpoint did not error")
+	}
+
+	foreign := append(bleAdStructure(bleAdFlags, []byte{0x06}), bleAdStructure(bleAdManufacturer, []byte{0x4C, 0x00, 0x02, 0x15})...)
+	if _, _, _, ok := parseBleAdvertisement(foreign); ok {
+		t.Fatal("foreign advertisement was parsed")
+	}
+}
+
+func TestBleDiscovery(t *testing.T) {
+	interfaceAddrs = func() ([]net.Addr, error) {
+		return []net.Addr{&net.IPNet{IP: net.ParseIP("10.0.0.1"), Mask: net.CIDRMask(24, 32)}}, nil
+	}
+	defer func() { interfaceAddrs = net.InterfaceAddrs }()
+
+	registered := make(chan cla.Convergable, 2)
+	manager := &Manager{
+		NodeId:       bpv7.MustNewEndpointID("dtn://self/"),
+		RegisterFunc: func(c cla.Convergable) { registered <- c },
+		interval:     time.Hour,
+	}
+	self := Announcement{cla.MTCP, manager.NodeId, 35037, 0, ""}
+	if err := manager.SetAnnouncements([]Announcement{self}); err != nil {
+		t.Fatal(err)
+	}
+
+	device := newFakeBleDevice()
+	b, err := startBleWithDevice(manager, device)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer b.close()
+
+	peer := Announcement{cla.MTCP, bpv7.MustNewEndpointID("dtn://peer/"), 35037, 0, ""}
+	for i, ip := range []string{"192.0.2.2", "10.0.0.2"} {
+		adv, scanResponse, err := marshalBleAdvertisement(peer, net.ParseIP(ip))
+		if err != nil {
+			t.Fatal(err)
+		}
+		device.events <- bleReportEvent(bleReportScannable, byte(i), adv)
+		device.events <- bleReportEvent(bleReportScanResponse, byte(i), scanResponse)
+	}
+
+	select {
+	case c := <-registered:
+		if conv, ok := c.(cla.Convergence); !ok || conv.Address() != "10.0.0.2:35037" {
+			t.Fatalf("registered CLA %v", c)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("advertisement resulted in no registered CLA")
+	}
+
+	select {
+	case c := <-registered:
+		t.Fatalf("advertisement outside the local network resulted in a registered CLA: %v", c)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	adv, scanResponse, _ := marshalBleAdvertisement(self, net.ParseIP("10.0.0.1"))
+	if sent := device.sentData(hciLESetAdvData); !bytes.Equal(sent, adv) {
+		t.Fatalf("advertised %x, expected %x", sent, adv)
+	} else if sent := device.sentData(hciLESetScanResponseData); !bytes.Equal(sent, scanResponse) {
+		t.Fatalf("scan response %x, expected %x", sent, scanResponse)
+	}
+}
+
+func TestBleDiscoveryKey(t *testing.T) {
+	interfaceAddrs = func() ([]net.Addr, error) {
+		return []net.Addr{&net.IPNet{IP: net.ParseIP("10.0.0.1"), Mask: net.CIDRMask(24, 32)}}, nil
+	}
+	defer func() { interfaceAddrs = net.InterfaceAddrs }()
+
+	registered := make(chan cla.Convergable, 1)
+	manager := &Manager{
+		NodeId:       bpv7.MustNewEndpointID("dtn://self/"),
+		RegisterFunc: func(c cla.Convergable) { registered <- c },
+		interval:     time.Hour,
+	}
+	if err := manager.SetAnnouncements([]Announcement{{cla.MTCP, manager.NodeId, 35037, 0, ""}}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The key is set after BLE was started, e.g., by a reload
+	device := newFakeBleDevice()
+	b, err := startBleWithDevice(manager, device)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer b.close()
+
+	manager.SetKey([]byte("secret"))
+
+	peer := Announcement{cla.MTCP, bpv7.MustNewEndpointID("dtn://peer/"), 35037, 0, ""}
+	adv, scanResponse, err := marshalBleAdvertisement(peer, net.ParseIP("10.0.0.2"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	device.events <- bleReportEvent(bleReportScannable, 0, adv)
+	device.events <- bleReportEvent(bleReportScanResponse, 0, scanResponse)
+
+	select {
+	case c := <-registered:
+		t.Fatalf("unauthenticated advertisement resulted in a registered CLA: %v", c)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	if err := b.advertise(); err != nil {
+		t.Fatal(err)
+	}
+
+	device.mutex.Lock()
+	defer device.mutex.Unlock()
+	if cmd := device.commands[len(device.commands)-1]; uint16(cmd[0]) != hciLESetAdvEnable || cmd[1] != 0x00 {
+		t.Fatalf("advertising was not disabled, last command %x", cmd)
+	}
+}
@@ -0,0 +1,400 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package discovery
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/dns/dnsmessage"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+	"github.com/dtn7/dtn7-go/pkg/cla"
+)
+
+const (
+	// dnssdAddress is the mDNS IPv4 multicast address and port, as defined in RFC 6762.
+	dnssdAddress = "224.0.0.251:5353"
+
+	// dnssdService is the DNS-SD service type, as defined in RFC 6763, under which dtnd's CLAs are announced.
+	dnssdService = "_dtn._tcp.local."
+
+	// dnssdTTL is the TTL in seconds of announced resource records.
+	dnssdTTL = 120
+
+	// dnssdTxtEndpoint is the TXT record's key for an Announcement's Endpoint.
+	dnssdTxtEndpoint = "eid"
+
+	// dnssdTxtType is the TXT record's key for an Announcement's CLA Type.
+	dnssdTxtType = "cla"
+
+	// dnssdTxtPreference is the TXT record's key for an Announcement's Preference.
+	dnssdTxtPreference = "pref"
+
+	// dnssdTxtAddress is the optional TXT record's key for an Announcement's Address.
+	dnssdTxtAddress = "addr"
+)
+
+// dnssd publishes and browses Announcements as DNS-SD service instances via multicast DNS.
+//
+// Each Announcement is published as a service instance of the dnssdService type. Its SRV record holds the CLA's port
+// and its TXT record the Endpoint, the CLA Type, the Preference, and the optional Address. Without an Address, the
+// peer's address is taken from the received packet, as it is done for the UDP multicast based discovery.
+type dnssd struct {
+	manager *Manager
+
+	conn      *net.UDPConn
+	groupAddr *net.UDPAddr
+
+	stopChan  chan struct{}
+	closeOnce sync.Once
+}
+
+// startDnssd starts publishing and browsing via multicast DNS for the given Manager.
+func startDnssd(manager *Manager) (*dnssd, error) {
+	groupAddr, err := net.ResolveUDPAddr("udp4", dnssdAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := net.ListenMulticastUDP("udp4", nil, groupAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &dnssd{
+		manager:   manager,
+		conn:      conn,
+		groupAddr: groupAddr,
+		stopChan:  make(chan struct{}),
+	}
+
+	go d.handleReader()
+	go d.handleQuerier()
+
+	return d, nil
+}
+
+// handleReader answers queries for the dnssdService and passes received Announcements to the Manager.
+func (d *dnssd) handleReader() {
+	buff := make([]byte, 9000)
+
+	for {
+		n, addr, err := d.conn.ReadFromUDP(buff)
+		if err != nil {
+			select {
+			case <-d.stopChan:
+			default:
+				d.manager.reportError(fmt.Errorf("DNS-SD failed to read: %w", err))
+			}
+			return
+		}
+
+		msg := make([]byte, n)
+		copy(msg, buff[:n])
+
+		if isDnssdQuery(msg) {
+			d.respond()
+			continue
+		}
+
+		announcements, err := parseDnssdResponse(msg)
+		if err != nil {
+			log.WithError(err).WithFields(log.Fields{
+				"discovery": d.manager,
+				"peer":      addr,
+			}).Debug("DNS-SD failed to parse incoming message")
+			continue
+		}
+
+		peerAddr := addr.IP.String()
+		if addr.IP.To4() == nil {
+			peerAddr = fmt.Sprintf("[%s]", peerAddr)
+		}
+
+		for _, announcement := range selectAnnouncements(announcements, isSupported) {
+			go d.manager.handleDiscovery(announcement, peerAddr, false)
+		}
+	}
+}
+
+// handleQuerier periodically queries for the dnssdService, based on the Manager's interval. A passive Manager does not
+// query at all.
+func (d *dnssd) handleQuerier() {
+	if d.manager.IsPassive() {
+		return
+	}
+
+	query, err := marshalDnssdQuery()
+	if err != nil {
+		log.WithError(err).WithField("discovery", d.manager).Warn("DNS-SD failed to create query, stopping")
+		return
+	}
+
+	for {
+		if _, err := d.conn.WriteToUDP(query, d.groupAddr); err != nil {
+			log.WithError(err).WithField("discovery", d.manager).Debug("DNS-SD failed to send query")
+		}
+
+		select {
+		case <-d.stopChan:
+			return
+
+		case <-time.After(d.manager.Interval()):
+		}
+	}
+}
+
+// respond to a query with this Manager's Announcements, unless the Manager is passive.
+func (d *dnssd) respond() {
+	if d.manager.IsPassive() {
+		return
+	}
+
+	announcements := d.manager.Announcements()
+	if len(announcements) == 0 {
+		return
+	}
+
+	msg, err := marshalDnssdResponse(d.manager.NodeId, announcements)
+	if err != nil {
+		log.WithError(err).WithField("discovery", d.manager).Warn("DNS-SD failed to create response")
+		return
+	}
+
+	if _, err := d.conn.WriteToUDP(msg, d.groupAddr); err != nil {
+		log.WithError(err).WithField("discovery", d.manager).Debug("DNS-SD failed to send response")
+	}
+}
+
+// close this DNS-SD publisher and browser.
+func (d *dnssd) close() {
+	d.closeOnce.Do(func() {
+		close(d.stopChan)
+		_ = d.conn.Close()
+	})
+}
+
+// dnssdLabel converts some string into a single DNS label.
+func dnssdLabel(s string) string {
+	return strings.NewReplacer(".", "-", ":", "-", "/", "-").Replace(s)
+}
+
+// dnssdInstance creates a service instance name for an Announcement.
+func dnssdInstance(announcement Announcement) string {
+	return fmt.Sprintf("%s-%d-%d.%s",
+		dnssdLabel(announcement.Endpoint.Authority()), uint(announcement.Type), announcement.Port, dnssdService)
+}
+
+// marshalDnssdQuery creates a multicast DNS query for the dnssdService's PTR records.
+func marshalDnssdQuery() ([]byte, error) {
+	name, err := dnsmessage.NewName(dnssdService)
+	if err != nil {
+		return nil, err
+	}
+
+	b := dnsmessage.NewBuilder(nil, dnsmessage.Header{})
+	if err := b.StartQuestions(); err != nil {
+		return nil, err
+	}
+	if err := b.Question(dnsmessage.Question{Name: name, Type: dnsmessage.TypePTR, Class: dnsmessage.ClassINET}); err != nil {
+		return nil, err
+	}
+	return b.Finish()
+}
+
+// isDnssdQuery checks if a multicast DNS message is a query for the dnssdService.
+func isDnssdQuery(msg []byte) bool {
+	var p dnsmessage.Parser
+	if h, err := p.Start(msg); err != nil || h.Response {
+		return false
+	}
+
+	questions, err := p.AllQuestions()
+	if err != nil {
+		return false
+	}
+
+	for _, q := range questions {
+		if strings.EqualFold(q.Name.String(), dnssdService) && (q.Type == dnsmessage.TypePTR || q.Type == dnsmessage.TypeALL) {
+			return true
+		}
+	}
+	return false
+}
+
+// marshalDnssdResponse creates a multicast DNS response, announcing each Announcement as a service instance.
+func marshalDnssdResponse(nodeId bpv7.EndpointID, announcements []Announcement) ([]byte, error) {
+	service, err := dnsmessage.NewName(dnssdService)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := dnsmessage.NewName(dnssdLabel(nodeId.Authority()) + ".local.")
+	if err != nil {
+		return nil, err
+	}
+
+	instances := make([]dnsmessage.Name, len(announcements))
+	for i, announcement := range announcements {
+		if instances[i], err = dnsmessage.NewName(dnssdInstance(announcement)); err != nil {
+			return nil, err
+		}
+	}
+
+	b := dnsmessage.NewBuilder(nil, dnsmessage.Header{Response: true, Authoritative: true})
+	b.EnableCompression()
+
+	if err := b.StartAnswers(); err != nil {
+		return nil, err
+	}
+	for _, instance := range instances {
+		hdr := dnsmessage.ResourceHeader{Name: service, Class: dnsmessage.ClassINET, TTL: dnssdTTL}
+		if err := b.PTRResource(hdr, dnsmessage.PTRResource{PTR: instance}); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := b.StartAdditionals(); err != nil {
+		return nil, err
+	}
+	for i, announcement := range announcements {
+		hdr := dnsmessage.ResourceHeader{Name: instances[i], Class: dnsmessage.ClassINET, TTL: dnssdTTL}
+		srv := dnsmessage.SRVResource{Port: uint16(announcement.Port), Target: target}
+		if err := b.SRVResource(hdr, srv); err != nil {
+			return nil, err
+		}
+
+		txt := dnsmessage.TXTResource{TXT: []string{
+			fmt.Sprintf("%s=%s", dnssdTxtEndpoint, announcement.Endpoint),
+			fmt.Sprintf("%s=%d", dnssdTxtType, uint(announcement.Type)),
+			fmt.Sprintf("%s=%d", dnssdTxtPreference, announcement.Preference),
+		}}
+		if announcement.Address != "" {
+			txt.TXT = append(txt.TXT, fmt.Sprintf("%s=%s", dnssdTxtAddress, announcement.Address))
+		}
+		if err := b.TXTResource(hdr, txt); err != nil {
+			return nil, err
+		}
+	}
+
+	return b.Finish()
+}
+
+// parseDnssdResponse extracts all complete service instances of the dnssdService from a multicast DNS response.
+func parseDnssdResponse(msg []byte) (announcements []Announcement, err error) {
+	var p dnsmessage.Parser
+	if h, hErr := p.Start(msg); hErr != nil {
+		err = hErr
+		return
+	} else if !h.Response {
+		err = fmt.Errorf("message is no response")
+		return
+	}
+
+	if err = p.SkipAllQuestions(); err != nil {
+		return
+	}
+
+	var resources []dnsmessage.Resource
+	if answers, aErr := p.AllAnswers(); aErr != nil {
+		err = aErr
+		return
+	} else {
+		resources = append(resources, answers...)
+	}
+	if err = p.SkipAllAuthorities(); err != nil {
+		return
+	}
+	if additionals, aErr := p.AllAdditionals(); aErr != nil {
+		err = aErr
+		return
+	} else {
+		resources = append(resources, additionals...)
+	}
+
+	type instanceInfo struct {
+		port       *uint
+		endpoint   *bpv7.EndpointID
+		claType    *cla.CLAType
+		preference uint
+		address    string
+	}
+
+	var instances []string
+	infos := make(map[string]*instanceInfo)
+	info := func(name string) *instanceInfo {
+		name = strings.ToLower(name)
+		if _, ok := infos[name]; !ok {
+			infos[name] = &instanceInfo{}
+		}
+		return infos[name]
+	}
+
+	for _, resource := range resources {
+		switch body := resource.Body.(type) {
+		case *dnsmessage.PTRResource:
+			if strings.EqualFold(resource.Header.Name.String(), dnssdService) {
+				instances = append(instances, strings.ToLower(body.PTR.String()))
+			}
+
+		case *dnsmessage.SRVResource:
+			port := uint(body.Port)
+			info(resource.Header.Name.String()).port = &port
+
+		case *dnsmessage.TXTResource:
+			ii := info(resource.Header.Name.String())
+			for _, txt := range body.TXT {
+				key, value, found := strings.Cut(txt, "=")
+				if !found {
+					continue
+				}
+
+				switch key {
+				case dnssdTxtEndpoint:
+					if eid, eidErr := bpv7.NewEndpointID(value); eidErr == nil {
+						ii.endpoint = &eid
+					}
+
+				case dnssdTxtType:
+					if claTypeNo, claTypeErr := strconv.ParseUint(value, 10, 64); claTypeErr == nil {
+						claType := cla.CLAType(claTypeNo)
+						ii.claType = &claType
+					}
+
+				case dnssdTxtPreference:
+					if preference, prefErr := strconv.ParseUint(value, 10, 64); prefErr == nil {
+						ii.preference = uint(preference)
+					}
+
+				case dnssdTxtAddress:
+					ii.address = value
+				}
+			}
+		}
+	}
+
+	for _, instance := range instances {
+		ii, ok := infos[instance]
+		if !ok || ii.port == nil || ii.endpoint == nil || ii.claType == nil {
+			continue
+		}
+
+		announcements = append(announcements, Announcement{
+			Type:       *ii.claType,
+			Endpoint:   *ii.endpoint,
+			Port:       *ii.port,
+			Preference: ii.preference,
+			Address:    ii.address,
+		})
+	}
+
+	return
+}
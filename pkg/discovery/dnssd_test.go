@@ -0,0 +1,119 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package discovery
+
+import (
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+	"github.com/dtn7/dtn7-go/pkg/cla"
+)
+
+func TestDnssdQuery(t *testing.T) {
+	query, err := marshalDnssdQuery()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !isDnssdQuery(query) {
+		t.Fatal("query was not identified as such")
+	}
+
+	if _, err := parseDnssdResponse(query); err == nil {
+		t.Fatal("query was parsed as a response")
+	}
+}
+
+func TestDnssdResponse(t *testing.T) {
+	tests := []struct {
+		nodeId        bpv7.EndpointID
+		announcements []Announcement
+	}{
+		{
+			bpv7.MustNewEndpointID("dtn://foo.bar/"),
+			[]Announcement{
+				{cla.TCPCLv4, bpv7.MustNewEndpointID("dtn://foo.bar/"), 4556, 0, ""},
+			},
+		},
+		{
+			bpv7.MustNewEndpointID("dtn://foo/"),
+			[]Announcement{
+				{cla.MTCP, bpv7.MustNewEndpointID("dtn://foo/"), 35037, 0, ""},
+				{cla.TCPCLv4, bpv7.MustNewEndpointID("dtn://foo/"), 4556, 0, ""},
+				{cla.QUICL, bpv7.MustNewEndpointID("dtn://foo/"), 35038, 10, "dtn.example.org"},
+			},
+		},
+		{
+			bpv7.MustNewEndpointID("ipn:1337.23"),
+			[]Announcement{
+				{cla.TCPCLv4WebSocket, bpv7.MustNewEndpointID("ipn:1337.23"), 8080, 0, ""},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		msg, err := marshalDnssdResponse(test.nodeId, test.announcements)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if isDnssdQuery(msg) {
+			t.Fatal("response was identified as a query")
+		}
+
+		if announcements, err := parseDnssdResponse(msg); err != nil {
+			t.Fatal(err)
+		} else if !reflect.DeepEqual(announcements, test.announcements) {
+			t.Fatalf("expected %v, got %v", test.announcements, announcements)
+		}
+	}
+}
+
+func TestDnssdPassive(t *testing.T) {
+	manager := &Manager{NodeId: bpv7.MustNewEndpointID("dtn://foo/"), passive: true}
+	if err := manager.SetAnnouncements([]Announcement{{cla.TCPCLv4, bpv7.MustNewEndpointID("dtn://foo/"), 4556, 0, ""}}); err != nil {
+		t.Fatal(err)
+	}
+
+	receiver, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer receiver.Close()
+
+	conn, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	d := &dnssd{
+		manager:   manager,
+		conn:      conn,
+		groupAddr: receiver.LocalAddr().(*net.UDPAddr),
+		stopChan:  make(chan struct{}),
+	}
+	close(d.stopChan)
+
+	d.respond()
+	d.handleQuerier()
+
+	_ = receiver.SetReadDeadline(time.Now().Add(100 * time.Millisecond))
+	if n, _, err := receiver.ReadFromUDP(make([]byte, 1500)); err == nil {
+		t.Fatalf("passive DNS-SD transmitted %d bytes", n)
+	}
+
+	// Counter-check that an active DNS-SD would have responded
+	manager.passive = false
+	d.respond()
+
+	_ = receiver.SetReadDeadline(time.Now().Add(100 * time.Millisecond))
+	if _, _, err := receiver.ReadFromUDP(make([]byte, 1500)); err != nil {
+		t.Fatalf("active DNS-SD did not respond: %v", err)
+	}
+}
@@ -0,0 +1,246 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package discovery
+
+import (
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/schollz/peerdiscovery"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/ipv4"
+	"golang.org/x/net/ipv6"
+)
+
+// ifaceBeaconTTL is the multicast TTL or hop limit of sent beacons, identical to the peerdiscovery library's value.
+const ifaceBeaconTTL = 2
+
+// ifaceBeacon is an independent beacon, bound to a single network interface and IP version. It is used instead of the
+// peerdiscovery library, which always uses all available interfaces, if a Manager is restricted to some interfaces.
+//
+// The beacon is compatible with peerdiscovery's wire format. Only packets received on its interface are handled.
+type ifaceBeacon struct {
+	manager *Manager
+	iface   *net.Interface
+	ipv6    bool
+
+	conn  net.PacketConn
+	pc4   *ipv4.PacketConn
+	pc6   *ipv6.PacketConn
+	group *net.UDPAddr
+
+	stopChan  chan struct{}
+	closeOnce sync.Once
+}
+
+// startIfaceBeacon starts a beacon on the named network interface for the given Manager.
+func startIfaceBeacon(manager *Manager, name string, ipv6 bool) (*ifaceBeacon, error) {
+	iface, err := net.InterfaceByName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	network, address := "udp4", address4
+	if ipv6 {
+		network, address = "udp6", address6
+	}
+
+	group := &net.UDPAddr{IP: net.ParseIP(address), Port: port}
+
+	conn, err := net.ListenPacket(network, group.String())
+	if err != nil {
+		return nil, err
+	}
+
+	beacon := &ifaceBeacon{
+		manager:  manager,
+		iface:    iface,
+		ipv6:     ipv6,
+		conn:     conn,
+		group:    group,
+		stopChan: make(chan struct{}),
+	}
+
+	if err := beacon.setup(); err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("interface %s: %v", name, err)
+	}
+
+	go beacon.handleReader()
+	go beacon.handleBroadcaster()
+
+	return beacon, nil
+}
+
+// setup joins the multicast group and configures the outgoing interface.
+func (beacon *ifaceBeacon) setup() error {
+	if beacon.ipv6 {
+		pc := ipv6.NewPacketConn(beacon.conn)
+		beacon.pc6 = pc
+		if err := pc.JoinGroup(beacon.iface, beacon.group); err != nil {
+			return err
+		}
+		if err := pc.SetMulticastInterface(beacon.iface); err != nil {
+			return err
+		}
+		if err := pc.SetMulticastHopLimit(ifaceBeaconTTL); err != nil {
+			return err
+		}
+		return pc.SetControlMessage(ipv6.FlagInterface, true)
+	}
+
+	pc := ipv4.NewPacketConn(beacon.conn)
+	beacon.pc4 = pc
+	if err := pc.JoinGroup(beacon.iface, beacon.group); err != nil {
+		return err
+	}
+	if err := pc.SetMulticastInterface(beacon.iface); err != nil {
+		return err
+	}
+	if err := pc.SetMulticastTTL(ifaceBeaconTTL); err != nil {
+		return err
+	}
+	return pc.SetControlMessage(ipv4.FlagInterface, true)
+}
+
+// read the next packet and the index of the interface it was received on.
+func (beacon *ifaceBeacon) read(buff []byte) (n int, ifIndex int, src net.Addr, err error) {
+	if beacon.ipv6 {
+		var cm *ipv6.ControlMessage
+		n, cm, src, err = beacon.pc6.ReadFrom(buff)
+		if cm != nil {
+			ifIndex = cm.IfIndex
+		}
+		return
+	}
+
+	var cm *ipv4.ControlMessage
+	n, cm, src, err = beacon.pc4.ReadFrom(buff)
+	if cm != nil {
+		ifIndex = cm.IfIndex
+	}
+	return
+}
+
+// handleReader passes packets, received on this beacon's interface, to the Manager.
+func (beacon *ifaceBeacon) handleReader() {
+	buff := make([]byte, 66507)
+
+	for {
+		n, ifIndex, src, err := beacon.read(buff)
+		if err != nil {
+			select {
+			case <-beacon.stopChan:
+			default:
+				beacon.manager.reportError(fmt.Errorf("beacon on %s failed to read: %w", beacon.iface.Name, err))
+			}
+			return
+		}
+
+		if ifIndex != beacon.iface.Index {
+			continue
+		}
+
+		srcHost, _, err := net.SplitHostPort(src.String())
+		if err != nil {
+			continue
+		}
+
+		payload := make([]byte, n)
+		copy(payload, buff[:n])
+
+		discovered := peerdiscovery.Discovered{Address: srcHost, Payload: payload}
+		if beacon.ipv6 {
+			beacon.manager.notify6(discovered)
+		} else {
+			beacon.manager.notify(discovered)
+		}
+	}
+}
+
+// handleBroadcaster periodically sends the Manager's payload on this beacon's interface, unless the Manager is passive.
+func (beacon *ifaceBeacon) handleBroadcaster() {
+	if beacon.manager.IsPassive() {
+		return
+	}
+
+	for {
+		if _, err := beacon.conn.WriteTo(beacon.manager.currentPayload(), beacon.group); err != nil {
+			log.WithError(err).WithFields(log.Fields{
+				"discovery": beacon.manager,
+				"interface": beacon.iface.Name,
+			}).Debug("Beacon failed to send")
+		}
+
+		select {
+		case <-beacon.stopChan:
+			return
+
+		case <-time.After(beacon.manager.Interval()):
+		}
+	}
+}
+
+// close this beacon.
+func (beacon *ifaceBeacon) close() {
+	beacon.closeOnce.Do(func() {
+		close(beacon.stopChan)
+		_ = beacon.conn.Close()
+	})
+}
+
+// interfaceAddrs lists this node's unicast interface addresses, replaceable for tests.
+var interfaceAddrs = net.InterfaceAddrs
+
+// inLocalNetwork checks if an IP address lies within one of this node's networks.
+func inLocalNetwork(ip net.IP) bool {
+	addrs, err := interfaceAddrs()
+	if err != nil {
+		return false
+	}
+
+	for _, addr := range addrs {
+		if ipNet, ok := addr.(*net.IPNet); ok && !ipNet.IP.IsLoopback() && ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// inSameLocalNetwork checks if both IP addresses lie within the same one of this node's networks.
+func inSameLocalNetwork(a, b net.IP) bool {
+	if a == nil || b == nil {
+		return false
+	}
+
+	addrs, err := interfaceAddrs()
+	if err != nil {
+		return false
+	}
+
+	for _, addr := range addrs {
+		if ipNet, ok := addr.(*net.IPNet); ok && !ipNet.IP.IsLoopback() && ipNet.Contains(a) && ipNet.Contains(b) {
+			return true
+		}
+	}
+	return false
+}
+
+// localIPv4 returns this node's first non-loopback IPv4 address or nil.
+func localIPv4() net.IP {
+	addrs, err := interfaceAddrs()
+	if err != nil {
+		return nil
+	}
+
+	for _, addr := range addrs {
+		if ipNet, ok := addr.(*net.IPNet); ok && !ipNet.IP.IsLoopback() && ipNet.IP.To4() != nil {
+			return ipNet.IP.To4()
+		}
+	}
+	return nil
+}
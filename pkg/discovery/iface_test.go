@@ -0,0 +1,120 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package discovery
+
+import (
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+	"github.com/dtn7/dtn7-go/pkg/cla"
+)
+
+func loopbackInterface(t *testing.T) string {
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, iface := range ifaces {
+		if iface.Flags&net.FlagLoopback != 0 && iface.Flags&net.FlagUp != 0 {
+			return iface.Name
+		}
+	}
+
+	t.Skip("no loopback interface available")
+	return ""
+}
+
+func TestIfaceBeacon(t *testing.T) {
+	iface := loopbackInterface(t)
+
+	registered := make(chan cla.Convergable, 16)
+
+	sender, err := NewManager(
+		bpv7.MustNewEndpointID("dtn://sender/"), func(cla.Convergable) {},
+		[]Announcement{{cla.TCPCLv4, bpv7.MustNewEndpointID("dtn://sender/"), 4556, 0, ""}},
+		100*time.Millisecond, true, false, iface)
+	if err != nil {
+		t.Skipf("cannot start beacon on %s: %v", iface, err)
+	}
+	defer sender.Close()
+
+	receiver, err := NewPassiveManager(
+		bpv7.MustNewEndpointID("dtn://receiver/"), func(c cla.Convergable) { registered <- c },
+		100*time.Millisecond, true, false, iface)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer receiver.Close()
+
+	if ifaces := receiver.Interfaces(); len(ifaces) != 1 || ifaces[0] != iface {
+		t.Fatalf("unexpected interfaces: %v", ifaces)
+	}
+
+	select {
+	case c := <-registered:
+		if conv, ok := c.(cla.Convergence); !ok || !strings.HasSuffix(conv.Address(), ":4556") {
+			t.Fatalf("unexpected CLA: %v", c)
+		}
+
+	case <-time.After(3 * time.Second):
+		t.Fatal("no announcement was received on the loopback interface")
+	}
+
+	if err := receiver.StartDNSSD(); err == nil {
+		t.Fatal("DNS-SD was started for a Manager restricted to interfaces")
+	}
+
+	if err := receiver.SetInterfaces([]string{"no-such-interface"}); err == nil {
+		t.Fatal("unknown interface did not error")
+	}
+}
+
+func TestIfaceBeaconRestart(t *testing.T) {
+	iface := loopbackInterface(t)
+
+	errs := make(chan error, 4)
+
+	manager, err := NewPassiveManager(
+		bpv7.MustNewEndpointID("dtn://self/"), func(cla.Convergable) {}, 100*time.Millisecond, true, false, iface)
+	if err != nil {
+		t.Skipf("cannot start beacon on %s: %v", iface, err)
+	}
+	defer manager.Close()
+
+	manager.SetErrorFunc(func(err error) { errs <- err })
+
+	// A failing socket is reported, while a regular stop is not
+	_ = manager.beacons[0].conn.Close()
+
+	select {
+	case <-errs:
+	case <-time.After(time.Second):
+		t.Fatal("failed beacon was not reported")
+	}
+
+	if err := manager.Restart(); err != nil {
+		t.Fatal(err)
+	} else if len(manager.beacons) != 1 {
+		t.Fatalf("expected one beacon, got %d", len(manager.beacons))
+	}
+
+	manager.Close()
+
+	select {
+	case err := <-errs:
+		t.Fatalf("closing resulted in an error: %v", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	if err := manager.Restart(); err != nil {
+		t.Fatal(err)
+	} else if len(manager.beacons) != 0 {
+		t.Fatalf("closed Manager was restarted with %d beacons", len(manager.beacons))
+	}
+}
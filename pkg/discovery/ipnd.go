@@ -0,0 +1,403 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package discovery
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+	"github.com/dtn7/dtn7-go/pkg/cla"
+)
+
+const (
+	// ipndAddress is the IPv4 multicast address and the IANA-assigned UDP port of IPND.
+	ipndAddress = "224.0.0.142:4551"
+
+	// ipndVersion of the beacon format, as defined in draft-irtf-dtnrg-ipnd-03.
+	ipndVersion byte = 0x04
+)
+
+// IPND beacon flags, indicating the presence of optional fields.
+const (
+	ipndFlagEndpoint byte = 1 << iota
+	ipndFlagServices
+	ipndFlagBloomFilter
+	ipndFlagPeriod
+)
+
+// IPND service tags for primitive and constructed types. Only the types used by CLA services are listed.
+const (
+	ipndTagFixed16 byte = 4
+	ipndTagFixed32 byte = 5
+	ipndTagString  byte = 9
+	ipndTagBytes   byte = 10
+
+	ipndTagClaTcpV4 byte = 64
+	ipndTagClaTcpV6 byte = 66
+	ipndTagClaTcpHN byte = 68
+)
+
+// writeSdnv writes an unsigned integer as a Self-Delimiting Numeric Value, as used by IPND.
+func writeSdnv(n uint64, w io.ByteWriter) error {
+	var buff [10]byte
+	i := len(buff) - 1
+	buff[i] = byte(n & 0x7F)
+
+	for n >>= 7; n > 0; n >>= 7 {
+		i--
+		buff[i] = byte(n&0x7F) | 0x80
+	}
+
+	for _, b := range buff[i:] {
+		if err := w.WriteByte(b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readSdnv reads a Self-Delimiting Numeric Value.
+func readSdnv(r io.ByteReader) (n uint64, err error) {
+	for i := 0; i < 10; i++ {
+		var b byte
+		if b, err = r.ReadByte(); err != nil {
+			return
+		}
+
+		n = n<<7 | uint64(b&0x7F)
+		if b&0x80 == 0 {
+			return
+		}
+	}
+
+	err = fmt.Errorf("SDNV exceeds 64 bit")
+	return
+}
+
+// marshalIpndService creates an IPND CLA service for a TCPCLv4 Announcement. Based on the Announcement's Address, a
+// CLA-TCP-v4, CLA-TCP-v6, or CLA-TCP-HN service is created. Without an Address, the unspecified IPv4 address is used,
+// telling receivers to use the beacon's source address.
+func marshalIpndService(announcement Announcement) []byte {
+	content := new(bytes.Buffer)
+	tag := ipndTagClaTcpV4
+
+	ip := net.IPv4zero
+	if announcement.Address != "" {
+		ip = net.ParseIP(announcement.Address)
+	}
+
+	switch {
+	case ip == nil:
+		tag = ipndTagClaTcpHN
+		_ = content.WriteByte(ipndTagString)
+		_ = writeSdnv(uint64(len(announcement.Address)), content)
+		_, _ = content.WriteString(announcement.Address)
+
+	case ip.To4() != nil:
+		_ = content.WriteByte(ipndTagFixed32)
+		_, _ = content.Write(ip.To4())
+
+	default:
+		tag = ipndTagClaTcpV6
+		_ = content.WriteByte(ipndTagBytes)
+		_ = writeSdnv(uint64(net.IPv6len), content)
+		_, _ = content.Write(ip.To16())
+	}
+
+	_ = content.WriteByte(ipndTagFixed16)
+	_ = binary.Write(content, binary.BigEndian, uint16(announcement.Port))
+
+	service := new(bytes.Buffer)
+	_ = service.WriteByte(tag)
+	_ = writeSdnv(uint64(content.Len()), service)
+	_, _ = service.Write(content.Bytes())
+	return service.Bytes()
+}
+
+// marshalIpndBeacon creates an IPND beacon for a node's Announcements. As IPND only defines TCP based CLAs, only
+// TCPCLv4 Announcements are included.
+func marshalIpndBeacon(nodeId bpv7.EndpointID, announcements []Announcement, sequence uint16, period time.Duration) []byte {
+	var services [][]byte
+	for _, announcement := range announcements {
+		if announcement.Type == cla.TCPCLv4 {
+			services = append(services, marshalIpndService(announcement))
+		}
+	}
+
+	flags := ipndFlagEndpoint | ipndFlagPeriod
+	if len(services) > 0 {
+		flags |= ipndFlagServices
+	}
+
+	buff := new(bytes.Buffer)
+	_ = buff.WriteByte(ipndVersion)
+	_ = buff.WriteByte(flags)
+	_ = binary.Write(buff, binary.BigEndian, sequence)
+
+	eid := nodeId.String()
+	_ = writeSdnv(uint64(len(eid)), buff)
+	_, _ = buff.WriteString(eid)
+
+	if len(services) > 0 {
+		_ = writeSdnv(uint64(len(services)), buff)
+		for _, service := range services {
+			_, _ = buff.Write(service)
+		}
+	}
+
+	_ = writeSdnv(uint64(period/time.Second), buff)
+
+	return buff.Bytes()
+}
+
+// parseIpndService extracts an Announcement from an IPND CLA service's content. Unknown services result in an error.
+func parseIpndService(endpoint bpv7.EndpointID, tag byte, content []byte) (announcement Announcement, err error) {
+	announcement = Announcement{Type: cla.TCPCLv4, Endpoint: endpoint}
+	buff := bytes.NewReader(content)
+
+	var fieldTag byte
+	if fieldTag, err = buff.ReadByte(); err != nil {
+		return
+	}
+
+	switch {
+	case tag == ipndTagClaTcpV4 && fieldTag == ipndTagFixed32:
+		ip := make(net.IP, net.IPv4len)
+		if _, err = io.ReadFull(buff, ip); err != nil {
+			return
+		}
+		if !ip.IsUnspecified() {
+			announcement.Address = ip.String()
+		}
+
+	case (tag == ipndTagClaTcpV6 && fieldTag == ipndTagBytes) || (tag == ipndTagClaTcpHN && fieldTag == ipndTagString):
+		var l uint64
+		if l, err = readSdnv(buff); err != nil {
+			return
+		} else if l > uint64(buff.Len()) {
+			err = fmt.Errorf("field length %d exceeds service", l)
+			return
+		}
+
+		field := make([]byte, l)
+		if _, err = io.ReadFull(buff, field); err != nil {
+			return
+		}
+
+		if tag == ipndTagClaTcpHN {
+			announcement.Address = string(field)
+		} else if ip := net.IP(field); len(field) != net.IPv6len {
+			err = fmt.Errorf("IPv6 address has a length of %d", len(field))
+			return
+		} else if !ip.IsUnspecified() {
+			announcement.Address = ip.String()
+		}
+
+	default:
+		err = fmt.Errorf("unsupported IPND service %d with field %d", tag, fieldTag)
+		return
+	}
+
+	if fieldTag, err = buff.ReadByte(); err != nil {
+		return
+	} else if fieldTag != ipndTagFixed16 {
+		err = fmt.Errorf("expected port as fixed16, got field %d", fieldTag)
+		return
+	}
+
+	var port uint16
+	if err = binary.Read(buff, binary.BigEndian, &port); err != nil {
+		return
+	}
+	announcement.Port = uint(port)
+
+	return
+}
+
+// parseIpndBeacon extracts the sender's Endpoint, the Announcements of its supported CLA services, and its beacon
+// period from an IPND beacon. Unknown services are skipped. Without a source EID, dtn:none is used.
+func parseIpndBeacon(data []byte) (endpoint bpv7.EndpointID, announcements []Announcement, period time.Duration, err error) {
+	buff := bytes.NewReader(data)
+	endpoint = bpv7.DtnNone()
+
+	var header [4]byte
+	if _, err = io.ReadFull(buff, header[:]); err != nil {
+		return
+	} else if header[0] != ipndVersion {
+		err = fmt.Errorf("unsupported IPND version %#x", header[0])
+		return
+	}
+	flags := header[1]
+
+	readBytes := func() ([]byte, error) {
+		l, lErr := readSdnv(buff)
+		if lErr != nil {
+			return nil, lErr
+		} else if l > uint64(buff.Len()) {
+			return nil, fmt.Errorf("length %d exceeds beacon", l)
+		}
+
+		b := make([]byte, l)
+		_, rErr := io.ReadFull(buff, b)
+		return b, rErr
+	}
+
+	if flags&ipndFlagEndpoint != 0 {
+		var eid []byte
+		if eid, err = readBytes(); err != nil {
+			return
+		}
+		if endpoint, err = bpv7.NewEndpointID(string(eid)); err != nil {
+			return
+		}
+	}
+
+	if flags&ipndFlagServices != 0 {
+		var services uint64
+		if services, err = readSdnv(buff); err != nil {
+			return
+		}
+
+		for i := uint64(0); i < services; i++ {
+			var tag byte
+			if tag, err = buff.ReadByte(); err != nil {
+				return
+			}
+
+			var content []byte
+			if content, err = readBytes(); err != nil {
+				return
+			}
+
+			if announcement, serviceErr := parseIpndService(endpoint, tag, content); serviceErr == nil {
+				announcements = append(announcements, announcement)
+			}
+		}
+	}
+
+	if flags&ipndFlagBloomFilter != 0 {
+		if _, err = readBytes(); err != nil {
+			return
+		}
+	}
+
+	if flags&ipndFlagPeriod != 0 {
+		var seconds uint64
+		if seconds, err = readSdnv(buff); err != nil {
+			return
+		}
+		period = time.Duration(seconds) * time.Second
+	}
+
+	return
+}
+
+// ipnd sends and receives IP Neighbor Discovery (IPND) beacons, as specified in draft-irtf-dtnrg-ipnd-03. This allows
+// interoperability with other DTN implementations supporting IPND, limited to TCPCLv4.
+type ipnd struct {
+	manager *Manager
+
+	conn      *net.UDPConn
+	groupAddr *net.UDPAddr
+	sequence  uint16
+
+	stopChan  chan struct{}
+	closeOnce sync.Once
+}
+
+// startIpnd starts sending and receiving IPND beacons for the given Manager.
+func startIpnd(manager *Manager) (*ipnd, error) {
+	groupAddr, err := net.ResolveUDPAddr("udp4", ipndAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := net.ListenMulticastUDP("udp4", nil, groupAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	i := &ipnd{
+		manager:   manager,
+		conn:      conn,
+		groupAddr: groupAddr,
+		stopChan:  make(chan struct{}),
+	}
+
+	go i.handleReader()
+	go i.handleSender()
+
+	return i, nil
+}
+
+// handleReader passes Announcements from received beacons to the Manager.
+func (i *ipnd) handleReader() {
+	buff := make([]byte, 9000)
+
+	for {
+		n, addr, err := i.conn.ReadFromUDP(buff)
+		if err != nil {
+			select {
+			case <-i.stopChan:
+			default:
+				i.manager.reportError(fmt.Errorf("IPND failed to read: %w", err))
+			}
+			return
+		}
+
+		_, announcements, _, err := parseIpndBeacon(buff[:n])
+		if err != nil {
+			log.WithError(err).WithFields(log.Fields{
+				"discovery": i.manager,
+				"peer":      addr,
+			}).Debug("IPND failed to parse incoming beacon")
+			continue
+		}
+
+		for _, announcement := range selectAnnouncements(announcements, isSupported) {
+			go i.manager.handleDiscovery(announcement, addr.IP.String(), false)
+		}
+	}
+}
+
+// handleSender periodically sends beacons, based on the Manager's interval. A passive Manager does not send at all.
+func (i *ipnd) handleSender() {
+	if i.manager.IsPassive() {
+		return
+	}
+
+	for {
+		interval := i.manager.Interval()
+		beacon := marshalIpndBeacon(i.manager.NodeId, i.manager.Announcements(), i.sequence, interval)
+		i.sequence++
+
+		if _, err := i.conn.WriteToUDP(beacon, i.groupAddr); err != nil {
+			log.WithError(err).WithField("discovery", i.manager).Debug("IPND failed to send beacon")
+		}
+
+		select {
+		case <-i.stopChan:
+			return
+
+		case <-time.After(interval):
+		}
+	}
+}
+
+// close this IPND sender and receiver.
+func (i *ipnd) close() {
+	i.closeOnce.Do(func() {
+		close(i.stopChan)
+		_ = i.conn.Close()
+	})
+}
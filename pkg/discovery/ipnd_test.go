@@ -0,0 +1,115 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package discovery
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+	"github.com/dtn7/dtn7-go/pkg/cla"
+)
+
+func TestSdnv(t *testing.T) {
+	tests := []struct {
+		n    uint64
+		sdnv []byte
+	}{
+		{0, []byte{0x00}},
+		{127, []byte{0x7F}},
+		{128, []byte{0x81, 0x00}},
+		{0xABC, []byte{0x95, 0x3C}},
+		{0x1234, []byte{0xA4, 0x34}},
+		{0x4234, []byte{0x81, 0x84, 0x34}},
+	}
+
+	for _, test := range tests {
+		buff := new(bytes.Buffer)
+		if err := writeSdnv(test.n, buff); err != nil {
+			t.Fatal(err)
+		} else if !bytes.Equal(buff.Bytes(), test.sdnv) {
+			t.Fatalf("%d resulted in %x, expected %x", test.n, buff.Bytes(), test.sdnv)
+		}
+
+		if n, err := readSdnv(bytes.NewReader(test.sdnv)); err != nil {
+			t.Fatal(err)
+		} else if n != test.n {
+			t.Fatalf("%x resulted in %d, expected %d", test.sdnv, n, test.n)
+		}
+	}
+
+	if _, err := readSdnv(bytes.NewReader([]byte{0x81, 0x81})); err == nil {
+		t.Fatal("truncated SDNV did not error")
+	}
+}
+
+func TestIpndBeacon(t *testing.T) {
+	nodeId := bpv7.MustNewEndpointID("dtn://foo/")
+	announcements := []Announcement{
+		{cla.TCPCLv4, nodeId, 4556, 0, ""},
+		{cla.MTCP, nodeId, 35037, 0, ""},
+		{cla.TCPCLv4, nodeId, 4557, 0, "192.0.2.1"},
+		{cla.TCPCLv4, nodeId, 4558, 0, "2001:db8::1"},
+		{cla.TCPCLv4, nodeId, 4559, 0, "dtn.example.org"},
+	}
+
+	beacon := marshalIpndBeacon(nodeId, announcements, 23, 10*time.Second)
+	if beacon[0] != ipndVersion {
+		t.Fatalf("beacon has version %#x", beacon[0])
+	}
+
+	endpoint, parsed, period, err := parseIpndBeacon(beacon)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []Announcement{announcements[0], announcements[2], announcements[3], announcements[4]}
+	if endpoint != nodeId {
+		t.Fatalf("expected endpoint %v, got %v", nodeId, endpoint)
+	} else if !reflect.DeepEqual(parsed, expected) {
+		t.Fatalf("expected %v, got %v", expected, parsed)
+	} else if period != 10*time.Second {
+		t.Fatalf("expected period of 10s, got %v", period)
+	}
+}
+
+func TestIpndBeaconForeign(t *testing.T) {
+	// Beacon without an EID, containing a CLA-UDP-v4 and a CLA-TCP-v4 service, a bloom filter, and a period
+	beacon := []byte{
+		ipndVersion, ipndFlagServices | ipndFlagBloomFilter | ipndFlagPeriod, 0x00, 0x01,
+		0x02,
+		65, 0x08, ipndTagFixed32, 10, 0, 0, 1, ipndTagFixed16, 0x11, 0xD7,
+		ipndTagClaTcpV4, 0x08, ipndTagFixed32, 10, 0, 0, 1, ipndTagFixed16, 0x11, 0xCC,
+		0x02, 0xFF, 0xFF,
+		0x1E,
+	}
+
+	endpoint, announcements, period, err := parseIpndBeacon(beacon)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []Announcement{{cla.TCPCLv4, bpv7.DtnNone(), 4556, 0, "10.0.0.1"}}
+	if endpoint != bpv7.DtnNone() {
+		t.Fatalf("expected dtn:none, got %v", endpoint)
+	} else if !reflect.DeepEqual(announcements, expected) {
+		t.Fatalf("expected %v, got %v", expected, announcements)
+	} else if period != 30*time.Second {
+		t.Fatalf("expected period of 30s, got %v", period)
+	}
+
+	for _, invalid := range [][]byte{
+		{},
+		{0x02, 0x00, 0x00, 0x00},
+		{ipndVersion, ipndFlagEndpoint, 0x00, 0x00, 0x10, 'd', 't', 'n'},
+		beacon[:len(beacon)-1],
+	} {
+		if _, _, _, err := parseIpndBeacon(invalid); err == nil {
+			t.Fatalf("invalid beacon %x was parsed", invalid)
+		}
+	}
+}
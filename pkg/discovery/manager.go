@@ -1,5 +1,5 @@
 // SPDX-FileCopyrightText: 2020, 2022 Markus Sommer
-// SPDX-FileCopyrightText: 2020, 2021 Alvar Penning
+// SPDX-FileCopyrightText: 2020, 2021, 2022 Alvar Penning
 //
 // SPDX-License-Identifier: GPL-3.0-or-later
 
@@ -7,6 +7,10 @@ package discovery
 
 import (
 	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/dtn7/dtn7-go/pkg/cla/quicl"
@@ -22,43 +26,137 @@ import (
 )
 
 // Manager publishes and receives Announcements.
+//
+// The announced CLAs, the interval between two announcements, and the TTL of received Announcements might be altered
+// at runtime.
 type Manager struct {
 	NodeId       bpv7.EndpointID
 	RegisterFunc func(cla.Convergable) `json:"-"`
 
-	stopChan4 chan struct{}
-	stopChan6 chan struct{}
+	ipv4    bool
+	ipv6    bool
+	passive bool
+
+	runMutex   sync.Mutex
+	stopChan4  chan struct{}
+	stopChan6  chan struct{}
+	interfaces []string
+	beacons    []*ifaceBeacon
+	closed     bool
+	dnssd      *dnssd
+	ipnd       *ipnd
+	static     *staticProber
+	ble        *ble
+
+	mutex         sync.Mutex
+	announcements []Announcement
+	payload       []byte
+	interval      time.Duration
+	ttl           time.Duration
+	seen          map[string]time.Time
+	key           []byte
+
+	errorFunc     func(error)
+	eventFunc     func(PeerEvent)
+	neighborsFunc func() []bpv7.EndpointID
+	gossipFunc    func(peer bpv7.EndpointID, neighbors []bpv7.EndpointID)
+
+	peers          map[Peer]*discoveredPeer
+	staticPeers    map[Peer]cla.Convergable
+	expiryMultiple uint
+	unregisterFunc func(cla.Convergable)
+	expiryStopChan chan struct{}
+}
+
+// discoveredPeer is a Peer's state, learned from its Announcements.
+type discoveredPeer struct {
+	convergable cla.Convergable
+	lastSeen    time.Time
+	period      time.Duration
 }
 
 // NewManager for Announcements will be created and started.
+//
+// If interfaces are named, the discovery is restricted to those network interfaces, each having its own beacon.
+// Otherwise, all available interfaces are used.
 func NewManager(
 	nodeId bpv7.EndpointID, registerFunc func(cla.Convergable),
 	announcements []Announcement, announcementInterval time.Duration,
-	ipv4, ipv6 bool) (*Manager, error) {
+	ipv4, ipv6 bool, interfaces ...string) (*Manager, error) {
+
+	return newManager(nodeId, registerFunc, announcements, announcementInterval, ipv4, ipv6, false, interfaces)
+}
+
+// NewPassiveManager creates and starts a Manager which only receives Announcements, but never transmits anything. This
+// might be used for covert or power-constrained nodes, which should not announce themselves.
+//
+// Since a passive Manager does not send DNS-SD queries, it only learns from responses to other nodes' queries. Static
+// peer probing is not available.
+func NewPassiveManager(
+	nodeId bpv7.EndpointID, registerFunc func(cla.Convergable),
+	interval time.Duration, ipv4, ipv6 bool, interfaces ...string) (*Manager, error) {
+
+	return newManager(nodeId, registerFunc, nil, interval, ipv4, ipv6, true, interfaces)
+}
+
+func newManager(
+	nodeId bpv7.EndpointID, registerFunc func(cla.Convergable),
+	announcements []Announcement, announcementInterval time.Duration,
+	ipv4, ipv6, passive bool, interfaces []string) (*Manager, error) {
 
 	var manager = &Manager{
-		NodeId:       nodeId,
-		RegisterFunc: registerFunc,
-	}
-	if ipv4 {
-		manager.stopChan4 = make(chan struct{})
-	}
-	if ipv6 {
-		manager.stopChan6 = make(chan struct{})
+		NodeId:         nodeId,
+		RegisterFunc:   registerFunc,
+		ipv4:           ipv4,
+		ipv6:           ipv6,
+		passive:        passive,
+		interfaces:     interfaces,
+		interval:       announcementInterval,
+		seen:           make(map[string]time.Time),
+		peers:          make(map[Peer]*discoveredPeer),
+		expiryStopChan: make(chan struct{}),
 	}
 
 	log.WithFields(log.Fields{
 		"interval":      announcementInterval,
 		"IPv4":          ipv4,
 		"IPv6":          ipv6,
+		"passive":       passive,
+		"interfaces":    interfaces,
 		"announcements": announcements,
 	}).Info("Starting Manager")
 
-	msg, err := MarshalAnnouncements(announcements)
-	if err != nil {
+	if err := manager.SetAnnouncements(announcements); err != nil {
+		return nil, err
+	}
+
+	if err := manager.start(); err != nil {
 		return nil, err
 	}
 
+	go manager.handleExpiry()
+
+	return manager, nil
+}
+
+// start the peerdiscovery for the configured IP versions. If the Manager is restricted to some interfaces, a beacon is
+// started for each interface instead.
+func (manager *Manager) start() error {
+	if len(manager.interfaces) > 0 {
+		return manager.startBeacons()
+	}
+
+	manager.mutex.Lock()
+	interval := manager.interval
+	manager.mutex.Unlock()
+
+	if manager.ipv4 {
+		manager.stopChan4 = make(chan struct{})
+	}
+	if manager.ipv6 {
+		manager.stopChan6 = make(chan struct{})
+	}
+
 	sets := []struct {
 		active           bool
 		multicastAddress string
@@ -66,8 +164,8 @@ func NewManager(
 		ipVersion        peerdiscovery.IPVersion
 		notify           func(discovered peerdiscovery.Discovered)
 	}{
-		{ipv4, address4, manager.stopChan4, peerdiscovery.IPv4, manager.notify},
-		{ipv6, address6, manager.stopChan6, peerdiscovery.IPv6, manager.notify6},
+		{manager.ipv4, address4, manager.stopChan4, peerdiscovery.IPv4, manager.notify},
+		{manager.ipv6, address6, manager.stopChan6, peerdiscovery.IPv6, manager.notify6},
 	}
 
 	for _, set := range sets {
@@ -79,16 +177,17 @@ func NewManager(
 			Limit:            -1,
 			Port:             fmt.Sprintf("%d", port),
 			MulticastAddress: set.multicastAddress,
-			Payload:          msg,
-			Delay:            announcementInterval,
+			PayloadFunc:      manager.currentPayload,
+			Delay:            interval,
 			TimeLimit:        -1,
 			StopChan:         set.stopChan,
 			AllowSelf:        true,
+			DisableBroadcast: manager.passive,
 			IPVersion:        set.ipVersion,
 			Notify:           set.notify,
 		}
 
-		discoverErrChan := make(chan error)
+		discoverErrChan := make(chan error, 1)
 		go func() {
 			_, discoverErr := peerdiscovery.Discover(set)
 			discoverErrChan <- discoverErr
@@ -97,15 +196,541 @@ func NewManager(
 		select {
 		case discoverErr := <-discoverErrChan:
 			if discoverErr != nil {
-				return nil, discoverErr
+				return discoverErr
 			}
 
 		case <-time.After(time.Second):
+			go manager.watchDiscover(set.IPVersion, discoverErrChan)
+		}
+	}
+
+	return nil
+}
+
+// watchDiscover reports an error of a running peerdiscovery, which would otherwise stop silently.
+func (manager *Manager) watchDiscover(ipVersion peerdiscovery.IPVersion, discoverErrChan chan error) {
+	if err := <-discoverErrChan; err != nil {
+		manager.reportError(fmt.Errorf("peerdiscovery for IPv%d failed: %w", ipVersion, err))
+	}
+}
+
+// reportError of a stopped discovery mechanism to the errorFunc. Afterwards, the Manager might be restarted.
+func (manager *Manager) reportError(err error) {
+	log.WithError(err).WithField("discovery", manager).Error("Peer discovery stopped")
+
+	manager.mutex.Lock()
+	errorFunc := manager.errorFunc
+	manager.mutex.Unlock()
+
+	if errorFunc != nil {
+		errorFunc(err)
+	}
+}
+
+// SetErrorFunc sets an optional callback for errors, which stopped some discovery mechanism. It might be called from
+// any goroutine. Usually, the Manager should be restarted afterwards, as done by Restart.
+func (manager *Manager) SetErrorFunc(errorFunc func(error)) {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+
+	manager.errorFunc = errorFunc
+}
+
+// Restart all enabled discovery mechanisms, e.g., after an error was reported to the error callback. Statically
+// configured peers are not affected. If a mechanism fails to start, it will be tried again with the next Restart. A
+// closed Manager will not be restarted.
+func (manager *Manager) Restart() error {
+	manager.runMutex.Lock()
+	defer manager.runMutex.Unlock()
+
+	if manager.closed {
+		return nil
+	}
+
+	log.WithField("discovery", manager).Info("Restarting Manager")
+
+	manager.stop()
+	if err := manager.start(); err != nil {
+		return err
+	}
+
+	if manager.dnssd != nil {
+		manager.dnssd.close()
+		d, err := startDnssd(manager)
+		if err != nil {
+			return fmt.Errorf("DNS-SD: %w", err)
+		}
+		manager.dnssd = d
+	}
+
+	if manager.ipnd != nil {
+		manager.ipnd.close()
+		i, err := startIpnd(manager)
+		if err != nil {
+			return fmt.Errorf("IPND: %w", err)
+		}
+		manager.ipnd = i
+	}
+
+	if manager.ble != nil {
+		manager.ble.close()
+		b, err := startBle(manager, manager.ble.index)
+		if err != nil {
+			return fmt.Errorf("BLE: %w", err)
+		}
+		manager.ble = b
+	}
+
+	return nil
+}
+
+// startBeacons starts an ifaceBeacon for each configured interface and IP version.
+func (manager *Manager) startBeacons() error {
+	for _, name := range manager.interfaces {
+		for _, ipv6 := range []bool{false, true} {
+			if (!ipv6 && !manager.ipv4) || (ipv6 && !manager.ipv6) {
+				continue
+			}
+
+			beacon, err := startIfaceBeacon(manager, name, ipv6)
+			if err != nil {
+				manager.stop()
+				return err
+			}
+			manager.beacons = append(manager.beacons, beacon)
+		}
+	}
+
+	return nil
+}
+
+// stop the peerdiscovery for all IP versions and all beacons.
+func (manager *Manager) stop() {
+	for _, beacon := range manager.beacons {
+		beacon.close()
+	}
+	manager.beacons = nil
+
+	for _, c := range []chan struct{}{manager.stopChan4, manager.stopChan6} {
+		if c != nil {
+			c <- struct{}{}
+		}
+	}
+
+	manager.stopChan4 = nil
+	manager.stopChan6 = nil
+}
+
+// StartDNSSD additionally publishes and browses Announcements as DNS-SD service instances of the "_dtn._tcp" type via
+// multicast DNS. This might be used next to or instead of the UDP multicast based discovery.
+func (manager *Manager) StartDNSSD() error {
+	manager.runMutex.Lock()
+	defer manager.runMutex.Unlock()
+
+	if manager.dnssd != nil {
+		return fmt.Errorf("DNS-SD is already running")
+	} else if manager.getKey() != nil {
+		return fmt.Errorf("DNS-SD does not support signed announcements")
+	} else if len(manager.interfaces) > 0 {
+		return fmt.Errorf("DNS-SD cannot be restricted to interfaces")
+	}
+
+	d, err := startDnssd(manager)
+	if err != nil {
+		return err
+	}
+
+	log.WithField("discovery", manager).Info("Started DNS-SD")
+
+	manager.dnssd = d
+	return nil
+}
+
+// StartIPND additionally sends and receives IP Neighbor Discovery (IPND) beacons, as specified in
+// draft-irtf-dtnrg-ipnd-03. This allows discovering and being discovered by other DTN implementations. As IPND only
+// specifies TCP based CLAs, only TCPCLv4 is announced and connected to.
+func (manager *Manager) StartIPND() error {
+	manager.runMutex.Lock()
+	defer manager.runMutex.Unlock()
+
+	if manager.ipnd != nil {
+		return fmt.Errorf("IPND is already running")
+	} else if manager.getKey() != nil {
+		return fmt.Errorf("IPND does not support signed announcements")
+	} else if len(manager.interfaces) > 0 {
+		return fmt.Errorf("IPND cannot be restricted to interfaces")
+	}
+
+	i, err := startIpnd(manager)
+	if err != nil {
+		return err
+	}
+
+	log.WithField("discovery", manager).Info("Started IPND")
+
+	manager.ipnd = i
+	return nil
+}
+
+// StartBLE additionally advertises and scans Announcements by Bluetooth Low Energy on the HCI device of the given
+// index, e.g., 0 for hci0. As a BLE advertisement is too small to carry an Announcement, one supported CLA is advertised
+// in each interval together with an IPv4 address. Received Announcements are only accepted for addresses within one of
+// this node's networks. A passive Manager only scans.
+//
+// There is no BLE CLA: BLE only discovers the IPv4 addresses of IP based CLAs, e.g., TCPCLv4, which are used for the
+// bundle exchange. As advertisements cannot be signed, BLE neither advertises nor accepts advertisements while a key is
+// set, see SetKey.
+func (manager *Manager) StartBLE(device uint16) error {
+	manager.runMutex.Lock()
+	defer manager.runMutex.Unlock()
+
+	if manager.ble != nil {
+		return fmt.Errorf("BLE is already running")
+	} else if manager.getKey() != nil {
+		return fmt.Errorf("BLE does not support signed announcements")
+	}
+
+	b, err := startBle(manager, device)
+	if err != nil {
+		return err
+	}
+
+	log.WithFields(log.Fields{
+		"discovery": manager,
+		"device":    fmt.Sprintf("hci%d", device),
+	}).Info("Started BLE")
+
+	manager.ble = b
+	return nil
+}
+
+// StartStatic probes the reachability of the given static Peers in each interval. A CLA is registered for each peer
+// becoming reachable.
+func (manager *Manager) StartStatic(peers []Peer) error {
+	manager.runMutex.Lock()
+	defer manager.runMutex.Unlock()
+
+	if manager.static != nil {
+		return fmt.Errorf("static peer probing is already running")
+	} else if manager.passive {
+		return fmt.Errorf("static peer probing is not available for a passive Manager")
+	}
+
+	prober, err := startStaticProber(manager, peers)
+	if err != nil {
+		return err
+	}
+
+	log.WithFields(log.Fields{
+		"discovery": manager,
+		"peers":     peers,
+	}).Info("Started static peer probing")
+
+	manager.static = prober
+	return nil
+}
+
+// handlePeerEvent registers a CLA for an appeared static Peer and unregisters it after its disappearance. The event
+// is passed to the eventFunc.
+func (manager *Manager) handlePeerEvent(event PeerEvent) {
+	log.WithFields(log.Fields{
+		"discovery": manager,
+		"peer":      event.Peer,
+		"event":     event.Type,
+	}).Info("Static peer's reachability changed")
+
+	switch event.Type {
+	case PeerAppeared:
+		if manager.NodeId.SameNode(event.Peer.Endpoint) {
 			break
 		}
+
+		if announcement, host, err := event.Peer.announcement(); err != nil {
+			log.WithError(err).WithField("peer", event.Peer).Warn("Static peer is invalid")
+		} else if convergable, err := newConvergable(announcement, host, manager.NodeId); err != nil {
+			log.WithError(err).WithField("peer", event.Peer).Warn("Static peer's Type is unknown or unsupported")
+		} else {
+			manager.mutex.Lock()
+			if manager.staticPeers == nil {
+				manager.staticPeers = make(map[Peer]cla.Convergable)
+			}
+			manager.staticPeers[event.Peer] = convergable
+			manager.mutex.Unlock()
+
+			event.Convergable = convergable
+			manager.RegisterFunc(convergable)
+		}
+
+	case PeerDisappeared:
+		manager.mutex.Lock()
+		event.Convergable = manager.staticPeers[event.Peer]
+		delete(manager.staticPeers, event.Peer)
+		manager.mutex.Unlock()
 	}
 
-	return manager, nil
+	if eventFunc := manager.getEventFunc(); eventFunc != nil {
+		eventFunc(event)
+	}
+
+	if event.Type == PeerDisappeared && event.Convergable != nil {
+		if unregisterFunc := manager.getUnregisterFunc(); unregisterFunc != nil {
+			unregisterFunc(event.Convergable)
+		}
+	}
+}
+
+// notifyPeerEvent passes the event of a discovered Peer to the eventFunc.
+func (manager *Manager) notifyPeerEvent(event PeerEvent) {
+	log.WithFields(log.Fields{
+		"discovery": manager,
+		"peer":      event.Peer,
+		"event":     event.Type,
+	}).Info("Discovered peer's reachability changed")
+
+	if eventFunc := manager.getEventFunc(); eventFunc != nil {
+		eventFunc(event)
+	}
+}
+
+// SetEventFunc sets a callback for changes of a Peer's reachability, e.g., to inform a routing algorithm about expired
+// Peers. A nil function disables it.
+func (manager *Manager) SetEventFunc(eventFunc func(PeerEvent)) {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+
+	manager.eventFunc = eventFunc
+}
+
+func (manager *Manager) getEventFunc() func(PeerEvent) {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+
+	return manager.eventFunc
+}
+
+// currentPayload returns the marshalled Announcements, called by peerdiscovery before each broadcast. If a key is set,
+// the payload will be signed.
+func (manager *Manager) currentPayload() []byte {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+
+	payload := manager.payload
+	if manager.neighborsFunc != nil && len(manager.announcements) > 0 {
+		if gossipPayload, err := marshalBeacon(manager.announcements, manager.neighborsFunc()); err != nil {
+			log.WithError(err).WithField("discovery", manager).Warn("Peer discovery failed to attach neighbors")
+		} else {
+			payload = gossipPayload
+		}
+	}
+
+	if manager.key == nil {
+		return payload
+	}
+
+	payload, err := signPayload(manager.key, payload, time.Now())
+	if err != nil {
+		log.WithError(err).WithField("discovery", manager).Warn("Peer discovery failed to sign payload")
+		return nil
+	}
+	return payload
+}
+
+// SetGossip enables second-hop gossip for the UDP multicast based discovery. The neighborsFunc lists this node's
+// currently connected peers, which are attached to each outgoing beacon. Neighbors received from other nodes are passed
+// to the gossipFunc, giving, e.g., a routing algorithm knowledge of the two-hop topology. Nil functions disable each.
+func (manager *Manager) SetGossip(neighborsFunc func() []bpv7.EndpointID, gossipFunc func(peer bpv7.EndpointID, neighbors []bpv7.EndpointID)) {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+
+	manager.neighborsFunc = neighborsFunc
+	manager.gossipFunc = gossipFunc
+}
+
+// SetKey for signing outgoing and verifying incoming UDP multicast payloads with HMAC-SHA256. This key must be shared
+// within the network. If a key is set, unsigned or invalid payloads are ignored. A nil key disables signing.
+//
+// Signed payloads are not supported by the DNS-SD discovery. A running BLE discovery is suspended while a key is set.
+func (manager *Manager) SetKey(key []byte) {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+
+	if key == nil {
+		manager.key = nil
+	} else {
+		manager.key = make([]byte, len(key))
+		copy(manager.key, key)
+	}
+}
+
+func (manager *Manager) getKey() []byte {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+
+	return manager.key
+}
+
+// Announcements returns a copy of the currently announced CLAs.
+func (manager *Manager) Announcements() []Announcement {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+
+	announcements := make([]Announcement, len(manager.announcements))
+	copy(announcements, manager.announcements)
+	return announcements
+}
+
+// IsPassive checks if this Manager only receives, but never transmits Announcements.
+func (manager *Manager) IsPassive() bool {
+	return manager.passive
+}
+
+// SetAnnouncements replaces the announced CLAs, effective from the next broadcast.
+func (manager *Manager) SetAnnouncements(announcements []Announcement) error {
+	payload, err := MarshalAnnouncements(announcements)
+	if err != nil {
+		return err
+	}
+
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+
+	manager.announcements = make([]Announcement, len(announcements))
+	copy(manager.announcements, announcements)
+	manager.payload = payload
+
+	return nil
+}
+
+// AddAnnouncement adds another CLA to be announced, e.g., for a newly registered CLA listener.
+func (manager *Manager) AddAnnouncement(announcement Announcement) error {
+	announcements := manager.Announcements()
+	for _, a := range announcements {
+		if a == announcement {
+			return nil
+		}
+	}
+
+	return manager.SetAnnouncements(append(announcements, announcement))
+}
+
+// RemoveAnnouncement stops announcing a CLA.
+func (manager *Manager) RemoveAnnouncement(announcement Announcement) error {
+	announcements := manager.Announcements()
+	for i, a := range announcements {
+		if a == announcement {
+			return manager.SetAnnouncements(append(announcements[:i], announcements[i+1:]...))
+		}
+	}
+
+	return nil
+}
+
+// Interval between two broadcasted announcements.
+func (manager *Manager) Interval() time.Duration {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+
+	return manager.interval
+}
+
+// SetInterval between two broadcasted announcements. Because the underlying peerdiscovery is configured on startup,
+// it will be restarted.
+func (manager *Manager) SetInterval(interval time.Duration) error {
+	manager.mutex.Lock()
+	if interval == manager.interval {
+		manager.mutex.Unlock()
+		return nil
+	}
+	manager.interval = interval
+	manager.mutex.Unlock()
+
+	log.WithFields(log.Fields{
+		"discovery": manager,
+		"interval":  interval,
+	}).Info("Restarting Manager with a new interval")
+
+	manager.runMutex.Lock()
+	defer manager.runMutex.Unlock()
+
+	manager.stop()
+	return manager.start()
+}
+
+// Interfaces to which the discovery is restricted. An empty list refers to all available interfaces.
+func (manager *Manager) Interfaces() []string {
+	manager.runMutex.Lock()
+	defer manager.runMutex.Unlock()
+
+	return append([]string(nil), manager.interfaces...)
+}
+
+// SetInterfaces restricts the discovery to the named network interfaces, each having its own beacon. An empty list
+// results in using all available interfaces. The discovery will be restarted.
+func (manager *Manager) SetInterfaces(interfaces []string) error {
+	manager.runMutex.Lock()
+	defer manager.runMutex.Unlock()
+
+	if len(interfaces) > 0 && manager.dnssd != nil {
+		return fmt.Errorf("DNS-SD cannot be restricted to interfaces")
+	} else if len(interfaces) > 0 && manager.ipnd != nil {
+		return fmt.Errorf("IPND cannot be restricted to interfaces")
+	}
+
+	log.WithFields(log.Fields{
+		"discovery":  manager,
+		"interfaces": interfaces,
+	}).Info("Restarting Manager with new interfaces")
+
+	manager.stop()
+	manager.interfaces = append([]string(nil), interfaces...)
+	return manager.start()
+}
+
+// TTL of a received Announcement. Within this duration, the same Announcement from the same address will not result
+// in another CLA registration. A TTL of zero, the default, handles each Announcement.
+func (manager *Manager) TTL() time.Duration {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+
+	return manager.ttl
+}
+
+// SetTTL of received Announcements, as described for TTL.
+func (manager *Manager) SetTTL(ttl time.Duration) {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+
+	manager.ttl = ttl
+}
+
+// isFresh checks if an Announcement from this address was already handled within the TTL. Otherwise, it will be
+// marked as seen now.
+func (manager *Manager) isFresh(announcement Announcement, addr string) bool {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+
+	if manager.ttl <= 0 {
+		return false
+	}
+
+	if manager.seen == nil {
+		manager.seen = make(map[string]time.Time)
+	}
+
+	now := time.Now()
+	for k, t := range manager.seen {
+		if now.Sub(t) > manager.ttl {
+			delete(manager.seen, k)
+		}
+	}
+
+	key := fmt.Sprintf("%s/%v", addr, announcement)
+	if _, ok := manager.seen[key]; ok {
+		return true
+	}
+
+	manager.seen[key] = now
+	return false
 }
 
 func (manager *Manager) notify6(discovered peerdiscovery.Discovered) {
@@ -115,7 +740,23 @@ func (manager *Manager) notify6(discovered peerdiscovery.Discovered) {
 }
 
 func (manager *Manager) notify(discovered peerdiscovery.Discovered) {
-	announcements, err := UnmarshalAnnouncements(discovered.Payload)
+	payload := discovered.Payload
+	key := manager.getKey()
+	authenticated := key != nil
+	if authenticated {
+		if verified, err := verifyPayload(key, payload, time.Now()); err != nil {
+			log.WithError(err).WithFields(log.Fields{
+				"discovery": manager,
+				"peer":      discovered.Address,
+			}).Debug("Peer discovery ignored an unauthenticated package")
+
+			return
+		} else {
+			payload = verified
+		}
+	}
+
+	announcements, neighbors, err := unmarshalBeacon(payload)
 	if err != nil {
 		log.WithError(err).WithFields(log.Fields{
 			"discovery": manager,
@@ -125,35 +766,75 @@ func (manager *Manager) notify(discovered peerdiscovery.Discovered) {
 		return
 	}
 
-	for _, announcement := range announcements {
-		go manager.handleDiscovery(announcement, discovered.Address)
+	for _, announcement := range selectAnnouncements(announcements, isSupported) {
+		go manager.handleDiscovery(announcement, discovered.Address, authenticated)
 	}
+
+	for peer, peerNeighbors := range neighbors {
+		go manager.handleGossip(peer, peerNeighbors)
+	}
+}
+
+// handleGossip passes the received neighbors of some peer to the gossipFunc.
+func (manager *Manager) handleGossip(peer bpv7.EndpointID, neighbors []bpv7.EndpointID) {
+	manager.mutex.Lock()
+	gossipFunc := manager.gossipFunc
+	manager.mutex.Unlock()
+
+	if gossipFunc == nil || manager.NodeId.SameNode(peer) {
+		return
+	}
+
+	log.WithFields(log.Fields{
+		"discovery": manager,
+		"peer":      peer,
+		"neighbors": neighbors,
+	}).Debug("Peer discovery received neighbors")
+
+	gossipFunc(peer, neighbors)
 }
 
-func (manager *Manager) handleDiscovery(announcement Announcement, addr string) {
+// handleDiscovery registers a CLA for an Announcement received from the given address. Only an authenticated
+// Announcement, i.e., of an HMAC-signed beacon, might redirect to an arbitrary Address. Otherwise, the Address must be
+// an IP address within a local network shared with the sender, not to be abused for dialing arbitrary hosts.
+func (manager *Manager) handleDiscovery(announcement Announcement, addr string, authenticated bool) {
 	if manager.NodeId.SameNode(announcement.Endpoint) {
 		return
 	}
 
+	if announcement.Address != "" {
+		if !authenticated && !inSameLocalNetwork(net.ParseIP(strings.Trim(addr, "[]")), net.ParseIP(announcement.Address)) {
+			log.WithFields(log.Fields{
+				"discovery": manager,
+				"peer":      addr,
+				"address":   announcement.Address,
+			}).Info("Peer discovery ignored an unauthenticated Announcement's Address outside the sender's network")
+			return
+		}
+
+		addr = announcement.Address
+		if ip := net.ParseIP(addr); ip != nil && ip.To4() == nil {
+			addr = fmt.Sprintf("[%s]", addr)
+		}
+	}
+
 	log.WithFields(log.Fields{
 		"discovery": manager,
 		"peer":      addr,
 		"message":   announcement,
 	}).Debug("Peer discovery received a message")
 
-	var convergable cla.Convergable
-	switch announcement.Type {
-	case cla.MTCP:
-		convergable = mtcp.NewMTCPClient(fmt.Sprintf("%s:%d", addr, announcement.Port), announcement.Endpoint, false)
-
-	case cla.TCPCLv4:
-		convergable = tcpclv4.DialTCP(fmt.Sprintf("%s:%d", addr, announcement.Port), manager.NodeId, false)
-
-	case cla.QUICL:
-		convergable = quicl.NewDialerEndpoint(fmt.Sprintf("%s:%d", addr, announcement.Port), manager.NodeId, false)
+	peer := Peer{
+		Type:     announcement.Type,
+		Endpoint: announcement.Endpoint,
+		Address:  fmt.Sprintf("%s:%d", addr, announcement.Port),
+	}
 
-	default:
-		log.WithFields(log.Fields{
+	convergable, appeared, err := manager.trackPeer(peer, func() (cla.Convergable, error) {
+		return newConvergable(announcement, addr, manager.NodeId)
+	})
+	if err != nil {
+		log.WithError(err).WithFields(log.Fields{
 			"discovery": manager,
 			"peer":      addr,
 			"type":      announcement.Type,
@@ -162,15 +843,201 @@ func (manager *Manager) handleDiscovery(announcement Announcement, addr string)
 		return
 	}
 
+	fresh := manager.isFresh(announcement, addr)
+	if appeared {
+		manager.notifyPeerEvent(PeerEvent{Type: PeerAppeared, Peer: peer, Convergable: convergable})
+	} else if fresh {
+		return
+	}
+
 	manager.RegisterFunc(convergable)
 }
 
+// trackPeer updates the last seen time of a discovered Peer. For an unknown Peer, a new CLA will be created by the
+// createFunc and appeared is true. Otherwise, the Peer's known CLA is returned.
+func (manager *Manager) trackPeer(peer Peer, createFunc func() (cla.Convergable, error)) (
+	convergable cla.Convergable, appeared bool, err error) {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+
+	now := time.Now()
+
+	if dp, ok := manager.peers[peer]; ok {
+		dp.period = now.Sub(dp.lastSeen)
+		dp.lastSeen = now
+		return dp.convergable, false, nil
+	}
+
+	if convergable, err = createFunc(); err != nil {
+		return
+	}
+
+	if manager.peers == nil {
+		manager.peers = make(map[Peer]*discoveredPeer)
+	}
+	manager.peers[peer] = &discoveredPeer{convergable: convergable, lastSeen: now}
+
+	appeared = true
+	return
+}
+
+// Peers currently known from received Announcements, sorted by their Endpoint, Type, and Address. Statically
+// configured Peers are not included.
+func (manager *Manager) Peers() []DiscoveredPeer {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+
+	peers := make([]DiscoveredPeer, 0, len(manager.peers))
+	for peer, dp := range manager.peers {
+		peers = append(peers, DiscoveredPeer{Peer: peer, LastSeen: dp.lastSeen, Period: dp.period})
+	}
+
+	sort.Slice(peers, func(i, j int) bool {
+		if a, b := peers[i].Endpoint.String(), peers[j].Endpoint.String(); a != b {
+			return a < b
+		} else if peers[i].Type != peers[j].Type {
+			return peers[i].Type < peers[j].Type
+		}
+		return peers[i].Address < peers[j].Address
+	})
+
+	return peers
+}
+
+// SetExpiry of discovered Peers. If a Peer was not seen for the multiple of its beacon period, it disappears and its
+// CLA is passed to the unregisterFunc. The beacon period is observed, but at least this Manager's interval. A multiple
+// of zero, the default, disables the expiry. Independent of the multiple, the CLAs of unreachable static Peers are
+// passed to the unregisterFunc as well.
+func (manager *Manager) SetExpiry(multiple uint, unregisterFunc func(cla.Convergable)) {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+
+	manager.expiryMultiple = multiple
+	manager.unregisterFunc = unregisterFunc
+}
+
+func (manager *Manager) getUnregisterFunc() func(cla.Convergable) {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+
+	return manager.unregisterFunc
+}
+
+// expirePeers removes all discovered Peers which were not seen in time and returns them with their CLAs.
+func (manager *Manager) expirePeers(now time.Time) (expired map[Peer]cla.Convergable, unregisterFunc func(cla.Convergable)) {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+
+	if manager.expiryMultiple == 0 {
+		return
+	}
+
+	for peer, dp := range manager.peers {
+		period := dp.period
+		if period < manager.interval {
+			period = manager.interval
+		}
+
+		if now.Sub(dp.lastSeen) <= time.Duration(manager.expiryMultiple)*period {
+			continue
+		}
+
+		if expired == nil {
+			expired = make(map[Peer]cla.Convergable)
+		}
+		expired[peer] = dp.convergable
+		delete(manager.peers, peer)
+	}
+
+	unregisterFunc = manager.unregisterFunc
+	return
+}
+
+// handleExpiry periodically checks for expired Peers until the Manager is closed.
+func (manager *Manager) handleExpiry() {
+	for {
+		select {
+		case <-manager.expiryStopChan:
+			return
+
+		case now := <-time.After(manager.Interval()):
+			expired, unregisterFunc := manager.expirePeers(now)
+			for peer, convergable := range expired {
+				manager.notifyPeerEvent(PeerEvent{Type: PeerDisappeared, Peer: peer, Convergable: convergable})
+
+				if unregisterFunc != nil {
+					unregisterFunc(convergable)
+				}
+			}
+		}
+	}
+}
+
+// isSupported checks if a CLA client can be created for an Announcement.
+func isSupported(announcement Announcement) bool {
+	switch announcement.Type {
+	case cla.MTCP, cla.TCPCLv4, cla.TCPCLv4WebSocket, cla.QUICL:
+		return true
+
+	default:
+		return false
+	}
+}
+
+// newConvergable creates a CLA client for a peer's Announcement, received from the given address.
+func newConvergable(announcement Announcement, addr string, nodeId bpv7.EndpointID) (cla.Convergable, error) {
+	hostPort := fmt.Sprintf("%s:%d", addr, announcement.Port)
+
+	switch announcement.Type {
+	case cla.MTCP:
+		return mtcp.NewMTCPClient(hostPort, announcement.Endpoint, false), nil
+
+	case cla.TCPCLv4:
+		return tcpclv4.DialTCP(hostPort, nodeId, false), nil
+
+	case cla.TCPCLv4WebSocket:
+		return tcpclv4.DialWebSocket(fmt.Sprintf("ws://%s/tcpclv4", hostPort), nodeId, false), nil
+
+	case cla.QUICL:
+		return quicl.NewDialerEndpoint(hostPort, nodeId, false), nil
+
+	default:
+		return nil, fmt.Errorf("no CLA client available for %v", announcement.Type)
+	}
+}
+
 // Close this Manager.
 func (manager *Manager) Close() {
-	for _, c := range []chan struct{}{manager.stopChan4, manager.stopChan6} {
-		if c != nil {
-			c <- struct{}{}
-		}
+	manager.runMutex.Lock()
+	defer manager.runMutex.Unlock()
+
+	manager.closed = true
+	manager.stop()
+
+	select {
+	case <-manager.expiryStopChan:
+	default:
+		close(manager.expiryStopChan)
+	}
+
+	if manager.dnssd != nil {
+		manager.dnssd.close()
+		manager.dnssd = nil
+	}
+
+	if manager.ipnd != nil {
+		manager.ipnd.close()
+		manager.ipnd = nil
+	}
+
+	if manager.static != nil {
+		manager.static.close()
+		manager.static = nil
+	}
+
+	if manager.ble != nil {
+		manager.ble.close()
+		manager.ble = nil
 	}
 }
 
@@ -0,0 +1,370 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package discovery
+
+import (
+	"errors"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/schollz/peerdiscovery"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+	"github.com/dtn7/dtn7-go/pkg/cla"
+)
+
+func TestManagerHandleDiscovery(t *testing.T) {
+	tests := []struct {
+		announcement Announcement
+		address      string
+		registered   bool
+	}{
+		{Announcement{cla.MTCP, bpv7.MustNewEndpointID("dtn://peer/"), 35037, 0, ""}, "10.0.0.2:35037", true},
+		{Announcement{cla.TCPCLv4, bpv7.MustNewEndpointID("dtn://peer/"), 4556, 0, ""}, "10.0.0.2:4556", true},
+		{Announcement{cla.TCPCLv4WebSocket, bpv7.MustNewEndpointID("dtn://peer/"), 8080, 0, ""}, "ws://10.0.0.2:8080/tcpclv4", true},
+		{Announcement{cla.QUICL, bpv7.MustNewEndpointID("dtn://peer/"), 35038, 0, ""}, "10.0.0.2:35038", true},
+		{Announcement{cla.BBC, bpv7.MustNewEndpointID("dtn://peer/"), 0, 0, ""}, "", false},
+		{Announcement{cla.MTCP, bpv7.MustNewEndpointID("dtn://self/"), 35037, 0, ""}, "", false},
+	}
+
+	for _, test := range tests {
+		var registered cla.Convergable
+
+		manager := &Manager{
+			NodeId:       bpv7.MustNewEndpointID("dtn://self/"),
+			RegisterFunc: func(c cla.Convergable) { registered = c },
+		}
+		manager.handleDiscovery(test.announcement, "10.0.0.2", false)
+
+		if !test.registered {
+			if registered != nil {
+				t.Fatalf("%v resulted in a registered CLA: %v", test.announcement, registered)
+			}
+			continue
+		}
+
+		if registered == nil {
+			t.Fatalf("%v resulted in no registered CLA", test.announcement)
+		} else if conv, ok := registered.(cla.Convergence); !ok {
+			t.Fatalf("%v resulted in %T, not a Convergence", test.announcement, registered)
+		} else if addr := conv.Address(); addr != test.address {
+			t.Fatalf("%v resulted in address %s, expected %s", test.announcement, addr, test.address)
+		}
+	}
+}
+
+func TestManagerHandleDiscoveryAddress(t *testing.T) {
+	interfaceAddrs = func() ([]net.Addr, error) {
+		return []net.Addr{&net.IPNet{IP: net.ParseIP("10.0.0.1"), Mask: net.CIDRMask(24, 32)}}, nil
+	}
+	defer func() { interfaceAddrs = net.InterfaceAddrs }()
+
+	tests := []struct {
+		address       string
+		authenticated bool
+		expected      string
+	}{
+		{"10.0.0.3", false, "10.0.0.3:35037"},
+		{"10.0.1.3", false, ""},
+		{"dtn.example.org", false, ""},
+		{"10.0.1.3", true, "10.0.1.3:35037"},
+		{"dtn.example.org", true, "dtn.example.org:35037"},
+	}
+
+	for _, test := range tests {
+		var registered cla.Convergable
+
+		manager := &Manager{
+			NodeId:       bpv7.MustNewEndpointID("dtn://self/"),
+			RegisterFunc: func(c cla.Convergable) { registered = c },
+		}
+		announcement := Announcement{cla.MTCP, bpv7.MustNewEndpointID("dtn://peer/"), 35037, 0, test.address}
+		manager.handleDiscovery(announcement, "10.0.0.2", test.authenticated)
+
+		if test.expected == "" {
+			if registered != nil {
+				t.Fatalf("%v resulted in a registered CLA: %v", announcement, registered)
+			}
+		} else if registered == nil {
+			t.Fatalf("%v resulted in no registered CLA", announcement)
+		} else if addr := registered.(cla.Convergence).Address(); addr != test.expected {
+			t.Fatalf("%v resulted in address %s, expected %s", announcement, addr, test.expected)
+		}
+	}
+}
+
+func TestManagerAnnouncements(t *testing.T) {
+	a1 := Announcement{cla.MTCP, bpv7.MustNewEndpointID("dtn://self/"), 35037, 0, ""}
+	a2 := Announcement{cla.TCPCLv4, bpv7.MustNewEndpointID("dtn://self/"), 4556, 0, ""}
+
+	manager := &Manager{NodeId: bpv7.MustNewEndpointID("dtn://self/")}
+	if err := manager.SetAnnouncements([]Announcement{a1}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, a := range []Announcement{a2, a2} {
+		if err := manager.AddAnnouncement(a); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if as := manager.Announcements(); !reflect.DeepEqual(as, []Announcement{a1, a2}) {
+		t.Fatalf("unexpected announcements: %v", as)
+	}
+
+	if err := manager.RemoveAnnouncement(a1); err != nil {
+		t.Fatal(err)
+	}
+	if as := manager.Announcements(); !reflect.DeepEqual(as, []Announcement{a2}) {
+		t.Fatalf("unexpected announcements: %v", as)
+	}
+
+	if as, err := UnmarshalAnnouncements(manager.currentPayload()); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(as, []Announcement{a2}) {
+		t.Fatalf("unexpected payload announcements: %v", as)
+	}
+}
+
+func TestManagerTTL(t *testing.T) {
+	var registrations int
+
+	manager := &Manager{
+		NodeId:       bpv7.MustNewEndpointID("dtn://self/"),
+		RegisterFunc: func(_ cla.Convergable) { registrations++ },
+	}
+	announcement := Announcement{cla.MTCP, bpv7.MustNewEndpointID("dtn://peer/"), 35037, 0, ""}
+
+	for i := 0; i < 3; i++ {
+		manager.handleDiscovery(announcement, "10.0.0.2", false)
+	}
+	if registrations != 3 {
+		t.Fatalf("expected 3 registrations without TTL, got %d", registrations)
+	}
+
+	manager.SetTTL(100 * time.Millisecond)
+	for i := 0; i < 3; i++ {
+		manager.handleDiscovery(announcement, "10.0.0.2", false)
+	}
+	manager.handleDiscovery(announcement, "10.0.0.3", false)
+	if registrations != 5 {
+		t.Fatalf("expected 5 registrations within TTL, got %d", registrations)
+	}
+
+	time.Sleep(150 * time.Millisecond)
+	manager.handleDiscovery(announcement, "10.0.0.2", false)
+	if registrations != 6 {
+		t.Fatalf("expected 6 registrations after TTL, got %d", registrations)
+	}
+}
+
+func TestManagerNotifySigned(t *testing.T) {
+	registered := make(chan cla.Convergable, 2)
+
+	manager := &Manager{
+		NodeId:       bpv7.MustNewEndpointID("dtn://self/"),
+		RegisterFunc: func(c cla.Convergable) { registered <- c },
+	}
+	manager.SetKey([]byte("shared network key"))
+
+	payload, err := MarshalAnnouncements([]Announcement{{cla.MTCP, bpv7.MustNewEndpointID("dtn://peer/"), 35037, 0, ""}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	manager.notify(peerdiscovery.Discovered{Address: "10.0.0.2", Payload: payload})
+	select {
+	case c := <-registered:
+		t.Fatalf("unsigned payload resulted in a registered CLA: %v", c)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	signed, err := signPayload([]byte("shared network key"), payload, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	manager.notify(peerdiscovery.Discovered{Address: "10.0.0.2", Payload: signed})
+	select {
+	case <-registered:
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("signed payload resulted in no registered CLA")
+	}
+}
+
+func TestManagerExpiry(t *testing.T) {
+	announcement := Announcement{cla.TCPCLv4, bpv7.MustNewEndpointID("dtn://peer/"), 4556, 0, ""}
+	peer := Peer{Type: cla.TCPCLv4, Endpoint: announcement.Endpoint, Address: "10.0.0.2:4556"}
+
+	var (
+		registered   []cla.Convergable
+		unregistered []cla.Convergable
+		events       []PeerEvent
+	)
+
+	manager := &Manager{
+		NodeId:       bpv7.MustNewEndpointID("dtn://self/"),
+		RegisterFunc: func(c cla.Convergable) { registered = append(registered, c) },
+		eventFunc:    func(e PeerEvent) { events = append(events, e) },
+		interval:     time.Minute,
+	}
+
+	manager.handleDiscovery(announcement, "10.0.0.2", false)
+	manager.handleDiscovery(announcement, "10.0.0.2", false)
+
+	if len(registered) != 2 {
+		t.Fatalf("expected two registrations, got %d", len(registered))
+	} else if registered[0] != registered[1] {
+		t.Fatalf("a known peer resulted in a new CLA: %v != %v", registered[0], registered[1])
+	} else if !reflect.DeepEqual(events, []PeerEvent{{PeerAppeared, peer, registered[0]}}) {
+		t.Fatalf("unexpected events: %v", events)
+	}
+
+	// Without an expiry multiple, peers never expire
+	if expired, _ := manager.expirePeers(time.Now().Add(time.Hour)); len(expired) != 0 {
+		t.Fatalf("peers expired without an expiry: %v", expired)
+	}
+
+	manager.SetExpiry(3, func(c cla.Convergable) { unregistered = append(unregistered, c) })
+
+	// The observed period between both announcements is tiny, so the interval is used
+	if expired, _ := manager.expirePeers(time.Now().Add(2 * time.Minute)); len(expired) != 0 {
+		t.Fatalf("peers expired too early: %v", expired)
+	}
+
+	expired, unregisterFunc := manager.expirePeers(time.Now().Add(4 * time.Minute))
+	if len(expired) != 1 {
+		t.Fatalf("expected one expired peer, got %v", expired)
+	} else if expired[peer] != registered[0] {
+		t.Fatalf("expired peer has another CLA: %v", expired[peer])
+	}
+
+	unregisterFunc(expired[peer])
+	if len(unregistered) != 1 || unregistered[0] != registered[0] {
+		t.Fatalf("unexpected unregistered CLAs: %v", unregistered)
+	}
+
+	// Afterwards, the peer appears again
+	manager.handleDiscovery(announcement, "10.0.0.2", false)
+	if len(events) != 2 || events[1].Type != PeerAppeared {
+		t.Fatalf("peer did not appear again: %v", events)
+	}
+}
+
+func TestManagerPeers(t *testing.T) {
+	manager := &Manager{
+		NodeId:       bpv7.MustNewEndpointID("dtn://self/"),
+		RegisterFunc: func(cla.Convergable) {},
+	}
+
+	if peers := manager.Peers(); len(peers) != 0 {
+		t.Fatalf("unexpected peers: %v", peers)
+	}
+
+	manager.handleDiscovery(Announcement{cla.TCPCLv4, bpv7.MustNewEndpointID("dtn://b/"), 4556, 0, ""}, "10.0.0.3", false)
+	manager.handleDiscovery(Announcement{cla.MTCP, bpv7.MustNewEndpointID("dtn://a/"), 35037, 0, ""}, "10.0.0.2", false)
+	manager.handleDiscovery(Announcement{cla.TCPCLv4, bpv7.MustNewEndpointID("dtn://a/"), 4556, 0, ""}, "10.0.0.2", false)
+	manager.handleDiscovery(Announcement{cla.TCPCLv4, bpv7.MustNewEndpointID("dtn://a/"), 4556, 0, ""}, "10.0.0.2", false)
+
+	expected := []Peer{
+		{cla.TCPCLv4, bpv7.MustNewEndpointID("dtn://a/"), "10.0.0.2:4556"},
+		{cla.MTCP, bpv7.MustNewEndpointID("dtn://a/"), "10.0.0.2:35037"},
+		{cla.TCPCLv4, bpv7.MustNewEndpointID("dtn://b/"), "10.0.0.3:4556"},
+	}
+
+	peers := manager.Peers()
+	if len(peers) != len(expected) {
+		t.Fatalf("expected %d peers, got %v", len(expected), peers)
+	}
+
+	for i, peer := range peers {
+		if peer.Peer != expected[i] {
+			t.Fatalf("expected %v at %d, got %v", expected[i], i, peer.Peer)
+		} else if peer.LastSeen.IsZero() || time.Since(peer.LastSeen) > time.Minute {
+			t.Fatalf("%v has an unexpected last seen time: %v", peer.Peer, peer.LastSeen)
+		}
+	}
+
+	if peers[1].Period != 0 || peers[2].Period != 0 {
+		t.Fatalf("peers seen once have a period: %v", peers)
+	} else if peers[0].Period <= 0 {
+		t.Fatalf("peer seen twice has no period: %v", peers[0])
+	}
+}
+
+func TestManagerGossip(t *testing.T) {
+	type gossip struct {
+		peer      bpv7.EndpointID
+		neighbors []bpv7.EndpointID
+	}
+	gossips := make(chan gossip, 2)
+
+	neighbors := []bpv7.EndpointID{bpv7.MustNewEndpointID("dtn://a/"), bpv7.MustNewEndpointID("dtn://b/")}
+
+	sender := &Manager{NodeId: bpv7.MustNewEndpointID("dtn://peer/")}
+	if err := sender.SetAnnouncements([]Announcement{{cla.MTCP, sender.NodeId, 35037, 0, ""}}); err != nil {
+		t.Fatal(err)
+	}
+	sender.SetGossip(func() []bpv7.EndpointID { return neighbors }, nil)
+
+	receiver := &Manager{
+		NodeId:       bpv7.MustNewEndpointID("dtn://self/"),
+		RegisterFunc: func(cla.Convergable) {},
+	}
+	receiver.SetGossip(nil, func(peer bpv7.EndpointID, neighbors []bpv7.EndpointID) {
+		gossips <- gossip{peer, neighbors}
+	})
+
+	receiver.notify(peerdiscovery.Discovered{Address: "10.0.0.2", Payload: sender.currentPayload()})
+	select {
+	case g := <-gossips:
+		if g.peer != sender.NodeId || !reflect.DeepEqual(g.neighbors, neighbors) {
+			t.Fatalf("unexpected gossip: %v", g)
+		}
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("no gossip was received")
+	}
+
+	// Disabling gossip at the sender results in plain Announcements
+	sender.SetGossip(nil, nil)
+	receiver.notify(peerdiscovery.Discovered{Address: "10.0.0.2", Payload: sender.currentPayload()})
+	select {
+	case g := <-gossips:
+		t.Fatalf("unexpected gossip: %v", g)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestManagerWatchDiscover(t *testing.T) {
+	errs := make(chan error, 2)
+
+	manager := &Manager{NodeId: bpv7.MustNewEndpointID("dtn://self/")}
+	manager.SetErrorFunc(func(err error) { errs <- err })
+
+	discoverErr := errors.New("socket closed")
+	discoverErrChan := make(chan error, 1)
+	discoverErrChan <- discoverErr
+	manager.watchDiscover(peerdiscovery.IPv4, discoverErrChan)
+
+	select {
+	case err := <-errs:
+		if !errors.Is(err, discoverErr) {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	default:
+		t.Fatal("error was not reported")
+	}
+
+	// A regularly stopped peerdiscovery is no error
+	discoverErrChan <- nil
+	manager.watchDiscover(peerdiscovery.IPv4, discoverErrChan)
+
+	select {
+	case err := <-errs:
+		t.Fatalf("unexpected error: %v", err)
+	default:
+	}
+}
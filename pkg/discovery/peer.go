@@ -0,0 +1,87 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package discovery
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+	"github.com/dtn7/dtn7-go/pkg/cla"
+)
+
+// Peer is a remote node's CLA, either discovered or statically configured.
+type Peer struct {
+	Type     cla.CLAType
+	Endpoint bpv7.EndpointID
+	Address  string
+}
+
+// announcement converts this Peer into an Announcement and the peer's host address.
+func (peer Peer) announcement() (Announcement, string, error) {
+	host, portStr, err := net.SplitHostPort(peer.Address)
+	if err != nil {
+		return Announcement{}, "", err
+	}
+
+	portNo, err := strconv.ParseUint(portStr, 10, 16)
+	if err != nil {
+		return Announcement{}, "", err
+	}
+
+	if ip := net.ParseIP(host); ip != nil && ip.To4() == nil {
+		host = fmt.Sprintf("[%s]", host)
+	}
+
+	return Announcement{Type: peer.Type, Endpoint: peer.Endpoint, Port: uint(portNo)}, host, nil
+}
+
+func (peer Peer) String() string {
+	return fmt.Sprintf("Peer(%v, %v, %s)", peer.Type, peer.Endpoint, peer.Address)
+}
+
+// DiscoveredPeer is a Peer learned from received Announcements, together with its observed beacon behavior.
+type DiscoveredPeer struct {
+	Peer
+
+	// LastSeen is the time of the latest Announcement.
+	LastSeen time.Time
+
+	// Period is the observed time between the two latest Announcements, or zero if only one was received.
+	Period time.Duration
+}
+
+// PeerEventType describes the kind of a PeerEvent.
+type PeerEventType int
+
+const (
+	// PeerAppeared shows that a Peer became reachable.
+	PeerAppeared PeerEventType = iota
+
+	// PeerDisappeared shows that a Peer became unreachable.
+	PeerDisappeared
+)
+
+func (eventType PeerEventType) String() string {
+	switch eventType {
+	case PeerAppeared:
+		return "Peer Appeared"
+	case PeerDisappeared:
+		return "Peer Disappeared"
+	default:
+		return "unknown"
+	}
+}
+
+// PeerEvent is reported for each change of a Peer's reachability or presence.
+type PeerEvent struct {
+	Type PeerEventType
+	Peer Peer
+
+	// Convergable is the Peer's CLA, registered on its appearance and unregistered after its disappearance.
+	Convergable cla.Convergable
+}
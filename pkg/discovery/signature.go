@@ -0,0 +1,85 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package discovery
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"fmt"
+	"time"
+
+	"github.com/dtn7/cboring"
+)
+
+// signatureMaxAge is the maximum time difference between a signed payload's timestamp and the local clock. This only
+// limits how long a captured payload can be replayed. Neither the sender's address nor a nonce is authenticated, so
+// within this window, anyone can replay a captured payload from any address.
+const signatureMaxAge = 5 * time.Minute
+
+// signatureTag calculates the HMAC-SHA256 over a payload and its timestamp.
+func signatureTag(key []byte, payload []byte, timestamp uint64) []byte {
+	mac := hmac.New(sha256.New, key)
+	_ = cboring.WriteUInt(timestamp, mac)
+	_, _ = mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+// signPayload wraps a payload into a CBOR array of the payload, the current timestamp, and their HMAC-SHA256 tag.
+func signPayload(key []byte, payload []byte, now time.Time) ([]byte, error) {
+	timestamp := uint64(now.Unix())
+
+	buff := new(bytes.Buffer)
+	if err := cboring.WriteArrayLength(3, buff); err != nil {
+		return nil, err
+	}
+	if err := cboring.WriteByteString(payload, buff); err != nil {
+		return nil, err
+	}
+	if err := cboring.WriteUInt(timestamp, buff); err != nil {
+		return nil, err
+	}
+	if err := cboring.WriteByteString(signatureTag(key, payload, timestamp), buff); err != nil {
+		return nil, err
+	}
+
+	return buff.Bytes(), nil
+}
+
+// verifyPayload unwraps a payload, created by signPayload, after checking its timestamp and tag.
+func verifyPayload(key []byte, data []byte, now time.Time) ([]byte, error) {
+	buff := bytes.NewBuffer(data)
+
+	if l, err := cboring.ReadArrayLength(buff); err != nil {
+		return nil, err
+	} else if l != 3 {
+		return nil, fmt.Errorf("wrong array length: %d instead of 3, payload might be unsigned", l)
+	}
+
+	payload, err := cboring.ReadByteString(buff)
+	if err != nil {
+		return nil, fmt.Errorf("payload might be unsigned: %v", err)
+	}
+
+	timestamp, err := cboring.ReadUInt(buff)
+	if err != nil {
+		return nil, err
+	}
+
+	tag, err := cboring.ReadByteString(buff)
+	if err != nil {
+		return nil, err
+	}
+
+	if !hmac.Equal(tag, signatureTag(key, payload, timestamp)) {
+		return nil, fmt.Errorf("invalid signature")
+	}
+
+	if age := now.Sub(time.Unix(int64(timestamp), 0)); age > signatureMaxAge || age < -signatureMaxAge {
+		return nil, fmt.Errorf("signature's timestamp differs by %v", age)
+	}
+
+	return payload, nil
+}
@@ -0,0 +1,51 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package discovery
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestSignature(t *testing.T) {
+	key := []byte("shared network key")
+	payload := []byte("hello world")
+	now := time.Now()
+
+	signed, err := signPayload(key, payload, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if verified, err := verifyPayload(key, signed, now.Add(time.Minute)); err != nil {
+		t.Fatal(err)
+	} else if !bytes.Equal(verified, payload) {
+		t.Fatalf("expected %x, got %x", payload, verified)
+	}
+
+	if _, err := verifyPayload([]byte("another key"), signed, now); err == nil {
+		t.Fatal("payload was verified with another key")
+	}
+
+	if _, err := verifyPayload(key, signed, now.Add(2*signatureMaxAge)); err == nil {
+		t.Fatal("outdated payload was verified")
+	}
+
+	tampered := make([]byte, len(signed))
+	copy(tampered, signed)
+	tampered[5] ^= 0xff
+	if _, err := verifyPayload(key, tampered, now); err == nil {
+		t.Fatal("tampered payload was verified")
+	}
+
+	unsigned, err := MarshalAnnouncements(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := verifyPayload(key, unsigned, now); err == nil {
+		t.Fatal("unsigned payload was verified")
+	}
+}
@@ -0,0 +1,118 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package discovery
+
+import (
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/cla"
+)
+
+// staticProbeTimeout is the maximum duration of a single reachability probe.
+const staticProbeTimeout = 3 * time.Second
+
+// probe checks if this Peer is reachable. Because QUICL is based on UDP, a QUICL peer is only checked for a
+// resolvable address.
+func (peer Peer) probe() bool {
+	if peer.Type == cla.QUICL {
+		_, err := net.ResolveUDPAddr("udp", peer.Address)
+		return err == nil
+	}
+
+	conn, err := net.DialTimeout("tcp", peer.Address, staticProbeTimeout)
+	if err != nil {
+		return false
+	}
+	_ = conn.Close()
+	return true
+}
+
+// staticProber periodically probes a list of statically configured Peers. This might be used instead of the multicast
+// based discovery, e.g., in networks where multicast is blocked.
+type staticProber struct {
+	manager *Manager
+	peers   []Peer
+
+	reachable map[int]bool
+	probeFunc func(Peer) bool
+
+	stopChan  chan struct{}
+	closeOnce sync.Once
+}
+
+// startStaticProber starts probing the static Peers for the given Manager.
+func startStaticProber(manager *Manager, peers []Peer) (*staticProber, error) {
+	for _, peer := range peers {
+		if _, _, err := peer.announcement(); err != nil {
+			return nil, fmt.Errorf("static peer %v is invalid: %v", peer, err)
+		}
+	}
+
+	prober := &staticProber{
+		manager:   manager,
+		peers:     peers,
+		reachable: make(map[int]bool),
+		probeFunc: Peer.probe,
+		stopChan:  make(chan struct{}),
+	}
+
+	go prober.handler()
+
+	return prober, nil
+}
+
+func (prober *staticProber) handler() {
+	for {
+		prober.probeAll()
+
+		select {
+		case <-prober.stopChan:
+			return
+
+		case <-time.After(prober.manager.Interval()):
+		}
+	}
+}
+
+// probeAll probes each Peer concurrently and reports changes of reachability.
+func (prober *staticProber) probeAll() {
+	results := make([]bool, len(prober.peers))
+
+	var wg sync.WaitGroup
+	wg.Add(len(prober.peers))
+	for i, peer := range prober.peers {
+		go func(i int, peer Peer) {
+			defer wg.Done()
+			results[i] = prober.probeFunc(peer)
+		}(i, peer)
+	}
+	wg.Wait()
+
+	for i, reachable := range results {
+		if prober.reachable[i] == reachable {
+			continue
+		}
+		prober.reachable[i] = reachable
+
+		event := PeerEvent{Peer: prober.peers[i]}
+		if reachable {
+			event.Type = PeerAppeared
+		} else {
+			event.Type = PeerDisappeared
+		}
+
+		prober.manager.handlePeerEvent(event)
+	}
+}
+
+// close this staticProber.
+func (prober *staticProber) close() {
+	prober.closeOnce.Do(func() {
+		close(prober.stopChan)
+	})
+}
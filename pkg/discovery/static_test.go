@@ -0,0 +1,145 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package discovery
+
+import (
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+	"github.com/dtn7/dtn7-go/pkg/cla"
+)
+
+func TestStaticPeerAnnouncement(t *testing.T) {
+	tests := []struct {
+		peer         Peer
+		announcement Announcement
+		host         string
+		valid        bool
+	}{
+		{
+			Peer{cla.TCPCLv4, bpv7.DtnNone(), "10.0.0.2:4556"},
+			Announcement{cla.TCPCLv4, bpv7.DtnNone(), 4556, 0, ""}, "10.0.0.2", true,
+		},
+		{
+			Peer{cla.MTCP, bpv7.MustNewEndpointID("dtn://peer/"), "[fe80::1]:35037"},
+			Announcement{cla.MTCP, bpv7.MustNewEndpointID("dtn://peer/"), 35037, 0, ""}, "[fe80::1]", true,
+		},
+		{
+			Peer{cla.TCPCLv4, bpv7.DtnNone(), "example.org:4556"},
+			Announcement{cla.TCPCLv4, bpv7.DtnNone(), 4556, 0, ""}, "example.org", true,
+		},
+		{Peer{cla.TCPCLv4, bpv7.DtnNone(), "10.0.0.2"}, Announcement{}, "", false},
+		{Peer{cla.TCPCLv4, bpv7.DtnNone(), "10.0.0.2:foo"}, Announcement{}, "", false},
+	}
+
+	for _, test := range tests {
+		announcement, host, err := test.peer.announcement()
+		if (err == nil) != test.valid {
+			t.Fatalf("%v: expected valid %t, got %v", test.peer, test.valid, err)
+		} else if !test.valid {
+			continue
+		}
+
+		if !reflect.DeepEqual(announcement, test.announcement) {
+			t.Fatalf("%v: expected %v, got %v", test.peer, test.announcement, announcement)
+		} else if host != test.host {
+			t.Fatalf("%v: expected host %s, got %s", test.peer, test.host, host)
+		}
+	}
+}
+
+func TestStaticPeerProbe(t *testing.T) {
+	listener, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	peer := Peer{cla.TCPCLv4, bpv7.DtnNone(), listener.Addr().String()}
+	if !peer.probe() {
+		t.Fatalf("%v is not reachable", peer)
+	}
+
+	_ = listener.Close()
+	if peer.probe() {
+		t.Fatalf("%v is still reachable", peer)
+	}
+}
+
+func TestStaticProberEvents(t *testing.T) {
+	var (
+		registrations   []cla.Convergable
+		unregistrations []cla.Convergable
+		events          []PeerEvent
+	)
+
+	manager := &Manager{
+		NodeId:         bpv7.MustNewEndpointID("dtn://self/"),
+		RegisterFunc:   func(c cla.Convergable) { registrations = append(registrations, c) },
+		eventFunc:      func(e PeerEvent) { events = append(events, e) },
+		unregisterFunc: func(c cla.Convergable) { unregistrations = append(unregistrations, c) },
+	}
+
+	peers := []Peer{
+		{cla.TCPCLv4, bpv7.DtnNone(), "10.0.0.2:4556"},
+		{cla.MTCP, bpv7.MustNewEndpointID("dtn://peer/"), "10.0.0.3:35037"},
+	}
+	reachable := map[string]bool{}
+
+	prober := &staticProber{
+		manager:   manager,
+		peers:     peers,
+		reachable: make(map[int]bool),
+		probeFunc: func(peer Peer) bool { return reachable[peer.Address] },
+	}
+
+	steps := []struct {
+		reachable     map[string]bool
+		events        []PeerEvent
+		registrations int
+	}{
+		{map[string]bool{}, nil, 0},
+		{map[string]bool{"10.0.0.2:4556": true}, []PeerEvent{{Type: PeerAppeared, Peer: peers[0]}}, 1},
+		{map[string]bool{"10.0.0.2:4556": true}, nil, 1},
+		{
+			map[string]bool{"10.0.0.3:35037": true},
+			[]PeerEvent{{Type: PeerDisappeared, Peer: peers[0]}, {Type: PeerAppeared, Peer: peers[1]}}, 2,
+		},
+	}
+
+	for i, step := range steps {
+		reachable = step.reachable
+		events = nil
+
+		prober.probeAll()
+
+		// Each event carries the CLA registered for its peer.
+		var convergables []cla.Convergable
+		for j := range events {
+			convergables = append(convergables, events[j].Convergable)
+			events[j].Convergable = nil
+		}
+
+		if !reflect.DeepEqual(events, step.events) {
+			t.Fatalf("step %d: expected events %v, got %v", i, step.events, events)
+		} else if len(registrations) != step.registrations {
+			t.Fatalf("step %d: expected %d registrations, got %d", i, step.registrations, len(registrations))
+		}
+
+		for j, event := range events {
+			if event.Type == PeerAppeared && convergables[j] != registrations[len(registrations)-1] {
+				t.Fatalf("step %d: appeared peer's event has CLA %v", i, convergables[j])
+			} else if event.Type == PeerDisappeared && convergables[j] != registrations[0] {
+				t.Fatalf("step %d: disappeared peer's event has CLA %v", i, convergables[j])
+			}
+		}
+	}
+
+	// The unreachable peer's CLA was unregistered.
+	if len(unregistrations) != 1 || unregistrations[0] != registrations[0] {
+		t.Fatalf("expected the first CLA to be unregistered, got %v", unregistrations)
+	}
+}
@@ -0,0 +1,12 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+// Package energy monitors a node's battery level to switch between a normal and a low power mode, e.g., for solar
+// powered relays.
+//
+// A Monitor periodically reads the battery's charge from a LevelSource, like the capacity file of a Linux power supply
+// "/sys/class/power_supply/BAT0/capacity". If the level drops to a low threshold, the Monitor enters the LowPower Mode
+// and notifies its callback, which might, e.g., reduce the discovery's beacon rate or pause CLAs. The Normal Mode is
+// only resumed after the level recovered to a higher threshold, preventing oscillating modes around a single one.
+package energy
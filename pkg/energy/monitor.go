@@ -0,0 +1,147 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package energy
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Mode of a node's power consumption.
+type Mode int
+
+const (
+	// Normal Mode without any restrictions.
+	Normal Mode = iota
+
+	// LowPower Mode, in which non-essential energy consumers should be restricted.
+	LowPower
+)
+
+func (m Mode) String() string {
+	switch m {
+	case Normal:
+		return "normal"
+	case LowPower:
+		return "low power"
+	default:
+		return "unknown"
+	}
+}
+
+// Monitor switches between the Normal and the LowPower Mode based on the battery level of its LevelSource.
+type Monitor struct {
+	source     LevelSource
+	low        float64
+	resume     float64
+	changeFunc func(Mode)
+
+	// runMutex serializes checks and thus the changeFunc's calls.
+	runMutex sync.Mutex
+
+	mutex sync.Mutex
+	mode  Mode
+	level float64
+
+	stopChan  chan struct{}
+	closeOnce sync.Once
+}
+
+// NewMonitor for a LevelSource, entering the LowPower Mode at a battery level of low percent and resuming the Normal
+// Mode at resume percent. The changeFunc is called for each Mode change. The Monitor starts in the Normal Mode and
+// must be started by Start or checked manually by Check.
+func NewMonitor(source LevelSource, low, resume float64, changeFunc func(Mode)) (*Monitor, error) {
+	if low < 0 || resume > 100 || low > resume {
+		return nil, fmt.Errorf("thresholds of %v%% for a low and %v%% for resuming are invalid", low, resume)
+	}
+
+	return &Monitor{
+		source:     source,
+		low:        low,
+		resume:     resume,
+		changeFunc: changeFunc,
+		mode:       Normal,
+		level:      100,
+		stopChan:   make(chan struct{}),
+	}, nil
+}
+
+// Check the battery level and change the Mode if a threshold was crossed. If the level cannot be read, the current
+// Mode is kept.
+func (m *Monitor) Check() (Mode, error) {
+	m.runMutex.Lock()
+	defer m.runMutex.Unlock()
+
+	level, err := m.source.Level()
+	if err != nil {
+		return m.Mode(), err
+	}
+
+	m.mutex.Lock()
+	old := m.mode
+	switch {
+	case m.mode == Normal && level <= m.low:
+		m.mode = LowPower
+	case m.mode == LowPower && level >= m.resume:
+		m.mode = Normal
+	}
+	mode := m.mode
+	m.level = level
+	m.mutex.Unlock()
+
+	if mode != old {
+		log.WithFields(log.Fields{
+			"battery": level,
+			"mode":    mode,
+		}).Info("Battery level changed the energy mode")
+
+		if m.changeFunc != nil {
+			m.changeFunc(mode)
+		}
+	}
+	return mode, nil
+}
+
+// Start checking the battery level immediately and within each interval until the Monitor is closed. Failed checks
+// are logged.
+func (m *Monitor) Start(interval time.Duration) {
+	go func() {
+		for {
+			if _, err := m.Check(); err != nil {
+				log.WithError(err).Warn("Reading battery level erred")
+			}
+
+			select {
+			case <-m.stopChan:
+				return
+			case <-time.After(interval):
+			}
+		}
+	}()
+}
+
+// Mode currently active.
+func (m *Monitor) Mode() Mode {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return m.mode
+}
+
+// Level of the battery in percent, as read by the last successful check.
+func (m *Monitor) Level() float64 {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return m.level
+}
+
+// Close this Monitor, stopping its periodic checks.
+func (m *Monitor) Close() {
+	m.closeOnce.Do(func() { close(m.stopChan) })
+}
@@ -0,0 +1,125 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package energy
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// levels is a LevelSource returning its levels in order, or an error for a negative one.
+type levels []float64
+
+func (l *levels) Level() (float64, error) {
+	level := (*l)[0]
+	*l = (*l)[1:]
+
+	if level < 0 {
+		return 0, fmt.Errorf("oops")
+	}
+	return level, nil
+}
+
+func TestMonitorCheck(t *testing.T) {
+	source := &levels{80, 25, 20, -1, 25, 29, 30, 21}
+	expected := []Mode{Normal, Normal, LowPower, LowPower, LowPower, LowPower, Normal, Normal}
+
+	var changes []Mode
+	m, err := NewMonitor(source, 20, 30, func(mode Mode) { changes = append(changes, mode) })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, mode := range expected {
+		if checked, err := m.Check(); (err != nil) != (i == 3) {
+			t.Fatalf("check %d: unexpected error %v", i, err)
+		} else if checked != mode || m.Mode() != mode {
+			t.Fatalf("check %d: expected %v, got %v", i, mode, checked)
+		}
+	}
+
+	if len(changes) != 2 || changes[0] != LowPower || changes[1] != Normal {
+		t.Fatalf("unexpected mode changes %v", changes)
+	}
+	if m.Level() != 21 {
+		t.Fatalf("last level is %v", m.Level())
+	}
+}
+
+func TestMonitorStart(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "capacity")
+	if err := os.WriteFile(file, []byte("15\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	changes := make(chan Mode, 2)
+	m, err := NewMonitor(FileSource(file), 20, 30, func(mode Mode) { changes <- mode })
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.Start(10 * time.Millisecond)
+	defer m.Close()
+
+	for _, content := range []string{"", "95"} {
+		if content != "" {
+			if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		select {
+		case mode := <-changes:
+			if (content == "") != (mode == LowPower) {
+				t.Fatalf("unexpected mode %v", mode)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("mode did not change")
+		}
+	}
+}
+
+func TestNewMonitorInvalid(t *testing.T) {
+	for _, thresholds := range [][2]float64{{-1, 30}, {20, 101}, {40, 30}} {
+		if _, err := NewMonitor(FileSource(""), thresholds[0], thresholds[1], nil); err == nil {
+			t.Fatalf("thresholds %v were accepted", thresholds)
+		}
+	}
+}
+
+func TestFileSource(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		content string
+		level   float64
+		valid   bool
+	}{
+		{"42\n", 42, true},
+		{"87.5", 87.5, true},
+		{"", 0, false},
+		{"full", 0, false},
+		{"120", 0, false},
+	}
+
+	for i, test := range tests {
+		file := filepath.Join(dir, fmt.Sprintf("capacity%d", i))
+		if err := os.WriteFile(file, []byte(test.content), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		if level, err := FileSource(file).Level(); (err == nil) != test.valid {
+			t.Fatalf("content %q: unexpected error %v", test.content, err)
+		} else if level != test.level {
+			t.Fatalf("content %q: expected %v, got %v", test.content, test.level, level)
+		}
+	}
+
+	if _, err := FileSource(filepath.Join(dir, "missing")).Level(); err == nil {
+		t.Fatal("missing file was read")
+	}
+}
@@ -0,0 +1,37 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package energy
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// LevelSource reports a battery's charge in percent, from 0 to 100.
+type LevelSource interface {
+	Level() (float64, error)
+}
+
+// FileSource reads the battery level from a file containing only a number in percent, like the capacity file of a
+// Linux power supply. Other sources, e.g., a solar charge controller, might write their level to such a file.
+type FileSource string
+
+// Level reads and parses the file's content.
+func (fs FileSource) Level() (float64, error) {
+	data, err := os.ReadFile(string(fs))
+	if err != nil {
+		return 0, err
+	}
+
+	level, err := strconv.ParseFloat(strings.TrimSpace(string(data)), 64)
+	if err != nil {
+		return 0, fmt.Errorf("battery level file %s is invalid: %w", fs, err)
+	} else if level < 0 || level > 100 {
+		return 0, fmt.Errorf("battery level %v of file %s is not within 0 and 100", level, fs)
+	}
+	return level, nil
+}
@@ -0,0 +1,81 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+// Package bufpool provides reusable buffers for the serialization of bundles, backed by sync.Pools. Allocating fresh
+// buffers for each transmitted, received, or stored bundle otherwise results in a high garbage collection load on
+// busy nodes.
+//
+// Buffers must not be used after they were put back, including slices returned by their Bytes methods.
+package bufpool
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"sync"
+
+	"github.com/gorilla/websocket"
+)
+
+// maxPooledSize limits the capacity of pooled buffers. Larger buffers are dropped, as a single large bundle would
+// otherwise pin its memory within the pool.
+const maxPooledSize = 1 << 20
+
+var buffers = sync.Pool{New: func() interface{} { return new(bytes.Buffer) }}
+
+// GetBuffer returns an empty buffer, which should be put back by PutBuffer.
+func GetBuffer() *bytes.Buffer {
+	buf := buffers.Get().(*bytes.Buffer)
+	buf.Reset()
+	return buf
+}
+
+// PutBuffer back into the pool.
+func PutBuffer(buf *bytes.Buffer) {
+	if buf.Cap() > maxPooledSize {
+		return
+	}
+	buffers.Put(buf)
+}
+
+var writers = sync.Pool{New: func() interface{} { return bufio.NewWriter(nil) }}
+
+// GetWriter returns a buffered writer for w, which should be flushed and put back by PutWriter.
+func GetWriter(w io.Writer) *bufio.Writer {
+	bw := writers.Get().(*bufio.Writer)
+	bw.Reset(w)
+	return bw
+}
+
+// PutWriter back into the pool. Unflushed data is discarded.
+func PutWriter(bw *bufio.Writer) {
+	bw.Reset(nil)
+	writers.Put(bw)
+}
+
+var readers = sync.Pool{New: func() interface{} { return bufio.NewReader(nil) }}
+
+// GetReader returns a buffered reader for r, which should be put back by PutReader.
+func GetReader(r io.Reader) *bufio.Reader {
+	br := readers.Get().(*bufio.Reader)
+	br.Reset(r)
+	return br
+}
+
+// PutReader back into the pool. Buffered but unread data is discarded.
+func PutReader(br *bufio.Reader) {
+	br.Reset(nil)
+	readers.Put(br)
+}
+
+// WebSocketWriteBuffers is a websocket.BufferPool for the write buffers of gorilla's Upgrader and Dialer. All users
+// must stick to the default buffer sizes, as gorilla expects all buffers within one pool to be of the same size.
+var WebSocketWriteBuffers = &sync.Pool{}
+
+// WebSocketDialer returns a copy of websocket.DefaultDialer, using the WebSocketWriteBuffers.
+func WebSocketDialer() *websocket.Dialer {
+	dialer := *websocket.DefaultDialer
+	dialer.WriteBufferPool = WebSocketWriteBuffers
+	return &dialer
+}
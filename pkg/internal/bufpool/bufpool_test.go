@@ -0,0 +1,64 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package bufpool
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestBuffer(t *testing.T) {
+	for i := 0; i < 3; i++ {
+		buf := GetBuffer()
+		if buf.Len() != 0 {
+			t.Fatalf("buffer is not empty: %q", buf.String())
+		}
+		buf.WriteString("hello world")
+		PutBuffer(buf)
+	}
+
+	// Oversized buffers are not pooled, but putting them back must be safe.
+	large := GetBuffer()
+	large.Grow(2 * maxPooledSize)
+	PutBuffer(large)
+}
+
+func TestWriter(t *testing.T) {
+	var first, second bytes.Buffer
+
+	bw := GetWriter(&first)
+	_, _ = bw.WriteString("unflushed")
+	PutWriter(bw)
+
+	bw = GetWriter(&second)
+	_, _ = bw.WriteString("flushed")
+	if err := bw.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	PutWriter(bw)
+
+	if first.Len() != 0 || second.String() != "flushed" {
+		t.Fatalf("unexpected writes %q and %q", first.String(), second.String())
+	}
+}
+
+func TestReader(t *testing.T) {
+	br := GetReader(strings.NewReader("first"))
+	if _, err := br.ReadByte(); err != nil {
+		t.Fatal(err)
+	}
+	PutReader(br)
+
+	br = GetReader(strings.NewReader("second"))
+	defer PutReader(br)
+
+	if data, err := io.ReadAll(br); err != nil {
+		t.Fatal(err)
+	} else if string(data) != "second" {
+		t.Fatalf("expected %q, got %q", "second", data)
+	}
+}
@@ -0,0 +1,65 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package node
+
+import (
+	log "github.com/sirupsen/logrus"
+
+	"github.com/dtn7/dtn7-go/pkg/agent"
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+// handlerAgent is an ApplicationAgent passing the bundles for its endpoint to a Handler.
+type handlerAgent struct {
+	endpoint bpv7.EndpointID
+	handler  Handler
+	receiver chan agent.Message
+	sender   chan agent.Message
+}
+
+func newHandlerAgent(endpoint bpv7.EndpointID, handler Handler) *handlerAgent {
+	ha := &handlerAgent{
+		endpoint: endpoint,
+		handler:  handler,
+		receiver: make(chan agent.Message),
+		sender:   make(chan agent.Message),
+	}
+
+	go ha.handle()
+
+	return ha
+}
+
+func (ha *handlerAgent) handle() {
+	defer close(ha.sender)
+
+	for m := range ha.receiver {
+		switch m := m.(type) {
+		case agent.BundleMessage:
+			ha.handler(m.Bundle)
+
+		case agent.ShutdownMessage:
+			return
+
+		default:
+			log.WithFields(log.Fields{
+				"endpoint": ha.endpoint,
+				"message":  m,
+			}).Debug("Handler received unsupported Message")
+		}
+	}
+}
+
+func (ha *handlerAgent) Endpoints() []bpv7.EndpointID {
+	return []bpv7.EndpointID{ha.endpoint}
+}
+
+func (ha *handlerAgent) MessageReceiver() chan agent.Message {
+	return ha.receiver
+}
+
+func (ha *handlerAgent) MessageSender() chan agent.Message {
+	return ha.sender
+}
@@ -0,0 +1,231 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+// Package node embeds a full DTN node into Go applications, as an alternative to running dtnd.
+//
+// A Node is created by NewNode from a Config, started, and finally stopped. Local applications send bundles by Send
+// and receive bundles for their endpoints by handlers, registered by Handle. Further settings of the underlying
+// routing.Core can be applied through Config's Configure function or the Core method.
+package node
+
+import (
+	"crypto/ed25519"
+	"fmt"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+	"github.com/dtn7/dtn7-go/pkg/cla"
+	"github.com/dtn7/dtn7-go/pkg/cla/mtcp"
+	"github.com/dtn7/dtn7-go/pkg/cla/tcpclv4"
+	"github.com/dtn7/dtn7-go/pkg/routing"
+)
+
+// Convergence describes a convergence layer to listen on or a peer to connect to.
+type Convergence struct {
+	// Protocol of this convergence layer, "mtcp" or "tcpclv4".
+	Protocol string
+
+	// Endpoint is the address to listen on or the peer's address, e.g., "localhost:4556".
+	Endpoint string
+
+	// Node is the peer's Node ID, which is required for MTCP peers.
+	Node string
+}
+
+// Config of a Node.
+type Config struct {
+	// NodeId is this node's singleton Endpoint ID, e.g., "dtn://foo/".
+	NodeId string
+
+	// Store is the directory of the bundle store.
+	Store string
+
+	// Routing selects the routing algorithm, epidemic routing by default.
+	Routing routing.RoutingConf
+
+	// SignPriv is an optional ed25519 private key to sign outgoing administrative records.
+	SignPriv ed25519.PrivateKey
+
+	// Listen and Peers are the convergence layers to listen on and the peers to connect to.
+	Listen []Convergence
+	Peers  []Convergence
+
+	// ShutdownTimeout limits the draining of transfers and bundles on Stop, ten seconds by default.
+	ShutdownTimeout time.Duration
+
+	// Configure is an optional function to apply further settings to the Core before it is started.
+	Configure func(*routing.Core) error
+}
+
+// Handler receives the bundles delivered to an endpoint. Handlers are called sequentially for each endpoint and
+// should return quickly.
+type Handler func(bpv7.Bundle)
+
+// Node is an embeddable DTN node.
+type Node struct {
+	mutex sync.Mutex
+
+	conf   Config
+	nodeId bpv7.EndpointID
+	core   *routing.Core
+
+	handlers map[bpv7.EndpointID]Handler
+}
+
+// NewNode validates a Config and creates a Node, which needs to be started.
+func NewNode(conf Config) (*Node, error) {
+	nodeId, err := bpv7.NewEndpointID(conf.NodeId)
+	if err != nil {
+		return nil, err
+	} else if !nodeId.IsSingleton() {
+		return nil, fmt.Errorf("node ID %v is not a singleton", nodeId)
+	}
+
+	if conf.Store == "" {
+		return nil, fmt.Errorf("store is empty")
+	}
+	if conf.Routing.Algorithm == "" {
+		conf.Routing.Algorithm = "epidemic"
+	}
+	if conf.ShutdownTimeout == 0 {
+		conf.ShutdownTimeout = 10 * time.Second
+	}
+
+	for _, conv := range append(append([]Convergence(nil), conf.Listen...), conf.Peers...) {
+		if conv.Protocol != "mtcp" && conv.Protocol != "tcpclv4" {
+			return nil, fmt.Errorf("unknown protocol %q", conv.Protocol)
+		}
+	}
+	for _, conv := range conf.Peers {
+		if conv.Protocol != "mtcp" {
+			continue
+		}
+		if _, err := bpv7.NewEndpointID(conv.Node); err != nil {
+			return nil, fmt.Errorf("MTCP peer %s requires a node ID: %v", conv.Endpoint, err)
+		}
+	}
+
+	return &Node{
+		conf:     conf,
+		nodeId:   nodeId,
+		handlers: make(map[bpv7.EndpointID]Handler),
+	}, nil
+}
+
+// NodeId returns this Node's Node ID.
+func (n *Node) NodeId() bpv7.EndpointID {
+	return n.nodeId
+}
+
+// Core returns the underlying routing.Core of a started Node or nil.
+func (n *Node) Core() *routing.Core {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	return n.core
+}
+
+// Start this Node by creating its Core, registering its handlers, and starting its convergence layers.
+func (n *Node) Start() error {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	if n.core != nil {
+		return fmt.Errorf("node is already started")
+	}
+
+	c, err := routing.NewCore(n.conf.Store, n.nodeId, false, n.conf.Routing, n.conf.SignPriv)
+	if err != nil {
+		return err
+	}
+
+	if n.conf.Configure != nil {
+		if err := n.conf.Configure(c); err != nil {
+			c.Close()
+			return err
+		}
+	}
+
+	for endpoint, handler := range n.handlers {
+		c.RegisterApplicationAgent(newHandlerAgent(endpoint, handler))
+	}
+
+	for _, conv := range n.conf.Listen {
+		switch conv.Protocol {
+		case "mtcp":
+			c.RegisterCLA(mtcp.NewMTCPServer(conv.Endpoint, n.nodeId, true), cla.MTCP, n.nodeId)
+		case "tcpclv4":
+			c.RegisterCLA(tcpclv4.ListenTCP(conv.Endpoint, n.nodeId), cla.TCPCLv4, n.nodeId)
+		}
+	}
+
+	for _, conv := range n.conf.Peers {
+		switch conv.Protocol {
+		case "mtcp":
+			c.RegisterConvergable(mtcp.NewMTCPClient(conv.Endpoint, bpv7.MustNewEndpointID(conv.Node), true))
+		case "tcpclv4":
+			c.RegisterConvergable(tcpclv4.DialTCP(conv.Endpoint, n.nodeId, true))
+		}
+	}
+
+	n.core = c
+
+	log.WithField("node", n.nodeId).Info("Started embedded node")
+	return nil
+}
+
+// Stop this Node gracefully within the Config's ShutdownTimeout. A stopped Node might be started again.
+func (n *Node) Stop() {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	if n.core == nil {
+		return
+	}
+
+	n.core.Shutdown(n.conf.ShutdownTimeout)
+	n.core = nil
+
+	log.WithField("node", n.nodeId).Info("Stopped embedded node")
+}
+
+// Send a bundle from a started Node, e.g., created by bpv7.Builder.
+func (n *Node) Send(bndl bpv7.Bundle) error {
+	c := n.Core()
+	if c == nil {
+		return fmt.Errorf("node is not started")
+	}
+
+	if err := bndl.CheckValid(); err != nil {
+		return err
+	}
+
+	c.SendBundle(&bndl)
+	return nil
+}
+
+// Handle registers a Handler for all bundles delivered to an endpoint. Endpoints ending with "/*" are patterns,
+// matching all endpoints below this path. Each endpoint can only be registered once.
+func (n *Node) Handle(endpoint string, handler Handler) error {
+	eid, err := bpv7.NewEndpointID(endpoint)
+	if err != nil {
+		return err
+	}
+
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	if _, exists := n.handlers[eid]; exists {
+		return fmt.Errorf("endpoint %v already has a handler", eid)
+	}
+	n.handlers[eid] = handler
+
+	if n.core != nil {
+		n.core.RegisterApplicationAgent(newHandlerAgent(eid, handler))
+	}
+	return nil
+}
@@ -0,0 +1,182 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package node
+
+import (
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+func TestNewNode(t *testing.T) {
+	tests := []struct {
+		conf  Config
+		valid bool
+	}{
+		{Config{NodeId: "dtn://a/", Store: t.TempDir()}, true},
+		{Config{NodeId: "dtn://a/", Store: ""}, false},
+		{Config{NodeId: "dtn://group/~all", Store: t.TempDir()}, false},
+		{Config{NodeId: "dtn://a/", Store: t.TempDir(), Listen: []Convergence{{Protocol: "quic"}}}, false},
+		{Config{NodeId: "dtn://a/", Store: t.TempDir(), Peers: []Convergence{{Protocol: "mtcp"}}}, false},
+	}
+
+	for _, test := range tests {
+		if _, err := NewNode(test.conf); (err == nil) != test.valid {
+			t.Fatalf("config %v: expected valid %t, got %v", test.conf, test.valid, err)
+		}
+	}
+}
+
+// loopbackNodes creates two unstarted Nodes, where node B connects to node A via MTCP on the loopback interface.
+func loopbackNodes(tb testing.TB) (nodeA, nodeB *Node) {
+	l, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		tb.Fatal(err)
+	}
+	addr := l.Addr().String()
+	if err := l.Close(); err != nil {
+		tb.Fatal(err)
+	}
+
+	nodeA, err = NewNode(Config{
+		NodeId:          "dtn://a/",
+		Store:           tb.TempDir(),
+		Listen:          []Convergence{{Protocol: "mtcp", Endpoint: addr}},
+		ShutdownTimeout: time.Second,
+	})
+	if err != nil {
+		tb.Fatal(err)
+	}
+
+	nodeB, err = NewNode(Config{
+		NodeId:          "dtn://b/",
+		Store:           tb.TempDir(),
+		Peers:           []Convergence{{Protocol: "mtcp", Endpoint: addr, Node: "dtn://a/"}},
+		ShutdownTimeout: time.Second,
+	})
+	if err != nil {
+		tb.Fatal(err)
+	}
+
+	return
+}
+
+// waitConnected until node B has connected to node A.
+func waitConnected(tb testing.TB, nodeB *Node) {
+	for deadline := time.Now().Add(5 * time.Second); len(nodeB.Core().ConnectedPeers()) == 0; {
+		if time.Now().After(deadline) {
+			tb.Fatal("node B did not connect to node A")
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+func TestNodes(t *testing.T) {
+	nodeA, nodeB := loopbackNodes(t)
+
+	received := make(chan bpv7.Bundle, 1)
+	if err := nodeA.Handle("dtn://a/inbox", func(b bpv7.Bundle) { received <- b }); err != nil {
+		t.Fatal(err)
+	}
+	if err := nodeA.Handle("dtn://a/inbox", func(bpv7.Bundle) {}); err == nil {
+		t.Fatal("endpoint was registered twice")
+	}
+
+	for _, n := range []*Node{nodeA, nodeB} {
+		if err := n.Start(); err != nil {
+			t.Fatal(err)
+		}
+		defer n.Stop()
+	}
+	if err := nodeA.Start(); err == nil {
+		t.Fatal("node was started twice")
+	}
+
+	waitConnected(t, nodeB)
+
+	bndl, err := bpv7.Builder().
+		Source("dtn://b/outbox").
+		Destination("dtn://a/inbox").
+		CreationTimestampNow().
+		Lifetime("10m").
+		PayloadBlock([]byte("hello node")).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := nodeB.Send(bndl); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case b := <-received:
+		if b.ID() != bndl.ID() {
+			t.Fatalf("received %v, expected %v", b.ID(), bndl.ID())
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("node A did not receive the bundle")
+	}
+}
+
+func BenchmarkNodesLoopback(b *testing.B) {
+	level := log.GetLevel()
+	log.SetLevel(log.WarnLevel)
+	defer log.SetLevel(level)
+
+	for _, size := range []int{64, 65536} {
+		b.Run(fmt.Sprintf("%d", size), func(b *testing.B) {
+			nodeA, nodeB := loopbackNodes(b)
+
+			received := make(chan struct{}, b.N)
+			if err := nodeA.Handle("dtn://a/inbox", func(bpv7.Bundle) { received <- struct{}{} }); err != nil {
+				b.Fatal(err)
+			}
+
+			for _, n := range []*Node{nodeA, nodeB} {
+				if err := n.Start(); err != nil {
+					b.Fatal(err)
+				}
+				defer n.Stop()
+			}
+			waitConnected(b, nodeB)
+
+			payload := make([]byte, size)
+
+			b.SetBytes(int64(size))
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				bndl, err := bpv7.Builder().
+					Source("dtn://b/outbox").
+					Destination("dtn://a/inbox").
+					CreationTimestampNow().
+					Lifetime("10m").
+					PayloadBlock(payload).
+					Build()
+				if err != nil {
+					b.Fatal(err)
+				}
+				// Bundles created within the same millisecond are distinguished by their sequence number.
+				bndl.PrimaryBlock.CreationTimestamp[1] = uint64(i)
+
+				if err := nodeB.Send(bndl); err != nil {
+					b.Fatal(err)
+				}
+			}
+
+			for i := 0; i < b.N; i++ {
+				select {
+				case <-received:
+				case <-time.After(10 * time.Second):
+					b.Fatalf("node A received only %d of %d bundles", i, b.N)
+				}
+			}
+		})
+	}
+}
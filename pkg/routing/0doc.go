@@ -5,5 +5,9 @@
 // Package routing represents a bundle node's router and contains both the bundle
 // protocol agent and application agent.
 //
+// Multiple Cores may run within one process, each with its own Cron, store, CLAs
+// and routing Algorithm. Only the bpv7 package's ExtensionBlockManager, a
+// registry of block types, and its clock offset are shared process-wide.
+//
 // Here be dragons.
 package routing
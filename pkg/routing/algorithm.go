@@ -8,6 +8,7 @@ package routing
 import (
 	"fmt"
 	"regexp"
+	"time"
 
 	"github.com/dtn7/dtn7-go/pkg/bpv7"
 	"github.com/dtn7/dtn7-go/pkg/cla"
@@ -47,11 +48,26 @@ type Algorithm interface {
 	ReportPeerDisappeared(peer cla.Convergence)
 }
 
+// NeighborGossipReceiver is an optional interface for an Algorithm to receive the neighbors of a peer, as announced
+// by the peer discovery's second-hop gossip. This gives an Algorithm a warm start before any metadata bundles are
+// exchanged. Metadata from bundles should take precedence over gossiped neighbors.
+type NeighborGossipReceiver interface {
+	// ReportPeerNeighbors notifies the Algorithm about a peer's currently connected neighbors.
+	ReportPeerNeighbors(peer bpv7.EndpointID, neighbors []bpv7.EndpointID)
+}
+
+// ReconfigurableAlgorithm is an optional interface for an Algorithm to apply a changed configuration of the same
+// algorithm at runtime, e.g., rescheduling its Cron jobs, while keeping its state.
+type ReconfigurableAlgorithm interface {
+	// Reconfigure applies the configuration. On an error, the previous configuration stays in effect.
+	Reconfigure(routingConf RoutingConf) error
+}
+
 // RoutingConf contains necessary configuration data to initialize a routing algorithm.
 type RoutingConf struct {
 	// Algorithm is one of the implemented routing algorithms.
 	//
-	// One of: "epidemic", "spray", "binary_spray", "dtlsr", "prophet", "sensor-mule"
+	// One of: "epidemic", "spray", "binary_spray", "dtlsr", "prophet", "sensor-mule", "external"
 	Algorithm string
 
 	// SprayConf contains data to initialize "spray" or "binary_spray"
@@ -65,6 +81,9 @@ type RoutingConf struct {
 
 	// SensorNetworkMuleConfig contains data to initialize "sensor-mule"
 	SensorMuleConf SensorNetworkMuleConfig `toml:"sensor-mule-conf"`
+
+	// ExternalConf contains data to initialize "external"
+	ExternalConf ExternalConfig
 }
 
 // RoutingAlgorithm from its configuration.
@@ -94,6 +113,9 @@ func (routingConf RoutingConf) RoutingAlgorithm(c *Core) (algo Algorithm, err er
 			algo = NewSensorNetworkMuleRouting(muleAlgo, sensorNode)
 		}
 
+	case "external":
+		algo, err = NewExternalRouting(c, routingConf.ExternalConf)
+
 	default:
 		err = fmt.Errorf("unknown routing algorithm %s", routingConf.Algorithm)
 	}
@@ -101,6 +123,60 @@ func (routingConf RoutingConf) RoutingAlgorithm(c *Core) (algo Algorithm, err er
 	return
 }
 
+// Validate this configuration without creating the routing algorithm, e.g., an unknown algorithm or an unparsable
+// duration, which would otherwise stop the creation of a Core.
+func (routingConf RoutingConf) Validate() error {
+	switch routingConf.Algorithm {
+	case "epidemic":
+		return nil
+
+	case "spray", "binary_spray":
+		if routingConf.SprayConf.Multiplicity == 0 {
+			return fmt.Errorf("%s requires a multiplicity", routingConf.Algorithm)
+		}
+		return nil
+
+	case "dtlsr":
+		for _, interval := range []struct{ name, value string }{
+			{"recompute time", routingConf.DTLSRConf.RecomputeTime},
+			{"broadcast time", routingConf.DTLSRConf.BroadcastTime},
+			{"purge time", routingConf.DTLSRConf.PurgeTime},
+		} {
+			if _, err := time.ParseDuration(interval.value); err != nil {
+				return fmt.Errorf("dtlsr %s: %v", interval.name, err)
+			}
+		}
+		return nil
+
+	case "prophet":
+		if _, err := time.ParseDuration(routingConf.ProphetConf.AgeInterval); err != nil {
+			return fmt.Errorf("prophet age interval: %v", err)
+		}
+		return nil
+
+	case "sensor-mule":
+		if routingConf.SensorMuleConf.Algorithm == nil {
+			return fmt.Errorf("sensor-mule requires an underlying routing algorithm")
+		} else if err := routingConf.SensorMuleConf.Algorithm.Validate(); err != nil {
+			return fmt.Errorf("sensor-mule: %v", err)
+		} else if _, err := regexp.Compile(routingConf.SensorMuleConf.SensorNodeRegex); err != nil {
+			return fmt.Errorf("sensor-mule sensor node regex: %v", err)
+		}
+		return nil
+
+	case "external":
+		if routingConf.ExternalConf.Address == "" {
+			return fmt.Errorf("external requires an address")
+		} else if _, err := routingConf.ExternalConf.timeout(); err != nil {
+			return fmt.Errorf("external timeout: %v", err)
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("unknown routing algorithm %s", routingConf.Algorithm)
+	}
+}
+
 // sendMetadataBundle can be used by routing algorithm to send relevant metadata to peers
 // Metadata needs to be serialised as an ExtensionBlock
 func sendMetadataBundle(c *Core, source bpv7.EndpointID, destination bpv7.EndpointID, metadataBlock bpv7.ExtensionBlock) error {
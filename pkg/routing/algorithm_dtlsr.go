@@ -6,12 +6,15 @@
 package routing
 
 import (
+	"bytes"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	log "github.com/sirupsen/logrus"
 
 	"github.com/RyanCarrier/dijkstra"
+	"github.com/dtn7/cboring"
 
 	"github.com/dtn7/dtn7-go/pkg/bpv7"
 	"github.com/dtn7/dtn7-go/pkg/cla"
@@ -35,8 +38,11 @@ type DTLSRConfig struct {
 // DTLSR is an implementation of "Delay Tolerant Link State Routing"
 type DTLSR struct {
 	c *Core
-	// routingTable is a [endpoint]forwardingNode mapping
-	routingTable map[bpv7.EndpointID]bpv7.EndpointID
+	// routingTable holds an immutable map[bpv7.EndpointID]bpv7.EndpointID of each endpoint's forwarding node. It is
+	// swapped as a whole after each recomputation, so that forwarding never waits for a recomputation.
+	routingTable atomic.Value
+	// recomputing is set while a recomputation is running in the background
+	recomputing int32
 	// peerChange denotes whether there has been a change in our direct connections
 	// since we last calculated our routing table/broadcast our peer data
 	peerChange bool
@@ -70,6 +76,7 @@ func NewDTLSR(c *Core, config DTLSRConfig) *DTLSR {
 			"dtlsrBroadcastAddress": dtlsrBroadcastAddress,
 		}).Fatal("Unable to parse broadcast address")
 	}
+	c.RegisterBroadcastEndpoint(bAddress)
 
 	purgeTime, err := time.ParseDuration(config.PurgeTime)
 	if err != nil {
@@ -79,9 +86,8 @@ func NewDTLSR(c *Core, config DTLSRConfig) *DTLSR {
 	}
 
 	dtlsr := DTLSR{
-		c:            c,
-		routingTable: make(map[bpv7.EndpointID]bpv7.EndpointID),
-		peerChange:   false,
+		c:          c,
+		peerChange: false,
 		peers: bpv7.DTLSRPeerData{
 			ID:        c.NodeId,
 			Timestamp: bpv7.DtnTimeNow(),
@@ -95,8 +101,9 @@ func NewDTLSR(c *Core, config DTLSRConfig) *DTLSR {
 		broadcastAddress: bAddress,
 		purgeTime:        purgeTime,
 	}
+	dtlsr.routingTable.Store(make(map[bpv7.EndpointID]bpv7.EndpointID))
 
-	err = c.Cron.Register("dtlsr_purge", dtlsr.purgePeers, purgeTime)
+	err = c.registerRoutingCron("dtlsr_purge", dtlsr.purgePeers, purgeTime)
 	if err != nil {
 		log.WithFields(log.Fields{
 			"reason": err.Error(),
@@ -110,7 +117,7 @@ func NewDTLSR(c *Core, config DTLSRConfig) *DTLSR {
 		}).Fatal("Unable to parse duration")
 	}
 
-	err = c.Cron.Register("dtlsr_recompute", dtlsr.recomputeCron, recomputeTime)
+	err = c.registerRoutingCron("dtlsr_recompute", dtlsr.recomputeCron, recomputeTime)
 	if err != nil {
 		log.WithFields(log.Fields{
 			"reason": err.Error(),
@@ -124,7 +131,7 @@ func NewDTLSR(c *Core, config DTLSRConfig) *DTLSR {
 		}).Fatal("Unable to parse duration")
 	}
 
-	err = c.Cron.Register("dtlsr_broadcast", dtlsr.broadcastCron, broadcastTime)
+	err = c.registerRoutingCron("dtlsr_broadcast", dtlsr.broadcastCron, broadcastTime)
 	if err != nil {
 		log.WithFields(log.Fields{
 			"reason": err.Error(),
@@ -141,6 +148,35 @@ func NewDTLSR(c *Core, config DTLSRConfig) *DTLSR {
 	return &dtlsr
 }
 
+// Reconfigure DTLSR's intervals by rescheduling its Cron jobs, see ReconfigurableAlgorithm.
+func (dtlsr *DTLSR) Reconfigure(routingConf RoutingConf) error {
+	config := routingConf.DTLSRConf
+
+	var intervals [3]time.Duration
+	for i, interval := range []string{config.PurgeTime, config.RecomputeTime, config.BroadcastTime} {
+		if d, err := time.ParseDuration(interval); err != nil {
+			return err
+		} else if err := checkCronTiming(d, 0); err != nil {
+			return err
+		} else {
+			intervals[i] = d
+		}
+	}
+
+	for i, name := range []string{"dtlsr_purge", "dtlsr_recompute", "dtlsr_broadcast"} {
+		if err := dtlsr.c.Cron.Reschedule(name, intervals[i], 0); err != nil {
+			return err
+		}
+	}
+
+	dtlsr.dataMutex.Lock()
+	dtlsr.purgeTime = intervals[0]
+	dtlsr.dataMutex.Unlock()
+
+	log.WithField("config", config).Info("Reconfigured DTLSR")
+	return nil
+}
+
 func (dtlsr *DTLSR) NotifyNewBundle(bp BundleDescriptor) {
 	if metaDataBlock, err := bp.MustBundle().ExtensionBlock(bpv7.ExtBlockTypeDTLSRBlock); err == nil {
 		log.WithFields(log.Fields{
@@ -155,6 +191,14 @@ func (dtlsr *DTLSR) NotifyNewBundle(bp BundleDescriptor) {
 			"data": data,
 		}).Debug("Decoded peer data")
 
+		// publish updated links after releasing the lock, as EventHandlers are called synchronously
+		updated := false
+		defer func() {
+			if updated {
+				dtlsr.c.events.publish(Event{Type: LinkUpdated, Peer: data.ID, Peers: data.ConnectedPeers()})
+			}
+		}()
+
 		dtlsr.dataMutex.Lock()
 		defer dtlsr.dataMutex.Unlock()
 		storedData, present := dtlsr.receivedData[data.ID]
@@ -164,6 +208,7 @@ func (dtlsr *DTLSR) NotifyNewBundle(bp BundleDescriptor) {
 			// if we didn't have any data for that peer, we simply add it
 			dtlsr.receivedData[data.ID] = data
 			dtlsr.receivedChange = true
+			updated = true
 
 			// track node
 			dtlsr.newNode(data.ID)
@@ -178,6 +223,7 @@ func (dtlsr *DTLSR) NotifyNewBundle(bp BundleDescriptor) {
 				log.Debug("Updating peer data")
 				dtlsr.receivedData[data.ID] = data
 				dtlsr.receivedChange = true
+				updated = true
 
 				// track peers of this node
 				for node := range data.Peers {
@@ -264,9 +310,8 @@ func (dtlsr *DTLSR) SenderForBundle(bp BundleDescriptor) (sender []cla.Convergen
 
 	recipient := bndl.PrimaryBlock.Destination
 
-	dtlsr.dataMutex.RLock()
-	forwarder, present := dtlsr.routingTable[recipient]
-	dtlsr.dataMutex.RUnlock()
+	// routes are computed between nodes, e.g., "ipn:23.0" for the recipient "ipn:23.42"
+	forwarder, present := dtlsr.table()[recipient.NodeID()]
 	if !present {
 		// we don't know where to forward this bundle
 		log.WithFields(log.Fields{
@@ -277,7 +322,7 @@ func (dtlsr *DTLSR) SenderForBundle(bp BundleDescriptor) (sender []cla.Convergen
 	}
 
 	for _, cs := range dtlsr.c.claManager.Sender() {
-		if cs.GetPeerEndpointID() == forwarder {
+		if cs.GetPeerEndpointID().SameNode(forwarder) {
 			sender = append(sender, cs)
 			log.WithFields(log.Fields{
 				"bundle":             bndl.ID(),
@@ -349,10 +394,24 @@ func (dtlsr *DTLSR) ReportPeerDisappeared(peer cla.Convergence) {
 		"peer": peerID,
 	}).Debug("PeerID discovered")
 
+	// the peer might still be connected by another CLA, as known by the Core's NeighborTable
+	neighbor, known := dtlsr.c.neighbors.Neighbor(peerID)
+	if known && neighbor.Connected() {
+		log.WithFields(log.Fields{
+			"peer": peerID,
+			"clas": neighbor.CLAs,
+		}).Debug("Peer is still connected")
+		return
+	}
+
+	timestamp := bpv7.DtnTimeNow()
+	if known && !neighbor.Disconnected.IsZero() {
+		timestamp = bpv7.DtnTimeFromTime(neighbor.Disconnected)
+	}
+
 	dtlsr.dataMutex.Lock()
 	defer dtlsr.dataMutex.Unlock()
 	// set expiration timestamp for peer
-	timestamp := bpv7.DtnTimeNow()
 	dtlsr.peers.Peers[peerID] = timestamp
 	dtlsr.peers.Timestamp = timestamp
 	dtlsr.peerChange = true
@@ -362,6 +421,49 @@ func (dtlsr *DTLSR) ReportPeerDisappeared(peer cla.Convergence) {
 	}).Debug("Peer timeout is now running")
 }
 
+// ReportPeerNeighbors adds a peer's neighbors, received by the peer discovery's second-hop gossip, as this peer's data.
+// Such data has a zero timestamp and is replaced by the first received metadata bundle.
+func (dtlsr *DTLSR) ReportPeerNeighbors(peer bpv7.EndpointID, neighbors []bpv7.EndpointID) {
+	dtlsr.dataMutex.Lock()
+	defer dtlsr.dataMutex.Unlock()
+
+	if storedData, present := dtlsr.receivedData[peer]; present {
+		if storedData.Timestamp != 0 || (len(storedData.Peers) == len(neighbors) && containsAllPeers(storedData, neighbors)) {
+			return
+		}
+	}
+
+	data := bpv7.DTLSRPeerData{
+		ID:        peer,
+		Timestamp: 0,
+		Peers:     make(map[bpv7.EndpointID]bpv7.DtnTime),
+	}
+
+	dtlsr.newNode(peer)
+	for _, neighbor := range neighbors {
+		dtlsr.newNode(neighbor)
+		data.Peers[neighbor] = 0
+	}
+
+	dtlsr.receivedData[peer] = data
+	dtlsr.receivedChange = true
+
+	log.WithFields(log.Fields{
+		"peer":      peer,
+		"neighbors": neighbors,
+	}).Debug("Peer data was gossiped")
+}
+
+// containsAllPeers checks if all neighbors are present in the peer data.
+func containsAllPeers(data bpv7.DTLSRPeerData, neighbors []bpv7.EndpointID) bool {
+	for _, neighbor := range neighbors {
+		if _, ok := data.Peers[neighbor]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
 // DispatchingAllowed allows the processing of all packages.
 func (_ *DTLSR) DispatchingAllowed(_ BundleDescriptor) bool {
 	// TODO: for future optimisation, we might track the timestamp of the last recomputation of the routing table
@@ -392,37 +494,89 @@ func (dtlsr *DTLSR) newNode(id bpv7.EndpointID) {
 	}).Debug("Added node to tracking store")
 }
 
-// computeRoutingTable finds shortest paths using dijkstra's algorithm
-func (dtlsr *DTLSR) computeRoutingTable() {
+// dtlsrSnapshot is a copy of DTLSR's link-state data, allowing the routing table's computation without holding the
+// dataMutex while new data is received.
+type dtlsrSnapshot struct {
+	peers        map[bpv7.EndpointID]bpv7.DtnTime
+	receivedData []bpv7.DTLSRPeerData
+	nodeIndex    map[bpv7.EndpointID]int
+	indexNode    []bpv7.EndpointID
+}
+
+// snapshot copies the current link-state data. The dataMutex must be held.
+func (dtlsr *DTLSR) snapshot() dtlsrSnapshot {
+	snapshot := dtlsrSnapshot{
+		peers:        make(map[bpv7.EndpointID]bpv7.DtnTime, len(dtlsr.peers.Peers)),
+		receivedData: make([]bpv7.DTLSRPeerData, 0, len(dtlsr.receivedData)),
+		nodeIndex:    make(map[bpv7.EndpointID]int, len(dtlsr.nodeIndex)),
+		indexNode:    append([]bpv7.EndpointID(nil), dtlsr.indexNode...),
+	}
+
+	for peer, timestamp := range dtlsr.peers.Peers {
+		snapshot.peers[peer] = timestamp
+	}
+	for node, index := range dtlsr.nodeIndex {
+		snapshot.nodeIndex[node] = index
+	}
+
+	// Received peer data is replaced as a whole on updates, but its maps might be shared with the stored data.
+	for _, data := range dtlsr.receivedData {
+		peers := make(map[bpv7.EndpointID]bpv7.DtnTime, len(data.Peers))
+		for peer, timestamp := range data.Peers {
+			peers[peer] = timestamp
+		}
+		snapshot.receivedData = append(snapshot.receivedData, bpv7.DTLSRPeerData{ID: data.ID, Timestamp: data.Timestamp, Peers: peers})
+	}
+
+	return snapshot
+}
+
+// table returns the current routing table, which must not be modified.
+func (dtlsr *DTLSR) table() map[bpv7.EndpointID]bpv7.EndpointID {
+	return dtlsr.routingTable.Load().(map[bpv7.EndpointID]bpv7.EndpointID)
+}
+
+// edgeCost of a link reported by a node, which disappeared at the timestamp of this node's clock or is still present
+// for a zero timestamp. The timestamp is corrected by the node's estimated clock offset and never lies in the future.
+func (dtlsr *DTLSR) edgeCost(node bpv7.EndpointID, timestamp, currentTime bpv7.DtnTime) int64 {
+	if timestamp == 0 {
+		return 1
+	}
+
+	age := currentTime.Time().Sub(timestamp.Time())
+	if offset, ok := dtlsr.c.PeerClockOffset(node); ok {
+		age += offset
+	}
+	if age < 0 {
+		age = 0
+	}
+
+	return 1 + age.Milliseconds()
+}
+
+// computeRoutingTable finds shortest paths on a snapshot using dijkstra's algorithm
+func (dtlsr *DTLSR) computeRoutingTable(snapshot dtlsrSnapshot) (map[bpv7.EndpointID]bpv7.EndpointID, error) {
 	log.Debug("Recomputing routing table")
 
 	currentTime := bpv7.DtnTimeNow()
 	graph := dijkstra.NewGraph()
 
 	// add vertices
-	for i := 0; i < dtlsr.length; i++ {
+	for i := range snapshot.indexNode {
 		graph.AddVertex(i)
 		// log node-index mapping for debug purposes
 		log.WithFields(log.Fields{
 			"index": i,
-			"node":  dtlsr.indexNode[i],
+			"node":  snapshot.indexNode[i],
 		}).Debug("Node-index-mapping")
 	}
 
 	// add edges originating from this node
-	for peer, timestamp := range dtlsr.peers.Peers {
-		var edgeCost int64
-		if timestamp == 0 {
-			edgeCost = 1
-		} else {
-			edgeCost = 1 + int64(currentTime-timestamp)
-		}
+	for peer, timestamp := range snapshot.peers {
+		edgeCost := dtlsr.edgeCost(dtlsr.c.NodeId, timestamp, currentTime)
 
-		if err := graph.AddArc(0, dtlsr.nodeIndex[peer], edgeCost); err != nil {
-			log.WithFields(log.Fields{
-				"reason": err.Error(),
-			}).Warn("Error computing routing table")
-			return
+		if err := graph.AddArc(0, snapshot.nodeIndex[peer], edgeCost); err != nil {
+			return nil, err
 		}
 
 		log.WithFields(log.Fields{
@@ -433,20 +587,12 @@ func (dtlsr *DTLSR) computeRoutingTable() {
 	}
 
 	// add edges originating from other nodes
-	for _, data := range dtlsr.receivedData {
+	for _, data := range snapshot.receivedData {
 		for peer, timestamp := range data.Peers {
-			var edgeCost int64
-			if timestamp == 0 {
-				edgeCost = 1
-			} else {
-				edgeCost = 1 + int64(currentTime-timestamp)
-			}
+			edgeCost := dtlsr.edgeCost(data.ID, timestamp, currentTime)
 
-			if err := graph.AddArc(dtlsr.nodeIndex[data.ID], dtlsr.nodeIndex[peer], edgeCost); err != nil {
-				log.WithFields(log.Fields{
-					"reason": err.Error(),
-				}).Warn("Error computing routing table")
-				return
+			if err := graph.AddArc(snapshot.nodeIndex[data.ID], snapshot.nodeIndex[peer], edgeCost); err != nil {
+				return nil, err
 			}
 
 			log.WithFields(log.Fields{
@@ -458,24 +604,24 @@ func (dtlsr *DTLSR) computeRoutingTable() {
 	}
 
 	routingTable := make(map[bpv7.EndpointID]bpv7.EndpointID)
-	for i := 1; i < dtlsr.length; i++ {
+	for i := 1; i < len(snapshot.indexNode); i++ {
 		shortest, err := graph.Shortest(0, i)
 		if err == nil {
 			if len(shortest.Path) <= 1 {
 				log.WithFields(log.Fields{
 					"node_index": i,
-					"node":       dtlsr.indexNode[i],
+					"node":       snapshot.indexNode[i],
 					"path":       shortest.Path,
 				}).Warn("Single step path found - this should not happen")
 				continue
 			}
 
-			routingTable[dtlsr.indexNode[i]] = dtlsr.indexNode[shortest.Path[1]]
+			routingTable[snapshot.indexNode[i]] = snapshot.indexNode[shortest.Path[1]]
 			log.WithFields(log.Fields{
 				"node_index": i,
-				"node":       dtlsr.indexNode[i],
+				"node":       snapshot.indexNode[i],
 				"path":       shortest.Path,
-				"next_hop":   routingTable[dtlsr.indexNode[i]],
+				"next_hop":   routingTable[snapshot.indexNode[i]],
 			}).Debug("Found path to node")
 		} else {
 			log.WithFields(log.Fields{
@@ -489,11 +635,11 @@ func (dtlsr *DTLSR) computeRoutingTable() {
 		"routingTable": routingTable,
 	}).Debug("Finished routing table computation")
 
-	dtlsr.routingTable = routingTable
+	return routingTable, nil
 }
 
 // recomputeCron gets called periodically by the routing's cron module.
-// Only actually triggers a recompute if the underlying data has changed.
+// Only actually triggers a recompute if the underlying data has changed and no recompute is already running.
 func (dtlsr *DTLSR) recomputeCron() {
 	dtlsr.dataMutex.RLock()
 	peerChange := dtlsr.peerChange
@@ -505,14 +651,32 @@ func (dtlsr *DTLSR) recomputeCron() {
 		"receivedChange": receivedChange,
 	}).Debug("Executing recomputeCron")
 
-	if peerChange || receivedChange {
-		dtlsr.dataMutex.Lock()
-		dtlsr.computeRoutingTable()
-		dtlsr.receivedChange = false
-		dtlsr.dataMutex.Unlock()
+	if (peerChange || receivedChange) && atomic.CompareAndSwapInt32(&dtlsr.recomputing, 0, 1) {
+		go dtlsr.recompute()
 	}
 }
 
+// recompute the routing table from a snapshot and swap it in afterwards. Changes received in the meantime are
+// considered by the next recomputation.
+func (dtlsr *DTLSR) recompute() {
+	defer atomic.StoreInt32(&dtlsr.recomputing, 0)
+
+	dtlsr.dataMutex.Lock()
+	snapshot := dtlsr.snapshot()
+	dtlsr.receivedChange = false
+	dtlsr.dataMutex.Unlock()
+
+	routingTable, err := dtlsr.computeRoutingTable(snapshot)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"reason": err.Error(),
+		}).Warn("Error computing routing table")
+		return
+	}
+
+	dtlsr.routingTable.Store(routingTable)
+}
+
 // broadcast broadcasts this node's peer data to the network
 func (dtlsr *DTLSR) broadcast() {
 	log.Debug("Broadcasting metadata")
@@ -574,3 +738,74 @@ func (dtlsr *DTLSR) purgePeers() {
 		}
 	}
 }
+
+// RoutingTableSize returns the number of destinations in the computed routing table, see RoutingTableSizer.
+func (dtlsr *DTLSR) RoutingTableSize() int {
+	return len(dtlsr.table())
+}
+
+// RoutingTable returns the next hop for each destination, see RoutingTableDumper.
+func (dtlsr *DTLSR) RoutingTable() map[string]string {
+	routingTable := dtlsr.table()
+
+	table := make(map[string]string, len(routingTable))
+	for destination, nextHop := range routingTable {
+		table[destination.String()] = nextHop.String()
+	}
+	return table
+}
+
+// Checkpoint serializes the received peer data, i.e., the link-state database, as a CBOR array of DTLSRBlocks, see
+// CheckpointingAlgorithm. This node's own peer data is rebuilt from its connections after a restart.
+func (dtlsr *DTLSR) Checkpoint() ([]byte, error) {
+	dtlsr.dataMutex.RLock()
+	defer dtlsr.dataMutex.RUnlock()
+
+	buff := new(bytes.Buffer)
+	if err := cboring.WriteArrayLength(uint64(len(dtlsr.receivedData)), buff); err != nil {
+		return nil, err
+	}
+	for _, data := range dtlsr.receivedData {
+		if err := cboring.Marshal(bpv7.NewDTLSRBlock(data), buff); err != nil {
+			return nil, err
+		}
+	}
+	return buff.Bytes(), nil
+}
+
+// Restore received peer data from a Checkpoint, unless newer data was already received, see CheckpointingAlgorithm.
+func (dtlsr *DTLSR) Restore(data []byte) error {
+	buff := bytes.NewBuffer(data)
+	n, err := cboring.ReadArrayLength(buff)
+	if err != nil {
+		return err
+	}
+
+	restored := make([]bpv7.DTLSRPeerData, 0, n)
+	for i := uint64(0); i < n; i++ {
+		var block bpv7.DTLSRBlock
+		if err := cboring.Unmarshal(&block, buff); err != nil {
+			return err
+		}
+		restored = append(restored, block.GetPeerData())
+	}
+
+	dtlsr.dataMutex.Lock()
+	defer dtlsr.dataMutex.Unlock()
+
+	for _, peerData := range restored {
+		if storedData, present := dtlsr.receivedData[peerData.ID]; present && !peerData.ShouldReplace(storedData) {
+			continue
+		}
+
+		dtlsr.receivedData[peerData.ID] = peerData
+		dtlsr.newNode(peerData.ID)
+		for node := range peerData.Peers {
+			dtlsr.newNode(node)
+		}
+	}
+	dtlsr.receivedChange = true
+
+	log.WithField("nodes", len(restored)).Debug("Restored DTLSR peer data")
+	return nil
+}
@@ -0,0 +1,163 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+func TestDTLSRRecompute(t *testing.T) {
+	conf := RoutingConf{
+		Algorithm: "dtlsr",
+		DTLSRConf: DTLSRConfig{RecomputeTime: "30s", BroadcastTime: "30s", PurgeTime: "10m"},
+	}
+
+	c, err := NewCore(t.TempDir(), bpv7.MustNewEndpointID("dtn://a/"), false, conf, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	peerB := bpv7.MustNewEndpointID("dtn://b/")
+	peerC := bpv7.MustNewEndpointID("dtn://c/")
+
+	dtlsr := c.routing.(*DTLSR)
+	dtlsr.dataMutex.Lock()
+	dtlsr.newNode(peerB)
+	dtlsr.newNode(peerC)
+	dtlsr.peers.Peers[peerB] = 0
+	dtlsr.receivedData[peerB] = bpv7.DTLSRPeerData{
+		ID:        peerB,
+		Timestamp: bpv7.DtnTimeNow(),
+		Peers:     map[bpv7.EndpointID]bpv7.DtnTime{peerC: 0},
+	}
+	dtlsr.receivedChange = true
+
+	// The snapshot must not be affected by later changes.
+	snapshot := dtlsr.snapshot()
+	dtlsr.receivedData[peerB].Peers[peerB] = 0
+	dtlsr.dataMutex.Unlock()
+
+	if l := len(snapshot.receivedData[0].Peers); l != 1 {
+		t.Fatalf("snapshot's peer data was modified, has %d peers", l)
+	}
+
+	// Recomputation runs in the background, while the previous, empty routing table stays available.
+	dtlsr.recomputeCron()
+
+	for deadline := time.Now().Add(5 * time.Second); dtlsr.RoutingTableSize() != 2; {
+		if time.Now().After(deadline) {
+			t.Fatalf("routing table was not recomputed: %v", dtlsr.RoutingTable())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if nextHop := dtlsr.RoutingTable()[peerC.String()]; nextHop != peerB.String() {
+		t.Fatalf("expected next hop %v for %v, got %q", peerB, peerC, nextHop)
+	}
+
+	dtlsr.dataMutex.RLock()
+	receivedChange := dtlsr.receivedChange
+	dtlsr.dataMutex.RUnlock()
+	if receivedChange {
+		t.Fatal("received change is still set after recomputation")
+	}
+}
+
+func TestDTLSRSenderForBundleIpn(t *testing.T) {
+	conf := RoutingConf{
+		Algorithm: "dtlsr",
+		DTLSRConf: DTLSRConfig{RecomputeTime: "1h", BroadcastTime: "1h", PurgeTime: "1h"},
+	}
+
+	c, err := NewCore(t.TempDir(), bpv7.MustNewEndpointID("ipn:1.0"), false, conf, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	// Routes are known for node IDs, while the bundle is addressed to a service of node 3, reachable via node 2.
+	dtlsr := c.routing.(*DTLSR)
+	dtlsr.routingTable.Store(map[bpv7.EndpointID]bpv7.EndpointID{
+		bpv7.MustNewEndpointID("ipn:2.0"): bpv7.MustNewEndpointID("ipn:2.0"),
+		bpv7.MustNewEndpointID("ipn:3.0"): bpv7.MustNewEndpointID("ipn:2.0"),
+	})
+
+	c.claManager.Register(&dispatchSender{bpv7.MustNewEndpointID("ipn:2.0"), func(bpv7.Bundle) error { return nil }})
+	for deadline := time.Now().Add(5 * time.Second); len(c.claManager.Sender()) == 0; {
+		if time.Now().After(deadline) {
+			t.Fatal("sender was not registered")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	bndl, err := bpv7.Builder().
+		Source("ipn:1.1").
+		Destination("ipn:3.42").
+		CreationTimestampNow().
+		Lifetime("10m").
+		PayloadBlock([]byte("hello node 3")).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sender, _ := dtlsr.SenderForBundle(NewBundleDescriptorFromBundle(bndl, c.Store))
+	if len(sender) != 1 || sender[0].GetPeerEndpointID() != bpv7.MustNewEndpointID("ipn:2.0") {
+		t.Fatalf("expected node 2 as forwarder, got %v", sender)
+	}
+}
+
+func BenchmarkDTLSRRecompute(b *testing.B) {
+	for _, nodes := range []int{10, 100, 500} {
+		b.Run(fmt.Sprintf("%d", nodes), func(b *testing.B) {
+			conf := RoutingConf{
+				Algorithm: "dtlsr",
+				DTLSRConf: DTLSRConfig{RecomputeTime: "1h", BroadcastTime: "1h", PurgeTime: "1h"},
+			}
+
+			c, err := NewCore(b.TempDir(), bpv7.MustNewEndpointID("dtn://own/"), false, conf, nil)
+			if err != nil {
+				b.Fatal(err)
+			}
+			defer c.Close()
+
+			// Each node is linked to its successor and to a more distant one, forming a ring with chords.
+			ids := make([]bpv7.EndpointID, nodes)
+			for i := range ids {
+				ids[i] = bpv7.MustNewEndpointID(fmt.Sprintf("dtn://node-%d/", i))
+			}
+
+			dtlsr := c.routing.(*DTLSR)
+			dtlsr.dataMutex.Lock()
+			for _, id := range ids {
+				dtlsr.newNode(id)
+			}
+			dtlsr.peers.Peers[ids[0]] = 0
+			for i, id := range ids {
+				dtlsr.receivedData[id] = bpv7.DTLSRPeerData{
+					ID:        id,
+					Timestamp: bpv7.DtnTimeNow(),
+					Peers:     map[bpv7.EndpointID]bpv7.DtnTime{ids[(i+1)%nodes]: 0, ids[(i+7)%nodes]: 0},
+				}
+			}
+			snapshot := dtlsr.snapshot()
+			dtlsr.dataMutex.Unlock()
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if routingTable, err := dtlsr.computeRoutingTable(snapshot); err != nil {
+					b.Fatal(err)
+				} else if len(routingTable) != nodes {
+					b.Fatalf("expected %d routes, got %d", nodes, len(routingTable))
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,260 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+	"github.com/dtn7/dtn7-go/pkg/cla"
+	"github.com/dtn7/dtn7-go/pkg/routing/external"
+)
+
+// ExternalConfig contains the configuration for "external" routing.
+type ExternalConfig struct {
+	// Address of the external routing process' gRPC server, "host:port" for TCP or "unix:/path" for a Unix domain
+	// socket.
+	Address string
+	// Timeout for each decision and notification, one second by default.
+	Timeout string
+}
+
+// dial the external routing process. The connection is established in the background and reestablished if lost.
+func (conf ExternalConfig) dial() (*grpc.ClientConn, error) {
+	return grpc.Dial(conf.Address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+}
+
+// timeout of the configuration, defaulting to one second.
+func (conf ExternalConfig) timeout() (time.Duration, error) {
+	if conf.Timeout == "" {
+		return time.Second, nil
+	}
+
+	timeout, err := time.ParseDuration(conf.Timeout)
+	if err != nil {
+		return 0, err
+	} else if timeout <= 0 {
+		return 0, fmt.Errorf("timeout %v is not positive", timeout)
+	}
+	return timeout, nil
+}
+
+// newExternalBundle describes a BundleDescriptor for the external routing process.
+func newExternalBundle(bp BundleDescriptor) *external.Bundle {
+	bndl := bp.MustBundle()
+	size, _ := bndl.SerializedSize()
+
+	eb := &external.Bundle{
+		Id:           bp.ID().String(),
+		Source:       bndl.PrimaryBlock.SourceNode.String(),
+		Destination:  bndl.PrimaryBlock.Destination.String(),
+		CreationTime: uint64(bndl.PrimaryBlock.CreationTimestamp.DtnTime()),
+		Lifetime:     bndl.PrimaryBlock.Lifetime,
+		Size:         size,
+		Priority:     bp.Priority.String(),
+	}
+	if prevNode, ok := bp.PreviousNode(); ok {
+		eb.PreviousNode = prevNode.String()
+	}
+	return eb
+}
+
+// ExternalRouting delegates the routing decisions to an external process, e.g., a researcher's prototype written in
+// Python, serving the gRPC ExternalRouting service of the external package's external.proto.
+//
+// The external process is notified about new bundles, failed transmissions, and appearing or disappearing peers. For
+// each bundle to be forwarded, the external process is asked for the peers to forward it to, out of the currently
+// connected peers, and if the bundle should be deleted afterwards.
+//
+// Without a connection or a response within the timeout, bundles are kept and retried later. Lost connections are
+// reestablished in the background.
+type ExternalRouting struct {
+	c *Core
+
+	mutex   sync.Mutex
+	conf    ExternalConfig
+	timeout time.Duration
+	conn    *grpc.ClientConn
+	client  external.ExternalRoutingClient
+}
+
+// NewExternalRouting for an external routing process. The connection is established in the background.
+func NewExternalRouting(c *Core, conf ExternalConfig) (*ExternalRouting, error) {
+	timeout, err := conf.timeout()
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := conf.dial()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ExternalRouting{
+		c:       c,
+		conf:    conf,
+		timeout: timeout,
+		conn:    conn,
+		client:  external.NewExternalRoutingClient(conn),
+	}, nil
+}
+
+func (er *ExternalRouting) String() string {
+	return "external"
+}
+
+func (er *ExternalRouting) log() *log.Entry {
+	return log.WithField("routing", "external")
+}
+
+// call the external routing process with the current client and a context limited by the timeout.
+func (er *ExternalRouting) call(f func(ctx context.Context, client external.ExternalRoutingClient) error) error {
+	er.mutex.Lock()
+	client, timeout := er.client, er.timeout
+	er.mutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	return f(ctx, client)
+}
+
+// notify the external routing process, logging failures.
+func (er *ExternalRouting) notify(method string, f func(ctx context.Context, client external.ExternalRoutingClient) error) {
+	if err := er.call(f); err != nil {
+		er.log().WithError(err).WithField("method", method).Debug("Notifying external routing process failed")
+	}
+}
+
+func (er *ExternalRouting) NotifyNewBundle(bp BundleDescriptor) {
+	er.notify("NotifyNewBundle", func(ctx context.Context, client external.ExternalRoutingClient) error {
+		_, err := client.NotifyNewBundle(ctx, newExternalBundle(bp))
+		return err
+	})
+}
+
+// DispatchingAllowed is always true, as the external routing process decides while forwarding.
+func (er *ExternalRouting) DispatchingAllowed(_ BundleDescriptor) bool {
+	return true
+}
+
+func (er *ExternalRouting) SenderForBundle(bp BundleDescriptor) (css []cla.ConvergenceSender, del bool) {
+	senders := er.c.claManager.Sender()
+	if len(senders) == 0 {
+		return nil, false
+	}
+
+	req := &external.SenderForBundleRequest{Bundle: newExternalBundle(bp)}
+	for _, cs := range senders {
+		req.Peers = append(req.Peers, &external.Peer{Endpoint: cs.GetPeerEndpointID().String()})
+	}
+
+	var resp *external.SenderForBundleResponse
+	err := er.call(func(ctx context.Context, client external.ExternalRoutingClient) (err error) {
+		resp, err = client.SenderForBundle(ctx, req)
+		return
+	})
+	if err != nil {
+		er.log().WithError(err).WithField("bundle", bp.ID().String()).Warn("Requesting external routing decision failed")
+		return nil, false
+	}
+
+	var peers []bpv7.EndpointID
+	for _, peer := range resp.Peers {
+		eid, err := bpv7.NewEndpointID(peer.Endpoint)
+		if err != nil {
+			er.log().WithError(err).WithField("peer", peer.Endpoint).Warn("External routing process decided for an invalid peer")
+			continue
+		}
+		peers = append(peers, eid)
+	}
+
+	for _, cs := range senders {
+		for _, peer := range peers {
+			if cs.GetPeerEndpointID().SameNode(peer) {
+				css = append(css, cs)
+				break
+			}
+		}
+	}
+
+	er.log().WithFields(log.Fields{
+		"bundle": bp.ID().String(),
+		"peers":  peers,
+		"delete": resp.Delete,
+	}).Debug("External routing process decided")
+
+	return css, resp.Delete
+}
+
+func (er *ExternalRouting) ReportFailure(bp BundleDescriptor, sender cla.ConvergenceSender) {
+	failure := &external.Failure{
+		Bundle: newExternalBundle(bp),
+		Peer:   &external.Peer{Endpoint: sender.GetPeerEndpointID().String()},
+	}
+	er.notify("ReportFailure", func(ctx context.Context, client external.ExternalRoutingClient) error {
+		_, err := client.ReportFailure(ctx, failure)
+		return err
+	})
+}
+
+func (er *ExternalRouting) ReportPeerAppeared(peer cla.Convergence) {
+	if cs, ok := peer.(cla.ConvergenceSender); ok {
+		er.notify("ReportPeerAppeared", func(ctx context.Context, client external.ExternalRoutingClient) error {
+			_, err := client.ReportPeerAppeared(ctx, &external.Peer{Endpoint: cs.GetPeerEndpointID().String()})
+			return err
+		})
+	}
+}
+
+func (er *ExternalRouting) ReportPeerDisappeared(peer cla.Convergence) {
+	if cs, ok := peer.(cla.ConvergenceSender); ok {
+		er.notify("ReportPeerDisappeared", func(ctx context.Context, client external.ExternalRoutingClient) error {
+			_, err := client.ReportPeerDisappeared(ctx, &external.Peer{Endpoint: cs.GetPeerEndpointID().String()})
+			return err
+		})
+	}
+}
+
+// Reconfigure the address and timeout. A changed address replaces the current connection.
+func (er *ExternalRouting) Reconfigure(routingConf RoutingConf) error {
+	timeout, err := routingConf.ExternalConf.timeout()
+	if err != nil {
+		return err
+	}
+
+	er.mutex.Lock()
+	defer er.mutex.Unlock()
+
+	if routingConf.ExternalConf.Address != er.conf.Address {
+		conn, err := routingConf.ExternalConf.dial()
+		if err != nil {
+			return err
+		}
+
+		_ = er.conn.Close()
+		er.conn = conn
+		er.client = external.NewExternalRoutingClient(conn)
+	}
+
+	er.conf = routingConf.ExternalConf
+	er.timeout = timeout
+	return nil
+}
+
+// Close the connection to the external routing process.
+func (er *ExternalRouting) Close() error {
+	er.mutex.Lock()
+	defer er.mutex.Unlock()
+
+	return er.conn.Close()
+}
@@ -0,0 +1,127 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+	"github.com/dtn7/dtn7-go/pkg/routing/external"
+)
+
+// externalTestProcess is an ExternalRoutingServer, forwarding all received calls by their method name and answering
+// each request by forwarding to the first offered peer.
+type externalTestProcess struct {
+	external.UnimplementedExternalRoutingServer
+
+	calls chan string
+	bndls chan *external.Bundle
+}
+
+func (p *externalTestProcess) NotifyNewBundle(_ context.Context, b *external.Bundle) (*emptypb.Empty, error) {
+	p.calls <- "NotifyNewBundle"
+	p.bndls <- b
+	return &emptypb.Empty{}, nil
+}
+
+func (p *externalTestProcess) SenderForBundle(_ context.Context, req *external.SenderForBundleRequest) (*external.SenderForBundleResponse, error) {
+	p.calls <- "SenderForBundle"
+	p.bndls <- req.Bundle
+	return &external.SenderForBundleResponse{Peers: req.Peers[:1], Delete: true}, nil
+}
+
+func TestExternalRouting(t *testing.T) {
+	l, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	process := &externalTestProcess{calls: make(chan string, 16), bndls: make(chan *external.Bundle, 16)}
+	server := grpc.NewServer()
+	external.RegisterExternalRoutingServer(server, process)
+	go func() { _ = server.Serve(l) }()
+	defer server.Stop()
+
+	c, err := NewCore(t.TempDir(), bpv7.MustNewEndpointID("dtn://a/"), false,
+		RoutingConf{Algorithm: "external", ExternalConf: ExternalConfig{Address: l.Addr().String()}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	er := c.routing.(*ExternalRouting)
+
+	peer := &dispatchSender{peer: bpv7.MustNewEndpointID("dtn://b/"), send: func(bpv7.Bundle) error { return nil }}
+	c.RegisterConvergable(peer)
+	for i := 0; len(c.claManager.Sender()) == 0; i++ {
+		if i > 50 {
+			t.Fatal("peer was not registered")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	bndl, err := bpv7.Builder().
+		Source("dtn://a/").
+		Destination("dtn://z/").
+		CreationTimestampNow().
+		Lifetime("10m").
+		PayloadBlock([]byte("hello world")).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	bp := NewBundleDescriptorFromBundle(bndl, c.Store)
+
+	er.NotifyNewBundle(bp)
+	css, del := er.SenderForBundle(bp)
+	if len(css) != 1 || css[0] != peer || !del {
+		t.Fatalf("expected forwarding to %v and deletion, got %v and %t", peer, css, del)
+	}
+
+	for _, method := range []string{"NotifyNewBundle", "SenderForBundle"} {
+		var call string
+		for call != method {
+			select {
+			case call = <-process.calls:
+				b := <-process.bndls
+				if call == method && (b.Id != bndl.ID().String() || b.Destination != "dtn://z/") {
+					t.Fatalf("%s describes another bundle, %v", method, b)
+				}
+			case <-time.After(time.Second):
+				t.Fatalf("external process received no %s", method)
+			}
+		}
+	}
+
+	// Without a connection, bundles are kept without any forwarding.
+	server.Stop()
+	if css, del := er.SenderForBundle(bp); len(css) != 0 || del {
+		t.Fatalf("disconnected external routing decided %v and %t", css, del)
+	}
+}
+
+func TestExternalRoutingValidate(t *testing.T) {
+	tests := []struct {
+		conf  ExternalConfig
+		valid bool
+	}{
+		{ExternalConfig{Address: "localhost:4557"}, true},
+		{ExternalConfig{Address: "unix:/run/dtn7/routing.sock", Timeout: "100ms"}, true},
+		{ExternalConfig{}, false},
+		{ExternalConfig{Address: "localhost:4557", Timeout: "-1s"}, false},
+	}
+
+	for _, test := range tests {
+		if err := (RoutingConf{Algorithm: "external", ExternalConf: test.conf}).Validate(); (err == nil) != test.valid {
+			t.Fatalf("%v: expected validity %t, got %v", test.conf, test.valid, err)
+		}
+	}
+}
@@ -6,11 +6,15 @@
 package routing
 
 import (
+	"bytes"
+	"fmt"
 	"sync"
 	"time"
 
 	log "github.com/sirupsen/logrus"
 
+	"github.com/dtn7/cboring"
+
 	"github.com/dtn7/dtn7-go/pkg/bpv7"
 	"github.com/dtn7/dtn7-go/pkg/cla"
 )
@@ -60,7 +64,7 @@ func NewProphet(c *Core, config ProphetConfig) *Prophet {
 		}).Fatal("Unable to parse duration")
 	}
 
-	err = c.Cron.Register("dtlsr_recompute", prophet.ageCron, ageInterval)
+	err = c.registerRoutingCron("dtlsr_recompute", prophet.ageCron, ageInterval)
 	if err != nil {
 		log.WithFields(log.Fields{
 			"reason": err.Error(),
@@ -78,6 +82,26 @@ func NewProphet(c *Core, config ProphetConfig) *Prophet {
 }
 
 // encounter updates the predictability for an encountered node
+// Reconfigure Prophet's constants and reschedule its ageing, see ReconfigurableAlgorithm.
+func (prophet *Prophet) Reconfigure(routingConf RoutingConf) error {
+	config := routingConf.ProphetConf
+
+	ageInterval, err := time.ParseDuration(config.AgeInterval)
+	if err != nil {
+		return err
+	}
+	if err := prophet.c.Cron.Reschedule("dtlsr_recompute", ageInterval, 0); err != nil {
+		return err
+	}
+
+	prophet.dataMutex.Lock()
+	prophet.config = config
+	prophet.dataMutex.Unlock()
+
+	log.WithField("config", config).Info("Reconfigured Prophet")
+	return nil
+}
+
 func (prophet *Prophet) encounter(peer bpv7.EndpointID) {
 	// map will return 0 if no value is stored for key
 	pOld := prophet.predictabilities[peer]
@@ -170,7 +194,7 @@ func (prophet *Prophet) NotifyNewBundle(bp BundleDescriptor) {
 			"source": bp.MustBundle().PrimaryBlock.SourceNode,
 		}).Debug("Received metadata")
 
-		if bp.MustBundle().PrimaryBlock.Destination != prophet.c.NodeId {
+		if !bp.MustBundle().PrimaryBlock.Destination.SameNode(prophet.c.NodeId) {
 			log.WithFields(log.Fields{
 				"recipient": bp.MustBundle().PrimaryBlock.Destination,
 				"own_id":    prophet.c.NodeId,
@@ -299,7 +323,8 @@ func (prophet *Prophet) SenderForBundle(bp BundleDescriptor) (sender []cla.Conve
 		sentEids = make([]bpv7.EndpointID, 0)
 	}
 
-	destination := bndl.PrimaryBlock.Destination
+	// predictabilities are kept for nodes, e.g., "ipn:23.0" for the destination "ipn:23.42"
+	destination := bndl.PrimaryBlock.Destination.NodeID()
 	sender = make([]cla.ConvergenceSender, 0)
 
 	for _, cs := range prophet.c.claManager.Sender() {
@@ -439,6 +464,15 @@ func (prophet *Prophet) ReportPeerAppeared(peer cla.Convergence) {
 		"peer": peerID,
 	}).Debug("PeerID discovered")
 
+	// another CLA to an already connected peer is no new encounter, as known by the Core's NeighborTable
+	if neighbor, known := prophet.c.neighbors.Neighbor(peerID); known && len(neighbor.CLAs) > 1 {
+		log.WithFields(log.Fields{
+			"peer": peerID,
+			"clas": neighbor.CLAs,
+		}).Debug("Peer was already connected")
+		return
+	}
+
 	// update our delivery predictability for this peer
 	prophet.dataMutex.Lock()
 	prophet.encounter(peerID)
@@ -448,9 +482,118 @@ func (prophet *Prophet) ReportPeerAppeared(peer cla.Convergence) {
 	prophet.sendMetadata(peerID)
 }
 
+// ReportPeerNeighbors estimates a peer's predictabilities for its neighbors, received by the peer discovery's
+// second-hop gossip, as the initialisation constant. These are replaced by the first received metadata bundle.
+func (prophet *Prophet) ReportPeerNeighbors(peer bpv7.EndpointID, neighbors []bpv7.EndpointID) {
+	prophet.dataMutex.Lock()
+	defer prophet.dataMutex.Unlock()
+
+	if _, present := prophet.peerPredictabilities[peer]; present {
+		return
+	}
+
+	data := make(map[bpv7.EndpointID]float64)
+	for _, neighbor := range neighbors {
+		if neighbor != prophet.c.NodeId {
+			data[neighbor] = prophet.config.PInit
+		}
+	}
+	prophet.peerPredictabilities[peer] = data
+
+	log.WithFields(log.Fields{
+		"peer":      peer,
+		"neighbors": neighbors,
+	}).Debug("Estimated peer's predictabilities from gossip")
+}
+
 func (prophet *Prophet) ReportPeerDisappeared(peer cla.Convergence) {
 	log.WithFields(log.Fields{
 		"address": peer,
 	}).Debug("Peer disappeared")
 	// there really isn't anything to do upon a peer's disappearance
 }
+
+// RoutingTableSize returns the number of nodes with a known delivery predictability, see RoutingTableSizer.
+func (prophet *Prophet) RoutingTableSize() int {
+	prophet.dataMutex.RLock()
+	defer prophet.dataMutex.RUnlock()
+
+	return len(prophet.predictabilities)
+}
+
+// RoutingTable returns the delivery predictability for each known node, see RoutingTableDumper.
+func (prophet *Prophet) RoutingTable() map[string]string {
+	prophet.dataMutex.RLock()
+	defer prophet.dataMutex.RUnlock()
+
+	table := make(map[string]string, len(prophet.predictabilities))
+	for node, predictability := range prophet.predictabilities {
+		table[node.String()] = fmt.Sprintf("%.3f", predictability)
+	}
+	return table
+}
+
+// Checkpoint serializes this node's and its peers' delivery predictabilities as a CBOR array, starting with this
+// node's ProphetBlock, followed by each peer's Endpoint ID and ProphetBlock, see CheckpointingAlgorithm.
+func (prophet *Prophet) Checkpoint() ([]byte, error) {
+	prophet.dataMutex.RLock()
+	defer prophet.dataMutex.RUnlock()
+
+	buff := new(bytes.Buffer)
+	if err := cboring.WriteArrayLength(1+2*uint64(len(prophet.peerPredictabilities)), buff); err != nil {
+		return nil, err
+	}
+	if err := cboring.Marshal(bpv7.NewProphetBlock(prophet.predictabilities), buff); err != nil {
+		return nil, err
+	}
+
+	for peer, predictabilities := range prophet.peerPredictabilities {
+		peer := peer
+		if err := cboring.Marshal(&peer, buff); err != nil {
+			return nil, err
+		}
+		if err := cboring.Marshal(bpv7.NewProphetBlock(predictabilities), buff); err != nil {
+			return nil, err
+		}
+	}
+	return buff.Bytes(), nil
+}
+
+// Restore delivery predictabilities from a Checkpoint, replacing the current ones, see CheckpointingAlgorithm.
+func (prophet *Prophet) Restore(data []byte) error {
+	buff := bytes.NewBuffer(data)
+	n, err := cboring.ReadArrayLength(buff)
+	if err != nil {
+		return err
+	} else if n%2 != 1 {
+		return fmt.Errorf("expected an odd number of elements, got %d", n)
+	}
+
+	var own bpv7.ProphetBlock
+	if err := cboring.Unmarshal(&own, buff); err != nil {
+		return err
+	}
+
+	peers := make(map[bpv7.EndpointID]map[bpv7.EndpointID]float64, n/2)
+	for i := uint64(0); i < n/2; i++ {
+		var peer bpv7.EndpointID
+		if err := cboring.Unmarshal(&peer, buff); err != nil {
+			return err
+		}
+
+		var block bpv7.ProphetBlock
+		if err := cboring.Unmarshal(&block, buff); err != nil {
+			return err
+		}
+		peers[peer] = block.GetPredictabilities()
+	}
+
+	prophet.dataMutex.Lock()
+	defer prophet.dataMutex.Unlock()
+
+	prophet.predictabilities = own.GetPredictabilities()
+	prophet.peerPredictabilities = peers
+
+	log.WithField("nodes", len(prophet.predictabilities)).Debug("Restored PRoPHET predictabilities")
+	return nil
+}
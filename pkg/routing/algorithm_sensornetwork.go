@@ -111,6 +111,38 @@ func (snm *SensorNetworkMuleRouting) ReportPeerDisappeared(peer cla.Convergence)
 	snm.algorithm.ReportPeerDisappeared(peer)
 }
 
+// RoutingTableSize of the underlying algorithm, or -1 if it does not implement RoutingTableSizer.
+func (snm *SensorNetworkMuleRouting) RoutingTableSize() int {
+	if sizer, ok := snm.algorithm.(RoutingTableSizer); ok {
+		return sizer.RoutingTableSize()
+	}
+	return -1
+}
+
+// RoutingTable of the underlying algorithm, or nil if it does not implement RoutingTableDumper.
+func (snm *SensorNetworkMuleRouting) RoutingTable() map[string]string {
+	if dumper, ok := snm.algorithm.(RoutingTableDumper); ok {
+		return dumper.RoutingTable()
+	}
+	return nil
+}
+
 func (snm *SensorNetworkMuleRouting) String() string {
 	return fmt.Sprintf("sensor mule overlaying %v", snm.algorithm)
 }
+
+// Checkpoint of the underlying algorithm, or nil if it does not implement CheckpointingAlgorithm.
+func (snm *SensorNetworkMuleRouting) Checkpoint() ([]byte, error) {
+	if algo, ok := snm.algorithm.(CheckpointingAlgorithm); ok {
+		return algo.Checkpoint()
+	}
+	return nil, nil
+}
+
+// Restore a Checkpoint of the underlying algorithm, if it implements CheckpointingAlgorithm.
+func (snm *SensorNetworkMuleRouting) Restore(data []byte) error {
+	if algo, ok := snm.algorithm.(CheckpointingAlgorithm); ok {
+		return algo.Restore(data)
+	}
+	return nil
+}
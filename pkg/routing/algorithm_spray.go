@@ -62,7 +62,7 @@ func NewSprayAndWait(c *Core, config SprayConfig) *SprayAndWait {
 		bundleData: make(map[bpv7.BundleID]sprayMetaData),
 	}
 
-	err := c.Cron.Register("spray_and_wait_gc", sprayAndWait.GarbageCollect, time.Second*60)
+	err := c.registerRoutingCron("spray_and_wait_gc", sprayAndWait.GarbageCollect, time.Second*60)
 	if err != nil {
 		log.WithFields(log.Fields{
 			"error": err,
@@ -243,7 +243,7 @@ func NewBinarySpray(c *Core, config SprayConfig) *BinarySpray {
 		bundleData: make(map[bpv7.BundleID]sprayMetaData),
 	}
 
-	err := c.Cron.Register("binary_spray_gc", binarySpray.GarbageCollect, time.Second*60)
+	err := c.registerRoutingCron("binary_spray_gc", binarySpray.GarbageCollect, time.Second*60)
 	if err != nil {
 		log.WithFields(log.Fields{
 			"error": err,
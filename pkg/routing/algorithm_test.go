@@ -0,0 +1,40 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import "testing"
+
+func TestRoutingConfValidate(t *testing.T) {
+	dtlsrConf := DTLSRConfig{RecomputeTime: "30s", BroadcastTime: "30s", PurgeTime: "10m"}
+
+	tests := []struct {
+		conf  RoutingConf
+		valid bool
+	}{
+		{RoutingConf{Algorithm: "epidemic"}, true},
+		{RoutingConf{Algorithm: "unknown"}, false},
+		{RoutingConf{Algorithm: "spray", SprayConf: SprayConfig{Multiplicity: 10}}, true},
+		{RoutingConf{Algorithm: "binary_spray"}, false},
+		{RoutingConf{Algorithm: "dtlsr", DTLSRConf: dtlsrConf}, true},
+		{RoutingConf{Algorithm: "dtlsr", DTLSRConf: DTLSRConfig{RecomputeTime: "30s", BroadcastTime: "soon"}}, false},
+		{RoutingConf{Algorithm: "prophet", ProphetConf: ProphetConfig{AgeInterval: "1m"}}, true},
+		{RoutingConf{Algorithm: "prophet"}, false},
+		{RoutingConf{Algorithm: "sensor-mule", SensorMuleConf: SensorNetworkMuleConfig{
+			Algorithm:       &RoutingConf{Algorithm: "dtlsr", DTLSRConf: dtlsrConf},
+			SensorNodeRegex: "^dtn://sensor-.*/$",
+		}}, true},
+		{RoutingConf{Algorithm: "sensor-mule", SensorMuleConf: SensorNetworkMuleConfig{SensorNodeRegex: ".*"}}, false},
+		{RoutingConf{Algorithm: "sensor-mule", SensorMuleConf: SensorNetworkMuleConfig{
+			Algorithm:       &RoutingConf{Algorithm: "epidemic"},
+			SensorNodeRegex: "(",
+		}}, false},
+	}
+
+	for _, test := range tests {
+		if err := test.conf.Validate(); (err == nil) != test.valid {
+			t.Fatalf("%s: validation resulted in %v, expected validity %t", test.conf.Algorithm, err, test.valid)
+		}
+	}
+}
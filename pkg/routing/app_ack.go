@@ -0,0 +1,179 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+// AckState describes the application acknowledgment state of an outgoing bundle, requesting an acknowledgment by an
+// bpv7.AckRequestBlock.
+type AckState int
+
+const (
+	// AckUnknown is the state of bundles not requesting an acknowledgment or not being sent by this node.
+	AckUnknown AckState = iota
+
+	// AckPending is the state of bundles awaiting their acknowledgment.
+	AckPending
+
+	// Acknowledged is the state of bundles whose delivery was acknowledged by their destination.
+	Acknowledged
+
+	// AckExpired is the state of bundles whose lifetime ended without an acknowledgment.
+	AckExpired
+)
+
+func (as AckState) String() string {
+	switch as {
+	case AckPending:
+		return "pending"
+	case Acknowledged:
+		return "acknowledged"
+	case AckExpired:
+		return "expired"
+	default:
+		return "unknown"
+	}
+}
+
+// ackRetention is the duration an acknowledgment state is kept after its bundle's lifetime ended.
+const ackRetention = time.Hour
+
+// ackEntry is the tracked state of an outgoing bundle.
+type ackEntry struct {
+	state   AckState
+	expires time.Time
+}
+
+// ackTracker keeps the AckState of outgoing bundles, identified by their scrubbed BundleID.
+type ackTracker struct {
+	mutex   sync.Mutex
+	entries map[bpv7.BundleID]*ackEntry
+}
+
+func newAckTracker() *ackTracker {
+	return &ackTracker{entries: make(map[bpv7.BundleID]*ackEntry)}
+}
+
+// track a bundle as pending until its expiration.
+func (at *ackTracker) track(bid bpv7.BundleID, expires time.Time) {
+	at.mutex.Lock()
+	defer at.mutex.Unlock()
+
+	if _, exists := at.entries[bid.Scrub()]; !exists {
+		at.entries[bid.Scrub()] = &ackEntry{state: AckPending, expires: expires}
+	}
+}
+
+// acknowledge a pending bundle. False is returned for unknown or already acknowledged bundles.
+func (at *ackTracker) acknowledge(bid bpv7.BundleID) bool {
+	at.mutex.Lock()
+	defer at.mutex.Unlock()
+
+	entry, exists := at.entries[bid.Scrub()]
+	if !exists || entry.state == Acknowledged {
+		return false
+	}
+
+	entry.state = Acknowledged
+	return true
+}
+
+// state returns a bundle's AckState.
+func (at *ackTracker) state(bid bpv7.BundleID) AckState {
+	at.mutex.Lock()
+	defer at.mutex.Unlock()
+
+	if entry, exists := at.entries[bid.Scrub()]; exists {
+		return entry.state
+	}
+	return AckUnknown
+}
+
+// expire pending bundles whose lifetime ended and forget states older than the ackRetention.
+func (at *ackTracker) expire(now time.Time) {
+	at.mutex.Lock()
+	defer at.mutex.Unlock()
+
+	for bid, entry := range at.entries {
+		if now.After(entry.expires.Add(ackRetention)) {
+			delete(at.entries, bid)
+		} else if entry.state == AckPending && now.After(entry.expires) {
+			entry.state = AckExpired
+		}
+	}
+}
+
+// AckState returns the application acknowledgment state of a bundle sent by this node. Only bundles requesting an
+// acknowledgment by an bpv7.AckRequestBlock are tracked; a BundleAcknowledged Event is published on their
+// acknowledgment.
+func (c *Core) AckState(bid bpv7.BundleID) AckState {
+	return c.acks.state(bid)
+}
+
+// trackAck starts tracking an outgoing bundle, if it requests an acknowledgment.
+func (c *Core) trackAck(bndl *bpv7.Bundle) {
+	if !bndl.RequestsAck() || bndl.IsAdministrativeRecord() {
+		return
+	}
+
+	lifetime := time.Duration(bndl.PrimaryBlock.Lifetime) * time.Millisecond
+	created := time.Now()
+	if !bndl.PrimaryBlock.CreationTimestamp.IsZeroTime() {
+		created = bndl.PrimaryBlock.CreationTimestamp.DtnTime().Time()
+	}
+
+	c.acks.track(bndl.ID(), created.Add(lifetime))
+}
+
+// receiveAck updates the state of a bundle acknowledged by a locally delivered acknowledgment bundle.
+func (c *Core) receiveAck(bp BundleDescriptor) {
+	bndl := bp.MustBundle()
+	bid, ok := bndl.AcknowledgedBundle()
+	if !ok || !c.acks.acknowledge(bid) {
+		return
+	}
+
+	log.WithFields(log.Fields{
+		"bundle": bid.String(),
+		"ack":    bp.ID().String(),
+	}).Info("Received application acknowledgment")
+
+	c.events.publish(Event{Type: BundleAcknowledged, Bundle: bid.Scrub(), Peer: bndl.PrimaryBlock.SourceNode})
+}
+
+// sendAck returns an acknowledgment bundle to the source of a locally delivered bundle, if it was requested.
+func (c *Core) sendAck(bp BundleDescriptor) {
+	bndl := bp.MustBundle()
+	if !bndl.RequestsAck() || bndl.IsAdministrativeRecord() || bndl.PrimaryBlock.SourceNode == bpv7.DtnNone() {
+		return
+	}
+
+	ackBndl, err := bpv7.Builder().
+		Source(bndl.PrimaryBlock.Destination).
+		Destination(bndl.PrimaryBlock.SourceNode).
+		CreationTimestampNow().
+		Lifetime(bndl.PrimaryBlock.Lifetime).
+		Canonical(bpv7.NewAckBlock(bp.ID().Scrub()), bpv7.ReplicateBlock).
+		PayloadBlock([]byte{}).
+		Build()
+	if err != nil {
+		log.WithField("bundle", bp.ID().String()).WithError(err).Warn("Creating acknowledgment bundle failed")
+		return
+	}
+
+	log.WithFields(log.Fields{
+		"bundle": bp.ID().String(),
+		"ack":    ackBndl.ID().String(),
+	}).Info("Sending application acknowledgment")
+
+	c.SendBundle(&ackBndl)
+}
@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+func TestAckTracker(t *testing.T) {
+	at := newAckTracker()
+	now := time.Now()
+
+	acked := bpv7.BundleID{SourceNode: bpv7.MustNewEndpointID("dtn://src/"), Timestamp: bpv7.NewCreationTimestamp(bpv7.DtnTimeEpoch, 1)}
+	expired := bpv7.BundleID{SourceNode: bpv7.MustNewEndpointID("dtn://src/"), Timestamp: bpv7.NewCreationTimestamp(bpv7.DtnTimeEpoch, 2)}
+
+	at.track(acked, now.Add(time.Minute))
+	at.track(expired, now.Add(time.Minute))
+
+	if !at.acknowledge(acked) {
+		t.Fatal("pending bundle was not acknowledged")
+	} else if at.acknowledge(acked) {
+		t.Fatal("bundle was acknowledged twice")
+	}
+
+	at.expire(now.Add(2 * time.Minute))
+	for bid, state := range map[bpv7.BundleID]AckState{acked: Acknowledged, expired: AckExpired} {
+		if s := at.state(bid); s != state {
+			t.Fatalf("%v is %v, expected %v", bid, s, state)
+		}
+	}
+
+	at.expire(now.Add(time.Minute + ackRetention + time.Second))
+	if s := at.state(acked); s != AckUnknown {
+		t.Fatalf("state %v was retained", s)
+	}
+}
+
+func TestCoreAppAck(t *testing.T) {
+	c := newTestCore(t, "dtn://a/")
+	defer c.Close()
+
+	c.RegisterApplicationAgent(newCoreTestAgent(bpv7.MustNewEndpointID("dtn://a/inbox")))
+	c.RegisterApplicationAgent(newCoreTestAgent(bpv7.MustNewEndpointID("dtn://a/outbox")))
+
+	acknowledged := make(chan bpv7.BundleID, 1)
+	c.Subscribe(func(e Event) {
+		select {
+		case acknowledged <- e.Bundle:
+		default:
+		}
+	}, BundleAcknowledged)
+
+	bndl, err := bpv7.Builder().
+		Source("dtn://a/outbox").
+		Destination("dtn://a/inbox").
+		CreationTimestampNow().
+		Lifetime("10m").
+		AckRequestBlock().
+		PayloadBlock([]byte("hello ack")).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.SendBundle(&bndl)
+
+	select {
+	case bid := <-acknowledged:
+		if bid != bndl.ID() {
+			t.Fatalf("acknowledged %v, expected %v", bid, bndl.ID())
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("bundle was not acknowledged")
+	}
+
+	if state := c.AckState(bndl.ID()); state != Acknowledged {
+		t.Fatalf("bundle is %v, expected acknowledged", state)
+	}
+}
@@ -0,0 +1,126 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"fmt"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+// broadcastSuppressionCapacity is the minimum number of broadcast bundle IDs remembered to suppress duplicates.
+const broadcastSuppressionCapacity = 4096
+
+// BroadcastScope confines broadcasts of routing metadata, e.g., DTLSR's peer data, to a routing area.
+type BroadcastScope struct {
+	// HopLimit of outgoing broadcasts, inserted as a Hop Count Block. Zero falls back to the Core's hop limit.
+	HopLimit uint8
+
+	// Region tags outgoing broadcasts by a Region Block. Received broadcasts of another region are dropped. An empty
+	// Region neither tags nor drops broadcasts.
+	Region string
+}
+
+// broadcasts are the registered broadcast endpoints and the IDs of already seen broadcast bundles.
+type broadcasts struct {
+	mutex     sync.RWMutex
+	endpoints map[bpv7.EndpointID]struct{}
+	scope     BroadcastScope
+	seen      *knownBundles
+}
+
+func newBroadcasts() *broadcasts {
+	return &broadcasts{
+		endpoints: make(map[bpv7.EndpointID]struct{}),
+		seen:      newKnownBundles(broadcastSuppressionCapacity),
+	}
+}
+
+// SetBroadcastScope configures the hop limit and region of broadcasts.
+func (c *Core) SetBroadcastScope(scope BroadcastScope) {
+	c.broadcasts.mutex.Lock()
+	defer c.broadcasts.mutex.Unlock()
+
+	c.broadcasts.scope = scope
+}
+
+// RegisterBroadcastEndpoint marks an endpoint as a broadcast address, e.g., by a routing algorithm. Bundles for this
+// endpoint are confined by the BroadcastScope and their duplicates are dropped.
+func (c *Core) RegisterBroadcastEndpoint(endpoint bpv7.EndpointID) {
+	c.broadcasts.mutex.Lock()
+	defer c.broadcasts.mutex.Unlock()
+
+	c.broadcasts.endpoints[endpoint] = struct{}{}
+}
+
+// broadcastScope returns the current BroadcastScope and if an endpoint is a registered broadcast address.
+func (c *Core) broadcastScope(endpoint bpv7.EndpointID) (scope BroadcastScope, isBroadcast bool) {
+	c.broadcasts.mutex.RLock()
+	defer c.broadcasts.mutex.RUnlock()
+
+	_, isBroadcast = c.broadcasts.endpoints[endpoint]
+	return c.broadcasts.scope, isBroadcast
+}
+
+// sendBundleScopeBroadcast attaches the BroadcastScope's Hop Count and Region Block to an outgoing broadcast and
+// remembers it, dropping it when being received back from other nodes.
+func (c *Core) sendBundleScopeBroadcast(bndl *bpv7.Bundle) {
+	scope, isBroadcast := c.broadcastScope(bndl.PrimaryBlock.Destination)
+	if !isBroadcast {
+		return
+	}
+
+	var blocks []bpv7.ExtensionBlock
+	if scope.HopLimit > 0 && !bndl.HasExtensionBlock(bpv7.ExtBlockTypeHopCountBlock) {
+		blocks = append(blocks, bpv7.NewHopCountBlock(scope.HopLimit))
+	}
+	if scope.Region != "" && !bndl.HasExtensionBlock(bpv7.ExtBlockTypeRegionBlock) {
+		blocks = append(blocks, bpv7.NewRegionBlock(scope.Region))
+	}
+
+	for _, block := range blocks {
+		cb := bpv7.NewCanonicalBlock(0, bpv7.ReplicateBlock, block)
+		cb.SetCRCType(bpv7.CRC32)
+
+		if err := bndl.AddExtensionBlock(cb); err != nil {
+			log.WithFields(log.Fields{
+				"bundle": bndl.ID().String(),
+				"block":  block.BlockTypeName(),
+				"error":  err,
+			}).Error("Error attaching broadcast scope block")
+		}
+	}
+
+	c.broadcasts.seen.checkAndAdd(bndl.ID())
+}
+
+// checkBroadcastScope returns an error for a received broadcast which is either a duplicate or of another region.
+func (c *Core) checkBroadcastScope(bndl *bpv7.Bundle) error {
+	scope, isBroadcast := c.broadcastScope(bndl.PrimaryBlock.Destination)
+	if !isBroadcast {
+		return nil
+	}
+
+	if region, ok := bndl.Region(); ok && scope.Region != "" && region != scope.Region {
+		return fmt.Errorf("broadcast of region %q leaves region %q", region, scope.Region)
+	}
+
+	if c.broadcasts.seen.checkAndAdd(bndl.ID()) {
+		return fmt.Errorf("broadcast was already seen")
+	}
+	return nil
+}
+
+// admitBroadcast checks a received bundle against its BroadcastScope. A rejected broadcast is dropped silently.
+func (c *Core) admitBroadcast(bndl *bpv7.Bundle) bool {
+	if err := c.checkBroadcastScope(bndl); err != nil {
+		log.WithField("bundle", bndl.ID().String()).WithError(err).Debug("Dropping received broadcast out of scope")
+		return false
+	}
+	return true
+}
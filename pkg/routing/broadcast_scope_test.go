@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"testing"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+func TestCoreBroadcastScope(t *testing.T) {
+	c := newTestCore(t, "dtn://a/")
+	defer c.Close()
+
+	broadcast := bpv7.MustNewEndpointID("dtn://routing/test/broadcast/")
+	c.RegisterBroadcastEndpoint(broadcast)
+	c.SetBroadcastScope(BroadcastScope{HopLimit: 3, Region: "north"})
+
+	newBroadcast := func(source string) bpv7.Bundle {
+		bndl, err := bpv7.Builder().
+			Source(source).
+			Destination(broadcast).
+			CreationTimestampNow().
+			Lifetime("10m").
+			PayloadBlock([]byte("hello broadcast")).
+			Build()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return bndl
+	}
+
+	own := newBroadcast("dtn://a/")
+	c.sendBundleScopeBroadcast(&own)
+
+	if cb, err := own.ExtensionBlock(bpv7.ExtBlockTypeHopCountBlock); err != nil {
+		t.Fatal(err)
+	} else if limit := cb.Value.(*bpv7.HopCountBlock).Limit; limit != 3 {
+		t.Fatalf("hop limit is %d, expected 3", limit)
+	}
+	if region, ok := own.Region(); !ok || region != "north" {
+		t.Fatalf("region is %q (%t), expected north", region, ok)
+	}
+	if c.admitBroadcast(&own) {
+		t.Fatal("own broadcast was admitted")
+	}
+
+	foreign := newBroadcast("dtn://b/")
+	if err := foreign.AddExtensionBlock(bpv7.NewCanonicalBlock(0, bpv7.ReplicateBlock, bpv7.NewRegionBlock("south"))); err != nil {
+		t.Fatal(err)
+	}
+	if c.admitBroadcast(&foreign) {
+		t.Fatal("broadcast of another region was admitted")
+	}
+
+	local := newBroadcast("dtn://c/")
+	if err := local.AddExtensionBlock(bpv7.NewCanonicalBlock(0, bpv7.ReplicateBlock, bpv7.NewRegionBlock("north"))); err != nil {
+		t.Fatal(err)
+	}
+	if !c.admitBroadcast(&local) {
+		t.Fatal("broadcast of this region was dropped")
+	} else if c.admitBroadcast(&local) {
+		t.Fatal("duplicate broadcast was admitted")
+	}
+
+	unicast, err := bpv7.Builder().
+		Source("dtn://b/").
+		Destination("dtn://a/").
+		CreationTimestampNow().
+		Lifetime("10m").
+		PayloadBlock([]byte("hello unicast")).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		if !c.admitBroadcast(&unicast) {
+			t.Fatal("unicast was dropped")
+		}
+	}
+}
@@ -25,6 +25,15 @@ type BundleDescriptor struct {
 	Constraints map[Constraint]bool
 	Tags        map[Tag]struct{}
 
+	// Priority of this bundle, based on its bpv7.PriorityBlock or the Core's PriorityPolicy.
+	Priority bpv7.Priority
+
+	// Retries counts the retransmissions after all senders failed, see RetryPolicy.
+	Retries uint
+
+	// CustodyRetransmit is the time to retransmit a forwarded bundle in custody, see CustodyAccepted.
+	CustodyRetransmit time.Time
+
 	bndl  *bpv7.Bundle
 	store *storage.Store
 }
@@ -37,6 +46,7 @@ func NewBundleDescriptor(bid bpv7.BundleID, store *storage.Store) BundleDescript
 		Timestamp:   time.Now(),
 		Constraints: make(map[Constraint]bool),
 		Tags:        make(map[Tag]struct{}),
+		Priority:    bpv7.PriorityNormal,
 
 		bndl:  nil,
 		store: store,
@@ -52,6 +62,15 @@ func NewBundleDescriptor(bid bpv7.BundleID, store *storage.Store) BundleDescript
 		if v, ok := bi.Properties["bundlepack/constraints"]; ok {
 			descriptor.Constraints = v.(map[Constraint]bool)
 		}
+		if v, ok := bi.Properties["bundlepack/custody-retransmit"]; ok {
+			descriptor.CustodyRetransmit = v.(time.Time)
+		}
+		if v, ok := bi.Properties["bundlepack/priority"]; ok {
+			descriptor.Priority = v.(bpv7.Priority)
+		}
+		if v, ok := bi.Properties["bundlepack/retries"]; ok {
+			descriptor.Retries = v.(uint)
+		}
 	}
 
 	return descriptor
@@ -61,6 +80,9 @@ func NewBundleDescriptor(bid bpv7.BundleID, store *storage.Store) BundleDescript
 func NewBundleDescriptorFromBundle(b bpv7.Bundle, store *storage.Store) BundleDescriptor {
 	descriptor := NewBundleDescriptor(b.ID(), store)
 	descriptor.bndl = &b
+	if !store.KnowsBundle(descriptor.Id.Scrub()) {
+		descriptor.Priority = b.Priority()
+	}
 
 	_ = descriptor.Sync()
 	return descriptor
@@ -81,6 +103,9 @@ func (descriptor BundleDescriptor) Sync() error {
 		bi.Properties["bundlepack/receiver"] = descriptor.Receiver
 		bi.Properties["bundlepack/timestamp"] = descriptor.Timestamp
 		bi.Properties["bundlepack/constraints"] = descriptor.Constraints
+		bi.Properties["bundlepack/custody-retransmit"] = descriptor.CustodyRetransmit
+		bi.Properties["bundlepack/priority"] = descriptor.Priority
+		bi.Properties["bundlepack/retries"] = descriptor.Retries
 
 		log.WithFields(log.Fields{
 			"bundle":      descriptor.Id,
@@ -104,7 +129,7 @@ func (descriptor *BundleDescriptor) Bundle() (*bpv7.Bundle, error) {
 
 	if bi, err := descriptor.store.QueryId(descriptor.Id.Scrub()); err != nil {
 		return nil, err
-	} else if bndl, err := bi.Parts[0].Load(); err != nil {
+	} else if bndl, err := descriptor.store.LoadBundlePart(bi.Parts[0]); err != nil {
 		return nil, err
 	} else {
 		descriptor.bndl = &bndl
@@ -177,8 +202,25 @@ func (descriptor *BundleDescriptor) RemoveTag(tag Tag) {
 	delete(descriptor.Tags, tag)
 }
 
+// PreviousNode returns the Endpoint ID of the node this bundle was received from, based on its Previous Node Block.
+// The second return value is false for bundles without such a block, e.g., locally created ones.
+func (descriptor *BundleDescriptor) PreviousNode() (bpv7.EndpointID, bool) {
+	bndl, err := descriptor.Bundle()
+	if err != nil {
+		return bpv7.EndpointID{}, false
+	}
+
+	pnBlock, err := bndl.ExtensionBlock(bpv7.ExtBlockTypePreviousNodeBlock)
+	if err != nil {
+		return bpv7.EndpointID{}, false
+	}
+
+	return pnBlock.Value.(*bpv7.PreviousNodeBlock).Endpoint(), true
+}
+
 // UpdateBundleAge updates the bundle's Bundle Age block based on its reception
-// timestamp, if such a block exists.
+// timestamp, if such a block exists. The age is incremented by the milliseconds
+// passed since reception and the new age is returned.
 func (descriptor *BundleDescriptor) UpdateBundleAge() (uint64, error) {
 	bndl, err := descriptor.Bundle()
 	if err != nil {
@@ -191,7 +233,7 @@ func (descriptor *BundleDescriptor) UpdateBundleAge() (uint64, error) {
 	}
 
 	age := ageBlock.Value.(*bpv7.BundleAgeBlock)
-	return age.Increment(uint64(time.Since(descriptor.Timestamp)) / 1000), nil
+	return age.Increment(uint64(time.Since(descriptor.Timestamp).Milliseconds())), nil
 }
 
 func (descriptor BundleDescriptor) String() string {
@@ -0,0 +1,96 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// CheckpointingAlgorithm is an optional interface for an Algorithm to persist its state, e.g., learned link states or
+// delivery predictabilities, across restarts. Otherwise, a restarted node would trigger a network-wide reconvergence.
+type CheckpointingAlgorithm interface {
+	// Checkpoint serializes the Algorithm's current state.
+	Checkpoint() ([]byte, error)
+
+	// Restore a state created by Checkpoint of the same algorithm.
+	Restore(data []byte) error
+}
+
+// checkpointCronJob is the name of the Cron job periodically checkpointing the routing Algorithm.
+const checkpointCronJob = "routing_checkpoint"
+
+// checkpointKey of the current routing Algorithm's state within the store.
+func (c *Core) checkpointKey() string {
+	return "routing/" + c.routingConf.Algorithm
+}
+
+// SetRoutingCheckpoint enables the periodic checkpointing of the routing Algorithm's state to the store, if it
+// implements the CheckpointingAlgorithm. When being enabled, a previous checkpoint is restored immediately. A final
+// checkpoint is created when the Core is closed. Zero disables checkpointing.
+func (c *Core) SetRoutingCheckpoint(interval time.Duration) error {
+	enabled := c.checkpointInterval > 0
+
+	c.Cron.Unregister(checkpointCronJob)
+	c.checkpointInterval = interval
+
+	if interval == 0 {
+		return nil
+	}
+
+	if !enabled {
+		if err := c.restoreRouting(); err != nil {
+			return err
+		}
+	}
+	return c.Cron.Register(checkpointCronJob, c.checkpointRouting, interval)
+}
+
+// restoreRouting restores the routing Algorithm's last checkpoint, if any.
+func (c *Core) restoreRouting() error {
+	algo, ok := c.routing.(CheckpointingAlgorithm)
+	if !ok {
+		return nil
+	}
+
+	data, err := c.Store.GetState(c.checkpointKey())
+	if err != nil {
+		return err
+	} else if data == nil {
+		return nil
+	}
+
+	if err := algo.Restore(data); err != nil {
+		return fmt.Errorf("restoring %s checkpoint failed: %v", c.routingConf.Algorithm, err)
+	}
+
+	log.WithField("algorithm", c.routingConf.Algorithm).Info("Restored routing checkpoint")
+	return nil
+}
+
+// checkpointRouting stores the routing Algorithm's current state, if checkpointing is enabled.
+func (c *Core) checkpointRouting() {
+	algo, ok := c.routing.(CheckpointingAlgorithm)
+	if !ok || c.checkpointInterval == 0 {
+		return
+	}
+
+	logger := log.WithField("algorithm", c.routingConf.Algorithm)
+
+	data, err := algo.Checkpoint()
+	if err != nil {
+		logger.WithError(err).Warn("Creating routing checkpoint failed")
+		return
+	}
+
+	if err := c.Store.PutState(c.checkpointKey(), data); err != nil {
+		logger.WithError(err).Warn("Storing routing checkpoint failed")
+		return
+	}
+
+	logger.WithField("size", len(data)).Debug("Stored routing checkpoint")
+}
@@ -0,0 +1,88 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+func TestCoreRoutingCheckpoint(t *testing.T) {
+	dir := t.TempDir()
+	nodeId := bpv7.MustNewEndpointID("dtn://a/")
+	peer := bpv7.MustNewEndpointID("dtn://b/")
+	conf := RoutingConf{
+		Algorithm:   "prophet",
+		ProphetConf: ProphetConfig{PInit: 0.75, Beta: 0.25, Gamma: 0.98, AgeInterval: "1h"},
+	}
+
+	c, err := NewCore(dir, nodeId, false, conf, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.SetRoutingCheckpoint(time.Hour); err != nil {
+		t.Fatal(err)
+	}
+
+	c.routing.(*Prophet).encounter(peer)
+	c.Close()
+
+	c, err = NewCore(dir, nodeId, false, conf, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	if pred := c.routing.(*Prophet).predictabilities[peer]; pred != 0 {
+		t.Fatalf("predictability is %f before restoring", pred)
+	}
+
+	if err := c.SetRoutingCheckpoint(time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	if pred := c.routing.(*Prophet).predictabilities[peer]; pred != 0.75 {
+		t.Fatalf("restored predictability is %f, expected 0.75", pred)
+	}
+	if _, exists := c.Cron.jobs[checkpointCronJob]; !exists {
+		t.Fatal("checkpoint cron job is missing")
+	}
+}
+
+func TestDTLSRCheckpoint(t *testing.T) {
+	c, err := NewCore(t.TempDir(), bpv7.MustNewEndpointID("dtn://a/"), false, RoutingConf{
+		Algorithm: "dtlsr",
+		DTLSRConf: DTLSRConfig{RecomputeTime: "30s", BroadcastTime: "30s", PurgeTime: "10m"},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	dtlsr := c.routing.(*DTLSR)
+	peer := bpv7.MustNewEndpointID("dtn://b/")
+	dtlsr.receivedData[peer] = bpv7.DTLSRPeerData{
+		ID:        peer,
+		Timestamp: 23,
+		Peers:     map[bpv7.EndpointID]bpv7.DtnTime{bpv7.MustNewEndpointID("dtn://c/"): 0},
+	}
+
+	data, err := dtlsr.Checkpoint()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	delete(dtlsr.receivedData, peer)
+	if err := dtlsr.Restore(data); err != nil {
+		t.Fatal(err)
+	}
+
+	if restored, exists := dtlsr.receivedData[peer]; !exists {
+		t.Fatal("peer data was not restored")
+	} else if restored.Timestamp != 23 || len(restored.Peers) != 1 {
+		t.Fatalf("restored peer data %v differs", restored)
+	}
+}
@@ -0,0 +1,149 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+// ClockSkewPolicy configures how the Core copes with drifting clocks of other nodes.
+type ClockSkewPolicy struct {
+	// Tolerance is the maximum duration a received bundle's creation time might lie in the future, in addition to
+	// its source's estimated clock offset. Zero results in bpv7.DefaultClockTolerance.
+	Tolerance time.Duration
+}
+
+// tolerance returns the configured Tolerance or its default.
+func (policy ClockSkewPolicy) tolerance() time.Duration {
+	if policy.Tolerance == 0 {
+		return bpv7.DefaultClockTolerance
+	}
+	return policy.Tolerance
+}
+
+// peerClockSamples is the number of recent samples from which a peer's clock offset is estimated.
+const peerClockSamples = 16
+
+// peerClocks estimates the clock offsets of peers from the creation time of their bundles, received directly from
+// them. As transmission and storage delays only reduce such a sample, the largest recent sample is the estimate.
+type peerClocks struct {
+	mutex   sync.Mutex
+	samples map[string][]time.Duration
+}
+
+func newPeerClocks() *peerClocks {
+	return &peerClocks{samples: make(map[string][]time.Duration)}
+}
+
+// peerClockKey identifies a node by its scheme and authority, shared by all of its endpoints.
+func peerClockKey(eid bpv7.EndpointID) string {
+	if eid.EndpointType == nil {
+		return ""
+	}
+	return eid.EndpointType.SchemeName() + ":" + eid.Authority()
+}
+
+// observe a sample, the difference between a peer's creation time and the local reception time.
+func (pc *peerClocks) observe(peer bpv7.EndpointID, sample time.Duration) {
+	pc.mutex.Lock()
+	defer pc.mutex.Unlock()
+
+	key := peerClockKey(peer)
+	samples := append(pc.samples[key], sample)
+	if len(samples) > peerClockSamples {
+		samples = samples[len(samples)-peerClockSamples:]
+	}
+	pc.samples[key] = samples
+}
+
+// offset returns the estimated clock offset of a peer's node, positive if its clock is ahead.
+func (pc *peerClocks) offset(peer bpv7.EndpointID) (offset time.Duration, ok bool) {
+	pc.mutex.Lock()
+	defer pc.mutex.Unlock()
+
+	for i, sample := range pc.samples[peerClockKey(peer)] {
+		if i == 0 || sample > offset {
+			offset = sample
+		}
+		ok = true
+	}
+	return
+}
+
+// SetClockSkewPolicy configures the tolerance of creation times lying in the future.
+func (c *Core) SetClockSkewPolicy(policy ClockSkewPolicy) {
+	c.updateSettings(func(s *coreSettings) { s.clockSkew = policy })
+}
+
+// PeerClockOffset returns the estimated clock offset of a node, positive if its clock is ahead of the local one. The
+// estimate is based on the creation times of bundles received directly from their source, e.g., routing metadata.
+func (c *Core) PeerClockOffset(node bpv7.EndpointID) (time.Duration, bool) {
+	return c.peerClocks.offset(node)
+}
+
+// observePeerClock samples the source's clock offset of a bundle, received directly from its source.
+func (c *Core) observePeerClock(bp BundleDescriptor) {
+	bndl := bp.MustBundle()
+	if bndl.PrimaryBlock.CreationTimestamp.IsZeroTime() {
+		return
+	}
+
+	prevNode, ok := bp.PreviousNode()
+	if !ok || !prevNode.SameNode(bndl.PrimaryBlock.SourceNode) {
+		return
+	}
+
+	sample := bndl.PrimaryBlock.CreationTimestamp.DtnTime().Time().Sub(bp.Timestamp.Add(bpv7.ClockOffset()))
+	c.peerClocks.observe(prevNode, sample)
+
+	log.WithFields(log.Fields{
+		"bundle": bp.ID().String(),
+		"peer":   prevNode,
+		"sample": sample,
+	}).Debug("Sampled peer's clock offset")
+}
+
+// sourceClockOffset returns the positive estimated clock offset of a bundle's source, or zero.
+func (c *Core) sourceClockOffset(bndl *bpv7.Bundle) time.Duration {
+	if offset, ok := c.peerClocks.offset(bndl.PrimaryBlock.SourceNode); ok && offset > 0 {
+		return offset
+	}
+	return 0
+}
+
+// isLifetimeExceeded checks a bundle's lifetime like bpv7.Bundle.IsLifetimeExceeded, but corrects its creation time
+// by its source's estimated clock offset.
+func (c *Core) isLifetimeExceeded(bndl *bpv7.Bundle) bool {
+	offset := c.sourceClockOffset(bndl)
+	if offset == 0 || bndl.PrimaryBlock.CreationTimestamp.IsZeroTime() {
+		return bndl.IsLifetimeExceeded()
+	}
+
+	created := bndl.PrimaryBlock.CreationTimestamp.DtnTime().Time().Add(-offset)
+	return bpv7.Now().After(created.Add(time.Duration(bndl.PrimaryBlock.Lifetime) * time.Millisecond))
+}
+
+// correctExpiration moves a stored bundle's expiration date by its source's estimated clock offset.
+func (c *Core) correctExpiration(bp BundleDescriptor) {
+	offset := c.sourceClockOffset(bp.MustBundle())
+	if offset == 0 {
+		return
+	}
+
+	bi, err := c.Store.QueryId(bp.ID().Scrub())
+	if err != nil {
+		return
+	}
+
+	bi.Expires = bi.Expires.Add(-offset)
+	if err := c.Store.Update(bi); err != nil {
+		log.WithField("bundle", bp.ID().String()).WithError(err).Warn("Failed to correct bundle's expiration date")
+	}
+}
@@ -0,0 +1,81 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+func TestPeerClocks(t *testing.T) {
+	pc := newPeerClocks()
+	peer := bpv7.MustNewEndpointID("dtn://peer/")
+
+	if _, ok := pc.offset(peer); ok {
+		t.Fatal("offset without samples")
+	}
+
+	pc.observe(bpv7.MustNewEndpointID("dtn://peer/app"), -time.Minute)
+	pc.observe(peer, time.Minute)
+	pc.observe(peer, -2*time.Minute)
+
+	if offset, ok := pc.offset(peer); !ok || offset != time.Minute {
+		t.Fatalf("expected offset of a minute, got %v", offset)
+	}
+
+	// Old samples are replaced by recent ones.
+	for i := 0; i < peerClockSamples; i++ {
+		pc.observe(peer, time.Second)
+	}
+	if offset, _ := pc.offset(peer); offset != time.Second {
+		t.Fatalf("expected offset of a second, got %v", offset)
+	}
+}
+
+func TestCoreClockSkew(t *testing.T) {
+	c := newTestCore(t, "dtn://local/")
+	defer c.Close()
+
+	source := bpv7.MustNewEndpointID("dtn://drifting/")
+
+	// The source's clock is ten minutes ahead, as sampled from a bundle received directly.
+	metadata, err := bpv7.Builder().
+		Source("dtn://drifting/meta").
+		Destination("dtn://local/").
+		CreationTimestampTime(time.Now().Add(10*time.Minute)).
+		Lifetime("10m").
+		Canonical(bpv7.NewPreviousNodeBlock(source), bpv7.ReplicateBlock).
+		PayloadBlock([]byte("metadata")).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.observePeerClock(NewBundleDescriptorFromBundle(metadata, c.Store))
+
+	if offset, ok := c.PeerClockOffset(source); !ok || offset < 9*time.Minute || offset > 10*time.Minute {
+		t.Fatalf("expected an offset of about ten minutes, got %v", offset)
+	}
+
+	// This bundle was created twenty minutes ago, but only ten minutes ago by the source's clock.
+	bndl, err := bpv7.Builder().
+		Source("dtn://drifting/app").
+		Destination("dtn://elsewhere/").
+		CreationTimestampTime(time.Now().Add(-10 * time.Minute)).
+		Lifetime("15m").
+		PayloadBlock([]byte("hello world")).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if bndl.IsLifetimeExceeded() {
+		t.Fatal("uncorrected lifetime is exceeded")
+	}
+	if !c.isLifetimeExceeded(&bndl) {
+		t.Fatal("corrected lifetime is not exceeded")
+	}
+}
@@ -0,0 +1,109 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+const (
+	// privateBlockTypeMin is the first block type code of the range for private and experimental use, RFC 9171,
+	// section 9.1. All of dtn7's own extension blocks are within this range.
+	privateBlockTypeMin uint64 = 192
+	// privateBlockTypeMax is the last block type code of the range for private and experimental use.
+	privateBlockTypeMax uint64 = 255
+)
+
+// CompatibilityProfile adapts the Core for a network shared with another Bundle Protocol implementation. The zero
+// value is dtn7's default behavior.
+type CompatibilityProfile struct {
+	// Name of the profile, e.g., "ion".
+	Name string
+
+	// IpnOnly requires the node ID and all of its aliases to be ipn endpoints of the administrative service number
+	// zero, "ipn:N.0", as the other implementation cannot address dtn endpoints.
+	IpnOnly bool
+
+	// StripPrivateBlocks removes extension blocks of the private and experimental block type range from forwarded
+	// bundles. The other implementation might assign different meanings to these block type codes, or delete bundles
+	// containing them. As dtn7's PRoPHET, DTLSR, and Spray routing exchange such blocks, they cannot be used.
+	StripPrivateBlocks bool
+}
+
+// compatibilityProfiles are the known CompatibilityProfiles by their names.
+var compatibilityProfiles = map[string]CompatibilityProfile{
+	// ION is NASA JPL's Interplanetary Overlay Network, whose nodes are identified by ipn node numbers. This profile only
+	// covers ION's naming and extension block conventions. It was not tested against ION nodes, and ION's LTP
+	// convergence layer is not available in dtn7.
+	"ion": {
+		Name:               "ion",
+		IpnOnly:            true,
+		StripPrivateBlocks: true,
+	},
+}
+
+// NewCompatibilityProfile by its name. An empty name results in the default profile.
+func NewCompatibilityProfile(name string) (CompatibilityProfile, error) {
+	if name == "" {
+		return CompatibilityProfile{}, nil
+	}
+
+	if profile, ok := compatibilityProfiles[name]; ok {
+		return profile, nil
+	}
+
+	var names []string
+	for n := range compatibilityProfiles {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return CompatibilityProfile{}, fmt.Errorf("unknown compatibility profile %q, expected one of %s", name, strings.Join(names, ", "))
+}
+
+// Check if a node's configuration is compatible with this profile.
+func (profile CompatibilityProfile) Check(nodeId bpv7.EndpointID, aliases []bpv7.EndpointID, routingConf RoutingConf) error {
+	if profile.IpnOnly {
+		for _, eid := range append([]bpv7.EndpointID{nodeId}, aliases...) {
+			if ipn, ok := eid.EndpointType.(bpv7.IpnEndpoint); !ok || ipn.Service != 0 {
+				return fmt.Errorf("compatibility profile %s requires ipn:N.0 node IDs, not %v", profile.Name, eid)
+			}
+		}
+	}
+
+	if profile.StripPrivateBlocks {
+		switch routingConf.Algorithm {
+		case "spray", "binary_spray", "dtlsr", "prophet":
+			return fmt.Errorf("compatibility profile %s strips the extension blocks of %s routing", profile.Name, routingConf.Algorithm)
+		}
+	}
+
+	return nil
+}
+
+// isPrivateBlockType checks if a block type code is within the range for private and experimental use.
+func isPrivateBlockType(blockType uint64) bool {
+	return blockType >= privateBlockTypeMin && blockType <= privateBlockTypeMax
+}
+
+// stripPrivateBlocks from a bundle's own copy of its canonical blocks.
+func stripPrivateBlocks(bndl *bpv7.Bundle) {
+	blocks := make([]bpv7.CanonicalBlock, 0, len(bndl.CanonicalBlocks))
+	for _, cb := range bndl.CanonicalBlocks {
+		if !isPrivateBlockType(cb.TypeCode()) {
+			blocks = append(blocks, cb)
+		}
+	}
+	bndl.CanonicalBlocks = blocks
+}
+
+// SetCompatibilityProfile for a network shared with another Bundle Protocol implementation. The profile should be
+// checked against the node's configuration by CompatibilityProfile.Check before.
+func (c *Core) SetCompatibilityProfile(profile CompatibilityProfile) {
+	c.updateSettings(func(s *coreSettings) { s.compatibility = profile })
+}
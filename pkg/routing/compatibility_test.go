@@ -0,0 +1,119 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+	"github.com/dtn7/dtn7-go/pkg/cla"
+)
+
+func TestCompatibilityProfileCheck(t *testing.T) {
+	ion, err := NewCompatibilityProfile("ion")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewCompatibilityProfile("unknown"); err == nil {
+		t.Fatal("unknown profile was accepted")
+	}
+
+	epidemic := RoutingConf{Algorithm: "epidemic"}
+	tests := []struct {
+		name        string
+		profile     CompatibilityProfile
+		nodeId      string
+		aliases     []string
+		routingConf RoutingConf
+		valid       bool
+	}{
+		{"default dtn", CompatibilityProfile{}, "dtn://a/", nil, RoutingConf{Algorithm: "prophet"}, true},
+		{"ion", ion, "ipn:23.0", []string{"ipn:42.0"}, epidemic, true},
+		{"ion dtn node", ion, "dtn://a/", nil, epidemic, false},
+		{"ion service node", ion, "ipn:23.1", nil, epidemic, false},
+		{"ion dtn alias", ion, "ipn:23.0", []string{"dtn://a/"}, epidemic, false},
+		{"ion prophet", ion, "ipn:23.0", nil, RoutingConf{Algorithm: "prophet"}, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var aliases []bpv7.EndpointID
+			for _, alias := range test.aliases {
+				aliases = append(aliases, bpv7.MustNewEndpointID(alias))
+			}
+
+			err := test.profile.Check(bpv7.MustNewEndpointID(test.nodeId), aliases, test.routingConf)
+			if (err == nil) != test.valid {
+				t.Fatalf("expected valid %t, got %v", test.valid, err)
+			}
+		})
+	}
+}
+
+func TestCoreDispatchStripPrivateBlocks(t *testing.T) {
+	c, err := NewCore(t.TempDir(), bpv7.MustNewEndpointID("ipn:1.0"), false, RoutingConf{Algorithm: "epidemic"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	ion, err := NewCompatibilityProfile("ion")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.SetCompatibilityProfile(ion)
+
+	bndl, err := bpv7.Builder().
+		CRC(bpv7.CRC16).
+		Source("ipn:1.1").
+		Destination("ipn:2.1").
+		CreationTimestampNow().
+		Lifetime("10m").
+		HopCountBlock(64).
+		PriorityBlock(bpv7.PriorityExpedited).
+		TransitLogBlock().
+		PayloadBlock([]byte("hello ion")).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	bp := NewBundleDescriptorFromBundle(bndl, c.Store)
+
+	var sent []byte
+	node := &dispatchSender{bpv7.MustNewEndpointID("ipn:2.0"), func(b bpv7.Bundle) error {
+		buff := new(bytes.Buffer)
+		if err := b.WriteBundle(buff); err != nil {
+			return err
+		}
+		sent = buff.Bytes()
+		return nil
+	}}
+
+	if result := c.dispatch(bp, []cla.ConvergenceSender{node}); !result.sent {
+		t.Fatal("bundle was not sent")
+	}
+
+	received, err := bpv7.ParseBundle(bytes.NewReader(sent))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, cb := range received.CanonicalBlocks {
+		if isPrivateBlockType(cb.TypeCode()) {
+			t.Fatalf("private block %v was sent", cb)
+		}
+	}
+	if len(received.CanonicalBlocks) != 2 {
+		t.Fatalf("expected the hop count and payload block, got %v", received.CanonicalBlocks)
+	}
+	if data, err := received.PayloadData(); err != nil || string(data) != "hello ion" {
+		t.Fatalf("payload differs: %q, %v", data, err)
+	}
+
+	// The stored bundle keeps its blocks.
+	if _, err := bp.MustBundle().ExtensionBlock(bpv7.ExtBlockTypePriorityBlock); err != nil {
+		t.Fatal(err)
+	}
+}
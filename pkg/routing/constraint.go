@@ -26,6 +26,14 @@ const (
 	// LocalEndpoint is assigned to a bundle after delivery to a local endpoint.
 	// This constraint demands storage until the endpoint removes this constraint.
 	LocalEndpoint Constraint = iota
+
+	// CustodyAccepted is assigned to a bundle if this node accepted its custody. The bundle must be retained and
+	// retransmitted until another node accepts custody or the bundle is delivered.
+	CustodyAccepted Constraint = iota
+
+	// GroupDelivered is assigned to a bundle for a group endpoint after its local delivery, while it is still being
+	// forwarded to other group members.
+	GroupDelivered Constraint = iota
 )
 
 func (c Constraint) String() string {
@@ -45,6 +53,12 @@ func (c Constraint) String() string {
 	case LocalEndpoint:
 		return "local endpoint"
 
+	case CustodyAccepted:
+		return "custody accepted"
+
+	case GroupDelivered:
+		return "group delivered"
+
 	default:
 		return "unknown"
 	}
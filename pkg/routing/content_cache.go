@@ -0,0 +1,214 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+// contentEntry is a cached bundle of a content name, see bpv7.ContentBlock.
+type contentEntry struct {
+	name    string
+	bundle  bpv7.Bundle
+	size    uint64
+	expires time.Time
+}
+
+// contentCache keeps copies of the latest bundles of content names up to a capacity in bytes, evicting the least
+// recently used content first. Entries end with their bundle's lifetime.
+type contentCache struct {
+	mutex    sync.Mutex
+	capacity uint64
+	size     uint64
+	entries  map[string]*list.Element
+	lru      *list.List
+}
+
+func newContentCache(capacity uint64) *contentCache {
+	return &contentCache{
+		capacity: capacity,
+		entries:  make(map[string]*list.Element),
+		lru:      list.New(),
+	}
+}
+
+// put a content bundle into the cache, replacing a previous bundle of the same name. Bundles exceeding the capacity
+// on their own are not cached.
+func (cc *contentCache) put(bndl bpv7.Bundle, now time.Time) {
+	name, ok := bndl.Content()
+	if !ok {
+		return
+	}
+
+	cc.mutex.Lock()
+	defer cc.mutex.Unlock()
+
+	// Bundles retried from the store are dispatched again, but only cached once.
+	elem, exists := cc.entries[name]
+	if exists && elem.Value.(*contentEntry).bundle.ID() == bndl.ID() {
+		return
+	}
+
+	size, err := bndl.SerializedSize()
+	if err != nil || size > cc.capacity {
+		return
+	}
+
+	created := now
+	if !bndl.PrimaryBlock.CreationTimestamp.IsZeroTime() {
+		created = bndl.PrimaryBlock.CreationTimestamp.DtnTime().Time()
+	}
+	expires := created.Add(time.Duration(bndl.PrimaryBlock.Lifetime) * time.Millisecond)
+	if !expires.After(now) {
+		return
+	}
+
+	// The bundle is copied, as the original's blocks are altered while being forwarded.
+	bndlCopy, err := bndl.Copy()
+	if err != nil {
+		return
+	}
+
+	if exists {
+		cc.remove(elem)
+	}
+
+	for cc.size+size > cc.capacity {
+		cc.remove(cc.lru.Back())
+	}
+
+	cc.entries[name] = cc.lru.PushFront(&contentEntry{name: name, bundle: bndlCopy, size: size, expires: expires})
+	cc.size += size
+}
+
+// get the cached bundle of a content name, if it has not expired yet.
+func (cc *contentCache) get(name string, now time.Time) (entry contentEntry, ok bool) {
+	cc.mutex.Lock()
+	defer cc.mutex.Unlock()
+
+	elem, exists := cc.entries[name]
+	if !exists {
+		return
+	} else if e := elem.Value.(*contentEntry); now.After(e.expires) {
+		cc.remove(elem)
+		return
+	} else {
+		cc.lru.MoveToFront(elem)
+		return *e, true
+	}
+}
+
+// expire the cached bundles whose lifetime ended.
+func (cc *contentCache) expire(now time.Time) {
+	cc.mutex.Lock()
+	defer cc.mutex.Unlock()
+
+	for _, elem := range cc.entries {
+		if now.After(elem.Value.(*contentEntry).expires) {
+			cc.remove(elem)
+		}
+	}
+}
+
+// remove an element, while the mutex is held.
+func (cc *contentCache) remove(elem *list.Element) {
+	e := cc.lru.Remove(elem).(*contentEntry)
+	delete(cc.entries, e.name)
+	cc.size -= e.size
+}
+
+// SetContentCache enables caching bundles of named content, as marked by a bpv7.ContentBlock, up to the capacity in
+// bytes. Relayed bundles requesting a cached content by a bpv7.InterestBlock are answered by this node and not
+// forwarded any further. As the cache is kept in memory, it starts empty after a restart. Zero disables this cache.
+// An unchanged capacity keeps the cached bundles.
+func (c *Core) SetContentCache(capacity uint64) {
+	c.updateSettings(func(s *coreSettings) {
+		if capacity == 0 {
+			s.contentCache = nil
+		} else if s.contentCache == nil || s.contentCache.capacity != capacity {
+			s.contentCache = newContentCache(capacity)
+		}
+	})
+}
+
+// cacheContent of a dispatched bundle, if the content cache is enabled.
+func (c *Core) cacheContent(bndl *bpv7.Bundle) {
+	cache := c.loadSettings().contentCache
+	if cache == nil || !bndl.HasExtensionBlock(bpv7.ExtBlockTypeContentBlock) {
+		return
+	}
+
+	cache.put(*bndl, bpv7.Now())
+}
+
+// answerInterest of a dispatched bundle for a cached content by a new bundle of the cached payload to the interested
+// source, and returns true. The answered interest is not forwarded any further and deleted. Interests addressed to
+// this node are left to its application.
+func (c *Core) answerInterest(bp BundleDescriptor, bndl *bpv7.Bundle) bool {
+	cache := c.loadSettings().contentCache
+	if cache == nil {
+		return false
+	}
+
+	name, ok := bndl.Interest()
+	if !ok {
+		return false
+	}
+
+	dst, src := bndl.PrimaryBlock.Destination, bndl.PrimaryBlock.SourceNode
+	if src == bpv7.DtnNone() || c.HasEndpoint(dst) || c.isGroupMember(dst) {
+		return false
+	}
+
+	now := bpv7.Now()
+	entry, ok := cache.get(name, now)
+	if !ok {
+		return false
+	}
+
+	payload, err := entry.bundle.PayloadData()
+	if err != nil {
+		return false
+	}
+
+	answer, err := bpv7.Builder().
+		Source(c.NodeId).
+		Destination(src).
+		CreationTimestampNow().
+		Lifetime(entry.expires.Sub(now)).
+		ContentBlock(name).
+		PayloadBlock(payload).
+		Build()
+	if err != nil {
+		log.WithField("bundle", bp.ID().String()).WithError(err).Warn("Creating answer of cached content erred")
+		return false
+	}
+
+	log.WithFields(log.Fields{
+		"bundle":  bp.ID().String(),
+		"content": name,
+		"cached":  entry.bundle.ID().String(),
+		"answer":  answer.ID().String(),
+	}).Info("Answering interest from the content cache")
+
+	c.SendBundle(&answer)
+
+	// Answering ends the custody transfer like a delivery.
+	if !bp.HasConstraint(CustodyAccepted) {
+		c.signalCustodian(bp, true, bpv7.NoInformation)
+	}
+
+	c.events.publish(Event{Type: BundleDeleted, Bundle: bp.ID(), Reason: bpv7.NoInformation})
+
+	bp.PurgeConstraints()
+	_ = bp.Sync()
+	return true
+}
@@ -0,0 +1,166 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/agent"
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+// contentBundle of a name and payload, created at the given time.
+func contentBundle(t *testing.T, name string, payload []byte, created time.Time) bpv7.Bundle {
+	bndl, err := bpv7.Builder().
+		Source("dtn://producer/").
+		Destination("dtn://consumer/").
+		CreationTimestampTime(created).
+		Lifetime("10m").
+		ContentBlock(name).
+		PayloadBlock(payload).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return bndl
+}
+
+func TestContentCache(t *testing.T) {
+	now := time.Now()
+
+	a := contentBundle(t, "a", make([]byte, 400), now)
+	size, err := a.SerializedSize()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cc := newContentCache(2*size + size/2)
+	cc.put(a, now)
+	cc.put(contentBundle(t, "b", make([]byte, 400), now), now)
+
+	// Using "a" makes "b" the least recently used content, being evicted for "c".
+	if _, ok := cc.get("a", now); !ok {
+		t.Fatal("content a is not cached")
+	}
+	cc.put(contentBundle(t, "c", make([]byte, 400), now), now)
+
+	for name, cached := range map[string]bool{"a": true, "b": false, "c": true} {
+		if _, ok := cc.get(name, now); ok != cached {
+			t.Fatalf("content %s is cached: %t, expected %t", name, ok, cached)
+		}
+	}
+
+	// A newer bundle replaces the content's previous bundle.
+	a2 := contentBundle(t, "a", []byte("newer"), now.Add(time.Second))
+	cc.put(a2, now)
+	if entry, ok := cc.get("a", now); !ok || entry.bundle.ID() != a2.ID() {
+		t.Fatalf("content a was not replaced, got %v", entry.bundle.ID())
+	}
+
+	// Bundles exceeding the capacity on their own are not cached.
+	cc.put(contentBundle(t, "d", make([]byte, 4*size), now), now)
+	if _, ok := cc.get("d", now); ok {
+		t.Fatal("oversized content was cached")
+	}
+
+	cc.expire(now.Add(11 * time.Minute))
+	if _, ok := cc.get("c", now); ok {
+		t.Fatal("expired content is still cached")
+	}
+}
+
+func TestCoreSetContentCache(t *testing.T) {
+	c := newTestCore(t, "dtn://a/")
+	defer c.Close()
+
+	bndl := contentBundle(t, "a", []byte("hello world"), time.Now())
+
+	c.SetContentCache(1 << 20)
+	c.cacheContent(&bndl)
+
+	// Reloading an unchanged capacity keeps the cached bundles.
+	c.SetContentCache(1 << 20)
+	if _, ok := c.loadSettings().contentCache.get("a", time.Now()); !ok {
+		t.Fatal("content was dropped by setting an unchanged capacity")
+	}
+
+	c.SetContentCache(1 << 21)
+	if _, ok := c.loadSettings().contentCache.get("a", time.Now()); ok {
+		t.Fatal("content is cached after changing the capacity")
+	}
+
+	c.SetContentCache(0)
+	if c.loadSettings().contentCache != nil {
+		t.Fatal("content cache was not disabled")
+	}
+}
+
+func TestCoreAnswerInterest(t *testing.T) {
+	c := newTestCore(t, "dtn://relay/")
+	defer c.Close()
+	c.SetContentCache(1 << 20)
+
+	app := &coreTestAgent{
+		endpoint: bpv7.MustNewEndpointID("dtn://relay/app"),
+		receiver: make(chan agent.Message),
+		sender:   make(chan agent.Message),
+	}
+	c.RegisterApplicationAgent(app)
+
+	// The content bundle is relayed, but cannot be forwarded yet.
+	content, err := bpv7.Builder().
+		Source("dtn://relay/producer").
+		Destination("dtn://village/").
+		CreationTimestampNow().
+		Lifetime("10m").
+		ContentBlock("weather/today").
+		PayloadBlock([]byte("sunny")).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.SendBundle(&content)
+
+	interest, err := bpv7.Builder().
+		Source("dtn://relay/app").
+		Destination("dtn://weather-service/").
+		CreationTimestampNow().
+		Lifetime("10m").
+		InterestBlock("weather/today").
+		PayloadBlock([]byte{}).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.SendBundle(&interest)
+
+	select {
+	case msg := <-app.receiver:
+		bndl := msg.(agent.BundleMessage).Bundle
+		if name, ok := bndl.Content(); !ok || name != "weather/today" {
+			t.Fatalf("answer has content %q (%t)", name, ok)
+		} else if payload, _ := bndl.PayloadData(); !bytes.Equal(payload, []byte("sunny")) {
+			t.Fatalf("answer has payload %q", payload)
+		}
+
+	case <-time.After(5 * time.Second):
+		t.Fatal("interest was not answered")
+	}
+
+	if c.Store.KnowsBundle(interest.ID()) {
+		t.Fatal("answered interest is still stored")
+	}
+
+	go func() {
+		for msg := range app.receiver {
+			if _, isShutdown := msg.(agent.ShutdownMessage); isShutdown {
+				close(app.sender)
+				return
+			}
+		}
+	}()
+}
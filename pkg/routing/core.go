@@ -9,6 +9,9 @@ import (
 	"crypto/ed25519"
 	"encoding/gob"
 	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	log "github.com/sirupsen/logrus"
@@ -19,25 +22,184 @@ import (
 	"github.com/dtn7/dtn7-go/pkg/storage"
 )
 
+// CRCPolicy configures the CRC types of locally created Bundles and the CRCs required for received Bundles.
+type CRCPolicy struct {
+	// Enforce the following CRC types on locally created Bundles. Otherwise, their CRC types remain unchanged.
+	Enforce bool
+	// Primary is the primary block's CRC type. As primary blocks require a CRC, CRCNo results in CRC32.
+	Primary bpv7.CRCType
+	// Canonical is the CRC type of canonical blocks, including those inserted while forwarding.
+	Canonical bpv7.CRCType
+
+	// RequireCRC deletes received Bundles containing a block without a CRC.
+	RequireCRC bool
+}
+
+// SignaturePolicy configures the verification of received Bundles' SignatureBlocks by their source's trusted key.
+type SignaturePolicy struct {
+	// Keys maps source EndpointIDs or whole nodes to their trusted ed25519 public keys. Bundles from a source with a
+	// trusted key are deleted unless they carry a valid signature by this key.
+	Keys bpv7.SignatureKeys
+	// Require deletes received Bundles from sources without a trusted key as well as fragments, which cannot be verified.
+	Require bool
+}
+
+// ValidationMode defines how Bundles violating the strict validation rules of bpv7.Bundle.CheckStrict are handled.
+type ValidationMode int
+
+const (
+	// ValidationOff skips the strict validation. Bundles are only checked while being parsed.
+	ValidationOff ValidationMode = iota
+	// ValidationWarn logs violations, but processes such Bundles regularly.
+	ValidationWarn
+	// ValidationReject logs violations and deletes such Bundles.
+	ValidationReject
+)
+
+func (mode ValidationMode) String() string {
+	switch mode {
+	case ValidationOff:
+		return "off"
+	case ValidationWarn:
+		return "warn"
+	case ValidationReject:
+		return "reject"
+	default:
+		return "unknown"
+	}
+}
+
 // Core is the inner processing of our DTN which handles transmission, reception and
 // reception of bundles.
 type Core struct {
 	InspectAllBundles bool
 	NodeId            bpv7.EndpointID
 
+	nodeAliases      []bpv7.EndpointID
+	nodeAliasesMutex sync.RWMutex
+
+	groups      []bpv7.EndpointID
+	groupsMutex sync.RWMutex
+
 	agentManager *AgentManager
 	Cron         *Cron
 	claManager   *cla.Manager
 	IdKeeper     IdKeeper
 	routing      Algorithm
-	signPriv     ed25519.PrivateKey
+	routingConf  RoutingConf
+	// routingCronJobs are the names of the Cron jobs registered by the current Algorithm.
+	routingCronJobs []string
+	signPriv        ed25519.PrivateKey
+	peersFunc       func() []DiscoveredPeer
+	neighbors       *NeighborTable
+
+	// settings holds a *coreSettings, replaced as a whole by the setters. Thus, a configuration might be reloaded
+	// while bundles are processed, which only load the current settings without locking. settingsMutex serializes the
+	// setters.
+	settings      atomic.Value
+	settingsMutex sync.Mutex
+
+	statusReportLimiter rateLimiter
+
+	broadcasts    *broadcasts
+	supersessions *supersessions
+	shards        *shardCollector
+
+	checkpointInterval time.Duration
+
+	// deferBulk is set while forwarding bulk bundles is deferred, accessed atomically.
+	deferBulk uint32
+
+	retryQueue *retryQueue
+
+	senders *senderQueues
+
+	// pendingChecks counts requested runs of CheckPendingBundles, accessed atomically.
+	pendingChecks int32
+
+	events  *eventBus
+	metrics *coreMetrics
+	started time.Time
+
+	peerClocks *peerClocks
+	acks       *ackTracker
+
+	workers      *workerPool
+	workersMutex sync.RWMutex
+
+	eventLog            *EventLog
+	eventLogPath        string
+	eventLogUnsubscribe func()
+	eventLogMutex       sync.Mutex
+
+	clocklessSeq   uint64
+	clocklessMutex sync.Mutex
 
 	Store *storage.Store
 
+	// shuttingDown is set atomically by Shutdown; processing counts active bundle processing, accessed atomically.
+	shuttingDown uint32
+	processing   int32
+
 	stopSyn chan struct{}
 	stopAck chan struct{}
 }
 
+// coreSettings are the Core's settings which might be changed at runtime, e.g., by a configuration reload. A stored
+// coreSettings must not be modified, but replaced by updateSettings.
+type coreSettings struct {
+	fragmentMtu   int
+	hopLimit      uint8
+	transitLog    uint
+	clockless     bool
+	crcPolicy     CRCPolicy
+	signatures    SignaturePolicy
+	compatibility CompatibilityProfile
+	validation    ValidationMode
+	reportTo      bpv7.EndpointID
+	custody       CustodyPolicy
+	statusReports StatusReportPolicy
+	knownBundles  *knownBundles
+	contentCache  *contentCache
+	erasureCoding ErasureCoding
+	priority      PriorityPolicy
+	policy        *policyEngine
+	trafficShaper *trafficShaper
+	retry         RetryPolicy
+
+	clockSkew         ClockSkewPolicy
+	storageAdmission  StorageAdmissionPolicy
+	destinationQuotas []DestinationQuota
+}
+
+// loadSettings returns the current settings, which must not be modified.
+func (c *Core) loadSettings() *coreSettings {
+	return c.settings.Load().(*coreSettings)
+}
+
+// updateSettings replaces the current settings by a modified copy.
+func (c *Core) updateSettings(update func(s *coreSettings)) {
+	c.settingsMutex.Lock()
+	defer c.settingsMutex.Unlock()
+
+	s := *c.loadSettings()
+	update(&s)
+	c.settings.Store(&s)
+}
+
+// init registers the types of the BundleDescriptors' properties, stored as gob. Thus, importing this package allows
+// decoding a store, e.g., of a stopped Core.
+func init() {
+	gob.Register([]bpv7.EndpointID{})
+	gob.Register(bpv7.EndpointID{})
+	gob.Register(map[cla.CLAType][]bpv7.EndpointID{})
+	gob.Register(bpv7.DtnEndpoint{})
+	gob.Register(bpv7.IpnEndpoint{})
+	gob.Register(map[Constraint]bool{})
+	gob.Register(time.Time{})
+	gob.Register(bpv7.PriorityNormal)
+}
+
 // NewCore will be created according to the parameters.
 //
 //	storePath: path for the bundle and metadata storage
@@ -48,19 +210,24 @@ type Core struct {
 func NewCore(storePath string, nodeId bpv7.EndpointID, inspectAllBundles bool, routingConf RoutingConf, signPriv ed25519.PrivateKey) (*Core, error) {
 	var c = new(Core)
 
-	gob.Register([]bpv7.EndpointID{})
-	gob.Register(bpv7.EndpointID{})
-	gob.Register(map[cla.CLAType][]bpv7.EndpointID{})
-	gob.Register(bpv7.DtnEndpoint{})
-	gob.Register(bpv7.IpnEndpoint{})
-	gob.Register(map[Constraint]bool{})
-	gob.Register(time.Time{})
-
 	if !nodeId.IsSingleton() {
 		return nil, fmt.Errorf("passed Node ID MUST be a singleton; %s is not", nodeId)
 	}
 	c.InspectAllBundles = inspectAllBundles
 	c.NodeId = nodeId
+	c.started = time.Now()
+	c.settings.Store(new(coreSettings))
+	c.retryQueue = newRetryQueue()
+	c.senders = newSenderQueues(c.dispatchTo)
+	c.events = newEventBus()
+	c.metrics = newCoreMetrics()
+	c.shards = newShardCollector()
+	c.peerClocks = newPeerClocks()
+	c.acks = newAckTracker()
+	c.broadcasts = newBroadcasts()
+	c.neighbors = newNeighborTable()
+	c.supersessions = newSupersessions()
+	c.Subscribe(c.metrics.handleEvent)
 
 	if store, err := storage.NewStore(storePath); err != nil {
 		return nil, err
@@ -68,6 +235,9 @@ func NewCore(storePath string, nodeId bpv7.EndpointID, inspectAllBundles bool, r
 		c.Store = store
 	}
 
+	// Each Core has its own Cron, allowing multiple Cores within one process.
+	c.Cron = NewCron()
+
 	c.agentManager = NewAgentManager(c)
 
 	c.claManager = cla.NewManager()
@@ -78,6 +248,7 @@ func NewCore(storePath string, nodeId bpv7.EndpointID, inspectAllBundles bool, r
 		return nil, raErr
 	} else {
 		c.routing = ra
+		c.routingConf = routingConf
 	}
 
 	if signPriv != nil {
@@ -86,7 +257,9 @@ func NewCore(storePath string, nodeId bpv7.EndpointID, inspectAllBundles bool, r
 		}
 		c.signPriv = signPriv
 
-		if err := bpv7.GetExtensionBlockManager().Register(&bpv7.SignatureBlock{}); err != nil {
+		// The ExtensionBlockManager is shared within this process; another Core might have registered it already.
+		ebm := bpv7.GetExtensionBlockManager()
+		if err := ebm.Register(&bpv7.SignatureBlock{}); err != nil && !ebm.IsKnown(bpv7.ExtBlockTypeSignatureBlock) {
 			return nil, fmt.Errorf("SignatureBlock registration erred: %v", err)
 		}
 	}
@@ -105,24 +278,176 @@ func (c *Core) SetRoutingAlgorithm(routing Algorithm) {
 	c.routing = routing
 }
 
+// registerRoutingCron registers an Algorithm's Cron job, which is removed when replacing this Algorithm.
+func (c *Core) registerRoutingCron(name string, task func(), interval time.Duration) error {
+	if err := c.Cron.Register(name, task, interval); err != nil {
+		return err
+	}
+
+	c.routingCronJobs = append(c.routingCronJobs, name)
+	return nil
+}
+
+// ReloadRoutingAlgorithm applies a changed configuration of the Algorithm. A ReconfigurableAlgorithm of the same kind
+// is reconfigured, keeping its state. Otherwise, the Algorithm is replaced by a new one; the previous Algorithm's Cron
+// jobs are removed and its state is lost, unless a checkpoint exists, see SetRoutingCheckpoint. Stored bundles are
+// kept in both cases.
+func (c *Core) ReloadRoutingAlgorithm(routingConf RoutingConf) error {
+	if reconfigurable, ok := c.routing.(ReconfigurableAlgorithm); ok && routingConf.Algorithm == c.routingConf.Algorithm {
+		if err := reconfigurable.Reconfigure(routingConf); err != nil {
+			return err
+		}
+
+		c.routingConf = routingConf
+		return nil
+	}
+
+	c.checkpointRouting()
+
+	if c.Cron != nil {
+		for _, name := range c.routingCronJobs {
+			c.Cron.Unregister(name)
+		}
+	}
+	c.routingCronJobs = nil
+	c.closeRouting()
+
+	algo, err := routingConf.RoutingAlgorithm(c)
+	if err != nil {
+		return err
+	}
+
+	c.SetRoutingAlgorithm(algo)
+	c.routingConf = routingConf
+
+	if c.checkpointInterval > 0 {
+		return c.restoreRouting()
+	}
+	return nil
+}
+
+// closeRouting closes the Algorithm if it holds resources, e.g., ExternalRouting's connection.
+func (c *Core) closeRouting() {
+	if closer, ok := c.routing.(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			log.WithError(err).Warn("Closing routing algorithm erred")
+		}
+	}
+}
+
 // CheckPendingBundles queries pending bundle (packs) from the store and
 // tries to dispatch them.
+//
+// Only one check runs at a time, as dispatching a large backlog to slow links might take longer than the interval of
+// checks. Requests during a running check result in one more check afterwards.
 func (c *Core) CheckPendingBundles() {
-	if bis, err := c.Store.QueryPending(); err != nil {
+	if c.isShuttingDown() {
+		return
+	}
+
+	if atomic.AddInt32(&c.pendingChecks, 1) > 1 {
+		return
+	}
+
+	for {
+		c.checkPendingBundles()
+
+		if atomic.CompareAndSwapInt32(&c.pendingChecks, 1, 0) {
+			return
+		}
+		atomic.StoreInt32(&c.pendingChecks, 1)
+	}
+}
+
+// checkPendingBundles dispatches all pending bundles, ordered by their priority.
+func (c *Core) checkPendingBundles() {
+	if bps, err := c.pendingBundles(); err != nil {
 		log.WithFields(log.Fields{
 			"error": err,
 		}).Warn("Failed to fetch pending bundle packs")
 	} else {
-		for _, bi := range bis {
+		for _, bp := range bps {
+			if c.isShuttingDown() {
+				return
+			}
+
+			// Forwarded bundles in custody are retransmitted after their custody signal's timeout.
+			if bp.HasConstraint(CustodyAccepted) && time.Now().Before(bp.CustodyRetransmit) {
+				continue
+			}
+
 			log.WithFields(log.Fields{
-				"bundle": bi.Id,
+				"bundle":   bp.ID().String(),
+				"priority": bp.Priority,
 			}).Info("Retrying bundle from store")
 
-			c.dispatching(NewBundleDescriptor(bi.BId, c.Store))
+			c.dispatching(bp)
 		}
 	}
 }
 
+// DeleteExpiredBundles removes expired bundles from the store, like storage.Store.DeleteExpired. Additionally, a
+// deletion status report is sent for each bundle requesting one.
+func (c *Core) DeleteExpiredBundles() {
+	c.acks.expire(time.Now())
+	c.neighbors.purge(time.Now().Add(-neighborRetention))
+	c.supersessions.expire(time.Now())
+	if cache := c.loadSettings().contentCache; cache != nil {
+		cache.expire(bpv7.Now())
+	}
+	c.shards.expire(bpv7.Now())
+
+	bis, err := c.Store.QueryExpired()
+	if err != nil {
+		log.WithError(err).Warn("Failed to fetch expired bundles")
+		return
+	}
+
+	for _, bi := range bis {
+		bp := NewBundleDescriptor(bi.BId, c.Store)
+		if _, err := bp.Bundle(); err == nil {
+			c.bundleDeletion(bp, bpv7.LifetimeExpired)
+		}
+
+		// Expired bundles are deleted even if a local endpoint still retains them or if they cannot be loaded.
+		if c.Store.KnowsBundle(bi.BId) {
+			if err := c.Store.Delete(bi.BId); err != nil {
+				log.WithField("bundle", bi.Id).WithError(err).Warn("Failed to delete expired bundle")
+			}
+		}
+	}
+}
+
+// DeleteBundle removes a stored bundle on request, e.g., by an operator. A deletion status report is sent if the
+// bundle requests one.
+func (c *Core) DeleteBundle(bid bpv7.BundleID) error {
+	if !c.Store.KnowsBundle(bid) {
+		return fmt.Errorf("bundle %v is unknown", bid)
+	}
+
+	bp := NewBundleDescriptor(bid.Scrub(), c.Store)
+	if _, err := bp.Bundle(); err == nil {
+		c.bundleDeletion(bp, bpv7.NoInformation)
+	}
+
+	if c.Store.KnowsBundle(bid) {
+		return c.Store.Delete(bid)
+	}
+	return nil
+}
+
+// DeletionStatistics returns the number of bundles deleted by this Core, grouped by their deletion reason.
+func (c *Core) DeletionStatistics() map[bpv7.StatusReportReason]uint64 {
+	c.metrics.mutex.Lock()
+	defer c.metrics.mutex.Unlock()
+
+	stats := make(map[bpv7.StatusReportReason]uint64, len(c.metrics.deleted))
+	for reason, n := range c.metrics.deleted {
+		stats[reason] = n
+	}
+	return stats
+}
+
 // handler does the Core's background tasks
 func (c *Core) handler() {
 	for {
@@ -131,9 +456,19 @@ func (c *Core) handler() {
 		case <-c.stopSyn:
 			c.Cron.Stop()
 
+			c.checkpointRouting()
+			c.closeRouting()
+
 			if err := c.claManager.Close(); err != nil {
 				log.WithError(err).Warn("Closing CLA Manager while shutting down erred")
 			}
+			c.senders.close()
+
+			c.SetProcessingWorkers(0)
+
+			if err := c.SetEventLog(""); err != nil {
+				log.WithError(err).Warn("Closing event log while shutting down erred")
+			}
 
 			if err := c.Store.Close(); err != nil {
 				log.WithError(err).Warn("Closing store while shutting down erred")
@@ -146,20 +481,16 @@ func (c *Core) handler() {
 		case cs := <-c.claManager.Channel():
 			switch cs.MessageType {
 			case cla.ReceivedBundle:
-				crb := cs.Message.(cla.ConvergenceReceivedBundle)
-
-				bp := NewBundleDescriptorFromBundle(*crb.Bundle, c.Store)
-				bp.Receiver = crb.Endpoint
-				_ = bp.Sync()
-
-				c.receive(bp)
+				c.submitReceived(cs.Message.(cla.ConvergenceReceivedBundle), cs.Sender)
 
 			case cla.PeerAppeared:
+				c.neighbors.connect(cs.Message.(bpv7.EndpointID), cs.Sender.Address(), time.Now())
 				c.routing.ReportPeerAppeared(cs.Sender)
+				c.events.publish(Event{Type: PeerAppeared, Peer: cs.Message.(bpv7.EndpointID)})
 				c.CheckPendingBundles()
 
 			case cla.PeerDisappeared:
-				c.routing.ReportPeerDisappeared(cs.Sender)
+				c.peerDisappeared(cs.Message.(bpv7.EndpointID), cs.Sender)
 
 			default:
 				log.WithFields(log.Fields{
@@ -172,11 +503,85 @@ func (c *Core) handler() {
 	}
 }
 
+// submitReceived passes a received bundle to the processing workers, or processes it directly without workers.
+// Bundles of the same ID, including their fragments, are processed in their order of reception.
+func (c *Core) submitReceived(crb cla.ConvergenceReceivedBundle, sender cla.Convergence) {
+	c.workersMutex.RLock()
+	defer c.workersMutex.RUnlock()
+
+	if c.workers == nil {
+		c.processReceived(crb, sender)
+		return
+	}
+
+	// Queued bundles are already counted as being processed, allowing Shutdown to drain them.
+	atomic.AddInt32(&c.processing, 1)
+	c.workers.submit(crb.Bundle.ID().Scrub().String(), func() {
+		defer atomic.AddInt32(&c.processing, -1)
+		c.processReceived(crb, sender)
+	})
+}
+
+// processReceived drops known duplicates of a received bundle and those rejected by the BroadcastScope, the
+// StorageAdmissionPolicy, or a newer version of their stream, or stores and processes it.
+func (c *Core) processReceived(crb cla.ConvergenceReceivedBundle, sender cla.Convergence) {
+	if c.isKnownBundle(crb.Bundle) {
+		log.WithField("bundle", crb.Bundle.ID().String()).Debug("Dropping received duplicate bundle")
+		return
+	}
+
+	c.metrics.countBytes(c.metrics.bytesReceived, sender, crb.Bundle)
+
+	if !c.admitBroadcast(crb.Bundle) || !c.admitStorage(crb) || !c.admitSupersession(crb.Bundle) {
+		return
+	}
+
+	bp := NewBundleDescriptorFromBundle(*crb.Bundle, c.Store)
+	bp.Receiver = crb.Endpoint
+	_ = bp.Sync()
+
+	c.receive(bp, sender)
+}
+
+// Shutdown the Core gracefully within the timeout. First, new local bundles and retries are rejected. Afterwards,
+// active CLA transfers and the processing of bundles, e.g., received ones and their store synchronization, are drained
+// until the timeout. Finally, the Core is closed, as by Close.
+func (c *Core) Shutdown(timeout time.Duration) {
+	atomic.StoreUint32(&c.shuttingDown, 1)
+	c.retryQueue.stop()
+
+	deadline := time.Now().Add(timeout)
+	for {
+		transfers, processing := c.claManager.ActiveTransfers(), atomic.LoadInt32(&c.processing)
+		if transfers == 0 && processing == 0 {
+			log.Info("Drained all transfers and bundles, shutting down")
+			break
+		} else if time.Now().After(deadline) {
+			log.WithFields(log.Fields{
+				"transfers":  transfers,
+				"processing": processing,
+			}).Warn("Shutdown timeout exceeded, shutting down without draining")
+			break
+		}
+
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	c.Close()
+}
+
+// isShuttingDown checks if Shutdown was called.
+func (c *Core) isShuttingDown() bool {
+	return atomic.LoadUint32(&c.shuttingDown) != 0
+}
+
 // Close shuts the Core down and notifies all bounded ConvergenceReceivers to
 // also close the connection.
 func (c *Core) Close() {
 	close(c.stopSyn)
 	<-c.stopAck
+
+	c.retryQueue.stop()
 }
 
 // RegisterApplicationAgent adds a new ApplicationAgent to this Core's list.
@@ -199,7 +604,7 @@ func (c *Core) senderForDestination(endpoint bpv7.EndpointID) (css []cla.Converg
 // HasEndpoint checks if the given endpoint ID is assigned either to an
 // application or a CLA governed by this Application Agent.
 func (c *Core) HasEndpoint(endpoint bpv7.EndpointID) bool {
-	if c.NodeId.SameNode(endpoint) {
+	if c.isLocalNode(endpoint) {
 		return true
 	}
 
@@ -221,36 +626,58 @@ func (c *Core) HasEndpoint(endpoint bpv7.EndpointID) bool {
 }
 
 // SendStatusReport creates a new status report in response to the given
-// BundleDescriptor and transmits it.
+// BundleDescriptor and transmits it. The report is only generated if it was
+// requested by the bundle and is allowed by the StatusReportPolicy.
 func (c *Core) SendStatusReport(descriptor BundleDescriptor, status bpv7.StatusInformationPos, reason bpv7.StatusReportReason) {
+	bndl, _ := descriptor.Bundle()
+	if !bndl.PrimaryBlock.BundleControlFlags.Has(statusRequestFlag(status)) {
+		return
+	}
+
+	c.sendStatusReport(descriptor, status, reason)
+}
+
+// sendStatusReport creates and transmits a status report without checking the
+// bundle's request flags, e.g., for a block's StatusReportBlock flag.
+func (c *Core) sendStatusReport(descriptor BundleDescriptor, status bpv7.StatusInformationPos, reason bpv7.StatusReportReason) {
 	// Don't respond to other administrative records
 	bndl, _ := descriptor.Bundle()
 	if bndl.PrimaryBlock.BundleControlFlags.Has(bpv7.AdministrativeRecordPayload) {
 		return
 	}
 
+	// Don't report disabled status information
+	policy := c.loadSettings().statusReports
+	if policy.Disabled[status] {
+		return
+	}
+
+	// Don't respond to an unset report-to endpoint
+	if bndl.PrimaryBlock.ReportTo == bpv7.DtnNone() {
+		return
+	}
+
 	// Don't respond to ourself
 	if c.HasEndpoint(bndl.PrimaryBlock.ReportTo) {
 		return
 	}
 
-	log.WithFields(log.Fields{
-		"bundle": descriptor.ID().String(),
-		"status": status,
-		"reason": reason,
-	}).Info("Sending a status report for a bundle")
-
-	var sr = bpv7.NewStatusReport(*bndl, status, reason, bpv7.DtnTimeNow())
-	var ar, arErr = bpv7.AdministrativeRecordToCbor(sr)
-	if arErr != nil {
+	if !c.statusReportLimiter.allow(policy.RateLimit) {
 		log.WithFields(log.Fields{
 			"bundle": descriptor.ID().String(),
-			"error":  arErr,
-		}).Warn("Serializing administrative record failed")
+			"status": status,
+			"limit":  policy.RateLimit,
+		}).Debug("Dropping status report, rate limit is exceeded")
 
 		return
 	}
 
+	log.WithFields(log.Fields{
+		"bundle": descriptor.ID().String(),
+		"status": status,
+		"reason": reason,
+	}).Info("Sending a status report for a bundle")
+
 	var aaEndpoint = descriptor.Receiver
 	if aaEndpoint == bpv7.DtnNone() {
 		aaEndpoint = c.NodeId
@@ -266,12 +693,11 @@ func (c *Core) SendStatusReport(descriptor BundleDescriptor, status bpv7.StatusI
 	}
 
 	var outBndl, err = bpv7.Builder().
-		BundleCtrlFlags(bpv7.AdministrativeRecordPayload).
 		Source(aaEndpoint).
 		Destination(bndl.PrimaryBlock.ReportTo).
 		CreationTimestampNow().
 		Lifetime("60m").
-		Canonical(ar).
+		AdministrativeRecord(bpv7.NewStatusReport(*bndl, status, reason, bpv7.DtnTimeNow())).
 		Build()
 
 	if err != nil {
@@ -291,6 +717,252 @@ func (c *Core) RegisterConvergable(conv cla.Convergable) {
 	c.claManager.Register(conv)
 }
 
+// UnregisterConvergable is the exposed Unregister method from the CLA Manager.
+func (c *Core) UnregisterConvergable(conv cla.Convergable) {
+	c.claManager.Unregister(conv)
+}
+
+// ReportPeerUnreachable is called by a peer discovery for a peer considered unreachable, e.g., because its
+// announcements ceased. As the peer's CLA might not have noticed yet, a still connected CLA is handled as disappeared,
+// updating the neighbor table and informing the routing algorithm. Unregistering the CLA is left to the caller.
+func (c *Core) ReportPeerUnreachable(conv cla.Convergable) {
+	sender, ok := conv.(cla.ConvergenceSender)
+	if !ok {
+		return
+	}
+
+	if peer := sender.GetPeerEndpointID(); c.neighbors.isConnected(peer, sender.Address()) {
+		c.peerDisappeared(peer, sender)
+	}
+}
+
+// peerDisappeared handles a peer's disappeared CLA.
+func (c *Core) peerDisappeared(peer bpv7.EndpointID, conv cla.Convergence) {
+	c.neighbors.disconnect(peer, conv.Address(), time.Now())
+	c.routing.ReportPeerDisappeared(conv)
+	c.senders.remove(conv)
+	c.events.publish(Event{Type: PeerDisappeared, Peer: peer})
+}
+
+// SetDiscoveredPeersFunc sets the function to query a peer discovery's currently known peers, as returned by
+// DiscoveredPeers.
+func (c *Core) SetDiscoveredPeersFunc(peersFunc func() []DiscoveredPeer) {
+	c.peersFunc = peersFunc
+}
+
+// SetNodeAliases sets additional singleton Endpoint IDs owned by this node next to its Node ID, e.g., "ipn:42.0" for a
+// "dtn://node/" node bridging networks of different naming schemes. Bundles for any of these nodes are delivered locally.
+func (c *Core) SetNodeAliases(aliases []bpv7.EndpointID) error {
+	for _, alias := range aliases {
+		if !alias.IsSingleton() {
+			return fmt.Errorf("node alias MUST be a singleton; %s is not", alias)
+		}
+	}
+
+	c.nodeAliasesMutex.Lock()
+	defer c.nodeAliasesMutex.Unlock()
+
+	c.nodeAliases = aliases
+	return nil
+}
+
+// NodeAliases returns the Endpoint IDs set by SetNodeAliases.
+func (c *Core) NodeAliases() []bpv7.EndpointID {
+	c.nodeAliasesMutex.RLock()
+	defer c.nodeAliasesMutex.RUnlock()
+
+	return append([]bpv7.EndpointID(nil), c.nodeAliases...)
+}
+
+// isLocalNode checks if the endpoint belongs to this node, either by its Node ID or by one of its aliases.
+func (c *Core) isLocalNode(endpoint bpv7.EndpointID) bool {
+	if c.NodeId.SameNode(endpoint) {
+		return true
+	}
+
+	for _, alias := range c.NodeAliases() {
+		if alias.SameNode(endpoint) {
+			return true
+		}
+	}
+	return false
+}
+
+// SetFragmentMtu sets the maximum length of a serialized Bundle to be sent. Larger Bundles are proactively fragmented
+// before transmission, unless their control flags forbid fragmentation. A CLA might announce an even smaller MTU by
+// implementing cla.ConvergenceMtu. Zero disables this limit.
+func (c *Core) SetFragmentMtu(mtu int) {
+	c.updateSettings(func(s *coreSettings) { s.fragmentMtu = mtu })
+}
+
+// SetHopLimit sets the limit of a Hop Count Block to be inserted into locally created Bundles without one. Zero
+// disables this insertion. Forwarded Bundles exceeding their hop limit are deleted.
+func (c *Core) SetHopLimit(limit uint8) {
+	c.updateSettings(func(s *coreSettings) { s.hopLimit = limit })
+}
+
+// SetClockless configures this node as having no accurate clock. Locally created Bundles will then have a zero
+// creation time and a Bundle Age Block, which is updated on each transmission.
+func (c *Core) SetClockless(clockless bool) {
+	c.updateSettings(func(s *coreSettings) { s.clockless = clockless })
+}
+
+// SetCRCPolicy sets the CRCPolicy for locally created and received Bundles.
+func (c *Core) SetCRCPolicy(policy CRCPolicy) {
+	c.updateSettings(func(s *coreSettings) { s.crcPolicy = policy })
+}
+
+// SetSignaturePolicy sets the SignaturePolicy for received Bundles.
+func (c *Core) SetSignaturePolicy(policy SignaturePolicy) {
+	c.updateSettings(func(s *coreSettings) { s.signatures = policy })
+}
+
+// SetReportTo sets a default report-to endpoint for locally created Bundles, e.g., to centralize status reports on a
+// monitoring node. It replaces a report-to endpoint which is dtn:none or equals the Bundle's source. Administrative
+// records are left unchanged. A zero EndpointID disables this feature.
+func (c *Core) SetReportTo(reportTo bpv7.EndpointID) {
+	c.updateSettings(func(s *coreSettings) { s.reportTo = reportTo })
+}
+
+// SetProcessingWorkers sets the number of workers processing received bundles in parallel. Bundles of the same ID,
+// including their fragments, are always processed by the same worker in their order of reception. Zero, the default,
+// processes all received bundles sequentially. Bundles queued for a replaced pool of workers are processed first.
+func (c *Core) SetProcessingWorkers(workers uint) {
+	c.workersMutex.Lock()
+	defer c.workersMutex.Unlock()
+
+	if c.workers != nil && c.workers.size() == int(workers) {
+		return
+	}
+
+	if c.workers != nil {
+		c.workers.stop()
+		c.workers = nil
+	}
+	if workers > 0 {
+		c.workers = newWorkerPool(int(workers))
+	}
+}
+
+// SetEventLog records all Events to an append-only EventLog at the given path, replacing a previous one. An empty path
+// disables this recording.
+func (c *Core) SetEventLog(path string) error {
+	c.eventLogMutex.Lock()
+	defer c.eventLogMutex.Unlock()
+
+	if path == c.eventLogPath {
+		return nil
+	}
+
+	if c.eventLog != nil {
+		c.eventLogUnsubscribe()
+		if err := c.eventLog.Close(); err != nil {
+			return err
+		}
+		c.eventLog, c.eventLogPath, c.eventLogUnsubscribe = nil, "", nil
+	}
+
+	if path != "" {
+		eventLog, err := OpenEventLog(path)
+		if err != nil {
+			return err
+		}
+		c.eventLog, c.eventLogPath, c.eventLogUnsubscribe = eventLog, path, c.Subscribe(eventLog.Record)
+	}
+	return nil
+}
+
+// SetCustodyPolicy configures if this node accepts custody of Bundles requesting custody transfer.
+func (c *Core) SetCustodyPolicy(policy CustodyPolicy) {
+	c.updateSettings(func(s *coreSettings) { s.custody = policy })
+}
+
+// SetStatusReportPolicy configures which of the requested status reports are generated.
+func (c *Core) SetStatusReportPolicy(policy StatusReportPolicy) {
+	c.updateSettings(func(s *coreSettings) { s.statusReports = policy })
+}
+
+// SetKnownBundles enables a filter of recently received bundle IDs, dropping duplicates before they reach the store.
+// The filter remembers at least the given number of bundle IDs, rarely dropping a new bundle as a false positive.
+// Bundles requesting custody transfer are never dropped, as their retransmissions acknowledge lost custody signals.
+// Zero disables this filter. An unchanged capacity keeps the remembered bundle IDs.
+func (c *Core) SetKnownBundles(capacity uint) {
+	c.updateSettings(func(s *coreSettings) {
+		if capacity == 0 {
+			s.knownBundles = nil
+		} else if s.knownBundles == nil || s.knownBundles.capacity != capacity {
+			s.knownBundles = newKnownBundles(capacity)
+		}
+	})
+}
+
+// isKnownBundle checks a received bundle against the known bundles filter, if enabled.
+func (c *Core) isKnownBundle(bndl *bpv7.Bundle) bool {
+	known := c.loadSettings().knownBundles
+	if known == nil || bndl.HasExtensionBlock(bpv7.ExtBlockTypeCustodyTransferBlock) {
+		return false
+	}
+	return known.checkAndAdd(bndl.ID())
+}
+
+// SetPriorityPolicy to classify bundles without a Priority Block and to limit the store.
+func (c *Core) SetPriorityPolicy(policy PriorityPolicy) {
+	c.updateSettings(func(s *coreSettings) { s.priority = policy })
+}
+
+// SetTrafficShapingPolicy to limit the bytes forwarded to each peer within a time window. A zero budget disables it.
+// An unchanged policy keeps the peers' consumed budgets.
+func (c *Core) SetTrafficShapingPolicy(policy TrafficShapingPolicy) {
+	c.updateSettings(func(s *coreSettings) {
+		if policy.Budget == 0 || policy.Window <= 0 {
+			s.trafficShaper = nil
+		} else if s.trafficShaper == nil || s.trafficShaper.policy != policy {
+			s.trafficShaper = newTrafficShaper(policy)
+		}
+	})
+}
+
+// SetRetryPolicy to retry bundles with an exponential backoff after all senders failed.
+func (c *Core) SetRetryPolicy(policy RetryPolicy) {
+	c.updateSettings(func(s *coreSettings) { s.retry = policy })
+}
+
+// SetValidationMode sets the ValidationMode for received Bundles and Bundles to be sent.
+func (c *Core) SetValidationMode(mode ValidationMode) {
+	c.updateSettings(func(s *coreSettings) { s.validation = mode })
+}
+
+// DiscoveredPeers returns the peers currently known by the peer discovery. Without a discovery, nil is returned.
+func (c *Core) DiscoveredPeers() []DiscoveredPeer {
+	if c.peersFunc == nil {
+		return nil
+	}
+	return c.peersFunc()
+}
+
+// ConnectedPeers returns the Endpoint IDs of all peers with an active ConvergenceSender.
+func (c *Core) ConnectedPeers() (peers []bpv7.EndpointID) {
+	seen := make(map[bpv7.EndpointID]bool)
+	for _, cs := range c.claManager.Sender() {
+		peer := cs.GetPeerEndpointID()
+		if seen[peer] || c.isLocalNode(peer) {
+			continue
+		}
+
+		seen[peer] = true
+		peers = append(peers, peer)
+	}
+	return
+}
+
+// ReportPeerNeighbors passes a peer's neighbors, e.g., received by the peer discovery's second-hop gossip, to the
+// routing Algorithm, if it implements the NeighborGossipReceiver.
+func (c *Core) ReportPeerNeighbors(peer bpv7.EndpointID, neighbors []bpv7.EndpointID) {
+	if receiver, ok := c.routing.(NeighborGossipReceiver); ok {
+		receiver.ReportPeerNeighbors(peer, neighbors)
+	}
+}
+
 // RegisterCLA registers a CLA with the clamanager (just as the RegisterConvergable-method)
 // but also adds the CLAs endpoint id to the set of registered IDs for its type.
 func (c *Core) RegisterCLA(conv cla.Convergable, claType cla.CLAType, eid bpv7.EndpointID) {
@@ -298,6 +970,12 @@ func (c *Core) RegisterCLA(conv cla.Convergable, claType cla.CLAType, eid bpv7.E
 	c.claManager.Register(conv)
 }
 
+// UnregisterCLA unregisters a CLA registered by RegisterCLA, also removing its endpoint id for its type.
+func (c *Core) UnregisterCLA(conv cla.Convergable, claType cla.CLAType, eid bpv7.EndpointID) {
+	c.claManager.Unregister(conv)
+	c.claManager.UnregisterEndpointID(claType, eid)
+}
+
 // RegisteredCLAs returns the EndpointIDs of all registered CLAs of the specified type.
 // Returns an empty slice if no CLAs of the tye exist.
 func (c *Core) RegisteredCLAs(claType cla.CLAType) []bpv7.EndpointID {
@@ -0,0 +1,372 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"crypto/ed25519"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/agent"
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+	"github.com/dtn7/dtn7-go/pkg/cla/mtcp"
+)
+
+// coreTestAgent is a minimal ApplicationAgent, discarding all received Messages.
+type coreTestAgent struct {
+	endpoint bpv7.EndpointID
+	receiver chan agent.Message
+	sender   chan agent.Message
+}
+
+func newCoreTestAgent(endpoint bpv7.EndpointID) *coreTestAgent {
+	a := &coreTestAgent{
+		endpoint: endpoint,
+		receiver: make(chan agent.Message),
+		sender:   make(chan agent.Message),
+	}
+
+	go func() {
+		for msg := range a.receiver {
+			if _, isShutdown := msg.(agent.ShutdownMessage); isShutdown {
+				close(a.sender)
+				return
+			}
+		}
+	}()
+
+	return a
+}
+
+func (a *coreTestAgent) Endpoints() []bpv7.EndpointID        { return []bpv7.EndpointID{a.endpoint} }
+func (a *coreTestAgent) MessageReceiver() chan agent.Message { return a.receiver }
+func (a *coreTestAgent) MessageSender() chan agent.Message   { return a.sender }
+
+func newTestCore(t *testing.T, nodeId string) *Core {
+	_, signPriv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := NewCore(t.TempDir(), bpv7.MustNewEndpointID(nodeId), false, RoutingConf{Algorithm: "epidemic"}, signPriv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestMultipleCores(t *testing.T) {
+	l, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	port := l.Addr().(*net.TCPAddr).Port
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	coreA := newTestCore(t, "dtn://a/")
+	defer coreA.Close()
+	coreB := newTestCore(t, "dtn://b/")
+	defer coreB.Close()
+
+	if coreA.Cron == coreB.Cron || coreA.Store == coreB.Store {
+		t.Fatal("Cores share their Cron or Store")
+	}
+
+	coreA.SetProcessingWorkers(2)
+	coreA.RegisterApplicationAgent(newCoreTestAgent(bpv7.MustNewEndpointID("dtn://a/inbox")))
+
+	delivered := make(chan bpv7.BundleID, 1)
+	coreA.Subscribe(func(e Event) {
+		select {
+		case delivered <- e.Bundle:
+		default:
+		}
+	}, BundleDelivered)
+
+	coreA.RegisterConvergable(mtcp.NewMTCPServer(fmt.Sprintf("localhost:%d", port), coreA.NodeId, true))
+	coreB.RegisterConvergable(mtcp.NewMTCPClient(fmt.Sprintf("localhost:%d", port), coreA.NodeId, true))
+
+	for deadline := time.Now().Add(5 * time.Second); len(coreB.ConnectedPeers()) == 0; {
+		if time.Now().After(deadline) {
+			t.Fatal("Core B did not connect to Core A")
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	bndl, err := bpv7.Builder().
+		Source("dtn://b/outbox").
+		Destination("dtn://a/inbox").
+		CreationTimestampNow().
+		Lifetime("10m").
+		PayloadBlock([]byte("hello core")).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	coreB.SendBundle(&bndl)
+
+	select {
+	case bid := <-delivered:
+		if bid != bndl.ID() {
+			t.Fatalf("Core A delivered %v, expected %v", bid, bndl.ID())
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Core A did not deliver the bundle")
+	}
+}
+
+func TestCoreNodeAliases(t *testing.T) {
+	c := newTestCore(t, "dtn://a/")
+	defer c.Close()
+
+	if c.HasEndpoint(bpv7.MustNewEndpointID("ipn:42.1")) {
+		t.Fatal("ipn:42.1 is local without an alias")
+	}
+
+	if err := c.SetNodeAliases([]bpv7.EndpointID{bpv7.MustNewEndpointID("dtn://group/~all")}); err == nil {
+		t.Fatal("non-singleton alias was accepted")
+	}
+	if err := c.SetNodeAliases([]bpv7.EndpointID{bpv7.MustNewEndpointID("ipn:42.0")}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, eid := range []string{"dtn://a/foo", "ipn:42.0", "ipn:42.1"} {
+		if !c.HasEndpoint(bpv7.MustNewEndpointID(eid)) {
+			t.Fatalf("%s is not local", eid)
+		}
+	}
+	if c.HasEndpoint(bpv7.MustNewEndpointID("ipn:23.1")) {
+		t.Fatal("ipn:23.1 is local")
+	}
+}
+
+func TestCoreReloadRoutingAlgorithm(t *testing.T) {
+	conf := RoutingConf{
+		Algorithm: "dtlsr",
+		DTLSRConf: DTLSRConfig{RecomputeTime: "30s", BroadcastTime: "30s", PurgeTime: "10m"},
+	}
+
+	c, err := NewCore(t.TempDir(), bpv7.MustNewEndpointID("dtn://a/"), false, conf, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	algo := c.routing
+
+	conf.DTLSRConf.BroadcastTime = "1m"
+	if err := c.ReloadRoutingAlgorithm(conf); err != nil {
+		t.Fatal(err)
+	}
+	if c.routing != algo {
+		t.Fatal("DTLSR was replaced instead of reconfigured")
+	}
+	if interval := c.Cron.jobs["dtlsr_broadcast"].interval; interval != time.Minute {
+		t.Fatalf("broadcast interval is %v, expected a minute", interval)
+	}
+
+	conf.Algorithm = "epidemic"
+	if err := c.ReloadRoutingAlgorithm(conf); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := c.routing.(*EpidemicRouting); !ok {
+		t.Fatalf("expected epidemic routing, got %T", c.routing)
+	}
+	if _, exists := c.Cron.jobs["dtlsr_broadcast"]; exists {
+		t.Fatal("DTLSR's cron job was not removed")
+	}
+}
+
+func TestCoreDeleteBundle(t *testing.T) {
+	c := newTestCore(t, "dtn://a/")
+	defer c.Close()
+
+	bndl, err := bpv7.Builder().
+		Source("dtn://src/").
+		Destination("dtn://dst/").
+		CreationTimestampNow().
+		Lifetime("10m").
+		PayloadBlock([]byte("hello world")).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.DeleteBundle(bndl.ID()); err == nil {
+		t.Fatal("unknown bundle was deleted")
+	}
+
+	if err := c.Store.Push(bndl); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.DeleteBundle(bndl.ID()); err != nil {
+		t.Fatal(err)
+	}
+	if c.Store.KnowsBundle(bndl.ID()) {
+		t.Fatal("deleted bundle is still stored")
+	}
+}
+
+func TestCoreRetryLocalDelivery(t *testing.T) {
+	c := newTestCore(t, "dtn://a/")
+	defer c.Close()
+
+	delivered := make(chan bpv7.BundleID, 1)
+	c.Subscribe(func(e Event) {
+		select {
+		case delivered <- e.Bundle:
+		default:
+		}
+	}, BundleDelivered)
+
+	bndl, err := bpv7.Builder().
+		Source("dtn://a/outbox").
+		Destination("dtn://a/inbox").
+		CreationTimestampNow().
+		Lifetime("10m").
+		PayloadBlock([]byte("hello later")).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.SendBundle(&bndl)
+
+	// Without a registered endpoint, the bundle is retained for a later delivery.
+	time.Sleep(100 * time.Millisecond)
+	if bi, err := c.Store.QueryId(bndl.ID()); err != nil {
+		t.Fatal(err)
+	} else if !bi.Pending {
+		t.Fatal("undelivered bundle is not pending")
+	}
+
+	c.RegisterApplicationAgent(newCoreTestAgent(bpv7.MustNewEndpointID("dtn://a/inbox")))
+	c.checkPendingBundles()
+
+	select {
+	case bid := <-delivered:
+		if bid != bndl.ID() {
+			t.Fatalf("delivered %v, expected %v", bid, bndl.ID())
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("bundle was not delivered")
+	}
+}
+
+func TestCoreSignaturePolicy(t *testing.T) {
+	trustedPub, trustedPriv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, otherPriv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newBundle := func(source string, priv ed25519.PrivateKey) bpv7.Bundle {
+		bndl, err := bpv7.Builder().
+			Source(source).
+			Destination("dtn://ground/").
+			CreationTimestampNow().
+			Lifetime("1h").
+			PayloadBlock(make([]byte, 512)).
+			Build()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if priv != nil {
+			if err := bndl.Sign(priv); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return bndl
+	}
+
+	newFragment := func(source string, priv ed25519.PrivateKey) bpv7.Bundle {
+		frags, err := newBundle(source, priv).Fragment(256)
+		if err != nil {
+			t.Fatal(err)
+		} else if len(frags) < 2 {
+			t.Fatalf("expected fragments, got %d bundles", len(frags))
+		}
+		return frags[0]
+	}
+
+	tests := []struct {
+		name    string
+		bndl    bpv7.Bundle
+		require bool
+		deleted bool
+	}{
+		{"trusted signed", newBundle("dtn://trusted/app", trustedPriv), false, false},
+		{"trusted unsigned", newBundle("dtn://trusted/app", nil), false, true},
+		{"trusted wrong key", newBundle("dtn://trusted/app", otherPriv), false, true},
+		{"trusted fragment", newFragment("dtn://trusted/app", trustedPriv), false, true},
+		{"untrusted unsigned", newBundle("dtn://other/app", nil), false, false},
+		{"untrusted fragment", newFragment("dtn://other/app", nil), false, false},
+		{"untrusted required", newBundle("dtn://other/app", otherPriv), true, true},
+		{"trusted signed required", newBundle("dtn://trusted/app", trustedPriv), true, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := newTestCore(t, "dtn://relay/")
+			defer c.Close()
+
+			c.SetSignaturePolicy(SignaturePolicy{
+				Keys:    bpv7.SignatureKeys{bpv7.MustNewEndpointID("dtn://trusted/"): trustedPub},
+				Require: test.require,
+			})
+
+			var deleted bool
+			c.Subscribe(func(e Event) {
+				if e.Bundle == test.bndl.ID() && e.Reason == bpv7.BlockUnintelligible {
+					deleted = true
+				}
+			}, BundleDeleted)
+
+			c.receive(NewBundleDescriptorFromBundle(test.bndl, c.Store), policyTestConvergence("mtcp://10.0.0.2:35037"))
+
+			if deleted != test.deleted {
+				t.Fatalf("bundle deleted: %t, expected %t", deleted, test.deleted)
+			}
+		})
+	}
+}
+
+func TestCoreSignLocalBundles(t *testing.T) {
+	c := newTestCore(t, "dtn://a/")
+	defer c.Close()
+
+	bndl, err := bpv7.Builder().
+		Source("dtn://a/app").
+		Destination("dtn://b/").
+		CreationTimestampNow().
+		Lifetime("1h").
+		PayloadBlock([]byte("hello world")).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.SendBundle(&bndl)
+
+	bi, err := c.Store.QueryId(bndl.ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	stored, err := bi.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keys := bpv7.SignatureKeys{c.NodeId: c.signPriv.Public().(ed25519.PublicKey)}
+	if err := stored.VerifySignature(keys); err != nil {
+		t.Fatalf("locally created bundle is not signed: %v", err)
+	}
+}
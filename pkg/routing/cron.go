@@ -6,6 +6,7 @@ package routing
 
 import (
 	"fmt"
+	"math/rand"
 	"sync"
 	"time"
 
@@ -13,11 +14,24 @@ import (
 )
 
 type cronjob struct {
-	task      func()
-	interval  time.Duration
+	task     func()
+	interval time.Duration
+	jitter   time.Duration
+
+	// scheduled is the regular time of the next execution, which is delayed by a random jitter to nextEvent.
+	scheduled time.Time
 	nextEvent time.Time
 }
 
+// schedule the next execution at the given regular time, delayed by a random jitter.
+func (job *cronjob) schedule(scheduled time.Time) {
+	job.scheduled = scheduled
+	job.nextEvent = scheduled
+	if job.jitter > 0 {
+		job.nextEvent = job.nextEvent.Add(time.Duration(rand.Int63n(int64(job.jitter))))
+	}
+}
+
 // Cron manages different jobs which require interval based execution.
 type Cron struct {
 	jobs  map[string]*cronjob
@@ -65,7 +79,7 @@ func (cron *Cron) fire(t time.Time) {
 			continue
 		}
 
-		job.nextEvent = job.nextEvent.Add(job.interval)
+		job.schedule(job.scheduled.Add(job.interval))
 		go job.task()
 
 		log.WithFields(log.Fields{
@@ -86,6 +100,13 @@ func (cron *Cron) Stop() {
 // at least one second. The function will be executed in a new Goroutine and
 // must be thread-safe.
 func (cron *Cron) Register(name string, task func(), interval time.Duration) error {
+	return cron.RegisterWithJitter(name, task, interval, 0)
+}
+
+// RegisterWithJitter registers a new task like Register, but delays each
+// execution by a random duration up to the jitter. This spreads periodic tasks
+// of multiple nodes, e.g., broadcasts, which would otherwise be synchronized.
+func (cron *Cron) RegisterWithJitter(name string, task func(), interval, jitter time.Duration) error {
 	cron.mutex.Lock()
 	defer cron.mutex.Unlock()
 
@@ -93,20 +114,56 @@ func (cron *Cron) Register(name string, task func(), interval time.Duration) err
 		return fmt.Errorf("A job named %s is already registered", name)
 	}
 
-	if interval < time.Second {
-		return fmt.Errorf("Given interval %v is shorter than a second", interval)
+	if err := checkCronTiming(interval, jitter); err != nil {
+		return err
 	}
 
 	job := &cronjob{
-		task:      task,
-		interval:  interval,
-		nextEvent: time.Now().Add(interval),
+		task:     task,
+		interval: interval,
+		jitter:   jitter,
 	}
+	job.schedule(time.Now().Add(interval))
 	cron.jobs[name] = job
 
 	return nil
 }
 
+// Reschedule a registered task with a new interval and jitter, e.g., after a
+// configuration change. Its next execution is one new interval from now.
+func (cron *Cron) Reschedule(name string, interval, jitter time.Duration) error {
+	cron.mutex.Lock()
+	defer cron.mutex.Unlock()
+
+	job, exists := cron.jobs[name]
+	if !exists {
+		return fmt.Errorf("No job named %s is registered", name)
+	}
+
+	if err := checkCronTiming(interval, jitter); err != nil {
+		return err
+	}
+
+	if job.interval != interval || job.jitter != jitter {
+		job.interval = interval
+		job.jitter = jitter
+		job.schedule(time.Now().Add(interval))
+	}
+
+	return nil
+}
+
+// checkCronTiming validates an interval of at least a second and a non-negative jitter.
+func checkCronTiming(interval, jitter time.Duration) error {
+	if interval < time.Second {
+		return fmt.Errorf("Given interval %v is shorter than a second", interval)
+	}
+	if jitter < 0 {
+		return fmt.Errorf("Given jitter %v is negative", jitter)
+	}
+	return nil
+}
+
 // Unregister a task by its name.
 func (cron *Cron) Unregister(name string) {
 	cron.mutex.Lock()
@@ -0,0 +1,68 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCronJitter(t *testing.T) {
+	cron := NewCron()
+	defer cron.Stop()
+
+	if err := cron.RegisterWithJitter("job", func() {}, time.Minute, -time.Second); err == nil {
+		t.Fatal("negative jitter was accepted")
+	}
+
+	start := time.Now()
+	if err := cron.RegisterWithJitter("job", func() {}, time.Minute, 10*time.Second); err != nil {
+		t.Fatal(err)
+	}
+
+	job := cron.jobs["job"]
+	if job.nextEvent.Before(start.Add(time.Minute)) || job.nextEvent.After(time.Now().Add(time.Minute+10*time.Second)) {
+		t.Fatalf("next event %v is not within the jitter of a minute from %v", job.nextEvent, start)
+	}
+}
+
+func TestCronReschedule(t *testing.T) {
+	cron := NewCron()
+	defer cron.Stop()
+
+	if err := cron.Reschedule("unknown", time.Minute, 0); err == nil {
+		t.Fatal("unknown job was rescheduled")
+	}
+
+	executed := make(chan struct{}, 1)
+	task := func() {
+		select {
+		case executed <- struct{}{}:
+		default:
+		}
+	}
+
+	if err := cron.Register("job", task, time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	if err := cron.Reschedule("job", time.Millisecond, 0); err == nil {
+		t.Fatal("too short interval was accepted")
+	}
+
+	if err := cron.Reschedule("job", time.Second, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case <-executed:
+	case <-time.After(3 * time.Second):
+		t.Fatal("rescheduled job was not executed")
+	}
+
+	cron.Unregister("job")
+	if _, exists := cron.jobs["job"]; exists {
+		t.Fatal("unregistered job still exists")
+	}
+}
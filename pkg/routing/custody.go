@@ -0,0 +1,125 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+// CustodyPolicy configures custody transfer for Bundles requesting it by a bpv7.CustodyTransferBlock.
+type CustodyPolicy struct {
+	// Accept custody for received and locally created Bundles requesting custody transfer.
+	Accept bool
+	// Retransmit a forwarded Bundle in custody after this duration if no other node has accepted its custody.
+	Retransmit time.Duration
+}
+
+// custodian returns the current custodian of a Bundle requesting custody transfer. False is returned for Bundles
+// without a CustodyTransferBlock.
+func custodian(bp BundleDescriptor) (bpv7.EndpointID, bool) {
+	ctBlock, err := bp.MustBundle().ExtensionBlock(bpv7.ExtBlockTypeCustodyTransferBlock)
+	if err != nil {
+		return bpv7.EndpointID{}, false
+	}
+
+	return ctBlock.Value.(*bpv7.CustodyTransferBlock).Custodian(), true
+}
+
+// acceptCustody for a Bundle requesting custody transfer. The previous custodian is informed and the Bundle will be
+// retained until another node accepts custody.
+func (c *Core) acceptCustody(bp BundleDescriptor) {
+	if _, ok := custodian(bp); !ok {
+		return
+	}
+
+	log.WithField("bundle", bp.ID().String()).Info("Accepting custody of bundle")
+
+	c.signalCustodian(bp, true, bpv7.NoInformation)
+
+	bp.AddConstraint(CustodyAccepted)
+	_ = bp.Sync()
+}
+
+// custodyForwarded retains a Bundle in custody after it was forwarded. It will be retransmitted after the
+// CustodyPolicy's Retransmit duration, unless another node accepts custody before.
+func (c *Core) custodyForwarded(bp BundleDescriptor) {
+	bp.RemoveConstraint(ForwardPending)
+	bp.AddConstraint(Contraindicated)
+	bp.CustodyRetransmit = time.Now().Add(c.loadSettings().custody.Retransmit)
+	_ = bp.Sync()
+
+	log.WithFields(log.Fields{
+		"bundle":     bp.ID().String(),
+		"retransmit": bp.CustodyRetransmit,
+	}).Info("Forwarded bundle in custody, awaiting custody signal")
+}
+
+// signalCustodian sends a CustodySignal to a Bundle's current custodian, unless this node is the custodian itself.
+func (c *Core) signalCustodian(bp BundleDescriptor, accepted bool, reason bpv7.StatusReportReason) {
+	prevCustodian, ok := custodian(bp)
+	if !ok || prevCustodian == bpv7.DtnNone() || c.HasEndpoint(prevCustodian) {
+		return
+	}
+
+	logger := log.WithFields(log.Fields{
+		"bundle":    bp.ID().String(),
+		"custodian": prevCustodian,
+		"accepted":  accepted,
+		"reason":    reason,
+	})
+
+	signal := bpv7.NewCustodySignal(*bp.MustBundle(), accepted, reason, bpv7.DtnTimeNow())
+	outBndl, err := bpv7.Builder().
+		Source(c.NodeId).
+		Destination(prevCustodian).
+		CreationTimestampNow().
+		Lifetime("60m").
+		AdministrativeRecord(signal).
+		Build()
+	if err != nil {
+		logger.WithError(err).Warn("Creating custody signal bundle failed")
+		return
+	}
+
+	logger.Info("Sending a custody signal for a bundle")
+	c.SendBundle(&outBndl)
+}
+
+// inspectCustodySignal releases a Bundle in custody if another node accepted its custody. A refused custody results
+// in an immediate retransmission.
+func (c *Core) inspectCustodySignal(bp BundleDescriptor, ar bpv7.AdministrativeRecord) {
+	signal := *ar.(*bpv7.CustodySignal)
+
+	logger := log.WithFields(log.Fields{
+		"bundle":         bp.ID().String(),
+		"custody_signal": signal,
+	})
+
+	if !c.Store.KnowsBundle(signal.RefBundle) {
+		logger.Info("Custody signal's bundle is unknown")
+		return
+	}
+
+	refBp := NewBundleDescriptor(signal.RefBundle, c.Store)
+	if !refBp.HasConstraint(CustodyAccepted) {
+		logger.Info("Custody signal's bundle is not in custody")
+		return
+	}
+
+	if signal.Accepted {
+		logger.Info("Custody was accepted by another node, releasing bundle")
+
+		refBp.PurgeConstraints()
+	} else {
+		logger.Info("Custody was refused by another node, retransmitting bundle")
+
+		refBp.CustodyRetransmit = time.Now()
+	}
+	_ = refBp.Sync()
+}
@@ -0,0 +1,76 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+// DestinationQuota limits the stored bundles for destinations starting with an Endpoint ID prefix, so that the
+// backlog of an unreachable destination cannot consume the whole store of a relay.
+type DestinationQuota struct {
+	// Prefix of the destinations' Endpoint IDs, e.g., "dtn://mars/" or "ipn:23.".
+	Prefix string
+
+	// Bundles is the maximum number of stored bundles. Zero disables this limit.
+	Bundles uint
+
+	// Bytes is the maximum serialized size of stored bundles in bytes. Zero disables this limit.
+	Bytes uint64
+}
+
+// SetDestinationQuotas limits the bundles stored for forwarding to destinations. Received bundles exceeding a
+// matching quota are rejected, as described for the StorageAdmissionPolicy. Bundles for local endpoints are exempted.
+func (c *Core) SetDestinationQuotas(quotas []DestinationQuota) {
+	c.updateSettings(func(s *coreSettings) { s.destinationQuotas = quotas })
+}
+
+// checkDestinationQuotas returns an error if storing a received bundle exceeds a matching DestinationQuota.
+func (c *Core) checkDestinationQuotas(bndl *bpv7.Bundle) error {
+	quotas := c.loadSettings().destinationQuotas
+	if len(quotas) == 0 || c.HasEndpoint(bndl.PrimaryBlock.Destination) {
+		return nil
+	}
+
+	// Retransmissions of a stored, unfragmented bundle are already accounted for.
+	if !bndl.PrimaryBlock.HasFragmentation() && c.Store.KnowsBundle(bndl.ID()) {
+		return nil
+	}
+
+	destination := bndl.PrimaryBlock.Destination.String()
+	for _, quota := range quotas {
+		if !strings.HasPrefix(destination, quota.Prefix) {
+			continue
+		}
+
+		bis, err := c.Store.QueryDestination(quota.Prefix)
+		if err != nil {
+			return err
+		}
+
+		if quota.Bundles > 0 && uint(len(bis)) >= quota.Bundles {
+			return fmt.Errorf("%d stored bundles for %s reach the quota of %d", len(bis), quota.Prefix, quota.Bundles)
+		}
+
+		if quota.Bytes > 0 {
+			size, err := bndl.SerializedSize()
+			if err != nil {
+				return err
+			}
+
+			for _, bi := range bis {
+				size += bi.Size
+			}
+			if size > quota.Bytes {
+				return fmt.Errorf("storing %d bytes for %s exceeds the quota of %d bytes", size, quota.Prefix, quota.Bytes)
+			}
+		}
+	}
+
+	return nil
+}
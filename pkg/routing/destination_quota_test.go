@@ -0,0 +1,65 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+func TestCoreDestinationQuotas(t *testing.T) {
+	c := newTestCore(t, "dtn://relay/")
+	defer c.Close()
+
+	newBundle := func(destination string, seq uint64) bpv7.Bundle {
+		bndl, err := bpv7.Builder().
+			Source(fmt.Sprintf("dtn://src%d/", seq)).
+			Destination(destination).
+			CreationTimestampNow().
+			Lifetime("10m").
+			PayloadBlock(make([]byte, 1024)).
+			Build()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return bndl
+	}
+
+	stored := newBundle("dtn://mars/app", 0)
+	if err := c.Store.Push(stored); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		quota       DestinationQuota
+		destination string
+		admit       bool
+	}{
+		{DestinationQuota{Prefix: "dtn://mars/", Bundles: 2}, "dtn://mars/app", true},
+		{DestinationQuota{Prefix: "dtn://mars/", Bundles: 1}, "dtn://mars/app", false},
+		{DestinationQuota{Prefix: "dtn://mars/", Bytes: 4096}, "dtn://mars/app", true},
+		{DestinationQuota{Prefix: "dtn://mars/", Bytes: 1536}, "dtn://mars/app", false},
+		{DestinationQuota{Prefix: "dtn://mars/", Bundles: 1}, "dtn://venus/app", true},
+		{DestinationQuota{Prefix: "dtn://relay/", Bundles: 1}, "dtn://relay/app", true},
+	}
+
+	for i, test := range tests {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			c.SetDestinationQuotas([]DestinationQuota{test.quota})
+
+			bndl := newBundle(test.destination, 1)
+			if err := c.checkDestinationQuotas(&bndl); (err == nil) != test.admit {
+				t.Fatalf("expected admission = %t, got %v", test.admit, err)
+			}
+
+			// A retransmission of a stored bundle is always admitted.
+			if err := c.checkDestinationQuotas(&stored); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
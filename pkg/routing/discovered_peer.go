@@ -0,0 +1,25 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+	"github.com/dtn7/dtn7-go/pkg/cla"
+)
+
+// DiscoveredPeer is a neighbor's CLA, as currently known by a peer discovery.
+type DiscoveredPeer struct {
+	Endpoint bpv7.EndpointID
+	Type     cla.CLAType
+	Address  string
+
+	// LastSeen is the time of the latest received announcement.
+	LastSeen time.Time
+
+	// Period is the observed time between the two latest announcements, or zero if only one was received.
+	Period time.Duration
+}
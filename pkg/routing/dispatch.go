@@ -0,0 +1,98 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+	"github.com/dtn7/dtn7-go/pkg/cla"
+)
+
+// dispatchResult summarizes the transmissions of a bundle to multiple ConvergenceSenders.
+type dispatchResult struct {
+	// sent indicates that at least one transmission succeeded.
+	sent bool
+
+	// interruptedAck is the largest number of acknowledged bytes of an interrupted transfer, see reactiveFragment.
+	interruptedAck int
+}
+
+// dispatch a bundle to all ConvergenceSenders concurrently and wait until each transmission has finished. Thus, a
+// brief contact to multiple peers is not spent waiting for the slowest one.
+//
+// The transmissions are queued in the bounded senderQueues of their ConvergenceSenders. If a slow link's queue is
+// full, dispatching blocks, pushing back on the dispatching of further bundles.
+//
+// Each transmission fails independently, even by a panicking CLA, and is reported to the routing algorithm on its own.
+// As serializing a bundle updates its blocks' CRC values, each transmission works on its own copy of the blocks. The
+// CompatibilityProfile might remove some blocks from these copies.
+func (c *Core) dispatch(bp BundleDescriptor, nodes []cla.ConvergenceSender) (result dispatchResult) {
+	bndls := make([]bpv7.Bundle, len(nodes))
+	dones := make([]<-chan error, len(nodes))
+	stripPrivate := c.loadSettings().compatibility.StripPrivateBlocks
+
+	for i, node := range nodes {
+		bndls[i] = *bp.MustBundle()
+		if stripPrivate {
+			stripPrivateBlocks(&bndls[i])
+		} else {
+			bndls[i].CanonicalBlocks = append([]bpv7.CanonicalBlock(nil), bndls[i].CanonicalBlocks...)
+		}
+
+		log.WithFields(log.Fields{
+			"bundle": bp.ID().String(),
+			"cla":    node,
+		}).Info("Sending bundle to a CLA (ConvergenceSender)")
+
+		dones[i] = c.senders.submit(node, bndls[i])
+	}
+
+	for i, node := range nodes {
+		if err := <-dones[i]; err != nil {
+			log.WithFields(log.Fields{
+				"bundle": bp.ID().String(),
+				"cla":    node,
+				"error":  err,
+			}).Warn("Sending bundle failed")
+
+			var interruptedErr *cla.InterruptedTransferError
+			if errors.As(err, &interruptedErr) && interruptedErr.Acknowledged > result.interruptedAck {
+				result.interruptedAck = interruptedErr.Acknowledged
+			}
+
+			c.neighbors.transmitted(node.GetPeerEndpointID(), false, time.Now())
+			c.routing.ReportFailure(bp, node)
+		} else {
+			log.WithFields(log.Fields{
+				"bundle": bp.ID().String(),
+				"cla":    node,
+			}).Printf("Sending bundle succeeded")
+
+			c.metrics.countBytes(c.metrics.bytesSent, node, &bndls[i])
+			c.neighbors.transmitted(node.GetPeerEndpointID(), true, time.Now())
+			c.events.publish(Event{Type: BundleTransmitted, Bundle: bp.ID(), Peer: node.GetPeerEndpointID()})
+
+			result.sent = true
+		}
+	}
+
+	return
+}
+
+// dispatchTo sends a bundle to a single ConvergenceSender, converting a panic of the CLA into an error.
+func (c *Core) dispatchTo(node cla.ConvergenceSender, bndl bpv7.Bundle) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("CLA panicked while sending: %v", r)
+		}
+	}()
+
+	return c.sendFragmented(node, bndl)
+}
@@ -0,0 +1,114 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+	"github.com/dtn7/dtn7-go/pkg/cla"
+)
+
+// dispatchSender is a ConvergenceSender whose Send is implemented by a function.
+type dispatchSender struct {
+	peer bpv7.EndpointID
+	send func(bpv7.Bundle) error
+}
+
+func (ds *dispatchSender) Close() error                        { return nil }
+func (ds *dispatchSender) Start() (error, bool)                { return nil, false }
+func (ds *dispatchSender) Channel() chan cla.ConvergenceStatus { return nil }
+func (ds *dispatchSender) Address() string                     { return "dispatch://" + ds.peer.String() }
+func (ds *dispatchSender) IsPermanent() bool                   { return false }
+func (ds *dispatchSender) GetPeerEndpointID() bpv7.EndpointID  { return ds.peer }
+func (ds *dispatchSender) Send(b bpv7.Bundle) error            { return ds.send(b) }
+
+func TestCoreDispatch(t *testing.T) {
+	c, err := NewCore(t.TempDir(), bpv7.MustNewEndpointID("dtn://a/"), false, RoutingConf{Algorithm: "epidemic"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	bndl, err := bpv7.Builder().
+		CRC(bpv7.CRC32).
+		Source("dtn://a/").
+		Destination("dtn://z/").
+		CreationTimestampNow().
+		Lifetime("10m").
+		HopCountBlock(64).
+		PayloadBlock([]byte("hello world")).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	bp := NewBundleDescriptorFromBundle(bndl, c.Store)
+
+	// Both succeeding senders serialize the bundle and block until the other one was entered as well.
+	var entered sync.WaitGroup
+	entered.Add(2)
+	concurrentSend := func(b bpv7.Bundle) error {
+		if err := b.WriteBundle(io.Discard); err != nil {
+			return err
+		}
+
+		entered.Done()
+		done := make(chan struct{})
+		go func() { entered.Wait(); close(done) }()
+
+		select {
+		case <-done:
+			return nil
+		case <-time.After(5 * time.Second):
+			return fmt.Errorf("bundle was not sent concurrently")
+		}
+	}
+
+	var sent sync.Map
+	nodes := []cla.ConvergenceSender{
+		&dispatchSender{bpv7.MustNewEndpointID("dtn://b/"), concurrentSend},
+		&dispatchSender{bpv7.MustNewEndpointID("dtn://c/"), concurrentSend},
+		&dispatchSender{bpv7.MustNewEndpointID("dtn://d/"), func(bpv7.Bundle) error { panic("oops") }},
+		&dispatchSender{bpv7.MustNewEndpointID("dtn://e/"), func(bpv7.Bundle) error {
+			return &cla.InterruptedTransferError{Acknowledged: 5, Err: fmt.Errorf("interrupted")}
+		}},
+	}
+	for _, node := range nodes {
+		node := node.(*dispatchSender)
+		send := node.send
+		node.send = func(b bpv7.Bundle) error {
+			err := send(b)
+			sent.Store(node.peer, err)
+			return err
+		}
+	}
+
+	result := c.dispatch(bp, nodes)
+	if !result.sent {
+		t.Fatal("bundle was not sent")
+	} else if result.interruptedAck != 5 {
+		t.Fatalf("expected 5 acknowledged bytes, got %d", result.interruptedAck)
+	}
+
+	for i, node := range nodes {
+		errI, ok := sent.Load(node.GetPeerEndpointID())
+		if i == 2 {
+			if ok {
+				t.Fatal("panicking sender has returned")
+			}
+			continue
+		} else if !ok {
+			t.Fatalf("sender %v was not called", node.GetPeerEndpointID())
+		}
+
+		if err, _ := errI.(error); (err == nil) != (i < 2) {
+			t.Fatalf("sender %v returned unexpected %v", node.GetPeerEndpointID(), err)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"sync/atomic"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+	"github.com/dtn7/dtn7-go/pkg/cla"
+)
+
+// SetDeferBulk defers forwarding bundles of the bulk priority, e.g., to save energy on a low battery. Deferred bundles
+// remain in the store and are retried by the next check of pending bundles after deferring ended.
+func (c *Core) SetDeferBulk(deferBulk bool) {
+	var value uint32
+	if deferBulk {
+		value = 1
+	}
+
+	if old := atomic.SwapUint32(&c.deferBulk, value); old == value {
+		return
+	} else if deferBulk {
+		log.Info("Deferring forwarding of bulk bundles")
+	} else {
+		log.Info("Resuming forwarding of bulk bundles")
+	}
+}
+
+// IsDeferringBulk checks if forwarding bulk bundles is deferred, see SetDeferBulk.
+func (c *Core) IsDeferringBulk() bool {
+	return atomic.LoadUint32(&c.deferBulk) == 1
+}
+
+// deferBulkBundle returns no ConvergenceSenders for a bulk bundle while forwarding those is deferred.
+func (c *Core) deferBulkBundle(bp BundleDescriptor, nodes []cla.ConvergenceSender) []cla.ConvergenceSender {
+	if len(nodes) == 0 || bp.Priority != bpv7.PriorityBulk || !c.IsDeferringBulk() {
+		return nodes
+	}
+
+	log.WithField("bundle", bp.ID().String()).Debug("Deferred forwarding of bulk bundle")
+	return nil
+}
@@ -0,0 +1,58 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"testing"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+	"github.com/dtn7/dtn7-go/pkg/cla"
+)
+
+func TestCoreDeferBulk(t *testing.T) {
+	c := newTestCore(t, "dtn://a/")
+	defer c.Close()
+
+	descriptor := func(priority bpv7.Priority) BundleDescriptor {
+		bndl, err := bpv7.Builder().
+			Source("dtn://a/app").
+			Destination("dtn://z/app").
+			CreationTimestampNow().
+			Lifetime("10m").
+			PriorityBlock(priority).
+			PayloadBlock([]byte("hello world")).
+			Build()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return NewBundleDescriptorFromBundle(bndl, c.Store)
+	}
+	bulk, normal := descriptor(bpv7.PriorityBulk), descriptor(bpv7.PriorityNormal)
+
+	nodes := []cla.ConvergenceSender{&dispatchSender{peer: bpv7.MustNewEndpointID("dtn://b/")}}
+
+	tests := []struct {
+		deferBulk bool
+		bp        BundleDescriptor
+		expected  int
+	}{
+		{false, bulk, 1},
+		{false, normal, 1},
+		{true, bulk, 0},
+		{true, normal, 1},
+		{false, bulk, 1},
+	}
+
+	for i, test := range tests {
+		c.SetDeferBulk(test.deferBulk)
+		if c.IsDeferringBulk() != test.deferBulk {
+			t.Fatalf("test %d: deferring is %t", i, c.IsDeferringBulk())
+		}
+
+		if selected := c.deferBulkBundle(test.bp, nodes); len(selected) != test.expected {
+			t.Fatalf("test %d: expected %d senders, got %d", i, test.expected, len(selected))
+		}
+	}
+}
@@ -0,0 +1,208 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+	"github.com/dtn7/dtn7-go/pkg/cla"
+)
+
+// ErasureCoding splits large, locally created bundles into erasure-coded shards, see bpv7.Bundle.EncodeShards. The
+// shards are spread over different next hops and the bundle is reassembled at its destination once DataShards of its
+// shards arrived. Thus, up to ParityShards shards might be lost without flooding the whole bundle. Bundles which must
+// not be fragmented, e.g., anonymous ones, are never sharded.
+type ErasureCoding struct {
+	// MinSize is the minimum serialized size of a bundle in bytes to be sharded. Zero disables erasure coding.
+	MinSize uint64
+	// DataShards are required for the reassembly, ParityShards are sent additionally.
+	DataShards   int
+	ParityShards int
+}
+
+// shardSet collects the shards of one bundle until its reassembly.
+type shardSet struct {
+	shards  []bpv7.Bundle
+	indices map[uint64]bool
+	expires time.Time
+	// done marks a reassembled bundle, whose late shards are dropped until its expiration.
+	done bool
+}
+
+// shardCollector keeps the shards of bundles addressed to this node by their bundle's ID.
+type shardCollector struct {
+	mutex sync.Mutex
+	sets  map[string]*shardSet
+}
+
+func newShardCollector() *shardCollector {
+	return &shardCollector{sets: make(map[string]*shardSet)}
+}
+
+// add a shard, expiring with its lifetime. If enough shards are present, they are returned for the reassembly.
+func (sc *shardCollector) add(shard bpv7.Bundle, now time.Time) (shards []bpv7.Bundle, complete bool) {
+	sb, ok := shard.Shard()
+	if !ok {
+		return
+	}
+
+	created := now
+	if !shard.PrimaryBlock.CreationTimestamp.IsZeroTime() {
+		created = shard.PrimaryBlock.CreationTimestamp.DtnTime().Time()
+	}
+	expires := created.Add(time.Duration(shard.PrimaryBlock.Lifetime) * time.Millisecond)
+
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+
+	key := sb.Bundle.String()
+	set, exists := sc.sets[key]
+	if !exists {
+		set = &shardSet{indices: make(map[uint64]bool)}
+		sc.sets[key] = set
+	}
+	if expires.After(set.expires) {
+		set.expires = expires
+	}
+
+	if set.done || set.indices[sb.Index] {
+		return
+	}
+	set.indices[sb.Index] = true
+	set.shards = append(set.shards, shard)
+
+	if uint64(len(set.shards)) < sb.DataShards {
+		return
+	}
+
+	shards, complete = set.shards, true
+	set.shards, set.done = nil, true
+	return
+}
+
+// expire the shards of bundles whose lifetime ended.
+func (sc *shardCollector) expire(now time.Time) {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+
+	for key, set := range sc.sets {
+		if now.After(set.expires) {
+			delete(sc.sets, key)
+		}
+	}
+}
+
+// SetErasureCoding of locally created bundles. Shards of other nodes' bundles are always reassembled.
+func (c *Core) SetErasureCoding(ec ErasureCoding) error {
+	if ec.MinSize > 0 && (ec.DataShards < 1 || ec.ParityShards < 0 || ec.DataShards+ec.ParityShards > 256) {
+		return fmt.Errorf("%d data and %d parity shards are invalid", ec.DataShards, ec.ParityShards)
+	}
+
+	c.updateSettings(func(s *coreSettings) { s.erasureCoding = ec })
+	return nil
+}
+
+// shardBundle replaces an outgoing bundle by its shards, if erasure coding is enabled and the bundle is large enough.
+// The returned boolean indicates if the bundle was sharded.
+func (c *Core) shardBundle(bndl *bpv7.Bundle) bool {
+	ec := c.loadSettings().erasureCoding
+	if ec.MinSize == 0 || bndl.IsAdministrativeRecord() || bndl.HasExtensionBlock(bpv7.ExtBlockTypeShardBlock) ||
+		bndl.PrimaryBlock.BundleControlFlags.Has(bpv7.MustNotFragmented) ||
+		!bndl.PrimaryBlock.Destination.IsSingleton() || c.HasEndpoint(bndl.PrimaryBlock.Destination) {
+		return false
+	}
+
+	if size, err := bndl.SerializedSize(); err != nil || size < ec.MinSize {
+		return false
+	}
+
+	// The original bundle's ID is fixed by its shards, thus it is signed before being encoded.
+	c.sendBundleAttachSignature(bndl)
+
+	shards, err := bndl.EncodeShards(c.NodeId, ec.DataShards, ec.ParityShards)
+	if err != nil {
+		log.WithField("bundle", bndl.ID().String()).WithError(err).Warn("Sharding bundle erred, sending it unaltered")
+		return false
+	}
+
+	log.WithFields(log.Fields{
+		"bundle": bndl.ID().String(),
+		"data":   ec.DataShards,
+		"parity": ec.ParityShards,
+	}).Info("Bundle was split into erasure-coded shards")
+
+	for i := range shards {
+		c.SendBundle(&shards[i])
+	}
+	return true
+}
+
+// spreadShard selects one next hop for a shard by its index, spreading a bundle's shards over different peers instead
+// of replicating each shard to all next hops.
+func spreadShard(bndl *bpv7.Bundle, nodes []cla.ConvergenceSender) []cla.ConvergenceSender {
+	sb, ok := bndl.Shard()
+	if !ok || len(nodes) <= 1 {
+		return nodes
+	}
+
+	var peers []string
+	senders := make(map[string]cla.ConvergenceSender)
+	for _, node := range nodes {
+		peer := node.GetPeerEndpointID().NodeID().String()
+		if _, known := senders[peer]; !known {
+			peers = append(peers, peer)
+			senders[peer] = node
+		}
+	}
+	sort.Strings(peers)
+
+	return []cla.ConvergenceSender{senders[peers[sb.Index%uint64(len(peers))]]}
+}
+
+// collectShard of a bundle addressed to this node. The shard itself is not delivered, but kept in memory until
+// enough shards arrived to reassemble the bundle, which is then processed like a received one.
+func (c *Core) collectShard(bp BundleDescriptor) {
+	logger := log.WithField("bundle", bp.ID().String())
+
+	shard, err := bp.MustBundle().Copy()
+	if err != nil {
+		logger.WithError(err).Warn("Copying shard erred")
+		return
+	}
+
+	shards, complete := c.shards.add(shard, bpv7.Now())
+
+	bp.PurgeConstraints()
+	_ = bp.Sync()
+
+	if !complete {
+		logger.Debug("Collected shard of an erasure-coded bundle")
+		return
+	}
+
+	bndl, err := bpv7.ReassembleShards(shards)
+	if err != nil {
+		logger.WithError(err).Warn("Reassembling erasure-coded bundle erred")
+		return
+	}
+
+	logger.WithField("reassembled", bndl.ID().String()).Info("Reassembled erasure-coded bundle from its shards")
+
+	if c.isKnownBundle(&bndl) {
+		return
+	}
+
+	reassembled := NewBundleDescriptorFromBundle(bndl, c.Store)
+	reassembled.Receiver = bp.Receiver
+	_ = reassembled.Sync()
+
+	c.receive(reassembled, nil)
+}
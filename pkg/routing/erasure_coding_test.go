@@ -0,0 +1,190 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/agent"
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+	"github.com/dtn7/dtn7-go/pkg/cla"
+)
+
+func TestCoreErasureCoding(t *testing.T) {
+	src := newTestCore(t, "dtn://a/")
+	defer src.Close()
+	if err := src.SetErasureCoding(ErasureCoding{MinSize: 1024, DataShards: 4, ParityShards: 2}); err != nil {
+		t.Fatal(err)
+	}
+
+	payload := make([]byte, 4096)
+	rand.Read(payload)
+
+	bndl, err := bpv7.Builder().
+		Source("dtn://a/app").
+		Destination("dtn://b/app").
+		CreationTimestampNow().
+		Lifetime("10m").
+		PayloadBlock(payload).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	src.SendBundle(&bndl)
+	bid := bndl.ID()
+
+	if src.Store.KnowsBundle(bid) {
+		t.Fatal("sharded bundle is stored itself")
+	}
+
+	bis, err := src.Store.QueryPending()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var shards []bpv7.Bundle
+	for _, bi := range bis {
+		shard, err := bi.Load()
+		if err != nil {
+			t.Fatal(err)
+		} else if sb, ok := shard.Shard(); !ok || sb.Bundle != bid {
+			t.Fatalf("stored bundle %v is no shard", shard.ID())
+		}
+		shards = append(shards, shard)
+	}
+	if len(shards) != 6 {
+		t.Fatalf("expected 6 shards, got %d", len(shards))
+	}
+
+	dst := newTestCore(t, "dtn://b/")
+	defer dst.Close()
+
+	app := &coreTestAgent{
+		endpoint: bpv7.MustNewEndpointID("dtn://b/app"),
+		receiver: make(chan agent.Message),
+		sender:   make(chan agent.Message),
+	}
+	dst.RegisterApplicationAgent(app)
+
+	// Two shards are lost, the remaining ones arrive in any order.
+	received := make(chan struct{})
+	go func() {
+		defer close(received)
+		for _, i := range rand.Perm(len(shards))[:4] {
+			dst.receive(NewBundleDescriptorFromBundle(shards[i], dst.Store), nil)
+		}
+	}()
+
+	select {
+	case msg := <-app.receiver:
+		delivered := msg.(agent.BundleMessage).Bundle
+		if delivered.ID() != bid {
+			t.Fatalf("delivered bundle %v instead of %v", delivered.ID(), bid)
+		} else if data, _ := delivered.PayloadData(); !bytes.Equal(data, payload) {
+			t.Fatal("reassembled bundle's payload differs")
+		}
+
+	case <-time.After(5 * time.Second):
+		t.Fatal("bundle was not reassembled")
+	}
+	<-received
+
+	go func() {
+		for msg := range app.receiver {
+			if _, isShutdown := msg.(agent.ShutdownMessage); isShutdown {
+				close(app.sender)
+				return
+			}
+		}
+	}()
+}
+
+func TestCoreErasureCodingSameSecond(t *testing.T) {
+	c := newTestCore(t, "dtn://a/")
+	defer c.Close()
+	if err := c.SetErasureCoding(ErasureCoding{MinSize: 1024, DataShards: 2, ParityShards: 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Both bundles share their creation time and are only distinguished by their sequence numbers.
+	now := time.Now()
+	bids := make(map[bpv7.BundleID]bool)
+	for i := 0; i < 2; i++ {
+		payload := make([]byte, 2048)
+		rand.Read(payload)
+
+		bndl, err := bpv7.Builder().
+			Source("dtn://a/app").
+			Destination("dtn://b/app").
+			CreationTimestampTime(now).
+			Lifetime("10m").
+			PayloadBlock(payload).
+			Build()
+		if err != nil {
+			t.Fatal(err)
+		}
+		c.SendBundle(&bndl)
+		bids[bndl.ID()] = true
+	}
+	if len(bids) != 2 {
+		t.Fatalf("both bundles got the same ID %v", bids)
+	}
+
+	bis, err := c.Store.QueryPending()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(bis) != 6 {
+		t.Fatalf("expected 6 shards, got %d", len(bis))
+	}
+	for _, bi := range bis {
+		shard, err := bi.Load()
+		if err != nil {
+			t.Fatal(err)
+		} else if sb, ok := shard.Shard(); !ok || !bids[sb.Bundle] {
+			t.Fatalf("stored bundle %v is no shard of the sent bundles", shard.ID())
+		}
+	}
+}
+
+func TestSpreadShard(t *testing.T) {
+	bndl, err := bpv7.Builder().
+		Source("dtn://a/app").
+		Destination("dtn://d/app").
+		CreationTimestampNow().
+		Lifetime("10m").
+		PayloadBlock(make([]byte, 64)).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	shards, err := bndl.EncodeShards(bpv7.MustNewEndpointID("dtn://a/"), 2, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b := &dispatchSender{peer: bpv7.MustNewEndpointID("dtn://b/")}
+	c := &dispatchSender{peer: bpv7.MustNewEndpointID("dtn://c/")}
+	nodes := []cla.ConvergenceSender{c, b}
+
+	peers := make(map[bpv7.EndpointID]int)
+	for i := range shards {
+		selected := spreadShard(&shards[i], nodes)
+		if len(selected) != 1 {
+			t.Fatalf("shard %d was spread to %d next hops", i, len(selected))
+		}
+		peers[selected[0].GetPeerEndpointID()]++
+	}
+	if peers[b.GetPeerEndpointID()] != 2 || peers[c.GetPeerEndpointID()] != 1 {
+		t.Fatalf("shards were not spread by their index, %v", peers)
+	}
+
+	if selected := spreadShard(&bndl, nodes); len(selected) != 2 {
+		t.Fatalf("unsharded bundle was sent to %d next hops", len(selected))
+	}
+}
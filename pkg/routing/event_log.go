@@ -0,0 +1,122 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+// EventLogEntry is an Event as recorded in an EventLog. Bundle IDs and Endpoint IDs are kept in their String
+// representation; unset fields are omitted.
+type EventLogEntry struct {
+	Type   EventType `json:"type"`
+	Time   time.Time `json:"time"`
+	Bundle string    `json:"bundle,omitempty"`
+	Peer   string    `json:"peer,omitempty"`
+	Peers  []string  `json:"peers,omitempty"`
+	Reason string    `json:"reason,omitempty"`
+}
+
+// newEventLogEntry converts an Event into its EventLogEntry.
+func newEventLogEntry(e Event) (entry EventLogEntry) {
+	entry.Type = e.Type
+	entry.Time = e.Time
+
+	if e.Bundle != (bpv7.BundleID{}) {
+		entry.Bundle = e.Bundle.String()
+	}
+	if e.Peer != (bpv7.EndpointID{}) {
+		entry.Peer = e.Peer.String()
+	}
+	for _, peer := range e.Peers {
+		entry.Peers = append(entry.Peers, peer.String())
+	}
+	if e.Type == BundleDeleted {
+		entry.Reason = e.Reason.String()
+	}
+	return
+}
+
+// EventLog records Events to an append-only file, one JSON encoded EventLogEntry per line. Such a log can be read by
+// an EventLogReader, e.g., to analyze a node's behavior offline.
+type EventLog struct {
+	mutex sync.Mutex
+	file  *os.File
+	enc   *json.Encoder
+}
+
+// OpenEventLog opens or creates the file to append Events to.
+func OpenEventLog(path string) (*EventLog, error) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EventLog{file: f, enc: json.NewEncoder(f)}, nil
+}
+
+// Record an Event. This method is an EventHandler to be passed to Core.Subscribe. Errors are logged.
+func (el *EventLog) Record(e Event) {
+	el.mutex.Lock()
+	defer el.mutex.Unlock()
+
+	if err := el.enc.Encode(newEventLogEntry(e)); err != nil {
+		log.WithField("event", e.Type).WithError(err).Warn("Failed to record event")
+	}
+}
+
+// Close the EventLog's file.
+func (el *EventLog) Close() error {
+	el.mutex.Lock()
+	defer el.mutex.Unlock()
+
+	return el.file.Close()
+}
+
+// EventLogReader reads the EventLogEntries written by an EventLog.
+type EventLogReader struct {
+	dec *json.Decoder
+}
+
+// NewEventLogReader reads EventLogEntries from an io.Reader, e.g., an EventLog's file.
+func NewEventLogReader(r io.Reader) *EventLogReader {
+	return &EventLogReader{dec: json.NewDecoder(r)}
+}
+
+// Next returns the next EventLogEntry or io.EOF after the last one.
+func (elr *EventLogReader) Next() (entry EventLogEntry, err error) {
+	err = elr.dec.Decode(&entry)
+	return
+}
+
+// ReadEventLog reads all EventLogEntries from an EventLog's file.
+func ReadEventLog(path string) (entries []EventLogEntry, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return
+	}
+	defer func() { _ = f.Close() }()
+
+	elr := NewEventLogReader(f)
+	for {
+		entry, nextErr := elr.Next()
+		if nextErr == io.EOF {
+			return
+		} else if nextErr != nil {
+			err = nextErr
+			return
+		}
+
+		entries = append(entries, entry)
+	}
+}
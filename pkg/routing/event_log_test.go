@@ -0,0 +1,91 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+func TestEventLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.log")
+
+	bid := bpv7.BundleID{
+		SourceNode: bpv7.MustNewEndpointID("dtn://src/"),
+		Timestamp:  bpv7.NewCreationTimestamp(bpv7.DtnTimeEpoch, 23),
+	}
+	peer := bpv7.MustNewEndpointID("dtn://peer/")
+	now := time.Now().Round(0)
+
+	events := []Event{
+		{Type: BundleReceived, Time: now, Bundle: bid},
+		{Type: BundleRouted, Time: now, Bundle: bid, Peers: []bpv7.EndpointID{peer}},
+		{Type: BundleTransmitted, Time: now, Bundle: bid, Peer: peer},
+		{Type: BundleDeleted, Time: now, Bundle: bid, Reason: bpv7.LifetimeExpired},
+	}
+	expected := []EventLogEntry{
+		{Type: BundleReceived, Time: now, Bundle: bid.String()},
+		{Type: BundleRouted, Time: now, Bundle: bid.String(), Peers: []string{peer.String()}},
+		{Type: BundleTransmitted, Time: now, Bundle: bid.String(), Peer: peer.String()},
+		{Type: BundleDeleted, Time: now, Bundle: bid.String(), Reason: bpv7.LifetimeExpired.String()},
+	}
+
+	// Events are appended to an existing log.
+	for i := 0; i < 2; i++ {
+		el, err := OpenEventLog(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, e := range events[2*i : 2*i+2] {
+			el.Record(e)
+		}
+		if err := el.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	entries, err := ReadEventLog(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != len(expected) {
+		t.Fatalf("read %d entries, expected %d", len(entries), len(expected))
+	}
+	for i := range entries {
+		if !entries[i].Time.Equal(expected[i].Time) {
+			t.Fatalf("entry %d has time %v, expected %v", i, entries[i].Time, expected[i].Time)
+		}
+		entries[i].Time = expected[i].Time
+
+		if !reflect.DeepEqual(entries[i], expected[i]) {
+			t.Fatalf("entry %d is %v, expected %v", i, entries[i], expected[i])
+		}
+	}
+}
+
+func TestEventTypeText(t *testing.T) {
+	for _, et := range eventTypes {
+		text, err := et.MarshalText()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var parsed EventType
+		if err := parsed.UnmarshalText(text); err != nil {
+			t.Fatal(err)
+		} else if parsed != et {
+			t.Fatalf("parsed %v from %s, expected %v", parsed, text, et)
+		}
+	}
+
+	var parsed EventType
+	if err := parsed.UnmarshalText([]byte("unknown")); err == nil {
+		t.Fatal("unknown event type was parsed")
+	}
+}
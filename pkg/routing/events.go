@@ -0,0 +1,188 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+// EventType describes the kind of an Event, published by the Core.
+type EventType int
+
+const (
+	// BundleReceived is published for each newly received bundle, excluding duplicates.
+	BundleReceived EventType = iota
+
+	// BundleForwarded is published after a bundle was sent to at least one peer.
+	BundleForwarded
+
+	// BundleDelivered is published after a bundle was delivered to a local endpoint.
+	BundleDelivered
+
+	// BundleDeleted is published for each deleted bundle, including its deletion reason.
+	BundleDeleted
+
+	// PeerAppeared is published if a CLA's peer appeared.
+	PeerAppeared
+
+	// PeerDisappeared is published if a CLA's peer disappeared.
+	PeerDisappeared
+
+	// StoreEvicted is published if a bundle was evicted from the store to comply with its limit, see PriorityPolicy.
+	StoreEvicted
+
+	// BundleRouted is published after the routing decision for a bundle, listing the selected Peers.
+	BundleRouted
+
+	// BundleTransmitted is published for each peer a bundle was successfully sent to.
+	BundleTransmitted
+
+	// BundleAcknowledged is published if an outgoing bundle's delivery was acknowledged by its destination, the Peer.
+	BundleAcknowledged
+
+	// LinkUpdated is published if a node's announced links changed, e.g., by DTLSR's peer data. Peer is this node and
+	// Peers are its currently connected neighbors.
+	LinkUpdated
+)
+
+// eventTypes lists all EventTypes, e.g., to parse their String representation.
+var eventTypes = []EventType{BundleReceived, BundleForwarded, BundleDelivered, BundleDeleted,
+	PeerAppeared, PeerDisappeared, StoreEvicted, BundleRouted, BundleTransmitted, BundleAcknowledged, LinkUpdated}
+
+func (et EventType) String() string {
+	switch et {
+	case BundleReceived:
+		return "bundle received"
+	case BundleForwarded:
+		return "bundle forwarded"
+	case BundleDelivered:
+		return "bundle delivered"
+	case BundleDeleted:
+		return "bundle deleted"
+	case PeerAppeared:
+		return "peer appeared"
+	case PeerDisappeared:
+		return "peer disappeared"
+	case StoreEvicted:
+		return "store evicted"
+	case BundleRouted:
+		return "bundle routed"
+	case BundleTransmitted:
+		return "bundle transmitted"
+	case BundleAcknowledged:
+		return "bundle acknowledged"
+	case LinkUpdated:
+		return "link updated"
+	default:
+		return "unknown"
+	}
+}
+
+// MarshalText returns the String representation, e.g., for an EventLog.
+func (et EventType) MarshalText() ([]byte, error) {
+	return []byte(et.String()), nil
+}
+
+// UnmarshalText parses an EventType from its String representation.
+func (et *EventType) UnmarshalText(text []byte) error {
+	for _, t := range eventTypes {
+		if t.String() == string(text) {
+			*et = t
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown event type %q", text)
+}
+
+// Event published by the Core. Depending on its Type, only some fields are set.
+type Event struct {
+	Type EventType
+	Time time.Time
+
+	// Bundle is set for bundle related events.
+	Bundle bpv7.BundleID
+	// Peer is set for peer related events, BundleTransmitted, BundleAcknowledged, and LinkUpdated.
+	Peer bpv7.EndpointID
+	// Peers is set for BundleRouted and LinkUpdated events.
+	Peers []bpv7.EndpointID
+	// Reason is set for BundleDeleted events.
+	Reason bpv7.StatusReportReason
+}
+
+// EventHandler receives published Events. Handlers are called synchronously and must return quickly.
+type EventHandler func(Event)
+
+// eventSubscription is an EventHandler for a set of EventTypes, or all types if empty.
+type eventSubscription struct {
+	handler EventHandler
+	types   map[EventType]bool
+}
+
+// eventBus dispatches Events to the EventHandlers subscribed for their EventType.
+type eventBus struct {
+	mutex         sync.RWMutex
+	nextId        uint64
+	subscriptions map[uint64]eventSubscription
+}
+
+// newEventBus without any subscriptions.
+func newEventBus() *eventBus {
+	return &eventBus{subscriptions: make(map[uint64]eventSubscription)}
+}
+
+// subscribe an EventHandler and return the subscription's id.
+func (bus *eventBus) subscribe(handler EventHandler, types []EventType) uint64 {
+	sub := eventSubscription{handler: handler, types: make(map[EventType]bool)}
+	for _, t := range types {
+		sub.types[t] = true
+	}
+
+	bus.mutex.Lock()
+	defer bus.mutex.Unlock()
+
+	id := bus.nextId
+	bus.nextId++
+	bus.subscriptions[id] = sub
+	return id
+}
+
+// unsubscribe a subscription by its id.
+func (bus *eventBus) unsubscribe(id uint64) {
+	bus.mutex.Lock()
+	defer bus.mutex.Unlock()
+
+	delete(bus.subscriptions, id)
+}
+
+// publish an Event to all matching subscriptions. A zero Time is set to the current time.
+func (bus *eventBus) publish(e Event) {
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+
+	bus.mutex.RLock()
+	var handlers []EventHandler
+	for _, sub := range bus.subscriptions {
+		if len(sub.types) == 0 || sub.types[e.Type] {
+			handlers = append(handlers, sub.handler)
+		}
+	}
+	bus.mutex.RUnlock()
+
+	for _, handler := range handlers {
+		handler(e)
+	}
+}
+
+// Subscribe an EventHandler to the Core's Events of the given types, or all Events if no type is given. The returned
+// function cancels this subscription.
+func (c *Core) Subscribe(handler EventHandler, types ...EventType) (unsubscribe func()) {
+	id := c.events.subscribe(handler, types)
+	return func() { c.events.unsubscribe(id) }
+}
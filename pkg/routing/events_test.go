@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"testing"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+func TestEventBus(t *testing.T) {
+	bus := newEventBus()
+
+	var all, deleted []Event
+	allId := bus.subscribe(func(e Event) { all = append(all, e) }, nil)
+	bus.subscribe(func(e Event) { deleted = append(deleted, e) }, []EventType{BundleDeleted})
+
+	bus.publish(Event{Type: BundleReceived})
+	bus.publish(Event{Type: BundleDeleted, Reason: bpv7.LifetimeExpired})
+
+	bus.unsubscribe(allId)
+	bus.publish(Event{Type: PeerAppeared})
+
+	if len(all) != 2 {
+		t.Fatalf("expected two events for all types, got %v", all)
+	}
+	if len(deleted) != 1 || deleted[0].Reason != bpv7.LifetimeExpired {
+		t.Fatalf("expected one deletion event, got %v", deleted)
+	}
+	for _, e := range append(all, deleted...) {
+		if e.Time.IsZero() {
+			t.Fatalf("event %v has no time", e)
+		}
+	}
+}
+
+func TestDTLSRLinkUpdated(t *testing.T) {
+	conf := RoutingConf{
+		Algorithm: "dtlsr",
+		DTLSRConf: DTLSRConfig{RecomputeTime: "30s", BroadcastTime: "30s", PurgeTime: "10m"},
+	}
+
+	c, err := NewCore(t.TempDir(), bpv7.MustNewEndpointID("dtn://a/"), false, conf, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	var updates []Event
+	c.Subscribe(func(e Event) { updates = append(updates, e) }, LinkUpdated)
+
+	nodeB, nodeC, nodeD := bpv7.MustNewEndpointID("dtn://b/"), bpv7.MustNewEndpointID("dtn://c/"), bpv7.MustNewEndpointID("dtn://d/")
+	data := bpv7.DTLSRPeerData{
+		ID:        nodeB,
+		Timestamp: bpv7.DtnTimeNow(),
+		Peers:     map[bpv7.EndpointID]bpv7.DtnTime{nodeC: 0, nodeD: bpv7.DtnTimeNow()},
+	}
+
+	bndl, err := bpv7.Builder().
+		Source(nodeB).
+		Destination(dtlsrBroadcastAddress).
+		CreationTimestampNow().
+		Lifetime("1m").
+		Canonical(bpv7.NewDTLSRBlock(data)).
+		PayloadBlock(byte(1)).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The same peer data is only published once.
+	for i := 0; i < 2; i++ {
+		c.routing.NotifyNewBundle(NewBundleDescriptorFromBundle(bndl, c.Store))
+	}
+
+	if len(updates) != 1 {
+		t.Fatalf("expected one link update, got %v", updates)
+	} else if e := updates[0]; e.Peer != nodeB || len(e.Peers) != 1 || e.Peers[0] != nodeC {
+		t.Fatalf("unexpected link update %v", e)
+	}
+}
@@ -0,0 +1,11 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+// Package external contains the gRPC service of an external routing process, generated from external.proto.
+//
+// The "external" routing algorithm of the routing package is a client of this ExternalRouting service. An external
+// process, e.g., a researcher's prototype written in Python, implements this service based on the same external.proto.
+package external
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative external.proto
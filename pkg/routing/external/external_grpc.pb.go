@@ -0,0 +1,275 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: external.proto
+
+// The gRPC service of an external routing process, used by dtnd's "external"
+// routing algorithm. The external process serves this service; dtnd is its client.
+
+package external
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	ExternalRouting_NotifyNewBundle_FullMethodName       = "/dtn7.routing.external.ExternalRouting/NotifyNewBundle"
+	ExternalRouting_SenderForBundle_FullMethodName       = "/dtn7.routing.external.ExternalRouting/SenderForBundle"
+	ExternalRouting_ReportFailure_FullMethodName         = "/dtn7.routing.external.ExternalRouting/ReportFailure"
+	ExternalRouting_ReportPeerAppeared_FullMethodName    = "/dtn7.routing.external.ExternalRouting/ReportPeerAppeared"
+	ExternalRouting_ReportPeerDisappeared_FullMethodName = "/dtn7.routing.external.ExternalRouting/ReportPeerDisappeared"
+)
+
+// ExternalRoutingClient is the client API for ExternalRouting service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ExternalRoutingClient interface {
+	// NotifyNewBundle informs about a newly received or created bundle.
+	NotifyNewBundle(ctx context.Context, in *Bundle, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// SenderForBundle asks for the peers to forward a bundle to, out of the currently connected peers.
+	SenderForBundle(ctx context.Context, in *SenderForBundleRequest, opts ...grpc.CallOption) (*SenderForBundleResponse, error)
+	// ReportFailure informs about a failed transmission of a bundle to a peer.
+	ReportFailure(ctx context.Context, in *Failure, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// ReportPeerAppeared informs about a newly connected peer.
+	ReportPeerAppeared(ctx context.Context, in *Peer, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// ReportPeerDisappeared informs about a disconnected peer.
+	ReportPeerDisappeared(ctx context.Context, in *Peer, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type externalRoutingClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewExternalRoutingClient(cc grpc.ClientConnInterface) ExternalRoutingClient {
+	return &externalRoutingClient{cc}
+}
+
+func (c *externalRoutingClient) NotifyNewBundle(ctx context.Context, in *Bundle, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, ExternalRouting_NotifyNewBundle_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *externalRoutingClient) SenderForBundle(ctx context.Context, in *SenderForBundleRequest, opts ...grpc.CallOption) (*SenderForBundleResponse, error) {
+	out := new(SenderForBundleResponse)
+	err := c.cc.Invoke(ctx, ExternalRouting_SenderForBundle_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *externalRoutingClient) ReportFailure(ctx context.Context, in *Failure, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, ExternalRouting_ReportFailure_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *externalRoutingClient) ReportPeerAppeared(ctx context.Context, in *Peer, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, ExternalRouting_ReportPeerAppeared_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *externalRoutingClient) ReportPeerDisappeared(ctx context.Context, in *Peer, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, ExternalRouting_ReportPeerDisappeared_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ExternalRoutingServer is the server API for ExternalRouting service.
+// All implementations must embed UnimplementedExternalRoutingServer
+// for forward compatibility
+type ExternalRoutingServer interface {
+	// NotifyNewBundle informs about a newly received or created bundle.
+	NotifyNewBundle(context.Context, *Bundle) (*emptypb.Empty, error)
+	// SenderForBundle asks for the peers to forward a bundle to, out of the currently connected peers.
+	SenderForBundle(context.Context, *SenderForBundleRequest) (*SenderForBundleResponse, error)
+	// ReportFailure informs about a failed transmission of a bundle to a peer.
+	ReportFailure(context.Context, *Failure) (*emptypb.Empty, error)
+	// ReportPeerAppeared informs about a newly connected peer.
+	ReportPeerAppeared(context.Context, *Peer) (*emptypb.Empty, error)
+	// ReportPeerDisappeared informs about a disconnected peer.
+	ReportPeerDisappeared(context.Context, *Peer) (*emptypb.Empty, error)
+	mustEmbedUnimplementedExternalRoutingServer()
+}
+
+// UnimplementedExternalRoutingServer must be embedded to have forward compatible implementations.
+type UnimplementedExternalRoutingServer struct {
+}
+
+func (UnimplementedExternalRoutingServer) NotifyNewBundle(context.Context, *Bundle) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NotifyNewBundle not implemented")
+}
+func (UnimplementedExternalRoutingServer) SenderForBundle(context.Context, *SenderForBundleRequest) (*SenderForBundleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SenderForBundle not implemented")
+}
+func (UnimplementedExternalRoutingServer) ReportFailure(context.Context, *Failure) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportFailure not implemented")
+}
+func (UnimplementedExternalRoutingServer) ReportPeerAppeared(context.Context, *Peer) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportPeerAppeared not implemented")
+}
+func (UnimplementedExternalRoutingServer) ReportPeerDisappeared(context.Context, *Peer) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportPeerDisappeared not implemented")
+}
+func (UnimplementedExternalRoutingServer) mustEmbedUnimplementedExternalRoutingServer() {}
+
+// UnsafeExternalRoutingServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ExternalRoutingServer will
+// result in compilation errors.
+type UnsafeExternalRoutingServer interface {
+	mustEmbedUnimplementedExternalRoutingServer()
+}
+
+func RegisterExternalRoutingServer(s grpc.ServiceRegistrar, srv ExternalRoutingServer) {
+	s.RegisterService(&ExternalRouting_ServiceDesc, srv)
+}
+
+func _ExternalRouting_NotifyNewBundle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Bundle)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExternalRoutingServer).NotifyNewBundle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExternalRouting_NotifyNewBundle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExternalRoutingServer).NotifyNewBundle(ctx, req.(*Bundle))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExternalRouting_SenderForBundle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SenderForBundleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExternalRoutingServer).SenderForBundle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExternalRouting_SenderForBundle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExternalRoutingServer).SenderForBundle(ctx, req.(*SenderForBundleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExternalRouting_ReportFailure_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Failure)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExternalRoutingServer).ReportFailure(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExternalRouting_ReportFailure_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExternalRoutingServer).ReportFailure(ctx, req.(*Failure))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExternalRouting_ReportPeerAppeared_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Peer)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExternalRoutingServer).ReportPeerAppeared(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExternalRouting_ReportPeerAppeared_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExternalRoutingServer).ReportPeerAppeared(ctx, req.(*Peer))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExternalRouting_ReportPeerDisappeared_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Peer)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExternalRoutingServer).ReportPeerDisappeared(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExternalRouting_ReportPeerDisappeared_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExternalRoutingServer).ReportPeerDisappeared(ctx, req.(*Peer))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ExternalRouting_ServiceDesc is the grpc.ServiceDesc for ExternalRouting service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ExternalRouting_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "dtn7.routing.external.ExternalRouting",
+	HandlerType: (*ExternalRoutingServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "NotifyNewBundle",
+			Handler:    _ExternalRouting_NotifyNewBundle_Handler,
+		},
+		{
+			MethodName: "SenderForBundle",
+			Handler:    _ExternalRouting_SenderForBundle_Handler,
+		},
+		{
+			MethodName: "ReportFailure",
+			Handler:    _ExternalRouting_ReportFailure_Handler,
+		},
+		{
+			MethodName: "ReportPeerAppeared",
+			Handler:    _ExternalRouting_ReportPeerAppeared_Handler,
+		},
+		{
+			MethodName: "ReportPeerDisappeared",
+			Handler:    _ExternalRouting_ReportPeerDisappeared_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "external.proto",
+}
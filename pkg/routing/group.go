@@ -0,0 +1,76 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+// SetGroupMemberships sets the non-singleton Endpoint IDs of groups this node is a member of, e.g.,
+// "dtn://sensors/~all". Bundles for these groups are delivered locally and still forwarded to other members.
+func (c *Core) SetGroupMemberships(groups []bpv7.EndpointID) error {
+	for _, group := range groups {
+		if group.IsSingleton() {
+			return fmt.Errorf("group MUST NOT be a singleton; %s is", group)
+		}
+	}
+
+	c.groupsMutex.Lock()
+	defer c.groupsMutex.Unlock()
+
+	c.groups = groups
+	return nil
+}
+
+// GroupMemberships returns the Endpoint IDs set by SetGroupMemberships.
+func (c *Core) GroupMemberships() []bpv7.EndpointID {
+	c.groupsMutex.RLock()
+	defer c.groupsMutex.RUnlock()
+
+	return append([]bpv7.EndpointID(nil), c.groups...)
+}
+
+// isGroupMember checks if a non-singleton endpoint is a group of this node, either configured by
+// SetGroupMemberships or registered by a local agent.
+func (c *Core) isGroupMember(endpoint bpv7.EndpointID) bool {
+	if endpoint.EndpointType == nil || endpoint.IsSingleton() {
+		return false
+	}
+
+	for _, group := range c.GroupMemberships() {
+		if group == endpoint {
+			return true
+		}
+	}
+	return c.agentManager.HasEndpoint(endpoint)
+}
+
+// groupDelivery delivers a bundle for a group endpoint once to the local agents and forwards it to other members.
+func (c *Core) groupDelivery(bp BundleDescriptor) {
+	if !bp.HasConstraint(GroupDelivered) {
+		log.WithField("bundle", bp.ID().String()).Info("Received bundle for a group of this node")
+
+		bp.AddConstraint(GroupDelivered)
+		bp.AddConstraint(LocalEndpoint)
+		_ = bp.Sync()
+
+		if err := c.agentManager.Deliver(bp); err != nil {
+			log.WithField("bundle", bp.ID().String()).WithError(err).Warn("Delivering group bundle erred")
+
+			bp.RemoveConstraint(LocalEndpoint)
+			_ = bp.Sync()
+		} else {
+			c.events.publish(Event{Type: BundleDelivered, Bundle: bp.ID()})
+		}
+
+		c.SendStatusReport(bp, bpv7.DeliveredBundle, bpv7.NoInformation)
+	}
+
+	c.forward(bp)
+}
@@ -0,0 +1,85 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+func TestCoreGroupMemberships(t *testing.T) {
+	c := newTestCore(t, "dtn://a/")
+	defer c.Close()
+
+	if err := c.SetGroupMemberships([]bpv7.EndpointID{bpv7.MustNewEndpointID("dtn://a/")}); err == nil {
+		t.Fatal("singleton group was accepted")
+	}
+	if err := c.SetGroupMemberships([]bpv7.EndpointID{bpv7.MustNewEndpointID("dtn://sensors/~all")}); err != nil {
+		t.Fatal(err)
+	}
+	c.RegisterApplicationAgent(newCoreTestAgent(bpv7.MustNewEndpointID("dtn://news/~all")))
+
+	tests := []struct {
+		eid    string
+		member bool
+	}{
+		{"dtn://sensors/~all", true},
+		{"dtn://news/~all", true},
+		{"dtn://other/~all", false},
+		{"dtn://a/", false},
+	}
+	for _, test := range tests {
+		if member := c.isGroupMember(bpv7.MustNewEndpointID(test.eid)); member != test.member {
+			t.Fatalf("%s: membership is %t, expected %t", test.eid, member, test.member)
+		}
+	}
+}
+
+func TestCoreGroupDelivery(t *testing.T) {
+	c := newTestCore(t, "dtn://a/")
+	defer c.Close()
+
+	group := bpv7.MustNewEndpointID("dtn://sensors/~all")
+	c.RegisterApplicationAgent(newCoreTestAgent(group))
+
+	delivered := make(chan bpv7.BundleID, 2)
+	c.Subscribe(func(e Event) { delivered <- e.Bundle }, BundleDelivered)
+
+	bndl, err := bpv7.Builder().
+		Source("dtn://a/outbox").
+		Destination(group).
+		CreationTimestampNow().
+		Lifetime("10m").
+		PayloadBlock([]byte("hello group")).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.SendBundle(&bndl)
+
+	select {
+	case bid := <-delivered:
+		if bid != bndl.ID() {
+			t.Fatalf("delivered %v, expected %v", bid, bndl.ID())
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("group bundle was not delivered")
+	}
+
+	// The bundle must be kept for other group members and must not be delivered again on its retransmission.
+	bp := NewBundleDescriptor(bndl.ID(), c.Store)
+	if !c.Store.KnowsBundle(bndl.ID()) || !bp.HasConstraint(GroupDelivered) {
+		t.Fatalf("group bundle was not kept, constraints: %v", bp.Constraints)
+	}
+
+	c.dispatching(bp)
+	select {
+	case bid := <-delivered:
+		t.Fatalf("%v was delivered twice", bid)
+	case <-time.After(250 * time.Millisecond):
+	}
+}
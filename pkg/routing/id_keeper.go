@@ -40,9 +40,9 @@ func NewIdKeeper() IdKeeper {
 }
 
 // update updates the IdKeeper's state regarding this bundle and sets this
-// bundle's sequence number.
-func (idk *IdKeeper) update(bp *BundleDescriptor) {
-	bndl := bp.MustBundle()
+// bundle's sequence number. This must happen before the bundle is stored, as
+// its sequence number is part of its ID.
+func (idk *IdKeeper) update(bndl *bpv7.Bundle) {
 	var tpl = newIdTuple(bndl)
 
 	idk.mutex.Lock()
@@ -53,7 +53,6 @@ func (idk *IdKeeper) update(bp *BundleDescriptor) {
 	}
 
 	bndl.PrimaryBlock.CreationTimestamp[1] = idk.data[tpl]
-	bp.Id.Timestamp[1] = idk.data[tpl]
 	idk.mutex.Unlock()
 }
 
@@ -64,7 +63,7 @@ func (idk *IdKeeper) Clean() {
 	var threshold = bpv7.DtnTimeNow() - 60
 
 	for tpl := range idk.data {
-		if tpl.time < threshold {
+		if tpl.time != bpv7.DtnTimeEpoch && tpl.time < threshold {
 			delete(idk.data, tpl)
 		}
 	}
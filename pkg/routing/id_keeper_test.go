@@ -26,17 +26,6 @@ func TestIdKeeper(t *testing.T) {
 		t.Errorf("Creating bundle failed: %v", err)
 	}
 
-	bdsc0 := BundleDescriptor{
-		Id:          bndl0.ID(),
-		Receiver:    bpv7.DtnNone(),
-		Timestamp:   time.Now(),
-		Constraints: make(map[Constraint]bool),
-		Tags:        make(map[Tag]struct{}),
-
-		bndl:  &bndl0,
-		store: nil,
-	}
-
 	bndl1, err := bpv7.Builder().
 		Source("dtn://src/").
 		Destination("dtn://dest/").
@@ -50,21 +39,10 @@ func TestIdKeeper(t *testing.T) {
 		t.Errorf("Creating bundle failed: %v", err)
 	}
 
-	bdsc1 := BundleDescriptor{
-		Id:          bndl1.ID(),
-		Receiver:    bpv7.DtnNone(),
-		Timestamp:   time.Now(),
-		Constraints: make(map[Constraint]bool),
-		Tags:        make(map[Tag]struct{}),
-
-		bndl:  &bndl1,
-		store: nil,
-	}
-
 	var keeper = NewIdKeeper()
 
-	keeper.update(&bdsc0)
-	keeper.update(&bdsc1)
+	keeper.update(&bndl0)
+	keeper.update(&bndl1)
 
 	if seq := bndl0.PrimaryBlock.CreationTimestamp.SequenceNumber(); seq != 0 {
 		t.Errorf("First bundle's sequence number is %d", seq)
@@ -74,3 +52,95 @@ func TestIdKeeper(t *testing.T) {
 		t.Errorf("Second bundle's sequence number is %d", seq)
 	}
 }
+
+func TestCoreSendBundleSequenceNumbers(t *testing.T) {
+	c := newTestCore(t, "dtn://a/")
+	defer c.Close()
+
+	c.RegisterApplicationAgent(newCoreTestAgent(bpv7.MustNewEndpointID("dtn://a/inbox")))
+
+	delivered := make(chan bpv7.BundleID, 5)
+	c.Subscribe(func(e Event) { delivered <- e.Bundle }, BundleDelivered)
+
+	// All bundles share the same creation time and must be distinguished by their sequence numbers.
+	now := time.Now()
+	for i := 0; i < cap(delivered); i++ {
+		bndl, err := bpv7.Builder().
+			Source("dtn://a/outbox").
+			Destination("dtn://a/inbox").
+			CreationTimestampTime(now).
+			Lifetime("10m").
+			PayloadBlock([]byte{byte(i)}).
+			Build()
+		if err != nil {
+			t.Fatal(err)
+		}
+		c.SendBundle(&bndl)
+	}
+
+	seen := make(map[bpv7.BundleID]struct{})
+	for len(seen) < cap(delivered) {
+		select {
+		case bid := <-delivered:
+			seen[bid] = struct{}{}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("only %d of %d bundles were delivered", len(seen), cap(delivered))
+		}
+	}
+}
+
+func TestIdKeeperCleanEpoch(t *testing.T) {
+	var keeper = NewIdKeeper()
+
+	epoch := bpv7.Bundle{PrimaryBlock: bpv7.PrimaryBlock{
+		SourceNode:        bpv7.MustNewEndpointID("dtn://src/"),
+		CreationTimestamp: bpv7.NewCreationTimestamp(bpv7.DtnTimeEpoch, 0),
+	}}
+	old := bpv7.Bundle{PrimaryBlock: bpv7.PrimaryBlock{
+		SourceNode:        bpv7.MustNewEndpointID("dtn://src/"),
+		CreationTimestamp: bpv7.NewCreationTimestamp(bpv7.DtnTimeNow()-3600, 0),
+	}}
+
+	keeper.update(&epoch)
+	keeper.update(&old)
+	keeper.Clean()
+
+	if _, ok := keeper.data[newIdTuple(&epoch)]; !ok {
+		t.Errorf("Clean removed the epoch time's state")
+	}
+	if _, ok := keeper.data[newIdTuple(&old)]; ok {
+		t.Errorf("Clean kept an outdated state")
+	}
+}
+
+func TestCoreSendBundleClocklessClean(t *testing.T) {
+	c := newTestCore(t, "dtn://a/")
+	defer c.Close()
+	c.SetClockless(true)
+
+	seen := make(map[string]struct{})
+	for i := 0; i < 5; i++ {
+		bndl, err := bpv7.Builder().
+			Source("dtn://a/outbox").
+			Destination("dtn://b/inbox").
+			CreationTimestampEpoch().
+			Lifetime("10m").
+			BundleAgeBlock(0, bpv7.DeleteBundle).
+			PayloadBlock([]byte{byte(i)}).
+			Build()
+		if err != nil {
+			t.Fatal(err)
+		}
+		c.SendBundle(&bndl)
+
+		if !bndl.PrimaryBlock.CreationTimestamp.IsZeroTime() {
+			t.Fatalf("clockless bundle %v has a creation time", bndl.ID())
+		}
+		if _, ok := seen[bndl.ID().String()]; ok {
+			t.Fatalf("bundle ID %v was assigned twice", bndl.ID())
+		}
+		seen[bndl.ID().String()] = struct{}{}
+
+		c.IdKeeper.Clean()
+	}
+}
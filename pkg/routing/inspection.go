@@ -0,0 +1,46 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+// Inspection is a machine-readable snapshot of a Core's routing state, e.g., to export the network's topology. In
+// contrast to WriteStatus, it is restricted to the routing-relevant parts.
+type Inspection struct {
+	// Node is this Core's node ID.
+	Node bpv7.EndpointID
+
+	// Time of this snapshot.
+	Time time.Time
+
+	// Algorithm is the configured routing algorithm's name, or empty if it was set by SetRoutingAlgorithm.
+	Algorithm string
+
+	// Neighbors are all currently known Neighbors, see NeighborTable.
+	Neighbors []Neighbor
+
+	// RoutingTable of the Algorithm, or nil if it does not implement RoutingTableDumper.
+	RoutingTable map[string]string
+}
+
+// Inspect this Core's routing state.
+func (c *Core) Inspect() Inspection {
+	inspection := Inspection{
+		Node:      c.NodeId,
+		Time:      time.Now(),
+		Algorithm: c.routingConf.Algorithm,
+		Neighbors: c.Neighbors().Neighbors(),
+	}
+
+	if dumper, ok := c.routing.(RoutingTableDumper); ok {
+		inspection.RoutingTable = dumper.RoutingTable()
+	}
+
+	return inspection
+}
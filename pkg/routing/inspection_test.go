@@ -0,0 +1,58 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+func TestCoreInspect(t *testing.T) {
+	conf := RoutingConf{
+		Algorithm: "dtlsr",
+		DTLSRConf: DTLSRConfig{RecomputeTime: "30s", BroadcastTime: "30s", PurgeTime: "10m"},
+	}
+
+	c, err := NewCore(t.TempDir(), bpv7.MustNewEndpointID("dtn://a/"), false, conf, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	peer := bpv7.MustNewEndpointID("dtn://b/")
+	c.neighbors.connect(peer, "10.0.0.2:4556", time.Now())
+
+	dtlsr := c.routing.(*DTLSR)
+	dtlsr.routingTable.Store(map[bpv7.EndpointID]bpv7.EndpointID{bpv7.MustNewEndpointID("dtn://c/"): peer})
+
+	inspection := c.Inspect()
+
+	if inspection.Node != c.NodeId {
+		t.Fatalf("expected node %v, got %v", c.NodeId, inspection.Node)
+	}
+	if inspection.Algorithm != "dtlsr" {
+		t.Fatalf("expected algorithm dtlsr, got %q", inspection.Algorithm)
+	}
+	if l := len(inspection.Neighbors); l != 1 || inspection.Neighbors[0].Endpoint != peer || !inspection.Neighbors[0].Connected() {
+		t.Fatalf("expected connected neighbor %v, got %v", peer, inspection.Neighbors)
+	}
+	if nextHop := inspection.RoutingTable["dtn://c/"]; nextHop != "dtn://b/" {
+		t.Fatalf("expected next hop dtn://b/, got %q", nextHop)
+	}
+}
+
+func TestCoreInspectWithoutRoutingTable(t *testing.T) {
+	c, err := NewCore(t.TempDir(), bpv7.MustNewEndpointID("dtn://a/"), false, RoutingConf{Algorithm: "epidemic"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	if inspection := c.Inspect(); inspection.RoutingTable != nil || len(inspection.Neighbors) != 0 {
+		t.Fatalf("expected neither a routing table nor neighbors, got %v", inspection)
+	}
+}
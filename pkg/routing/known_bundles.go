@@ -0,0 +1,117 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"hash/fnv"
+	"math"
+	"sync"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+// knownBundlesFalsePositiveRate is the targeted false positive rate of a knownBundles filter. A false positive drops
+// a new bundle as a presumed duplicate.
+const knownBundlesFalsePositiveRate = 0.001
+
+// bloomFilter is a fixed size bloom filter, using double hashing of a 64-bit FNV-1a hash.
+type bloomFilter struct {
+	bits   []uint64
+	m      uint64
+	k      uint64
+	length uint
+}
+
+// newBloomFilter for n elements with a false positive rate of p.
+func newBloomFilter(n uint, p float64) *bloomFilter {
+	m := uint64(math.Ceil(-float64(n) * math.Log(p) / (math.Ln2 * math.Ln2)))
+	if m < 64 {
+		m = 64
+	}
+	k := uint64(math.Round(float64(m) / float64(n) * math.Ln2))
+	if k < 1 {
+		k = 1
+	}
+
+	return &bloomFilter{
+		bits: make([]uint64, (m+63)/64),
+		m:    m,
+		k:    k,
+	}
+}
+
+// positions of the k bits for some data.
+func (bf *bloomFilter) positions(data []byte) func(i uint64) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write(data)
+	sum := h.Sum64()
+	h1, h2 := sum&0xFFFFFFFF, sum>>32|1
+
+	return func(i uint64) uint64 {
+		return (h1 + i*h2) % bf.m
+	}
+}
+
+// add data to this bloomFilter.
+func (bf *bloomFilter) add(data []byte) {
+	pos := bf.positions(data)
+	for i := uint64(0); i < bf.k; i++ {
+		p := pos(i)
+		bf.bits[p/64] |= 1 << (p % 64)
+	}
+	bf.length++
+}
+
+// test if data might have been added to this bloomFilter.
+func (bf *bloomFilter) test(data []byte) bool {
+	pos := bf.positions(data)
+	for i := uint64(0); i < bf.k; i++ {
+		p := pos(i)
+		if bf.bits[p/64]&(1<<(p%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// knownBundles remembers recently seen bundle IDs in two rotating bloom filters. After the current filter holds its
+// capacity, it replaces the previous one. Thus, between capacity and twice the capacity of the most recent bundle
+// IDs are remembered with a bounded false positive rate.
+type knownBundles struct {
+	capacity uint
+	current  *bloomFilter
+	previous *bloomFilter
+	mutex    sync.Mutex
+}
+
+// newKnownBundles remembering at least capacity bundle IDs.
+func newKnownBundles(capacity uint) *knownBundles {
+	return &knownBundles{
+		capacity: capacity,
+		current:  newBloomFilter(capacity, knownBundlesFalsePositiveRate),
+		previous: newBloomFilter(capacity, knownBundlesFalsePositiveRate),
+	}
+}
+
+// checkAndAdd reports if a bundle ID is already known and remembers it otherwise. Fragments of the same bundle have
+// different IDs.
+func (kb *knownBundles) checkAndAdd(bid bpv7.BundleID) bool {
+	data := []byte(bid.String())
+
+	kb.mutex.Lock()
+	defer kb.mutex.Unlock()
+
+	if kb.current.test(data) || kb.previous.test(data) {
+		return true
+	}
+
+	if kb.current.length >= kb.capacity {
+		kb.previous = kb.current
+		kb.current = newBloomFilter(kb.capacity, knownBundlesFalsePositiveRate)
+	}
+	kb.current.add(data)
+
+	return false
+}
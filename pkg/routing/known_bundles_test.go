@@ -0,0 +1,94 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"testing"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+func TestKnownBundles(t *testing.T) {
+	const capacity = 1000
+
+	kb := newKnownBundles(capacity)
+	src := bpv7.MustNewEndpointID("dtn://src/")
+	bid := func(seq uint64) bpv7.BundleID {
+		return bpv7.BundleID{SourceNode: src, Timestamp: bpv7.NewCreationTimestamp(bpv7.DtnTimeEpoch, seq)}
+	}
+
+	for seq := uint64(0); seq < capacity; seq++ {
+		if kb.checkAndAdd(bid(seq)) {
+			t.Fatalf("new bundle ID %d is known", seq)
+		}
+	}
+	for seq := uint64(0); seq < capacity; seq++ {
+		if !kb.checkAndAdd(bid(seq)) {
+			t.Fatalf("bundle ID %d is unknown", seq)
+		}
+	}
+
+	// Fill the next filter, which rotates the first one out afterwards.
+	for seq := uint64(capacity); seq < 3*capacity; seq++ {
+		kb.checkAndAdd(bid(seq))
+	}
+
+	falsePositives := 0
+	for seq := uint64(10 * capacity); seq < 20*capacity; seq++ {
+		if kb.checkAndAdd(bid(seq)) {
+			falsePositives++
+		}
+	}
+	if falsePositives > 10*capacity/100 {
+		t.Fatalf("%d false positives for %d new bundle IDs", falsePositives, 10*capacity)
+	}
+
+	forgotten := 0
+	for seq := uint64(0); seq < capacity; seq++ {
+		if !kb.checkAndAdd(bid(seq)) {
+			forgotten++
+		}
+	}
+	if forgotten == 0 {
+		t.Fatal("no bundle ID was forgotten after rotation")
+	}
+}
+
+func TestCoreSetKnownBundles(t *testing.T) {
+	c := newTestCore(t, "dtn://a/")
+	defer c.Close()
+
+	bndl, err := bpv7.Builder().
+		Source("dtn://b/").
+		Destination("dtn://a/").
+		CreationTimestampNow().
+		Lifetime("10m").
+		PayloadBlock([]byte("hello world")).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.SetKnownBundles(100)
+	if c.isKnownBundle(&bndl) {
+		t.Fatal("new bundle is known")
+	}
+
+	// Reloading an unchanged capacity keeps the remembered bundle IDs.
+	c.SetKnownBundles(100)
+	if !c.isKnownBundle(&bndl) {
+		t.Fatal("bundle was forgotten by setting an unchanged capacity")
+	}
+
+	c.SetKnownBundles(200)
+	if c.isKnownBundle(&bndl) {
+		t.Fatal("bundle is known after changing the capacity")
+	}
+
+	c.SetKnownBundles(0)
+	if c.isKnownBundle(&bndl) || c.isKnownBundle(&bndl) {
+		t.Fatal("disabled filter knows a bundle")
+	}
+}
@@ -0,0 +1,127 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+	"github.com/dtn7/dtn7-go/pkg/cla"
+)
+
+// RoutingTableSizer might be implemented by an Algorithm to report the size of its routing table for Metrics.
+type RoutingTableSizer interface {
+	// RoutingTableSize returns the number of entries in this Algorithm's routing table.
+	RoutingTableSize() int
+}
+
+// Metrics is a snapshot of a Core's counters and gauges, e.g., for monitoring.
+type Metrics struct {
+	// Received, Forwarded, and Delivered count bundles since the Core's start.
+	Received  uint64
+	Forwarded uint64
+	Delivered uint64
+	// Deleted counts deleted bundles by their deletion reason, see Core.DeletionStatistics.
+	Deleted map[bpv7.StatusReportReason]uint64
+
+	// StoredBundles is the number of bundles currently held in the store.
+	StoredBundles int
+	// ConnectedPeers is the number of currently connected peers.
+	ConnectedPeers int
+	// RoutingTableSize is the size of the Algorithm's routing table, or -1 if it does not implement RoutingTableSizer.
+	RoutingTableSize int
+
+	// BytesSent and BytesReceived count the serialized bundle bytes by each CLA's address.
+	BytesSent     map[string]uint64
+	BytesReceived map[string]uint64
+}
+
+// coreMetrics are the Core's counters, being part of the Metrics.
+type coreMetrics struct {
+	mutex sync.Mutex
+
+	received  uint64
+	forwarded uint64
+	delivered uint64
+	deleted   map[bpv7.StatusReportReason]uint64
+
+	bytesSent     map[string]uint64
+	bytesReceived map[string]uint64
+}
+
+// newCoreMetrics with all counters being zero.
+func newCoreMetrics() *coreMetrics {
+	return &coreMetrics{
+		deleted:       make(map[bpv7.StatusReportReason]uint64),
+		bytesSent:     make(map[string]uint64),
+		bytesReceived: make(map[string]uint64),
+	}
+}
+
+// handleEvent is an EventHandler, counting bundle Events.
+func (cm *coreMetrics) handleEvent(e Event) {
+	cm.mutex.Lock()
+	defer cm.mutex.Unlock()
+
+	switch e.Type {
+	case BundleReceived:
+		cm.received++
+	case BundleForwarded:
+		cm.forwarded++
+	case BundleDelivered:
+		cm.delivered++
+	case BundleDeleted:
+		cm.deleted[e.Reason]++
+	}
+}
+
+// countBytes adds a bundle's serialized size to a CLA's byte counter.
+func (cm *coreMetrics) countBytes(counter map[string]uint64, conv cla.Convergence, bndl *bpv7.Bundle) {
+	size, err := bndl.SerializedSize()
+	if err != nil {
+		log.WithField("bundle", bndl.ID().String()).WithError(err).Debug("Failed to calculate size for metrics")
+		return
+	}
+
+	cm.mutex.Lock()
+	counter[conv.Address()] += size
+	cm.mutex.Unlock()
+}
+
+// Metrics returns a snapshot of this Core's Metrics.
+func (c *Core) Metrics() (m Metrics) {
+	c.metrics.mutex.Lock()
+	m.Received = c.metrics.received
+	m.Forwarded = c.metrics.forwarded
+	m.Delivered = c.metrics.delivered
+
+	m.BytesSent = make(map[string]uint64, len(c.metrics.bytesSent))
+	for address, n := range c.metrics.bytesSent {
+		m.BytesSent[address] = n
+	}
+	m.BytesReceived = make(map[string]uint64, len(c.metrics.bytesReceived))
+	for address, n := range c.metrics.bytesReceived {
+		m.BytesReceived[address] = n
+	}
+	c.metrics.mutex.Unlock()
+
+	m.Deleted = c.DeletionStatistics()
+	m.ConnectedPeers = len(c.ConnectedPeers())
+
+	if n, err := c.Store.Count(); err != nil {
+		log.WithError(err).Warn("Failed to count stored bundles for metrics")
+	} else {
+		m.StoredBundles = n
+	}
+
+	m.RoutingTableSize = -1
+	if sizer, ok := c.routing.(RoutingTableSizer); ok {
+		m.RoutingTableSize = sizer.RoutingTableSize()
+	}
+
+	return
+}
@@ -0,0 +1,211 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+// neighborRetention is the duration a disconnected Neighbor is kept after it was last seen.
+const neighborRetention = 24 * time.Hour
+
+// Neighbor is a node in direct reach of this node, either connected by at least one CLA or announced by the peer
+// discovery.
+type Neighbor struct {
+	Endpoint bpv7.EndpointID
+
+	// CLAs are the addresses of all currently connected CLAs to this neighbor.
+	CLAs []string
+
+	// LastSeen is the latest time this neighbor was connected, reached, or announced by the peer discovery.
+	LastSeen time.Time
+
+	// Disconnected is the time this neighbor's last CLA disappeared, or zero if it was never disconnected.
+	Disconnected time.Time
+
+	// Transmissions and Failures count all successful and failed transmissions to this neighbor.
+	Transmissions uint64
+	Failures      uint64
+}
+
+// Connected checks if this Neighbor has at least one connected CLA.
+func (n Neighbor) Connected() bool {
+	return len(n.CLAs) > 0
+}
+
+// Quality is the measured ratio of successful transmissions to this Neighbor, one without any transmission.
+func (n Neighbor) Quality() float64 {
+	if total := n.Transmissions + n.Failures; total > 0 {
+		return float64(n.Transmissions) / float64(total)
+	}
+	return 1
+}
+
+// NeighborTable is the Core's view of all Neighbors, maintained from the CLA manager's peer events, the transmissions,
+// and the peer discovery. Routing algorithms should consult this table instead of keeping their own peer state.
+//
+// Neighbors are identified by their node IDs, as returned by bpv7.EndpointID.NodeID. Thus, a peer announcing itself as
+// "ipn:23.0" and a bundle's destination "ipn:23.42" refer to the same Neighbor.
+type NeighborTable struct {
+	mutex     sync.RWMutex
+	neighbors map[bpv7.EndpointID]*Neighbor
+}
+
+// newNeighborTable creates an empty NeighborTable.
+func newNeighborTable() *NeighborTable {
+	return &NeighborTable{neighbors: make(map[bpv7.EndpointID]*Neighbor)}
+}
+
+// entry returns a peer's Neighbor, creating a new one. The mutex must be held.
+func (nt *NeighborTable) entry(peer bpv7.EndpointID) *Neighbor {
+	peer = peer.NodeID()
+	n, exists := nt.neighbors[peer]
+	if !exists {
+		n = &Neighbor{Endpoint: peer}
+		nt.neighbors[peer] = n
+	}
+	return n
+}
+
+// connect a peer's CLA. True is returned if this is the peer's first connected CLA.
+func (nt *NeighborTable) connect(peer bpv7.EndpointID, address string, now time.Time) (first bool) {
+	nt.mutex.Lock()
+	defer nt.mutex.Unlock()
+
+	n := nt.entry(peer)
+	first = !n.Connected()
+	n.LastSeen = now
+
+	for _, other := range n.CLAs {
+		if other == address {
+			return
+		}
+	}
+	n.CLAs = append(n.CLAs, address)
+	return
+}
+
+// disconnect a peer's CLA. True is returned if this was the peer's last connected CLA.
+func (nt *NeighborTable) disconnect(peer bpv7.EndpointID, address string, now time.Time) (last bool) {
+	nt.mutex.Lock()
+	defer nt.mutex.Unlock()
+
+	n, exists := nt.neighbors[peer.NodeID()]
+	if !exists || !n.Connected() {
+		return false
+	}
+
+	for i, other := range n.CLAs {
+		if other == address {
+			n.CLAs = append(n.CLAs[:i], n.CLAs[i+1:]...)
+			break
+		}
+	}
+
+	if !n.Connected() {
+		n.Disconnected = now
+		return true
+	}
+	return false
+}
+
+// isConnected checks if a peer's CLA is currently connected.
+func (nt *NeighborTable) isConnected(peer bpv7.EndpointID, address string) bool {
+	nt.mutex.RLock()
+	defer nt.mutex.RUnlock()
+
+	if n, exists := nt.neighbors[peer.NodeID()]; exists {
+		for _, other := range n.CLAs {
+			if other == address {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// transmitted records a successful or failed transmission to a peer.
+func (nt *NeighborTable) transmitted(peer bpv7.EndpointID, success bool, now time.Time) {
+	nt.mutex.Lock()
+	defer nt.mutex.Unlock()
+
+	n := nt.entry(peer)
+	if success {
+		n.Transmissions++
+		n.LastSeen = now
+	} else {
+		n.Failures++
+	}
+}
+
+// discovered merges the peers announced by a peer discovery.
+func (nt *NeighborTable) discovered(peers []DiscoveredPeer) {
+	nt.mutex.Lock()
+	defer nt.mutex.Unlock()
+
+	for _, peer := range peers {
+		if n := nt.entry(peer.Endpoint); peer.LastSeen.After(n.LastSeen) {
+			n.LastSeen = peer.LastSeen
+		}
+	}
+}
+
+// Neighbor returns a copy of a peer's Neighbor, if known. Any endpoint of the peer can be passed, e.g., "ipn:23.42"
+// for the Neighbor "ipn:23.0".
+func (nt *NeighborTable) Neighbor(peer bpv7.EndpointID) (Neighbor, bool) {
+	nt.mutex.RLock()
+	defer nt.mutex.RUnlock()
+
+	n, exists := nt.neighbors[peer.NodeID()]
+	if !exists {
+		return Neighbor{}, false
+	}
+
+	neighbor := *n
+	neighbor.CLAs = append([]string(nil), n.CLAs...)
+	return neighbor, true
+}
+
+// Neighbors returns copies of all known Neighbors, ordered by their Endpoint IDs.
+func (nt *NeighborTable) Neighbors() []Neighbor {
+	nt.mutex.RLock()
+	defer nt.mutex.RUnlock()
+
+	neighbors := make([]Neighbor, 0, len(nt.neighbors))
+	for _, n := range nt.neighbors {
+		neighbor := *n
+		neighbor.CLAs = append([]string(nil), n.CLAs...)
+		neighbors = append(neighbors, neighbor)
+	}
+
+	sort.Slice(neighbors, func(i, j int) bool {
+		return neighbors[i].Endpoint.String() < neighbors[j].Endpoint.String()
+	})
+	return neighbors
+}
+
+// purge all disconnected Neighbors not seen since the given time.
+func (nt *NeighborTable) purge(before time.Time) {
+	nt.mutex.Lock()
+	defer nt.mutex.Unlock()
+
+	for peer, n := range nt.neighbors {
+		if !n.Connected() && n.LastSeen.Before(before) {
+			delete(nt.neighbors, peer)
+		}
+	}
+}
+
+// Neighbors returns the NeighborTable, after merging the peers currently known by the peer discovery.
+func (c *Core) Neighbors() *NeighborTable {
+	if peers := c.DiscoveredPeers(); len(peers) > 0 {
+		c.neighbors.discovered(peers)
+	}
+	return c.neighbors
+}
@@ -0,0 +1,107 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+func TestNeighborTable(t *testing.T) {
+	nt := newNeighborTable()
+	now := time.Now()
+
+	peerA := bpv7.MustNewEndpointID("dtn://a/")
+	peerB := bpv7.MustNewEndpointID("dtn://b/")
+
+	if !nt.connect(peerA, "10.0.0.1:4556", now) {
+		t.Fatal("first CLA was not reported as first")
+	} else if nt.connect(peerA, "10.0.0.1:35037", now) {
+		t.Fatal("second CLA was reported as first")
+	}
+
+	if nt.disconnect(peerA, "10.0.0.1:4556", now) {
+		t.Fatal("disconnecting one of two CLAs was reported as last")
+	} else if n, _ := nt.Neighbor(peerA); !n.Connected() || len(n.CLAs) != 1 {
+		t.Fatalf("neighbor has CLAs %v", n.CLAs)
+	}
+
+	nt.transmitted(peerA, true, now)
+	nt.transmitted(peerA, true, now)
+	nt.transmitted(peerA, true, now)
+	nt.transmitted(peerA, false, now)
+	if n, _ := nt.Neighbor(peerA); n.Quality() != 0.75 {
+		t.Fatalf("quality is %f, expected 0.75", n.Quality())
+	}
+
+	disconnected := now.Add(time.Second)
+	if !nt.disconnect(peerA, "10.0.0.1:35037", disconnected) {
+		t.Fatal("disconnecting the last CLA was not reported as last")
+	} else if n, _ := nt.Neighbor(peerA); n.Connected() || !n.Disconnected.Equal(disconnected) {
+		t.Fatalf("neighbor is connected or has a wrong disconnection time %v", n.Disconnected)
+	}
+
+	nt.discovered([]DiscoveredPeer{{Endpoint: peerB, LastSeen: now.Add(-time.Hour)}})
+	if neighbors := nt.Neighbors(); len(neighbors) != 2 || neighbors[0].Endpoint != peerA || neighbors[1].Endpoint != peerB {
+		t.Fatalf("unexpected neighbors %v", neighbors)
+	}
+
+	nt.purge(now.Add(-time.Minute))
+	if _, known := nt.Neighbor(peerB); known {
+		t.Fatal("stale neighbor was not purged")
+	} else if _, known := nt.Neighbor(peerA); !known {
+		t.Fatal("recent neighbor was purged")
+	}
+}
+
+func TestNeighborTableIpn(t *testing.T) {
+	nt := newNeighborTable()
+	now := time.Now()
+
+	nt.connect(bpv7.MustNewEndpointID("ipn:23.0"), "10.0.0.1:4556", now)
+	nt.transmitted(bpv7.MustNewEndpointID("ipn:23.42"), true, now)
+
+	if n, known := nt.Neighbor(bpv7.MustNewEndpointID("ipn:23.7")); !known || !n.Connected() || n.Transmissions != 1 {
+		t.Fatalf("ipn service endpoint does not refer to its node's neighbor: %v, %t", n, known)
+	} else if n.Endpoint != bpv7.MustNewEndpointID("ipn:23.0") {
+		t.Fatalf("neighbor is identified by %v", n.Endpoint)
+	}
+
+	if !nt.disconnect(bpv7.MustNewEndpointID("ipn:23.1"), "10.0.0.1:4556", now) {
+		t.Fatal("disconnecting by a service endpoint was not reported as last")
+	} else if neighbors := nt.Neighbors(); len(neighbors) != 1 {
+		t.Fatalf("unexpected neighbors %v", neighbors)
+	}
+}
+
+func TestCoreReportPeerUnreachable(t *testing.T) {
+	c := newTestCore(t, "dtn://a/")
+	defer c.Close()
+
+	var disappeared []bpv7.EndpointID
+	c.Subscribe(func(e Event) { disappeared = append(disappeared, e.Peer) }, PeerDisappeared)
+
+	peer := bpv7.MustNewEndpointID("dtn://b/")
+	sender := &dispatchSender{peer: peer}
+
+	// A CLA which was never connected is ignored.
+	c.ReportPeerUnreachable(sender)
+	if len(disappeared) != 0 {
+		t.Fatalf("unconnected CLA disappeared: %v", disappeared)
+	}
+
+	c.neighbors.connect(peer, sender.Address(), time.Now())
+	c.ReportPeerUnreachable(sender)
+	c.ReportPeerUnreachable(sender)
+
+	if len(disappeared) != 1 || disappeared[0] != peer {
+		t.Fatalf("expected one disappearance of %v, got %v", peer, disappeared)
+	}
+	if n, _ := c.neighbors.Neighbor(peer); n.Connected() {
+		t.Fatalf("unreachable peer is still connected: %v", n)
+	}
+}
@@ -90,12 +90,15 @@ func (pipeline *Pipeline) sendReport(descriptor BundleDescriptor, status bpv7.St
 	// - not enabled for this status information.
 	// - it's an outgoing bundle.
 	// - the bundle is an administrative record itself.
+	// - the report-to endpoint is dtn:none.
 	if ok, exists := pipeline.SendReports[status]; !ok || !exists {
 		return
 	} else if descriptor.HasTag(Outgoing) {
 		return
 	} else if descriptor.MustBundle().PrimaryBlock.BundleControlFlags.Has(bpv7.AdministrativeRecordPayload) {
 		return
+	} else if descriptor.MustBundle().PrimaryBlock.ReportTo == bpv7.DtnNone() {
+		return
 	}
 
 	pipeline.log().WithFields(log.Fields{
@@ -0,0 +1,215 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+	"github.com/dtn7/dtn7-go/pkg/cla"
+)
+
+// PolicyStage describes when a PolicyRule is evaluated.
+type PolicyStage int
+
+const (
+	// PolicyAnyStage evaluates a PolicyRule both on reception and before forwarding.
+	PolicyAnyStage PolicyStage = iota
+
+	// PolicyReception evaluates a PolicyRule only for received bundles.
+	PolicyReception
+
+	// PolicyForwarding evaluates a PolicyRule only for each CLA a bundle is about to be forwarded to.
+	PolicyForwarding
+)
+
+// PolicyAction is the consequence of a matching PolicyRule.
+type PolicyAction int
+
+const (
+	// PolicyAccept accepts a bundle without evaluating further rules.
+	PolicyAccept PolicyAction = iota
+
+	// PolicyReject deletes a received bundle or skips a CLA while forwarding.
+	PolicyReject
+
+	// PolicyReprioritize sets the PolicyRule's Priority for a bundle and accepts it.
+	PolicyReprioritize
+
+	// PolicyLimit accepts at most the PolicyRule's Limit of bundles per second and rejects exceeding ones.
+	PolicyLimit
+)
+
+// ParsePolicyAction from its string representation, as returned by String.
+func ParsePolicyAction(s string) (PolicyAction, error) {
+	for _, action := range []PolicyAction{PolicyAccept, PolicyReject, PolicyReprioritize, PolicyLimit} {
+		if action.String() == s {
+			return action, nil
+		}
+	}
+	return PolicyAccept, fmt.Errorf("unknown policy action %q, expected accept, reject, reprioritize, or limit", s)
+}
+
+func (action PolicyAction) String() string {
+	switch action {
+	case PolicyAccept:
+		return "accept"
+	case PolicyReject:
+		return "reject"
+	case PolicyReprioritize:
+		return "reprioritize"
+	case PolicyLimit:
+		return "limit"
+	default:
+		return "unknown"
+	}
+}
+
+// PolicyRule matches bundles and applies its Action. Empty or zero match fields match every bundle.
+type PolicyRule struct {
+	Stage PolicyStage
+
+	// Source and Destination are prefixes of the bundle's source and destination endpoint IDs.
+	Source      string
+	Destination string
+	// CLA is a prefix of the receiving or sending CLA's address.
+	CLA string
+
+	// MinSize and MaxSize limit the bundle's serialized size in bytes.
+	MinSize uint64
+	MaxSize uint64
+	// MinLifetime and MaxLifetime limit the bundle's lifetime.
+	MinLifetime time.Duration
+	MaxLifetime time.Duration
+
+	Action PolicyAction
+	// Priority is the new priority for PolicyReprioritize.
+	Priority bpv7.Priority
+	// Limit is the number of bundles per second for PolicyLimit.
+	Limit uint
+}
+
+// matches checks if this PolicyRule applies to a bundle at some stage, received or to be sent by a CLA.
+func (rule PolicyRule) matches(stage PolicyStage, bndl *bpv7.Bundle, conv cla.Convergence) bool {
+	if rule.Stage != PolicyAnyStage && rule.Stage != stage {
+		return false
+	}
+
+	if !strings.HasPrefix(bndl.PrimaryBlock.SourceNode.String(), rule.Source) ||
+		!strings.HasPrefix(bndl.PrimaryBlock.Destination.String(), rule.Destination) {
+		return false
+	}
+
+	if rule.CLA != "" && (conv == nil || !strings.HasPrefix(conv.Address(), rule.CLA)) {
+		return false
+	}
+
+	lifetime := time.Duration(bndl.PrimaryBlock.Lifetime) * time.Millisecond
+	if lifetime < rule.MinLifetime || (rule.MaxLifetime > 0 && lifetime > rule.MaxLifetime) {
+		return false
+	}
+
+	if rule.MinSize > 0 || rule.MaxSize > 0 {
+		size, err := bndl.SerializedSize()
+		if err != nil || size < rule.MinSize || (rule.MaxSize > 0 && size > rule.MaxSize) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// policyEngine evaluates PolicyRules in their order; the first matching rule decides.
+type policyEngine struct {
+	rules    []PolicyRule
+	limiters []rateLimiter
+}
+
+// newPolicyEngine for a list of PolicyRules.
+func newPolicyEngine(rules []PolicyRule) *policyEngine {
+	return &policyEngine{
+		rules:    rules,
+		limiters: make([]rateLimiter, len(rules)),
+	}
+}
+
+// evaluate the PolicyRules for a bundle. Accepted bundles might get a new priority by PolicyReprioritize.
+func (pe *policyEngine) evaluate(stage PolicyStage, bp *BundleDescriptor, conv cla.Convergence) (accept bool) {
+	for i, rule := range pe.rules {
+		if !rule.matches(stage, bp.MustBundle(), conv) {
+			continue
+		}
+
+		switch rule.Action {
+		case PolicyReject:
+			return false
+		case PolicyReprioritize:
+			bp.Priority = rule.Priority
+		case PolicyLimit:
+			return pe.limiters[i].allow(rule.Limit)
+		}
+		return true
+	}
+
+	return true
+}
+
+// SetPolicyRules to be evaluated for received bundles and before forwarding. An empty list disables the policy.
+// Unchanged rules keep their rate limits' state.
+func (c *Core) SetPolicyRules(rules []PolicyRule) {
+	c.updateSettings(func(s *coreSettings) {
+		if len(rules) == 0 {
+			s.policy = nil
+		} else if s.policy == nil || !reflect.DeepEqual(s.policy.rules, rules) {
+			s.policy = newPolicyEngine(rules)
+		}
+	})
+}
+
+// admitReceived evaluates the PolicyRules for a received bundle. A rejected bundle is deleted.
+func (c *Core) admitReceived(bp *BundleDescriptor, conv cla.Convergence) bool {
+	policy := c.loadSettings().policy
+	if policy == nil {
+		return true
+	}
+
+	if !policy.evaluate(PolicyReception, bp, conv) {
+		log.WithField("bundle", bp.ID().String()).Info("Received bundle was rejected by policy")
+
+		c.bundleDeletion(*bp, bpv7.TrafficPared)
+		return false
+	}
+
+	_ = bp.Sync()
+	return true
+}
+
+// admitForwarding returns those ConvergenceSenders the PolicyRules allow to send the bundle to.
+func (c *Core) admitForwarding(bp *BundleDescriptor, nodes []cla.ConvergenceSender) []cla.ConvergenceSender {
+	policy := c.loadSettings().policy
+	if policy == nil {
+		return nodes
+	}
+
+	var allowed []cla.ConvergenceSender
+	for _, node := range nodes {
+		if policy.evaluate(PolicyForwarding, bp, node) {
+			allowed = append(allowed, node)
+		} else {
+			log.WithFields(log.Fields{
+				"bundle": bp.ID().String(),
+				"cla":    node,
+			}).Debug("Forwarding bundle to CLA was rejected by policy")
+		}
+	}
+
+	_ = bp.Sync()
+	return allowed
+}
@@ -0,0 +1,110 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+	"github.com/dtn7/dtn7-go/pkg/cla"
+)
+
+// policyTestConvergence is a cla.Convergence, only identified by its address.
+type policyTestConvergence string
+
+func (ptc policyTestConvergence) Close() error                        { return nil }
+func (ptc policyTestConvergence) Start() (error, bool)                { return nil, false }
+func (ptc policyTestConvergence) Channel() chan cla.ConvergenceStatus { return nil }
+func (ptc policyTestConvergence) Address() string                     { return string(ptc) }
+func (ptc policyTestConvergence) IsPermanent() bool                   { return false }
+
+func TestPolicyEngine(t *testing.T) {
+	bndl, err := bpv7.Builder().
+		Source("dtn://telemetry/sensor").
+		Destination("dtn://ground/").
+		CreationTimestampNow().
+		Lifetime("1h").
+		PayloadBlock(make([]byte, 1024)).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pe := newPolicyEngine([]PolicyRule{
+		{Stage: PolicyForwarding, CLA: "mtcp://", Action: PolicyReject},
+		{Source: "dtn://telemetry/", MaxSize: 512, Action: PolicyReject},
+		{Source: "dtn://telemetry/", MaxLifetime: 2 * time.Hour, Action: PolicyReprioritize, Priority: bpv7.PriorityBulk},
+		{Action: PolicyReject},
+	})
+
+	bp := BundleDescriptor{Id: bndl.ID(), Priority: bpv7.PriorityNormal, bndl: &bndl}
+	if !pe.evaluate(PolicyReception, &bp, policyTestConvergence("mtcp://10.0.0.1:35037")) {
+		t.Fatal("bundle was rejected on reception")
+	} else if bp.Priority != bpv7.PriorityBulk {
+		t.Fatalf("bundle was not reprioritized, got %v", bp.Priority)
+	}
+
+	if pe.evaluate(PolicyForwarding, &bp, policyTestConvergence("mtcp://10.0.0.1:35037")) {
+		t.Fatal("bundle was accepted for a rejected CLA")
+	}
+	if !pe.evaluate(PolicyForwarding, &bp, policyTestConvergence("10.0.0.2:4556")) {
+		t.Fatal("bundle was rejected for an allowed CLA")
+	}
+
+	limited := newPolicyEngine([]PolicyRule{{Destination: "dtn://ground/", Action: PolicyLimit, Limit: 2}})
+	for i, expected := range []bool{true, true, false} {
+		if accept := limited.evaluate(PolicyReception, &bp, nil); accept != expected {
+			t.Fatalf("limited bundle %d: expected %t, got %t", i, expected, accept)
+		}
+	}
+}
+
+func TestCorePolicyRejectionReport(t *testing.T) {
+	c := newTestCore(t, "dtn://relay/")
+	defer c.Close()
+
+	c.SetPolicyRules([]PolicyRule{{Stage: PolicyReception, Source: "dtn://telemetry/", Action: PolicyReject}})
+
+	bndl, err := bpv7.Builder().
+		BundleCtrlFlags(bpv7.StatusRequestReception | bpv7.StatusRequestDeletion).
+		Source("dtn://telemetry/sensor").
+		Destination("dtn://ground/").
+		ReportTo("dtn://ground/reports").
+		CreationTimestampNow().
+		Lifetime("1h").
+		PayloadBlock([]byte("hello world")).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.receive(NewBundleDescriptorFromBundle(bndl, c.Store), policyTestConvergence("mtcp://10.0.0.2:35037"))
+
+	bis, err := c.Store.QueryAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var reported []bpv7.StatusInformationPos
+	for _, bi := range bis {
+		b, err := bi.Load()
+		if err != nil {
+			t.Fatal(err)
+		} else if !b.IsAdministrativeRecord() {
+			continue
+		}
+
+		ar, err := b.AdministrativeRecord()
+		if err != nil {
+			t.Fatal(err)
+		}
+		reported = append(reported, ar.(*bpv7.StatusReport).StatusInformations()...)
+	}
+
+	if len(reported) != 1 || reported[0] != bpv7.DeletedBundle {
+		t.Fatalf("rejected bundle resulted in the status reports %v, expected only a deletion", reported)
+	}
+}
@@ -0,0 +1,129 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"sort"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+// PriorityPolicy configures the priority of bundles without a bpv7.PriorityBlock and the store's capacity.
+type PriorityPolicy struct {
+	// Classes maps endpoints to a priority. A bundle is classified by its destination's node and, if unmatched, by its
+	// source's node. A bundle's own bpv7.PriorityBlock takes precedence.
+	Classes map[bpv7.EndpointID]bpv7.Priority
+
+	// StoreLimit is the maximum number of pending bundles. If exceeded, the pending bundle of the lowest priority and,
+	// within this priority, the oldest one is deleted. Zero disables this limit.
+	StoreLimit uint
+}
+
+// bundlePriority returns a bundle's priority based on its bpv7.PriorityBlock or the PriorityPolicy's classes.
+func (c *Core) bundlePriority(bndl *bpv7.Bundle) bpv7.Priority {
+	if bndl.HasExtensionBlock(bpv7.ExtBlockTypePriorityBlock) {
+		return bndl.Priority()
+	}
+
+	for _, eid := range []bpv7.EndpointID{bndl.PrimaryBlock.Destination, bndl.PrimaryBlock.SourceNode} {
+		for classEid, priority := range c.loadSettings().priority.Classes {
+			if eid.SameNode(classEid) {
+				return priority
+			}
+		}
+	}
+
+	return bpv7.PriorityNormal
+}
+
+// sortByPriority sorts BundleDescriptors by descending priority and, within the same priority, by their ascending
+// reception timestamp.
+func sortByPriority(bps []BundleDescriptor) {
+	sort.SliceStable(bps, func(i, j int) bool {
+		if bps[i].Priority != bps[j].Priority {
+			return bps[i].Priority > bps[j].Priority
+		}
+		return bps[i].Timestamp.Before(bps[j].Timestamp)
+	})
+}
+
+// evictionCandidate returns the index of the BundleDescriptor to be deleted first under storage pressure, or -1 if
+// none is evictable. Bundles in custody or awaiting local delivery are never evicted.
+func evictionCandidate(bps []BundleDescriptor) int {
+	victim := -1
+	for i, bp := range bps {
+		if bp.HasConstraint(CustodyAccepted) || bp.HasConstraint(LocalEndpoint) {
+			continue
+		}
+
+		if victim == -1 || bp.Priority < bps[victim].Priority ||
+			(bp.Priority == bps[victim].Priority && bp.Timestamp.Before(bps[victim].Timestamp)) {
+			victim = i
+		}
+	}
+	return victim
+}
+
+// pendingBundles returns all pending BundleDescriptors, sorted by sortByPriority.
+func (c *Core) pendingBundles() ([]BundleDescriptor, error) {
+	bis, err := c.Store.QueryPending()
+	if err != nil {
+		return nil, err
+	}
+
+	bps := make([]BundleDescriptor, 0, len(bis))
+	for _, bi := range bis {
+		bps = append(bps, NewBundleDescriptor(bi.BId, c.Store))
+	}
+	sortByPriority(bps)
+
+	return bps, nil
+}
+
+// enforceStoreLimit deletes the least important pending bundles while the PriorityPolicy's StoreLimit is exceeded by
+// the newly stored BundleDescriptor. False is returned if the new bundle itself was deleted.
+func (c *Core) enforceStoreLimit(bp BundleDescriptor) bool {
+	limit := c.loadSettings().priority.StoreLimit
+	if limit == 0 {
+		return true
+	}
+
+	bis, err := c.Store.QueryPending()
+	if err != nil {
+		log.WithError(err).Warn("Failed to fetch pending bundles for the store limit")
+		return true
+	}
+
+	// The new bundle might not be pending yet, but competes for the store as well.
+	bps := []BundleDescriptor{bp}
+	for _, bi := range bis {
+		if bi.BId != bp.Id.Scrub() {
+			bps = append(bps, NewBundleDescriptor(bi.BId, c.Store))
+		}
+	}
+
+	for uint(len(bps)) > limit {
+		victim := evictionCandidate(bps)
+		if victim == -1 {
+			break
+		}
+
+		log.WithFields(log.Fields{
+			"bundle":   bps[victim].ID().String(),
+			"priority": bps[victim].Priority,
+		}).Info("Store limit exceeded, deleting bundle")
+
+		c.events.publish(Event{Type: StoreEvicted, Bundle: bps[victim].ID()})
+		c.bundleDeletion(bps[victim], bpv7.DepletedStorage)
+		if victim == 0 {
+			return false
+		}
+		bps = append(bps[:victim], bps[victim+1:]...)
+	}
+
+	return true
+}
@@ -0,0 +1,63 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+func TestPriorityOrder(t *testing.T) {
+	now := time.Now()
+	descriptor := func(priority bpv7.Priority, age time.Duration, constraints ...Constraint) BundleDescriptor {
+		bp := BundleDescriptor{
+			Priority:    priority,
+			Timestamp:   now.Add(-age),
+			Constraints: make(map[Constraint]bool),
+		}
+		for _, c := range constraints {
+			bp.AddConstraint(c)
+		}
+		return bp
+	}
+
+	bps := []BundleDescriptor{
+		descriptor(bpv7.PriorityNormal, time.Minute),
+		descriptor(bpv7.PriorityBulk, time.Minute),
+		descriptor(bpv7.PriorityExpedited, time.Second),
+		descriptor(bpv7.PriorityBulk, time.Hour, CustodyAccepted),
+		descriptor(bpv7.PriorityExpedited, time.Minute),
+		descriptor(bpv7.PriorityNormal, time.Hour),
+	}
+
+	if victim := evictionCandidate(bps); victim != 1 {
+		t.Fatalf("expected the bulk bundle not in custody as eviction candidate, got %d", victim)
+	}
+
+	sortByPriority(bps)
+
+	expected := []struct {
+		priority bpv7.Priority
+		age      time.Duration
+	}{
+		{bpv7.PriorityExpedited, time.Minute},
+		{bpv7.PriorityExpedited, time.Second},
+		{bpv7.PriorityNormal, time.Hour},
+		{bpv7.PriorityNormal, time.Minute},
+		{bpv7.PriorityBulk, time.Hour},
+		{bpv7.PriorityBulk, time.Minute},
+	}
+	for i, e := range expected {
+		if bps[i].Priority != e.priority || !bps[i].Timestamp.Equal(now.Add(-e.age)) {
+			t.Fatalf("unexpected order at %d: %v, %v", i, bps[i].Priority, now.Sub(bps[i].Timestamp))
+		}
+	}
+
+	if victim := evictionCandidate([]BundleDescriptor{descriptor(bpv7.PriorityBulk, 0, LocalEndpoint)}); victim != -1 {
+		t.Fatalf("expected no eviction candidate, got %d", victim)
+	}
+}
@@ -6,7 +6,8 @@
 package routing
 
 import (
-	"sync"
+	"fmt"
+	"sync/atomic"
 
 	log "github.com/sirupsen/logrus"
 
@@ -16,50 +17,222 @@ import (
 
 // SendBundle transmits an outbounding bundle.
 func (c *Core) SendBundle(bndl *bpv7.Bundle) {
-	if c.signPriv != nil && bndl.IsAdministrativeRecord() {
+	if c.isShuttingDown() {
+		log.WithField("bundle", bndl.ID().String()).Warn("Rejecting outgoing bundle while shutting down")
+		return
+	}
+
+	atomic.AddInt32(&c.processing, 1)
+	defer atomic.AddInt32(&c.processing, -1)
+
+	s := c.loadSettings()
+	if s.clockless {
+		c.sendBundleClockless(bndl)
+	}
+	if s.reportTo != (bpv7.EndpointID{}) && !bndl.IsAdministrativeRecord() {
+		c.sendBundleReportTo(bndl, s.reportTo)
+	}
+	c.sendBundleScopeBroadcast(bndl)
+	if s.hopLimit > 0 && !bndl.HasExtensionBlock(bpv7.ExtBlockTypeHopCountBlock) {
+		c.sendBundleAttachHopCount(bndl, s.hopLimit)
+	}
+	if s.transitLog > 0 && !bndl.IsAdministrativeRecord() && !bndl.HasExtensionBlock(bpv7.ExtBlockTypeTransitLogBlock) {
+		c.sendBundleAttachTransitLog(bndl)
+	}
+	if s.crcPolicy.Enforce {
+		bndl.SetCRCTypes(s.crcPolicy.Primary, s.crcPolicy.Canonical)
+	}
+	if !c.checkStrict(bndl, "Outgoing") {
+		return
+	}
+	if !c.admitSupersession(bndl) {
+		return
+	}
+	// A clockless node's sequence numbers are already assigned, unique across Cleans and restarts. Otherwise, the
+	// sequence number must be assigned before sharding, as the shards reference the bundle's ID.
+	if !s.clockless {
+		c.IdKeeper.update(bndl)
+	}
+	if c.shardBundle(bndl) {
+		return
+	}
+	// The signature covers the sequence number, which is only now assigned.
+	if c.signPriv != nil {
 		c.sendBundleAttachSignature(bndl)
 	}
 	bp := NewBundleDescriptorFromBundle(*bndl, c.Store)
+	c.trackAck(bndl)
+
+	// Remember own bundles to drop them when being received back from other nodes.
+	if s.knownBundles != nil {
+		s.knownBundles.checkAndAdd(bp.ID())
+	}
 
 	c.routing.NotifyNewBundle(bp)
 	c.transmit(bp)
 }
 
-// sendBundleAttachSignature attaches a SignatureBlock to outgoing Administrative Records, if configured.
-func (c *Core) sendBundleAttachSignature(bndl *bpv7.Bundle) {
-	if c.signPriv == nil || !bndl.IsAdministrativeRecord() {
+// checkStrict validates a Bundle according to the ValidationMode. False is returned if the Bundle must be rejected.
+func (c *Core) checkStrict(bndl *bpv7.Bundle, direction string) bool {
+	s := c.loadSettings()
+	if s.validation == ValidationOff {
+		return true
+	}
+
+	err := bndl.CheckStrictClockTolerance(s.clockSkew.tolerance() + c.sourceClockOffset(bndl))
+	if err == nil {
+		return true
+	}
+
+	log.WithFields(log.Fields{
+		"bundle":     bndl.ID().String(),
+		"validation": s.validation,
+		"error":      err,
+	}).Warnf("%s bundle violates the strict validation", direction)
+
+	return s.validation != ValidationReject
+}
+
+// withoutPeer returns the ConvergenceSenders except those connected to the given peer node.
+func withoutPeer(nodes []cla.ConvergenceSender, peer bpv7.EndpointID) []cla.ConvergenceSender {
+	var filtered []cla.ConvergenceSender
+	for _, node := range nodes {
+		if !node.GetPeerEndpointID().SameNode(peer) {
+			filtered = append(filtered, node)
+		}
+	}
+	return filtered
+}
+
+// sendBundleReportTo replaces an outgoing bundle's report-to endpoint by the configured default, if it is dtn:none
+// or the bundle's source, as set by the bpv7.BundleBuilder if no report-to endpoint was given.
+func (c *Core) sendBundleReportTo(bndl *bpv7.Bundle, reportTo bpv7.EndpointID) {
+	pb := &bndl.PrimaryBlock
+	if pb.ReportTo != bpv7.DtnNone() && pb.ReportTo != pb.SourceNode {
 		return
 	}
 
-	sb, sbErr := bpv7.NewSignatureBlock(*bndl, c.signPriv)
-	if sbErr != nil {
-		log.WithField("bundle", bndl.ID()).WithError(sbErr).Error("Creating signature erred, proceeding without")
+	log.WithFields(log.Fields{
+		"bundle":    bndl.ID().String(),
+		"report-to": reportTo,
+	}).Debug("Setting the default report-to endpoint for an outgoing bundle")
+
+	pb.ReportTo = reportTo
+}
+
+// sendBundleClockless sets a zero creation time with a new sequence number for outgoing bundles and attaches a
+// BundleAgeBlock, as required for bundles of nodes without an accurate clock. Sequence numbers of bundles already in
+// the store, e.g., from before a restart, are skipped.
+func (c *Core) sendBundleClockless(bndl *bpv7.Bundle) {
+	c.clocklessMutex.Lock()
+	for {
+		bndl.PrimaryBlock.CreationTimestamp = bpv7.NewCreationTimestamp(bpv7.DtnTimeEpoch, c.clocklessSeq)
+		c.clocklessSeq++
+
+		if !c.Store.KnowsBundle(bndl.ID().Scrub()) {
+			break
+		}
+	}
+	c.clocklessMutex.Unlock()
+
+	if bndl.HasExtensionBlock(bpv7.ExtBlockTypeBundleAgeBlock) {
 		return
 	}
 
-	cb := bpv7.NewCanonicalBlock(0, bpv7.ReplicateBlock|bpv7.DeleteBundle, sb)
+	cb := bpv7.NewCanonicalBlock(0, bpv7.ReplicateBlock, bpv7.NewBundleAgeBlock(0))
 	cb.SetCRCType(bpv7.CRC32)
 
 	if err := bndl.AddExtensionBlock(cb); err != nil {
 		log.WithFields(log.Fields{
 			"bundle": bndl.ID().String(),
 			"error":  err,
-		}).Error("Error attaching signature block")
+		}).Error("Error attaching bundle age block")
+		return
+	}
+
+	log.WithField("bundle", bndl.ID().String()).Debug("Attached bundle age block to outgoing bundle")
+}
+
+// sendBundleAttachHopCount attaches a HopCountBlock with the configured hop limit to outgoing bundles.
+func (c *Core) sendBundleAttachHopCount(bndl *bpv7.Bundle, hopLimit uint8) {
+	cb := bpv7.NewCanonicalBlock(0, bpv7.ReplicateBlock, bpv7.NewHopCountBlock(hopLimit))
+	cb.SetCRCType(bpv7.CRC32)
+
+	if err := bndl.AddExtensionBlock(cb); err != nil {
+		log.WithFields(log.Fields{
+			"bundle": bndl.ID().String(),
+			"error":  err,
+		}).Error("Error attaching hop count block")
+		return
+	}
+
+	log.WithFields(log.Fields{
+		"bundle":    bndl.ID().String(),
+		"hop_limit": hopLimit,
+	}).Debug("Attached hop count block to outgoing bundle")
+}
+
+// sendBundleAttachSignature attaches a SignatureBlock to locally created bundles, if configured.
+func (c *Core) sendBundleAttachSignature(bndl *bpv7.Bundle) {
+	if c.signPriv == nil {
+		return
+	}
+
+	if err := bndl.Sign(c.signPriv); err != nil {
+		log.WithField("bundle", bndl.ID()).WithError(err).Error("Creating signature erred, proceeding without")
+		return
 	}
 
 	log.WithField("bundle", bndl.ID()).Info("Attached signature to outgoing bundle")
 }
 
+// checkSignature verifies a received bundle's SignatureBlock against the SignaturePolicy. Bundles from sources without
+// a trusted key pass, unless signatures are required. Fragments cannot be verified and only pass if neither signatures
+// are required nor their source has a trusted key.
+func (c *Core) checkSignature(bp BundleDescriptor) bool {
+	bndl := bp.MustBundle()
+	src := bndl.PrimaryBlock.SourceNode
+	policy := c.loadSettings().signatures
+	_, trusted := policy.Keys.Lookup(src)
+
+	var err error
+	switch {
+	case !trusted && !policy.Require:
+		return true
+	case bndl.PrimaryBlock.BundleControlFlags.Has(bpv7.IsFragment):
+		err = fmt.Errorf("fragments cannot be verified")
+	default:
+		err = bndl.VerifySignature(policy.Keys)
+	}
+
+	if err != nil {
+		log.WithFields(log.Fields{
+			"bundle": bp.ID().String(),
+			"source": src,
+			"error":  err,
+		}).Info("Received bundle violates the signature policy")
+		return false
+	}
+	return true
+}
+
 // transmit starts the transmission of an outgoing bundle pack.
 // Therefore, the source's endpoint ID must be dtn:none or a member of this node.
 func (c *Core) transmit(bp BundleDescriptor) {
-	c.IdKeeper.update(&bp)
-
 	log.WithField("bundle", bp.ID().String()).Info("Transmission of bundle requested")
 
 	bp.AddConstraint(DispatchPending)
+	bp.Priority = c.bundlePriority(bp.MustBundle())
 	_ = bp.Sync()
 
+	if !c.enforceStoreLimit(bp) {
+		return
+	}
+
+	if c.loadSettings().custody.Accept {
+		c.acceptCustody(bp)
+	}
+
 	src := bp.MustBundle().PrimaryBlock.SourceNode
 	if src != bpv7.DtnNone() && !c.HasEndpoint(src) {
 		log.WithFields(log.Fields{
@@ -74,13 +247,21 @@ func (c *Core) transmit(bp BundleDescriptor) {
 	c.dispatching(bp)
 }
 
-// receive handles received/incoming bundles.
-func (c *Core) receive(bp BundleDescriptor) {
+// receive handles received/incoming bundles from a CLA.
+func (c *Core) receive(bp BundleDescriptor, conv cla.Convergence) {
+	atomic.AddInt32(&c.processing, 1)
+	defer atomic.AddInt32(&c.processing, -1)
+
 	log.WithField("bundle", bp.ID().String()).Debug("Received new bundle")
 
 	if len(bp.Constraints) > 0 {
 		log.WithField("bundle", bp.ID().String()).Debug("Received bundle's ID is already known.")
 
+		// A retransmission of a bundle in custody indicates a lost custody signal.
+		if bp.HasConstraint(CustodyAccepted) {
+			c.signalCustodian(bp, true, bpv7.NoInformation)
+		}
+
 		// bundleDeletion is _not_ called because this would delete the already
 		// stored BundleDescriptor.
 		return
@@ -88,13 +269,48 @@ func (c *Core) receive(bp BundleDescriptor) {
 
 	log.WithField("bundle", bp.ID().String()).Info("Processing newly received bundle")
 
+	c.events.publish(Event{Type: BundleReceived, Bundle: bp.ID()})
+
+	c.observePeerClock(bp)
+
 	bp.AddConstraint(DispatchPending)
+	bp.Priority = c.bundlePriority(bp.MustBundle())
 	_ = bp.Sync()
+	c.correctExpiration(bp)
+
+	if !c.admitReceived(&bp, conv) {
+		return
+	}
+
+	if !c.enforceStoreLimit(bp) {
+		return
+	}
+
+	if c.loadSettings().crcPolicy.RequireCRC {
+		if err := bp.MustBundle().CheckCRCPresence(); err != nil {
+			log.WithFields(log.Fields{
+				"bundle": bp.ID().String(),
+				"error":  err,
+			}).Info("Received bundle violates the CRC policy")
 
-	if bp.MustBundle().PrimaryBlock.BundleControlFlags.Has(bpv7.StatusRequestReception) {
-		c.SendStatusReport(bp, bpv7.ReceivedBundle, bpv7.NoInformation)
+			c.bundleDeletion(bp, bpv7.BlockUnintelligible)
+			return
+		}
 	}
 
+	if !c.checkStrict(bp.MustBundle(), "Received") {
+		c.bundleDeletion(bp, bpv7.BlockUnintelligible)
+		return
+	}
+
+	if !c.checkSignature(bp) {
+		c.bundleDeletion(bp, bpv7.BlockUnintelligible)
+		return
+	}
+
+	// Reception is only reported for admitted bundles; rejected ones result in a deletion report instead.
+	c.SendStatusReport(bp, bpv7.ReceivedBundle, bpv7.NoInformation)
+
 	for i := len(bp.MustBundle().CanonicalBlocks) - 1; i >= 0; i-- {
 		var cb = &bp.MustBundle().CanonicalBlocks[i]
 
@@ -115,7 +331,7 @@ func (c *Core) receive(bp BundleDescriptor) {
 				"type":   cb.TypeCode(),
 			}).Info("Bundle's unknown canonical block requested reporting")
 
-			c.SendStatusReport(bp, bpv7.ReceivedBundle, bpv7.BlockUnsupported)
+			c.sendStatusReport(bp, bpv7.ReceivedBundle, bpv7.BlockUnsupported)
 		}
 
 		if cb.BlockControlFlags.Has(bpv7.DeleteBundle) {
@@ -141,6 +357,10 @@ func (c *Core) receive(bp BundleDescriptor) {
 		}
 	}
 
+	if c.loadSettings().custody.Accept {
+		c.acceptCustody(bp)
+	}
+
 	c.routing.NotifyNewBundle(bp)
 
 	c.dispatching(bp)
@@ -148,15 +368,10 @@ func (c *Core) receive(bp BundleDescriptor) {
 
 // dispatching handles the dispatching of received bundles.
 func (c *Core) dispatching(bp BundleDescriptor) {
-	log.WithField("bundle", bp.ID().String()).Info("Dispatching bundle")
+	atomic.AddInt32(&c.processing, 1)
+	defer atomic.AddInt32(&c.processing, -1)
 
-	if !c.routing.DispatchingAllowed(bp) {
-		log.WithFields(log.Fields{
-			"bundle":  bp.ID().String(),
-			"routing": c.routing,
-		}).Info("Routing Algorithm has not allowed dispatching of bundle")
-		return
-	}
+	log.WithField("bundle", bp.ID().String()).Info("Dispatching bundle")
 
 	bndl, err := bp.Bundle()
 	if err != nil {
@@ -167,7 +382,23 @@ func (c *Core) dispatching(bp BundleDescriptor) {
 		return
 	}
 
-	if c.HasEndpoint(bndl.PrimaryBlock.Destination) {
+	// Content is cached and interests are answered even without any peer to forward them to.
+	if c.answerInterest(bp, bndl) {
+		return
+	}
+	c.cacheContent(bndl)
+
+	if !c.routing.DispatchingAllowed(bp) {
+		log.WithFields(log.Fields{
+			"bundle":  bp.ID().String(),
+			"routing": c.routing,
+		}).Info("Routing Algorithm has not allowed dispatching of bundle")
+		return
+	}
+
+	if c.isGroupMember(bndl.PrimaryBlock.Destination) {
+		c.groupDelivery(bp)
+	} else if c.HasEndpoint(bndl.PrimaryBlock.Destination) {
 		c.localDelivery(bp)
 	} else {
 		c.forward(bp)
@@ -203,7 +434,7 @@ func (c *Core) forward(bp BundleDescriptor) {
 		}
 	}
 
-	if bp.MustBundle().IsLifetimeExceeded() {
+	if c.isLifetimeExceeded(bp.MustBundle()) {
 		log.WithFields(log.Fields{
 			"bundle":        bp.ID().String(),
 			"primary_block": bp.MustBundle().PrimaryBlock,
@@ -213,6 +444,13 @@ func (c *Core) forward(bp BundleDescriptor) {
 		return
 	}
 
+	// The Bundle Age Block is only updated for transmission and reset afterwards, as the bundle's reception
+	// timestamp remains the base of its age.
+	var receivedAge uint64
+	if ageBlock, err := bp.MustBundle().ExtensionBlock(bpv7.ExtBlockTypeBundleAgeBlock); err == nil {
+		receivedAge = ageBlock.Value.(*bpv7.BundleAgeBlock).Age()
+	}
+
 	if age, err := bp.UpdateBundleAge(); err == nil {
 		if age >= bp.MustBundle().PrimaryBlock.Lifetime {
 			log.WithField("bunde", bp.ID().String()).Warn("Bundle lifetime expired")
@@ -222,6 +460,22 @@ func (c *Core) forward(bp BundleDescriptor) {
 		}
 	}
 
+	var nodes []cla.ConvergenceSender
+	var deleteAfterwards = true
+
+	// Try a direct delivery or consult the Algorithm otherwise.
+	nodes = c.senderForDestination(bp.MustBundle().PrimaryBlock.Destination)
+	if nodes == nil {
+		nodes, deleteAfterwards = c.routing.SenderForBundle(bp)
+
+		// Never send a bundle straight back to the node it was received from.
+		if prevNode, ok := bp.PreviousNode(); ok {
+			nodes = withoutPeer(nodes, prevNode)
+		}
+
+		nodes = spreadShard(bp.MustBundle(), nodes)
+	}
+
 	if pnBlock, err := bp.MustBundle().ExtensionBlock(bpv7.ExtBlockTypePreviousNodeBlock); err == nil {
 		// Replace the PreviousNodeBlock
 		prevEid := pnBlock.Value.(*bpv7.PreviousNodeBlock).Endpoint()
@@ -234,8 +488,12 @@ func (c *Core) forward(bp BundleDescriptor) {
 		}).Debug("Previous Node Block updated")
 	} else {
 		// Append a new PreviousNodeBlock
-		if err := bp.MustBundle().AddExtensionBlock(bpv7.NewCanonicalBlock(
-			0, 0, bpv7.NewPreviousNodeBlock(c.NodeId))); err != nil {
+		pnBlock := bpv7.NewCanonicalBlock(0, 0, bpv7.NewPreviousNodeBlock(c.NodeId))
+		if crcPolicy := c.loadSettings().crcPolicy; crcPolicy.Enforce {
+			pnBlock.SetCRCType(crcPolicy.Canonical)
+		}
+
+		if err := bp.MustBundle().AddExtensionBlock(pnBlock); err != nil {
 			log.WithFields(log.Fields{
 				"bundle": bp.ID(),
 				"error":  err,
@@ -243,51 +501,27 @@ func (c *Core) forward(bp BundleDescriptor) {
 		}
 	}
 
-	var nodes []cla.ConvergenceSender
-	var deleteAfterwards = true
-
-	// Try a direct delivery or consult the Algorithm otherwise.
-	nodes = c.senderForDestination(bp.MustBundle().PrimaryBlock.Destination)
-	if nodes == nil {
-		nodes, deleteAfterwards = c.routing.SenderForBundle(bp)
+	if bp.HasConstraint(CustodyAccepted) {
+		if ctBlock, err := bp.MustBundle().ExtensionBlock(bpv7.ExtBlockTypeCustodyTransferBlock); err == nil {
+			ctBlock.Value = bpv7.NewCustodyTransferBlock(c.NodeId)
+		}
 	}
 
-	var bundleSent = false
+	resetTransitLog := c.recordTransit(bp)
 
-	var wg sync.WaitGroup
-	var once sync.Once
-
-	wg.Add(len(nodes))
+	// CLAs rejected by the policy, peers exceeding their traffic budget, and deferred bulk bundles are skipped; the
+	// bundle remains contraindicated and will be retried.
+	nodes = c.admitForwarding(&bp, nodes)
+	nodes = c.shapeTraffic(bp, nodes)
+	nodes = c.deferBulkBundle(bp, nodes)
 
+	routed := Event{Type: BundleRouted, Bundle: bp.ID()}
 	for _, node := range nodes {
-		go func(node cla.ConvergenceSender) {
-			log.WithFields(log.Fields{
-				"bundle": bp.ID().String(),
-				"cla":    node,
-			}).Info("Sending bundle to a CLA (ConvergenceSender)")
-
-			if err := node.Send(*bp.MustBundle()); err != nil {
-				log.WithFields(log.Fields{
-					"bundle": bp.ID().String(),
-					"cla":    node,
-					"error":  err,
-				}).Warn("Sending bundle failed")
-
-				c.routing.ReportFailure(bp, node)
-			} else {
-				log.WithFields(log.Fields{
-					"bundle": bp.ID().String(),
-					"cla":    node,
-				}).Printf("Sending bundle succeeded")
-
-				once.Do(func() { bundleSent = true })
-			}
-
-			wg.Done()
-		}(node)
+		routed.Peers = append(routed.Peers, node.GetPeerEndpointID())
 	}
+	c.events.publish(routed)
 
-	wg.Wait()
+	result := c.dispatch(bp, nodes)
 
 	if hcBlock, err := bp.MustBundle().ExtensionBlock(bpv7.ExtBlockTypeHopCountBlock); err == nil {
 		hc := hcBlock.Value.(*bpv7.HopCountBlock)
@@ -300,12 +534,21 @@ func (c *Core) forward(bp BundleDescriptor) {
 		}).Debug("Reset bundle hop count")
 	}
 
-	if bundleSent {
-		if bp.MustBundle().PrimaryBlock.BundleControlFlags.Has(bpv7.StatusRequestForward) {
-			c.SendStatusReport(bp, bpv7.ForwardedBundle, bpv7.NoInformation)
-		}
+	if ageBlock, err := bp.MustBundle().ExtensionBlock(bpv7.ExtBlockTypeBundleAgeBlock); err == nil {
+		ageBlock.Value = bpv7.NewBundleAgeBlock(receivedAge)
+	}
 
-		if deleteAfterwards {
+	resetTransitLog()
+
+	if result.sent {
+		c.SendStatusReport(bp, bpv7.ForwardedBundle, bpv7.NoInformation)
+		c.events.publish(Event{Type: BundleForwarded, Bundle: bp.ID()})
+
+		bp.Retries = 0
+
+		if bp.HasConstraint(CustodyAccepted) {
+			c.custodyForwarded(bp)
+		} else if deleteAfterwards {
 			bp.PurgeConstraints()
 			_ = bp.Sync()
 		} else if c.InspectAllBundles && bp.MustBundle().IsAdministrativeRecord() {
@@ -314,32 +557,98 @@ func (c *Core) forward(bp BundleDescriptor) {
 		} else {
 			c.bundleContraindicated(bp)
 		}
+	} else if result.interruptedAck > 0 && c.reactiveFragment(bp, result.interruptedAck) {
+		log.WithField("bundle", bp.ID().String()).Info("Failed to forward bundle completely, kept remaining fragment")
 	} else {
 		log.WithField("bundle", bp.ID().String()).Info("Failed to forward bundle to any CLA")
 		c.bundleContraindicated(bp)
+
+		// Only failed senders result in a retry; without any sender, the next contact triggers CheckPendingBundles.
+		if len(nodes) > 0 {
+			c.scheduleRetry(bp)
+		}
 	}
 }
 
-// checkAdministrativeRecord checks administrative records. If this method
-// returns false, an error occured.
-func (c *Core) checkAdministrativeRecord(bp BundleDescriptor) bool {
-	if !bp.MustBundle().IsAdministrativeRecord() {
-		log.WithField("bundle", bp.ID().String()).Debug("Bundle does not contain an administrative record")
+// reactiveFragment replaces a bundle, whose transfer was interrupted after the given number of acknowledged bytes, by
+// a fragment of its remaining payload. Thus, the transmitted part is not sent again, as the peer keeps it as a
+// fragment itself. The returned boolean indicates if the bundle was replaced.
+func (c *Core) reactiveFragment(bp BundleDescriptor, acknowledged int) bool {
+	frag, err := bp.MustBundle().ReactiveFragment(acknowledged)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"bundle":       bp.ID().String(),
+			"acknowledged": acknowledged,
+		}).WithError(err).Debug("Reactive fragmentation of interrupted transfer is not possible")
 		return false
 	}
 
-	canonicalAr, err := bp.MustBundle().PayloadBlock()
+	fragBp := NewBundleDescriptorFromBundle(frag, c.Store)
+	c.routing.NotifyNewBundle(fragBp)
+	c.bundleContraindicated(fragBp)
+
+	bp.PurgeConstraints()
+	_ = bp.Sync()
+
+	log.WithFields(log.Fields{
+		"bundle":       bp.ID().String(),
+		"fragment":     fragBp.ID().String(),
+		"acknowledged": acknowledged,
+	}).Info("Interrupted bundle was replaced by a fragment of its remaining payload")
+	return true
+}
+
+// sendFragmented sends a Bundle to a ConvergenceSender. If the Bundle exceeds the smaller of the Core's and the CLA's
+// MTU, it is proactively fragmented and each fragment is sent.
+func (c *Core) sendFragmented(node cla.ConvergenceSender, bndl bpv7.Bundle) error {
+	mtu := c.loadSettings().fragmentMtu
+	if convMtu, ok := node.(cla.ConvergenceMtu); ok {
+		if nodeMtu := convMtu.Mtu(); nodeMtu > 0 && (mtu == 0 || nodeMtu < mtu) {
+			mtu = nodeMtu
+		}
+	}
+
+	if mtu == 0 || bndl.PrimaryBlock.BundleControlFlags.Has(bpv7.MustNotFragmented) {
+		return node.Send(bndl)
+	}
+
+	// Fragmenting reads the whole payload, which can be skipped for Bundles fitting into the MTU.
+	if size, sizeErr := bndl.SerializedSize(); sizeErr == nil && size <= uint64(mtu) {
+		return node.Send(bndl)
+	}
+
+	frags, err := bndl.Fragment(mtu)
 	if err != nil {
-		log.WithFields(log.Fields{
-			"bundle": bp.ID().String(),
-			"error":  err,
-		}).Warn("Bundle with an administrative record flag missing payload block")
+		return fmt.Errorf("fragmenting bundle for MTU %d erred: %v", mtu, err)
+	} else if len(frags) <= 1 {
+		return node.Send(bndl)
+	}
 
+	log.WithFields(log.Fields{
+		"bundle":    bndl.ID().String(),
+		"cla":       node,
+		"mtu":       mtu,
+		"fragments": len(frags),
+	}).Info("Bundle exceeds MTU and was proactively fragmented")
+
+	for i, frag := range frags {
+		// An interrupted transfer's acknowledged bytes refer to the fragment, not to the whole bundle.
+		if err := node.Send(frag); err != nil {
+			return fmt.Errorf("sending fragment %d erred: %v", i, err)
+		}
+	}
+	return nil
+}
+
+// checkAdministrativeRecord checks administrative records. If this method
+// returns false, an error occured.
+func (c *Core) checkAdministrativeRecord(bp BundleDescriptor) bool {
+	if !bp.MustBundle().IsAdministrativeRecord() {
+		log.WithField("bundle", bp.ID().String()).Debug("Bundle does not contain an administrative record")
 		return false
 	}
 
-	payload := canonicalAr.Value.(*bpv7.PayloadBlock).Data()
-	ar, err := bpv7.NewAdministrativeRecordFromCbor(payload)
+	ar, err := bp.MustBundle().AdministrativeRecord()
 	if err != nil {
 		log.WithFields(log.Fields{
 			"bundle": bp.ID().String(),
@@ -354,9 +663,13 @@ func (c *Core) checkAdministrativeRecord(bp BundleDescriptor) bool {
 		"admin_rec": ar,
 	}).Info("Received bundle with administrative record")
 
-	// Currently there are only status reports. This must be changed if more
-	// types of administrative records are introduced.
-	c.inspectStatusReport(bp, ar)
+	switch ar.RecordTypeCode() {
+	case bpv7.AdminRecordTypeCustodySignal:
+		c.inspectCustodySignal(bp, ar)
+
+	default:
+		c.inspectStatusReport(bp, ar)
+	}
 
 	return true
 }
@@ -438,6 +751,11 @@ func (c *Core) localDelivery(bp BundleDescriptor) {
 
 	log.WithField("bundle", bp.ID().String()).Info("Received bundle for local delivery")
 
+	if bp.MustBundle().HasExtensionBlock(bpv7.ExtBlockTypeShardBlock) {
+		c.collectShard(bp)
+		return
+	}
+
 	if bp.MustBundle().IsAdministrativeRecord() {
 		if !c.checkAdministrativeRecord(bp) {
 			c.bundleDeletion(bp, bpv7.NoInformation)
@@ -448,12 +766,23 @@ func (c *Core) localDelivery(bp BundleDescriptor) {
 	bp.AddConstraint(LocalEndpoint)
 	_ = bp.Sync()
 
+	c.receiveAck(bp)
+
 	if err := c.agentManager.Deliver(bp); err != nil {
+		// The bundle is retried from the store, e.g., after its application (re)registered its endpoint.
 		log.WithField("bundle", bp.ID().String()).WithError(err).Warn("Delivering local bundle erred")
+		c.bundleContraindicated(bp)
+		return
 	}
 
-	if bp.MustBundle().PrimaryBlock.BundleControlFlags.Has(bpv7.StatusRequestDelivery) {
-		c.SendStatusReport(bp, bpv7.DeliveredBundle, bpv7.NoInformation)
+	c.events.publish(Event{Type: BundleDelivered, Bundle: bp.ID()})
+	c.sendAck(bp)
+
+	c.SendStatusReport(bp, bpv7.DeliveredBundle, bpv7.NoInformation)
+
+	// Delivery ends the custody transfer, even if this node does not accept custody itself.
+	if !bp.HasConstraint(CustodyAccepted) {
+		c.signalCustodian(bp, true, bpv7.NoInformation)
 	}
 
 	bp.PurgeConstraints()
@@ -468,8 +797,12 @@ func (c *Core) bundleContraindicated(bp BundleDescriptor) {
 }
 
 func (c *Core) bundleDeletion(bp BundleDescriptor, reason bpv7.StatusReportReason) {
-	if bp.MustBundle().PrimaryBlock.BundleControlFlags.Has(bpv7.StatusRequestDeletion) {
-		c.SendStatusReport(bp, bpv7.DeletedBundle, reason)
+	c.events.publish(Event{Type: BundleDeleted, Bundle: bp.ID(), Reason: reason})
+
+	c.SendStatusReport(bp, bpv7.DeletedBundle, reason)
+
+	if !bp.HasConstraint(CustodyAccepted) {
+		c.signalCustodian(bp, false, reason)
 	}
 
 	bp.PurgeConstraints()
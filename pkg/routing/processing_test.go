@@ -0,0 +1,217 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+	"github.com/dtn7/dtn7-go/pkg/cla"
+)
+
+// storedBundle loads a bundle from the Core's store, as it would be forwarded.
+func storedBundle(t *testing.T, c *Core, bid bpv7.BundleID) bpv7.Bundle {
+	bi, err := c.Store.QueryId(bid.Scrub())
+	if err != nil {
+		t.Fatal(err)
+	}
+	bndl, err := bi.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return bndl
+}
+
+func TestCoreSendBundleHopCount(t *testing.T) {
+	tests := []struct {
+		name          string
+		hopCountBlock bool
+		expectedLimit uint8
+	}{
+		{"inserted", false, 8},
+		{"existing", true, 64},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := newTestCore(t, "dtn://a/")
+			defer c.Close()
+			c.SetHopLimit(8)
+
+			bldr := bpv7.Builder().
+				Source("dtn://a/app").
+				Destination("dtn://b/app").
+				CreationTimestampNow().
+				Lifetime("10m")
+			if test.hopCountBlock {
+				bldr = bldr.HopCountBlock(64)
+			}
+			bndl, err := bldr.PayloadBlock([]byte("hello world")).Build()
+			if err != nil {
+				t.Fatal(err)
+			}
+			c.SendBundle(&bndl)
+
+			stored := storedBundle(t, c, bndl.ID())
+			cb, err := stored.ExtensionBlock(bpv7.ExtBlockTypeHopCountBlock)
+			if err != nil {
+				t.Fatalf("bundle has no hop count block: %v", err)
+			}
+			if hc := cb.Value.(*bpv7.HopCountBlock); hc.Limit != test.expectedLimit || hc.Count != 0 {
+				t.Fatalf("expected hop limit %d, got %v", test.expectedLimit, hc)
+			}
+		})
+	}
+}
+
+func TestCoreForwardHopLimitExceeded(t *testing.T) {
+	c := newTestCore(t, "dtn://a/")
+	defer c.Close()
+
+	bndl, err := bpv7.Builder().
+		Source("dtn://b/app").
+		Destination("dtn://c/app").
+		CreationTimestampNow().
+		Lifetime("10m").
+		HopCountBlock(2).
+		PayloadBlock([]byte("hello world")).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The bundle already passed its two hops, being deleted instead of the third one.
+	cb, err := bndl.ExtensionBlock(bpv7.ExtBlockTypeHopCountBlock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cb.Value.(*bpv7.HopCountBlock).Count = 2
+
+	var deleted []Event
+	c.Subscribe(func(e Event) { deleted = append(deleted, e) }, BundleDeleted)
+
+	c.forward(NewBundleDescriptorFromBundle(bndl, c.Store))
+
+	if len(deleted) != 1 || deleted[0].Bundle != bndl.ID() || deleted[0].Reason != bpv7.HopLimitExceeded {
+		t.Fatalf("expected the deletion of %v as its hop limit was exceeded, got %v", bndl.ID(), deleted)
+	}
+	if bis, err := c.Store.QueryPending(); err != nil {
+		t.Fatal(err)
+	} else if len(bis) != 0 {
+		t.Fatalf("deleted bundle is still pending: %v", bis)
+	}
+}
+
+func TestWithoutPeer(t *testing.T) {
+	send := func(bpv7.Bundle) error { return nil }
+	nodes := []cla.ConvergenceSender{
+		&dispatchSender{bpv7.MustNewEndpointID("dtn://b/"), send},
+		&dispatchSender{bpv7.MustNewEndpointID("dtn://c/"), send},
+		&dispatchSender{bpv7.MustNewEndpointID("dtn://b/other"), send},
+	}
+
+	tests := []struct {
+		peer     string
+		expected []string
+	}{
+		{"dtn://b/", []string{"dtn://c/"}},
+		{"dtn://b/app", []string{"dtn://c/"}},
+		{"dtn://c/", []string{"dtn://b/", "dtn://b/other"}},
+		{"dtn://d/", []string{"dtn://b/", "dtn://c/", "dtn://b/other"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.peer, func(t *testing.T) {
+			filtered := withoutPeer(nodes, bpv7.MustNewEndpointID(test.peer))
+			if len(filtered) != len(test.expected) {
+				t.Fatalf("expected %v, got %v", test.expected, filtered)
+			}
+			for i, node := range filtered {
+				if peer := node.GetPeerEndpointID().String(); peer != test.expected[i] {
+					t.Fatalf("expected %v, got %s at %d", test.expected, peer, i)
+				}
+			}
+		})
+	}
+}
+
+func TestCoreForwardPreviousNode(t *testing.T) {
+	c := newTestCore(t, "dtn://a/")
+	defer c.Close()
+
+	send := func(bpv7.Bundle) error { return nil }
+	c.claManager.Register(&dispatchSender{bpv7.MustNewEndpointID("dtn://b/"), send})
+	c.claManager.Register(&dispatchSender{bpv7.MustNewEndpointID("dtn://c/"), send})
+	for deadline := time.Now().Add(5 * time.Second); len(c.claManager.Sender()) != 2; {
+		if time.Now().After(deadline) {
+			t.Fatal("senders were not registered")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	bndl, err := bpv7.Builder().
+		Source("dtn://z/app").
+		Destination("dtn://y/app").
+		CreationTimestampNow().
+		Lifetime("10m").
+		PreviousNodeBlock("dtn://b/").
+		PayloadBlock([]byte("hello world")).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var routed []Event
+	c.Subscribe(func(e Event) { routed = append(routed, e) }, BundleRouted)
+
+	c.forward(NewBundleDescriptorFromBundle(bndl, c.Store))
+
+	if len(routed) != 1 {
+		t.Fatalf("expected one routing decision, got %v", routed)
+	}
+	if peers := routed[0].Peers; len(peers) != 1 || peers[0] != bpv7.MustNewEndpointID("dtn://c/") {
+		t.Fatalf("bundle received from dtn://b/ was routed to %v instead of dtn://c/ only", peers)
+	}
+}
+
+func TestCoreSendBundleReportTo(t *testing.T) {
+	tests := []struct {
+		name     string
+		reportTo string
+		expected string
+	}{
+		{"none", "dtn:none", "dtn://monitor/"},
+		{"source", "", "dtn://monitor/"},
+		{"explicit", "dtn://other/", "dtn://other/"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := newTestCore(t, "dtn://a/")
+			defer c.Close()
+			c.SetReportTo(bpv7.MustNewEndpointID("dtn://monitor/"))
+
+			bldr := bpv7.Builder().
+				Source("dtn://a/app").
+				Destination("dtn://b/app").
+				CreationTimestampNow().
+				Lifetime("10m")
+			if test.reportTo != "" {
+				bldr = bldr.ReportTo(test.reportTo)
+			}
+			bndl, err := bldr.PayloadBlock([]byte("hello world")).Build()
+			if err != nil {
+				t.Fatal(err)
+			}
+			c.SendBundle(&bndl)
+
+			stored := storedBundle(t, c, bndl.ID())
+			if reportTo := stored.PrimaryBlock.ReportTo; reportTo != bpv7.MustNewEndpointID(test.expected) {
+				t.Fatalf("expected report-to %s, got %v", test.expected, reportTo)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+// RetryPolicy configures the retransmission of bundles after all senders failed. Each retry doubles the delay,
+// starting from Initial and limited by Max, until the bundle's lifetime expires.
+type RetryPolicy struct {
+	// Initial delay before the first retry. Zero disables retries, leaving bundles for CheckPendingBundles.
+	Initial time.Duration
+	// Max delay between two retries. Zero does not limit the delay.
+	Max time.Duration
+}
+
+// delay before the retry following the given number of previous retries.
+func (policy RetryPolicy) delay(retries uint) time.Duration {
+	delay := policy.Initial
+	for i := uint(0); i < retries; i++ {
+		if (policy.Max > 0 && delay >= policy.Max) || delay > math.MaxInt64/2 {
+			break
+		}
+		delay *= 2
+	}
+
+	if policy.Max > 0 && delay > policy.Max {
+		delay = policy.Max
+	}
+	return delay
+}
+
+// retryQueue holds the timers of bundles scheduled for a retry.
+type retryQueue struct {
+	mutex  sync.Mutex
+	timers map[bpv7.BundleID]*time.Timer
+}
+
+// newRetryQueue creates an empty retryQueue.
+func newRetryQueue() *retryQueue {
+	return &retryQueue{timers: make(map[bpv7.BundleID]*time.Timer)}
+}
+
+// schedule f for a bundle after some delay, if this bundle is not already scheduled.
+func (rq *retryQueue) schedule(bid bpv7.BundleID, delay time.Duration, f func()) bool {
+	rq.mutex.Lock()
+	defer rq.mutex.Unlock()
+
+	if _, ok := rq.timers[bid]; ok {
+		return false
+	}
+
+	rq.timers[bid] = time.AfterFunc(delay, func() {
+		rq.mutex.Lock()
+		delete(rq.timers, bid)
+		rq.mutex.Unlock()
+
+		f()
+	})
+	return true
+}
+
+// stop all scheduled retries.
+func (rq *retryQueue) stop() {
+	rq.mutex.Lock()
+	defer rq.mutex.Unlock()
+
+	for bid, timer := range rq.timers {
+		timer.Stop()
+		delete(rq.timers, bid)
+	}
+}
+
+// scheduleRetry of a bundle whose forwarding failed for all senders, based on the RetryPolicy.
+func (c *Core) scheduleRetry(bp BundleDescriptor) {
+	policy := c.loadSettings().retry
+	if policy.Initial <= 0 {
+		return
+	}
+
+	delay := policy.delay(bp.Retries)
+
+	if bi, err := c.Store.QueryId(bp.Id.Scrub()); err != nil {
+		return
+	} else if time.Now().Add(delay).After(bi.Expires) {
+		log.WithField("bundle", bp.ID().String()).Debug("Bundle expires before its next retry")
+		return
+	}
+
+	bp.Retries++
+	_ = bp.Sync()
+
+	bid := bp.Id
+	if c.retryQueue.schedule(bid, delay, func() { c.retryBundle(bid) }) {
+		log.WithFields(log.Fields{
+			"bundle":  bid.String(),
+			"retries": bp.Retries,
+			"delay":   delay,
+		}).Info("Scheduled retry of failed bundle")
+	}
+}
+
+// retryBundle dispatches a bundle again, unless it was already forwarded or deleted in the meantime.
+func (c *Core) retryBundle(bid bpv7.BundleID) {
+	if c.isShuttingDown() || !c.Store.KnowsBundle(bid.Scrub()) {
+		return
+	}
+
+	bp := NewBundleDescriptor(bid, c.Store)
+	if !bp.HasConstraint(Contraindicated) {
+		return
+	}
+
+	log.WithFields(log.Fields{
+		"bundle":  bid.String(),
+		"retries": bp.Retries,
+	}).Info("Retrying failed bundle")
+
+	c.dispatching(bp)
+}
@@ -0,0 +1,61 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+func TestRetryPolicyDelay(t *testing.T) {
+	tests := []struct {
+		policy   RetryPolicy
+		retries  uint
+		expected time.Duration
+	}{
+		{RetryPolicy{Initial: time.Second}, 0, time.Second},
+		{RetryPolicy{Initial: time.Second}, 3, 8 * time.Second},
+		{RetryPolicy{Initial: time.Second, Max: time.Minute}, 5, 32 * time.Second},
+		{RetryPolicy{Initial: time.Second, Max: time.Minute}, 6, time.Minute},
+		{RetryPolicy{Initial: time.Second, Max: time.Minute}, 1000, time.Minute},
+		{RetryPolicy{Initial: time.Second}, 1000, time.Second << 33},
+	}
+
+	for _, test := range tests {
+		if delay := test.policy.delay(test.retries); delay != test.expected {
+			t.Fatalf("%v after %d retries: expected %v, got %v", test.policy, test.retries, test.expected, delay)
+		}
+	}
+}
+
+func TestRetryQueue(t *testing.T) {
+	rq := newRetryQueue()
+	bid := bpv7.BundleID{SourceNode: bpv7.MustNewEndpointID("dtn://src/")}
+
+	fired := make(chan struct{}, 2)
+	if !rq.schedule(bid, 10*time.Millisecond, func() { fired <- struct{}{} }) {
+		t.Fatal("scheduling failed")
+	}
+	if rq.schedule(bid, 10*time.Millisecond, func() { fired <- struct{}{} }) {
+		t.Fatal("bundle was scheduled twice")
+	}
+
+	select {
+	case <-fired:
+	case <-time.After(time.Second):
+		t.Fatal("retry was not fired")
+	}
+
+	if !rq.schedule(bid, time.Hour, func() { fired <- struct{}{} }) {
+		t.Fatal("rescheduling after firing failed")
+	}
+	rq.stop()
+
+	if len(rq.timers) != 0 {
+		t.Fatalf("stopped retry queue has %d timers", len(rq.timers))
+	}
+}
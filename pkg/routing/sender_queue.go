@@ -0,0 +1,181 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+	"github.com/dtn7/dtn7-go/pkg/cla"
+)
+
+// senderQueueSize is the number of bundles queued for each ConvergenceSender before further dispatches block.
+const senderQueueSize = 32
+
+// sendJob is a queued transmission, whose result is reported back on done.
+type sendJob struct {
+	bndl bpv7.Bundle
+	done chan error
+}
+
+// senderQueue transmits bundles to a single ConvergenceSender, one after another.
+type senderQueue struct {
+	node cla.ConvergenceSender
+	send func(cla.ConvergenceSender, bpv7.Bundle) error
+
+	// mutex protects the closed flag against submissions racing with close.
+	mutex  sync.RWMutex
+	closed bool
+
+	jobs chan sendJob
+
+	stopSyn chan struct{}
+	stopAck chan struct{}
+}
+
+func newSenderQueue(node cla.ConvergenceSender, send func(cla.ConvergenceSender, bpv7.Bundle) error) *senderQueue {
+	sq := &senderQueue{
+		node:    node,
+		send:    send,
+		jobs:    make(chan sendJob, senderQueueSize),
+		stopSyn: make(chan struct{}),
+		stopAck: make(chan struct{}),
+	}
+
+	go sq.handler()
+
+	return sq
+}
+
+// submit a bundle, blocking while the queue is full. The transmission's result will be sent on the returned channel.
+func (sq *senderQueue) submit(bndl bpv7.Bundle) <-chan error {
+	job := sendJob{bndl: bndl, done: make(chan error, 1)}
+
+	sq.mutex.RLock()
+	defer sq.mutex.RUnlock()
+
+	if sq.closed {
+		job.done <- fmt.Errorf("sender queue of %v is closed", sq.node)
+	} else {
+		sq.jobs <- job
+	}
+	return job.done
+}
+
+// handler transmits the queued bundles until the senderQueue is closed. Afterwards, all remaining jobs fail.
+func (sq *senderQueue) handler() {
+	defer close(sq.stopAck)
+
+	for {
+		// A closed senderQueue takes precedence over its remaining jobs.
+		select {
+		case <-sq.stopSyn:
+			sq.drain()
+			return
+		default:
+		}
+
+		select {
+		case job := <-sq.jobs:
+			job.done <- sq.send(sq.node, job.bndl)
+
+		case <-sq.stopSyn:
+			sq.drain()
+			return
+		}
+	}
+}
+
+// drain the remaining jobs of a closed senderQueue by failing them.
+func (sq *senderQueue) drain() {
+	for {
+		select {
+		case job := <-sq.jobs:
+			job.done <- fmt.Errorf("sender queue of %v was closed", sq.node)
+		default:
+			return
+		}
+	}
+}
+
+// close the senderQueue after its current transmission. Further submissions fail.
+func (sq *senderQueue) close() {
+	sq.mutex.Lock()
+	if sq.closed {
+		sq.mutex.Unlock()
+		return
+	}
+	sq.closed = true
+	sq.mutex.Unlock()
+
+	close(sq.stopSyn)
+	<-sq.stopAck
+}
+
+// senderQueues holds a senderQueue for each ConvergenceSender, identified by its address. Thus, a slow link limits
+// the bundles queued for it and blocks their dispatchers, instead of piling up goroutines waiting to transmit.
+type senderQueues struct {
+	send func(cla.ConvergenceSender, bpv7.Bundle) error
+
+	mutex  sync.Mutex
+	queues map[string]*senderQueue
+	closed bool
+}
+
+func newSenderQueues(send func(cla.ConvergenceSender, bpv7.Bundle) error) *senderQueues {
+	return &senderQueues{
+		send:   send,
+		queues: make(map[string]*senderQueue),
+	}
+}
+
+// submit a bundle for a ConvergenceSender, creating its senderQueue if necessary. This blocks while the queue is full.
+func (sqs *senderQueues) submit(node cla.ConvergenceSender, bndl bpv7.Bundle) <-chan error {
+	sqs.mutex.Lock()
+	if sqs.closed {
+		sqs.mutex.Unlock()
+
+		done := make(chan error, 1)
+		done <- fmt.Errorf("sender queues are closed")
+		return done
+	}
+
+	sq, ok := sqs.queues[node.Address()]
+	if !ok {
+		sq = newSenderQueue(node, sqs.send)
+		sqs.queues[node.Address()] = sq
+	}
+	sqs.mutex.Unlock()
+
+	return sq.submit(bndl)
+}
+
+// remove the senderQueue of a ConvergenceSender, e.g., after its peer disappeared. Its queued bundles fail, while a
+// new senderQueue will be created for the next submission.
+func (sqs *senderQueues) remove(node cla.Convergence) {
+	sqs.mutex.Lock()
+	sq, ok := sqs.queues[node.Address()]
+	delete(sqs.queues, node.Address())
+	sqs.mutex.Unlock()
+
+	if ok {
+		// Closing waits for a current transmission, which must not block the caller.
+		go sq.close()
+	}
+}
+
+// close all senderQueues. Further submissions fail.
+func (sqs *senderQueues) close() {
+	sqs.mutex.Lock()
+	queues := sqs.queues
+	sqs.queues = make(map[string]*senderQueue)
+	sqs.closed = true
+	sqs.mutex.Unlock()
+
+	for _, sq := range queues {
+		sq.close()
+	}
+}
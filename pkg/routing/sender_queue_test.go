@@ -0,0 +1,115 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+	"github.com/dtn7/dtn7-go/pkg/cla"
+)
+
+// blockingSend returns a send function for senderQueues, blocking until release is closed and recording the
+// sequence numbers of the transmitted bundles.
+func blockingSend(release chan struct{}, sent *[]uint64, mutex *sync.Mutex) func(cla.ConvergenceSender, bpv7.Bundle) error {
+	return func(_ cla.ConvergenceSender, b bpv7.Bundle) error {
+		<-release
+
+		mutex.Lock()
+		*sent = append(*sent, b.PrimaryBlock.CreationTimestamp.SequenceNumber())
+		mutex.Unlock()
+		return nil
+	}
+}
+
+func seqBundle(seq uint64) (b bpv7.Bundle) {
+	b.PrimaryBlock.CreationTimestamp = bpv7.NewCreationTimestamp(bpv7.DtnTimeEpoch, seq)
+	return
+}
+
+func TestSenderQueuesBackpressure(t *testing.T) {
+	release := make(chan struct{})
+	var sent []uint64
+	var mutex sync.Mutex
+
+	sqs := newSenderQueues(blockingSend(release, &sent, &mutex))
+	defer sqs.close()
+
+	node := &dispatchSender{peer: bpv7.MustNewEndpointID("dtn://b/")}
+
+	// One bundle is being transmitted, while the others fill the queue.
+	var dones []<-chan error
+	for i := 0; i <= senderQueueSize; i++ {
+		dones = append(dones, sqs.submit(node, seqBundle(uint64(i))))
+		if i == 0 {
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	blocked := make(chan (<-chan error))
+	go func() { blocked <- sqs.submit(node, seqBundle(senderQueueSize+1)) }()
+
+	select {
+	case <-blocked:
+		t.Fatal("submission to a full queue did not block")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	dones = append(dones, <-blocked)
+
+	for i, done := range dones {
+		if err := <-done; err != nil {
+			t.Fatalf("bundle %d failed: %v", i, err)
+		}
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	for i, seq := range sent {
+		if seq != uint64(i) {
+			t.Fatalf("bundle %d was sent as %d", seq, i)
+		}
+	}
+}
+
+func TestSenderQueuesRemove(t *testing.T) {
+	release := make(chan struct{})
+	var sent []uint64
+	var mutex sync.Mutex
+
+	sqs := newSenderQueues(blockingSend(release, &sent, &mutex))
+	defer sqs.close()
+
+	node := &dispatchSender{peer: bpv7.MustNewEndpointID("dtn://b/")}
+
+	first := sqs.submit(node, seqBundle(0))
+	time.Sleep(10 * time.Millisecond)
+	second := sqs.submit(node, seqBundle(1))
+
+	// Removing waits for the current transmission, but fails the queued ones.
+	sqs.remove(node)
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+
+	if err := <-first; err != nil {
+		t.Fatalf("current transmission failed: %v", err)
+	}
+	if err := <-second; err == nil {
+		t.Fatal("queued transmission of a removed queue succeeded")
+	}
+
+	// A new queue is created for the next submission.
+	if err := <-sqs.submit(node, seqBundle(2)); err != nil {
+		t.Fatal(err)
+	}
+
+	sqs.close()
+	if err := <-sqs.submit(node, seqBundle(3)); err == nil {
+		t.Fatal("submission to closed queues succeeded")
+	}
+}
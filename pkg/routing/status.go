@@ -0,0 +1,232 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+	"github.com/dtn7/dtn7-go/pkg/cla"
+)
+
+// RoutingTableDumper might be implemented by an Algorithm to list its routing table in the Core's status.
+type RoutingTableDumper interface {
+	// RoutingTable returns a human-readable value, e.g., the next hop, for each entry of this Algorithm's table.
+	RoutingTable() map[string]string
+}
+
+// StatusPeer is a connected peer within a Status.
+type StatusPeer struct {
+	Endpoint bpv7.EndpointID `json:"endpoint"`
+	Address  string          `json:"address"`
+	// ActiveTransfers is only present if the CLA implements cla.ConvergenceActivity.
+	ActiveTransfers *int `json:"active_transfers,omitempty"`
+}
+
+// StatusDiscoveredPeer is a peer announced by the peer discovery within a Status.
+type StatusDiscoveredPeer struct {
+	Endpoint bpv7.EndpointID `json:"endpoint"`
+	Type     string          `json:"type"`
+	Address  string          `json:"address"`
+	LastSeen time.Time       `json:"last_seen"`
+}
+
+// StatusNeighbor is a neighbor of the NeighborTable within a Status.
+type StatusNeighbor struct {
+	Endpoint bpv7.EndpointID `json:"endpoint"`
+	CLAs     []string        `json:"clas"`
+	Quality  float64         `json:"quality"`
+	LastSeen time.Time       `json:"last_seen"`
+}
+
+// StatusStore describes the store within a Status. Values which could not be queried are nil.
+type StatusStore struct {
+	Bundles   *int    `json:"bundles,omitempty"`
+	Pending   *int    `json:"pending,omitempty"`
+	Size      *uint64 `json:"size,omitempty"`
+	FreeSpace *uint64 `json:"free_space,omitempty"`
+}
+
+// Status is a snapshot of a Core's peers, neighbors, store, activity, counters, and routing table. Its JSON
+// representation is a structured status document, e.g., for network management systems.
+type Status struct {
+	NodeId bpv7.EndpointID `json:"node_id"`
+	Time   time.Time       `json:"time"`
+	// Uptime of the Core in seconds.
+	Uptime  uint64 `json:"uptime"`
+	Routing string `json:"routing"`
+
+	Peers           []StatusPeer           `json:"peers"`
+	DiscoveredPeers []StatusDiscoveredPeer `json:"discovered_peers"`
+	Neighbors       []StatusNeighbor       `json:"neighbors"`
+	Store           StatusStore            `json:"store"`
+
+	ActiveTransfers int   `json:"active_transfers"`
+	Processing      int32 `json:"processing"`
+
+	// Received, Forwarded, Delivered, and Deleted count bundles since the Core's start, see Metrics.
+	Received  uint64            `json:"received"`
+	Forwarded uint64            `json:"forwarded"`
+	Delivered uint64            `json:"delivered"`
+	Deleted   map[string]uint64 `json:"deleted"`
+
+	BytesSent     map[string]uint64 `json:"bytes_sent"`
+	BytesReceived map[string]uint64 `json:"bytes_received"`
+
+	// RoutingTable is only present if the Algorithm implements RoutingTableDumper.
+	RoutingTable map[string]string `json:"routing_table,omitempty"`
+}
+
+// Status returns a snapshot of this Core's state.
+func (c *Core) Status() Status {
+	now := time.Now()
+	s := Status{
+		NodeId:  c.NodeId,
+		Time:    now,
+		Uptime:  uint64(now.Sub(c.started).Seconds()),
+		Routing: fmt.Sprintf("%v", c.routing),
+
+		Peers:           []StatusPeer{},
+		DiscoveredPeers: []StatusDiscoveredPeer{},
+		Neighbors:       []StatusNeighbor{},
+
+		ActiveTransfers: c.claManager.ActiveTransfers(),
+		Processing:      atomic.LoadInt32(&c.processing),
+	}
+
+	for _, cs := range c.claManager.Sender() {
+		peer := StatusPeer{Endpoint: cs.GetPeerEndpointID(), Address: cs.Address()}
+		if ca, ok := cs.(cla.ConvergenceActivity); ok {
+			n := ca.ActiveTransfers()
+			peer.ActiveTransfers = &n
+		}
+		s.Peers = append(s.Peers, peer)
+	}
+
+	for _, peer := range c.DiscoveredPeers() {
+		s.DiscoveredPeers = append(s.DiscoveredPeers, StatusDiscoveredPeer{
+			Endpoint: peer.Endpoint,
+			Type:     peer.Type.String(),
+			Address:  peer.Address,
+			LastSeen: peer.LastSeen,
+		})
+	}
+
+	for _, n := range c.Neighbors().Neighbors() {
+		s.Neighbors = append(s.Neighbors, StatusNeighbor{
+			Endpoint: n.Endpoint,
+			CLAs:     n.CLAs,
+			Quality:  n.Quality(),
+			LastSeen: n.LastSeen,
+		})
+	}
+
+	if n, err := c.Store.Count(); err == nil {
+		s.Store.Bundles = &n
+	}
+	if bis, err := c.Store.QueryPending(); err == nil {
+		n := len(bis)
+		s.Store.Pending = &n
+	}
+	if size, err := c.Store.Size(); err == nil {
+		s.Store.Size = &size
+	}
+	if free, err := c.Store.FreeSpace(); err == nil {
+		s.Store.FreeSpace = &free
+	}
+
+	m := c.Metrics()
+	s.Received, s.Forwarded, s.Delivered = m.Received, m.Forwarded, m.Delivered
+	s.Deleted = make(map[string]uint64, len(m.Deleted))
+	for reason, n := range m.Deleted {
+		s.Deleted[reason.String()] = n
+	}
+	s.BytesSent, s.BytesReceived = m.BytesSent, m.BytesReceived
+
+	if dumper, ok := c.routing.(RoutingTableDumper); ok {
+		s.RoutingTable = dumper.RoutingTable()
+	}
+
+	return s
+}
+
+// WriteStatus writes a human-readable snapshot of this Core's peers, neighbors, store, active transfers, counters, and
+// routing table, e.g., for a quick diagnosis of headless nodes.
+func (c *Core) WriteStatus(w io.Writer) error {
+	var b strings.Builder
+	s := c.Status()
+
+	_, _ = fmt.Fprintf(&b, "Status of %v at %s\n", s.NodeId, s.Time.Format(time.RFC3339))
+	_, _ = fmt.Fprintf(&b, "Routing: %s\n", s.Routing)
+
+	_, _ = fmt.Fprintf(&b, "\nConnected peers (%d):\n", len(s.Peers))
+	for _, peer := range s.Peers {
+		_, _ = fmt.Fprintf(&b, "  %v via %s", peer.Endpoint, peer.Address)
+		if peer.ActiveTransfers != nil {
+			_, _ = fmt.Fprintf(&b, ", %d active transfers", *peer.ActiveTransfers)
+		}
+		b.WriteString("\n")
+	}
+
+	_, _ = fmt.Fprintf(&b, "\nDiscovered peers (%d):\n", len(s.DiscoveredPeers))
+	for _, peer := range s.DiscoveredPeers {
+		_, _ = fmt.Fprintf(&b, "  %v via %s at %s, last seen %s\n",
+			peer.Endpoint, peer.Type, peer.Address, peer.LastSeen.Format(time.RFC3339))
+	}
+
+	_, _ = fmt.Fprintf(&b, "\nNeighbors (%d):\n", len(s.Neighbors))
+	for _, n := range s.Neighbors {
+		_, _ = fmt.Fprintf(&b, "  %v, %d CLAs, quality %.2f, last seen %s\n",
+			n.Endpoint, len(n.CLAs), n.Quality, n.LastSeen.Format(time.RFC3339))
+	}
+
+	b.WriteString("\nStore:\n")
+	if s.Store.Bundles != nil {
+		_, _ = fmt.Fprintf(&b, "  bundles: %d\n", *s.Store.Bundles)
+	}
+	if s.Store.Pending != nil {
+		_, _ = fmt.Fprintf(&b, "  pending: %d\n", *s.Store.Pending)
+	}
+	if s.Store.Size != nil {
+		_, _ = fmt.Fprintf(&b, "  size: %d bytes\n", *s.Store.Size)
+	}
+	if s.Store.FreeSpace != nil {
+		_, _ = fmt.Fprintf(&b, "  free space: %d bytes\n", *s.Store.FreeSpace)
+	}
+
+	b.WriteString("\nActivity:\n")
+	_, _ = fmt.Fprintf(&b, "  active transfers: %d\n", s.ActiveTransfers)
+	_, _ = fmt.Fprintf(&b, "  processing bundles: %d\n", s.Processing)
+	_, _ = fmt.Fprintf(&b, "  received: %d, forwarded: %d, delivered: %d\n", s.Received, s.Forwarded, s.Delivered)
+	reasons := make([]string, 0, len(s.Deleted))
+	for reason := range s.Deleted {
+		reasons = append(reasons, reason)
+	}
+	sort.Strings(reasons)
+	for _, reason := range reasons {
+		_, _ = fmt.Fprintf(&b, "  deleted, %s: %d\n", reason, s.Deleted[reason])
+	}
+
+	if s.RoutingTable != nil {
+		keys := make([]string, 0, len(s.RoutingTable))
+		for key := range s.RoutingTable {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		_, _ = fmt.Fprintf(&b, "\nRouting table (%d):\n", len(s.RoutingTable))
+		for _, key := range keys {
+			_, _ = fmt.Fprintf(&b, "  %s: %s\n", key, s.RoutingTable[key])
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
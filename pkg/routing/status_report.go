@@ -0,0 +1,66 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"sync"
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+// StatusReportPolicy configures the generation of status reports. Independent of this policy, status reports are
+// only generated if requested by a Bundle's control flags or a block's StatusReportBlock flag.
+type StatusReportPolicy struct {
+	// Disabled status information, e.g., bpv7.ForwardedBundle, are never reported.
+	Disabled map[bpv7.StatusInformationPos]bool
+	// RateLimit is the maximum number of status reports generated per second. Exceeding ones are dropped.
+	// Zero disables this limit.
+	RateLimit uint
+}
+
+// statusRequestFlag returns the bundle control flag requesting a status report for the status information.
+func statusRequestFlag(status bpv7.StatusInformationPos) bpv7.BundleControlFlags {
+	switch status {
+	case bpv7.ReceivedBundle:
+		return bpv7.StatusRequestReception
+	case bpv7.ForwardedBundle:
+		return bpv7.StatusRequestForward
+	case bpv7.DeliveredBundle:
+		return bpv7.StatusRequestDelivery
+	case bpv7.DeletedBundle:
+		return bpv7.StatusRequestDeletion
+	default:
+		return 0
+	}
+}
+
+// rateLimiter allows a maximum number of events within a one second window.
+type rateLimiter struct {
+	mutex  sync.Mutex
+	window time.Time
+	count  uint
+}
+
+// allow checks if another event is allowed for the given limit, which is unlimited for zero.
+func (rl *rateLimiter) allow(limit uint) bool {
+	if limit == 0 {
+		return true
+	}
+
+	rl.mutex.Lock()
+	defer rl.mutex.Unlock()
+
+	if now := time.Now(); now.Sub(rl.window) >= time.Second {
+		rl.window = now
+		rl.count = 0
+	}
+
+	if rl.count >= limit {
+		return false
+	}
+	rl.count++
+	return true
+}
@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"testing"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+// pendingStatusReports counts the status reports created by a Core, which are pending without a peer.
+func pendingStatusReports(t *testing.T, c *Core) (reports int) {
+	bis, err := c.Store.QueryPending()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, bi := range bis {
+		if bndl, err := bi.Load(); err != nil {
+			t.Fatal(err)
+		} else if bndl.IsAdministrativeRecord() {
+			reports++
+		}
+	}
+	return
+}
+
+func TestCoreSendStatusReport(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   StatusReportPolicy
+		statuses []bpv7.StatusInformationPos
+		expected int
+	}{
+		{"default", StatusReportPolicy{},
+			[]bpv7.StatusInformationPos{bpv7.ReceivedBundle, bpv7.DeletedBundle}, 2},
+		{"disabled", StatusReportPolicy{Disabled: map[bpv7.StatusInformationPos]bool{bpv7.ReceivedBundle: true}},
+			[]bpv7.StatusInformationPos{bpv7.ReceivedBundle, bpv7.DeletedBundle}, 1},
+		{"rate limit", StatusReportPolicy{RateLimit: 2},
+			[]bpv7.StatusInformationPos{bpv7.ReceivedBundle, bpv7.ReceivedBundle, bpv7.ReceivedBundle, bpv7.DeletedBundle}, 2},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := newTestCore(t, "dtn://a/")
+			defer c.Close()
+			c.SetStatusReportPolicy(test.policy)
+
+			bndl, err := bpv7.Builder().
+				Source("dtn://b/app").
+				Destination("dtn://a/app").
+				ReportTo("dtn://b/").
+				CreationTimestampNow().
+				Lifetime("10m").
+				PayloadBlock([]byte("hello world")).
+				Build()
+			if err != nil {
+				t.Fatal(err)
+			}
+			bp := NewBundleDescriptorFromBundle(bndl, c.Store)
+
+			for _, status := range test.statuses {
+				c.sendStatusReport(bp, status, bpv7.NoInformation)
+			}
+
+			if reports := pendingStatusReports(t, c); reports != test.expected {
+				t.Fatalf("expected %d status reports, got %d", test.expected, reports)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+func TestCoreWriteStatus(t *testing.T) {
+	conf := RoutingConf{
+		Algorithm: "dtlsr",
+		DTLSRConf: DTLSRConfig{RecomputeTime: "30s", BroadcastTime: "30s", PurgeTime: "10m"},
+	}
+
+	c, err := NewCore(t.TempDir(), bpv7.MustNewEndpointID("dtn://a/"), false, conf, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	dtlsr := c.routing.(*DTLSR)
+	dtlsr.routingTable.Store(map[bpv7.EndpointID]bpv7.EndpointID{
+		bpv7.MustNewEndpointID("dtn://c/"): bpv7.MustNewEndpointID("dtn://b/"),
+	})
+
+	var b strings.Builder
+	if err := c.WriteStatus(&b); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, expected := range []string{"Status of dtn://a/", "Connected peers (0)", "bundles: 0", "dtn://c/: dtn://b/"} {
+		if !strings.Contains(b.String(), expected) {
+			t.Fatalf("status misses %q:\n%s", expected, b.String())
+		}
+	}
+}
+
+func TestCoreStatusJSON(t *testing.T) {
+	conf := RoutingConf{
+		Algorithm: "dtlsr",
+		DTLSRConf: DTLSRConfig{RecomputeTime: "30s", BroadcastTime: "30s", PurgeTime: "10m"},
+	}
+
+	c, err := NewCore(t.TempDir(), bpv7.MustNewEndpointID("dtn://a/"), false, conf, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	dtlsr := c.routing.(*DTLSR)
+	dtlsr.routingTable.Store(map[bpv7.EndpointID]bpv7.EndpointID{
+		bpv7.MustNewEndpointID("dtn://c/"): bpv7.MustNewEndpointID("dtn://b/"),
+	})
+
+	data, err := json.Marshal(c.Status())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var doc map[string]interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatal(err)
+	}
+
+	if doc["node_id"] != "dtn://a/" {
+		t.Fatalf("node_id is %v", doc["node_id"])
+	}
+	if peers, ok := doc["peers"].([]interface{}); !ok || len(peers) != 0 {
+		t.Fatalf("peers are %v", doc["peers"])
+	}
+	if store, ok := doc["store"].(map[string]interface{}); !ok || store["bundles"] != float64(0) {
+		t.Fatalf("store is %v", doc["store"])
+	}
+	if table, ok := doc["routing_table"].(map[string]interface{}); !ok || table["dtn://c/"] != "dtn://b/" {
+		t.Fatalf("routing_table is %v", doc["routing_table"])
+	}
+}
@@ -0,0 +1,87 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+	"github.com/dtn7/dtn7-go/pkg/cla"
+)
+
+// StorageAdmissionPolicy rejects received bundles before they are written to the store if storage runs short.
+type StorageAdmissionPolicy struct {
+	// MinFreeSpace is the free disk space in bytes which must remain after storing a bundle. Zero disables this check.
+	MinFreeSpace uint64
+
+	// Quota is the maximum size of the store in bytes, including a new bundle. Zero disables this check.
+	Quota uint64
+}
+
+// SetStorageAdmissionPolicy configures the rejection of received bundles if storage runs short.
+func (c *Core) SetStorageAdmissionPolicy(policy StorageAdmissionPolicy) {
+	c.updateSettings(func(s *coreSettings) { s.storageAdmission = policy })
+}
+
+// checkStorage returns an error if storing a received bundle would violate the StorageAdmissionPolicy. If the free
+// disk space cannot be determined, this check is skipped.
+func (c *Core) checkStorage(bndl *bpv7.Bundle) error {
+	policy := c.loadSettings().storageAdmission
+	if policy.MinFreeSpace == 0 && policy.Quota == 0 {
+		return nil
+	}
+
+	// Retransmissions of a stored, unfragmented bundle do not need any further space.
+	if !bndl.PrimaryBlock.HasFragmentation() && c.Store.KnowsBundle(bndl.ID()) {
+		return nil
+	}
+
+	size, err := bndl.SerializedSize()
+	if err != nil {
+		return err
+	}
+
+	if policy.MinFreeSpace > 0 {
+		if free, err := c.Store.FreeSpace(); err != nil {
+			log.WithError(err).Debug("Failed to determine free disk space, skipping storage admission")
+		} else if free < policy.MinFreeSpace+size {
+			return fmt.Errorf("%d bytes of free disk space fall below %d bytes", free, policy.MinFreeSpace+size)
+		}
+	}
+
+	if policy.Quota > 0 {
+		if used, err := c.Store.Size(); err != nil {
+			return err
+		} else if used+size > policy.Quota {
+			return fmt.Errorf("storing %d bytes exceeds the quota, %d of %d bytes are used", size, used, policy.Quota)
+		}
+	}
+
+	return nil
+}
+
+// admitStorage checks a received bundle against the StorageAdmissionPolicy and the DestinationQuotas. A rejected
+// bundle is deleted with the reason of depleted storage without ever being stored; false is returned.
+func (c *Core) admitStorage(crb cla.ConvergenceReceivedBundle) bool {
+	err := c.checkStorage(crb.Bundle)
+	if err == nil {
+		err = c.checkDestinationQuotas(crb.Bundle)
+	}
+	if err == nil {
+		return true
+	}
+
+	log.WithField("bundle", crb.Bundle.ID().String()).WithError(err).Warn("Rejecting received bundle, storage is short")
+
+	bp := NewBundleDescriptor(crb.Bundle.ID(), c.Store)
+	bp.bndl = crb.Bundle
+	bp.Receiver = crb.Endpoint
+
+	c.events.publish(Event{Type: BundleDeleted, Bundle: bp.ID(), Reason: bpv7.DepletedStorage})
+	c.SendStatusReport(bp, bpv7.DeletedBundle, bpv7.DepletedStorage)
+	return false
+}
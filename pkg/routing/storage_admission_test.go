@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"math"
+	"testing"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+func TestCoreCheckStorage(t *testing.T) {
+	c := newTestCore(t, "dtn://local/")
+	defer c.Close()
+
+	bndl, err := bpv7.Builder().
+		Source("dtn://src/").
+		Destination("dtn://local/").
+		CreationTimestampNow().
+		Lifetime("10m").
+		PayloadBlock(make([]byte, 1024)).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		policy StorageAdmissionPolicy
+		admit  bool
+	}{
+		{"disabled", StorageAdmissionPolicy{}, true},
+		{"sufficient quota", StorageAdmissionPolicy{Quota: math.MaxUint32}, true},
+		{"exceeded quota", StorageAdmissionPolicy{Quota: 512}, false},
+		{"sufficient free space", StorageAdmissionPolicy{MinFreeSpace: 1}, true},
+		{"insufficient free space", StorageAdmissionPolicy{MinFreeSpace: math.MaxUint64 / 2}, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c.SetStorageAdmissionPolicy(test.policy)
+			if err := c.checkStorage(&bndl); (err == nil) != test.admit {
+				t.Fatalf("expected admission = %t, got %v", test.admit, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+// supersessionKey identifies an application's stream of bundles, see bpv7.SupersessionBlock.
+type supersessionKey struct {
+	source      bpv7.EndpointID
+	destination bpv7.EndpointID
+	stream      string
+}
+
+// supersessionEntry is the latest known version of a stream.
+type supersessionEntry struct {
+	version uint64
+	bundle  bpv7.BundleID
+	expires time.Time
+}
+
+// supersessions tracks the latest version of each stream, until its bundle's lifetime ends.
+type supersessions struct {
+	mutex   sync.Mutex
+	streams map[supersessionKey]supersessionEntry
+}
+
+func newSupersessions() *supersessions {
+	return &supersessions{streams: make(map[supersessionKey]supersessionEntry)}
+}
+
+// supersede a bundle's stream by this bundle. A bundle is stale if its stream has a newer version or another bundle
+// of the same version. Otherwise, the previously latest bundle is returned as superseded, if any.
+func (s *supersessions) supersede(bndl *bpv7.Bundle, now time.Time) (stale bool, superseded bpv7.BundleID, replaced bool) {
+	sb, ok := bndl.Supersession()
+	if !ok {
+		return
+	}
+
+	key := supersessionKey{
+		source:      bndl.PrimaryBlock.SourceNode,
+		destination: bndl.PrimaryBlock.Destination,
+		stream:      sb.Stream,
+	}
+	bid := bndl.ID().Scrub()
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	entry, exists := s.streams[key]
+	if exists && entry.bundle == bid {
+		return
+	} else if exists && entry.version >= sb.Version {
+		stale = true
+		return
+	}
+
+	created := now
+	if !bndl.PrimaryBlock.CreationTimestamp.IsZeroTime() {
+		created = bndl.PrimaryBlock.CreationTimestamp.DtnTime().Time()
+	}
+	expires := created.Add(time.Duration(bndl.PrimaryBlock.Lifetime) * time.Millisecond)
+
+	s.streams[key] = supersessionEntry{version: sb.Version, bundle: bid, expires: expires}
+	return false, entry.bundle, exists
+}
+
+// expire the streams whose latest bundle's lifetime ended.
+func (s *supersessions) expire(now time.Time) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for key, entry := range s.streams {
+		if now.After(entry.expires) {
+			delete(s.streams, key)
+		}
+	}
+}
+
+// admitSupersession drops a bundle whose stream already has a newer version, as marked by a bpv7.SupersessionBlock,
+// and returns false. Otherwise, a stored bundle superseded by this bundle is deleted, even if it is still queued for
+// its transmission.
+func (c *Core) admitSupersession(bndl *bpv7.Bundle) bool {
+	stale, superseded, replaced := c.supersessions.supersede(bndl, time.Now())
+	if stale {
+		log.WithField("bundle", bndl.ID().String()).Info("Dropping bundle superseded by a newer version")
+
+		c.events.publish(Event{Type: BundleDeleted, Bundle: bndl.ID(), Reason: bpv7.NoInformation})
+		return false
+	}
+
+	if replaced && c.Store.KnowsBundle(superseded) {
+		log.WithFields(log.Fields{
+			"bundle":     superseded.String(),
+			"superseder": bndl.ID().String(),
+		}).Info("Deleting bundle superseded by a newer version")
+
+		if err := c.DeleteBundle(superseded); err != nil {
+			log.WithField("bundle", superseded.String()).WithError(err).Warn("Deleting superseded bundle erred")
+		}
+	}
+	return true
+}
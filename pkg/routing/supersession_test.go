@@ -0,0 +1,71 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+func TestCoreSupersession(t *testing.T) {
+	c := newTestCore(t, "dtn://a/")
+	defer c.Close()
+
+	now := time.Now()
+	reading := func(version uint64) bpv7.Bundle {
+		bndl, err := bpv7.Builder().
+			Source("dtn://a/sensor").
+			Destination("dtn://collector/").
+			CreationTimestampTime(now.Add(time.Duration(version)*time.Second)).
+			Lifetime("10m").
+			SupersessionBlock("temperature", version).
+			PayloadBlock([]byte("21.5")).
+			Build()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return bndl
+	}
+
+	v1, v2, v3 := reading(1), reading(2), reading(3)
+
+	c.SendBundle(&v2)
+	if !c.Store.KnowsBundle(v2.ID()) {
+		t.Fatal("first reading was not stored")
+	}
+
+	c.SendBundle(&v1)
+	if c.Store.KnowsBundle(v1.ID()) {
+		t.Fatal("stale reading was stored")
+	}
+
+	c.SendBundle(&v3)
+	if !c.Store.KnowsBundle(v3.ID()) {
+		t.Fatal("newer reading was not stored")
+	} else if c.Store.KnowsBundle(v2.ID()) {
+		t.Fatal("superseded reading was not deleted")
+	}
+
+	if !c.admitSupersession(&v3) {
+		t.Fatal("retransmission of the latest reading was dropped")
+	}
+
+	other, err := bpv7.Builder().
+		Source("dtn://a/sensor").
+		Destination("dtn://collector/").
+		CreationTimestampNow().
+		Lifetime("10m").
+		SupersessionBlock("humidity", 1).
+		PayloadBlock([]byte("42")).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !c.admitSupersession(&other) {
+		t.Fatal("reading of another stream was dropped")
+	}
+}
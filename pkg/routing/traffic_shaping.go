@@ -0,0 +1,98 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+	"github.com/dtn7/dtn7-go/pkg/cla"
+)
+
+// TrafficShapingPolicy limits the bytes forwarded to each peer within a time window. Thus, a single peer cannot
+// monopolize a shared uplink. Bundles exceeding a peer's budget are deferred and retried from the store.
+type TrafficShapingPolicy struct {
+	// Budget is the maximum number of bytes sent to a peer within a Window. Zero disables traffic shaping.
+	Budget uint64
+	// Window is the duration after which each peer's budget is restored.
+	Window time.Duration
+}
+
+// peerBudget is a peer's consumed budget within its current window.
+type peerBudget struct {
+	window time.Time
+	used   uint64
+}
+
+// trafficShaper tracks the peerBudgets of a TrafficShapingPolicy.
+type trafficShaper struct {
+	mutex  sync.Mutex
+	policy TrafficShapingPolicy
+	peers  map[string]*peerBudget
+}
+
+// newTrafficShaper for a TrafficShapingPolicy.
+func newTrafficShaper(policy TrafficShapingPolicy) *trafficShaper {
+	return &trafficShaper{
+		policy: policy,
+		peers:  make(map[string]*peerBudget),
+	}
+}
+
+// allow checks if size bytes might be sent to the peer and consumes its budget if so. A bundle exceeding the whole
+// budget is allowed at the beginning of a window, as it would be deferred forever otherwise.
+func (ts *trafficShaper) allow(peer bpv7.EndpointID, size uint64) bool {
+	ts.mutex.Lock()
+	defer ts.mutex.Unlock()
+
+	budget, ok := ts.peers[peer.String()]
+	if !ok {
+		budget = &peerBudget{}
+		ts.peers[peer.String()] = budget
+	}
+
+	if now := time.Now(); now.Sub(budget.window) >= ts.policy.Window {
+		budget.window = now
+		budget.used = 0
+	}
+
+	if budget.used > 0 && budget.used+size > ts.policy.Budget {
+		return false
+	}
+
+	budget.used += size
+	return true
+}
+
+// shapeTraffic returns those ConvergenceSenders whose peers' budgets allow sending the bundle.
+func (c *Core) shapeTraffic(bp BundleDescriptor, nodes []cla.ConvergenceSender) []cla.ConvergenceSender {
+	shaper := c.loadSettings().trafficShaper
+	if shaper == nil || len(nodes) == 0 {
+		return nodes
+	}
+
+	size, err := bp.MustBundle().SerializedSize()
+	if err != nil {
+		log.WithField("bundle", bp.ID().String()).WithError(err).Warn("Failed to calculate size for traffic shaping")
+		return nodes
+	}
+
+	var allowed []cla.ConvergenceSender
+	for _, node := range nodes {
+		if shaper.allow(node.GetPeerEndpointID(), size) {
+			allowed = append(allowed, node)
+		} else {
+			log.WithFields(log.Fields{
+				"bundle": bp.ID().String(),
+				"peer":   node.GetPeerEndpointID(),
+				"size":   size,
+			}).Debug("Traffic shaping deferred bundle for peer")
+		}
+	}
+	return allowed
+}
@@ -0,0 +1,40 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+func TestTrafficShaper(t *testing.T) {
+	ts := newTrafficShaper(TrafficShapingPolicy{Budget: 1000, Window: 50 * time.Millisecond})
+	alpha := bpv7.MustNewEndpointID("dtn://alpha/")
+	beta := bpv7.MustNewEndpointID("dtn://beta/")
+
+	if !ts.allow(alpha, 600) {
+		t.Fatal("first bundle was not allowed")
+	}
+	if ts.allow(alpha, 600) {
+		t.Fatal("bundle exceeding the budget was allowed")
+	}
+	if !ts.allow(alpha, 400) {
+		t.Fatal("bundle within the budget was not allowed")
+	}
+	if !ts.allow(beta, 2000) {
+		t.Fatal("oversized bundle at the window's beginning was not allowed")
+	}
+	if ts.allow(beta, 1) {
+		t.Fatal("bundle after an oversized one was allowed")
+	}
+
+	time.Sleep(60 * time.Millisecond)
+
+	if !ts.allow(alpha, 1000) {
+		t.Fatal("budget was not restored after the window")
+	}
+}
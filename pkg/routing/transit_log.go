@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	log "github.com/sirupsen/logrus"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+// SetTransitLog enables the recording of forwarded bundles' paths. Locally created bundles get a
+// bpv7.TransitLogBlock and this node appends itself to the TransitLogBlock of each forwarded bundle, unless it already
+// holds maxRecords records. Zero disables the transit log.
+func (c *Core) SetTransitLog(maxRecords uint) {
+	c.updateSettings(func(s *coreSettings) { s.transitLog = maxRecords })
+}
+
+// sendBundleAttachTransitLog attaches an empty TransitLogBlock to outgoing bundles.
+func (c *Core) sendBundleAttachTransitLog(bndl *bpv7.Bundle) {
+	cb := bpv7.NewCanonicalBlock(0, bpv7.ReplicateBlock, bpv7.NewTransitLogBlock())
+	if crcPolicy := c.loadSettings().crcPolicy; crcPolicy.Enforce {
+		cb.SetCRCType(crcPolicy.Canonical)
+	}
+
+	if err := bndl.AddExtensionBlock(cb); err != nil {
+		log.WithField("bundle", bndl.ID().String()).WithError(err).Error("Error attaching transit log block")
+		return
+	}
+
+	log.WithField("bundle", bndl.ID().String()).Debug("Attached transit log block to outgoing bundle")
+}
+
+// recordTransit appends this node to a bundle's TransitLogBlock before its transmission. The returned function resets
+// the TransitLogBlock afterwards, as a bundle might be forwarded multiple times, e.g., for retries.
+func (c *Core) recordTransit(bp BundleDescriptor) (reset func()) {
+	reset = func() {}
+
+	s := c.loadSettings()
+	if s.transitLog == 0 {
+		return
+	}
+
+	cb, err := bp.MustBundle().ExtensionBlock(bpv7.ExtBlockTypeTransitLogBlock)
+	if err != nil {
+		return
+	}
+
+	received := cb.Value.(*bpv7.TransitLogBlock)
+	transitLog := &bpv7.TransitLogBlock{
+		Records: append([]bpv7.TransitRecord(nil), received.Records...),
+		Omitted: received.Omitted,
+	}
+
+	now := bpv7.DtnTimeNow()
+	if s.clockless {
+		now = bpv7.DtnTimeEpoch
+	}
+
+	if !transitLog.Append(bpv7.TransitRecord{Node: c.NodeId, Time: now}, int(s.transitLog)) {
+		log.WithFields(log.Fields{
+			"bundle":  bp.ID().String(),
+			"records": len(transitLog.Records),
+		}).Debug("Transit log block is full, omitting this hop")
+	}
+
+	cb.Value = transitLog
+	return func() { cb.Value = received }
+}
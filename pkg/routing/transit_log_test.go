@@ -0,0 +1,54 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"testing"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+func TestCoreRecordTransit(t *testing.T) {
+	c := newTestCore(t, "dtn://relay/")
+	defer c.Close()
+
+	bndl, err := bpv7.Builder().
+		Source("dtn://src/").
+		Destination("dtn://dst/").
+		CreationTimestampNow().
+		Lifetime("10m").
+		TransitLogBlock().
+		PayloadBlock([]byte("hello world")).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	bp := NewBundleDescriptorFromBundle(bndl, c.Store)
+
+	transitLog := func() *bpv7.TransitLogBlock {
+		cb, err := bp.MustBundle().ExtensionBlock(bpv7.ExtBlockTypeTransitLogBlock)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return cb.Value.(*bpv7.TransitLogBlock)
+	}
+
+	// A disabled transit log is left untouched.
+	c.recordTransit(bp)
+	if tlb := transitLog(); len(tlb.Records) != 0 {
+		t.Fatalf("disabled transit log recorded %v", tlb.Records)
+	}
+
+	c.SetTransitLog(1)
+	reset := c.recordTransit(bp)
+	if tlb := transitLog(); len(tlb.Records) != 1 || tlb.Records[0].Node != c.NodeId {
+		t.Fatalf("expected a record of %v, got %v", c.NodeId, tlb.Records)
+	}
+
+	reset()
+	if tlb := transitLog(); len(tlb.Records) != 0 {
+		t.Fatalf("reset transit log holds %v", tlb.Records)
+	}
+}
@@ -0,0 +1,68 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"hash/fnv"
+	"sync"
+)
+
+// workerQueueSize is the number of tasks each worker buffers before further submissions block.
+const workerQueueSize = 64
+
+// workerPool executes tasks by a fixed number of workers. All tasks of the same key are executed by the same worker in
+// their order of submission, while tasks of different keys might be executed in parallel.
+type workerPool struct {
+	queues []chan func()
+	wg     sync.WaitGroup
+}
+
+// newWorkerPool creates and starts a workerPool of the given number of workers, which must be positive.
+func newWorkerPool(workers int) *workerPool {
+	pool := &workerPool{queues: make([]chan func(), workers)}
+
+	for i := range pool.queues {
+		pool.queues[i] = make(chan func(), workerQueueSize)
+
+		pool.wg.Add(1)
+		go pool.work(pool.queues[i])
+	}
+
+	return pool
+}
+
+func (pool *workerPool) work(queue chan func()) {
+	defer pool.wg.Done()
+
+	for task := range queue {
+		task()
+	}
+}
+
+// size returns the number of workers.
+func (pool *workerPool) size() int {
+	return len(pool.queues)
+}
+
+// submit a task to the worker responsible for the key. This blocks while this worker's queue is full.
+func (pool *workerPool) submit(key string, task func()) {
+	pool.queues[workerIndex(key, len(pool.queues))] <- task
+}
+
+// workerIndex maps a key to one of the workers.
+func workerIndex(key string, workers int) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+
+	return int(h.Sum32() % uint32(workers))
+}
+
+// stop the workerPool after all submitted tasks were executed. Afterwards, no more tasks may be submitted.
+func (pool *workerPool) stop() {
+	for _, queue := range pool.queues {
+		close(queue)
+	}
+	pool.wg.Wait()
+}
@@ -0,0 +1,66 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWorkerPoolOrdering(t *testing.T) {
+	pool := newWorkerPool(4)
+
+	var mutex sync.Mutex
+	results := make(map[string][]int)
+
+	for i := 0; i < 100; i++ {
+		key, i := fmt.Sprintf("key-%d", i%5), i
+		pool.submit(key, func() {
+			mutex.Lock()
+			defer mutex.Unlock()
+			results[key] = append(results[key], i)
+		})
+	}
+	pool.stop()
+
+	for key, values := range results {
+		if len(values) != 20 {
+			t.Fatalf("%s has %d results, expected 20", key, len(values))
+		}
+		for i := 1; i < len(values); i++ {
+			if values[i-1] >= values[i] {
+				t.Fatalf("%s was processed out of order: %v", key, values)
+			}
+		}
+	}
+}
+
+func TestWorkerPoolParallel(t *testing.T) {
+	pool := newWorkerPool(2)
+	defer pool.stop()
+
+	// Find a second key, handled by another worker than the blocked one.
+	otherKey := ""
+	for i := 0; otherKey == ""; i++ {
+		if key := fmt.Sprintf("key-%d", i); workerIndex(key, 2) != workerIndex("slow", 2) {
+			otherKey = key
+		}
+	}
+
+	block := make(chan struct{})
+	pool.submit("slow", func() { <-block })
+	defer close(block)
+
+	done := make(chan struct{})
+	pool.submit(otherKey, func() { close(done) })
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("blocked worker delayed another key's task")
+	}
+}
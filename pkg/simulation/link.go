@@ -0,0 +1,187 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package simulation
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+	"github.com/dtn7/dtn7-go/pkg/cla"
+	"github.com/dtn7/dtn7-go/pkg/routing"
+)
+
+// Link is a bidirectional in-memory connection between two Cores, which can be brought up and down to simulate
+// contacts. Each side of a Link is registered as a CLA at its Core while the Link is up.
+type Link struct {
+	mutex sync.Mutex
+	up    bool
+
+	// delay is the propagation delay of each transmitted bundle.
+	delay time.Duration
+
+	endpoints [2]*linkEndpoint
+}
+
+// NewLink between two Cores with a propagation delay. A new Link is down.
+func NewLink(a, b *routing.Core, delay time.Duration) *Link {
+	l := &Link{delay: delay}
+
+	l.endpoints[0] = &linkEndpoint{link: l, core: a, reportChan: make(chan cla.ConvergenceStatus)}
+	l.endpoints[1] = &linkEndpoint{link: l, core: b, reportChan: make(chan cla.ConvergenceStatus)}
+	l.endpoints[0].peer = l.endpoints[1]
+	l.endpoints[1].peer = l.endpoints[0]
+
+	return l
+}
+
+// IsUp returns if this Link is currently up.
+func (l *Link) IsUp() bool {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	return l.up
+}
+
+// Up brings this Link up by registering both sides at their Cores, which will report their peers to be appeared.
+func (l *Link) Up() {
+	l.mutex.Lock()
+	if l.up {
+		l.mutex.Unlock()
+		return
+	}
+	l.up = true
+	l.mutex.Unlock()
+
+	for _, e := range l.endpoints {
+		e.core.RegisterConvergable(e)
+	}
+}
+
+// Down brings this Link down. Both sides report their peers to be disappeared, resulting in their CLA Managers
+// failing to restart them until the Link is up again.
+func (l *Link) Down() {
+	l.mutex.Lock()
+	if !l.up {
+		l.mutex.Unlock()
+		return
+	}
+	l.up = false
+	l.mutex.Unlock()
+
+	for _, e := range l.endpoints {
+		_ = e.report(cla.NewConvergencePeerDisappeared(e, e.peer.core.NodeId))
+	}
+}
+
+// linkEndpoint is one side of a Link, being both a ConvergenceReceiver and ConvergenceSender for its Core.
+type linkEndpoint struct {
+	mutex sync.Mutex
+
+	link *Link
+	core *routing.Core
+	peer *linkEndpoint
+
+	reportChan chan cla.ConvergenceStatus
+
+	// closed is non-nil while this linkEndpoint is started and will be closed by Close.
+	closed chan struct{}
+}
+
+// report a ConvergenceStatus to the Core, if this linkEndpoint is started. An error is returned otherwise.
+func (e *linkEndpoint) report(cs cla.ConvergenceStatus) error {
+	e.mutex.Lock()
+	closed := e.closed
+	e.mutex.Unlock()
+
+	if closed == nil {
+		return fmt.Errorf("%s is not started", e.Address())
+	}
+
+	select {
+	case e.reportChan <- cs:
+		return nil
+	case <-closed:
+		return fmt.Errorf("%s was closed", e.Address())
+	}
+}
+
+func (e *linkEndpoint) Start() (error, bool) {
+	if !e.link.IsUp() {
+		return fmt.Errorf("link is down"), false
+	}
+
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	if e.closed != nil {
+		return fmt.Errorf("%s is already started", e.Address()), false
+	}
+	e.closed = make(chan struct{})
+
+	// The CLA Manager starts forwarding this channel only after Start has returned.
+	go func() { _ = e.report(cla.NewConvergencePeerAppeared(e, e.peer.core.NodeId)) }()
+
+	return nil, false
+}
+
+func (e *linkEndpoint) Close() error {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	if e.closed != nil {
+		close(e.closed)
+		e.closed = nil
+	}
+	return nil
+}
+
+// Send a bundle to the peer's Core after the Link's delay. The bundle is serialized and parsed again, so that both
+// Cores never share a bundle's memory.
+func (e *linkEndpoint) Send(bndl bpv7.Bundle) error {
+	var buf bytes.Buffer
+	if err := bndl.MarshalCbor(&buf); err != nil {
+		return err
+	}
+
+	if e.link.delay > 0 {
+		time.Sleep(e.link.delay)
+	}
+	if !e.link.IsUp() {
+		return fmt.Errorf("link went down during transmission")
+	}
+
+	received, err := bpv7.ParseBundle(&buf)
+	if err != nil {
+		return err
+	}
+	return e.peer.report(cla.NewConvergenceReceivedBundle(e.peer, e.peer.core.NodeId, &received))
+}
+
+func (e *linkEndpoint) Channel() chan cla.ConvergenceStatus {
+	return e.reportChan
+}
+
+func (e *linkEndpoint) Address() string {
+	return fmt.Sprintf("sim://%s-%s", e.core.NodeId.Authority(), e.peer.core.NodeId.Authority())
+}
+
+func (e *linkEndpoint) IsPermanent() bool {
+	return false
+}
+
+func (e *linkEndpoint) GetEndpointID() bpv7.EndpointID {
+	return e.core.NodeId
+}
+
+func (e *linkEndpoint) GetPeerEndpointID() bpv7.EndpointID {
+	return e.peer.core.NodeId
+}
+
+func (e *linkEndpoint) String() string {
+	return e.Address()
+}
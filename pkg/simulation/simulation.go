@@ -0,0 +1,369 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+// Package simulation runs a whole network of DTN nodes within a single process for reproducible routing experiments.
+//
+// A Scenario describes the nodes, the in-memory Links between them with their contact schedules, and the generated
+// traffic. Run executes a Scenario in real time and returns the delivery statistics of each traffic flow.
+package simulation
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+	"github.com/dtn7/dtn7-go/pkg/node"
+	"github.com/dtn7/dtn7-go/pkg/routing"
+)
+
+// Contact is a time span, relative to the Scenario's start, during which a Link is up.
+type Contact struct {
+	Start time.Duration
+	End   time.Duration
+}
+
+// LinkConf connects two nodes, identified by their names.
+type LinkConf struct {
+	A, B string
+
+	// Delay is the propagation delay of each bundle.
+	Delay time.Duration
+
+	// Contacts schedule the Link's up times. Without Contacts, the Link is up during the whole Scenario.
+	Contacts []Contact
+}
+
+// Flow generates Count bundles from the Source to the Destination node, one each Interval after its Start.
+type Flow struct {
+	Source, Destination string
+
+	Start    time.Duration
+	Interval time.Duration
+	Count    int
+
+	// Size of each bundle's payload in bytes and its Lifetime.
+	Size     int
+	Lifetime time.Duration
+}
+
+// Scenario of a simulation.
+type Scenario struct {
+	// Duration of the whole Scenario, including the time for the last bundles to be delivered.
+	Duration time.Duration
+
+	// Routing algorithm used by all nodes.
+	Routing routing.RoutingConf
+
+	// Nodes are the names of all nodes, resulting in Node IDs like "dtn://name/".
+	Nodes []string
+
+	Links   []LinkConf
+	Traffic []Flow
+}
+
+// Validate a Scenario's references and time spans.
+func (s Scenario) Validate() error {
+	if s.Duration <= 0 {
+		return fmt.Errorf("duration must be positive")
+	}
+	if err := s.Routing.Validate(); err != nil {
+		return fmt.Errorf("routing: %v", err)
+	}
+
+	nodes := make(map[string]bool)
+	for _, name := range s.Nodes {
+		if nodes[name] {
+			return fmt.Errorf("node %q is defined twice", name)
+		} else if _, err := bpv7.NewEndpointID(nodeId(name)); err != nil {
+			return fmt.Errorf("node %q: %v", name, err)
+		}
+		nodes[name] = true
+	}
+
+	for i, l := range s.Links {
+		if !nodes[l.A] || !nodes[l.B] {
+			return fmt.Errorf("link[%d]: unknown node %q or %q", i, l.A, l.B)
+		} else if l.A == l.B {
+			return fmt.Errorf("link[%d]: node %q is linked to itself", i, l.A)
+		} else if l.Delay < 0 {
+			return fmt.Errorf("link[%d]: delay is negative", i)
+		}
+
+		for _, contact := range l.Contacts {
+			if contact.Start < 0 || contact.End <= contact.Start {
+				return fmt.Errorf("link[%d]: contact from %v to %v is invalid", i, contact.Start, contact.End)
+			}
+		}
+	}
+
+	for i, f := range s.Traffic {
+		switch {
+		case !nodes[f.Source] || !nodes[f.Destination]:
+			return fmt.Errorf("traffic[%d]: unknown node %q or %q", i, f.Source, f.Destination)
+		case f.Source == f.Destination:
+			return fmt.Errorf("traffic[%d]: source and destination are both %q", i, f.Source)
+		case f.Count <= 0:
+			return fmt.Errorf("traffic[%d]: count must be positive", i)
+		case f.Count > 1 && f.Interval <= 0:
+			return fmt.Errorf("traffic[%d]: interval must be positive", i)
+		case f.Start < 0 || f.Size < 0 || f.Lifetime <= 0:
+			return fmt.Errorf("traffic[%d]: start and size must not be negative, lifetime must be positive", i)
+		}
+	}
+
+	return nil
+}
+
+// nodeId of a named node.
+func nodeId(name string) string {
+	return "dtn://" + name + "/"
+}
+
+// flowEndpoint is the endpoint of a Flow's bundles on both its source and destination node.
+func flowEndpoint(name string, flow int) string {
+	return fmt.Sprintf("dtn://%s/sim/%d", name, flow)
+}
+
+// FlowResult are the delivery statistics of a Flow.
+type FlowResult struct {
+	Source      string `json:"source"`
+	Destination string `json:"destination"`
+
+	Sent      int `json:"sent"`
+	Delivered int `json:"delivered"`
+
+	// MinDelay, MeanDelay, and MaxDelay between sending and delivering the delivered bundles.
+	MinDelay  time.Duration `json:"min_delay_ns"`
+	MeanDelay time.Duration `json:"mean_delay_ns"`
+	MaxDelay  time.Duration `json:"max_delay_ns"`
+}
+
+// DeliveryRatio of the sent bundles.
+func (fr FlowResult) DeliveryRatio() float64 {
+	if fr.Sent == 0 {
+		return 0
+	}
+	return float64(fr.Delivered) / float64(fr.Sent)
+}
+
+// NodeResult are a node's final counters.
+type NodeResult struct {
+	Node      string `json:"node"`
+	Received  uint64 `json:"received"`
+	Forwarded uint64 `json:"forwarded"`
+	Delivered uint64 `json:"delivered"`
+	Stored    int    `json:"stored"`
+}
+
+// Result of a simulation run.
+type Result struct {
+	Flows []FlowResult `json:"flows"`
+	Nodes []NodeResult `json:"nodes"`
+}
+
+// simulation is the state of a running Scenario.
+type simulation struct {
+	mutex sync.Mutex
+
+	scenario Scenario
+	nodes    map[string]*node.Node
+	links    []*Link
+
+	// sent and delivered track each bundle by its Flow and sequence number.
+	sent      []map[int]time.Time
+	delivered []map[int]time.Duration
+}
+
+// Run a Scenario in real time, using directory dir for the nodes' stores. The Scenario takes its whole Duration.
+func Run(scenario Scenario, dir string) (Result, error) {
+	if err := scenario.Validate(); err != nil {
+		return Result{}, err
+	}
+
+	sim := &simulation{
+		scenario:  scenario,
+		nodes:     make(map[string]*node.Node),
+		sent:      make([]map[int]time.Time, len(scenario.Traffic)),
+		delivered: make([]map[int]time.Duration, len(scenario.Traffic)),
+	}
+	for i := range scenario.Traffic {
+		sim.sent[i] = make(map[int]time.Time)
+		sim.delivered[i] = make(map[int]time.Duration)
+	}
+
+	defer sim.stop()
+	if err := sim.start(dir); err != nil {
+		return Result{}, err
+	}
+
+	var timers []*time.Timer
+	for i, lc := range scenario.Links {
+		link := sim.links[i]
+		if len(lc.Contacts) == 0 {
+			link.Up()
+			continue
+		}
+		for _, contact := range lc.Contacts {
+			timers = append(timers, time.AfterFunc(contact.Start, link.Up), time.AfterFunc(contact.End, link.Down))
+		}
+	}
+	for i, f := range scenario.Traffic {
+		for seq := 0; seq < f.Count; seq++ {
+			i, seq := i, seq
+			timers = append(timers, time.AfterFunc(f.Start+time.Duration(seq)*f.Interval, func() { sim.send(i, seq) }))
+		}
+	}
+
+	time.Sleep(scenario.Duration)
+	for _, timer := range timers {
+		timer.Stop()
+	}
+
+	return sim.result(), nil
+}
+
+// start all nodes and create their Links.
+func (sim *simulation) start(dir string) error {
+	for _, name := range sim.scenario.Nodes {
+		n, err := node.NewNode(node.Config{
+			NodeId:          nodeId(name),
+			Store:           filepath.Join(dir, name),
+			Routing:         sim.scenario.Routing,
+			ShutdownTimeout: 100 * time.Millisecond,
+		})
+		if err != nil {
+			return err
+		}
+		if err := n.Start(); err != nil {
+			return fmt.Errorf("starting node %q failed: %v", name, err)
+		}
+		sim.nodes[name] = n
+	}
+
+	for i, f := range sim.scenario.Traffic {
+		i, dst := i, f.Destination
+		if err := sim.nodes[dst].Handle(flowEndpoint(dst, i), func(b bpv7.Bundle) { sim.receive(i, b) }); err != nil {
+			return err
+		}
+	}
+
+	for _, lc := range sim.scenario.Links {
+		sim.links = append(sim.links, NewLink(sim.nodes[lc.A].Core(), sim.nodes[lc.B].Core(), lc.Delay))
+	}
+	return nil
+}
+
+// stop all nodes, closing their Links' sides. The Links are not brought down before, as the resulting status
+// messages might race with the Cores' shutdown.
+func (sim *simulation) stop() {
+	var wg sync.WaitGroup
+	for _, n := range sim.nodes {
+		wg.Add(1)
+		go func(n *node.Node) {
+			defer wg.Done()
+			n.Stop()
+		}(n)
+	}
+	wg.Wait()
+}
+
+// send the bundle of a Flow's sequence number. Its payload starts with the sequence number, padded to the Flow's Size.
+func (sim *simulation) send(flow, seq int) {
+	f := sim.scenario.Traffic[flow]
+
+	payload := []byte(strconv.Itoa(seq) + "\n")
+	if len(payload) < f.Size {
+		payload = append(payload, bytes.Repeat([]byte{0}, f.Size-len(payload))...)
+	}
+
+	bndl, err := bpv7.Builder().
+		Source(flowEndpoint(f.Source, flow)).
+		Destination(flowEndpoint(f.Destination, flow)).
+		CreationTimestampNow().
+		Lifetime(f.Lifetime).
+		HopCountBlock(64).
+		PayloadBlock(payload).
+		Build()
+	if err != nil {
+		return
+	}
+	// Bundles of a Flow might be created within the same millisecond and are distinguished by their sequence number.
+	bndl.PrimaryBlock.CreationTimestamp[1] = uint64(seq)
+
+	sim.mutex.Lock()
+	sim.sent[flow][seq] = time.Now()
+	sim.mutex.Unlock()
+
+	_ = sim.nodes[f.Source].Send(bndl)
+}
+
+// receive a Flow's bundle at its destination, recording the first delivery of each sequence number.
+func (sim *simulation) receive(flow int, b bpv7.Bundle) {
+	now := time.Now()
+
+	payload, err := b.PayloadBlock()
+	if err != nil {
+		return
+	}
+	line, _, _ := bytes.Cut(payload.Value.(*bpv7.PayloadBlock).Data(), []byte("\n"))
+	seq, err := strconv.Atoi(string(line))
+	if err != nil {
+		return
+	}
+
+	sim.mutex.Lock()
+	defer sim.mutex.Unlock()
+
+	sentTime, ok := sim.sent[flow][seq]
+	if _, delivered := sim.delivered[flow][seq]; ok && !delivered {
+		sim.delivered[flow][seq] = now.Sub(sentTime)
+	}
+}
+
+// result of the simulation's Flows and nodes.
+func (sim *simulation) result() (r Result) {
+	sim.mutex.Lock()
+	defer sim.mutex.Unlock()
+
+	for i, f := range sim.scenario.Traffic {
+		fr := FlowResult{
+			Source:      f.Source,
+			Destination: f.Destination,
+			Sent:        len(sim.sent[i]),
+			Delivered:   len(sim.delivered[i]),
+		}
+
+		var total time.Duration
+		for _, delay := range sim.delivered[i] {
+			if fr.MinDelay == 0 || delay < fr.MinDelay {
+				fr.MinDelay = delay
+			}
+			if delay > fr.MaxDelay {
+				fr.MaxDelay = delay
+			}
+			total += delay
+		}
+		if fr.Delivered > 0 {
+			fr.MeanDelay = total / time.Duration(fr.Delivered)
+		}
+
+		r.Flows = append(r.Flows, fr)
+	}
+
+	for _, name := range sim.scenario.Nodes {
+		m := sim.nodes[name].Core().Metrics()
+		r.Nodes = append(r.Nodes, NodeResult{
+			Node:      name,
+			Received:  m.Received,
+			Forwarded: m.Forwarded,
+			Delivered: m.Delivered,
+			Stored:    m.StoredBundles,
+		})
+	}
+	return r
+}
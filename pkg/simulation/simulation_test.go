@@ -0,0 +1,93 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package simulation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/routing"
+)
+
+func TestScenarioValidate(t *testing.T) {
+	valid := func() Scenario {
+		return Scenario{
+			Duration: time.Second,
+			Routing:  routing.RoutingConf{Algorithm: "epidemic"},
+			Nodes:    []string{"a", "b"},
+			Links:    []LinkConf{{A: "a", B: "b", Contacts: []Contact{{Start: 0, End: time.Second}}}},
+			Traffic:  []Flow{{Source: "a", Destination: "b", Count: 2, Interval: time.Second, Lifetime: time.Minute}},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		modify func(*Scenario)
+		valid  bool
+	}{
+		{"valid", func(*Scenario) {}, true},
+		{"no duration", func(s *Scenario) { s.Duration = 0 }, false},
+		{"no routing", func(s *Scenario) { s.Routing.Algorithm = "" }, false},
+		{"duplicate node", func(s *Scenario) { s.Nodes = append(s.Nodes, "a") }, false},
+		{"unknown link node", func(s *Scenario) { s.Links[0].B = "c" }, false},
+		{"self link", func(s *Scenario) { s.Links[0].B = "a" }, false},
+		{"empty contact", func(s *Scenario) { s.Links[0].Contacts[0].End = 0 }, false},
+		{"unknown flow node", func(s *Scenario) { s.Traffic[0].Destination = "c" }, false},
+		{"no count", func(s *Scenario) { s.Traffic[0].Count = 0 }, false},
+		{"no interval", func(s *Scenario) { s.Traffic[0].Interval = 0 }, false},
+		{"single bundle", func(s *Scenario) { s.Traffic[0].Count, s.Traffic[0].Interval = 1, 0 }, true},
+		{"no lifetime", func(s *Scenario) { s.Traffic[0].Lifetime = 0 }, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := valid()
+			test.modify(&s)
+			if err := s.Validate(); (err == nil) != test.valid {
+				t.Fatalf("expected valid %t, got %v", test.valid, err)
+			}
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping simulation in short mode")
+	}
+
+	// Bundles from a to c are stored at b until its contact to c.
+	scenario := Scenario{
+		Duration: 3 * time.Second,
+		Routing:  routing.RoutingConf{Algorithm: "epidemic"},
+		Nodes:    []string{"a", "b", "c"},
+		Links: []LinkConf{
+			{A: "a", B: "b"},
+			{A: "b", B: "c", Delay: 10 * time.Millisecond, Contacts: []Contact{{Start: 1500 * time.Millisecond, End: 2500 * time.Millisecond}}},
+		},
+		Traffic: []Flow{
+			{Source: "a", Destination: "c", Start: 200 * time.Millisecond, Interval: 100 * time.Millisecond, Count: 3, Size: 64, Lifetime: time.Minute},
+		},
+	}
+
+	r, err := Run(scenario, t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(r.Flows) != 1 || len(r.Nodes) != 3 {
+		t.Fatalf("expected one flow and three nodes, got %v", r)
+	}
+	if fr := r.Flows[0]; fr.Sent != 3 || fr.Delivered != 3 {
+		t.Fatalf("expected three sent and delivered bundles, got %v", fr)
+	} else if fr.MinDelay < time.Second || fr.MaxDelay > 2*time.Second {
+		t.Fatalf("delays from %v to %v do not match the contact", fr.MinDelay, fr.MaxDelay)
+	} else if fr.DeliveryRatio() != 1 {
+		t.Fatalf("expected a delivery ratio of 1, got %f", fr.DeliveryRatio())
+	}
+
+	if n := r.Nodes[2]; n.Node != "c" || n.Delivered != 3 {
+		t.Fatalf("expected node c to have delivered three bundles, got %v", n)
+	}
+}
@@ -0,0 +1,144 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package storage
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/dgraph-io/badger"
+	log "github.com/sirupsen/logrus"
+	"github.com/timshannon/badgerhold"
+)
+
+// batchOp is a queued insert of a BundleItem, whose result is reported back on done.
+type batchOp struct {
+	key  string
+	item BundleItem
+	done chan error
+}
+
+// batchWriter commits the inserts of concurrent Pushes together within shared transactions. Each synchronous write
+// of the database costs a disk sync, which otherwise limits the rate of received bundles.
+//
+// Pushes are still blocking until their BundleItem was committed. Thus, a returned Push is durable and visible to
+// all queries, while a crash can only lose bundles whose Pushes have not returned yet.
+type batchWriter struct {
+	bh *badgerhold.Store
+
+	// mutex protects the closed flag against inserts racing with close.
+	mutex  sync.RWMutex
+	closed bool
+
+	queue chan *batchOp
+
+	stopSyn chan struct{}
+	stopAck chan struct{}
+}
+
+// newBatchWriter with a bounded queue. Inserts block while the queue is full.
+func newBatchWriter(bh *badgerhold.Store, queueSize int) *batchWriter {
+	bw := &batchWriter{
+		bh:      bh,
+		queue:   make(chan *batchOp, queueSize),
+		stopSyn: make(chan struct{}),
+		stopAck: make(chan struct{}),
+	}
+
+	go bw.handler()
+
+	return bw
+}
+
+// insert a BundleItem and wait for its commit.
+func (bw *batchWriter) insert(key string, item BundleItem) error {
+	op := &batchOp{key: key, item: item, done: make(chan error, 1)}
+
+	bw.mutex.RLock()
+	if bw.closed {
+		bw.mutex.RUnlock()
+		return fmt.Errorf("store is closed")
+	}
+	bw.queue <- op
+	bw.mutex.RUnlock()
+
+	return <-op.done
+}
+
+// handler commits the queued inserts until the batchWriter is closed, after flushing the remaining queue.
+func (bw *batchWriter) handler() {
+	defer close(bw.stopAck)
+
+	for {
+		select {
+		case op := <-bw.queue:
+			bw.commit(bw.collect(op))
+
+		case <-bw.stopSyn:
+			for {
+				select {
+				case op := <-bw.queue:
+					bw.commit(bw.collect(op))
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// collect all currently queued inserts after the first one into a batch.
+func (bw *batchWriter) collect(first *batchOp) []*batchOp {
+	ops := []*batchOp{first}
+	for len(ops) < cap(bw.queue) {
+		select {
+		case op := <-bw.queue:
+			ops = append(ops, op)
+		default:
+			return ops
+		}
+	}
+	return ops
+}
+
+// commit a batch within one transaction. If this fails, each insert is retried on its own, so that a single failing
+// insert, e.g., of an already known key, does not affect the others.
+func (bw *batchWriter) commit(ops []*batchOp) {
+	err := bw.bh.Badger().Update(func(tx *badger.Txn) error {
+		for _, op := range ops {
+			if err := bw.bh.TxInsert(tx, op.key, op.item); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	if err != nil && len(ops) > 1 {
+		log.WithError(err).WithField("size", len(ops)).Debug("Committing batch failed, inserting individually")
+
+		for _, op := range ops {
+			op.done <- bw.bh.Insert(op.key, op.item)
+		}
+		return
+	}
+
+	for _, op := range ops {
+		op.done <- err
+	}
+}
+
+// close the batchWriter after committing all queued inserts. Further inserts fail.
+func (bw *batchWriter) close() {
+	bw.mutex.Lock()
+	if bw.closed {
+		bw.mutex.Unlock()
+		return
+	}
+	bw.closed = true
+	bw.mutex.Unlock()
+
+	close(bw.stopSyn)
+	<-bw.stopAck
+}
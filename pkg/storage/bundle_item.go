@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/dtn7/dtn7-go/pkg/bpv7"
+	"github.com/dtn7/dtn7-go/pkg/internal/bufpool"
 )
 
 // BundleItem is a wrapper for meta data around a Bundle. The Store operates
@@ -23,6 +24,10 @@ type BundleItem struct {
 	Pending bool      `badgerholdIndex:"Pending"`
 	Expires time.Time `badgerholdIndex:"Expires"`
 
+	// Destination is the Bundle's destination and Size the serialized size of all its parts in bytes.
+	Destination string
+	Size        uint64
+
 	Fragmented bool
 	Parts      []BundlePart
 
@@ -42,6 +47,10 @@ func (bi BundleItem) bundleParts() (bundleParts []bpv7.Bundle, err error) {
 
 // Load the complete bpv7.Bundle for a BundleItem. If there are multiple fragments, a reassembly will be performed.
 func (bi BundleItem) Load() (b bpv7.Bundle, err error) {
+	if !bi.Fragmented && len(bi.Parts) == 1 {
+		return bi.Parts[0].Load()
+	}
+
 	var parts []bpv7.Bundle
 	if parts, err = bi.bundleParts(); err == nil {
 		b, err = bpv7.ReassembleFragments(parts)
@@ -68,12 +77,28 @@ type BundlePart struct {
 	TotalDataLength uint64
 }
 
-// storeBundle serializes the Bundle of a BundleItem/BundlePart to the disk.
-func (bp BundlePart) storeBundle(b bpv7.Bundle) error {
-	if f, err := os.OpenFile(bp.Filename, os.O_WRONLY|os.O_CREATE, 0600); err != nil {
+// storeBundle serializes the Bundle of a BundleItem/BundlePart to the disk. If sync is set, the file is synced
+// before returning, e.g., to be durable before its BundleItem gets committed.
+func (bp BundlePart) storeBundle(b bpv7.Bundle, sync bool) error {
+	if f, err := os.OpenFile(bp.Filename, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600); err != nil {
 		return err
 	} else {
-		return b.WriteBundle(f)
+		w := bufpool.GetWriter(f)
+		defer bufpool.PutWriter(w)
+
+		if err := b.WriteBundle(w); err != nil {
+			_ = f.Close()
+			return err
+		} else if err := w.Flush(); err != nil {
+			_ = f.Close()
+			return err
+		} else if sync {
+			if err := f.Sync(); err != nil {
+				_ = f.Close()
+				return err
+			}
+		}
+		return f.Close()
 	}
 }
 
@@ -87,7 +112,10 @@ func (bp BundlePart) Load() (b bpv7.Bundle, err error) {
 	if f, fErr := os.Open(bp.Filename); fErr != nil {
 		err = fErr
 	} else {
-		b, err = bpv7.ParseBundle(f)
+		r := bufpool.GetReader(f)
+		b, err = bpv7.ParseBundle(r)
+		bufpool.PutReader(r)
+		_ = f.Close()
 	}
 	return
 }
@@ -116,6 +144,8 @@ func newBundleItem(b bpv7.Bundle, storagePath string) (bi BundleItem) {
 		Pending: false,
 		Expires: calcExpirationDate(b),
 
+		Destination: b.PrimaryBlock.Destination.String(),
+
 		Fragmented: b.PrimaryBlock.HasFragmentation(),
 
 		Properties: make(map[string]interface{}),
@@ -130,5 +160,9 @@ func newBundleItem(b bpv7.Bundle, storagePath string) (bi BundleItem) {
 
 	bi.Parts = append(bi.Parts, bp)
 
+	if size, err := b.SerializedSize(); err == nil {
+		bi.Size = size
+	}
+
 	return
 }
@@ -0,0 +1,95 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package storage
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+// bundleCacheEntry is a cached Bundle, identified by its BundlePart's filename.
+type bundleCacheEntry struct {
+	key  string
+	bndl bpv7.Bundle
+}
+
+// bundleCache is a least recently used cache of loaded Bundles. The cached Bundles must not be modified; thus, only
+// copies are handed out.
+type bundleCache struct {
+	mutex sync.Mutex
+
+	size  int
+	items map[string]*list.Element
+	order *list.List // most recently used entries first
+
+	hits   uint64
+	misses uint64
+}
+
+// newBundleCache for up to size Bundles.
+func newBundleCache(size int) *bundleCache {
+	return &bundleCache{
+		size:  size,
+		items: make(map[string]*list.Element),
+		order: list.New(),
+	}
+}
+
+// get a cached Bundle and mark it as recently used.
+func (bc *bundleCache) get(key string) (b bpv7.Bundle, ok bool) {
+	bc.mutex.Lock()
+	defer bc.mutex.Unlock()
+
+	elem, ok := bc.items[key]
+	if !ok {
+		bc.misses++
+		return
+	}
+
+	bc.hits++
+	bc.order.MoveToFront(elem)
+	return elem.Value.(*bundleCacheEntry).bndl, true
+}
+
+// put a Bundle into the cache, evicting the least recently used one if the cache is full.
+func (bc *bundleCache) put(key string, b bpv7.Bundle) {
+	bc.mutex.Lock()
+	defer bc.mutex.Unlock()
+
+	if elem, ok := bc.items[key]; ok {
+		elem.Value.(*bundleCacheEntry).bndl = b
+		bc.order.MoveToFront(elem)
+		return
+	}
+
+	bc.items[key] = bc.order.PushFront(&bundleCacheEntry{key: key, bndl: b})
+
+	if bc.order.Len() > bc.size {
+		oldest := bc.order.Back()
+		bc.order.Remove(oldest)
+		delete(bc.items, oldest.Value.(*bundleCacheEntry).key)
+	}
+}
+
+// remove a Bundle from the cache.
+func (bc *bundleCache) remove(key string) {
+	bc.mutex.Lock()
+	defer bc.mutex.Unlock()
+
+	if elem, ok := bc.items[key]; ok {
+		bc.order.Remove(elem)
+		delete(bc.items, key)
+	}
+}
+
+// stats returns the numbers of cache hits and misses.
+func (bc *bundleCache) stats() (hits, misses uint64) {
+	bc.mutex.Lock()
+	defer bc.mutex.Unlock()
+
+	return bc.hits, bc.misses
+}
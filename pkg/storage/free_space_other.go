@@ -0,0 +1,18 @@
+// SPDX-FileCopyrightText: 2026 agent
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+//go:build !linux && !darwin && !freebsd && !windows
+// +build !linux,!darwin,!freebsd,!windows
+
+package storage
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// freeSpace is not supported on this operating system.
+func freeSpace(_ string) (uint64, error) {
+	return 0, fmt.Errorf("free space is unsupported on %s", runtime.GOOS)
+}
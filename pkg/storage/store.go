@@ -8,7 +8,8 @@ package storage
 import (
 	"os"
 	"path"
-	"time"
+	"path/filepath"
+	"sync"
 
 	log "github.com/sirupsen/logrus"
 
@@ -28,6 +29,14 @@ type Store struct {
 
 	badgerDir string
 	bundleDir string
+
+	// batch commits inserts in batches, if enabled by SetWriteBatching.
+	batchMutex sync.RWMutex
+	batch      *batchWriter
+
+	// cache of recently loaded Bundles, if enabled by SetBundleCache.
+	cacheMutex sync.RWMutex
+	cache      *bundleCache
 }
 
 // NewStore creates a new Store or opens an existing Store from the given path.
@@ -65,9 +74,106 @@ func NewStore(dir string) (s *Store, err error) {
 
 // Close the Store. It must not be used afterwards.
 func (s *Store) Close() error {
+	s.SetWriteBatching(0)
 	return s.bh.Close()
 }
 
+// SetWriteBatching commits the BundleItems of concurrently pushed Bundles in batches of up to queueSize items, sharing
+// one disk sync of the database. This raises the rate of received Bundles, especially for multiple processing
+// workers. Each Push still blocks until its Bundle is durably stored, syncing the serialized Bundle before committing
+// its BundleItem. Zero disables batching after flushing the queue.
+func (s *Store) SetWriteBatching(queueSize int) {
+	s.batchMutex.Lock()
+	defer s.batchMutex.Unlock()
+
+	if s.batch != nil && cap(s.batch.queue) == queueSize {
+		return
+	} else if s.batch != nil {
+		s.batch.close()
+		s.batch = nil
+	}
+	if queueSize > 0 {
+		s.batch = newBatchWriter(s.bh, queueSize)
+	}
+}
+
+// SetBundleCache keeps up to size recently loaded Bundles in memory, so that a Bundle being processed multiple times,
+// e.g., delivered locally and forwarded to several peers, is not parsed from the disk each time. Zero disables the
+// cache. Changing the size drops all cached Bundles.
+func (s *Store) SetBundleCache(size int) {
+	s.cacheMutex.Lock()
+	defer s.cacheMutex.Unlock()
+
+	if s.cache != nil && s.cache.size == size {
+		return
+	} else if size > 0 {
+		s.cache = newBundleCache(size)
+	} else {
+		s.cache = nil
+	}
+}
+
+// BundleCacheStats returns the numbers of hits and misses of the cache configured by SetBundleCache.
+func (s *Store) BundleCacheStats() (hits, misses uint64) {
+	s.cacheMutex.RLock()
+	defer s.cacheMutex.RUnlock()
+
+	if s.cache == nil {
+		return 0, 0
+	}
+	return s.cache.stats()
+}
+
+// getCache returns the current bundleCache or nil, if disabled.
+func (s *Store) getCache() *bundleCache {
+	s.cacheMutex.RLock()
+	defer s.cacheMutex.RUnlock()
+
+	return s.cache
+}
+
+// LoadBundlePart loads a BundlePart's Bundle, either from the cache configured by SetBundleCache or from the disk.
+// The returned Bundle might be modified by the caller without affecting the cache.
+func (s *Store) LoadBundlePart(bp BundlePart) (bpv7.Bundle, error) {
+	cache := s.getCache()
+	if cache == nil {
+		return bp.Load()
+	}
+
+	if b, ok := cache.get(bp.Filename); ok {
+		return b.Copy()
+	}
+
+	b, err := bp.Load()
+	if err != nil {
+		return b, err
+	}
+
+	if bCached, err := b.Copy(); err == nil {
+		cache.put(bp.Filename, bCached)
+	}
+	return b, nil
+}
+
+// insert a new BundleItem, either directly or by the batchWriter.
+func (s *Store) insert(bi BundleItem) error {
+	s.batchMutex.RLock()
+	defer s.batchMutex.RUnlock()
+
+	if s.batch == nil {
+		return s.bh.Insert(bi.Id, bi)
+	}
+	return s.batch.insert(bi.Id, bi)
+}
+
+// isBatching checks if SetWriteBatching is enabled.
+func (s *Store) isBatching() bool {
+	s.batchMutex.RLock()
+	defer s.batchMutex.RUnlock()
+
+	return s.batch != nil
+}
+
 // Push a new/received Bundle to the Store.
 func (s *Store) Push(b bpv7.Bundle) error {
 	bi := newBundleItem(b, s.bundleDir)
@@ -77,11 +183,11 @@ func (s *Store) Push(b bpv7.Bundle) error {
 			"bundle": b.ID().String(),
 		}).Info("Bundle ID is unknown, inserting BundleItem")
 
-		if err := bi.Parts[0].storeBundle(b); err != nil {
+		if err := bi.Parts[0].storeBundle(b, s.isBatching()); err != nil {
 			return err
 		}
 
-		return s.bh.Insert(bi.Id, bi)
+		return s.insert(bi)
 	} else if bi.Fragmented {
 		if !biStore.Fragmented {
 			log.WithFields(log.Fields{
@@ -110,11 +216,12 @@ func (s *Store) Push(b bpv7.Bundle) error {
 				"bundle": b.ID().String(),
 			}).Info("Received new bundle fragment, updating BundleItem")
 
-			if err := compPart.storeBundle(b); err != nil {
+			if err := compPart.storeBundle(b, s.isBatching()); err != nil {
 				return err
 			}
 
 			biStore.Parts = append(biStore.Parts, compPart)
+			biStore.Size += bi.Size
 			return s.bh.Update(biStore.Id, biStore)
 		}
 	} else {
@@ -142,7 +249,12 @@ func (s *Store) Delete(bid bpv7.BundleID) error {
 			"bundle": bid,
 		}).Info("Store deletes BundleItem")
 
+		cache := s.getCache()
 		for _, bp := range bi.Parts {
+			if cache != nil {
+				cache.remove(bp.Filename)
+			}
+
 			if err := bp.deleteBundle(); err != nil {
 				log.WithFields(log.Fields{
 					"bundle": bid,
@@ -160,8 +272,8 @@ func (s *Store) Delete(bid bpv7.BundleID) error {
 
 // DeleteExpired removes all expired Bundles.
 func (s *Store) DeleteExpired() {
-	var bis []BundleItem
-	if err := s.bh.Find(&bis, badgerhold.Where("Expires").Lt(time.Now())); err != nil {
+	bis, err := s.QueryExpired()
+	if err != nil {
 		log.WithError(err).Warn("Failed to get expired Bundles")
 		return
 	}
@@ -188,6 +300,73 @@ func (s *Store) QueryPending() (bis []BundleItem, err error) {
 	return
 }
 
+// QueryExpired fetches all expired Bundles.
+func (s *Store) QueryExpired() (bis []BundleItem, err error) {
+	err = s.bh.Find(&bis, badgerhold.Where("Expires").Lt(bpv7.Now()))
+	return
+}
+
+// QueryAll fetches all stored Bundles.
+func (s *Store) QueryAll() (bis []BundleItem, err error) {
+	err = s.bh.Find(&bis, nil)
+	return
+}
+
+// QueryDestination fetches all Bundles whose destination starts with the given prefix.
+func (s *Store) QueryDestination(prefix string) (bis []BundleItem, err error) {
+	err = s.bh.Find(&bis, badgerhold.Where("Destination").HasPrefix(prefix))
+	return
+}
+
+// Count the stored Bundles.
+func (s *Store) Count() (int, error) {
+	bis, err := s.QueryAll()
+	return len(bis), err
+}
+
+// Size returns the Store's size on disk in bytes, consisting of the serialized Bundles and the database.
+func (s *Store) Size() (uint64, error) {
+	lsm, vlog := s.bh.Badger().Size()
+	size := uint64(lsm + vlog)
+
+	err := filepath.Walk(s.bundleDir, func(_ string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			size += uint64(info.Size())
+		}
+		return nil
+	})
+	return size, err
+}
+
+// FreeSpace returns the available space in bytes of the Store's file system.
+func (s *Store) FreeSpace() (uint64, error) {
+	return freeSpace(s.bundleDir)
+}
+
+// stateItem is an opaque state stored next to the Bundles, e.g., a routing algorithm's checkpoint.
+type stateItem struct {
+	Data []byte
+}
+
+// PutState stores an opaque state under a key, replacing a previous state.
+func (s *Store) PutState(key string, data []byte) error {
+	return s.bh.Upsert(key, stateItem{Data: data})
+}
+
+// GetState returns the state stored under a key by PutState. For an unknown key, nil is returned without an error.
+func (s *Store) GetState(key string) ([]byte, error) {
+	var si stateItem
+	if err := s.bh.Get(key, &si); err == badgerhold.ErrNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return si.Data, nil
+}
+
 // KnowsBundle checks if such a Bundle is known.
 func (s *Store) KnowsBundle(bid bpv7.BundleID) bool {
 	_, err := s.QueryId(bid)
@@ -6,13 +6,18 @@ package storage
 
 import (
 	"bytes"
+	"fmt"
 	"io/ioutil"
 	"math/rand"
 	"os"
 	"reflect"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	log "github.com/sirupsen/logrus"
+
 	"github.com/dtn7/dtn7-go/pkg/bpv7"
 )
 
@@ -72,6 +77,41 @@ func TestStoreBundleLife(t *testing.T) {
 			} else if !reflect.DeepEqual(b, b2) {
 				t.Fatalf("Bundle changed after loading")
 			}
+
+			if b2, err := bi.Load(); err != nil {
+				t.Fatal(err)
+			} else if !reflect.DeepEqual(b, b2) {
+				t.Fatalf("Bundle changed after loading the BundleItem")
+			}
+		}
+
+		if n, err := store.Count(); err != nil {
+			t.Fatal(err)
+		} else if n != 1 {
+			t.Fatalf("Counted %d BundleItems, instead of 1", n)
+		}
+
+		if size, err := store.Size(); err != nil {
+			t.Fatal(err)
+		} else if bndlSize, _ := b.SerializedSize(); size < bndlSize {
+			t.Fatalf("Store's size of %d bytes is smaller than the Bundle's %d bytes", size, bndlSize)
+		}
+
+		if free, err := store.FreeSpace(); err != nil {
+			t.Fatal(err)
+		} else if free == 0 {
+			t.Fatal("Store has no free space")
+		}
+
+		if bis, err := store.QueryDestination("dtn://dest/"); err != nil {
+			t.Fatal(err)
+		} else if bndlSize, _ := b.SerializedSize(); len(bis) != 1 || bis[0].Size != bndlSize {
+			t.Fatalf("Found %d BundleItems for the destination, instead of 1 with %d bytes", len(bis), bndlSize)
+		}
+		if bis, err := store.QueryDestination("dtn://other/"); err != nil {
+			t.Fatal(err)
+		} else if len(bis) != 0 {
+			t.Fatalf("Found %d BundleItems for another destination, instead of 0", len(bis))
 		}
 
 		if bip, err := store.QueryPending(); err != nil {
@@ -104,11 +144,23 @@ func TestStoreBundleLife(t *testing.T) {
 			}
 		}
 
+		if bis, err := store.QueryExpired(); err != nil {
+			t.Fatal(err)
+		} else if l := len(bis); l != 1 {
+			t.Fatalf("Found %d expired BundleItem, instead of 1", l)
+		}
+
 		store.DeleteExpired()
 
 		if bi, err := store.QueryId(b.ID()); err == nil {
 			t.Fatalf("Deleted expired BundleItem was found: %v", bi)
 		}
+
+		if n, err := store.Count(); err != nil {
+			t.Fatal(err)
+		} else if n != 0 {
+			t.Fatalf("Counted %d BundleItems, instead of 0", n)
+		}
 	})
 }
 
@@ -181,3 +233,264 @@ func TestStoreFragmented(t *testing.T) {
 		}
 	})
 }
+
+func TestStoreState(t *testing.T) {
+	testStore(t, func(store *Store) {
+		if data, err := store.GetState("routing/test"); err != nil || data != nil {
+			t.Fatalf("unknown state returned %v, %v", data, err)
+		}
+
+		for _, data := range [][]byte{[]byte("first"), []byte("second")} {
+			if err := store.PutState("routing/test", data); err != nil {
+				t.Fatal(err)
+			}
+			if stored, err := store.GetState("routing/test"); err != nil {
+				t.Fatal(err)
+			} else if !bytes.Equal(stored, data) {
+				t.Fatalf("expected state %q, got %q", data, stored)
+			}
+		}
+
+		if n, err := store.Count(); err != nil || n != 0 {
+			t.Fatalf("states are counted as %d bundles: %v", n, err)
+		}
+	})
+}
+
+func TestStoreWriteBatching(t *testing.T) {
+	testStore(t, func(store *Store) {
+		store.SetWriteBatching(8)
+
+		const pushes = 64
+		bids := make([]bpv7.BundleID, pushes)
+
+		var wg sync.WaitGroup
+		for i := 0; i < pushes; i++ {
+			b, err := bpv7.Builder().
+				Source("dtn://src/").
+				Destination("dtn://dest/").
+				CreationTimestampNow().
+				Lifetime("10m").
+				PayloadBlock([]byte("hello world")).
+				Build()
+			if err != nil {
+				t.Fatal(err)
+			}
+			b.PrimaryBlock.CreationTimestamp[1] = uint64(i)
+			bids[i] = b.ID()
+
+			wg.Add(1)
+			go func(b bpv7.Bundle) {
+				defer wg.Done()
+				if err := store.Push(b); err != nil {
+					t.Error(err)
+				}
+			}(b)
+		}
+		wg.Wait()
+
+		// Returned Pushes must be visible, also after disabling batching.
+		store.SetWriteBatching(0)
+
+		if n, err := store.Count(); err != nil || n != pushes {
+			t.Fatalf("expected %d bundles, got %d: %v", pushes, n, err)
+		}
+		for _, bid := range bids {
+			if bi, err := store.QueryId(bid); err != nil {
+				t.Fatal(err)
+			} else if _, err := bi.Load(); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		// Closing the Store closes an enabled batchWriter.
+		store.SetWriteBatching(4)
+	})
+}
+
+func TestStoreBundleCache(t *testing.T) {
+	testStore(t, func(store *Store) {
+		store.SetBundleCache(2)
+
+		bis := make([]BundleItem, 3)
+		for i := range bis {
+			b, err := bpv7.Builder().
+				Source("dtn://src/").
+				Destination("dtn://dest/").
+				CreationTimestampNow().
+				Lifetime("10m").
+				HopCountBlock(64).
+				PayloadBlock([]byte("hello world")).
+				Build()
+			if err != nil {
+				t.Fatal(err)
+			}
+			b.PrimaryBlock.CreationTimestamp[1] = uint64(i)
+
+			if err := store.Push(b); err != nil {
+				t.Fatal(err)
+			} else if bis[i], err = store.QueryId(b.ID()); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		load := func(i int) bpv7.Bundle {
+			b, err := store.LoadBundlePart(bis[i].Parts[0])
+			if err != nil {
+				t.Fatal(err)
+			}
+			return b
+		}
+
+		// Modifying a loaded Bundle must not affect the cached one.
+		b := load(0)
+		hcBlock, _ := b.ExtensionBlock(bpv7.ExtBlockTypeHopCountBlock)
+		hcBlock.Value.(*bpv7.HopCountBlock).Increment()
+
+		b = load(0)
+		if hcBlock, _ := b.ExtensionBlock(bpv7.ExtBlockTypeHopCountBlock); hcBlock.Value.(*bpv7.HopCountBlock).Count != 0 {
+			t.Fatal("cached bundle was modified")
+		}
+		if hits, misses := store.BundleCacheStats(); hits != 1 || misses != 1 {
+			t.Fatalf("expected one hit and one miss, got %d and %d", hits, misses)
+		}
+
+		// Bundle 0 is the least recently used one and gets evicted.
+		load(1)
+		load(2)
+		load(0)
+		if hits, misses := store.BundleCacheStats(); hits != 1 || misses != 4 {
+			t.Fatalf("expected one hit and four misses, got %d and %d", hits, misses)
+		}
+
+		// Deleted Bundles are removed from the cache.
+		if err := store.Delete(bis[0].BId); err != nil {
+			t.Fatal(err)
+		} else if _, err := store.LoadBundlePart(bis[0].Parts[0]); err == nil {
+			t.Fatal("deleted bundle was loaded")
+		}
+
+		store.SetBundleCache(0)
+		if hits, misses := store.BundleCacheStats(); hits != 0 || misses != 0 {
+			t.Fatalf("disabled cache reports %d hits and %d misses", hits, misses)
+		}
+	})
+}
+
+// benchmarkStore creates a Store, which is closed after the benchmark, and silences the per-bundle logging.
+func benchmarkStore(b *testing.B) *Store {
+	level := log.GetLevel()
+	log.SetLevel(log.WarnLevel)
+
+	store, err := NewStore(b.TempDir())
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.Cleanup(func() {
+		if err := store.Close(); err != nil {
+			b.Fatal(err)
+		}
+		log.SetLevel(level)
+	})
+	return store
+}
+
+// benchmarkBundle with a unique sequence number and a payload of the given size.
+func benchmarkBundle(b *testing.B, seq uint64, size int) bpv7.Bundle {
+	bndl, err := bpv7.Builder().
+		Source("dtn://src/").
+		Destination("dtn://dest/").
+		CreationTimestampNow().
+		Lifetime("60m").
+		PayloadBlock(make([]byte, size)).
+		Build()
+	if err != nil {
+		b.Fatal(err)
+	}
+	bndl.PrimaryBlock.CreationTimestamp[1] = seq
+	return bndl
+}
+
+func BenchmarkStorePush(b *testing.B) {
+	for _, batching := range []int{0, 64} {
+		b.Run(fmt.Sprintf("batching-%d", batching), func(b *testing.B) {
+			store := benchmarkStore(b)
+			store.SetWriteBatching(batching)
+
+			var seq uint64
+			b.SetBytes(1024)
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					if err := store.Push(benchmarkBundle(b, atomic.AddUint64(&seq, 1), 1024)); err != nil {
+						b.Error(err)
+						return
+					}
+				}
+			})
+		})
+	}
+}
+
+func BenchmarkStoreQueryPending(b *testing.B) {
+	for _, bundles := range []int{100, 1000} {
+		b.Run(fmt.Sprintf("%d", bundles), func(b *testing.B) {
+			store := benchmarkStore(b)
+
+			// Every second bundle is pending.
+			for i := 0; i < bundles; i++ {
+				bndl := benchmarkBundle(b, uint64(i), 64)
+				if err := store.Push(bndl); err != nil {
+					b.Fatal(err)
+				}
+				if i%2 == 0 {
+					continue
+				}
+
+				bi, err := store.QueryId(bndl.ID())
+				if err != nil {
+					b.Fatal(err)
+				}
+				bi.Pending = true
+				if err := store.Update(bi); err != nil {
+					b.Fatal(err)
+				}
+			}
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if bis, err := store.QueryPending(); err != nil {
+					b.Fatal(err)
+				} else if len(bis) != bundles/2 {
+					b.Fatalf("expected %d pending bundles, got %d", bundles/2, len(bis))
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkStoreLoadBundlePart(b *testing.B) {
+	for _, cache := range []int{0, 16} {
+		b.Run(fmt.Sprintf("cache-%d", cache), func(b *testing.B) {
+			store := benchmarkStore(b)
+			store.SetBundleCache(cache)
+
+			bndl := benchmarkBundle(b, 0, 1024)
+			if err := store.Push(bndl); err != nil {
+				b.Fatal(err)
+			}
+			bi, err := store.QueryId(bndl.ID())
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			b.SetBytes(1024)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := store.LoadBundlePart(bi.Parts[0]); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}